@@ -0,0 +1,107 @@
+package gateway
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	apiKeyHeader        = "X-API-Key"
+	bearerPrefix        = "Bearer "
+)
+
+// protectedEndpoints are the endpoints that require a token whenever auth is enabled. Any other
+// endpoint is read-only and stays public unless AuthConfig.ProtectReads is set.
+var protectedEndpoints = map[string]bool{
+	submitTxEndpoint:     true,
+	submitPFBEndpoint:    true,
+	submitRawPFBEndpoint: true,
+}
+
+var errUnauthorized = errors.New("missing or invalid API token")
+
+// AuthConfig configures token authentication of the gateway. Auth is enabled when any token is
+// set.
+type AuthConfig struct {
+	// Tokens are the API keys accepted by the gateway. Clients pass one either as a bearer token
+	// in the Authorization header or in the X-API-Key header.
+	Tokens []string
+	// ProtectReads requires a token for the read-only endpoints as well.
+	ProtectReads bool
+}
+
+// Enabled reports whether token authentication is configured.
+func (cfg *AuthConfig) Enabled() bool {
+	return len(cfg.Tokens) != 0
+}
+
+// Validate checks that no token is empty and read protection is not requested without tokens.
+func (cfg *AuthConfig) Validate() error {
+	if !cfg.Enabled() && cfg.ProtectReads {
+		return errors.New("service/gateway: protecting read endpoints requires auth tokens")
+	}
+	for _, token := range cfg.Tokens {
+		if strings.TrimSpace(token) == "" {
+			return errors.New("service/gateway: auth tokens must not be empty")
+		}
+	}
+	return nil
+}
+
+// authenticate rejects requests to protected endpoints that do not carry a valid token.
+func authenticate(cfg AuthConfig) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !cfg.ProtectReads && !isProtected(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !cfg.authorized(requestToken(r)) {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				writeError(w, http.StatusUnauthorized, r.URL.Path, errUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// isProtected reports whether the route matched by the request is a protected endpoint.
+func isProtected(r *http.Request) bool {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return false
+	}
+	tmpl, err := route.GetPathTemplate()
+	if err != nil {
+		return false
+	}
+	return protectedEndpoints[tmpl]
+}
+
+// requestToken extracts the token from the Authorization or the X-API-Key header.
+func requestToken(r *http.Request) string {
+	if auth := r.Header.Get(authorizationHeader); strings.HasPrefix(auth, bearerPrefix) {
+		return strings.TrimSpace(strings.TrimPrefix(auth, bearerPrefix))
+	}
+	return r.Header.Get(apiKeyHeader)
+}
+
+// authorized compares the token against all the configured tokens in constant time.
+func (cfg *AuthConfig) authorized(token string) bool {
+	if token == "" {
+		return false
+	}
+	var ok bool
+	for _, valid := range cfg.Tokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(valid)) == 1 {
+			ok = true
+		}
+	}
+	return ok
+}
@@ -2,6 +2,7 @@ package gateway
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -12,6 +13,12 @@ import (
 
 const heightAvailabilityEndpoint = "/data_available"
 
+var availabilitySpecs = map[specKey]endpointSpec{
+	{http.MethodGet, fmt.Sprintf("%s/{%s}", heightAvailabilityEndpoint, heightKey)}: {
+		summary: "Samples the data at the given height and reports whether it is available",
+	},
+}
+
 // AvailabilityResponse represents the response to a
 // `/data_available` request.
 type AvailabilityResponse struct {
@@ -1,14 +1,39 @@
 package gateway
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"net"
+	"os"
 	"strconv"
+	"strings"
 )
 
+// UnixSocketPrefix is the prefix of listen addresses of Unix domain sockets, e.g.
+// unix:///run/celestia/gateway.sock. The port is ignored for such addresses.
+const UnixSocketPrefix = "unix://"
+
 type Config struct {
-	Address string
-	Port    string
+	Address   string
+	Port      string
+	TLS       TLSConfig
+	Auth      AuthConfig
+	CORS      CORSConfig
+	RateLimit RateLimitConfig
+}
+
+// TLSConfig configures the gateway to serve HTTPS. TLS is enabled when both the certificate and
+// the key are set.
+type TLSConfig struct {
+	// CertPath is the path to the PEM encoded certificate (chain) of the server.
+	CertPath string
+	// KeyPath is the path to the PEM encoded private key of the certificate.
+	KeyPath string
+	// ClientCAPath is the path to the PEM encoded CA certificates that client certificates are
+	// verified against. Clients are required to present a certificate (mTLS) when set.
+	ClientCAPath string
 }
 
 func DefaultConfig() Config {
@@ -16,16 +41,115 @@ func DefaultConfig() Config {
 		Address: "0.0.0.0",
 		// do NOT expose the same port as celestia-core by default so that both can run on the same machine
 		Port: "26658",
+		CORS: DefaultCORSConfig(),
 	}
 }
 
 func (cfg *Config) Validate() error {
-	if ip := net.ParseIP(cfg.Address); ip == nil {
-		return fmt.Errorf("service/gateway: invalid listen address format: %s", cfg.Address)
+	if path, ok := UnixSocketPath(cfg.Address); ok {
+		if path == "" {
+			return fmt.Errorf("service/gateway: empty Unix socket path: %s", cfg.Address)
+		}
+	} else {
+		if err := ValidateHost(cfg.Address); err != nil {
+			return err
+		}
+		_, err := strconv.Atoi(cfg.Port)
+		if err != nil {
+			return fmt.Errorf("service/gateway: invalid port: %s", err.Error())
+		}
 	}
-	_, err := strconv.Atoi(cfg.Port)
-	if err != nil {
-		return fmt.Errorf("service/gateway: invalid port: %s", err.Error())
+	if err := cfg.TLS.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.Auth.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.CORS.Validate(); err != nil {
+		return err
+	}
+	return cfg.RateLimit.Validate()
+}
+
+// UnixSocketPath returns the path of the Unix domain socket, if the given listen address is one.
+func UnixSocketPath(addr string) (string, bool) {
+	if !strings.HasPrefix(addr, UnixSocketPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(addr, UnixSocketPrefix), true
+}
+
+// ValidateHost checks that the given listen address is an IP or a well-formed hostname. Hostnames
+// are not resolved here, but only once the Server starts listening.
+func ValidateHost(host string) error {
+	if net.ParseIP(host) != nil {
+		return nil
+	}
+	if host == "" || len(host) > 253 {
+		return fmt.Errorf("service/gateway: invalid listen address format: %s", host)
+	}
+	for _, label := range strings.Split(strings.TrimSuffix(host, "."), ".") {
+		if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return fmt.Errorf("service/gateway: invalid listen address format: %s", host)
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return fmt.Errorf("service/gateway: invalid listen address format: %s", host)
+			}
+		}
 	}
 	return nil
 }
+
+// Enabled reports whether TLS is configured.
+func (cfg *TLSConfig) Enabled() bool {
+	return cfg.CertPath != "" || cfg.KeyPath != ""
+}
+
+// Validate checks that the certificate and the key are set together and all the files exist.
+func (cfg *TLSConfig) Validate() error {
+	if !cfg.Enabled() {
+		if cfg.ClientCAPath != "" {
+			return errors.New("service/gateway: TLS client CA requires a certificate and a key")
+		}
+		return nil
+	}
+	if cfg.CertPath == "" || cfg.KeyPath == "" {
+		return errors.New("service/gateway: TLS requires both a certificate and a key")
+	}
+	for _, path := range []string{cfg.CertPath, cfg.KeyPath, cfg.ClientCAPath} {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("service/gateway: invalid TLS file: %w", err)
+		}
+	}
+	return nil
+}
+
+// tlsConfig loads the certificate and the client CA from the configured files.
+func (cfg *TLSConfig) tlsConfig() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(cfg.CertPath, cfg.KeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("service/gateway: loading TLS certificate: %w", err)
+	}
+	tlsCfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if cfg.ClientCAPath != "" {
+		pem, err := os.ReadFile(cfg.ClientCAPath)
+		if err != nil {
+			return nil, fmt.Errorf("service/gateway: reading TLS client CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("service/gateway: no certificates found in TLS client CA %s", cfg.ClientCAPath)
+		}
+		tlsCfg.ClientCAs = pool
+		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsCfg, nil
+}
@@ -0,0 +1,100 @@
+package gateway
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	defaultCORSMethods = []string{http.MethodGet, http.MethodPost}
+	defaultCORSHeaders = []string{"Content-Type", authorizationHeader, apiKeyHeader}
+)
+
+// CORSConfig configures which browser origins are allowed to call the gateway. Empty fields fall
+// back to the defaults, so any origin is allowed unless restricted.
+type CORSConfig struct {
+	// AllowedOrigins are the origins allowed to make requests, e.g. "https://explorer.example".
+	// "*" allows any origin.
+	AllowedOrigins []string
+	// AllowedMethods are the HTTP methods allowed in cross-origin requests.
+	AllowedMethods []string
+	// AllowedHeaders are the request headers allowed in cross-origin requests.
+	AllowedHeaders []string
+	// MaxAge is how long browsers may cache the result of a preflight request. Not sent when zero.
+	MaxAge time.Duration
+}
+
+func DefaultCORSConfig() CORSConfig {
+	return CORSConfig{
+		AllowedOrigins: []string{"*"},
+		AllowedMethods: defaultCORSMethods,
+		AllowedHeaders: defaultCORSHeaders,
+	}
+}
+
+// Validate checks that the max age is not negative.
+func (cfg *CORSConfig) Validate() error {
+	if cfg.MaxAge < 0 {
+		return errors.New("service/gateway: CORS max age must not be negative")
+	}
+	return nil
+}
+
+// allowOrigin returns the value of the Access-Control-Allow-Origin header for the given origin,
+// or an empty string if the origin is not allowed.
+func (cfg *CORSConfig) allowOrigin(origin string) string {
+	if len(cfg.AllowedOrigins) == 0 {
+		return "*"
+	}
+	for _, allowed := range cfg.AllowedOrigins {
+		switch {
+		case allowed == "*":
+			return "*"
+		case origin != "" && strings.EqualFold(allowed, origin):
+			return origin
+		}
+	}
+	return ""
+}
+
+// serveCORS sets the CORS headers on the response and answers preflight requests. It reports
+// whether the request was served.
+func (cfg *CORSConfig) serveCORS(w http.ResponseWriter, r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	allowed := cfg.allowOrigin(origin)
+	if allowed != "*" {
+		w.Header().Add("Vary", "Origin")
+	}
+	if allowed == "" {
+		return false
+	}
+	w.Header().Set("Access-Control-Allow-Origin", allowed)
+
+	if r.Method != http.MethodOptions || r.Header.Get("Access-Control-Request-Method") == "" {
+		return false
+	}
+	methods, headers := cfg.AllowedMethods, cfg.AllowedHeaders
+	if len(methods) == 0 {
+		methods = defaultCORSMethods
+	}
+	if len(headers) == 0 {
+		headers = defaultCORSHeaders
+	}
+	w.Header().Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+	w.Header().Set("Access-Control-Allow-Headers", strings.Join(headers, ", "))
+	if cfg.MaxAge > 0 {
+		w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(cfg.MaxAge.Seconds())))
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return true
+}
+
+// checkOrigin reports whether a WebSocket connection from the origin of the request is allowed by
+// the CORS policy. Requests without an origin do not come from browsers and are always allowed.
+func (s *Server) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	return origin == "" || s.cors.allowOrigin(origin) != ""
+}
@@ -9,6 +9,13 @@ const (
 	dasStateEndpoint = "/daser/state"
 )
 
+var dasSpecs = map[specKey]endpointSpec{
+	{http.MethodGet, dasStateEndpoint}: {
+		summary:    "Returns the sampling progress of the DASer",
+		deprecated: true,
+	},
+}
+
 func (h *Handler) handleDASStateRequest(w http.ResponseWriter, r *http.Request) {
 	logDeprecation(dasStateEndpoint, "das.SamplingStats")
 	stats, err := h.das.SamplingStats(r.Context())
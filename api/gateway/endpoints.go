@@ -9,16 +9,19 @@ func (h *Handler) RegisterEndpoints(rpc *Server, deprecatedEndpointsEnabled bool
 	if deprecatedEndpointsEnabled {
 		log.Warn("Deprecated endpoints will be removed from the gateway in the next release. Use the RPC instead.")
 		// state endpoints
-		rpc.RegisterHandlerFunc(balanceEndpoint, h.handleBalanceRequest, http.MethodGet)
-		rpc.RegisterHandlerFunc(submitPFBEndpoint, h.handleSubmitPFB, http.MethodPost)
+		// only register if state service is available
+		if h.state != nil {
+			rpc.RegisterHandlerFunc(balanceEndpoint, h.handleBalanceRequest, http.MethodGet)
+			rpc.RegisterHandlerFunc(submitPFBEndpoint, h.handleSubmitPFB, http.MethodPost)
 
-		// staking queries
-		rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", queryDelegationEndpoint, addrKey), h.handleQueryDelegation,
-			http.MethodGet)
-		rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", queryUnbondingEndpoint, addrKey), h.handleQueryUnbonding,
-			http.MethodGet)
-		rpc.RegisterHandlerFunc(queryRedelegationsEndpoint, h.handleQueryRedelegations,
-			http.MethodPost)
+			// staking queries
+			rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", queryDelegationEndpoint, addrKey), h.handleQueryDelegation,
+				http.MethodGet)
+			rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", queryUnbondingEndpoint, addrKey), h.handleQueryUnbonding,
+				http.MethodGet)
+			rpc.RegisterHandlerFunc(queryRedelegationsEndpoint, h.handleQueryRedelegations,
+				http.MethodPost)
+		}
 
 		// DASer endpoints
 		// only register if DASer service is available
@@ -28,9 +31,15 @@ func (h *Handler) RegisterEndpoints(rpc *Server, deprecatedEndpointsEnabled bool
 	}
 
 	// state endpoints
-	rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", balanceEndpoint, addrKey), h.handleBalanceRequest,
-		http.MethodGet)
-	rpc.RegisterHandlerFunc(submitTxEndpoint, h.handleSubmitTx, http.MethodPost)
+	// only register if state service is available
+	if h.state != nil {
+		rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", balanceEndpoint, addrKey), h.handleBalanceRequest,
+			http.MethodGet)
+		rpc.RegisterHandlerFunc(submitTxEndpoint, h.handleSubmitTx, http.MethodPost)
+		rpc.RegisterHandlerFunc(submitRawPFBEndpoint, h.handleSubmitRawPFB, http.MethodPost)
+		rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", txStatusEndpoint, txHashKey), h.handleTxStatus,
+			http.MethodGet)
+	}
 
 	// share endpoints
 	rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}/height/{%s}", namespacedSharesEndpoint, namespaceKey, heightKey),
@@ -49,5 +58,20 @@ func (h *Handler) RegisterEndpoints(rpc *Server, deprecatedEndpointsEnabled bool
 	// header endpoints
 	rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", headerByHeightEndpoint, heightKey), h.handleHeaderRequest,
 		http.MethodGet)
+	rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}%s", headerByHeightEndpoint, heightKey, confidenceEndpoint),
+		h.handleConfidenceRequest, http.MethodGet)
 	rpc.RegisterHandlerFunc(headEndpoint, h.handleHeadRequest, http.MethodGet)
+
+	// explorer endpoints
+	rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", blockSummaryEndpoint, heightKey), h.handleBlockSummaryRequest,
+		http.MethodGet)
+	rpc.RegisterHandlerFunc(blockSummaryEndpoint, h.handleBlockSummaryRequest, http.MethodGet)
+
+	// subscription endpoints
+	h.upgrader.CheckOrigin = rpc.checkOrigin
+	rpc.RegisterHandlerFunc(subscribeHeadersEndpoint, h.handleSubscribeHeaders, http.MethodGet)
+	rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", subscribeSharesEndpoint, namespaceKey),
+		h.handleSubscribeShares, http.MethodGet)
+
+	rpc.RegisterHandlerFunc(openAPIEndpoint, rpc.handleOpenAPI, http.MethodGet)
 }
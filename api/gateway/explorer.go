@@ -0,0 +1,147 @@
+package gateway
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/celestiaorg/celestia-app/pkg/shares"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+const blockSummaryEndpoint = "/block_summary"
+
+var explorerSpecs = map[specKey]endpointSpec{
+	{http.MethodGet, fmt.Sprintf("%s/{%s}", blockSummaryEndpoint, heightKey)}: {
+		summary: "Summarizes the data square at the given height",
+	},
+	{http.MethodGet, blockSummaryEndpoint}: {
+		summary: "Summarizes the data square at the latest height",
+	},
+}
+
+// BlockSummaryResponse represents the response to a BlockSummary request. It summarizes the
+// contents of the data square at the given height.
+type BlockSummaryResponse struct {
+	Height     uint64             `json:"height"`
+	Hash       string             `json:"hash"`
+	Time       time.Time          `json:"time"`
+	DataRoot   string             `json:"data_root"`
+	SquareSize int                `json:"square_size"`
+	Blobs      int                `json:"blobs"`
+	BlobsSize  int                `json:"blobs_size"`
+	Namespaces []NamespaceSummary `json:"namespaces"`
+}
+
+// NamespaceSummary describes the data of a single namespace present in the data square.
+type NamespaceSummary struct {
+	Namespace string `json:"namespace"`
+	// Reserved indicates the namespace is reserved by the protocol, e.g. for transactions.
+	Reserved bool `json:"reserved"`
+	// Blobs is the amount of share sequences in the namespace, i.e. blobs for user namespaces
+	// and one sequence of transactions for reserved namespaces.
+	Blobs int `json:"blobs"`
+	// Shares is the amount of shares occupied by the namespace in the original data square.
+	Shares int `json:"shares"`
+	// Size is the amount of bytes of raw data in the namespace.
+	Size int `json:"size"`
+}
+
+func (h *Handler) handleBlockSummaryRequest(w http.ResponseWriter, r *http.Request) {
+	var (
+		eh  *header.ExtendedHeader
+		err error
+	)
+	// if a height was given, summarize it, otherwise summarize the latest header
+	if strHeight, ok := mux.Vars(r)[heightKey]; ok {
+		height, perr := strconv.ParseUint(strHeight, 10, 64)
+		if perr != nil {
+			writeError(w, http.StatusBadRequest, blockSummaryEndpoint, perr)
+			return
+		}
+		eh, err = h.header.GetByHeight(r.Context(), height)
+	} else {
+		eh, err = h.header.LocalHead(r.Context())
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, blockSummaryEndpoint, err)
+		return
+	}
+
+	eds, err := h.share.GetEDS(r.Context(), eh.DAH)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, blockSummaryEndpoint, err)
+		return
+	}
+	namespaces, err := summarizeNamespaces(share.ExtractODS(eds))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, blockSummaryEndpoint, err)
+		return
+	}
+
+	summary := &BlockSummaryResponse{
+		Height:     uint64(eh.Height()),
+		Hash:       eh.Hash().String(),
+		Time:       eh.Time(),
+		DataRoot:   share.DataHash(eh.DataHash).String(),
+		SquareSize: int(eds.Width() / 2),
+		Namespaces: namespaces,
+	}
+	for _, ns := range namespaces {
+		if !ns.Reserved {
+			summary.Blobs += ns.Blobs
+			summary.BlobsSize += ns.Size
+		}
+	}
+
+	resp, err := json.Marshal(summary)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, blockSummaryEndpoint, err)
+		return
+	}
+	_, err = w.Write(resp)
+	if err != nil {
+		log.Errorw("serving request", "endpoint", blockSummaryEndpoint, "err", err)
+	}
+}
+
+// summarizeNamespaces groups the given original data square shares by namespace, skipping
+// padding. Namespaces are returned in the order they appear in the square.
+func summarizeNamespaces(ods []share.Share) ([]NamespaceSummary, error) {
+	appShares, err := shares.FromBytes(ods)
+	if err != nil {
+		return nil, err
+	}
+	sequences, err := shares.ParseShares(appShares, true)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]NamespaceSummary, 0)
+	for _, sequence := range sequences {
+		raw, err := sequence.RawData()
+		if err != nil {
+			return nil, err
+		}
+
+		ns := hex.EncodeToString(sequence.Namespace.Bytes())
+		if len(summaries) == 0 || summaries[len(summaries)-1].Namespace != ns {
+			summaries = append(summaries, NamespaceSummary{
+				Namespace: ns,
+				Reserved:  sequence.Namespace.IsReserved(),
+			})
+		}
+		summary := &summaries[len(summaries)-1]
+		summary.Blobs++
+		summary.Shares += len(sequence.Shares)
+		summary.Size += len(raw)
+	}
+	return summaries, nil
+}
@@ -0,0 +1,57 @@
+package gateway
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	coretypes "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/pkg/shares"
+
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/sharetest"
+)
+
+func Test_summarizeNamespaces(t *testing.T) {
+	nsA, nsB := sharetest.RandV0Namespace(), sharetest.RandV0Namespace()
+	if nsB.IsLess(nsA) {
+		nsA, nsB = nsB, nsA
+	}
+	blobs := []struct {
+		ns   share.Namespace
+		data []byte
+	}{
+		{ns: nsA, data: []byte("beep")},
+		{ns: nsA, data: make([]byte, appconsts.ShareSize*2)},
+		{ns: nsB, data: []byte("BEEEEAHP")},
+	}
+
+	sss := shares.NewSparseShareSplitter()
+	for _, blob := range blobs {
+		err := sss.Write(coretypes.Blob{
+			Data:             blob.data,
+			NamespaceID:      blob.ns.ID(),
+			NamespaceVersion: blob.ns.Version(),
+			ShareVersion:     appconsts.ShareVersionZero,
+		})
+		require.NoError(t, err)
+	}
+	ods := append(sss.Export(), shares.TailPaddingShares(4)...)
+
+	summaries, err := summarizeNamespaces(shares.ToBytes(ods))
+	require.NoError(t, err)
+	require.Len(t, summaries, 2)
+
+	require.Equal(t, hex.EncodeToString(nsA), summaries[0].Namespace)
+	require.False(t, summaries[0].Reserved)
+	require.Equal(t, 2, summaries[0].Blobs)
+	require.Equal(t, 4+appconsts.ShareSize*2, summaries[0].Size)
+	require.Equal(t, 4, summaries[0].Shares)
+
+	require.Equal(t, hex.EncodeToString(nsB), summaries[1].Namespace)
+	require.Equal(t, 1, summaries[1].Blobs)
+	require.Equal(t, 8, summaries[1].Size)
+	require.Equal(t, 1, summaries[1].Shares)
+}
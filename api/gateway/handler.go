@@ -1,6 +1,7 @@
 package gateway
 
 import (
+	"github.com/gorilla/websocket"
 	logging "github.com/ipfs/go-log/v2"
 
 	"github.com/celestiaorg/celestia-node/das"
@@ -16,6 +17,8 @@ type Handler struct {
 	share  share.Module
 	header header.Module
 	das    *das.DASer
+
+	upgrader websocket.Upgrader
 }
 
 func NewHandler(
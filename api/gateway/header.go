@@ -2,6 +2,7 @@ package gateway
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -13,12 +14,23 @@ import (
 const (
 	headEndpoint           = "/head"
 	headerByHeightEndpoint = "/header"
+	confidenceEndpoint     = "/confidence"
 )
 
 var (
 	heightKey = "height"
 )
 
+var headerSpecs = map[specKey]endpointSpec{
+	{http.MethodGet, headEndpoint}: {summary: "Returns the latest header the node has synced"},
+	{http.MethodGet, fmt.Sprintf("%s/{%s}", headerByHeightEndpoint, heightKey)}: {
+		summary: "Returns the header at the given height",
+	},
+	{http.MethodGet, fmt.Sprintf("%s/{%s}%s", headerByHeightEndpoint, heightKey, confidenceEndpoint)}: {
+		summary: "Returns whether the data at the given height is synced, sampled and free of fraud proofs",
+	},
+}
+
 func (h *Handler) handleHeadRequest(w http.ResponseWriter, r *http.Request) {
 	head, err := h.header.LocalHead(r.Context())
 	if err != nil {
@@ -56,6 +68,29 @@ func (h *Handler) handleHeaderRequest(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (h *Handler) handleConfidenceRequest(w http.ResponseWriter, r *http.Request) {
+	height, err := strconv.ParseUint(mux.Vars(r)[heightKey], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, confidenceEndpoint, err)
+		return
+	}
+	confidence, err := h.header.Confidence(r.Context(), height)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, confidenceEndpoint, err)
+		return
+	}
+	resp, err := json.Marshal(confidence)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, confidenceEndpoint, err)
+		return
+	}
+	_, err = w.Write(resp)
+	if err != nil {
+		log.Errorw("writing response", "endpoint", confidenceEndpoint, "err", err)
+		return
+	}
+}
+
 func (h *Handler) performGetHeaderRequest(
 	w http.ResponseWriter,
 	r *http.Request,
@@ -0,0 +1,43 @@
+package gateway
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	modheader "github.com/celestiaorg/celestia-node/nodebuilder/header"
+	headerMock "github.com/celestiaorg/celestia-node/nodebuilder/header/mocks"
+)
+
+func TestHandleConfidenceRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mock := headerMock.NewMockModule(ctrl)
+	handler := NewHandler(nil, nil, mock, nil)
+
+	request := func(height string) *httptest.ResponseRecorder {
+		req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/", nil), map[string]string{heightKey: height})
+		rec := httptest.NewRecorder()
+		handler.handleConfidenceRequest(rec, req)
+		return rec
+	}
+
+	mock.EXPECT().Confidence(gomock.Any(), uint64(5)).
+		Return(&modheader.Confidence{Height: 5, Synced: true, Safe: true}, nil)
+	rec := request("5")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var confidence modheader.Confidence
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&confidence))
+	assert.EqualValues(t, 5, confidence.Height)
+	assert.True(t, confidence.Safe)
+
+	// heights that are not unsigned integers are rejected without asking the node
+	for _, height := range []string{"-1", "abc", "18446744073709551616"} {
+		assert.Equal(t, http.StatusBadRequest, request(height).Code, height)
+	}
+}
@@ -0,0 +1,264 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/das"
+	"github.com/celestiaorg/celestia-node/nodebuilder/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+)
+
+const (
+	livenessEndpoint  = "/livez"
+	readinessEndpoint = "/readyz"
+)
+
+var healthSpecs = map[specKey]endpointSpec{
+	{http.MethodGet, livenessEndpoint}: {
+		summary: "Reports whether the node is alive",
+	},
+	{http.MethodGet, readinessEndpoint}: {
+		summary: "Reports whether the node passes the configured readiness checks, e.g. synced and caught up with sampling",
+	},
+}
+
+// LivenessResponse represents the response to a `/livez` request.
+type LivenessResponse struct {
+	Alive bool `json:"alive"`
+}
+
+// ReadinessResponse represents the response to a `/readyz` request. The node is ready once all of
+// the checks pass.
+type ReadinessResponse struct {
+	Ready  bool                   `json:"ready"`
+	Checks map[string]HealthCheck `json:"checks"`
+}
+
+// HealthCheck is the result of checking whether a part of the node is ready.
+type HealthCheck struct {
+	Ready  bool   `json:"ready"`
+	Detail string `json:"detail"`
+}
+
+// Names of the checks the readiness of the node is made of.
+const (
+	CheckP2P        = "p2p"
+	CheckHeaderSync = "header_sync"
+	CheckDAS        = "das"
+	CheckCore       = "core"
+)
+
+// readinessPollInterval is the interval between readiness checks while waiting for the node to be
+// ready.
+var readinessPollInterval = time.Second
+
+// ReadinessConfig selects what the node must satisfy to be ready.
+type ReadinessConfig struct {
+	// Checks are the names of the checks required to pass, out of "p2p", "header_sync", "das" and
+	// "core". Empty requires every check available in the node type, i.e. "das" on light and full
+	// nodes and "core" on bridge nodes.
+	Checks []string
+	// MaxSyncLag is the amount of heights the header sync and sampling may lag behind the network
+	// head while the node is still ready. Zero requires them to be caught up.
+	MaxSyncLag uint64
+}
+
+// Validate checks that the checks are known.
+func (cfg *ReadinessConfig) Validate() error {
+	for _, check := range cfg.Checks {
+		switch check {
+		case CheckP2P, CheckHeaderSync, CheckDAS, CheckCore:
+		default:
+			return fmt.Errorf("service/gateway: unknown readiness check %q, must be one of %s, %s, %s, %s",
+				check, CheckP2P, CheckHeaderSync, CheckDAS, CheckCore)
+		}
+	}
+	return nil
+}
+
+// CoreStatus reports the status of the connection to the Core node, e.g. core.BlockFetcher.
+type CoreStatus interface {
+	// IsSyncing reports whether the Core node is still catching up with the network.
+	IsSyncing(ctx context.Context) (bool, error)
+}
+
+// HealthHandler serves the liveness and readiness probes of the node, e.g. for Kubernetes.
+type HealthHandler struct {
+	cfg    ReadinessConfig
+	p2p    p2p.Module
+	header header.Module
+	das    das.Module
+	core   CoreStatus
+}
+
+// NewHealthHandler constructs a HealthHandler. The DAS module and the Core status are optional,
+// as bridge nodes do not sample and only bridge nodes are connected to Core. The checks
+// configured but unavailable in the node are skipped.
+func NewHealthHandler(
+	cfg ReadinessConfig,
+	p2p p2p.Module,
+	header header.Module,
+	das das.Module,
+	core CoreStatus,
+) *HealthHandler {
+	return &HealthHandler{
+		cfg:    cfg,
+		p2p:    p2p,
+		header: header,
+		das:    das,
+		core:   core,
+	}
+}
+
+// RegisterEndpoints registers the probes on the Server, ahead of its authentication and rate
+// limiting.
+func (h *HealthHandler) RegisterEndpoints(rpc *Server) {
+	rpc.RegisterProbeFunc(livenessEndpoint, h.handleLiveness)
+	rpc.RegisterProbeFunc(readinessEndpoint, h.ServeReadiness)
+}
+
+func (h *HealthHandler) handleLiveness(w http.ResponseWriter, _ *http.Request) {
+	resp, err := json.Marshal(&LivenessResponse{Alive: true})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, livenessEndpoint, err)
+		return
+	}
+	_, err = w.Write(resp)
+	if err != nil {
+		log.Errorw("writing response", "endpoint", livenessEndpoint, "err", err)
+	}
+}
+
+// ServeReadiness serves the readiness probe, responding with 503 while the node is not ready.
+func (h *HealthHandler) ServeReadiness(w http.ResponseWriter, r *http.Request) {
+	readiness := h.Readiness(r.Context())
+	resp, err := json.Marshal(readiness)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, readinessEndpoint, err)
+		return
+	}
+	if !readiness.Ready {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_, err = w.Write(resp)
+	if err != nil {
+		log.Errorw("writing response", "endpoint", readinessEndpoint, "err", err)
+	}
+}
+
+// Readiness runs the required checks and reports whether all of them pass.
+func (h *HealthHandler) Readiness(ctx context.Context) *ReadinessResponse {
+	checks := make(map[string]HealthCheck)
+	for _, check := range h.checks() {
+		switch check {
+		case CheckP2P:
+			checks[check] = h.checkPeers(ctx)
+		case CheckHeaderSync:
+			checks[check] = h.checkSync(ctx)
+		case CheckDAS:
+			checks[check] = h.checkSampling(ctx)
+		case CheckCore:
+			checks[check] = h.checkCore(ctx)
+		}
+	}
+
+	readiness := &ReadinessResponse{Ready: true, Checks: checks}
+	for _, check := range checks {
+		readiness.Ready = readiness.Ready && check.Ready
+	}
+	return readiness
+}
+
+// WaitReady blocks until the node is ready or the context is done.
+func (h *HealthHandler) WaitReady(ctx context.Context) error {
+	ticker := time.NewTicker(readinessPollInterval)
+	defer ticker.Stop()
+	for {
+		if h.Readiness(ctx).Ready {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// checks returns the names of the required checks available in the node.
+func (h *HealthHandler) checks() []string {
+	required := h.cfg.Checks
+	if len(required) == 0 {
+		required = []string{CheckP2P, CheckHeaderSync, CheckDAS, CheckCore}
+	}
+	checks := make([]string, 0, len(required))
+	for _, check := range required {
+		if (check == CheckDAS && h.das == nil) || (check == CheckCore && h.core == nil) {
+			continue
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// checkPeers checks that the node is connected to at least one peer.
+func (h *HealthHandler) checkPeers(ctx context.Context) HealthCheck {
+	peers, err := h.p2p.Peers(ctx)
+	if err != nil {
+		return HealthCheck{Detail: err.Error()}
+	}
+	return HealthCheck{
+		Ready:  len(peers) > 0,
+		Detail: fmt.Sprintf("connected to %d peers", len(peers)),
+	}
+}
+
+// checkSync checks that the node is synced to the network head, within the allowed lag.
+func (h *HealthHandler) checkSync(ctx context.Context) HealthCheck {
+	state, err := h.header.SyncState(ctx)
+	if err != nil {
+		return HealthCheck{Detail: err.Error()}
+	}
+	switch {
+	case state.Error != "":
+		return HealthCheck{Detail: fmt.Sprintf("syncing failed at height %d: %s", state.Height, state.Error)}
+	case !state.Finished() && state.ToHeight-state.Height <= h.cfg.MaxSyncLag:
+		return HealthCheck{Ready: true, Detail: fmt.Sprintf("syncing height %d of %d", state.Height, state.ToHeight)}
+	case !state.Finished():
+		return HealthCheck{Detail: fmt.Sprintf("syncing height %d of %d", state.Height, state.ToHeight)}
+	default:
+		return HealthCheck{Ready: true, Detail: fmt.Sprintf("synced to height %d", state.Height)}
+	}
+}
+
+// checkSampling checks that the DASer caught up with the network head, within the allowed lag.
+func (h *HealthHandler) checkSampling(ctx context.Context) HealthCheck {
+	stats, err := h.das.SamplingStats(ctx)
+	if err != nil {
+		return HealthCheck{Detail: err.Error()}
+	}
+	if !stats.IsRunning {
+		return HealthCheck{Detail: "sampling is not running"}
+	}
+	return HealthCheck{
+		Ready:  stats.CatchUpDone || stats.NetworkHead <= stats.SampledChainHead+h.cfg.MaxSyncLag,
+		Detail: fmt.Sprintf("sampled up to height %d of %d", stats.SampledChainHead, stats.NetworkHead),
+	}
+}
+
+// checkCore checks that the Core node is reachable and caught up with the network.
+func (h *HealthHandler) checkCore(ctx context.Context) HealthCheck {
+	syncing, err := h.core.IsSyncing(ctx)
+	switch {
+	case err != nil:
+		return HealthCheck{Detail: err.Error()}
+	case syncing:
+		return HealthCheck{Detail: "core is catching up with the network"}
+	default:
+		return HealthCheck{Ready: true, Detail: "connected to core"}
+	}
+}
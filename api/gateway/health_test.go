@@ -0,0 +1,157 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/go-header/sync"
+
+	"github.com/celestiaorg/celestia-node/das"
+	dasMock "github.com/celestiaorg/celestia-node/nodebuilder/das/mocks"
+	modheader "github.com/celestiaorg/celestia-node/nodebuilder/header"
+	headerMock "github.com/celestiaorg/celestia-node/nodebuilder/header/mocks"
+	p2pMock "github.com/celestiaorg/celestia-node/nodebuilder/p2p/mocks"
+)
+
+func TestHealthHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	p2pMod := p2pMock.NewMockModule(ctrl)
+	headerMod := headerMock.NewMockModule(ctrl)
+	dasMod := dasMock.NewMockModule(ctrl)
+
+	server := NewServer(address, port)
+	NewHealthHandler(ReadinessConfig{}, p2pMod, headerMod, dasMod, nil).RegisterEndpoints(server)
+
+	t.Run("liveness", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, httptest.NewRequest(http.MethodGet, livenessEndpoint, nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"alive":true}`, w.Body.String())
+	})
+
+	t.Run("liveness bypasses auth and rate limiting", func(t *testing.T) {
+		server := NewServer(address, port)
+		server.WithAuth(AuthConfig{Tokens: []string{"secret"}, ProtectReads: true})
+		require.NoError(t, server.SetRateLimit(RateLimitConfig{GlobalRate: 1, GlobalBurst: 1}))
+		NewHealthHandler(ReadinessConfig{}, p2pMod, headerMod, dasMod, nil).RegisterEndpoints(server)
+		server.RegisterHandlerFunc(headEndpoint, new(ping).ServeHTTP, http.MethodGet)
+
+		// the other endpoints are still protected
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, httptest.NewRequest(http.MethodGet, headEndpoint, nil))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		for i := 0; i < 3; i++ {
+			w = httptest.NewRecorder()
+			server.ServeHTTP(w, httptest.NewRequest(http.MethodGet, livenessEndpoint, nil))
+			assert.Equal(t, http.StatusOK, w.Code)
+		}
+	})
+
+	readiness := func(t *testing.T) (int, ReadinessResponse) {
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, httptest.NewRequest(http.MethodGet, readinessEndpoint, nil))
+		var resp ReadinessResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return w.Code, resp
+	}
+
+	t.Run("ready", func(t *testing.T) {
+		p2pMod.EXPECT().Peers(gomock.Any()).Return([]peer.ID{"peer"}, nil)
+		headerMod.EXPECT().SyncState(gomock.Any()).Return(syncState(10, 10), nil)
+		dasMod.EXPECT().SamplingStats(gomock.Any()).Return(das.SamplingStats{
+			SampledChainHead: 10,
+			NetworkHead:      10,
+			CatchUpDone:      true,
+			IsRunning:        true,
+		}, nil)
+
+		code, resp := readiness(t)
+		assert.Equal(t, http.StatusOK, code)
+		assert.True(t, resp.Ready)
+		assert.Len(t, resp.Checks, 3)
+	})
+
+	t.Run("not ready", func(t *testing.T) {
+		p2pMod.EXPECT().Peers(gomock.Any()).Return(nil, nil)
+		headerMod.EXPECT().SyncState(gomock.Any()).Return(syncState(5, 10), nil)
+		dasMod.EXPECT().SamplingStats(gomock.Any()).Return(das.SamplingStats{}, errors.New("stopped"))
+
+		code, resp := readiness(t)
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.False(t, resp.Ready)
+		assert.Equal(t, HealthCheck{Detail: "connected to 0 peers"}, resp.Checks["p2p"])
+		assert.Equal(t, HealthCheck{Detail: "syncing height 5 of 10"}, resp.Checks["header_sync"])
+		assert.Equal(t, HealthCheck{Detail: "stopped"}, resp.Checks["das"])
+	})
+
+	t.Run("without DAS", func(t *testing.T) {
+		handler := NewHealthHandler(ReadinessConfig{}, p2pMod, headerMod, nil, nil)
+		p2pMod.EXPECT().Peers(gomock.Any()).Return([]peer.ID{"peer"}, nil)
+		headerMod.EXPECT().SyncState(gomock.Any()).Return(syncState(10, 10), nil)
+
+		resp := handler.Readiness(context.Background())
+		assert.True(t, resp.Ready)
+		assert.NotContains(t, resp.Checks, "das")
+	})
+
+	t.Run("selected checks within lag", func(t *testing.T) {
+		handler := NewHealthHandler(ReadinessConfig{
+			Checks:     []string{CheckHeaderSync, CheckDAS, CheckCore},
+			MaxSyncLag: 5,
+		}, p2pMod, headerMod, dasMod, coreStatus{})
+		headerMod.EXPECT().SyncState(gomock.Any()).Return(syncState(5, 10), nil)
+		dasMod.EXPECT().SamplingStats(gomock.Any()).Return(das.SamplingStats{
+			SampledChainHead: 4,
+			NetworkHead:      10,
+			IsRunning:        true,
+		}, nil)
+
+		resp := handler.Readiness(context.Background())
+		assert.False(t, resp.Ready)
+		assert.NotContains(t, resp.Checks, "p2p")
+		assert.True(t, resp.Checks["header_sync"].Ready)
+		assert.False(t, resp.Checks["das"].Ready)
+		assert.True(t, resp.Checks["core"].Ready)
+	})
+
+	t.Run("wait ready", func(t *testing.T) {
+		interval := readinessPollInterval
+		readinessPollInterval = time.Millisecond
+		t.Cleanup(func() {
+			readinessPollInterval = interval
+		})
+		handler := NewHealthHandler(ReadinessConfig{Checks: []string{CheckHeaderSync}}, p2pMod, headerMod, nil, nil)
+		gomock.InOrder(
+			headerMod.EXPECT().SyncState(gomock.Any()).Return(syncState(5, 10), nil),
+			headerMod.EXPECT().SyncState(gomock.Any()).Return(syncState(10, 10), nil),
+		)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		require.NoError(t, handler.WaitReady(ctx))
+	})
+
+	cfg := ReadinessConfig{Checks: []string{"db"}}
+	assert.Error(t, cfg.Validate())
+}
+
+type coreStatus struct{}
+
+func (coreStatus) IsSyncing(context.Context) (bool, error) {
+	return false, nil
+}
+
+func syncState(height, toHeight uint64) modheader.SyncState {
+	return modheader.SyncState{State: sync.State{Height: height, ToHeight: toHeight}}
+}
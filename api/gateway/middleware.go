@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
 
 	"github.com/celestiaorg/celestia-node/nodebuilder/state"
 )
@@ -17,18 +18,11 @@ func (h *Handler) RegisterMiddleware(srv *Server) {
 	srv.RegisterMiddleware(
 		setContentType,
 		checkPostDisabled(h.state),
+		validateRequest,
 		wrapRequestContext,
-		enableCors,
 	)
 }
 
-func enableCors(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		next.ServeHTTP(w, r)
-	})
-}
-
 func setContentType(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Add("Content-Type", "application/json")
@@ -41,7 +35,7 @@ func checkPostDisabled(state state.Module) mux.MiddlewareFunc {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// check if state service was halted and deny the transaction
-			if r.Method == http.MethodPost && state.IsStopped(r.Context()) {
+			if r.Method == http.MethodPost && state != nil && state.IsStopped(r.Context()) {
 				writeError(w, http.StatusMethodNotAllowed, r.URL.Path, errors.New("not possible to submit data"))
 				return
 			}
@@ -51,9 +45,14 @@ func checkPostDisabled(state state.Module) mux.MiddlewareFunc {
 }
 
 // wrapRequestContext ensures we implement a deadline on serving requests
-// via the gateway server-side to prevent context leaks.
+// via the gateway server-side to prevent context leaks. Subscriptions are long-lived and end
+// when the subscriber disconnects instead.
 func wrapRequestContext(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if websocket.IsWebSocketUpgrade(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
 		ctx, cancel := context.WithTimeout(r.Context(), timeout)
 		defer cancel()
 		next.ServeHTTP(w, r.WithContext(ctx))
@@ -0,0 +1,361 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/mux"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+const openAPIEndpoint = "/openapi.json"
+
+// maxValidatedBodySize bounds the size of request bodies read for validation.
+const maxValidatedBodySize = 8 << 20
+
+// schema is the subset of the OpenAPI 3 Schema Object the gateway describes its requests with.
+type schema struct {
+	Type        string             `json:"type"`
+	Format      string             `json:"format,omitempty"`
+	Pattern     string             `json:"pattern,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Properties  map[string]*schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+}
+
+var (
+	uint64Schema = &schema{Type: "integer", Format: "uint64"}
+	int64Schema  = &schema{Type: "integer", Format: "int64"}
+	hexSchema    = &schema{Type: "string", Pattern: "^([0-9a-fA-F]{2})+$", Description: "hex encoded bytes"}
+)
+
+// pathParams describes the path parameters shared by the endpoints.
+var pathParams = map[string]*schema{
+	addrKey: {
+		Type:        "string",
+		Pattern:     "^[a-z]+1[02-9ac-hj-np-z]+$",
+		Description: "bech32 encoded account or validator address",
+	},
+	heightKey: {Type: "integer", Format: "uint64", Description: "block height"},
+	namespaceKey: {
+		Type:        "string",
+		Pattern:     fmt.Sprintf("^[0-9a-fA-F]{%d}$", share.NamespaceSize*2),
+		Description: "hex encoded namespace",
+	},
+	txHashKey: {Type: "string", Pattern: "^[0-9a-fA-F]{64}$", Description: "hex encoded transaction hash"},
+}
+
+// endpointSpec annotates an endpoint for the OpenAPI document. Requests to the endpoint are
+// validated against the path parameters found in its route and the body schema, if any.
+type endpointSpec struct {
+	summary    string
+	body       *schema
+	deprecated bool
+}
+
+// specKey identifies an endpoint by its method and route template.
+type specKey struct {
+	method   string
+	template string
+}
+
+// endpointSpecs are the annotations of all the endpoints the gateway may serve.
+var endpointSpecs = mergeSpecs(
+	stateSpecs,
+	shareSpecs,
+	headerSpecs,
+	availabilitySpecs,
+	dasSpecs,
+	explorerSpecs,
+	subscribeSpecs,
+	healthSpecs,
+	map[specKey]endpointSpec{
+		{http.MethodGet, openAPIEndpoint}: {summary: "Returns the OpenAPI document of the gateway"},
+	},
+)
+
+func mergeSpecs(specs ...map[specKey]endpointSpec) map[specKey]endpointSpec {
+	merged := make(map[specKey]endpointSpec)
+	for _, s := range specs {
+		for key, spec := range s {
+			merged[key] = spec
+		}
+	}
+	return merged
+}
+
+// openAPIDocument is the subset of the OpenAPI 3 document the gateway serves.
+type openAPIDocument struct {
+	OpenAPI string                                 `json:"openapi"`
+	Info    openAPIInfo                            `json:"info"`
+	Paths   map[string]map[string]openAPIOperation `json:"paths"`
+}
+
+type openAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type openAPIOperation struct {
+	Summary     string                     `json:"summary,omitempty"`
+	Deprecated  bool                       `json:"deprecated,omitempty"`
+	Parameters  []openAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]openAPIResponse `json:"responses"`
+}
+
+type openAPIParameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *schema `json:"schema"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIMediaType struct {
+	Schema *schema `json:"schema"`
+}
+
+type openAPIResponse struct {
+	Description string `json:"description"`
+}
+
+// buildOpenAPI describes all the routes registered on the router.
+func buildOpenAPI(router *mux.Router) (*openAPIDocument, error) {
+	version := node.GetBuildInfo().SemanticVersion
+	if version == "" {
+		version = "unknown"
+	}
+	doc := &openAPIDocument{
+		OpenAPI: "3.0.3",
+		Info:    openAPIInfo{Title: "Celestia Node Gateway", Version: version},
+		Paths:   make(map[string]map[string]openAPIOperation),
+	}
+
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		tmpl, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return err
+		}
+		for _, method := range methods {
+			spec := endpointSpecs[specKey{method, tmpl}]
+			if doc.Paths[tmpl] == nil {
+				doc.Paths[tmpl] = make(map[string]openAPIOperation)
+			}
+			doc.Paths[tmpl][strings.ToLower(method)] = spec.operation(tmpl)
+		}
+		return nil
+	})
+	return doc, err
+}
+
+func (spec endpointSpec) operation(tmpl string) openAPIOperation {
+	op := openAPIOperation{
+		Summary:    spec.summary,
+		Deprecated: spec.deprecated,
+		Responses: map[string]openAPIResponse{
+			"200": {Description: "OK"},
+			"400": {Description: "Invalid request"},
+			"500": {Description: "Internal error"},
+		},
+	}
+	for _, name := range routeParams(tmpl) {
+		op.Parameters = append(op.Parameters, openAPIParameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   pathParams[name],
+		})
+	}
+	if spec.body != nil {
+		op.RequestBody = &openAPIRequestBody{
+			Required: true,
+			Content:  map[string]openAPIMediaType{"application/json": {Schema: spec.body}},
+		}
+	}
+	return op
+}
+
+var routeParamRegexp = regexp.MustCompile(`{([^}]+)}`)
+
+// routeParams returns the names of the path parameters in the route template.
+func routeParams(tmpl string) []string {
+	matches := routeParamRegexp.FindAllStringSubmatch(tmpl, -1)
+	names := make([]string, len(matches))
+	for i, match := range matches {
+		names[i] = match[1]
+	}
+	return names
+}
+
+func (s *Server) handleOpenAPI(w http.ResponseWriter, _ *http.Request) {
+	doc, err := buildOpenAPI(s.srvMux)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, openAPIEndpoint, err)
+		return
+	}
+	resp, err := json.Marshal(doc)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, openAPIEndpoint, err)
+		return
+	}
+	_, err = w.Write(resp)
+	if err != nil {
+		log.Errorw("writing response", "endpoint", openAPIEndpoint, "err", err)
+	}
+}
+
+// validateRequest rejects requests whose path parameters or body do not match the schema of the
+// endpoint.
+func validateRequest(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := mux.CurrentRoute(r)
+		if route == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		tmpl, err := route.GetPathTemplate()
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		for name, value := range mux.Vars(r) {
+			if s, ok := pathParams[name]; ok {
+				if err := s.validateString(value); err != nil {
+					writeError(w, http.StatusBadRequest, r.URL.Path, fmt.Errorf("invalid %s: %w", name, err))
+					return
+				}
+			}
+		}
+
+		spec, ok := endpointSpecs[specKey{r.Method, tmpl}]
+		if !ok || spec.body == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxValidatedBodySize))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, r.URL.Path, err)
+			return
+		}
+		if err = spec.body.validateJSON(body); err != nil {
+			writeError(w, http.StatusBadRequest, r.URL.Path, fmt.Errorf("invalid request body: %w", err))
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// validateJSON checks that the JSON encoded value matches the schema.
+func (s *schema) validateJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return err
+	}
+	return s.validate("", value)
+}
+
+// validate checks that the value decoded from JSON matches the schema.
+func (s *schema) validate(path string, value any) error {
+	switch s.Type {
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return schemaErr(path, "must be an object")
+		}
+		for _, name := range s.Required {
+			if _, ok := obj[name]; !ok {
+				return schemaErr(path+"."+name, "is required")
+			}
+		}
+		names := make([]string, 0, len(obj))
+		for name := range obj {
+			names = append(names, name)
+		}
+		// validate in a stable order to report the same error for the same request
+		sort.Strings(names)
+		for _, name := range names {
+			if prop, ok := s.Properties[name]; ok {
+				if err := prop.validate(path+"."+name, obj[name]); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	case "integer":
+		num, ok := value.(json.Number)
+		if !ok {
+			return schemaErr(path, "must be an integer")
+		}
+		return s.validateString(num.String())
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return schemaErr(path, "must be a string")
+		}
+		return s.validateString(str)
+	default:
+		return nil
+	}
+}
+
+// validateString checks that the string representation of a value matches the schema.
+func (s *schema) validateString(value string) error {
+	switch {
+	case s.Type == "integer" && s.Format == "uint64":
+		if _, err := strconv.ParseUint(value, 10, 64); err != nil {
+			return fmt.Errorf("%q is not an unsigned integer", value)
+		}
+	case s.Type == "integer":
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return fmt.Errorf("%q is not an integer", value)
+		}
+	case s.Pattern != "":
+		if !compilePattern(s.Pattern).MatchString(value) {
+			return fmt.Errorf("%q does not match %s", value, s.Pattern)
+		}
+	}
+	return nil
+}
+
+// patterns caches the compiled schema patterns.
+var patterns sync.Map
+
+func compilePattern(pattern string) *regexp.Regexp {
+	if re, ok := patterns.Load(pattern); ok {
+		return re.(*regexp.Regexp)
+	}
+	re := regexp.MustCompile(pattern)
+	patterns.Store(pattern, re)
+	return re
+}
+
+func schemaErr(path, msg string) error {
+	if path == "" {
+		return errors.New(msg)
+	}
+	return fmt.Errorf("%s %s", strings.TrimPrefix(path, "."), msg)
+}
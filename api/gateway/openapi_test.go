@@ -0,0 +1,121 @@
+package gateway
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stateMock "github.com/celestiaorg/celestia-node/nodebuilder/state/mocks"
+)
+
+func TestOpenAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	stateMod := stateMock.NewMockModule(ctrl)
+	stateMod.EXPECT().IsStopped(gomock.Any()).Return(false).AnyTimes()
+
+	server := NewServer(address, port)
+	handler := NewHandler(stateMod, nil, nil, nil)
+	handler.RegisterEndpoints(server, false)
+	handler.RegisterMiddleware(server)
+
+	t.Run("document", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, httptest.NewRequest(http.MethodGet, openAPIEndpoint, nil))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var doc openAPIDocument
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+		assert.Equal(t, "3.0.3", doc.OpenAPI)
+
+		header := doc.Paths["/header/{height}"]["get"]
+		assert.NotEmpty(t, header.Summary)
+		require.Len(t, header.Parameters, 1)
+		assert.Equal(t, heightKey, header.Parameters[0].Name)
+		assert.Equal(t, "path", header.Parameters[0].In)
+
+		submit := doc.Paths[submitTxEndpoint]["post"]
+		require.NotNil(t, submit.RequestBody)
+		assert.Equal(t, []string{"tx"}, submit.RequestBody.Content["application/json"].Schema.Required)
+
+		// deprecated endpoints are not registered
+		assert.NotContains(t, doc.Paths, submitPFBEndpoint)
+	})
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		errMsg string
+	}{
+		{
+			name:   "invalid height",
+			method: http.MethodGet,
+			path:   "/header/abc",
+			errMsg: "invalid height",
+		},
+		{
+			name:   "invalid namespace",
+			method: http.MethodGet,
+			path:   namespacedSharesEndpoint + "/0011",
+			errMsg: "invalid nid",
+		},
+		{
+			name:   "invalid tx hash",
+			method: http.MethodGet,
+			path:   txStatusEndpoint + "/" + strings.Repeat("z", 64),
+			errMsg: "invalid hash",
+		},
+		{
+			name:   "missing field",
+			method: http.MethodPost,
+			path:   submitTxEndpoint,
+			body:   `{}`,
+			errMsg: "tx is required",
+		},
+		{
+			name:   "wrong type",
+			method: http.MethodPost,
+			path:   submitTxEndpoint,
+			body:   `{"tx": 1}`,
+			errMsg: "tx must be a string",
+		},
+		{
+			name:   "not hex",
+			method: http.MethodPost,
+			path:   submitRawPFBEndpoint,
+			body:   `{"tx": "xyz"}`,
+			errMsg: "does not match",
+		},
+		{
+			name:   "not an object",
+			method: http.MethodPost,
+			path:   submitTxEndpoint,
+			body:   `[]`,
+			errMsg: "must be an object",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), tt.errMsg)
+		})
+	}
+
+	t.Run("valid body", func(t *testing.T) {
+		stateMod.EXPECT().SubmitTx(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		w := httptest.NewRecorder()
+		body := strings.NewReader(`{"tx": "abcd"}`)
+		server.ServeHTTP(w, httptest.NewRequest(http.MethodPost, submitTxEndpoint, body))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
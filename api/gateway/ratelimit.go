@@ -0,0 +1,151 @@
+package gateway
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/mux"
+	lru "github.com/hashicorp/golang-lru"
+	"golang.org/x/time/rate"
+)
+
+// maxRateLimitedIPs bounds the amount of clients tracked by the per-IP limiter. The least recently
+// seen clients are forgotten first.
+const maxRateLimitedIPs = 10000
+
+var errRateLimited = errors.New("too many requests")
+
+// RateLimitConfig configures token bucket rate limiting of the gateway requests. A limit is
+// disabled when its rate is zero.
+type RateLimitConfig struct {
+	// GlobalRate is the amount of requests per second served across all clients.
+	GlobalRate float64
+	// GlobalBurst is the amount of requests served at once across all clients.
+	GlobalBurst int
+	// PerIPRate is the amount of requests per second served for a single client IP.
+	PerIPRate float64
+	// PerIPBurst is the amount of requests served at once for a single client IP.
+	PerIPBurst int
+}
+
+// Enabled reports whether any limit is configured.
+func (cfg *RateLimitConfig) Enabled() bool {
+	return cfg.GlobalRate > 0 || cfg.PerIPRate > 0
+}
+
+// Validate checks that the rates are not negative and every enabled limit has a burst.
+func (cfg *RateLimitConfig) Validate() error {
+	if cfg.GlobalRate < 0 || cfg.PerIPRate < 0 {
+		return errors.New("service/gateway: rate limits must not be negative")
+	}
+	if cfg.GlobalRate > 0 && cfg.GlobalBurst <= 0 {
+		return fmt.Errorf("service/gateway: global rate limit burst must be positive, got %d", cfg.GlobalBurst)
+	}
+	if cfg.PerIPRate > 0 && cfg.PerIPBurst <= 0 {
+		return fmt.Errorf("service/gateway: per IP rate limit burst must be positive, got %d", cfg.PerIPBurst)
+	}
+	return nil
+}
+
+// rateLimiter limits requests globally and per client IP.
+type rateLimiter struct {
+	cfg    RateLimitConfig
+	global *rate.Limiter
+
+	lock  sync.Mutex
+	perIP *lru.Cache
+}
+
+func newRateLimiter(cfg RateLimitConfig) (*rateLimiter, error) {
+	rl := &rateLimiter{cfg: cfg}
+	if cfg.GlobalRate > 0 {
+		rl.global = rate.NewLimiter(rate.Limit(cfg.GlobalRate), cfg.GlobalBurst)
+	}
+	if cfg.PerIPRate > 0 {
+		cache, err := lru.New(maxRateLimitedIPs)
+		if err != nil {
+			return nil, fmt.Errorf("service/gateway: failed to instantiate rate limiter: %w", err)
+		}
+		rl.perIP = cache
+	}
+	return rl, nil
+}
+
+// reserve takes a token from every limit applying to the client. It returns how long the client
+// has to wait for the request to be allowed, in which case no token is taken.
+func (rl *rateLimiter) reserve(ip string) time.Duration {
+	limiters := make([]*rate.Limiter, 0, 2)
+	if rl.global != nil {
+		limiters = append(limiters, rl.global)
+	}
+	if rl.perIP != nil {
+		limiters = append(limiters, rl.limiter(ip))
+	}
+
+	now := time.Now()
+	var wait time.Duration
+	reservations := make([]*rate.Reservation, 0, len(limiters))
+	for _, limiter := range limiters {
+		r := limiter.ReserveN(now, 1)
+		reservations = append(reservations, r)
+		if d := r.DelayFrom(now); d > wait {
+			wait = d
+		}
+	}
+	if wait > 0 {
+		// give the tokens back, as the request is rejected
+		for _, r := range reservations {
+			r.CancelAt(now)
+		}
+	}
+	return wait
+}
+
+// limiter returns the limiter of the given client IP.
+func (rl *rateLimiter) limiter(ip string) *rate.Limiter {
+	rl.lock.Lock()
+	defer rl.lock.Unlock()
+
+	if limiter, ok := rl.perIP.Get(ip); ok {
+		return limiter.(*rate.Limiter)
+	}
+	limiter := rate.NewLimiter(rate.Limit(rl.cfg.PerIPRate), rl.cfg.PerIPBurst)
+	rl.perIP.Add(ip, limiter)
+	return limiter
+}
+
+// limitRate rejects requests over the limits of the current rate limiter, if any, with 429 Too
+// Many Requests, telling the client when to retry.
+func limitRate(current *atomic.Pointer[rateLimiter]) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rl := current.Load()
+			if rl == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if wait := rl.reserve(clientIP(r)); wait > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+				writeError(w, http.StatusTooManyRequests, r.URL.Path, errRateLimited)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// clientIP returns the IP of the client the request came from.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -2,33 +2,57 @@ package gateway
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net"
 	"net/http"
+	"os"
 	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/mux"
+
+	"github.com/celestiaorg/celestia-node/libs/handover"
 )
 
 // Server represents a gateway server on the Node.
 type Server struct {
-	srv      *http.Server
-	srvMux   *mux.Router // http request multiplexer
+	srv    *http.Server
+	srvMux *mux.Router // http request multiplexer
+	// probeMux serves the health probes ahead of the middleware of srvMux, e.g. authentication
+	// and rate limiting, so orchestrators can always reach them
+	probeMux *mux.Router
 	listener net.Listener
+	// listeners keep the listener for the handover to the next node process, if set
+	listeners *handover.Listeners
+	cors      CORSConfig
+	// rateLimit limits the requests, if set. It is replaced while the Server runs.
+	rateLimit atomic.Pointer[rateLimiter]
+	// rateLimited is set once the rate limiting middleware is in place
+	rateLimited bool
 
 	started atomic.Bool
 }
 
-// NewServer returns a new gateway Server.
+// NewServer returns a new gateway Server. The address is either an IP or hostname to listen on
+// the given port, or a Unix domain socket prefixed with UnixSocketPrefix.
 func NewServer(address, port string) *Server {
 	srvMux := mux.NewRouter()
 	srvMux.Use(setContentType)
 
+	probeMux := mux.NewRouter()
+	probeMux.Use(setContentType, wrapRequestContext)
+
 	server := &Server{
-		srvMux: srvMux,
+		srvMux:   srvMux,
+		probeMux: probeMux,
+	}
+	addr := address + ":" + port
+	if _, ok := UnixSocketPath(address); ok {
+		addr = address
 	}
 	server.srv = &http.Server{
-		Addr:    address + ":" + port,
+		Addr:    addr,
 		Handler: server,
 		// the amount of time allowed to read request headers. set to the default 2 seconds
 		ReadHeaderTimeout: 2 * time.Second,
@@ -36,6 +60,67 @@ func NewServer(address, port string) *Server {
 	return server
 }
 
+// WithListeners makes the Server listen through the given Listeners, inheriting the listener of
+// the previous node process, if any, and handing its own over to the next one.
+func (s *Server) WithListeners(listeners *handover.Listeners) {
+	s.listeners = listeners
+}
+
+// WithTLS makes the Server serve HTTPS with the given configuration.
+func (s *Server) WithTLS(cfg TLSConfig) error {
+	tlsCfg, err := cfg.tlsConfig()
+	if err != nil {
+		return err
+	}
+	s.srv.TLSConfig = tlsCfg
+	return nil
+}
+
+// WithCORS sets the CORS policy of the Server. Any origin is allowed by default.
+func (s *Server) WithCORS(cfg CORSConfig) {
+	s.cors = cfg
+}
+
+// WithAuth makes the Server require one of the configured tokens on the protected endpoints.
+func (s *Server) WithAuth(cfg AuthConfig) {
+	s.srvMux.Use(authenticate(cfg))
+}
+
+// SetRateLimit makes the Server reject requests over the configured limits, or serve all of them
+// if no limit is enabled. The first call must happen before the Server starts, as it puts the rate
+// limiting in place among the middleware. Later calls may happen on a running Server, in which case
+// the clients start with full buckets under the new limits.
+func (s *Server) SetRateLimit(cfg RateLimitConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if !s.rateLimited {
+		if s.started.Load() {
+			return errors.New("service/gateway: rate limiting must be set before starting")
+		}
+		s.srvMux.Use(limitRate(&s.rateLimit))
+		s.rateLimited = true
+	}
+	if !cfg.Enabled() {
+		s.rateLimit.Store(nil)
+		return nil
+	}
+	rl, err := newRateLimiter(cfg)
+	if err != nil {
+		return err
+	}
+	s.rateLimit.Store(rl)
+	return nil
+}
+
+// RateLimit returns the limits the Server rejects requests over.
+func (s *Server) RateLimit() RateLimitConfig {
+	if rl := s.rateLimit.Load(); rl != nil {
+		return rl.cfg
+	}
+	return RateLimitConfig{}
+}
+
 // Start starts the gateway Server, listening on the given address.
 func (s *Server) Start(context.Context) error {
 	couldStart := s.started.CompareAndSwap(false, true)
@@ -43,17 +128,47 @@ func (s *Server) Start(context.Context) error {
 		log.Warn("cannot start server: already started")
 		return nil
 	}
-	listener, err := net.Listen("tcp", s.srv.Addr)
+	network, address := "tcp", s.srv.Addr
+	if path, ok := UnixSocketPath(s.srv.Addr); ok {
+		network, address = "unix", path
+	}
+	listener, err := s.listeners.Listen(network, address, func() (net.Listener, error) {
+		return listen(s.srv.Addr)
+	})
 	if err != nil {
-		return err
+		// hostnames are resolved here rather than on config validation
+		return fmt.Errorf("service/gateway: listening on %s: %w", s.srv.Addr, err)
 	}
 	s.listener = listener
+
+	if s.srv.TLSConfig != nil {
+		log.Infow("server started", "listening on", s.srv.Addr, "tls", true)
+		// the certificate is already loaded into the TLS config
+		//nolint:errcheck
+		go s.srv.ServeTLS(listener, "", "")
+		return nil
+	}
 	log.Infow("server started", "listening on", s.srv.Addr)
 	//nolint:errcheck
 	go s.srv.Serve(listener)
 	return nil
 }
 
+// listen listens on the given TCP address or Unix domain socket. A socket file left by a previous
+// run is removed first.
+func listen(addr string) (net.Listener, error) {
+	path, ok := UnixSocketPath(addr)
+	if !ok {
+		return net.Listen("tcp", addr)
+	}
+	if info, err := os.Stat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("service/gateway: removing stale socket: %w", err)
+		}
+	}
+	return net.Listen("unix", path)
+}
+
 // Stop stops the gateway Server.
 func (s *Server) Stop(ctx context.Context) error {
 	couldStop := s.started.CompareAndSwap(true, false)
@@ -85,8 +200,24 @@ func (s *Server) RegisterHandlerFunc(pattern string, handlerFunc http.HandlerFun
 	s.srvMux.HandleFunc(pattern, handlerFunc).Methods(method)
 }
 
+// RegisterProbeFunc registers the given http.HandlerFunc as a GET health probe on the given
+// pattern. Probes bypass the middleware of the other endpoints, e.g. authentication and rate
+// limiting.
+func (s *Server) RegisterProbeFunc(pattern string, handlerFunc http.HandlerFunc) {
+	s.probeMux.HandleFunc(pattern, handlerFunc).Methods(http.MethodGet)
+}
+
 // ServeHTTP serves inbound requests on the Server.
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var match mux.RouteMatch
+	if s.probeMux.Match(r, &match) {
+		s.probeMux.ServeHTTP(w, r)
+		return
+	}
+	// CORS is handled before routing, as preflight requests do not match any route
+	if s.cors.serveCORS(w, r) {
+		return
+	}
 	s.srvMux.ServeHTTP(w, r)
 }
 
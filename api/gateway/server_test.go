@@ -2,9 +2,21 @@ package gateway
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
 	"io"
+	"math/big"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -48,7 +60,6 @@ func TestServer(t *testing.T) {
 
 func TestCorsEnabled(t *testing.T) {
 	server := NewServer(address, port)
-	server.RegisterMiddleware(enableCors)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	t.Cleanup(cancel)
@@ -127,3 +138,292 @@ func (ch contextHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 	w.Write(bin) //nolint:errcheck
 }
+
+func TestServer_TLS(t *testing.T) {
+	dir := t.TempDir()
+	serverCert, serverCertPath, serverKeyPath := writeTestCert(t, dir, "server")
+	clientCert, clientCAPath, _ := writeTestCert(t, dir, "client")
+
+	cfg := TLSConfig{CertPath: serverCertPath}
+	require.Error(t, cfg.Validate())
+	cfg.KeyPath = serverKeyPath
+	require.NoError(t, cfg.Validate())
+	require.Error(t, (&TLSConfig{ClientCAPath: clientCAPath}).Validate())
+
+	roots := x509.NewCertPool()
+	roots.AddCert(serverCert.Leaf)
+	get := func(t *testing.T, server *Server, certs ...tls.Certificate) (*http.Response, error) {
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			RootCAs:      roots,
+			Certificates: certs,
+			MinVersion:   tls.VersionTLS12,
+		}}}
+		resp, err := client.Get(fmt.Sprintf("https://%s/ping", server.ListenAddr()))
+		if err == nil {
+			t.Cleanup(func() {
+				resp.Body.Close()
+			})
+		}
+		return resp, err
+	}
+	startServer := func(t *testing.T, cfg TLSConfig) *Server {
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+
+		server := NewServer(address, port)
+		require.NoError(t, server.WithTLS(cfg))
+		server.RegisterHandlerFunc("/ping", ping{}.ServeHTTP, http.MethodGet)
+		require.NoError(t, server.Start(ctx))
+		t.Cleanup(func() {
+			require.NoError(t, server.Stop(ctx))
+		})
+		return server
+	}
+
+	t.Run("TLS", func(t *testing.T) {
+		server := startServer(t, cfg)
+		resp, err := get(t, server)
+		require.NoError(t, err)
+		buf, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "pong", string(buf))
+
+		// plain HTTP requests are refused
+		resp, err = http.Get(fmt.Sprintf("http://%s/ping", server.ListenAddr()))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("mTLS", func(t *testing.T) {
+		mtlsCfg := cfg
+		mtlsCfg.ClientCAPath = clientCAPath
+		server := startServer(t, mtlsCfg)
+
+		_, err := get(t, server)
+		require.Error(t, err)
+		resp, err := get(t, server, clientCert)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+}
+
+// writeTestCert generates a self-signed certificate for localhost and writes it along with its key
+// into the given dir.
+func writeTestCert(t *testing.T, dir, name string) (cert tls.Certificate, certPath, keyPath string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	certPath, keyPath = filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key")
+	require.NoError(t, os.WriteFile(certPath, certPEM, 0o600))
+	require.NoError(t, os.WriteFile(keyPath, keyPEM, 0o600))
+
+	cert, err = tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+	cert.Leaf, err = x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert, certPath, keyPath
+}
+
+func TestServer_Auth(t *testing.T) {
+	const token = "secret"
+
+	tests := []struct {
+		name         string
+		cfg          AuthConfig
+		endpoint     string
+		header       string
+		value        string
+		expectedCode int
+	}{
+		{"public endpoint without token", AuthConfig{Tokens: []string{token}}, headEndpoint, "", "",
+			http.StatusOK},
+		{"protected endpoint without token", AuthConfig{Tokens: []string{token}}, submitTxEndpoint, "", "",
+			http.StatusUnauthorized},
+		{"protected endpoint with invalid token", AuthConfig{Tokens: []string{token}}, submitTxEndpoint,
+			authorizationHeader, bearerPrefix + "invalid", http.StatusUnauthorized},
+		{"protected endpoint with bearer token", AuthConfig{Tokens: []string{token}}, submitTxEndpoint,
+			authorizationHeader, bearerPrefix + token, http.StatusOK},
+		{"protected endpoint with API key", AuthConfig{Tokens: []string{"other", token}}, submitTxEndpoint,
+			apiKeyHeader, token, http.StatusOK},
+		{"protected reads without token", AuthConfig{Tokens: []string{token}, ProtectReads: true}, headEndpoint,
+			"", "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := NewServer(address, port)
+			server.WithAuth(tt.cfg)
+			server.RegisterHandlerFunc(headEndpoint, new(ping).ServeHTTP, http.MethodGet)
+			server.RegisterHandlerFunc(submitTxEndpoint, new(ping).ServeHTTP, http.MethodGet)
+
+			req := httptest.NewRequest(http.MethodGet, tt.endpoint, nil)
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, req)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestAuthConfig_Validate(t *testing.T) {
+	assert.NoError(t, (&AuthConfig{}).Validate())
+	assert.NoError(t, (&AuthConfig{Tokens: []string{"secret"}, ProtectReads: true}).Validate())
+	assert.Error(t, (&AuthConfig{ProtectReads: true}).Validate())
+	assert.Error(t, (&AuthConfig{Tokens: []string{" "}}).Validate())
+}
+
+func TestServer_CORS(t *testing.T) {
+	const origin = "https://explorer.example"
+
+	server := NewServer(address, port)
+	server.WithCORS(CORSConfig{
+		AllowedOrigins: []string{origin},
+		AllowedHeaders: []string{"Content-Type"},
+		MaxAge:         time.Minute,
+	})
+	server.RegisterHandlerFunc("/ping", new(ping).ServeHTTP, http.MethodGet)
+
+	// preflight from an allowed origin is answered without reaching the handlers
+	req := httptest.NewRequest(http.MethodOptions, "/ping", nil)
+	req.Header.Set("Origin", origin)
+	req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, origin, rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "GET, POST", rec.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "Content-Type", rec.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "60", rec.Header().Get("Access-Control-Max-Age"))
+
+	// requests from an allowed origin are served with the CORS headers
+	req = httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.Header.Set("Origin", origin)
+	rec = httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, origin, rec.Header().Get("Access-Control-Allow-Origin"))
+
+	// requests from other origins are served without the CORS headers, so browsers block them
+	req = httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.Header.Set("Origin", "https://other.example")
+	rec = httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestServer_RateLimit(t *testing.T) {
+	server := NewServer(address, port)
+	cfg := RateLimitConfig{
+		GlobalRate:  1,
+		GlobalBurst: 3,
+		PerIPRate:   1,
+		PerIPBurst:  2,
+	}
+	err := server.SetRateLimit(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, cfg, server.RateLimit())
+	server.RegisterHandlerFunc("/ping", new(ping).ServeHTTP, http.MethodGet)
+
+	get := func(ip string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		req.RemoteAddr = ip + ":1234"
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// the per IP burst is exhausted first
+	assert.Equal(t, http.StatusOK, get("10.0.0.1").Code)
+	assert.Equal(t, http.StatusOK, get("10.0.0.1").Code)
+	rec := get("10.0.0.1")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+
+	// other clients are served until the global burst is exhausted
+	assert.Equal(t, http.StatusOK, get("10.0.0.2").Code)
+	assert.Equal(t, http.StatusTooManyRequests, get("10.0.0.3").Code)
+
+	// the limits are adjusted on the fly
+	require.Error(t, server.SetRateLimit(RateLimitConfig{PerIPRate: 1}))
+	require.NoError(t, server.SetRateLimit(RateLimitConfig{}))
+	assert.Equal(t, RateLimitConfig{}, server.RateLimit())
+	assert.Equal(t, http.StatusOK, get("10.0.0.1").Code)
+	assert.Equal(t, http.StatusOK, get("10.0.0.3").Code)
+}
+
+func TestRateLimitConfig_Validate(t *testing.T) {
+	assert.NoError(t, (&RateLimitConfig{}).Validate())
+	assert.NoError(t, (&RateLimitConfig{PerIPRate: 10, PerIPBurst: 20}).Validate())
+	assert.Error(t, (&RateLimitConfig{GlobalRate: -1}).Validate())
+	assert.Error(t, (&RateLimitConfig{PerIPRate: 10}).Validate())
+}
+
+func TestValidateHost(t *testing.T) {
+	// hostnames are validated without being resolved
+	for _, host := range []string{"0.0.0.0", "::1", "localhost", "gateway.celestia.invalid"} {
+		assert.NoError(t, ValidateHost(host), host)
+	}
+	for _, host := range []string{"", "gateway..invalid", "-gateway", "gate way", "gateway_1"} {
+		assert.Error(t, ValidateHost(host), host)
+	}
+}
+
+func TestServer_UnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gateway.sock")
+	addr := UnixSocketPrefix + path
+
+	cfg := Config{Address: addr}
+	require.NoError(t, cfg.Validate())
+
+	// a stale socket left by a previous run does not prevent the server from starting
+	stale, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+
+	server := NewServer(addr, "")
+	server.RegisterHandlerFunc("/ping", new(ping).ServeHTTP, http.MethodGet)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	require.NoError(t, server.Start(ctx))
+	require.Equal(t, path, server.ListenAddr())
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, "unix", path)
+			},
+		},
+	}
+	resp, err := client.Get("http://gateway/ping")
+	require.NoError(t, err)
+	buf, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, "pong", string(buf))
+
+	require.NoError(t, server.Stop(ctx))
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -21,6 +22,21 @@ const (
 
 var namespaceKey = "nid"
 
+var shareSpecs = map[specKey]endpointSpec{
+	{http.MethodGet, fmt.Sprintf("%s/{%s}/height/{%s}", namespacedSharesEndpoint, namespaceKey, heightKey)}: {
+		summary: "Returns the shares of the namespace at the given height",
+	},
+	{http.MethodGet, fmt.Sprintf("%s/{%s}", namespacedSharesEndpoint, namespaceKey)}: {
+		summary: "Returns the shares of the namespace at the latest height",
+	},
+	{http.MethodGet, fmt.Sprintf("%s/{%s}/height/{%s}", namespacedDataEndpoint, namespaceKey, heightKey)}: {
+		summary: "Returns the data of the namespace at the given height",
+	},
+	{http.MethodGet, fmt.Sprintf("%s/{%s}", namespacedDataEndpoint, namespaceKey)}: {
+		summary: "Returns the data of the namespace at the latest height",
+	},
+}
+
 // NamespacedSharesResponse represents the response to a
 // SharesByNamespace request.
 type NamespacedSharesResponse struct {
@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/cosmos/cosmos-sdk/types"
@@ -17,12 +18,78 @@ const (
 	balanceEndpoint            = "/balance"
 	submitTxEndpoint           = "/submit_tx"
 	submitPFBEndpoint          = "/submit_pfb"
+	submitRawPFBEndpoint       = "/submit_raw_pfb"
+	txStatusEndpoint           = "/tx_status"
 	queryDelegationEndpoint    = "/query_delegation"
 	queryUnbondingEndpoint     = "/query_unbonding"
 	queryRedelegationsEndpoint = "/query_redelegations"
 )
 
-const addrKey = "address"
+const (
+	addrKey   = "address"
+	txHashKey = "hash"
+)
+
+var stateSpecs = map[specKey]endpointSpec{
+	{http.MethodGet, balanceEndpoint}: {
+		summary:    "Returns the balance of the node's account",
+		deprecated: true,
+	},
+	{http.MethodGet, fmt.Sprintf("%s/{%s}", balanceEndpoint, addrKey)}: {
+		summary: "Returns the balance of the given account or validator",
+	},
+	{http.MethodPost, submitTxEndpoint}: {
+		summary: "Submits a signed transaction",
+		body:    submitTxSchema,
+	},
+	{http.MethodPost, submitPFBEndpoint}: {
+		summary:    "Submits a PayForBlob transaction signed by the node's account",
+		deprecated: true,
+		body: &schema{
+			Type: "object",
+			Properties: map[string]*schema{
+				"namespace_id": hexSchema,
+				"data":         hexSchema,
+				"fee":          int64Schema,
+				"gas_limit":    uint64Schema,
+			},
+			Required: []string{"namespace_id", "data", "fee", "gas_limit"},
+		},
+	},
+	{http.MethodPost, submitRawPFBEndpoint}: {
+		summary: "Submits a signed PayForBlob transaction",
+		body:    submitTxSchema,
+	},
+	{http.MethodGet, fmt.Sprintf("%s/{%s}", txStatusEndpoint, txHashKey)}: {
+		summary: "Returns the status of a submitted transaction",
+	},
+	{http.MethodGet, fmt.Sprintf("%s/{%s}", queryDelegationEndpoint, addrKey)}: {
+		summary:    "Returns the delegation of the node's account to the given validator",
+		deprecated: true,
+	},
+	{http.MethodGet, fmt.Sprintf("%s/{%s}", queryUnbondingEndpoint, addrKey)}: {
+		summary:    "Returns the unbonding delegation of the node's account from the given validator",
+		deprecated: true,
+	},
+	{http.MethodPost, queryRedelegationsEndpoint}: {
+		summary:    "Returns the redelegations of the node's account between the given validators",
+		deprecated: true,
+		body: &schema{
+			Type: "object",
+			Properties: map[string]*schema{
+				"from": pathParams[addrKey],
+				"to":   pathParams[addrKey],
+			},
+			Required: []string{"from", "to"},
+		},
+	},
+}
+
+var submitTxSchema = &schema{
+	Type:       "object",
+	Properties: map[string]*schema{"tx": hexSchema},
+	Required:   []string{"tx"},
+}
 
 var (
 	ErrInvalidAddressFormat = errors.New("address must be a valid account or validator address")
@@ -120,6 +187,62 @@ func (h *Handler) handleSubmitTx(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (h *Handler) handleSubmitRawPFB(w http.ResponseWriter, r *http.Request) {
+	// decode request
+	var req submitTxRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, submitRawPFBEndpoint, err)
+		return
+	}
+	rawTx, err := hex.DecodeString(req.Tx)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, submitRawPFBEndpoint, err)
+		return
+	}
+	// perform request
+	txResp, err := h.state.SubmitRawTx(r.Context(), rawTx)
+	if err != nil {
+		if errors.Is(err, state.ErrNotBlobTx) {
+			writeError(w, http.StatusBadRequest, submitRawPFBEndpoint, err)
+			return
+		}
+		writeError(w, http.StatusInternalServerError, submitRawPFBEndpoint, err)
+		return
+	}
+	resp, err := json.Marshal(txResp)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, submitRawPFBEndpoint, err)
+		return
+	}
+	_, err = w.Write(resp)
+	if err != nil {
+		log.Errorw("writing response", "endpoint", submitRawPFBEndpoint, "err", err)
+	}
+}
+
+func (h *Handler) handleTxStatus(w http.ResponseWriter, r *http.Request) {
+	hash := mux.Vars(r)[txHashKey]
+	if _, err := hex.DecodeString(hash); err != nil {
+		writeError(w, http.StatusBadRequest, txStatusEndpoint, err)
+		return
+	}
+	status, err := h.state.TxStatus(r.Context(), hash)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, txStatusEndpoint, err)
+		return
+	}
+	resp, err := json.Marshal(status)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, txStatusEndpoint, err)
+		return
+	}
+	_, err = w.Write(resp)
+	if err != nil {
+		log.Errorw("writing response", "endpoint", txStatusEndpoint, "err", err)
+	}
+}
+
 func (h *Handler) handleSubmitPFB(w http.ResponseWriter, r *http.Request) {
 	logDeprecation(submitPFBEndpoint, "blob.Submit or state.SubmitPayForBlob")
 	// decode request
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/require"
 
 	stateMock "github.com/celestiaorg/celestia-node/nodebuilder/state/mocks"
@@ -55,3 +56,37 @@ func TestHandleSubmitPFB(t *testing.T) {
 		require.Equal(t, resp, txResponse)
 	})
 }
+
+func TestHandleSubmitRawPFB(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mock := stateMock.NewMockModule(ctrl)
+	handler := NewHandler(mock, nil, nil, nil)
+
+	t.Run("not a blob tx", func(t *testing.T) {
+		mock.EXPECT().SubmitRawTx(gomock.Any(), gomock.Any()).Return(nil, state.ErrNotBlobTx)
+
+		bs, err := json.Marshal(submitTxRequest{Tx: "DEADBEEF"})
+		require.NoError(t, err)
+		httpreq := httptest.NewRequest(http.MethodPost, submitRawPFBEndpoint, bytes.NewReader(bs))
+		respRec := httptest.NewRecorder()
+		handler.handleSubmitRawPFB(respRec, httpreq)
+
+		require.Equal(t, http.StatusBadRequest, respRec.Code)
+	})
+
+	t.Run("status", func(t *testing.T) {
+		status := &state.TxStatus{Status: state.TxPending}
+		mock.EXPECT().TxStatus(gomock.Any(), "DEADBEEF").Return(status, nil)
+
+		httpreq := httptest.NewRequest(http.MethodGet, txStatusEndpoint+"/DEADBEEF", nil)
+		httpreq = mux.SetURLVars(httpreq, map[string]string{txHashKey: "DEADBEEF"})
+		respRec := httptest.NewRecorder()
+		handler.handleTxStatus(respRec, httpreq)
+
+		var resp state.TxStatus
+		err := json.NewDecoder(respRec.Body).Decode(&resp)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, respRec.Code)
+		require.Equal(t, *status, resp)
+	})
+}
@@ -0,0 +1,131 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+const (
+	subscribeHeadersEndpoint = "/subscribe/headers"
+	subscribeSharesEndpoint  = "/subscribe/shares"
+)
+
+var subscribeSpecs = map[specKey]endpointSpec{
+	{http.MethodGet, subscribeHeadersEndpoint}: {
+		summary: "Streams new headers over a WebSocket",
+	},
+	{http.MethodGet, fmt.Sprintf("%s/{%s}", subscribeSharesEndpoint, namespaceKey)}: {
+		summary: "Streams the shares of the namespace in new blocks over a WebSocket",
+	},
+}
+
+// subscriptionWriteTimeout bounds the time to send a message to a subscriber, so that stalled
+// subscribers are disconnected.
+const subscriptionWriteTimeout = 10 * time.Second
+
+func (h *Handler) handleSubscribeHeaders(w http.ResponseWriter, r *http.Request) {
+	h.serveSubscription(w, r, subscribeHeadersEndpoint,
+		func(_ context.Context, eh *header.ExtendedHeader) (any, error) {
+			return eh, nil
+		})
+}
+
+func (h *Handler) handleSubscribeShares(w http.ResponseWriter, r *http.Request) {
+	_, namespace, err := parseGetByNamespaceArgs(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, subscribeSharesEndpoint, err)
+		return
+	}
+	h.serveSubscription(w, r, subscribeSharesEndpoint,
+		func(ctx context.Context, eh *header.ExtendedHeader) (any, error) {
+			shares, err := h.share.GetSharesByNamespace(ctx, eh.DAH, namespace)
+			if err != nil {
+				return nil, fmt.Errorf("getting shares at height %d: %w", eh.Height(), err)
+			}
+			return &NamespacedSharesResponse{
+				Shares: shares.Flatten(),
+				Height: uint64(eh.Height()),
+			}, nil
+		})
+}
+
+// serveSubscription upgrades the request to a WebSocket connection and sends the message made out
+// of every new header as JSON, until the subscriber disconnects.
+func (h *Handler) serveSubscription(
+	w http.ResponseWriter,
+	r *http.Request,
+	endpoint string,
+	message func(context.Context, *header.ExtendedHeader) (any, error),
+) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader has already responded with an error
+		log.Debugw("upgrading to websocket", "endpoint", endpoint, "err", err)
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	// subscribers are not expected to send anything, but control frames have to be read and
+	// reading fails once the subscriber disconnects
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	headers, err := h.header.Subscribe(ctx)
+	if err != nil {
+		closeSubscription(conn, endpoint, err)
+		return
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case eh, ok := <-headers:
+			if !ok {
+				closeSubscription(conn, endpoint, nil)
+				return
+			}
+			msg, err := message(ctx, eh)
+			if err != nil {
+				if ctx.Err() == nil {
+					closeSubscription(conn, endpoint, err)
+				}
+				return
+			}
+			err = conn.SetWriteDeadline(time.Now().Add(subscriptionWriteTimeout))
+			if err == nil {
+				err = conn.WriteJSON(msg)
+			}
+			if err != nil {
+				log.Debugw("writing to subscriber", "endpoint", endpoint, "err", err)
+				return
+			}
+		}
+	}
+}
+
+// closeSubscription tells the subscriber the subscription ended, with the error if any.
+func closeSubscription(conn *websocket.Conn, endpoint string, err error) {
+	code, reason := websocket.CloseNormalClosure, ""
+	if err != nil {
+		log.Errorw("serving subscription", "endpoint", endpoint, "err", err)
+		code, reason = websocket.CloseInternalServerErr, err.Error()
+	}
+	msg := websocket.FormatCloseMessage(code, reason)
+	if err := conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(subscriptionWriteTimeout)); err != nil {
+		log.Debugw("closing subscription", "endpoint", endpoint, "err", err)
+	}
+}
@@ -0,0 +1,92 @@
+package gateway
+
+import (
+	"encoding/hex"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	headerMock "github.com/celestiaorg/celestia-node/nodebuilder/header/mocks"
+	shareMock "github.com/celestiaorg/celestia-node/nodebuilder/share/mocks"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/sharetest"
+)
+
+func TestSubscriptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	headerMod := headerMock.NewMockModule(ctrl)
+	shareMod := shareMock.NewMockModule(ctrl)
+
+	server := NewServer(address, port)
+	handler := NewHandler(nil, shareMod, headerMod, nil)
+	handler.RegisterEndpoints(server, false)
+	handler.RegisterMiddleware(server)
+	httpSrv := httptest.NewServer(server)
+	t.Cleanup(httpSrv.Close)
+	wsURL := "ws" + strings.TrimPrefix(httpSrv.URL, "http")
+
+	headers := headertest.NewTestSuite(t, 3).GenExtendedHeaders(2)
+	subscribe := func() {
+		headerMod.EXPECT().Subscribe(gomock.Any()).DoAndReturn(
+			func(any) (<-chan *header.ExtendedHeader, error) {
+				ch := make(chan *header.ExtendedHeader, len(headers))
+				for _, eh := range headers {
+					ch <- eh
+				}
+				close(ch)
+				return ch, nil
+			})
+	}
+
+	t.Run("headers", func(t *testing.T) {
+		subscribe()
+		conn, _, err := websocket.DefaultDialer.Dial(wsURL+subscribeHeadersEndpoint, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		for _, expected := range headers {
+			var eh header.ExtendedHeader
+			require.NoError(t, conn.ReadJSON(&eh))
+			assert.Equal(t, expected.Height(), eh.Height())
+			assert.Equal(t, expected.Hash(), eh.Hash())
+		}
+		// the subscription is closed once the headers are over
+		_, _, err = conn.ReadMessage()
+		assert.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure))
+	})
+
+	t.Run("shares", func(t *testing.T) {
+		namespace := sharetest.RandV0Namespace()
+		shr := sharetest.RandShares(t, 1)[0]
+		subscribe()
+		shareMod.EXPECT().GetSharesByNamespace(gomock.Any(), gomock.Any(), namespace).
+			Return(share.NamespacedShares{{Shares: []share.Share{shr}}}, nil).Times(len(headers))
+
+		url := wsURL + subscribeSharesEndpoint + "/" + hex.EncodeToString(namespace)
+		conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		for _, expected := range headers {
+			var resp NamespacedSharesResponse
+			require.NoError(t, conn.ReadJSON(&resp))
+			assert.EqualValues(t, expected.Height(), resp.Height)
+			assert.Equal(t, []share.Share{shr}, resp.Shares)
+		}
+	})
+
+	t.Run("invalid namespace", func(t *testing.T) {
+		_, resp, err := websocket.DefaultDialer.Dial(wsURL+subscribeSharesEndpoint+"/invalid", nil)
+		require.Error(t, err)
+		require.NotNil(t, resp)
+		assert.Equal(t, 400, resp.StatusCode)
+		resp.Body.Close()
+	})
+}
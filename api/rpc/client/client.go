@@ -7,9 +7,11 @@ import (
 
 	"github.com/filecoin-project/go-jsonrpc"
 
+	"github.com/celestiaorg/celestia-node/api/rpc"
 	"github.com/celestiaorg/celestia-node/api/rpc/perms"
 	"github.com/celestiaorg/celestia-node/nodebuilder/blob"
 	"github.com/celestiaorg/celestia-node/nodebuilder/das"
+	"github.com/celestiaorg/celestia-node/nodebuilder/debug"
 	"github.com/celestiaorg/celestia-node/nodebuilder/fraud"
 	"github.com/celestiaorg/celestia-node/nodebuilder/header"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
@@ -33,6 +35,8 @@ type Client struct {
 	P2P    p2p.API
 	Node   node.API
 	Blob   blob.API
+	// Debug is served only by the nodes built with the 'debug' build tag.
+	Debug debug.API
 
 	closer multiClientCloser
 }
@@ -75,7 +79,8 @@ func newClient(ctx context.Context, addr string, authHeader http.Header) (*Clien
 	var multiCloser multiClientCloser
 	var client Client
 	for name, module := range moduleMap(&client) {
-		closer, err := jsonrpc.NewClient(ctx, addr, name, module, authHeader)
+		closer, err := jsonrpc.NewMergeClient(ctx, addr, name, []interface{}{module}, authHeader,
+			jsonrpc.WithErrors(rpc.Errors))
 		if err != nil {
 			return nil, err
 		}
@@ -96,5 +101,6 @@ func moduleMap(client *Client) map[string]interface{} {
 		"p2p":    &client.P2P.Internal,
 		"node":   &client.Node.Internal,
 		"blob":   &client.Blob.Internal,
+		"debug":  &client.Debug.Internal,
 	}
 }
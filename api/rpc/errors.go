@@ -0,0 +1,17 @@
+package rpc
+
+import (
+	"github.com/filecoin-project/go-jsonrpc"
+
+	"github.com/celestiaorg/celestia-node/blob"
+)
+
+// Errors are the errors kept intact over the RPC, along with the data they carry. The server and
+// the clients have to use the same codes.
+var Errors = newErrors()
+
+func newErrors() jsonrpc.Errors {
+	errs := jsonrpc.NewErrors()
+	errs.Register(jsonrpc.FirstUserCode, new(*blob.PartialSubmissionError))
+	return errs
+}
@@ -0,0 +1,92 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+)
+
+// ErrFeatureDisabled is returned by the experimental methods whose feature is not enabled.
+var ErrFeatureDisabled = errors.New("feature is disabled")
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// EnableFeatures enables the experimental methods and services gated by the given features. It
+// must be called before the services are registered.
+//
+// A method of a service's API struct is gated by a feature with the `feature:"<name>"` tag, and
+// marked as deprecated with the `deprecated:"<note>"` tag, logging a warning with the note on its
+// first call.
+func (s *Server) EnableFeatures(features ...string) {
+	if s.features == nil {
+		s.features = make(map[string]bool, len(features))
+	}
+	for _, feature := range features {
+		s.features[feature] = true
+	}
+}
+
+// RegisterExperimentalService registers the service with RegisterAuthedService, if the given
+// feature is enabled.
+func (s *Server) RegisterExperimentalService(feature, namespace string, service interface{}, out interface{}) {
+	if !s.features[feature] {
+		log.Debugw("skipping experimental service", "namespace", namespace, "feature", feature)
+		return
+	}
+	s.RegisterAuthedService(namespace, service, out)
+}
+
+// gateMethods replaces the methods of the API struct gated by disabled features with stubs
+// returning ErrFeatureDisabled and wraps the deprecated ones to warn on their first call.
+func (s *Server) gateMethods(namespace string, out interface{}) {
+	internal := reflect.ValueOf(out).Elem().FieldByName("Internal")
+	for i := 0; i < internal.NumField(); i++ {
+		field, fn := internal.Type().Field(i), internal.Field(i)
+		if fn.Kind() != reflect.Func || fn.IsNil() {
+			continue
+		}
+		method := namespace + "." + field.Name
+
+		if feature := field.Tag.Get("feature"); feature != "" && !s.features[feature] {
+			fn.Set(disabledMethod(method, feature, fn.Type()))
+			continue
+		}
+		if note, ok := field.Tag.Lookup("deprecated"); ok {
+			fn.Set(deprecatedMethod(method, note, fn))
+		}
+	}
+}
+
+// disabledMethod returns a method of the given type that fails with ErrFeatureDisabled.
+func disabledMethod(method, feature string, tp reflect.Type) reflect.Value {
+	if tp.NumOut() == 0 || tp.Out(tp.NumOut()-1) != errorType {
+		panic(fmt.Sprintf("rpc: method %s gated by feature %q must return an error", method, feature))
+	}
+
+	err := fmt.Errorf("%w: %s requires the %q feature to be enabled", ErrFeatureDisabled, method, feature)
+	return reflect.MakeFunc(tp, func([]reflect.Value) []reflect.Value {
+		outs := make([]reflect.Value, tp.NumOut())
+		for i := range outs[:len(outs)-1] {
+			outs[i] = reflect.Zero(tp.Out(i))
+		}
+		outs[len(outs)-1] = reflect.ValueOf(&err).Elem()
+		return outs
+	})
+}
+
+// deprecatedMethod wraps the method to log a warning with the deprecation note on its first call.
+func deprecatedMethod(method, note string, fn reflect.Value) reflect.Value {
+	// copy the method out of the struct field it is about to replace
+	fn = reflect.ValueOf(fn.Interface())
+	var warned atomic.Bool
+	return reflect.MakeFunc(fn.Type(), func(args []reflect.Value) []reflect.Value {
+		if !warned.Swap(true) {
+			log.Warnw("deprecated RPC method called", "method", method, "note", note)
+		}
+		if fn.Type().IsVariadic() {
+			return fn.CallSlice(args)
+		}
+		return fn.Call(args)
+	})
+}
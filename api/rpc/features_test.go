@@ -0,0 +1,49 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testAPI struct {
+	Internal struct {
+		Stable       func(context.Context) (int, error) `perm:"read"`
+		Experimental func(context.Context) (int, error) `perm:"read" feature:"experiment"`
+		Deprecated   func(context.Context) (int, error) `perm:"read" deprecated:"use Stable instead"`
+	}
+}
+
+func TestGateMethods(t *testing.T) {
+	newAPI := func() *testAPI {
+		api := &testAPI{}
+		api.Internal.Stable = func(context.Context) (int, error) { return 1, nil }
+		api.Internal.Experimental = func(context.Context) (int, error) { return 2, nil }
+		api.Internal.Deprecated = func(context.Context) (int, error) { return 3, nil }
+		return api
+	}
+	ctx := context.Background()
+
+	srv := &Server{}
+	api := newAPI()
+	srv.gateMethods("test", api)
+
+	out, err := api.Internal.Stable(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, out)
+	_, err = api.Internal.Experimental(ctx)
+	assert.ErrorIs(t, err, ErrFeatureDisabled)
+	out, err = api.Internal.Deprecated(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, out)
+
+	srv.EnableFeatures("experiment")
+	api = newAPI()
+	srv.gateMethods("test", api)
+
+	out, err = api.Internal.Experimental(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, out)
+}
@@ -5,6 +5,7 @@ import (
 	"net"
 	"net/http"
 	"reflect"
+	"strings"
 	"sync/atomic"
 	"time"
 
@@ -12,25 +13,51 @@ import (
 	"github.com/filecoin-project/go-jsonrpc"
 	"github.com/filecoin-project/go-jsonrpc/auth"
 	logging "github.com/ipfs/go-log/v2"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/celestiaorg/celestia-node/api/rpc/perms"
 	"github.com/celestiaorg/celestia-node/libs/authtoken"
+	"github.com/celestiaorg/celestia-node/libs/handover"
+	"github.com/celestiaorg/celestia-node/state"
 )
 
 var log = logging.Logger("rpc")
 
+// CoreMetadataHeaderPrefix prefixes the HTTP headers of requests carrying gRPC metadata, e.g.
+// credentials, for the node to forward to core, e.g. "Core-Authorization: Bearer <token>". The node
+// forwards only the keys allowed by its config.
+const CoreMetadataHeaderPrefix = "Core-"
+
+// ReadinessEndpoint is the path the readiness probe of the node is served on, if set. See
+// ServeReadiness.
+const ReadinessEndpoint = "/readyz"
+
 type Server struct {
 	srv      *http.Server
 	rpc      *jsonrpc.RPCServer
 	listener net.Listener
+	// listeners keep the listener for the handover to the next node process, if set
+	listeners *handover.Listeners
 
 	started atomic.Bool
 
 	auth jwt.Signer
+	// features are the enabled features gating experimental methods and services
+	features map[string]bool
+	// streamed are the methods served by writing their results directly to the response
+	streamed map[string]streamedMethod
+	// readiness serves the readiness probe, if set
+	readiness http.HandlerFunc
+	// observeLatency is called with the time taken to serve each request, if set
+	observeLatency func(time.Duration)
+	// maxConcurrent is the amount of requests served at once, or zero for no limit
+	maxConcurrent atomic.Int64
+	// inFlight is the amount of requests being served
+	inFlight atomic.Int64
 }
 
 func NewServer(address, port string, secret jwt.Signer) *Server {
-	rpc := jsonrpc.NewServer()
+	rpc := jsonrpc.NewServer(jsonrpc.WithServerErrors(Errors))
 	srv := &Server{
 		rpc: rpc,
 		srv: &http.Server{
@@ -42,11 +69,107 @@ func NewServer(address, port string, secret jwt.Signer) *Server {
 	}
 	srv.srv.Handler = &auth.Handler{
 		Verify: srv.verifyAuth,
-		Next:   rpc.ServeHTTP,
+		Next: srv.withReadiness(
+			srv.withConcurrencyLimit(srv.withLatency(withCoreMetadata(srv.withStreamedMethods(rpc.ServeHTTP)))),
+		),
 	}
 	return srv
 }
 
+// WithListeners makes the Server listen through the given Listeners, inheriting the listener of
+// the previous node process, if any, and handing its own over to the next one. It must be called
+// before the Server is started.
+func (s *Server) WithListeners(listeners *handover.Listeners) {
+	s.listeners = listeners
+}
+
+// ObserveLatency registers the function to be called with the time taken to serve each request.
+// It must be called before the Server is started.
+func (s *Server) ObserveLatency(observe func(time.Duration)) {
+	s.observeLatency = observe
+}
+
+// ServeReadiness registers the handler of the readiness probe served on ReadinessEndpoint, e.g.
+// for load balancers to route requests only to ready nodes. It must be called before the Server is
+// started.
+func (s *Server) ServeReadiness(handler http.HandlerFunc) {
+	s.readiness = handler
+}
+
+// withReadiness serves the readiness probe, without limiting it along with the RPC requests.
+func (s *Server) withReadiness(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.readiness != nil && r.Method == http.MethodGet && r.URL.Path == ReadinessEndpoint {
+			s.readiness(w, r)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// withLatency measures the time taken to serve requests. WebSocket connections are skipped, as they
+// live as long as the client.
+func (s *Server) withLatency(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.observeLatency == nil || strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+			next(w, r)
+			return
+		}
+		start := time.Now()
+		next(w, r)
+		s.observeLatency(time.Since(start))
+	}
+}
+
+// SetMaxConcurrentRequests limits the amount of requests served at once, rejecting the requests
+// over the limit with 429 Too Many Requests. WebSocket connections are not limited, as they live
+// as long as the client. Zero disables the limit. It can be called on a running Server.
+func (s *Server) SetMaxConcurrentRequests(limit int) {
+	s.maxConcurrent.Store(int64(limit))
+}
+
+// MaxConcurrentRequests returns the amount of requests served at once, or zero if not limited.
+func (s *Server) MaxConcurrentRequests() int {
+	return int(s.maxConcurrent.Load())
+}
+
+// withConcurrencyLimit rejects requests over the limit of requests served at once.
+func (s *Server) withConcurrencyLimit(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+			next(w, r)
+			return
+		}
+		// requests are counted regardless of the limit, so that it holds once set on the fly
+		inFlight := s.inFlight.Add(1)
+		defer s.inFlight.Add(-1)
+		if limit := s.maxConcurrent.Load(); limit > 0 && inFlight > limit {
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, "too many concurrent requests", http.StatusTooManyRequests)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// withCoreMetadata attaches the metadata in the headers prefixed with CoreMetadataHeaderPrefix to
+// the context of the request.
+func withCoreMetadata(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		md := metadata.MD{}
+		for name, vals := range r.Header {
+			// header names are canonicalized, so the prefix matches regardless of the case it is sent in
+			if key, ok := strings.CutPrefix(name, CoreMetadataHeaderPrefix); ok && key != "" {
+				md.Append(key, vals...)
+			}
+		}
+		if md.Len() > 0 {
+			r = r.WithContext(state.WithCallerMetadata(r.Context(), md))
+		}
+		next(w, r)
+	}
+}
+
 // verifyAuth is the RPC server's auth middleware. This middleware is only
 // reached if a token is provided in the header of the request, otherwise only
 // methods with `read` permissions are accessible.
@@ -61,9 +184,10 @@ func (s *Server) RegisterService(namespace string, service interface{}) {
 }
 
 // RegisterAuthedService registers a service onto the RPC server. All methods on the service will
-// then be exposed over the RPC.
+// then be exposed over the RPC, except for the ones gated by disabled features. See EnableFeatures.
 func (s *Server) RegisterAuthedService(namespace string, service interface{}, out interface{}) {
 	auth.PermissionedProxy(perms.AllPerms, perms.DefaultPerms, service, getInternalStruct(out))
+	s.gateMethods(namespace, out)
 	s.RegisterService(namespace, out)
 }
 
@@ -78,7 +202,9 @@ func (s *Server) Start(context.Context) error {
 		log.Warn("cannot start server: already started")
 		return nil
 	}
-	listener, err := net.Listen("tcp", s.srv.Addr)
+	listener, err := s.listeners.Listen("tcp", s.srv.Addr, func() (net.Listener, error) {
+		return net.Listen("tcp", s.srv.Addr)
+	})
 	if err != nil {
 		return err
 	}
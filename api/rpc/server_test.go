@@ -0,0 +1,53 @@
+package rpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cristalhq/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_MaxConcurrentRequests(t *testing.T) {
+	signer, err := jwt.NewHS256(make([]byte, 32))
+	require.NoError(t, err)
+	srv := NewServer("127.0.0.1", "0", signer)
+
+	started, release := make(chan struct{}), make(chan struct{})
+	handler := srv.withConcurrencyLimit(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/block" {
+			started <- struct{}{}
+			<-release
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	serve := func(path string, header http.Header) int {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+		return rec.Code
+	}
+
+	// a request blocks while the limit is not set, and still counts once it is
+	done := make(chan int)
+	go func() { done <- serve("/block", nil) }()
+	<-started
+	srv.SetMaxConcurrentRequests(1)
+	assert.Equal(t, 1, srv.MaxConcurrentRequests())
+
+	assert.Equal(t, http.StatusTooManyRequests, serve("/", nil))
+	// WebSocket connections are not limited
+	assert.Equal(t, http.StatusOK, serve("/", http.Header{"Upgrade": []string{"websocket"}}))
+
+	close(release)
+	assert.Equal(t, http.StatusOK, <-done)
+	assert.Equal(t, http.StatusOK, serve("/", nil))
+
+	srv.SetMaxConcurrentRequests(0)
+	assert.Equal(t, 0, srv.MaxConcurrentRequests())
+}
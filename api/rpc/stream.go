@@ -0,0 +1,182 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/filecoin-project/go-jsonrpc/auth"
+
+	"github.com/celestiaorg/celestia-node/api/rpc/perms"
+)
+
+const (
+	// maxMethodPeekSize bounds the beginning of a request read to find the called method. It is
+	// given back to the requests not calling a streamed method, which are passed on as is.
+	maxMethodPeekSize = 4 << 10
+	// maxStreamedRequestSize bounds the requests calling a streamed method, whose params are small.
+	maxStreamedRequestSize = 1 << 20
+)
+
+// StreamedMethod serves an RPC method whose result is too large to be encoded in memory at once,
+// e.g. an EDS. It returns the function writing the JSON encoded result, so that the errors
+// occurring before anything is written are reported as regular RPC errors.
+type StreamedMethod func(ctx context.Context, params json.RawMessage) (func(io.Writer) error, error)
+
+type streamedMethod struct {
+	perm  auth.Permission
+	serve StreamedMethod
+}
+
+// RegisterStreamedMethod serves HTTP calls of the method, e.g. "share.GetEDS", with the given
+// StreamedMethod instead of the registered service, writing the result directly to the response.
+// Callers must have the given permission. WebSocket calls are still served by the service.
+// It must be called before the Server is started.
+func (s *Server) RegisterStreamedMethod(method string, perm auth.Permission, serve StreamedMethod) {
+	if s.streamed == nil {
+		s.streamed = make(map[string]streamedMethod)
+	}
+	s.streamed[method] = streamedMethod{perm: perm, serve: serve}
+}
+
+// withStreamedMethods serves the calls of the streamed methods, passing on all the other requests.
+func (s *Server) withStreamedMethods(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if len(s.streamed) == 0 || r.Method != http.MethodPost ||
+			strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+			next(w, r)
+			return
+		}
+
+		// the request is decoded as it is read, and only the part read up to the method is kept
+		body := &io.LimitedReader{R: r.Body, N: maxMethodPeekSize}
+		var peeked bytes.Buffer
+		req := &rpcRequest{dec: json.NewDecoder(io.TeeReader(body, &peeked))}
+		method, ok := s.streamed[req.peekMethod()]
+		if !ok {
+			// the part read is given back, so that the request is passed on intact
+			r.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(&peeked, r.Body), r.Body}
+			next(w, r)
+			return
+		}
+
+		body.N += maxStreamedRequestSize - maxMethodPeekSize
+		err := req.decode(false)
+		if len(req.ID) == 0 {
+			req.ID = json.RawMessage("null")
+		}
+		if err != nil {
+			writeStreamedError(w, req.ID, fmt.Errorf("decoding request: %w", err))
+			return
+		}
+		s.serveStreamed(r.Context(), w, req.Method, method, req.ID, req.Params)
+	}
+}
+
+// rpcRequest is a JSON-RPC request decoded field by field from the request body.
+type rpcRequest struct {
+	dec *json.Decoder
+
+	ID     json.RawMessage
+	Method string
+	Params json.RawMessage
+}
+
+// peekMethod decodes the request up to its method and returns it. An empty method is returned for
+// anything but a single request whose method is found early enough, e.g. batches.
+func (req *rpcRequest) peekMethod() string {
+	if tok, err := req.dec.Token(); err != nil || tok != json.Delim('{') {
+		return ""
+	}
+	if err := req.decode(true); err != nil {
+		return ""
+	}
+	return req.Method
+}
+
+// decode decodes the fields of the request object up to its end, or up to the method if
+// untilMethod is set.
+func (req *rpcRequest) decode(untilMethod bool) error {
+	for req.dec.More() {
+		tok, err := req.dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := tok.(string)
+		// the fields are matched case-insensitively, as by the RPC server
+		var field any
+		switch strings.ToLower(key) {
+		case "id":
+			field = &req.ID
+		case "method":
+			field = &req.Method
+		case "params":
+			field = &req.Params
+		default:
+			field = new(json.RawMessage)
+		}
+		if err = req.dec.Decode(field); err != nil {
+			return err
+		}
+		if untilMethod && req.Method != "" {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (s *Server) serveStreamed(
+	ctx context.Context,
+	w http.ResponseWriter,
+	name string,
+	method streamedMethod,
+	id, params json.RawMessage,
+) {
+	w.Header().Set("Content-Type", "application/json")
+	if !auth.HasPerm(ctx, perms.DefaultPerms, method.perm) {
+		writeStreamedError(w, id, fmt.Errorf("missing permission to invoke '%s' (need '%s')", name, method.perm))
+		return
+	}
+	write, err := method.serve(ctx, params)
+	if err != nil {
+		writeStreamedError(w, id, err)
+		return
+	}
+
+	_, err = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":`, id)
+	if err == nil {
+		err = write(w)
+	}
+	if err == nil {
+		_, err = io.WriteString(w, "}\n")
+	}
+	if err != nil {
+		// the response is already partially written, so the client fails to decode it
+		log.Errorw("writing streamed response", "method", name, "err", err)
+	}
+}
+
+// writeStreamedError writes the error response the same way the RPC server does for errors
+// returned by the called method.
+func writeStreamedError(w io.Writer, id json.RawMessage, err error) {
+	resp := struct {
+		Jsonrpc string          `json:"jsonrpc"`
+		ID      json.RawMessage `json:"id"`
+		Error   struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}{Jsonrpc: "2.0", ID: id}
+	resp.Error.Code = 1
+	resp.Error.Message = err.Error()
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Errorw("writing streamed error response", "err", err)
+	}
+}
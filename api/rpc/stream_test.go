@@ -0,0 +1,105 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cristalhq/jwt"
+	"github.com/filecoin-project/go-jsonrpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type echoAPI struct {
+	Internal struct {
+		Echo  func(ctx context.Context, msg string) (string, error) `perm:"public"`
+		Admin func(ctx context.Context, msg string) (string, error) `perm:"admin"`
+	}
+}
+
+func (api *echoAPI) Echo(ctx context.Context, msg string) (string, error) {
+	return api.Internal.Echo(ctx, msg)
+}
+
+func (api *echoAPI) Admin(ctx context.Context, msg string) (string, error) {
+	return api.Internal.Admin(ctx, msg)
+}
+
+type echoService struct{}
+
+func (echoService) Echo(_ context.Context, msg string) (string, error) {
+	return msg, nil
+}
+
+func (echoService) Admin(_ context.Context, msg string) (string, error) {
+	return msg, nil
+}
+
+func TestStreamedMethod(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	signer, err := jwt.NewHS256(make([]byte, 32))
+	require.NoError(t, err)
+	srv := NewServer("127.0.0.1", "0", signer)
+	srv.RegisterAuthedService("test", echoService{}, &echoAPI{})
+
+	stream := func(_ context.Context, params json.RawMessage) (func(io.Writer) error, error) {
+		var args []string
+		if err := json.Unmarshal(params, &args); err != nil {
+			return nil, err
+		}
+		if args[0] == "fail" {
+			return nil, errors.New("failed")
+		}
+		return func(w io.Writer) error {
+			_, err := fmt.Fprintf(w, `"streamed %s"`, args[0])
+			return err
+		}, nil
+	}
+	srv.RegisterStreamedMethod("test.Echo", "public", stream)
+	srv.RegisterStreamedMethod("test.Admin", "admin", stream)
+
+	require.NoError(t, srv.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, srv.Stop(ctx))
+	})
+
+	var client echoAPI
+	closer, err := jsonrpc.NewClient(ctx, "http://"+srv.ListenAddr(), "test", &client.Internal, nil)
+	require.NoError(t, err)
+	t.Cleanup(closer)
+
+	out, err := client.Internal.Echo(ctx, "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "streamed hello", out)
+
+	_, err = client.Internal.Echo(ctx, "fail")
+	require.ErrorContains(t, err, "failed")
+
+	_, err = client.Internal.Admin(ctx, "hello")
+	require.ErrorContains(t, err, "missing permission")
+
+	// the params of streamed methods may exceed the part read to find the method
+	large := strings.Repeat("a", maxMethodPeekSize*2)
+	out, err = client.Internal.Echo(ctx, large)
+	require.NoError(t, err)
+	assert.Equal(t, "streamed "+large, out)
+
+	// requests whose method is not found early enough are passed on intact
+	body := fmt.Sprintf(`{"params":[%q],"jsonrpc":"2.0","id":1,"method":"test.Echo"}`, large)
+	resp, err := http.Post("http://"+srv.ListenAddr(), "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var result struct {
+		Result string `json:"result"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	assert.Equal(t, large, result.Result)
+}
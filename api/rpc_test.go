@@ -18,6 +18,7 @@ import (
 	"github.com/celestiaorg/celestia-node/api/rpc"
 	"github.com/celestiaorg/celestia-node/api/rpc/client"
 	"github.com/celestiaorg/celestia-node/api/rpc/perms"
+	blobpkg "github.com/celestiaorg/celestia-node/blob"
 	daspkg "github.com/celestiaorg/celestia-node/das"
 	headerpkg "github.com/celestiaorg/celestia-node/header"
 	"github.com/celestiaorg/celestia-node/nodebuilder"
@@ -25,6 +26,8 @@ import (
 	blobMock "github.com/celestiaorg/celestia-node/nodebuilder/blob/mocks"
 	"github.com/celestiaorg/celestia-node/nodebuilder/das"
 	dasMock "github.com/celestiaorg/celestia-node/nodebuilder/das/mocks"
+	"github.com/celestiaorg/celestia-node/nodebuilder/debug"
+	debugMock "github.com/celestiaorg/celestia-node/nodebuilder/debug/mocks"
 	"github.com/celestiaorg/celestia-node/nodebuilder/fraud"
 	fraudMock "github.com/celestiaorg/celestia-node/nodebuilder/fraud/mocks"
 	"github.com/celestiaorg/celestia-node/nodebuilder/header"
@@ -71,6 +74,16 @@ func TestRPCCallsUnderlyingNode(t *testing.T) {
 	balance, err := rpcClient.State.Balance(ctx)
 	require.NoError(t, err)
 	require.Equal(t, expectedBalance, balance)
+
+	// registered errors keep their data over the RPC
+	expectedErr := &blobpkg.PartialSubmissionError{Heights: []uint64{0, 10}, Reason: "out of funds"}
+	server.Blob.EXPECT().SubmitAll(gomock.Any(), gomock.Any()).Return(expectedErr.Heights, expectedErr)
+
+	_, err = rpcClient.Blob.SubmitAll(ctx, nil)
+	var partialErr *blobpkg.PartialSubmissionError
+	require.ErrorAs(t, err, &partialErr)
+	require.Equal(t, expectedErr.Heights, partialErr.Heights)
+	require.Equal(t, expectedErr.Error(), partialErr.Error())
 }
 
 // api contains all modules that are made available as the node's
@@ -84,6 +97,7 @@ type api struct {
 	Node   node.Module
 	P2P    p2p.Module
 	Blob   blob.Module
+	Debug  debug.Module
 }
 
 func TestModulesImplementFullAPI(t *testing.T) {
@@ -208,6 +222,12 @@ func TestAuthedRPC(t *testing.T) {
 				require.ErrorContains(t, err, "missing permission")
 			}
 
+			// 5. Test experimental method, not served unless its feature is enabled
+			if tt.perm > 3 {
+				_, err := rpcClient.Blob.Backfill(ctx, nil, 1, 1)
+				require.ErrorContains(t, err, rpc.ErrFeatureDisabled.Error())
+			}
+
 			rpcClient.Close()
 		})
 	}
@@ -340,6 +360,7 @@ func setupNodeWithModifiedRPC(t *testing.T) (*nodebuilder.Node, *mockAPI) {
 		p2pMock.NewMockModule(ctrl),
 		nodeMock.NewMockModule(ctrl),
 		blobMock.NewMockModule(ctrl),
+		debugMock.NewMockModule(ctrl),
 	}
 
 	// given the behavior of fx.Invoke, this invoke will be called last as it is added at the root
@@ -382,6 +403,7 @@ func setupNodeWithAuthedRPC(t *testing.T, auth jwt.Signer) (*nodebuilder.Node, *
 		p2pMock.NewMockModule(ctrl),
 		nodeMock.NewMockModule(ctrl),
 		blobMock.NewMockModule(ctrl),
+		debugMock.NewMockModule(ctrl),
 	}
 
 	// given the behavior of fx.Invoke, this invoke will be called last as it is added at the root
@@ -395,6 +417,7 @@ func setupNodeWithAuthedRPC(t *testing.T, auth jwt.Signer) (*nodebuilder.Node, *
 		srv.RegisterAuthedService("p2p", mockAPI.P2P, &p2p.API{})
 		srv.RegisterAuthedService("node", mockAPI.Node, &node.API{})
 		srv.RegisterAuthedService("blob", mockAPI.Blob, &blob.API{})
+		srv.RegisterAuthedService("debug", mockAPI.Debug, &debug.API{})
 	})
 	// fx.Replace does not work here, but fx.Decorate does
 	nd := nodebuilder.TestNode(t, node.Full, invokeRPC, fx.Decorate(func() (jwt.Signer, error) {
@@ -419,4 +442,5 @@ type mockAPI struct {
 	P2P    *p2pMock.MockModule
 	Node   *nodeMock.MockModule
 	Blob   *blobMock.MockModule
+	Debug  *debugMock.MockModule
 }
@@ -0,0 +1,443 @@
+package blob
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	"github.com/ipfs/go-datastore/query"
+	"golang.org/x/time/rate"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+var (
+	// ErrBackfillDisabled is returned by the backfill methods of a Service without a Backfiller.
+	ErrBackfillDisabled = errors.New("blob: backfill is disabled")
+	// ErrBackfillNotFound is returned for namespaces that were never backfilled.
+	ErrBackfillNotFound = errors.New("blob: backfill not found")
+	// ErrBackfillRunning is returned when starting a backfill of a namespace that is already being
+	// backfilled.
+	ErrBackfillRunning = errors.New("blob: backfill is already running")
+
+	backfillPrefix = datastore.NewKey("blob_backfill")
+	jobsPrefix     = datastore.NewKey("jobs")
+	indexPrefix    = datastore.NewKey("index")
+)
+
+// BackfillState is the state of the backfill of a namespace.
+type BackfillState string
+
+const (
+	BackfillRunning BackfillState = "running"
+	BackfillPaused  BackfillState = "paused"
+	BackfillDone    BackfillState = "done"
+	BackfillFailed  BackfillState = "failed"
+)
+
+// BackfillStatus is the progress of the backfill of a namespace.
+type BackfillStatus struct {
+	Namespace  share.Namespace `json:"namespace"`
+	FromHeight uint64          `json:"from_height"`
+	ToHeight   uint64          `json:"to_height"`
+	// NextHeight is the height to be fetched next. The heights below it are indexed.
+	NextHeight uint64 `json:"next_height"`
+	// Blobs is the amount of blobs indexed so far.
+	Blobs uint64        `json:"blobs"`
+	State BackfillState `json:"state"`
+	// Error is the error the backfill failed with, if any. Failed backfills can be resumed.
+	Error string `json:"error,omitempty"`
+}
+
+type backfillJob struct {
+	// status is guarded by the lock of the Backfiller
+	status BackfillStatus
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Backfiller fetches the blobs of namespaces over ranges of historical heights in the background
+// and indexes them locally, so that they are served without being retrieved again. The progress is
+// persisted, so that backfills are resumed after restarts. The rate of fetched heights is limited
+// over all the backfills.
+type Backfiller struct {
+	service *Service
+	ds      datastore.Batching
+	limiter *rate.Limiter
+
+	lock sync.Mutex
+	jobs map[string]*backfillJob
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewBackfiller creates a Backfiller fetching the blobs with the Service, at most heightsPerSecond
+// heights per second, and sets it to the Service.
+func NewBackfiller(service *Service, ds datastore.Batching, heightsPerSecond float64) *Backfiller {
+	b := &Backfiller{
+		service: service,
+		ds:      namespace.Wrap(ds, backfillPrefix),
+		limiter: rate.NewLimiter(rate.Limit(heightsPerSecond), 1),
+		jobs:    make(map[string]*backfillJob),
+	}
+	service.backfiller = b
+	return b
+}
+
+// Start resumes the backfills that were running before the node stopped.
+func (b *Backfiller) Start(ctx context.Context) error {
+	b.ctx, b.cancel = context.WithCancel(context.Background())
+
+	res, err := b.ds.Query(ctx, query.Query{Prefix: jobsPrefix.String()})
+	if err != nil {
+		return fmt.Errorf("blob: loading backfills: %w", err)
+	}
+	entries, err := res.Rest()
+	if err != nil {
+		return fmt.Errorf("blob: loading backfills: %w", err)
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for _, entry := range entries {
+		job := &backfillJob{}
+		if err := json.Unmarshal(entry.Value, &job.status); err != nil {
+			return fmt.Errorf("blob: unmarshalling backfill: %w", err)
+		}
+		b.jobs[job.status.Namespace.String()] = job
+		if job.status.State == BackfillRunning {
+			log.Infow("resuming backfill", "namespace", job.status.Namespace.String(),
+				"from", job.status.NextHeight, "to", job.status.ToHeight)
+			b.start(job)
+		}
+	}
+	return nil
+}
+
+// Stop stops the running backfills, which are resumed on the next Start.
+func (b *Backfiller) Stop(ctx context.Context) error {
+	b.cancel()
+
+	b.lock.Lock()
+	var running []chan struct{}
+	for _, job := range b.jobs {
+		if job.done != nil {
+			running = append(running, job.done)
+		}
+	}
+	b.lock.Unlock()
+
+	for _, done := range running {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// Backfill starts the backfill of the blobs under the namespace between the given heights,
+// inclusive, replacing the previous backfill of the namespace, if any.
+func (b *Backfiller) Backfill(
+	ctx context.Context,
+	namespace share.Namespace,
+	fromHeight, toHeight uint64,
+) (*BackfillStatus, error) {
+	if err := namespace.ValidateForBlob(); err != nil {
+		return nil, err
+	}
+	if fromHeight == 0 || toHeight < fromHeight {
+		return nil, fmt.Errorf("blob: invalid backfill range [%d, %d]", fromHeight, toHeight)
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if job, ok := b.jobs[namespace.String()]; ok {
+		if job.done != nil {
+			return nil, ErrBackfillRunning
+		}
+		// the blobs indexed by the replaced backfill may be out of the new range
+		if err := b.clearIndex(ctx, namespace); err != nil {
+			return nil, err
+		}
+	}
+
+	job := &backfillJob{
+		status: BackfillStatus{
+			Namespace:  namespace,
+			FromHeight: fromHeight,
+			ToHeight:   toHeight,
+			NextHeight: fromHeight,
+			State:      BackfillRunning,
+		},
+	}
+	if err := b.persist(ctx, job.status); err != nil {
+		return nil, err
+	}
+	b.jobs[namespace.String()] = job
+	b.start(job)
+	status := job.status
+	return &status, nil
+}
+
+// Pause pauses the backfill of the namespace, keeping its progress.
+func (b *Backfiller) Pause(ctx context.Context, namespace share.Namespace) error {
+	b.lock.Lock()
+	job, ok := b.jobs[namespace.String()]
+	if !ok {
+		b.lock.Unlock()
+		return ErrBackfillNotFound
+	}
+	if job.done == nil {
+		b.lock.Unlock()
+		return nil
+	}
+	job.cancel()
+	done := job.done
+	b.lock.Unlock()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	// the backfill may have completed meanwhile
+	if job.status.State == BackfillRunning {
+		job.status.State = BackfillPaused
+	}
+	return b.persist(ctx, job.status)
+}
+
+// Resume resumes the paused or failed backfill of the namespace from where it stopped.
+func (b *Backfiller) Resume(ctx context.Context, namespace share.Namespace) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	job, ok := b.jobs[namespace.String()]
+	if !ok {
+		return ErrBackfillNotFound
+	}
+	if job.done != nil || job.status.State == BackfillDone {
+		return nil
+	}
+
+	job.status.State = BackfillRunning
+	job.status.Error = ""
+	if err := b.persist(ctx, job.status); err != nil {
+		return err
+	}
+	b.start(job)
+	return nil
+}
+
+// Remove stops the backfill of the namespace, if running, and removes it along with the blobs it
+// indexed.
+func (b *Backfiller) Remove(ctx context.Context, namespace share.Namespace) error {
+	if err := b.Pause(ctx, namespace); err != nil {
+		return err
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	job, ok := b.jobs[namespace.String()]
+	if !ok {
+		return ErrBackfillNotFound
+	}
+	if job.done != nil {
+		// resumed meanwhile
+		return ErrBackfillRunning
+	}
+	if err := b.clearIndex(ctx, namespace); err != nil {
+		return err
+	}
+	if err := b.ds.Delete(ctx, jobKey(namespace)); err != nil {
+		return fmt.Errorf("blob: removing backfill: %w", err)
+	}
+	delete(b.jobs, namespace.String())
+	return nil
+}
+
+// Status reports the progress of the backfill of the namespace.
+func (b *Backfiller) Status(_ context.Context, namespace share.Namespace) (*BackfillStatus, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	job, ok := b.jobs[namespace.String()]
+	if !ok {
+		return nil, ErrBackfillNotFound
+	}
+	status := job.status
+	return &status, nil
+}
+
+// indexed returns the blobs under the namespace at the given height, if they were backfilled.
+func (b *Backfiller) indexed(ctx context.Context, namespace share.Namespace, height uint64) ([]*Blob, bool) {
+	data, err := b.ds.Get(ctx, indexKey(namespace, height))
+	if err != nil {
+		if !errors.Is(err, datastore.ErrNotFound) {
+			log.Warnw("reading backfilled blobs", "height", height, "namespace", namespace.String(), "err", err)
+		}
+		return nil, false
+	}
+	var blobs []*Blob
+	if err := json.Unmarshal(data, &blobs); err != nil {
+		log.Warnw("unmarshalling backfilled blobs", "height", height, "namespace", namespace.String(), "err", err)
+		return nil, false
+	}
+	return blobs, true
+}
+
+// start runs the backfill in the background. It must be called with the lock held.
+func (b *Backfiller) start(job *backfillJob) {
+	ctx, cancel := context.WithCancel(b.ctx)
+	job.cancel = cancel
+	job.done = make(chan struct{})
+	go b.run(ctx, job)
+}
+
+func (b *Backfiller) run(ctx context.Context, job *backfillJob) {
+	defer func() {
+		b.lock.Lock()
+		close(job.done)
+		job.done = nil
+		b.lock.Unlock()
+	}()
+
+	for {
+		b.lock.Lock()
+		status := job.status
+		b.lock.Unlock()
+		if status.NextHeight > status.ToHeight {
+			b.finish(job, nil)
+			return
+		}
+
+		amount, err := b.fetch(ctx, status.Namespace, status.NextHeight)
+		if err != nil {
+			if ctx.Err() != nil {
+				// paused or stopped
+				return
+			}
+			b.finish(job, fmt.Errorf("height %d: %w", status.NextHeight, err))
+			return
+		}
+
+		b.lock.Lock()
+		job.status.NextHeight++
+		job.status.Blobs += uint64(amount)
+		status = job.status
+		b.lock.Unlock()
+		if err := b.persist(ctx, status); err != nil && ctx.Err() == nil {
+			log.Warnw("persisting backfill progress", "namespace", status.Namespace.String(), "err", err)
+		}
+	}
+}
+
+// fetch indexes the blobs under the namespace at the given height and reports their amount.
+func (b *Backfiller) fetch(ctx context.Context, namespace share.Namespace, height uint64) (int, error) {
+	if err := b.limiter.Wait(ctx); err != nil {
+		return 0, err
+	}
+
+	h, err := b.service.headerGetter(ctx, height)
+	if err != nil {
+		return 0, err
+	}
+	blobs, err := b.service.getBlobs(ctx, namespace, h.DAH)
+	if errors.Is(err, ErrBlobNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	out := make([]*Blob, 0, len(blobs))
+	for _, blob := range blobs {
+		if blob != nil {
+			out = append(out, blob)
+		}
+	}
+	if len(out) == 0 {
+		return 0, nil
+	}
+	data, err := json.Marshal(out)
+	if err != nil {
+		return 0, err
+	}
+	return len(out), b.ds.Put(ctx, indexKey(namespace, height), data)
+}
+
+// finish marks the backfill as done or, if err is given, failed.
+func (b *Backfiller) finish(job *backfillJob, err error) {
+	b.lock.Lock()
+	job.status.State = BackfillDone
+	if err != nil {
+		job.status.State = BackfillFailed
+		job.status.Error = err.Error()
+	}
+	status := job.status
+	b.lock.Unlock()
+
+	if err != nil {
+		log.Errorw("backfill failed", "namespace", status.Namespace.String(), "err", err)
+	} else {
+		log.Infow("backfill done", "namespace", status.Namespace.String(), "blobs", status.Blobs)
+	}
+	if err := b.persist(context.Background(), status); err != nil {
+		log.Warnw("persisting backfill state", "namespace", status.Namespace.String(), "err", err)
+	}
+}
+
+func (b *Backfiller) persist(ctx context.Context, status BackfillStatus) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	return b.ds.Put(ctx, jobKey(status.Namespace), data)
+}
+
+// clearIndex removes the blobs indexed under the namespace.
+func (b *Backfiller) clearIndex(ctx context.Context, namespace share.Namespace) error {
+	res, err := b.ds.Query(ctx, query.Query{
+		Prefix:   indexPrefix.ChildString(namespace.String()).String(),
+		KeysOnly: true,
+	})
+	if err != nil {
+		return fmt.Errorf("blob: clearing backfilled blobs: %w", err)
+	}
+	entries, err := res.Rest()
+	if err != nil {
+		return fmt.Errorf("blob: clearing backfilled blobs: %w", err)
+	}
+
+	batch, err := b.ds.Batch(ctx)
+	if err != nil {
+		return fmt.Errorf("blob: clearing backfilled blobs: %w", err)
+	}
+	for _, entry := range entries {
+		if err := batch.Delete(ctx, datastore.NewKey(entry.Key)); err != nil {
+			return fmt.Errorf("blob: clearing backfilled blobs: %w", err)
+		}
+	}
+	if err := batch.Commit(ctx); err != nil {
+		return fmt.Errorf("blob: clearing backfilled blobs: %w", err)
+	}
+	return nil
+}
+
+func jobKey(namespace share.Namespace) datastore.Key {
+	return jobsPrefix.ChildString(namespace.String())
+}
+
+func indexKey(namespace share.Namespace, height uint64) datastore.Key {
+	return indexPrefix.ChildString(namespace.String()).ChildString(strconv.FormatUint(height, 10))
+}
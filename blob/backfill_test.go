@@ -0,0 +1,122 @@
+package blob
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	ds_sync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/blob/blobtest"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+func TestBackfiller(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	appBlobs, err := blobtest.GenerateV0Blobs([]int{10, 6}, false)
+	require.NoError(t, err)
+	blobs, err := convertBlobs(appBlobs...)
+	require.NoError(t, err)
+	service := createService(ctx, t, blobs)
+	namespace := blobs[0].Namespace()
+
+	_, err = service.Backfill(ctx, namespace, 1, 1)
+	require.ErrorIs(t, err, ErrBackfillDisabled)
+
+	b := NewBackfiller(service, ds_sync.MutexWrap(ds.NewMapDatastore()), 100)
+	require.NoError(t, b.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, b.Stop(context.Background()))
+	})
+
+	_, err = service.BackfillStatus(ctx, namespace)
+	require.ErrorIs(t, err, ErrBackfillNotFound)
+	_, err = service.Backfill(ctx, namespace, 2, 1)
+	require.Error(t, err)
+
+	status, err := service.Backfill(ctx, namespace, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, BackfillRunning, status.State)
+
+	require.Eventually(t, func() bool {
+		status, err = service.BackfillStatus(ctx, namespace)
+		return err == nil && status.State == BackfillDone
+	}, time.Second*5, time.Millisecond*10)
+	assert.EqualValues(t, 2, status.NextHeight)
+	assert.EqualValues(t, 1, status.Blobs)
+
+	indexed, ok := b.indexed(ctx, namespace, 1)
+	require.True(t, ok)
+	require.Len(t, indexed, 1)
+	assert.Equal(t, blobs[0].Commitment, indexed[0].Commitment)
+
+	all, err := service.GetAll(ctx, 1, []share.Namespace{namespace})
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, blobs[0].Commitment, all[0].Commitment)
+
+	require.NoError(t, service.RemoveBackfill(ctx, namespace))
+	_, ok = b.indexed(ctx, namespace, 1)
+	assert.False(t, ok)
+	_, err = service.BackfillStatus(ctx, namespace)
+	require.ErrorIs(t, err, ErrBackfillNotFound)
+	require.ErrorIs(t, service.RemoveBackfill(ctx, namespace), ErrBackfillNotFound)
+}
+
+func TestBackfiller_PauseResume(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	appBlobs, err := blobtest.GenerateV0Blobs([]int{10, 6}, false)
+	require.NoError(t, err)
+	blobs, err := convertBlobs(appBlobs...)
+	require.NoError(t, err)
+	service := createService(ctx, t, blobs)
+	namespace := blobs[0].Namespace()
+
+	// the rate only allows the first height to be fetched during the test
+	batching := ds_sync.MutexWrap(ds.NewMapDatastore())
+	b := NewBackfiller(service, batching, 0.001)
+	require.NoError(t, b.Start(ctx))
+
+	_, err = b.Backfill(ctx, namespace, 1, 3)
+	require.NoError(t, err)
+	_, err = b.Backfill(ctx, namespace, 1, 3)
+	require.ErrorIs(t, err, ErrBackfillRunning)
+
+	require.Eventually(t, func() bool {
+		status, err := b.Status(ctx, namespace)
+		require.NoError(t, err)
+		return status.NextHeight == 2
+	}, time.Second*5, time.Millisecond*10)
+
+	require.NoError(t, b.Pause(ctx, namespace))
+	status, err := b.Status(ctx, namespace)
+	require.NoError(t, err)
+	assert.Equal(t, BackfillPaused, status.State)
+	assert.EqualValues(t, 2, status.NextHeight)
+	require.NoError(t, b.Stop(ctx))
+
+	// the progress survives restarts and paused backfills stay paused
+	b = NewBackfiller(service, batching, 0.001)
+	require.NoError(t, b.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, b.Stop(context.Background()))
+	})
+	status, err = b.Status(ctx, namespace)
+	require.NoError(t, err)
+	assert.Equal(t, BackfillPaused, status.State)
+	assert.EqualValues(t, 2, status.NextHeight)
+	assert.EqualValues(t, 1, status.Blobs)
+
+	require.NoError(t, b.Resume(ctx, namespace))
+	status, err = b.Status(ctx, namespace)
+	require.NoError(t, err)
+	assert.Equal(t, BackfillRunning, status.State)
+	assert.EqualValues(t, 2, status.NextHeight)
+}
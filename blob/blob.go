@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"google.golang.org/protobuf/encoding/protowire"
 
 	"github.com/celestiaorg/celestia-app/pkg/appconsts"
 	"github.com/celestiaorg/celestia-app/x/blob/types"
@@ -62,6 +63,22 @@ func (p Proof) equal(input Proof) error {
 	return nil
 }
 
+// The canonical encodings of Proof served over RPC are pinned below, so that verifiers in other
+// languages keep decoding them. Any change to them is breaking.
+//
+// The canonical JSON encoding lists the NMT inclusion proofs of the rows the blob spans, in order.
+// Their leaf hashes are omitted and the max namespace is ignored on decoding, as blob proofs only
+// prove inclusion in the trees of the EDS:
+//
+//	[{"start":<int>,"end":<int>,"nodes":[<base64>...]|null}...]
+//
+// The canonical protobuf encoding follows the schema below, with the NMT proofs encoded as by
+// share.MarshalNMTProof:
+//
+//	message Proof {
+//	  repeated NMTProof proofs = 1;
+//	}
+
 type jsonProof struct {
 	Start int      `json:"start"`
 	End   int      `json:"end"`
@@ -98,6 +115,54 @@ func (p *Proof) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// MarshalBinary encodes the Proof to its canonical protobuf.
+func (p Proof) MarshalBinary() ([]byte, error) {
+	var b []byte
+	for _, proof := range p {
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendBytes(b, share.MarshalNMTProof(proof))
+	}
+	return b, nil
+}
+
+// UnmarshalBinary decodes the Proof from its canonical protobuf.
+func (p *Proof) UnmarshalBinary(data []byte) error {
+	proofs := make([]*nmt.Proof, 0)
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return fmt.Errorf("blob: decoding proof: %w", protowire.ParseError(n))
+		}
+		data = data[n:]
+
+		if num != 1 {
+			n = protowire.ConsumeFieldValue(num, typ, data)
+			if n < 0 {
+				return fmt.Errorf("blob: decoding proof: %w", protowire.ParseError(n))
+			}
+			data = data[n:]
+			continue
+		}
+		if typ != protowire.BytesType {
+			return fmt.Errorf("blob: decoding proof: unexpected wire type %d", typ)
+		}
+		raw, n := protowire.ConsumeBytes(data)
+		if n < 0 {
+			return fmt.Errorf("blob: decoding proof: %w", protowire.ParseError(n))
+		}
+		data = data[n:]
+
+		proof, err := share.UnmarshalNMTProof(raw)
+		if err != nil {
+			return err
+		}
+		proofs = append(proofs, proof)
+	}
+
+	*p = proofs
+	return nil
+}
+
 // Blob represents any application-specific binary data that anyone can submit to Celestia.
 type Blob struct {
 	types.Blob `json:"blob"`
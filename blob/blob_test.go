@@ -1,6 +1,8 @@
 package blob
 
 import (
+	"encoding/hex"
+	"encoding/json"
 	"reflect"
 	"testing"
 
@@ -9,8 +11,10 @@ import (
 	"github.com/tendermint/tendermint/types"
 
 	apptypes "github.com/celestiaorg/celestia-app/x/blob/types"
+	"github.com/celestiaorg/nmt"
 
 	"github.com/celestiaorg/celestia-node/blob/blobtest"
+	"github.com/celestiaorg/celestia-node/share/ipld"
 )
 
 func TestBlob(t *testing.T) {
@@ -77,6 +81,34 @@ func TestBlob(t *testing.T) {
 	}
 }
 
+// The golden encodings below are relied upon by verifiers outside of this repository and must
+// never change.
+func TestProofEncoding(t *testing.T) {
+	first := nmt.NewInclusionProof(1, 3, [][]byte{{1, 2}, {3}}, ipld.NMTIgnoreMaxNamespace)
+	second := nmt.NewInclusionProof(0, 2, nil, ipld.NMTIgnoreMaxNamespace)
+	proof := Proof{&first, &second}
+	const (
+		goldenJSON  = `[{"start":1,"end":3,"nodes":["AQI=","Aw=="]},{"start":0,"end":2,"nodes":null}]`
+		goldenProto = "0a0d080110031a0201021a010328010a0410022801"
+	)
+
+	data, err := json.Marshal(&proof)
+	require.NoError(t, err)
+	assert.JSONEq(t, goldenJSON, string(data))
+	var decoded Proof
+	require.NoError(t, json.Unmarshal([]byte(goldenJSON), &decoded))
+	assert.Equal(t, proof, decoded)
+
+	data, err = proof.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, goldenProto, hex.EncodeToString(data))
+	raw, err := hex.DecodeString(goldenProto)
+	require.NoError(t, err)
+	decoded = nil
+	require.NoError(t, decoded.UnmarshalBinary(raw))
+	assert.Equal(t, proof, decoded)
+}
+
 func convertBlobs(appBlobs ...types.Blob) ([]*Blob, error) {
 	blobs := make([]*Blob, 0, len(appBlobs))
 	for _, b := range appBlobs {
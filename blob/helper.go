@@ -6,7 +6,6 @@ import (
 
 	"github.com/tendermint/tendermint/types"
 
-	"github.com/celestiaorg/celestia-app/pkg/appconsts"
 	"github.com/celestiaorg/celestia-app/pkg/shares"
 
 	"github.com/celestiaorg/celestia-node/share"
@@ -81,20 +80,44 @@ func BlobsToShares(blobs ...*Blob) ([]share.Share, error) {
 	return shares.ToBytes(rawShares), nil
 }
 
-const (
-	perByteGasTolerance = 2
-	pfbGasFixedCost     = 80000
-)
-
-// estimateGas estimates the gas required to pay for a set of blobs in a PFB.
-func estimateGas(blobs ...*Blob) uint64 {
-	totalByteCount := 0
-	for _, blob := range blobs {
-		totalByteCount += len(blob.Data) + appconsts.NamespaceSize
+// maxBatchBytes bounds the data of the Blobs packed into a single PFB transaction by SubmitAll,
+// keeping the transaction below the 2MiB mempool limit of celestia-app nodes.
+const maxBatchBytes = 1_800_000
+
+// packBlobs packs the Blobs into as few batches as possible without any batch exceeding maxBytes
+// of data, unless a single Blob does. Batches are returned as indexes into the given Blobs. It
+// follows the first-fit decreasing heuristic, placing larger Blobs first.
+func packBlobs(blobs []*Blob, maxBytes int) [][]int {
+	order := make([]int, len(blobs))
+	for i := range order {
+		order[i] = i
 	}
-	variableGasAmount := (appconsts.DefaultGasPerBlobByte + perByteGasTolerance) * totalByteCount
+	sort.SliceStable(order, func(i, j int) bool {
+		return len(blobs[order[i]].Data) > len(blobs[order[j]].Data)
+	})
 
-	return uint64(variableGasAmount + pfbGasFixedCost)
+	var (
+		batches [][]int
+		sizes   []int
+	)
+	for _, idx := range order {
+		size := len(blobs[idx].Data)
+		fit := -1
+		for i := range batches {
+			if sizes[i]+size <= maxBytes {
+				fit = i
+				break
+			}
+		}
+		if fit == -1 {
+			batches = append(batches, nil)
+			sizes = append(sizes, 0)
+			fit = len(batches) - 1
+		}
+		batches[fit] = append(batches[fit], idx)
+		sizes[fit] += size
+	}
+	return batches
 }
 
 // constructAndVerifyBlob reconstruct a Blob from the passed shares and compares commitments.
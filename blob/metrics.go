@@ -0,0 +1,197 @@
+package blob
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	libhead "github.com/celestiaorg/go-header"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+const (
+	namespaceLabel = "namespace"
+	failedLabel    = "failed"
+)
+
+const (
+	// retrievalWorkers is the amount of namespace retrievals done at once by the NamespaceMetrics.
+	retrievalWorkers = 8
+	// retrievalQueueSize is the amount of namespace retrievals queued at most. The retrievals of new
+	// headers are skipped while the queue is full, so that slow retrievals do not pile up.
+	retrievalQueueSize = 64
+)
+
+// retrievalTimeout bounds the time a namespace retrieval may take, after which it is recorded as
+// failed.
+var retrievalTimeout = time.Minute
+
+var meter = otel.Meter("blob")
+
+// NamespaceMetrics reports the amount of blobs and bytes published under a configured set of
+// namespaces in every new block, allowing rollup operators to monitor their DA usage. It also
+// reports the time from a header's arrival until the node has retrieved the header's data of each
+// namespace, i.e. the end-to-end DA latency experienced by the rollup.
+type NamespaceMetrics struct {
+	getter     share.Getter
+	headerSub  libhead.Subscriber[*header.ExtendedHeader]
+	namespaces []share.Namespace
+
+	blobs         metric.Int64Histogram
+	bytes         metric.Int64Histogram
+	retrievalTime metric.Float64Histogram
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewNamespaceMetrics creates NamespaceMetrics for the given namespaces.
+func NewNamespaceMetrics(
+	getter share.Getter,
+	headerSub libhead.Subscriber[*header.ExtendedHeader],
+	namespaces []share.Namespace,
+) (*NamespaceMetrics, error) {
+	blobs, err := meter.Int64Histogram("blob_namespace_blobs_per_block_hist",
+		metric.WithDescription("amount of blobs published under the namespace per block"))
+	if err != nil {
+		return nil, err
+	}
+
+	bytes, err := meter.Int64Histogram("blob_namespace_bytes_per_block_hist",
+		metric.WithDescription("amount of blob bytes published under the namespace per block"))
+	if err != nil {
+		return nil, err
+	}
+
+	retrievalTime, err := meter.Float64Histogram("blob_namespace_retrieval_time_hist",
+		metric.WithDescription("duration from the header arrival until the namespace data is retrieved"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &NamespaceMetrics{
+		getter:        getter,
+		headerSub:     headerSub,
+		namespaces:    namespaces,
+		blobs:         blobs,
+		bytes:         bytes,
+		retrievalTime: retrievalTime,
+		done:          make(chan struct{}),
+	}, nil
+}
+
+func (m *NamespaceMetrics) Start(context.Context) error {
+	sub, err := m.headerSub.Subscribe()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	go m.subscribe(ctx, sub)
+	return nil
+}
+
+func (m *NamespaceMetrics) Stop(ctx context.Context) error {
+	m.cancel()
+	select {
+	case <-m.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// retrieval is the retrieval of the data of a namespace in a header, done by the workers of the
+// NamespaceMetrics.
+type retrieval struct {
+	header    *header.ExtendedHeader
+	namespace share.Namespace
+	arrived   time.Time
+}
+
+func (m *NamespaceMetrics) subscribe(ctx context.Context, sub libhead.Subscription[*header.ExtendedHeader]) {
+	defer close(m.done)
+
+	// namespaces are retrieved concurrently and without holding off the next header, so that
+	// the retrieval time of one does not add up to the others'
+	var wg sync.WaitGroup
+	retrievals := make(chan retrieval, retrievalQueueSize)
+	for i := 0; i < retrievalWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for r := range retrievals {
+				m.observe(ctx, r.header, r.namespace, r.arrived)
+			}
+		}()
+	}
+	defer wg.Wait()
+	defer close(retrievals)
+
+	handleHeaders(ctx, "namespace metrics", sub, func(h *header.ExtendedHeader) {
+		arrived := time.Now()
+		for _, namespace := range m.namespaces {
+			select {
+			case retrievals <- retrieval{header: h, namespace: namespace, arrived: arrived}:
+			default:
+				log.Warnw("namespace metrics: too many pending retrievals, skipping",
+					"height", h.Height(), "namespace", namespace.String())
+			}
+		}
+	})
+}
+
+// observe records the blobs published under the namespace in the given header and the time
+// since the header arrived until they were retrieved.
+func (m *NamespaceMetrics) observe(
+	ctx context.Context,
+	h *header.ExtendedHeader,
+	namespace share.Namespace,
+	arrived time.Time,
+) {
+	var count, size int
+	retrieveCtx, cancel := context.WithTimeout(ctx, retrievalTimeout)
+	shares, err := m.getter.GetSharesByNamespace(retrieveCtx, h.DAH, namespace)
+	cancel()
+	if ctx.Err() != nil {
+		// the node is stopping, so the retrieval time is not representative
+		return
+	}
+	m.retrievalTime.Record(ctx, time.Since(arrived).Seconds(),
+		metric.WithAttributes(
+			attribute.String(namespaceLabel, namespace.String()),
+			attribute.Bool(failedLabel, err != nil),
+		))
+	if err != nil {
+		log.Warnw("namespace metrics: getting shares",
+			"height", h.Height(), "namespace", namespace.String(), "err", err)
+		return
+	}
+
+	if len(shares) > 0 {
+		blobs, err := SharesToBlobs(shares.Flatten())
+		if err != nil {
+			log.Warnw("namespace metrics: parsing blobs",
+				"height", h.Height(), "namespace", namespace.String(), "err", err)
+			return
+		}
+		for _, blob := range blobs {
+			if blob == nil {
+				continue
+			}
+			count++
+			size += len(blob.Data)
+		}
+	}
+
+	attrs := metric.WithAttributes(attribute.String(namespaceLabel, namespace.String()))
+	m.blobs.Record(ctx, int64(count), attrs)
+	m.bytes.Record(ctx, int64(size), attrs)
+}
@@ -0,0 +1,95 @@
+package blob
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mdutils "github.com/ipfs/go-merkledag/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	libheadtest "github.com/celestiaorg/go-header/headertest"
+
+	"github.com/celestiaorg/celestia-node/blob/blobtest"
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/getters"
+	"github.com/celestiaorg/celestia-node/share/ipld"
+)
+
+func TestNamespaceMetrics(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	reader := sdkmetric.NewManualReader()
+	prev := otel.GetMeterProvider()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	t.Cleanup(func() {
+		otel.SetMeterProvider(prev)
+	})
+
+	appBlobs, err := blobtest.GenerateV0Blobs([]int{10, 6}, false)
+	require.NoError(t, err)
+	blobs, err := convertBlobs(appBlobs...)
+	require.NoError(t, err)
+	rawShares, err := BlobsToShares(blobs...)
+	require.NoError(t, err)
+	bs := mdutils.Bserv()
+	eds, err := ipld.AddShares(ctx, rawShares, bs)
+	require.NoError(t, err)
+	h := headertest.ExtendedHeaderFromEDS(t, 1, eds)
+
+	// the subscription ends once the headers are over
+	sub := &libheadtest.Subscriber[*header.ExtendedHeader]{Headers: []*header.ExtendedHeader{h}}
+	namespace := blobs[0].Namespace()
+	m, err := NewNamespaceMetrics(getters.NewIPLDGetter(bs), sub, []share.Namespace{namespace})
+	require.NoError(t, err)
+	require.NoError(t, m.Start(ctx))
+	select {
+	case <-m.done:
+	case <-ctx.Done():
+		t.Fatal("namespace metrics did not finish")
+	}
+	require.NoError(t, m.Stop(ctx))
+
+	var data metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &data))
+	histograms := make(map[string]metricdata.HistogramDataPoint[float64])
+	blobCounts := make(map[string]metricdata.HistogramDataPoint[int64])
+	for _, scope := range data.ScopeMetrics {
+		for _, metric := range scope.Metrics {
+			switch hist := metric.Data.(type) {
+			case metricdata.Histogram[float64]:
+				if metric.Name == "blob_namespace_retrieval_time_hist" {
+					for _, point := range hist.DataPoints {
+						ns, _ := point.Attributes.Value(namespaceLabel)
+						histograms[ns.AsString()] = point
+					}
+				}
+			case metricdata.Histogram[int64]:
+				if metric.Name == "blob_namespace_blobs_per_block_hist" {
+					for _, point := range hist.DataPoints {
+						ns, _ := point.Attributes.Value(namespaceLabel)
+						blobCounts[ns.AsString()] = point
+					}
+				}
+			}
+		}
+	}
+
+	retrieval, ok := histograms[namespace.String()]
+	require.True(t, ok)
+	assert.EqualValues(t, 1, retrieval.Count)
+	failed, _ := retrieval.Attributes.Value(failedLabel)
+	assert.False(t, failed.AsBool())
+	assert.Positive(t, retrieval.Sum)
+
+	count, ok := blobCounts[namespace.String()]
+	require.True(t, ok)
+	assert.EqualValues(t, 1, count.Sum)
+}
@@ -2,6 +2,7 @@ package blob
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"sync"
@@ -10,7 +11,6 @@ import (
 	"github.com/cosmos/cosmos-sdk/types"
 	logging "github.com/ipfs/go-log/v2"
 
-	"github.com/celestiaorg/celestia-app/pkg/appconsts"
 	"github.com/celestiaorg/celestia-app/pkg/shares"
 
 	"github.com/celestiaorg/celestia-node/header"
@@ -20,13 +20,16 @@ import (
 var (
 	ErrBlobNotFound = errors.New("blob: not found")
 	ErrInvalidProof = errors.New("blob: invalid proof")
+	// ErrSubmissionDisabled is returned on submission by a Service without a Submitter.
+	ErrSubmissionDisabled = errors.New("blob: submission is disabled")
 
 	log = logging.Logger("blob")
 )
 
 // Submitter is an interface that allows submitting blobs to the celestia-core. It is used to
 // avoid a circular dependency between the blob and the state package, since the state package needs
-// the blob.Blob type for this signature.
+// the blob.Blob type for this signature. A zero gas limit is estimated by the Submitter, and a
+// zero fee is paid at its default gas price.
 type Submitter interface {
 	SubmitPayForBlob(ctx context.Context, fee math.Int, gasLim uint64, blobs []*Blob) (*types.TxResponse, error)
 }
@@ -38,38 +41,119 @@ type Service struct {
 	shareGetter share.Getter
 	//  headerGetter fetches header by the provided height
 	headerGetter func(context.Context, uint64) (*header.ExtendedHeader, error)
+	// headerSub streams headers starting from the provided height
+	headerSub func(context.Context, uint64) (<-chan *header.ExtendedHeader, error)
+	// backfiller indexes historical blobs locally, if set with NewBackfiller
+	backfiller *Backfiller
 }
 
 func NewService(
 	submitter Submitter,
 	getter share.Getter,
 	headerGetter func(context.Context, uint64) (*header.ExtendedHeader, error),
+	headerSub func(context.Context, uint64) (<-chan *header.ExtendedHeader, error),
 ) *Service {
 	return &Service{
 		blobSumitter: submitter,
 		shareGetter:  getter,
 		headerGetter: headerGetter,
+		headerSub:    headerSub,
 	}
 }
 
+// SubscriptionResponse is the blobs published under the subscribed namespace at the given height.
+type SubscriptionResponse struct {
+	Blobs  []*Blob
+	Height uint64
+}
+
 // Submit sends PFB transaction and reports the height in which it was included.
 // Allows sending multiple Blobs atomically synchronously.
-// Uses default wallet registered on the Node.
+// Uses default wallet registered on the Node and pays the fee suggested by simulating the
+// transaction against core.
 func (s *Service) Submit(ctx context.Context, blobs []*Blob) (uint64, error) {
+	if s.blobSumitter == nil {
+		return 0, ErrSubmissionDisabled
+	}
 	log.Debugw("submitting blobs", "amount", len(blobs))
 
-	var (
-		gasLimit = estimateGas(blobs...)
-		fee      = int64(appconsts.DefaultMinGasPrice * float64(gasLimit))
-	)
-
-	resp, err := s.blobSumitter.SubmitPayForBlob(ctx, types.NewInt(fee), gasLimit, blobs)
+	resp, err := s.blobSumitter.SubmitPayForBlob(ctx, types.ZeroInt(), 0, blobs)
 	if err != nil {
 		return 0, err
 	}
 	return uint64(resp.Height), nil
 }
 
+// PartialSubmissionError is returned by SubmitAll when some of the Blobs were included before a
+// transaction failed. It carries the heights of the included Blobs over the RPC as well.
+type PartialSubmissionError struct {
+	// Heights are the heights the Blobs were included in, in the order of the submitted Blobs.
+	// The heights of the Blobs not included are zero.
+	Heights []uint64 `json:"heights"`
+	// Reason is the error of the failed transaction.
+	Reason string `json:"reason"`
+
+	err error
+}
+
+func (e *PartialSubmissionError) Error() string {
+	var included int
+	for _, height := range e.Heights {
+		if height != 0 {
+			included++
+		}
+	}
+	return fmt.Sprintf("blob: submitted %d of %d blobs: %s", included, len(e.Heights), e.Reason)
+}
+
+// Unwrap returns the error of the failed transaction. It is lost when the error is sent over the
+// RPC, in which case only the Reason is kept.
+func (e *PartialSubmissionError) Unwrap() error {
+	return e.err
+}
+
+// partialSubmissionError is the JSON form of PartialSubmissionError without its methods.
+type partialSubmissionError PartialSubmissionError
+
+func (e *PartialSubmissionError) MarshalJSON() ([]byte, error) {
+	return json.Marshal((*partialSubmissionError)(e))
+}
+
+func (e *PartialSubmissionError) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*partialSubmissionError)(e))
+}
+
+// SubmitAll sends the Blobs, possibly under different namespaces, in as few PFB transactions as
+// possible and reports the height in which each of them was included, in the order of the given
+// Blobs. Transactions are submitted one after another. If one fails after others were included, a
+// PartialSubmissionError with the heights of the included Blobs is returned along with the heights.
+// Pays the fees suggested by simulating the transactions against core.
+func (s *Service) SubmitAll(ctx context.Context, blobs []*Blob) ([]uint64, error) {
+	if s.blobSumitter == nil {
+		return nil, ErrSubmissionDisabled
+	}
+	log.Debugw("submitting blobs in batches", "amount", len(blobs))
+
+	heights := make([]uint64, len(blobs))
+	for n, batch := range packBlobs(blobs, maxBatchBytes) {
+		batchBlobs := make([]*Blob, len(batch))
+		for i, idx := range batch {
+			batchBlobs[i] = blobs[idx]
+		}
+		resp, err := s.blobSumitter.SubmitPayForBlob(ctx, types.ZeroInt(), 0, batchBlobs)
+		if err != nil {
+			if n == 0 {
+				return nil, err
+			}
+			return heights, &PartialSubmissionError{Heights: heights, Reason: err.Error(), err: err}
+		}
+		for _, idx := range batch {
+			heights[idx] = uint64(resp.Height)
+		}
+	}
+	return heights, nil
+}
+
 // Get retrieves all the blobs for given namespaces at the given height by commitment.
 func (s *Service) Get(ctx context.Context, height uint64, ns share.Namespace, commitment Commitment) (*Blob, error) {
 	blob, _, err := s.getByCommitment(ctx, height, ns, commitment)
@@ -112,6 +196,12 @@ func (s *Service) GetAll(ctx context.Context, height uint64, namespaces []share.
 		wg.Add(1)
 		go func(i int, namespace share.Namespace) {
 			defer wg.Done()
+			if s.backfiller != nil {
+				if blobs, ok := s.backfiller.indexed(ctx, namespace, height); ok {
+					resultBlobs[i] = blobs
+					return
+				}
+			}
 			blobs, err := s.getBlobs(ctx, namespace, header.DAH)
 			if err != nil {
 				resultErr[i] = fmt.Errorf("getting blobs for namespace(%s): %s", namespace.String(), err)
@@ -135,6 +225,90 @@ func (s *Service) GetAll(ctx context.Context, height uint64, namespaces []share.
 	return blobs, errors.Join(resultErr...)
 }
 
+// Subscribe streams the blobs published under the given namespace, height by height, starting from
+// fromHeight. Stored history is replayed first, so a consumer resuming from its last processed
+// height does not miss any block. Zero fromHeight starts after the current local head.
+// Heights without blobs in the namespace are sent with no blobs.
+func (s *Service) Subscribe(
+	ctx context.Context,
+	namespace share.Namespace,
+	fromHeight uint64,
+) (<-chan *SubscriptionResponse, error) {
+	headerCh, err := s.headerSub(ctx, fromHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	blobCh := make(chan *SubscriptionResponse)
+	go func() {
+		defer close(blobCh)
+
+		for h := range headerCh {
+			blobs, err := s.getBlobs(ctx, namespace, h.DAH)
+			if err != nil && !errors.Is(err, ErrBlobNotFound) {
+				if !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, context.Canceled) {
+					log.Errorw("fetching blobs for subscription", "height", h.Height(), "err", err)
+				}
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case blobCh <- &SubscriptionResponse{Blobs: blobs, Height: uint64(h.Height())}:
+			}
+		}
+	}()
+	return blobCh, nil
+}
+
+// Backfill starts fetching the blobs under the namespace between the given heights, inclusive,
+// in the background and indexing them locally, so that GetAll serves them without retrieving them
+// again. The progress is reported by BackfillStatus.
+func (s *Service) Backfill(
+	ctx context.Context,
+	namespace share.Namespace,
+	fromHeight, toHeight uint64,
+) (*BackfillStatus, error) {
+	if s.backfiller == nil {
+		return nil, ErrBackfillDisabled
+	}
+	return s.backfiller.Backfill(ctx, namespace, fromHeight, toHeight)
+}
+
+// BackfillStatus reports the progress of the backfill of the namespace.
+func (s *Service) BackfillStatus(ctx context.Context, namespace share.Namespace) (*BackfillStatus, error) {
+	if s.backfiller == nil {
+		return nil, ErrBackfillDisabled
+	}
+	return s.backfiller.Status(ctx, namespace)
+}
+
+// PauseBackfill pauses the backfill of the namespace, keeping its progress.
+func (s *Service) PauseBackfill(ctx context.Context, namespace share.Namespace) error {
+	if s.backfiller == nil {
+		return ErrBackfillDisabled
+	}
+	return s.backfiller.Pause(ctx, namespace)
+}
+
+// ResumeBackfill resumes the paused or failed backfill of the namespace from where it stopped.
+func (s *Service) ResumeBackfill(ctx context.Context, namespace share.Namespace) error {
+	if s.backfiller == nil {
+		return ErrBackfillDisabled
+	}
+	return s.backfiller.Resume(ctx, namespace)
+}
+
+// RemoveBackfill stops the backfill of the namespace, if running, and removes it along with the
+// blobs it indexed.
+func (s *Service) RemoveBackfill(ctx context.Context, namespace share.Namespace) error {
+	if s.backfiller == nil {
+		return ErrBackfillDisabled
+	}
+	return s.backfiller.Remove(ctx, namespace)
+}
+
 // Included verifies that the blob was included in a specific height.
 // To ensure that blob was included in a specific height, we need:
 // 1. verify the provided commitment by recomputing it;
@@ -4,9 +4,13 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha256"
+	"encoding/json"
+	"errors"
 	"testing"
 	"time"
 
+	"cosmossdk.io/math"
+	"github.com/cosmos/cosmos-sdk/types"
 	ds "github.com/ipfs/go-datastore"
 	ds_sync "github.com/ipfs/go-datastore/sync"
 	mdutils "github.com/ipfs/go-merkledag/test"
@@ -23,6 +27,7 @@ import (
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/getters"
 	"github.com/celestiaorg/celestia-node/share/ipld"
+	"github.com/celestiaorg/celestia-node/share/sharetest"
 )
 
 func TestBlobService_Get(t *testing.T) {
@@ -336,7 +341,7 @@ func TestService_GetSingleBlobWithoutPadding(t *testing.T) {
 	fn := func(ctx context.Context, height uint64) (*header.ExtendedHeader, error) {
 		return headerStore.GetByHeight(ctx, height)
 	}
-	service := NewService(nil, getters.NewIPLDGetter(bs), fn)
+	service := NewService(nil, getters.NewIPLDGetter(bs), fn, nil)
 
 	newBlob, err := service.Get(ctx, 1, blobs[1].Namespace(), blobs[1].Commitment)
 	require.NoError(t, err)
@@ -388,12 +393,37 @@ func TestService_GetAllWithoutPadding(t *testing.T) {
 		return headerStore.GetByHeight(ctx, height)
 	}
 
-	service := NewService(nil, getters.NewIPLDGetter(bs), fn)
+	service := NewService(nil, getters.NewIPLDGetter(bs), fn, nil)
 
 	_, err = service.GetAll(ctx, 1, []share.Namespace{blobs[0].Namespace(), blobs[1].Namespace()})
 	require.NoError(t, err)
 }
 
+func TestService_Subscribe(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	appBlobs, err := blobtest.GenerateV0Blobs([]int{10, 6}, false)
+	require.NoError(t, err)
+	blobs, err := convertBlobs(appBlobs...)
+	require.NoError(t, err)
+	service := createService(ctx, t, blobs)
+
+	blobCh, err := service.Subscribe(ctx, blobs[0].Namespace(), 1)
+	require.NoError(t, err)
+	resp := <-blobCh
+	require.EqualValues(t, 1, resp.Height)
+	require.Len(t, resp.Blobs, 1)
+	assert.Equal(t, blobs[0].Commitment, resp.Blobs[0].Commitment)
+
+	// heights without blobs under the namespace are still reported
+	blobCh, err = service.Subscribe(ctx, sharetest.RandV0Namespace(), 1)
+	require.NoError(t, err)
+	resp = <-blobCh
+	require.EqualValues(t, 1, resp.Height)
+	require.Empty(t, resp.Blobs)
+}
+
 func createService(ctx context.Context, t *testing.T, blobs []*Blob) *Service {
 	bs := mdutils.Bserv()
 	batching := ds_sync.MutexWrap(ds.NewMapDatastore())
@@ -411,5 +441,106 @@ func createService(ctx context.Context, t *testing.T, blobs []*Blob) *Service {
 	fn := func(ctx context.Context, height uint64) (*header.ExtendedHeader, error) {
 		return headerStore.GetByHeight(ctx, height)
 	}
-	return NewService(nil, getters.NewIPLDGetter(bs), fn)
+	subFn := func(ctx context.Context, height uint64) (<-chan *header.ExtendedHeader, error) {
+		headerCh := make(chan *header.ExtendedHeader)
+		go func() {
+			defer close(headerCh)
+			for ; ; height++ {
+				h, err := headerStore.GetByHeight(ctx, height)
+				if err != nil {
+					return
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case headerCh <- h:
+				}
+			}
+		}()
+		return headerCh, nil
+	}
+	return NewService(nil, getters.NewIPLDGetter(bs), fn, subFn)
+}
+
+// batchSubmitter records the blobs of every PFB and includes each of them in its own block. If
+// fail is set, the PFBs over the limit fail.
+type batchSubmitter struct {
+	batches [][]*Blob
+	fail    bool
+	limit   int
+}
+
+func (s *batchSubmitter) SubmitPayForBlob(
+	_ context.Context,
+	_ math.Int,
+	_ uint64,
+	blobs []*Blob,
+) (*types.TxResponse, error) {
+	if s.fail && len(s.batches) >= s.limit {
+		return nil, errors.New("out of funds")
+	}
+	s.batches = append(s.batches, blobs)
+	return &types.TxResponse{Height: int64(len(s.batches))}, nil
+}
+
+func TestService_SubmitAll(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	appBlobs, err := blobtest.GenerateV0Blobs([]int{1, 1, 1}, false)
+	require.NoError(t, err)
+	blobs, err := convertBlobs(appBlobs...)
+	require.NoError(t, err)
+	large, err := NewBlobV0(blobs[0].Namespace(), tmrand.Bytes(maxBatchBytes))
+	require.NoError(t, err)
+	blobs = append(blobs, large)
+
+	submitter := &batchSubmitter{}
+	service := NewService(submitter, nil, nil, nil)
+	heights, err := service.SubmitAll(ctx, blobs)
+	require.NoError(t, err)
+
+	// the large blob fills a PFB on its own, while the small ones share another
+	require.Len(t, submitter.batches, 2)
+	assert.Equal(t, []*Blob{large}, submitter.batches[0])
+	assert.Equal(t, blobs[:3], submitter.batches[1])
+	assert.Equal(t, []uint64{2, 2, 2, 1}, heights)
+
+	// the heights of the blobs included before a failure are reported along with the error
+	submitter = &batchSubmitter{fail: true, limit: 1}
+	service = NewService(submitter, nil, nil, nil)
+	heights, err = service.SubmitAll(ctx, blobs)
+	var partialErr *PartialSubmissionError
+	require.ErrorAs(t, err, &partialErr)
+	assert.Equal(t, []uint64{0, 0, 0, 1}, heights)
+	assert.Equal(t, heights, partialErr.Heights)
+	assert.Equal(t, "blob: submitted 1 of 4 blobs: out of funds", err.Error())
+
+	// the heights survive the RPC encoding of the error
+	raw, err := json.Marshal(partialErr)
+	require.NoError(t, err)
+	decoded := &PartialSubmissionError{}
+	require.NoError(t, json.Unmarshal(raw, decoded))
+	assert.Equal(t, partialErr.Heights, decoded.Heights)
+	assert.Equal(t, partialErr.Error(), decoded.Error())
+
+	// nothing is reported if no blob was included
+	submitter = &batchSubmitter{fail: true}
+	heights, err = NewService(submitter, nil, nil, nil).SubmitAll(ctx, blobs)
+	require.Error(t, err)
+	assert.False(t, errors.As(err, &partialErr))
+	assert.Nil(t, heights)
+}
+
+func TestPackBlobs(t *testing.T) {
+	sizes := []int{6, 3, 5, 2, 4, 11}
+	blobs := make([]*Blob, len(sizes))
+	for i, size := range sizes {
+		blobs[i] = &Blob{}
+		blobs[i].Data = make([]byte, size)
+	}
+
+	batches := packBlobs(blobs, 10)
+	// the oversized blob goes alone, and the rest fills batches by decreasing size
+	assert.Equal(t, [][]int{{5}, {0, 4}, {2, 1, 3}}, batches)
 }
@@ -0,0 +1,107 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// webhookTimeout bounds the time given to a webhook to accept the blobs.
+const webhookTimeout = time.Second * 30
+
+// SinkRecord is the record of the blobs published under a namespace at a height, as exported by
+// the sinks.
+type SinkRecord struct {
+	Height    uint64          `json:"height"`
+	Namespace share.Namespace `json:"namespace"`
+	Blobs     []*Blob         `json:"blobs"`
+}
+
+// DirSink writes the blobs of each height to a JSON encoded SinkRecord file under the directory,
+// at "<namespace>/<height>.json".
+type DirSink struct {
+	dir string
+}
+
+// NewDirSink creates a DirSink writing under the given directory.
+func NewDirSink(dir string) (*DirSink, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &DirSink{dir: dir}, nil
+}
+
+func (s *DirSink) Write(_ context.Context, height uint64, namespace share.Namespace, blobs []*Blob) error {
+	data, err := json.Marshal(&SinkRecord{Height: height, Namespace: namespace, Blobs: blobs})
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(s.dir, namespace.String())
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	// the file is written atomically, so that readers never see a partial record
+	path := filepath.Join(dir, strconv.FormatUint(height, 10)+".json")
+	if err = os.WriteFile(path+".tmp", data, 0o644); err != nil { //nolint:gosec
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// WebhookSink posts the blobs of each height as a JSON encoded SinkRecord to the URL. Any response
+// status other than 2xx is treated as a failure.
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSink creates a WebhookSink posting to the given URL.
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{
+		url:    url,
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+func (s *WebhookSink) Write(ctx context.Context, height uint64, namespace share.Namespace, blobs []*Blob) error {
+	data, err := json.Marshal(&SinkRecord{Height: height, Namespace: namespace, Blobs: blobs})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status: %s", resp.Status)
+	}
+	return nil
+}
+
+// MultiSink writes the blobs to all of the given sinks.
+type MultiSink []Sink
+
+func (s MultiSink) Write(ctx context.Context, height uint64, namespace share.Namespace, blobs []*Blob) error {
+	var errs []error
+	for _, sink := range s {
+		errs = append(errs, sink.Write(ctx, height, namespace, blobs))
+	}
+	return errors.Join(errs...)
+}
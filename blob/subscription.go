@@ -0,0 +1,53 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	libhead "github.com/celestiaorg/go-header"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+// subscriptionBackoff and maxSubscriptionBackoff bound the interval between the attempts to get
+// the next header of a failing subscription. The interval doubles after each failure and is reset
+// once a header is received.
+var (
+	subscriptionBackoff    = time.Millisecond * 100
+	maxSubscriptionBackoff = time.Second * 10
+)
+
+// handleHeaders calls handle for every header received from the subscription, until the context is
+// canceled or the subscription ends. The subscription is canceled on return.
+func handleHeaders(
+	ctx context.Context,
+	name string,
+	sub libhead.Subscription[*header.ExtendedHeader],
+	handle func(*header.ExtendedHeader),
+) {
+	defer sub.Cancel()
+
+	backoff := subscriptionBackoff
+	for {
+		h, err := sub.NextHeader(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+			log.Errorw(name+": getting next header", "err", err, "backoff", backoff)
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return
+			}
+			if backoff *= 2; backoff > maxSubscriptionBackoff {
+				backoff = maxSubscriptionBackoff
+			}
+			continue
+		}
+
+		backoff = subscriptionBackoff
+		handle(h)
+	}
+}
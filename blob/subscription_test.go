@@ -0,0 +1,66 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+)
+
+func TestHandleHeaders_Backoff(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	backoff, maxBackoff := subscriptionBackoff, maxSubscriptionBackoff
+	subscriptionBackoff, maxSubscriptionBackoff = time.Millisecond*20, time.Millisecond*40
+	t.Cleanup(func() {
+		subscriptionBackoff, maxSubscriptionBackoff = backoff, maxBackoff
+	})
+
+	// the subscription fails three times, then delivers a header and ends
+	sub := &failingSubscription{
+		failures: 3,
+		headers:  []*header.ExtendedHeader{headertest.RandExtendedHeader(t)},
+	}
+	var handled int
+	start := time.Now()
+	handleHeaders(ctx, "test", sub, func(*header.ExtendedHeader) {
+		handled++
+	})
+
+	assert.Equal(t, 1, handled)
+	assert.True(t, sub.canceled)
+	// the failures are backed off by 20, 40 and, capped, 40 milliseconds
+	require.GreaterOrEqual(t, time.Since(start), time.Millisecond*100)
+}
+
+// failingSubscription fails the given amount of times before delivering its headers and
+// ending with context.Canceled.
+type failingSubscription struct {
+	failures int
+	headers  []*header.ExtendedHeader
+	canceled bool
+}
+
+func (s *failingSubscription) NextHeader(context.Context) (*header.ExtendedHeader, error) {
+	if s.failures > 0 {
+		s.failures--
+		return nil, errors.New("failed")
+	}
+	if len(s.headers) == 0 {
+		return nil, context.Canceled
+	}
+	h := s.headers[0]
+	s.headers = s.headers[1:]
+	return h, nil
+}
+
+func (s *failingSubscription) Cancel() {
+	s.canceled = true
+}
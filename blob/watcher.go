@@ -0,0 +1,167 @@
+package blob
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	libhead "github.com/celestiaorg/go-header"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// watcherRetries is the amount of times the watcher retries to retrieve and export the blobs of a
+// namespace at a height before skipping it.
+const watcherRetries = 5
+
+// watcherPendingRetries is the amount of failed exports retried at once. Failed exports are
+// skipped while that many are being retried.
+const watcherPendingRetries = 64
+
+// watcherBackoff is the initial interval between the retries, doubled after each retry.
+var watcherBackoff = time.Second
+
+// Sink receives the blobs retrieved by the Watcher.
+type Sink interface {
+	// Write exports the blobs published under the namespace at the given height. It is called for
+	// every height with at least one blob in the namespace, in the order of heights. Failed writes
+	// are retried in the background, so a retried height may come after the later ones.
+	Write(ctx context.Context, height uint64, namespace share.Namespace, blobs []*Blob) error
+}
+
+// Watcher retrieves the blobs published under the watched namespaces in every new block and
+// writes them to a Sink, turning the node into a data feed for rollups.
+type Watcher struct {
+	getter     share.Getter
+	headerSub  libhead.Subscriber[*header.ExtendedHeader]
+	namespaces []share.Namespace
+	sink       Sink
+
+	retries chan struct{}
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// NewWatcher creates a Watcher writing the blobs of the given namespaces to the Sink.
+func NewWatcher(
+	getter share.Getter,
+	headerSub libhead.Subscriber[*header.ExtendedHeader],
+	namespaces []share.Namespace,
+	sink Sink,
+) *Watcher {
+	return &Watcher{
+		getter:     getter,
+		headerSub:  headerSub,
+		namespaces: namespaces,
+		sink:       sink,
+		retries:    make(chan struct{}, watcherPendingRetries),
+		done:       make(chan struct{}),
+	}
+}
+
+func (w *Watcher) Start(context.Context) error {
+	sub, err := w.headerSub.Subscribe()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	go w.subscribe(ctx, sub)
+	return nil
+}
+
+func (w *Watcher) Stop(ctx context.Context) error {
+	w.cancel()
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w *Watcher) subscribe(ctx context.Context, sub libhead.Subscription[*header.ExtendedHeader]) {
+	defer close(w.done)
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	handleHeaders(ctx, "watcher", sub, func(h *header.ExtendedHeader) {
+		for _, namespace := range w.namespaces {
+			err := w.exportOnce(ctx, h, namespace)
+			if err == nil || ctx.Err() != nil {
+				continue
+			}
+
+			// failed exports are retried aside, so that a failing sink does not hold off the
+			// later headers
+			select {
+			case w.retries <- struct{}{}:
+			default:
+				log.Errorw("watcher: too many pending retries, skipping blobs",
+					"height", h.Height(), "namespace", namespace.String(), "err", err)
+				continue
+			}
+			wg.Add(1)
+			go func(namespace share.Namespace) {
+				defer wg.Done()
+				defer func() { <-w.retries }()
+				w.retry(ctx, h, namespace, err)
+			}(namespace)
+		}
+	})
+}
+
+// retry retries writing the blobs published under the namespace in the given header to the sink
+// after the first attempt failed with the given error.
+func (w *Watcher) retry(ctx context.Context, h *header.ExtendedHeader, namespace share.Namespace, err error) {
+	backoff := watcherBackoff
+	for attempt := 1; ; attempt++ {
+		if attempt == watcherRetries {
+			log.Errorw("watcher: skipping blobs after retries",
+				"height", h.Height(), "namespace", namespace.String(), "err", err)
+			return
+		}
+		log.Warnw("watcher: exporting blobs",
+			"height", h.Height(), "namespace", namespace.String(), "attempt", attempt, "err", err)
+
+		select {
+		case <-time.After(backoff):
+			backoff *= 2
+		case <-ctx.Done():
+			return
+		}
+
+		err = w.exportOnce(ctx, h, namespace)
+		if err == nil || ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+func (w *Watcher) exportOnce(ctx context.Context, h *header.ExtendedHeader, namespace share.Namespace) error {
+	shares, err := w.getter.GetSharesByNamespace(ctx, h.DAH, namespace)
+	if err != nil {
+		return err
+	}
+	if len(shares) == 0 {
+		return nil
+	}
+
+	blobs, err := SharesToBlobs(shares.Flatten())
+	if err != nil {
+		return err
+	}
+	out := make([]*Blob, 0, len(blobs))
+	for _, blob := range blobs {
+		if blob != nil {
+			out = append(out, blob)
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return w.sink.Write(ctx, uint64(h.Height()), namespace, out)
+}
@@ -0,0 +1,174 @@
+package blob
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	mdutils "github.com/ipfs/go-merkledag/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	libheadtest "github.com/celestiaorg/go-header/headertest"
+
+	"github.com/celestiaorg/celestia-node/blob/blobtest"
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/getters"
+	"github.com/celestiaorg/celestia-node/share/ipld"
+	"github.com/celestiaorg/celestia-node/share/sharetest"
+)
+
+func TestWatcher_Export(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	appBlobs, err := blobtest.GenerateV0Blobs([]int{10, 6}, false)
+	require.NoError(t, err)
+	blobs, err := convertBlobs(appBlobs...)
+	require.NoError(t, err)
+	rawShares, err := BlobsToShares(blobs...)
+	require.NoError(t, err)
+	bs := mdutils.Bserv()
+	eds, err := ipld.AddShares(ctx, rawShares, bs)
+	require.NoError(t, err)
+	h := headertest.ExtendedHeaderFromEDS(t, 1, eds)
+
+	sink := &recordingSink{}
+	w := NewWatcher(getters.NewIPLDGetter(bs), nil, nil, sink)
+
+	err = w.exportOnce(ctx, h, blobs[0].Namespace())
+	require.NoError(t, err)
+	require.Len(t, sink.records, 1)
+	assert.EqualValues(t, 1, sink.records[0].Height)
+	assert.Equal(t, blobs[0].Namespace(), sink.records[0].Namespace)
+	require.Len(t, sink.records[0].Blobs, 1)
+	assert.Equal(t, blobs[0].Commitment, sink.records[0].Blobs[0].Commitment)
+
+	// heights without blobs under the namespace are not written
+	err = w.exportOnce(ctx, h, sharetest.RandV0Namespace())
+	require.NoError(t, err)
+	require.Len(t, sink.records, 1)
+}
+
+func TestWatcher_Retry(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	backoff := watcherBackoff
+	watcherBackoff = time.Millisecond * 100
+	t.Cleanup(func() {
+		watcherBackoff = backoff
+	})
+
+	appBlobs, err := blobtest.GenerateV0Blobs([]int{10, 6}, false)
+	require.NoError(t, err)
+	blobs, err := convertBlobs(appBlobs...)
+	require.NoError(t, err)
+	rawShares, err := BlobsToShares(blobs...)
+	require.NoError(t, err)
+	bs := mdutils.Bserv()
+	eds, err := ipld.AddShares(ctx, rawShares, bs)
+	require.NoError(t, err)
+	headers := []*header.ExtendedHeader{
+		headertest.ExtendedHeaderFromEDS(t, 1, eds),
+		headertest.ExtendedHeaderFromEDS(t, 2, eds),
+	}
+
+	// the first write fails, so the first height is only exported after the second one
+	sink := &recordingSink{fail: 1}
+	sub := &libheadtest.Subscriber[*header.ExtendedHeader]{Headers: headers}
+	w := NewWatcher(getters.NewIPLDGetter(bs), sub, []share.Namespace{blobs[0].Namespace()}, sink)
+	require.NoError(t, w.Start(ctx))
+	select {
+	case <-w.done:
+	case <-ctx.Done():
+		t.Fatal("watcher did not finish")
+	}
+	require.NoError(t, w.Stop(ctx))
+
+	require.Len(t, sink.records, 2)
+	assert.EqualValues(t, 2, sink.records[0].Height)
+	assert.EqualValues(t, 1, sink.records[1].Height)
+}
+
+func TestDirSink(t *testing.T) {
+	blob := newTestBlob(t)
+	dir := t.TempDir()
+	sink, err := NewDirSink(dir)
+	require.NoError(t, err)
+
+	err = sink.Write(context.Background(), 7, blob.Namespace(), []*Blob{blob})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(dir, blob.Namespace().String(), "7.json"))
+	require.NoError(t, err)
+	var record SinkRecord
+	require.NoError(t, json.Unmarshal(data, &record))
+	assert.EqualValues(t, 7, record.Height)
+	require.Len(t, record.Blobs, 1)
+	assert.Equal(t, blob.Commitment, record.Blobs[0].Commitment)
+}
+
+func TestWebhookSink(t *testing.T) {
+	blob := newTestBlob(t)
+	status := http.StatusOK
+	records := make(chan SinkRecord, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var record SinkRecord
+		if err := json.NewDecoder(r.Body).Decode(&record); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		records <- record
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	sink := NewWebhookSink(srv.URL)
+
+	err := sink.Write(context.Background(), 3, blob.Namespace(), []*Blob{blob})
+	require.NoError(t, err)
+	record := <-records
+	assert.EqualValues(t, 3, record.Height)
+	require.Len(t, record.Blobs, 1)
+	assert.Equal(t, blob.Commitment, record.Blobs[0].Commitment)
+
+	status = http.StatusInternalServerError
+	err = sink.Write(context.Background(), 4, blob.Namespace(), []*Blob{blob})
+	require.Error(t, err)
+	record = <-records
+	assert.EqualValues(t, 4, record.Height)
+}
+
+func newTestBlob(t *testing.T) *Blob {
+	appBlobs, err := blobtest.GenerateV0Blobs([]int{1}, false)
+	require.NoError(t, err)
+	blobs, err := convertBlobs(appBlobs...)
+	require.NoError(t, err)
+	return blobs[0]
+}
+
+type recordingSink struct {
+	lock    sync.Mutex
+	fail    int
+	records []SinkRecord
+}
+
+func (s *recordingSink) Write(_ context.Context, height uint64, namespace share.Namespace, blobs []*Blob) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.fail > 0 {
+		s.fail--
+		return errors.New("failed write")
+	}
+	s.records = append(s.records, SinkRecord{Height: height, Namespace: namespace, Blobs: blobs})
+	return nil
+}
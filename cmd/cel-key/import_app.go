@@ -0,0 +1,114 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/celestiaorg/celestia-app/app"
+)
+
+var (
+	appKeyringDirKey     = "app.keyring-dir"
+	appKeyringBackendKey = "app.keyring-backend"
+)
+
+// ImportAppCmd imports accounts from an existing celestia-app keyring into the node's keyring.
+func ImportAppCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import-app [name...]",
+		Short: "Import keys from a celestia-app keyring into the node's keyring",
+		Long: "Import private keys from an existing celestia-app (or any cosmos-sdk) keyring into the " +
+			"node's keyring. If no names are given, all keys holding a private key are imported. " +
+			"Keys that already exist in the node's keyring are skipped.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			dir := cmd.Flag(appKeyringDirKey).Value.String()
+			if dir == "" {
+				return fmt.Errorf("--%s must be provided", appKeyringDirKey)
+			}
+			backend := cmd.Flag(appKeyringBackendKey).Value.String()
+
+			src, err := keyring.New(app.Name, backend, dir, clientCtx.Input, clientCtx.Codec)
+			if err != nil {
+				return fmt.Errorf("opening celestia-app keyring: %w", err)
+			}
+			return importAppKeys(src, clientCtx.Keyring, args, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().String(appKeyringDirKey, "", "The directory of the celestia-app keyring to import keys "+
+		"from, e.g. ~/.celestia-app.")
+	cmd.Flags().String(appKeyringBackendKey, keyring.BackendOS, "The backend of the celestia-app keyring "+
+		"to import keys from (os|file|test).")
+	return cmd
+}
+
+// importAppKeys copies the private keys with the given names from src to dst. All local keys of src
+// are copied if no names are given.
+func importAppKeys(src, dst keyring.Keyring, names []string, out io.Writer) error {
+	if len(names) == 0 {
+		records, err := src.List()
+		if err != nil {
+			return err
+		}
+		for _, record := range records {
+			if record.GetType() != keyring.TypeLocal {
+				fmt.Fprintf(out, "skipping %s: %s keys hold no private key to import\n",
+					record.Name, record.GetType())
+				continue
+			}
+			names = append(names, record.Name)
+		}
+	}
+
+	// the armored key never leaves memory, so a one-time passphrase is used to move it between keyrings
+	rnd := make([]byte, 32)
+	if _, err := rand.Read(rnd); err != nil {
+		return err
+	}
+	passphrase := hex.EncodeToString(rnd)
+
+	var imported int
+	for _, name := range names {
+		if _, err := dst.Key(name); err == nil {
+			fmt.Fprintf(out, "skipping %s: key already exists in the node's keyring\n", name)
+			continue
+		} else if !errors.Is(err, sdkerrors.ErrKeyNotFound) {
+			return err
+		}
+
+		armor, err := src.ExportPrivKeyArmor(name, passphrase)
+		if err != nil {
+			return fmt.Errorf("exporting %s: %w", name, err)
+		}
+		if err = dst.ImportPrivKey(name, armor, passphrase); err != nil {
+			return fmt.Errorf("importing %s: %w", name, err)
+		}
+
+		record, err := dst.Key(name)
+		if err != nil {
+			return err
+		}
+		addr, err := record.GetAddress()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "imported %s: %s\n", name, addr.String())
+		imported++
+	}
+
+	fmt.Fprintf(out, "imported %d key(s)\n", imported)
+	return nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-app/app"
+)
+
+func Test_importAppKeys(t *testing.T) {
+	newKeyring := func() keyring.Keyring {
+		ring, err := keyring.New(app.Name, keyring.BackendTest, t.TempDir(), nil, encodingConfig.Codec)
+		require.NoError(t, err)
+		return ring
+	}
+	src, dst := newKeyring(), newKeyring()
+
+	for _, name := range []string{"validator", "rollup"} {
+		_, _, err := src.NewMnemonic(name, keyring.English, "", "", hd.Secp256k1)
+		require.NoError(t, err)
+	}
+	// keys already in the node's keyring are not overwritten
+	existing, _, err := dst.NewMnemonic("rollup", keyring.English, "", "", hd.Secp256k1)
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	require.NoError(t, importAppKeys(src, dst, nil, out))
+	require.Contains(t, out.String(), "imported 1 key(s)")
+
+	for _, name := range []string{"validator", "rollup"} {
+		record, err := dst.Key(name)
+		require.NoError(t, err)
+		expected, err := src.Key(name)
+		require.NoError(t, err)
+		if name == "rollup" {
+			expected = existing
+		}
+		addr, err := record.GetAddress()
+		require.NoError(t, err)
+		expectedAddr, err := expected.GetAddress()
+		require.NoError(t, err)
+		require.Equal(t, expectedAddr, addr)
+	}
+
+	require.Error(t, importAppKeys(src, dst, []string{"unknown"}, out))
+}
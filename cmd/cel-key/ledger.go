@@ -0,0 +1,131 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/crypto/ledger"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ledgerAccountKey = "account"
+	ledgerIndexKey   = "index"
+	ledgerCountKey   = "count"
+)
+
+// LedgerCmd groups the commands managing accounts kept on a Ledger device. Transactions of the
+// node using a Ledger-backed account are signed on the device, so it has to be connected and
+// unlocked with the Cosmos app open while the node submits them.
+// NOTE: Binaries have to be built with the 'ledger' build tag to access the device.
+func LedgerCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ledger",
+		Short: "Manage accounts kept on a Ledger device",
+	}
+	cmd.AddCommand(ledgerListCmd(), ledgerImportCmd())
+	return cmd
+}
+
+func ledgerListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List addresses of the accounts on the connected Ledger device",
+		Long: "List the addresses derived by the connected Ledger device for the given account, along with " +
+			"the names they are imported under into the node's keyring, if any.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			account, err := cmd.Flags().GetUint32(ledgerAccountKey)
+			if err != nil {
+				return err
+			}
+			count, err := cmd.Flags().GetUint32(ledgerCountKey)
+			if err != nil {
+				return err
+			}
+			return listLedgerAddresses(clientCtx.Keyring, account, count, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().Uint32(ledgerAccountKey, 0, "The account number of the HD derivation path.")
+	cmd.Flags().Uint32(ledgerCountKey, 5, "The amount of address indexes to list.")
+	return cmd
+}
+
+func ledgerImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <name>",
+		Short: "Import an account of the connected Ledger device into the node's keyring",
+		Long: "Import a reference to an account of the connected Ledger device into the node's keyring. " +
+			"Only the public key is stored, and the address is displayed on the device for verification. " +
+			"Start the node with --keyring.accname <name> to sign its transactions with the account.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			account, err := cmd.Flags().GetUint32(ledgerAccountKey)
+			if err != nil {
+				return err
+			}
+			index, err := cmd.Flags().GetUint32(ledgerIndexKey)
+			if err != nil {
+				return err
+			}
+
+			cfg := sdk.GetConfig()
+			record, err := clientCtx.Keyring.SaveLedgerKey(args[0], hd.Secp256k1,
+				cfg.GetBech32AccountAddrPrefix(), cfg.GetCoinType(), account, index)
+			if err != nil {
+				return fmt.Errorf("importing ledger account: %w", err)
+			}
+			addr, err := record.GetAddress()
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "NAME: %s\nADDRESS: %s\nPATH: %s\n",
+				record.Name, addr, hd.NewFundraiserParams(account, cfg.GetCoinType(), index))
+			return nil
+		},
+	}
+
+	cmd.Flags().Uint32(ledgerAccountKey, 0, "The account number of the HD derivation path.")
+	cmd.Flags().Uint32(ledgerIndexKey, 0, "The address index of the HD derivation path.")
+	return cmd
+}
+
+// listLedgerAddresses prints the addresses of the first 'count' indexes of the account on the
+// device, marking the ones present in the keyring.
+func listLedgerAddresses(ring keyring.Keyring, account, count uint32, out io.Writer) error {
+	coinType := sdk.GetConfig().GetCoinType()
+	for index := uint32(0); index < count; index++ {
+		path := hd.NewFundraiserParams(account, coinType, index)
+		priv, err := ledger.NewPrivKeySecp256k1Unsafe(*path)
+		if err != nil {
+			return fmt.Errorf("reading ledger device: %w", err)
+		}
+		addr := sdk.AccAddress(priv.PubKey().Address())
+
+		name := "-"
+		record, err := ring.KeyByAddress(addr)
+		switch {
+		case err == nil:
+			name = record.Name
+		case !errors.Is(err, sdkerrors.ErrKeyNotFound):
+			return err
+		}
+		fmt.Fprintf(out, "%s\t%s\t%s\n", path, addr, name)
+	}
+	return nil
+}
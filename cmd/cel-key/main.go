@@ -32,6 +32,7 @@ var initClientCtx = client.Context{}.
 var rootCmd = keys.Commands("~")
 
 func init() {
+	rootCmd.AddCommand(ImportAppCmd(), LedgerCmd())
 	rootCmd.PersistentFlags().AddFlagSet(DirectoryFlags())
 	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, _ []string) error {
 		initClientCtx, err := client.ReadPersistentCommandFlags(initClientCtx, cmd.Flags())
@@ -0,0 +1,11 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var benchCmd = &cobra.Command{
+	Use:   "bench [subcommand]",
+	Short: "Benchmark node components on this machine",
+	Args:  cobra.NoArgs,
+}
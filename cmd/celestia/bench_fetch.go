@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/spf13/cobra"
+
+	"github.com/celestiaorg/celestia-node/api/rpc/client"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+var benchFetchCmd = &cobra.Command{
+	Use:   "fetch",
+	Short: "Benchmark retrieval of block data by a running node",
+	Long: "Benchmark how fast the running node retrieves data from the network. For every height in " +
+		"the given range, the node is requested over RPC for the whole EDS and, if a namespace is " +
+		"given, for the shares of the namespace. The report lists the latency percentiles and the " +
+		"throughput of each method. Data the node already stores is served locally, so light nodes " +
+		"give the most accurate view of the network.",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		heights, err := cmd.Flags().GetString("heights")
+		if err != nil {
+			return err
+		}
+		from, to, err := parseHeightRange(heights)
+		if err != nil {
+			return err
+		}
+
+		var namespace share.Namespace
+		if ns := cmd.Flag("namespace").Value.String(); ns != "" {
+			namespace, err = parseV0Namespace(ns)
+			if err != nil {
+				return fmt.Errorf("parsing namespace: %w", err)
+			}
+		}
+
+		peers, err := cmd.Flags().GetStringSlice("peers")
+		if err != nil {
+			return err
+		}
+		addrs := make([]ma.Multiaddr, len(peers))
+		for i, p := range peers {
+			addrs[i], err = ma.NewMultiaddr(p)
+			if err != nil {
+				return fmt.Errorf("parsing peer address %s: %w", p, err)
+			}
+		}
+		infos, err := peer.AddrInfosFromP2pAddrs(addrs...)
+		if err != nil {
+			return err
+		}
+
+		token := authTokenFlag
+		if token == "" {
+			token = os.Getenv(authEnvKey)
+		}
+		cl, err := client.NewClient(cmd.Context(), requestURL, token)
+		if err != nil {
+			return err
+		}
+		defer cl.Close()
+
+		return benchFetch(cmd.Context(), cl, from, to, namespace, infos, cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	benchFetchCmd.Flags().String("heights", "", "Range of heights to fetch, e.g. 100..200")
+	benchFetchCmd.Flags().String("namespace", "", "Namespace to fetch shares of, in hex (0x...) or base64. "+
+		"Only the EDS is fetched if not given")
+	benchFetchCmd.Flags().StringSlice("peers", nil, "Comma-separated multiaddresses of peers for the node "+
+		"to connect to before fetching")
+	benchFetchCmd.Flags().StringVar(&requestURL, "url", "http://localhost:26658", "Request URL")
+	benchFetchCmd.Flags().StringVar(&authTokenFlag, "auth", "", "Authorization token (if not provided, the "+
+		authEnvKey+" environment variable will be used)")
+	_ = benchFetchCmd.MarkFlagRequired("heights")
+	benchCmd.AddCommand(benchFetchCmd)
+}
+
+// benchFetch requests the data of every height in the range from the node and writes the report.
+func benchFetch(
+	ctx context.Context,
+	cl *client.Client,
+	from, to uint64,
+	namespace share.Namespace,
+	peers []peer.AddrInfo,
+	out io.Writer,
+) error {
+	for _, p := range peers {
+		if err := cl.P2P.Connect(ctx, p); err != nil {
+			return fmt.Errorf("connecting to %s: %w", p.ID, err)
+		}
+	}
+
+	var eds, nd fetchReport
+	start := time.Now()
+	for height := from; height <= to; height++ {
+		h, err := cl.Header.GetByHeight(ctx, height)
+		if err != nil {
+			return fmt.Errorf("getting header at height %d: %w", height, err)
+		}
+
+		reqStart := time.Now()
+		square, err := cl.Share.GetEDS(ctx, h.DAH)
+		var size int
+		if err == nil {
+			size = int(square.Width()*square.Width()) * share.Size
+		}
+		eds.add(time.Since(reqStart), size, err)
+
+		if namespace == nil {
+			continue
+		}
+		reqStart = time.Now()
+		shares, err := cl.Share.GetSharesByNamespace(ctx, h.DAH, namespace)
+		nd.add(time.Since(reqStart), len(shares.Flatten())*share.Size, err)
+	}
+
+	fmt.Fprintf(out, "Heights: %d..%d (%d blocks) in %v\n",
+		from, to, to-from+1, time.Since(start).Round(time.Millisecond))
+	eds.print(out, "GetEDS")
+	if namespace != nil {
+		nd.print(out, "GetSharesByNamespace")
+	}
+	return nil
+}
+
+// fetchReport collects the results of requests to a method.
+type fetchReport struct {
+	latencies []time.Duration
+	failures  int
+	size      int
+}
+
+// add records a request that took the given time to retrieve the given amount of bytes.
+func (r *fetchReport) add(took time.Duration, size int, err error) {
+	if err != nil {
+		r.failures++
+		return
+	}
+	r.latencies = append(r.latencies, took)
+	r.size += size
+}
+
+// print writes the latency percentiles and the throughput of successful requests.
+func (r *fetchReport) print(out io.Writer, method string) {
+	fmt.Fprintf(out, "%s: %d requests, %d failed\n", method, len(r.latencies)+r.failures, r.failures)
+	if len(r.latencies) == 0 {
+		return
+	}
+
+	sorted := make([]time.Duration, len(r.latencies))
+	copy(sorted, r.latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	var total time.Duration
+	for _, l := range sorted {
+		total += l
+	}
+	percentile := func(p int) time.Duration {
+		return sorted[(len(sorted)*p-1)/100]
+	}
+
+	fmt.Fprintf(out, "  latency: min %v, p50 %v, p95 %v, p99 %v, max %v\n",
+		sorted[0], percentile(50), percentile(95), percentile(99), sorted[len(sorted)-1])
+	fmt.Fprintf(out, "  throughput: %.2f req/s, %.2f MiB/s\n",
+		float64(len(sorted))/total.Seconds(), float64(r.size)/(1<<20)/total.Seconds())
+}
+
+// parseHeightRange parses an inclusive range of heights in the form of "from..to".
+func parseHeightRange(s string) (from, to uint64, err error) {
+	fromStr, toStr, ok := strings.Cut(s, "..")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid height range %q, expected from..to", s)
+	}
+	from, err = strconv.ParseUint(fromStr, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid height range %q: %w", s, err)
+	}
+	to, err = strconv.ParseUint(toStr, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid height range %q: %w", s, err)
+	}
+	if from == 0 || from > to {
+		return 0, 0, fmt.Errorf("invalid height range %q, expected 0 < from <= to", s)
+	}
+	return from, to, nil
+}
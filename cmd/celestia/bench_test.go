@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHeightRange(t *testing.T) {
+	from, to, err := parseHeightRange("5..10")
+	require.NoError(t, err)
+	require.EqualValues(t, 5, from)
+	require.EqualValues(t, 10, to)
+
+	for _, invalid := range []string{"", "5", "5..", "..10", "a..b", "10..5", "0..5"} {
+		_, _, err = parseHeightRange(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestFetchReport(t *testing.T) {
+	var report fetchReport
+	for i := 1; i <= 100; i++ {
+		report.add(time.Duration(i)*time.Millisecond, 1<<20, nil)
+	}
+	report.add(time.Second, 0, errors.New("fail"))
+
+	out := &bytes.Buffer{}
+	report.print(out, "GetEDS")
+	require.Equal(t, "GetEDS: 101 requests, 1 failed\n"+
+		"  latency: min 1ms, p50 50ms, p95 95ms, p99 99ms, max 100ms\n"+
+		"  throughput: 19.80 req/s, 19.80 MiB/s\n", out.String())
+}
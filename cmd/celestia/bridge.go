@@ -5,12 +5,19 @@ import (
 	"github.com/spf13/pflag"
 
 	cmdnode "github.com/celestiaorg/celestia-node/cmd"
+	"github.com/celestiaorg/celestia-node/nodebuilder/clock"
 	"github.com/celestiaorg/celestia-node/nodebuilder/core"
+	"github.com/celestiaorg/celestia-node/nodebuilder/features"
 	"github.com/celestiaorg/celestia-node/nodebuilder/gateway"
+	"github.com/celestiaorg/celestia-node/nodebuilder/health"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+	"github.com/celestiaorg/celestia-node/nodebuilder/pruner"
+	"github.com/celestiaorg/celestia-node/nodebuilder/quota"
 	"github.com/celestiaorg/celestia-node/nodebuilder/rpc"
 	"github.com/celestiaorg/celestia-node/nodebuilder/state"
+	"github.com/celestiaorg/celestia-node/nodebuilder/watchdog"
+	"github.com/celestiaorg/celestia-node/nodebuilder/watcher"
 )
 
 // NOTE: We should always ensure that the added Flags below are parsed somewhere, like in the
@@ -24,16 +31,29 @@ func init() {
 		cmdnode.MiscFlags(),
 		rpc.Flags(),
 		gateway.Flags(),
+		health.Flags(),
+		pruner.Flags(),
+		quota.Flags(),
+		clock.Flags(),
+		watchdog.Flags(),
 		state.Flags(),
+		features.Flags(),
+		watcher.Flags(),
 	}
 
 	bridgeCmd.AddCommand(
-		cmdnode.Init(flags...),
+		cmdnode.Init(append(flags, cmdnode.SnapshotFlags())...),
 		cmdnode.Start(flags...),
 		cmdnode.AuthCmd(flags...),
 		cmdnode.ResetStore(flags...),
 		cmdnode.RemoveConfigCmd(flags...),
 		cmdnode.UpdateConfigCmd(flags...),
+		cmdnode.ConfigCmd(flags...),
+		cmdnode.Doctor(flags...),
+		cmdnode.Graph(flags...),
+		debugCmd(flags...),
+		storeCmd(flags...),
+		snapshotCmd(flags...),
 	)
 }
 
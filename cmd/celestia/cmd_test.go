@@ -1,15 +1,21 @@
 package main
 
 import (
+	"archive/tar"
 	"bytes"
+	"compress/gzip"
 	"context"
+	"io"
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
 
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
 )
 
 func TestCompletionHelpString(t *testing.T) {
@@ -128,3 +134,76 @@ func TestBridge(t *testing.T) {
 			})
 	*/
 }
+
+func TestCompletion(t *testing.T) {
+	require.NoError(t, registerFlagCompletions(rootCmd))
+	t.Cleanup(func() {
+		rootCmd.SetOut(nil)
+		rootCmd.SetArgs(nil)
+	})
+
+	complete := func(args ...string) string {
+		output := &bytes.Buffer{}
+		rootCmd.SetOut(output)
+		rootCmd.SetArgs(append([]string{cobra.ShellCompRequestCmd}, args...))
+		require.NoError(t, rootCmd.ExecuteContext(context.Background()))
+		return output.String()
+	}
+
+	require.Contains(t, complete(""), "light")
+	require.Contains(t, complete("light", ""), "start")
+	require.Contains(t, complete("light", "start", "--p2p.network", ""), string(p2p.Mocha))
+	require.NotContains(t, complete("light", "start", "--p2p.network", ""), string(p2p.Private))
+	require.Contains(t, complete("full", "start", "--log.level", ""), "debug")
+	require.Contains(t, complete("bridge", "start", "--log.level.module", "share="), "share=warn")
+
+	output := &bytes.Buffer{}
+	rootCmd.SetOut(output)
+	rootCmd.SetArgs([]string{"completion", "zsh"})
+	require.NoError(t, rootCmd.ExecuteContext(context.Background()))
+	require.Contains(t, output.String(), "#compdef celestia")
+}
+
+func TestDebugBundle(t *testing.T) {
+	dir := t.TempDir()
+	bundlePath := filepath.Join(dir, "bundle.tar.gz")
+	t.Cleanup(func() {
+		rootCmd.SetOut(nil)
+		rootCmd.SetArgs(nil)
+	})
+
+	output := &bytes.Buffer{}
+	rootCmd.SetOut(output)
+	rootCmd.SetArgs([]string{
+		"light",
+		"--node.store", filepath.Join(dir, ".celestia-light"),
+		"debug", "bundle",
+		"--out", bundlePath,
+		// no node is running, so only the local state is collected
+		"--url", "http://127.0.0.1:1",
+	})
+	require.NoError(t, rootCmd.ExecuteContext(context.Background()))
+	require.Contains(t, output.String(), "see errors.txt")
+
+	f, err := os.Open(bundlePath)
+	require.NoError(t, err)
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	require.NoError(t, err)
+
+	files := make(map[string]string)
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		data, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[hdr.Name] = string(data)
+	}
+	require.Contains(t, files, "version.json")
+	require.Contains(t, files["config.toml"], "[Header]")
+	require.Contains(t, files["errors.txt"], "running node")
+}
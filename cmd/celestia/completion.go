@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	cmdnode "github.com/celestiaorg/celestia-node/cmd"
+	"github.com/celestiaorg/celestia-node/nodebuilder/logging"
+	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+)
+
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "Generate the autocompletion script for the given shell",
+	Long: `Generate the autocompletion script of celestia for the given shell. Besides the node types,
+subcommands and flags, the script completes the values of flags like --p2p.network and --log.level.
+
+Bash (requires the bash-completion package):
+  $ source <(celestia completion bash)
+  # to load the completions for every new session, on Linux:
+  $ celestia completion bash > /etc/bash_completion.d/celestia
+  # on macOS:
+  $ celestia completion bash > $(brew --prefix)/etc/bash_completion.d/celestia
+
+Zsh:
+  # if shell completion is not enabled yet, enable it once with:
+  $ echo "autoload -U compinit; compinit" >> ~/.zshrc
+  $ celestia completion zsh > "${fpath[1]}/_celestia"
+
+Fish:
+  $ celestia completion fish > ~/.config/fish/completions/celestia.fish
+
+PowerShell:
+  PS> celestia completion powershell | Out-String | Invoke-Expression
+  # to load the completions for every new session, add the output of the command above to the
+  # PowerShell profile.
+
+Start a new shell for the completions to take effect.`,
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		switch args[0] {
+		case "bash":
+			return cmd.Root().GenBashCompletionV2(out, true)
+		case "zsh":
+			return cmd.Root().GenZshCompletion(out)
+		case "fish":
+			return cmd.Root().GenFishCompletion(out, true)
+		case "powershell":
+			return cmd.Root().GenPowerShellCompletionWithDesc(out)
+		default:
+			return fmt.Errorf("unsupported shell: %s", args[0])
+		}
+	},
+}
+
+// registerFlagCompletions registers the completions of the flag values for the command and all of
+// its subcommands. The subcommands of a node type share their flags, while cobra keeps the
+// completions per flag, so each flag is registered once.
+func registerFlagCompletions(root *cobra.Command) error {
+	completions := cmdnode.FlagCompletions()
+	for name, complete := range p2p.FlagCompletions() {
+		completions[name] = complete
+	}
+	for name, complete := range logging.FlagCompletions() {
+		completions[name] = complete
+	}
+
+	registered := make(map[*pflag.Flag]bool)
+	var register func(cmd *cobra.Command) error
+	register = func(cmd *cobra.Command) error {
+		for name, complete := range completions {
+			flag := cmd.Flags().Lookup(name)
+			if flag == nil || registered[flag] {
+				continue
+			}
+			if err := cmd.RegisterFlagCompletionFunc(name, complete); err != nil {
+				return err
+			}
+			registered[flag] = true
+		}
+		for _, sub := range cmd.Commands() {
+			if err := register(sub); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return register(root)
+}
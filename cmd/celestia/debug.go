@@ -0,0 +1,203 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/celestiaorg/celestia-node/api/rpc/client"
+	cmdnode "github.com/celestiaorg/celestia-node/cmd"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+// debugRequestTimeout bounds the time given to collect the state of the running node.
+const debugRequestTimeout = 30 * time.Second
+
+// debugCmd constructs a CLI command to help debugging the node.
+func debugCmd(fsets ...*flag.FlagSet) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "debug [subcommand]",
+		Short: "Helps debugging the node",
+		Args:  cobra.NoArgs,
+	}
+	cmd.AddCommand(debugBundleCmd(fsets...))
+	return cmd
+}
+
+func debugBundleCmd(fsets ...*flag.FlagSet) *cobra.Command {
+	var out string
+	cmd := &cobra.Command{
+		Use:   "bundle",
+		Short: "Collects the state of the node into a tarball to attach to bug reports",
+		Long: "Collects the version, the config with its secrets redacted and, from the running node " +
+			"over RPC, its sync and DAS state, goroutines, most recent logs and metrics into a gzipped " +
+			"tarball. Requesting the running node requires an admin token. Whatever could not be " +
+			"collected is listed in the errors.txt of the bundle.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			path := out
+			if path == "" {
+				path = fmt.Sprintf("celestia-%s-debug-%s.tar.gz",
+					strings.ToLower(cmdnode.NodeType(ctx).String()), time.Now().UTC().Format("20060102T150405Z"))
+			}
+
+			bundle := &debugBundle{}
+			bundle.addJSON("version.json", node.GetBuildInfo())
+			var encoded bytes.Buffer
+			if cfg, err := cmdnode.NodeConfig(ctx); err != nil {
+				bundle.fail("config", err)
+			} else if err := cfg.Redacted().Encode(&encoded); err != nil {
+				bundle.fail("config", err)
+			} else {
+				bundle.add("config.toml", encoded.Bytes())
+			}
+
+			token := authTokenFlag
+			if token == "" {
+				token = os.Getenv(authEnvKey)
+			}
+			ctx, cancel := context.WithTimeout(ctx, debugRequestTimeout)
+			defer cancel()
+			collectNodeState(ctx, bundle, cmdnode.NodeType(ctx), token)
+
+			if err := bundle.write(path); err != nil {
+				return err
+			}
+			if len(bundle.errs) > 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "some of the state could not be collected, see errors.txt\n")
+			}
+			_, err := fmt.Fprintf(cmd.OutOrStdout(), "wrote debug bundle to %s\n", path)
+			return err
+		},
+	}
+
+	for _, set := range fsets {
+		cmd.Flags().AddFlagSet(set)
+	}
+	cmd.Flags().StringVar(&out, "out", "", "Path of the bundle (default: celestia-<type>-debug-<time>.tar.gz)")
+	cmd.Flags().StringVar(&requestURL, "url", "http://localhost:26658", "Request URL of the running node")
+	cmd.Flags().StringVar(&authTokenFlag, "auth", "", "Admin token of the running node (if not provided, the "+
+		authEnvKey+" environment variable will be used)")
+	return cmd
+}
+
+// collectNodeState requests the state of the running node, skipping it if the node is unreachable.
+func collectNodeState(ctx context.Context, bundle *debugBundle, tp node.Type, token string) {
+	cl, err := client.NewClient(ctx, requestURL, token)
+	if err != nil {
+		bundle.fail("running node", err)
+		return
+	}
+	defer cl.Close()
+
+	info, err := cl.Node.Info(ctx)
+	if err != nil {
+		bundle.fail("running node", err)
+		return
+	}
+	bundle.addJSON("node.json", info)
+
+	if state, err := cl.Header.SyncState(ctx); err != nil {
+		bundle.fail("sync state", err)
+	} else {
+		bundle.addJSON("sync.json", state)
+	}
+	if tp != node.Bridge {
+		if stats, err := cl.DAS.SamplingStats(ctx); err != nil {
+			bundle.fail("DAS state", err)
+		} else {
+			bundle.addJSON("das.json", stats)
+		}
+	}
+
+	snapshot, err := cl.Node.DebugSnapshot(ctx)
+	if err != nil {
+		bundle.fail("debug snapshot", err)
+		return
+	}
+	bundle.add("goroutines.txt", []byte(snapshot.Goroutines))
+	bundle.add("logs.txt", []byte(strings.Join(snapshot.Logs, "\n")))
+	if len(snapshot.Metrics) > 0 {
+		var metrics bytes.Buffer
+		if err := json.Indent(&metrics, snapshot.Metrics, "", "  "); err != nil {
+			bundle.fail("metrics", err)
+		} else {
+			bundle.add("metrics.json", metrics.Bytes())
+		}
+	}
+}
+
+// debugBundle gathers the files of the bundle along with the errors collecting them.
+type debugBundle struct {
+	names []string
+	files map[string][]byte
+	errs  []string
+}
+
+func (b *debugBundle) add(name string, data []byte) {
+	if b.files == nil {
+		b.files = make(map[string][]byte)
+	}
+	b.names = append(b.names, name)
+	b.files[name] = data
+}
+
+func (b *debugBundle) addJSON(name string, v interface{}) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		b.fail(name, err)
+		return
+	}
+	b.add(name, data)
+}
+
+func (b *debugBundle) fail(what string, err error) {
+	b.errs = append(b.errs, fmt.Sprintf("%s: %s", what, err))
+}
+
+// write writes the bundle as a gzipped tarball readable only by the user, as the state of the node
+// may still be sensitive.
+func (b *debugBundle) write(path string) (err error) {
+	if len(b.errs) > 0 {
+		b.add("errors.txt", []byte(strings.Join(b.errs, "\n")+"\n"))
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+	for _, name := range b.names {
+		data := b.files[name]
+		hdr := &tar.Header{Name: name, Mode: 0o600, Size: int64(len(data)), ModTime: now}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
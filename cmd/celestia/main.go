@@ -13,6 +13,8 @@ func init() {
 		lightCmd,
 		fullCmd,
 		versionCmd,
+		benchCmd,
+		completionCmd,
 	)
 	rootCmd.SetHelpCommand(&cobra.Command{})
 }
@@ -25,6 +27,9 @@ func main() {
 }
 
 func run() error {
+	if err := registerFlagCompletions(rootCmd); err != nil {
+		return err
+	}
 	return rootCmd.ExecuteContext(context.Background())
 }
 
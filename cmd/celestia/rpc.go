@@ -150,6 +150,28 @@ func parseParams(method string, params []string) []interface{} {
 		parsedParams[0] = []*blob.Blob{parsedBlob}
 		// param count doesn't match input length, so cut off nil values
 		return parsedParams[:1]
+	case "SubmitAll":
+		// pairs of namespace and blob data
+		if len(params)%2 != 0 {
+			panic("Error parsing blobs: every namespace has to be followed by blob data.")
+		}
+		blobs := make([]*blob.Blob, 0, len(params)/2)
+		for i := 0; i < len(params); i += 2 {
+			namespace, err := parseV0Namespace(params[i])
+			if err != nil {
+				panic(fmt.Sprintf("Error parsing namespace: %v", err))
+			}
+			blobData, err := decodeToBytes(params[i+1])
+			if err != nil {
+				panic("Error decoding blob data: hex or base64 string could not be decoded.")
+			}
+			parsedBlob, err := blob.NewBlobV0(namespace, blobData)
+			if err != nil {
+				panic(fmt.Sprintf("Error creating blob: %v", err))
+			}
+			blobs = append(blobs, parsedBlob)
+		}
+		return []interface{}{blobs}
 	case "SubmitPayForBlob":
 		// 1. Fee (state.Int is a string)
 		parsedParams[0] = params[0]
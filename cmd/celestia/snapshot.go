@@ -0,0 +1,75 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	cmdnode "github.com/celestiaorg/celestia-node/cmd"
+	"github.com/celestiaorg/celestia-node/nodebuilder"
+)
+
+// snapshotCmd constructs a CLI command to manage snapshots of the node's store.
+func snapshotCmd(fsets ...*flag.FlagSet) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot [subcommand]",
+		Short: "Manages snapshots of the node's store",
+		Args:  cobra.NoArgs,
+	}
+	cmd.AddCommand(createSnapshotCmd(fsets...))
+	return cmd
+}
+
+func createSnapshotCmd(fsets ...*flag.FlagSet) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create <out.tar>",
+		Short: "Creates a snapshot of the node's store",
+		Long: "Archives the datastore, the EDS store and the DAS checkpoint along with their checksums, " +
+			"so new nodes can bootstrap from it with 'init --init-from-snapshot'. " +
+			"Requires the node being stopped.",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			ctx := cmd.Context()
+			store, err := nodebuilder.OpenStoreReadOnly(cmdnode.StorePath(ctx), nil)
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			out, err := os.OpenFile(args[0], os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err != nil {
+					os.Remove(args[0]) //nolint:errcheck
+				}
+			}()
+			defer out.Close()
+
+			sum := sha256.New()
+			manifest, err := nodebuilder.ExportSnapshot(ctx, store, cmdnode.NodeType(ctx), io.MultiWriter(out, sum))
+			if err != nil {
+				return err
+			}
+			if err = out.Close(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "created snapshot of %d files taken at height %d\nsha256: %s\n",
+				len(manifest.Files), manifest.Head, hex.EncodeToString(sum.Sum(nil)))
+			return nil
+		},
+	}
+
+	for _, set := range fsets {
+		cmd.Flags().AddFlagSet(set)
+	}
+	return cmd
+}
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/celestiaorg/celestia-node/api/rpc/client"
+	cmdnode "github.com/celestiaorg/celestia-node/cmd"
+	"github.com/celestiaorg/celestia-node/nodebuilder"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	modshare "github.com/celestiaorg/celestia-node/nodebuilder/share"
+)
+
+// storeCmd constructs a CLI command to inspect the node's store.
+func storeCmd(fsets ...*flag.FlagSet) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "store [subcommand]",
+		Short: "Inspects the node's store",
+		Args:  cobra.NoArgs,
+	}
+	cmd.AddCommand(
+		verifyStoreCmd(fsets...),
+		lsStoreCmd(fsets...),
+		migrateStoreCmd(fsets...),
+		gcStoreCmd(fsets...),
+	)
+	return cmd
+}
+
+func verifyStoreCmd(fsets ...*flag.FlagSet) *cobra.Command {
+	var (
+		heights string
+		asJSON  bool
+	)
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Checks the node's store for inconsistencies",
+		Long: "Cross-checks the stored headers against each other, against the EDSes stored for them " +
+			"and against the DAS checkpoint, then prints the issues found along with how to repair " +
+			"them. Requires the node being stopped.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			var from, to uint64
+			if heights != "" {
+				var err error
+				from, to, err = parseHeightRange(heights)
+				if err != nil {
+					return err
+				}
+			}
+
+			ctx := cmd.Context()
+			store, err := nodebuilder.OpenStore(cmdnode.StorePath(ctx), nil)
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			report, err := nodebuilder.VerifyStore(ctx, store, cmdnode.NodeType(ctx), from, to)
+			if err != nil {
+				return err
+			}
+			if asJSON {
+				out, err := json.MarshalIndent(report, "", "  ")
+				if err != nil {
+					return err
+				}
+				_, err = fmt.Fprintln(cmd.OutOrStdout(), string(out))
+				return err
+			}
+			printStoreReport(cmd.OutOrStdout(), report)
+			return nil
+		},
+	}
+
+	for _, set := range fsets {
+		cmd.Flags().AddFlagSet(set)
+	}
+	cmd.Flags().StringVar(&heights, "heights", "", "Inclusive range of heights to verify, e.g. 100..200 "+
+		"(default: all stored heights)")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print the report as JSON")
+	return cmd
+}
+
+func printStoreReport(out io.Writer, report *nodebuilder.StoreReport) {
+	fmt.Fprintf(out, "verified heights %d..%d: %d headers, %d EDSes\n",
+		report.From, report.To, report.Headers, report.EDSes)
+	if len(report.Issues) == 0 {
+		fmt.Fprintln(out, "no issues found")
+		return
+	}
+
+	fmt.Fprintf(out, "%d issues found:\n", len(report.Issues))
+	for _, issue := range report.Issues {
+		if issue.Height != 0 {
+			fmt.Fprintf(out, "  height %d: %s\n", issue.Height, issue.Problem)
+		} else {
+			fmt.Fprintf(out, "  %s\n", issue.Problem)
+		}
+		fmt.Fprintf(out, "    repair: %s\n", issue.Repair)
+	}
+}
+
+func lsStoreCmd(fsets ...*flag.FlagSet) *cobra.Command {
+	var (
+		height uint64
+		asJSON bool
+	)
+	cmd := &cobra.Command{
+		Use:   "ls",
+		Short: "Lists the EDSes in the node's store",
+		Long: "Prints the stored heights along with the size of their original data square, the size of " +
+			"their EDS file on disk and the namespaces present in them. The store is read directly if " +
+			"the node is stopped, otherwise the running node is requested over RPC.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			if cmdnode.NodeType(ctx) == node.Light {
+				return errors.New("light nodes do not store EDSes")
+			}
+
+			var list func(from uint64) (*modshare.StoredSquaresPage, error)
+			store, err := nodebuilder.OpenStore(cmdnode.StorePath(ctx), nil)
+			switch {
+			case err == nil:
+				defer store.Close()
+				list = func(from uint64) (*modshare.StoredSquaresPage, error) {
+					return nodebuilder.ListStoredSquares(ctx, store, from, height)
+				}
+			case errors.Is(err, nodebuilder.ErrOpened):
+				token := authTokenFlag
+				if token == "" {
+					token = os.Getenv(authEnvKey)
+				}
+				var cl *client.Client
+				cl, err = client.NewClient(ctx, requestURL, token)
+				if err != nil {
+					return err
+				}
+				defer cl.Close()
+				list = func(from uint64) (*modshare.StoredSquaresPage, error) {
+					return cl.Share.StoredSquares(ctx, from, height)
+				}
+			}
+			if err != nil {
+				return err
+			}
+
+			// the stored heights are listed by pages
+			var squares []modshare.StoredSquare
+			for from := height; ; {
+				page, err := list(from)
+				if err != nil {
+					return err
+				}
+				squares = append(squares, page.Squares...)
+				if page.Next == 0 {
+					break
+				}
+				from = page.Next
+			}
+
+			if asJSON {
+				out, err := json.MarshalIndent(squares, "", "  ")
+				if err != nil {
+					return err
+				}
+				_, err = fmt.Fprintln(cmd.OutOrStdout(), string(out))
+				return err
+			}
+			printStoredSquares(cmd.OutOrStdout(), squares)
+			return nil
+		},
+	}
+
+	for _, set := range fsets {
+		cmd.Flags().AddFlagSet(set)
+	}
+	cmd.Flags().Uint64Var(&height, "height", 0, "Height to list (default: all stored heights)")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print the list as JSON")
+	cmd.Flags().StringVar(&requestURL, "url", "http://localhost:26658", "Request URL of the running node")
+	cmd.Flags().StringVar(&authTokenFlag, "auth", "", "Authorization token of the running node (if not "+
+		"provided, the "+authEnvKey+" environment variable will be used)")
+	return cmd
+}
+
+func migrateStoreCmd(fsets ...*flag.FlagSet) *cobra.Command {
+	var to string
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrates the node's datastore to another backend",
+		Long: "Copies every entry of the datastore into the backend given with --to and replaces the " +
+			"original datastore with the copy. Requires the node being stopped and enough free disk " +
+			"space to hold both copies of the datastore while migrating.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			backend, err := nodebuilder.ParseStoreBackend(to)
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			if err = nodebuilder.MigrateStoreBackend(ctx, cmdnode.StorePath(ctx), backend); err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "migrated the datastore to %s\n", backend)
+			return err
+		},
+	}
+
+	for _, set := range fsets {
+		cmd.Flags().AddFlagSet(set)
+	}
+	cmd.Flags().StringVar(&to, "to", string(nodebuilder.StoreBackendPebble), "Backend to migrate the "+
+		"datastore to: "+string(nodebuilder.StoreBackendBadger)+" or "+string(nodebuilder.StoreBackendPebble))
+	return cmd
+}
+
+func gcStoreCmd(fsets ...*flag.FlagSet) *cobra.Command {
+	var (
+		window time.Duration
+		asJSON bool
+	)
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Removes old EDSes from the node's store and compacts it",
+		Long: "Prunes the EDSes of the headers older than the pruning window, rewrites the missing " +
+			"or failed CAR indexes and removes the files not belonging to any EDS, then prints the " +
+			"space reclaimed. Requires the node being stopped, while running nodes prune in the " +
+			"background if Pruner.Window is set, and compact if Share.StoreGCInterval is set.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			if cmdnode.NodeType(ctx) == node.Light {
+				return errors.New("light nodes do not store EDSes")
+			}
+
+			store, err := nodebuilder.OpenStore(cmdnode.StorePath(ctx), nil)
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			if !cmd.Flags().Changed("window") {
+				cfg, err := store.Config()
+				if err != nil {
+					return err
+				}
+				window = cfg.Pruner.Window
+			}
+			res, err := nodebuilder.CollectStoreGarbage(ctx, store, cmdnode.NodeType(ctx), window)
+			if err != nil {
+				return err
+			}
+
+			if asJSON {
+				out, err := json.MarshalIndent(res, "", "  ")
+				if err != nil {
+					return err
+				}
+				_, err = fmt.Fprintln(cmd.OutOrStdout(), string(out))
+				return err
+			}
+			out := cmd.OutOrStdout()
+			if res.Pruned != 0 {
+				fmt.Fprintf(out, "pruned %d heights, up to height %d\n", res.Removed, res.Pruned)
+			}
+			fmt.Fprintf(out, "reindexed %d EDSes, removed %d orphaned files\n", res.Reindexed, res.Orphans)
+			fmt.Fprintf(out, "reclaimed %d bytes\n", res.Reclaimed)
+			return nil
+		},
+	}
+
+	for _, set := range fsets {
+		cmd.Flags().AddFlagSet(set)
+	}
+	cmd.Flags().DurationVar(&window, "window", 0, "Keep the EDSes of the headers within the given "+
+		"time back from now, zero keeps all (default: Pruner.Window of the config)")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print the result as JSON")
+	return cmd
+}
+
+func printStoredSquares(out io.Writer, squares []modshare.StoredSquare) {
+	// empty blocks share the same EDS file
+	var total int64
+	files := make(map[string]struct{}, len(squares))
+	for _, sq := range squares {
+		if _, ok := files[sq.DataHash.String()]; !ok {
+			files[sq.DataHash.String()] = struct{}{}
+			total += sq.DiskSize
+		}
+		namespaces := make([]string, len(sq.Namespaces))
+		for i, ns := range sq.Namespaces {
+			namespaces[i] = ns.String()
+		}
+		fmt.Fprintf(out, "%d\t%dx%d\t%d bytes\t%s\n",
+			sq.Height, sq.SquareSize, sq.SquareSize, sq.DiskSize, strings.Join(namespaces, ","))
+	}
+	fmt.Fprintf(out, "%d heights, %d EDS files, %d bytes on disk\n", len(squares), len(files), total)
+}
@@ -4,13 +4,20 @@ import (
 	"github.com/spf13/cobra"
 
 	cmdnode "github.com/celestiaorg/celestia-node/cmd"
+	"github.com/celestiaorg/celestia-node/nodebuilder/clock"
 	"github.com/celestiaorg/celestia-node/nodebuilder/core"
+	"github.com/celestiaorg/celestia-node/nodebuilder/features"
 	"github.com/celestiaorg/celestia-node/nodebuilder/gateway"
 	"github.com/celestiaorg/celestia-node/nodebuilder/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/health"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+	"github.com/celestiaorg/celestia-node/nodebuilder/pruner"
+	"github.com/celestiaorg/celestia-node/nodebuilder/quota"
 	"github.com/celestiaorg/celestia-node/nodebuilder/rpc"
 	"github.com/celestiaorg/celestia-node/nodebuilder/state"
+	"github.com/celestiaorg/celestia-node/nodebuilder/watchdog"
+	"github.com/celestiaorg/celestia-node/nodebuilder/watcher"
 )
 
 func persistentPreRunEnv(cmd *cobra.Command, nodeType node.Type, _ []string) error {
@@ -33,7 +40,10 @@ func persistentPreRunEnv(cmd *cobra.Command, nodeType node.Type, _ []string) err
 		return err
 	}
 
-	cfg := cmdnode.NodeConfig(ctx)
+	cfg, err := cmdnode.NodeConfig(ctx)
+	if err != nil {
+		return err
+	}
 
 	err = p2p.ParseFlags(cmd, &cfg.P2P)
 	if err != nil {
@@ -59,7 +69,14 @@ func persistentPreRunEnv(cmd *cobra.Command, nodeType node.Type, _ []string) err
 
 	rpc.ParseFlags(cmd, &cfg.RPC)
 	gateway.ParseFlags(cmd, &cfg.Gateway)
+	health.ParseFlags(cmd, &cfg.Health)
+	pruner.ParseFlags(cmd, &cfg.Pruner)
+	quota.ParseFlags(cmd, &cfg.Quota)
+	clock.ParseFlags(cmd, &cfg.Clock)
+	watchdog.ParseFlags(cmd, &cfg.Watchdog)
 	state.ParseFlags(cmd, &cfg.State)
+	features.ParseFlags(cmd, &cfg.Features)
+	watcher.ParseFlags(cmd, &cfg.Watcher)
 
 	// set config
 	ctx = cmdnode.WithNodeConfig(ctx, &cfg)
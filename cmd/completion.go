@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder"
+)
+
+// FlagCompletions gives the completions of the values of the node and miscellaneous flags, keyed
+// by the flag names.
+func FlagCompletions() map[string]func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	transports := cobra.FixedCompletions(
+		[]string{otlpTransportHTTP, otlpTransportGRPC},
+		cobra.ShellCompDirectiveNoFileComp,
+	)
+	return map[string]func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective){
+		nodeStoreFlag: func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+			return nil, cobra.ShellCompDirectiveFilterDirs
+		},
+		nodePresetFlag: func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+			presets := nodebuilder.Presets()
+			names := make([]string, len(presets))
+			for i, preset := range presets {
+				names[i] = string(preset)
+			}
+			return names, cobra.ShellCompDirectiveNoFileComp
+		},
+		tracingTransport: transports,
+		metricsTransport: transports,
+	}
+}
@@ -1,6 +1,9 @@
 package cmd
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
@@ -42,3 +45,80 @@ func UpdateConfigCmd(fsets ...*flag.FlagSet) *cobra.Command {
 	}
 	return cmd
 }
+
+// ConfigCmd constructs a CLI command to inspect the effective config of the node, being the
+// defaults overridden by the config file and the given flags.
+func ConfigCmd(fsets ...*flag.FlagSet) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config [subcommand]",
+		Short: "Inspects the node's effective config",
+		Args:  cobra.NoArgs,
+	}
+
+	cmd.AddCommand(
+		showConfigCmd(fsets...),
+		validateConfigCmd(fsets...),
+	)
+	return cmd
+}
+
+func showConfigCmd(fsets ...*flag.FlagSet) *cobra.Command {
+	var format string
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Prints the node's config merged with the given flags",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := NodeConfig(cmd.Context())
+			if err != nil {
+				return err
+			}
+			switch format {
+			case "toml":
+				return cfg.Encode(cmd.OutOrStdout())
+			case "json":
+				out, err := json.MarshalIndent(cfg, "", "  ")
+				if err != nil {
+					return err
+				}
+				_, err = fmt.Fprintln(cmd.OutOrStdout(), string(out))
+				return err
+			default:
+				return fmt.Errorf("unsupported format '%s', must be 'toml' or 'json'", format)
+			}
+		},
+	}
+
+	for _, set := range fsets {
+		cmd.Flags().AddFlagSet(set)
+	}
+	cmd.Flags().StringVar(&format, "format", "toml", "Output format: 'toml' or 'json'")
+	formats := cobra.FixedCompletions([]string{"toml", "json"}, cobra.ShellCompDirectiveNoFileComp)
+	cmd.RegisterFlagCompletionFunc("format", formats) //nolint:errcheck
+	return cmd
+}
+
+func validateConfigCmd(fsets ...*flag.FlagSet) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validates the node's config merged with the given flags",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			cfg, err := NodeConfig(ctx)
+			if err != nil {
+				return err
+			}
+			if err := cfg.Validate(NodeType(ctx)); err != nil {
+				return fmt.Errorf("invalid config:\n%w", err)
+			}
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), "config is valid")
+			return err
+		},
+	}
+
+	for _, set := range fsets {
+		cmd.Flags().AddFlagSet(set)
+	}
+	return cmd
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder"
+)
+
+// Doctor constructs a CLI command to check the environment the node is about to run in, printing
+// the problems found along with the advice to fix them.
+func Doctor(fsets ...*flag.FlagSet) *cobra.Command {
+	var format string
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Checks the environment the node is about to run in",
+		Long: "Checks the connectivity to the bootstrappers and the core node, the drift of the local clock, " +
+			"the speed and free space of the disk, the open-file limits and the availability of the configured " +
+			"ports, printing the problems found. Must be run while the node is stopped.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			cfg, err := NodeConfig(ctx)
+			if err != nil {
+				return err
+			}
+			findings := nodebuilder.Doctor(ctx, NodeType(ctx), Network(ctx), &cfg, StorePath(ctx))
+
+			switch format {
+			case "text":
+				if err := printFindings(cmd, findings); err != nil {
+					return err
+				}
+			case "json":
+				out, err := json.MarshalIndent(findings, "", "  ")
+				if err != nil {
+					return err
+				}
+				if _, err = fmt.Fprintln(cmd.OutOrStdout(), string(out)); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("unsupported format '%s', must be 'text' or 'json'", format)
+			}
+
+			var failures int
+			for _, f := range findings {
+				if f.Severity == nodebuilder.SeverityFailure {
+					failures++
+				}
+			}
+			if failures > 0 {
+				return fmt.Errorf("found %d problem(s) to fix before running the node", failures)
+			}
+			return nil
+		},
+	}
+
+	for _, set := range fsets {
+		cmd.Flags().AddFlagSet(set)
+	}
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: 'text' or 'json'")
+	formats := cobra.FixedCompletions([]string{"text", "json"}, cobra.ShellCompDirectiveNoFileComp)
+	cmd.RegisterFlagCompletionFunc("format", formats) //nolint:errcheck
+	return cmd
+}
+
+func printFindings(cmd *cobra.Command, findings []nodebuilder.Finding) error {
+	for _, f := range findings {
+		line := fmt.Sprintf("[%-7s] %s: %s", strings.ToUpper(string(f.Severity)), f.Check, f.Detail)
+		if f.Advice != "" {
+			line += "\n          -> " + f.Advice
+		}
+		if _, err := fmt.Fprintln(cmd.OutOrStdout(), line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -28,14 +28,18 @@ func StorePath(ctx context.Context) string {
 	return ctx.Value(storePathKey{}).(string)
 }
 
-// NodeConfig reads the node config from the context.
-func NodeConfig(ctx context.Context) nodebuilder.Config {
+// NodeConfig reads the node config from the context, or returns the default one for the node type
+// if none is set.
+func NodeConfig(ctx context.Context) (nodebuilder.Config, error) {
 	cfg, ok := ctx.Value(configKey{}).(nodebuilder.Config)
-	if !ok {
-		nodeType := NodeType(ctx)
-		cfg = *nodebuilder.DefaultConfig(nodeType)
+	if ok {
+		return cfg, nil
+	}
+	def, err := nodebuilder.DefaultConfig(NodeType(ctx))
+	if err != nil {
+		return nodebuilder.Config{}, err
 	}
-	return cfg
+	return *def, nil
 }
 
 // WithNodeType sets the node type in the given context.
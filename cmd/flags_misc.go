@@ -2,72 +2,108 @@ package cmd
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
-	"net/http"
-	"net/http/pprof"
 	"strings"
+	"time"
 
-	logging "github.com/ipfs/go-log/v2"
 	otelpyroscope "github.com/pyroscope-io/otel-profiling-go"
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 
-	"github.com/celestiaorg/celestia-node/logs"
 	"github.com/celestiaorg/celestia-node/nodebuilder"
+	modlogging "github.com/celestiaorg/celestia-node/nodebuilder/logging"
 	modp2p "github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+	"github.com/celestiaorg/celestia-node/share"
 )
 
 var (
-	logLevelFlag        = "log.level"
-	logLevelModuleFlag  = "log.level.module"
 	pprofFlag           = "pprof"
+	pprofAddr           = "pprof.addr"
+	pprofAuth           = "pprof.auth"
 	tracingFlag         = "tracing"
 	tracingEndpointFlag = "tracing.endpoint"
 	tracingTlS          = "tracing.tls"
+	tracingTransport    = "tracing.transport"
+	tracingHeaders      = "tracing.headers"
+	tracingShutdown     = "tracing.shutdown.timeout"
 	metricsFlag         = "metrics"
 	metricsEndpointFlag = "metrics.endpoint"
 	metricsTlS          = "metrics.tls"
+	metricsTransport    = "metrics.transport"
+	metricsHeaders      = "metrics.headers"
+	metricsNamespaces   = "metrics.namespaces"
+	metricsRuntime      = "metrics.runtime"
+	metricsHost         = "metrics.host"
+	metricsInterval     = "metrics.interval"
+	metricsTimeout      = "metrics.timeout"
+	metricsCardinality  = "metrics.cardinality.limit"
+	otelAttributes      = "otel.resource.attributes"
 	p2pMetrics          = "p2p.metrics"
 	pyroscopeFlag       = "pyroscope"
 	pyroscopeTracing    = "pyroscope.tracing"
 	pyroscopeEndpoint   = "pyroscope.endpoint"
+	pyroscopeApp        = "pyroscope.app"
+	pyroscopeProfiles   = "pyroscope.profiles"
+	pyroscopeMutex      = "pyroscope.mutex.fraction"
+	pyroscopeBlock      = "pyroscope.block.rate"
+	pyroscopeUpload     = "pyroscope.upload.rate"
+	pyroscopeToken      = "pyroscope.auth.token"
+	pyroscopeUser       = "pyroscope.auth.user"
+	pyroscopePassword   = "pyroscope.auth.password"
+	pyroscopeTenant     = "pyroscope.tenant"
+	pyroscopeHeaders    = "pyroscope.headers"
+	pyroscopeTags       = "pyroscope.tags"
+)
+
+const (
+	otlpTransportHTTP = "http"
+	otlpTransportGRPC = "grpc"
+
+	otlpHTTPEndpoint = "localhost:4318"
+	otlpGRPCEndpoint = "localhost:4317"
 )
 
 // MiscFlags gives a set of hardcoded miscellaneous flags.
 func MiscFlags() *flag.FlagSet {
 	flags := &flag.FlagSet{}
 
-	flags.String(
-		logLevelFlag,
-		"INFO",
-		`DEBUG, INFO, WARN, ERROR, DPANIC, PANIC, FATAL
-and their lower-case forms`,
-	)
-
-	flags.StringSlice(
-		logLevelModuleFlag,
-		nil,
-		"<module>:<level>, e.g. pubsub:debug",
-	)
+	flags.AddFlagSet(modlogging.Flags())
 
 	flags.Bool(
 		pprofFlag,
 		false,
-		"Enables standard profiling handler (pprof) and exposes the profiles on port 6000",
+		"Enables standard profiling handler (pprof) and exposes the profiles on '--pprof.addr'",
+	)
+
+	flags.String(
+		pprofAddr,
+		nodebuilder.DefaultPprofConfig().Address,
+		"Sets the address pprof listens on. Only local addresses are allowed without '--pprof.auth'. "+
+			"Depends on '--pprof'",
+	)
+
+	flags.String(
+		pprofAuth,
+		"",
+		"Protects pprof with HTTP basic auth with the given 'user:password'. Depends on '--pprof'",
 	)
 
 	flags.Bool(
 		tracingFlag,
 		false,
-		"Enables OTLP tracing with HTTP exporter",
+		"Enables OTLP tracing",
 	)
 
 	flags.String(
 		tracingEndpointFlag,
-		"localhost:4318",
-		"Sets HTTP endpoint for OTLP traces to be exported to. Depends on '--tracing'",
+		otlpHTTPEndpoint,
+		"Sets endpoint for OTLP traces to be exported to. Defaults to "+otlpGRPCEndpoint+
+			" for the gRPC transport. Depends on '--tracing'",
 	)
 
 	flags.Bool(
@@ -76,16 +112,35 @@ and their lower-case forms`,
 		"Enable TLS connection to OTLP tracing backend",
 	)
 
+	flags.String(
+		tracingTransport,
+		otlpTransportHTTP,
+		"Sets transport of the OTLP traces exporter: 'http' or 'grpc'. Depends on '--tracing'",
+	)
+
+	flags.StringToString(
+		tracingHeaders,
+		nil,
+		"Sets headers sent with every OTLP traces export, e.g. 'authorization=token'. Depends on '--tracing'",
+	)
+
+	flags.Duration(
+		tracingShutdown,
+		nodebuilder.DefaultTracesShutdownTimeout,
+		"Sets the time given to flush buffered spans on node stop. Depends on '--tracing'",
+	)
+
 	flags.Bool(
 		metricsFlag,
 		false,
-		"Enables OTLP metrics with HTTP exporter",
+		"Enables OTLP metrics",
 	)
 
 	flags.String(
 		metricsEndpointFlag,
-		"localhost:4318",
-		"Sets HTTP endpoint for OTLP metrics to be exported to. Depends on '--metrics'",
+		otlpHTTPEndpoint,
+		"Sets endpoint for OTLP metrics to be exported to. Defaults to "+otlpGRPCEndpoint+
+			" for the gRPC transport. Depends on '--metrics'",
 	)
 
 	flags.Bool(
@@ -94,6 +149,63 @@ and their lower-case forms`,
 		"Enable TLS connection to OTLP metric backend",
 	)
 
+	flags.String(
+		metricsTransport,
+		otlpTransportHTTP,
+		"Sets transport of the OTLP metrics exporter: 'http' or 'grpc'. Depends on '--metrics'",
+	)
+
+	flags.StringToString(
+		metricsHeaders,
+		nil,
+		"Sets headers sent with every OTLP metrics export, e.g. 'authorization=token'. Depends on '--metrics'",
+	)
+
+	flags.StringSlice(
+		metricsNamespaces,
+		nil,
+		"Hex encoded namespaces to report DA usage metrics for. Depends on '--metrics'",
+	)
+
+	flags.Bool(
+		metricsRuntime,
+		false,
+		"Enables Go runtime metrics: GC pauses, goroutines and heap, overriding Node.RuntimeMetrics "+
+			"of the config. Depends on '--metrics'",
+	)
+
+	flags.Bool(
+		metricsHost,
+		false,
+		"Enables host metrics: CPU, memory, network and disk space of the store, overriding "+
+			"Node.HostMetrics of the config. Depends on '--metrics'",
+	)
+
+	flags.Duration(
+		metricsInterval,
+		time.Minute,
+		"Sets the time between two exports of the metrics. Depends on '--metrics'",
+	)
+
+	flags.Duration(
+		metricsTimeout,
+		2*time.Second,
+		"Sets the time given to a single export of the metrics. Depends on '--metrics'",
+	)
+
+	flags.Int(
+		metricsCardinality,
+		2000,
+		"Caps the unique label combinations exported per metric, aggregating the ones over it. "+
+			"0 disables the cap. Depends on '--metrics'",
+	)
+
+	flags.StringToString(
+		otelAttributes,
+		nil,
+		"Adds attributes to the resource the metrics and traces are reported with, e.g. 'region=eu,operator=acme'",
+	)
+
 	flags.Bool(
 		p2pMetrics,
 		false,
@@ -118,35 +230,92 @@ and their lower-case forms`,
 		"Sets HTTP endpoint for Pyroscope profiles to be exported to. Depends on '--pyroscope'",
 	)
 
+	pyroscopeDefaults := nodebuilder.DefaultPyroscopeConfig()
+	flags.String(
+		pyroscopeApp,
+		pyroscopeDefaults.ApplicationName,
+		"Sets the application name Pyroscope profiles are reported under. Depends on '--pyroscope'",
+	)
+
+	flags.StringSlice(
+		pyroscopeProfiles,
+		pyroscopeDefaults.ProfileTypes,
+		"Sets the Pyroscope profile types to report, additionally: goroutines, mutex_count, mutex_duration, "+
+			"block_count and block_duration. Depends on '--pyroscope'",
+	)
+
+	flags.Int(
+		pyroscopeMutex,
+		pyroscopeDefaults.MutexProfileFraction,
+		"Sets the fraction of mutex contention events sampled for the mutex profiles, 1/n on average. "+
+			"Depends on '--pyroscope'",
+	)
+
+	flags.Int(
+		pyroscopeBlock,
+		pyroscopeDefaults.BlockProfileRate,
+		"Sets the sampling rate of the block profiles, one event per n nanoseconds blocked on average. "+
+			"Depends on '--pyroscope'",
+	)
+
+	flags.Duration(
+		pyroscopeUpload,
+		pyroscopeDefaults.UploadRate,
+		"Sets the time between two uploads of the Pyroscope profiles. Depends on '--pyroscope'",
+	)
+
+	flags.String(
+		pyroscopeToken,
+		"",
+		"Sets the bearer token Pyroscope profiles are uploaded with. Prefer the "+
+			nodebuilder.EnvPyroscopeAuthToken+" environment variable to keep it out of the process list. "+
+			"Depends on '--pyroscope'",
+	)
+
+	flags.String(
+		pyroscopeUser,
+		"",
+		"Sets the basic auth user Pyroscope profiles are uploaded with, e.g. the Grafana Cloud instance ID. "+
+			"Depends on '--pyroscope'",
+	)
+
+	flags.String(
+		pyroscopePassword,
+		"",
+		"Sets the basic auth password Pyroscope profiles are uploaded with, e.g. a Grafana Cloud API key. "+
+			"Prefer the "+nodebuilder.EnvPyroscopeBasicAuthPassword+" environment variable to keep it out of "+
+			"the process list. Depends on '--pyroscope'",
+	)
+
+	flags.String(
+		pyroscopeTenant,
+		"",
+		"Sets the tenant ID of the Pyroscope profiles on multi-tenant servers. Depends on '--pyroscope'",
+	)
+
+	flags.StringToString(
+		pyroscopeHeaders,
+		nil,
+		"Sets headers sent with every Pyroscope upload. Depends on '--pyroscope'",
+	)
+
+	flags.StringToString(
+		pyroscopeTags,
+		nil,
+		"Adds tags to the Pyroscope profiles, e.g. 'region=eu,operator=acme'. Depends on '--pyroscope'",
+	)
+
 	return flags
 }
 
 // ParseMiscFlags parses miscellaneous flags from the given cmd and applies values to Env.
 func ParseMiscFlags(ctx context.Context, cmd *cobra.Command) (context.Context, error) {
-	logLevel := cmd.Flag(logLevelFlag).Value.String()
-	if logLevel != "" {
-		level, err := logging.LevelFromString(logLevel)
-		if err != nil {
-			return ctx, fmt.Errorf("cmd: while parsing '%s': %w", logLevelFlag, err)
-		}
-
-		logs.SetAllLoggers(level)
-	}
-
-	logModules, err := cmd.Flags().GetStringSlice(logLevelModuleFlag)
+	logCfg, err := modlogging.ParseFlags(cmd)
 	if err != nil {
-		panic(err)
+		return ctx, err
 	}
-	for _, ll := range logModules {
-		params := strings.Split(ll, ":")
-		if len(params) != 2 {
-			return ctx, fmt.Errorf("cmd: %s arg must be in form <module>:<level>, e.g. pubsub:debug", logLevelModuleFlag)
-		}
-
-		err := logging.SetLogLevel(params[0], params[1])
-		if err != nil {
-			return ctx, err
-		}
+	if err := modlogging.Setup(logCfg); err != nil {
+		return ctx, err
 	}
 
 	ok, err := cmd.Flags().GetBool(pprofFlag)
@@ -155,23 +324,19 @@ func ParseMiscFlags(ctx context.Context, cmd *cobra.Command) (context.Context, e
 	}
 
 	if ok {
-		// TODO(@Wondertan): Eventually, this should be registered on http server in RPC
-		//  by passing the http.Server with preregistered pprof handlers to the node.
-		//  Node should not register pprof itself.
-		go func() {
-			mux := http.NewServeMux()
-			mux.HandleFunc("/debug/pprof/", pprof.Index)
-			mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
-			mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
-			mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
-			mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
-			err := http.ListenAndServe("0.0.0.0:6000", mux) //nolint:gosec
-			if err != nil {
-				log.Fatalw("failed to start pprof server", "err", err)
-			} else {
-				log.Info("started pprof server on port 6000")
+		cfg := nodebuilder.DefaultPprofConfig()
+		cfg.Address = cmd.Flag(pprofAddr).Value.String()
+		if auth := cmd.Flag(pprofAuth).Value.String(); auth != "" {
+			user, password, found := strings.Cut(auth, ":")
+			if !found || user == "" {
+				return ctx, fmt.Errorf("cmd: '%s' must be in the form 'user:password'", pprofAuth)
 			}
-		}()
+			cfg.User, cfg.Password = user, password
+		}
+		if err := cfg.Validate(); err != nil {
+			return ctx, fmt.Errorf("cmd: while parsing pprof flags: %w", err)
+		}
+		ctx = WithNodeOptions(ctx, nodebuilder.WithPprof(cfg))
 	}
 
 	ok, err = cmd.Flags().GetBool(pyroscopeFlag)
@@ -180,12 +345,11 @@ func ParseMiscFlags(ctx context.Context, cmd *cobra.Command) (context.Context, e
 	}
 
 	if ok {
-		ctx = WithNodeOptions(ctx,
-			nodebuilder.WithPyroscope(
-				cmd.Flag(pyroscopeEndpoint).Value.String(),
-				NodeType(ctx),
-			),
-		)
+		cfg, err := parsePyroscopeFlags(cmd)
+		if err != nil {
+			return ctx, err
+		}
+		ctx = WithNodeOptions(ctx, nodebuilder.WithPyroscopeConfig(cfg, NodeType(ctx)))
 	}
 
 	ok, err = cmd.Flags().GetBool(tracingFlag)
@@ -194,14 +358,10 @@ func ParseMiscFlags(ctx context.Context, cmd *cobra.Command) (context.Context, e
 	}
 
 	if ok {
-		opts := []otlptracehttp.Option{
-			otlptracehttp.WithCompression(otlptracehttp.GzipCompression),
-			otlptracehttp.WithEndpoint(cmd.Flag(tracingEndpointFlag).Value.String()),
-		}
-		if ok, err := cmd.Flags().GetBool(tracingTlS); err != nil {
-			panic(err)
-		} else if !ok {
-			opts = append(opts, otlptracehttp.WithInsecure())
+		transport, endpoint, tls, headers, err := parseOTLPFlags(
+			cmd, tracingTransport, tracingEndpointFlag, tracingTlS, tracingHeaders)
+		if err != nil {
+			return ctx, err
 		}
 
 		pyroOpts := make([]otelpyroscope.Option, 0)
@@ -211,7 +371,7 @@ func ParseMiscFlags(ctx context.Context, cmd *cobra.Command) (context.Context, e
 		}
 		if ok {
 			pyroOpts = append(pyroOpts,
-				otelpyroscope.WithAppName("celestia.da-node"),
+				otelpyroscope.WithAppName(cmd.Flag(pyroscopeApp).Value.String()),
 				otelpyroscope.WithPyroscopeURL(cmd.Flag(pyroscopeEndpoint).Value.String()),
 				otelpyroscope.WithRootSpanOnly(true),
 				otelpyroscope.WithAddSpanName(true),
@@ -219,7 +379,35 @@ func ParseMiscFlags(ctx context.Context, cmd *cobra.Command) (context.Context, e
 				otelpyroscope.WithProfileBaselineURL(true),
 			)
 		}
-		ctx = WithNodeOptions(ctx, nodebuilder.WithTraces(opts, pyroOpts))
+
+		switch transport {
+		case otlpTransportHTTP:
+			opts := []otlptracehttp.Option{
+				otlptracehttp.WithCompression(otlptracehttp.GzipCompression),
+				otlptracehttp.WithEndpoint(endpoint),
+				otlptracehttp.WithHeaders(headers),
+			}
+			if !tls {
+				opts = append(opts, otlptracehttp.WithInsecure())
+			}
+			ctx = WithNodeOptions(ctx, nodebuilder.WithTraces(opts, pyroOpts))
+		case otlpTransportGRPC:
+			opts := []otlptracegrpc.Option{
+				otlptracegrpc.WithCompressor("gzip"),
+				otlptracegrpc.WithEndpoint(endpoint),
+				otlptracegrpc.WithHeaders(headers),
+			}
+			if !tls {
+				opts = append(opts, otlptracegrpc.WithInsecure())
+			}
+			ctx = WithNodeOptions(ctx, nodebuilder.WithTracesGRPC(opts, pyroOpts))
+		}
+
+		timeout, err := cmd.Flags().GetDuration(tracingShutdown)
+		if err != nil {
+			panic(err)
+		}
+		ctx = WithNodeOptions(ctx, nodebuilder.WithTracesShutdownTimeout(timeout))
 	}
 
 	ok, err = cmd.Flags().GetBool(metricsFlag)
@@ -228,17 +416,110 @@ func ParseMiscFlags(ctx context.Context, cmd *cobra.Command) (context.Context, e
 	}
 
 	if ok {
-		opts := []otlpmetrichttp.Option{
-			otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression),
-			otlpmetrichttp.WithEndpoint(cmd.Flag(metricsEndpointFlag).Value.String()),
+		transport, endpoint, tls, headers, err := parseOTLPFlags(
+			cmd, metricsTransport, metricsEndpointFlag, metricsTlS, metricsHeaders)
+		if err != nil {
+			return ctx, err
+		}
+
+		switch transport {
+		case otlpTransportHTTP:
+			opts := []otlpmetrichttp.Option{
+				otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression),
+				otlpmetrichttp.WithEndpoint(endpoint),
+				otlpmetrichttp.WithHeaders(headers),
+			}
+			if !tls {
+				opts = append(opts, otlpmetrichttp.WithInsecure())
+			}
+			ctx = WithNodeOptions(ctx, nodebuilder.WithMetrics(opts, NodeType(ctx)))
+		case otlpTransportGRPC:
+			opts := []otlpmetricgrpc.Option{
+				otlpmetricgrpc.WithCompressor("gzip"),
+				otlpmetricgrpc.WithEndpoint(endpoint),
+				otlpmetricgrpc.WithHeaders(headers),
+			}
+			if !tls {
+				opts = append(opts, otlpmetricgrpc.WithInsecure())
+			}
+			ctx = WithNodeOptions(ctx, nodebuilder.WithMetricsGRPC(opts, NodeType(ctx)))
+		}
+
+		hexNamespaces, err := cmd.Flags().GetStringSlice(metricsNamespaces)
+		if err != nil {
+			panic(err)
+		}
+		if len(hexNamespaces) > 0 {
+			namespaces := make([]share.Namespace, 0, len(hexNamespaces))
+			for _, hexNamespace := range hexNamespaces {
+				namespace, err := hex.DecodeString(hexNamespace)
+				if err != nil {
+					return ctx, fmt.Errorf("cmd: while parsing '%s': %w", metricsNamespaces, err)
+				}
+				if err := share.Namespace(namespace).ValidateForBlob(); err != nil {
+					return ctx, fmt.Errorf("cmd: while parsing '%s': %w", metricsNamespaces, err)
+				}
+				namespaces = append(namespaces, namespace)
+			}
+			ctx = WithNodeOptions(ctx, nodebuilder.WithNamespaceMetrics(namespaces, NodeType(ctx)))
+		}
+
+		// the flags override the config
+		if cmd.Flags().Changed(metricsRuntime) || cmd.Flags().Changed(metricsHost) {
+			cfg, err := NodeConfig(ctx)
+			if err != nil {
+				return ctx, err
+			}
+			runtime, host := cfg.Node.RuntimeMetrics, cfg.Node.HostMetrics
+			if cmd.Flags().Changed(metricsRuntime) {
+				runtime, err = cmd.Flags().GetBool(metricsRuntime)
+				if err != nil {
+					panic(err)
+				}
+			}
+			if cmd.Flags().Changed(metricsHost) {
+				host, err = cmd.Flags().GetBool(metricsHost)
+				if err != nil {
+					panic(err)
+				}
+			}
+			ctx = WithNodeOptions(ctx, nodebuilder.WithMetricsInstrumentation(runtime, host))
+		}
+
+		interval, err := cmd.Flags().GetDuration(metricsInterval)
+		if err != nil {
+			panic(err)
 		}
-		if ok, err := cmd.Flags().GetBool(metricsTlS); err != nil {
+		timeout, err := cmd.Flags().GetDuration(metricsTimeout)
+		if err != nil {
 			panic(err)
-		} else if !ok {
-			opts = append(opts, otlpmetrichttp.WithInsecure())
 		}
+		if interval <= 0 || timeout <= 0 {
+			return ctx, fmt.Errorf("cmd: '%s' and '%s' must be positive", metricsInterval, metricsTimeout)
+		}
+		ctx = WithNodeOptions(ctx, nodebuilder.WithMetricsExport(interval, timeout))
 
-		ctx = WithNodeOptions(ctx, nodebuilder.WithMetrics(opts, NodeType(ctx)))
+		limit, err := cmd.Flags().GetInt(metricsCardinality)
+		if err != nil {
+			panic(err)
+		}
+		if limit < 0 {
+			return ctx, fmt.Errorf("cmd: '%s' must not be negative", metricsCardinality)
+		}
+		ctx = WithNodeOptions(ctx, nodebuilder.WithMetricsCardinalityLimit(limit))
+	}
+
+	attrs, err := cmd.Flags().GetStringToString(otelAttributes)
+	if err != nil {
+		panic(err)
+	}
+	if len(attrs) > 0 {
+		for key := range attrs {
+			if key == "" {
+				return ctx, fmt.Errorf("cmd: while parsing '%s': empty attribute key", otelAttributes)
+			}
+		}
+		ctx = WithNodeOptions(ctx, nodebuilder.WithResourceAttributes(attrs))
 	}
 
 	ok, err = cmd.Flags().GetBool(p2pMetrics)
@@ -256,3 +537,70 @@ func ParseMiscFlags(ctx context.Context, cmd *cobra.Command) (context.Context, e
 
 	return ctx, err
 }
+
+// parsePyroscopeFlags reads the configuration of the Pyroscope profiling.
+func parsePyroscopeFlags(cmd *cobra.Command) (nodebuilder.PyroscopeConfig, error) {
+	cfg := nodebuilder.PyroscopeConfig{
+		Endpoint:          cmd.Flag(pyroscopeEndpoint).Value.String(),
+		ApplicationName:   cmd.Flag(pyroscopeApp).Value.String(),
+		AuthToken:         cmd.Flag(pyroscopeToken).Value.String(),
+		BasicAuthUser:     cmd.Flag(pyroscopeUser).Value.String(),
+		BasicAuthPassword: cmd.Flag(pyroscopePassword).Value.String(),
+		TenantID:          cmd.Flag(pyroscopeTenant).Value.String(),
+	}
+
+	var err error
+	if cfg.ProfileTypes, err = cmd.Flags().GetStringSlice(pyroscopeProfiles); err != nil {
+		panic(err)
+	}
+	if cfg.MutexProfileFraction, err = cmd.Flags().GetInt(pyroscopeMutex); err != nil {
+		panic(err)
+	}
+	if cfg.BlockProfileRate, err = cmd.Flags().GetInt(pyroscopeBlock); err != nil {
+		panic(err)
+	}
+	if cfg.UploadRate, err = cmd.Flags().GetDuration(pyroscopeUpload); err != nil {
+		panic(err)
+	}
+	if cfg.Headers, err = cmd.Flags().GetStringToString(pyroscopeHeaders); err != nil {
+		panic(err)
+	}
+	if cfg.Tags, err = cmd.Flags().GetStringToString(pyroscopeTags); err != nil {
+		panic(err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return cfg, fmt.Errorf("cmd: while parsing pyroscope flags: %w", err)
+	}
+	return cfg, nil
+}
+
+// parseOTLPFlags reads the transport, endpoint, TLS and headers flags of an OTLP exporter.
+// If the endpoint is not set explicitly, the default one for the chosen transport is used.
+func parseOTLPFlags(
+	cmd *cobra.Command,
+	transportFlag, endpointFlag, tlsFlag, headersFlag string,
+) (transport, endpoint string, tls bool, headers map[string]string, err error) {
+	transport = strings.ToLower(cmd.Flag(transportFlag).Value.String())
+	endpoint = cmd.Flag(endpointFlag).Value.String()
+	switch transport {
+	case otlpTransportHTTP:
+	case otlpTransportGRPC:
+		if !cmd.Flag(endpointFlag).Changed {
+			endpoint = otlpGRPCEndpoint
+		}
+	default:
+		return "", "", false, nil, fmt.Errorf("cmd: unsupported '%s' value '%s', must be '%s' or '%s'",
+			transportFlag, transport, otlpTransportHTTP, otlpTransportGRPC)
+	}
+
+	tls, err = cmd.Flags().GetBool(tlsFlag)
+	if err != nil {
+		panic(err)
+	}
+	headers, err = cmd.Flags().GetStringToString(headersFlag)
+	if err != nil {
+		panic(err)
+	}
+	return transport, endpoint, tls, headers, nil
+}
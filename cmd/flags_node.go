@@ -16,8 +16,10 @@ import (
 )
 
 var (
-	nodeStoreFlag  = "node.store"
-	nodeConfigFlag = "node.config"
+	nodeStoreFlag          = "node.store"
+	nodeConfigFlag         = "node.config"
+	nodeConfigChecksumFlag = "node.config.checksum"
+	nodePresetFlag         = "preset"
 )
 
 // NodeFlags gives a set of hardcoded Node package flags.
@@ -32,7 +34,19 @@ func NodeFlags() *flag.FlagSet {
 	flags.String(
 		nodeConfigFlag,
 		"",
-		"Path to a customized node config TOML file",
+		"Path or HTTP(S)/S3 URL to a customized node config TOML file. "+
+			"Remote configs are cached in the node store and used if the URL becomes unreachable",
+	)
+	flags.String(
+		nodeConfigChecksumFlag,
+		"",
+		fmt.Sprintf("Hex encoded SHA-256 checksum the config given with '--%s' must match", nodeConfigFlag),
+	)
+	flags.String(
+		nodePresetFlag,
+		"",
+		"Applies a curated set of config values for a common way of running the node, overridden by other flags: "+
+			presetsUsage(),
 	)
 
 	return flags
@@ -52,7 +66,20 @@ func ParseNodeFlags(ctx context.Context, cmd *cobra.Command, network p2p.Network
 	ctx = WithStorePath(ctx, store)
 
 	nodeConfig := cmd.Flag(nodeConfigFlag).Value.String()
-	if nodeConfig != "" {
+	checksum := cmd.Flag(nodeConfigChecksumFlag).Value.String()
+	if checksum != "" && !nodebuilder.IsRemoteConfig(nodeConfig) {
+		return ctx, fmt.Errorf("cmd: '%s' requires '%s' to be a URL", nodeConfigChecksumFlag, nodeConfigFlag)
+	}
+	switch {
+	case nodebuilder.IsRemoteConfig(nodeConfig):
+		// fetch config from the given URL, falling back to the cached copy
+		cfg, err := nodebuilder.LoadRemoteConfig(ctx, nodeConfig, checksum, store)
+		if err != nil {
+			return ctx, fmt.Errorf("cmd: while parsing '%s': %w", nodeConfigFlag, err)
+		}
+
+		ctx = WithNodeConfig(ctx, cfg)
+	case nodeConfig != "":
 		// try to load config from given path
 		cfg, err := nodebuilder.LoadConfig(nodeConfig)
 		if err != nil {
@@ -60,7 +87,7 @@ func ParseNodeFlags(ctx context.Context, cmd *cobra.Command, network p2p.Network
 		}
 
 		ctx = WithNodeConfig(ctx, cfg)
-	} else {
+	default:
 		// check if config already exists at the store path and load it
 		path := StorePath(ctx)
 		expanded, err := homedir.Expand(filepath.Clean(path))
@@ -72,9 +99,31 @@ func ParseNodeFlags(ctx context.Context, cmd *cobra.Command, network p2p.Network
 			ctx = WithNodeConfig(ctx, cfg)
 		}
 	}
+
+	if preset := cmd.Flag(nodePresetFlag).Value.String(); preset != "" {
+		cfg, err := NodeConfig(ctx)
+		if err != nil {
+			return ctx, err
+		}
+		err = nodebuilder.WithPreset(nodebuilder.Preset(preset), NodeType(ctx))(&cfg)
+		if err != nil {
+			return ctx, fmt.Errorf("cmd: while parsing '%s': %w", nodePresetFlag, err)
+		}
+		ctx = WithNodeConfig(ctx, &cfg)
+	}
 	return ctx, nil
 }
 
+// presetsUsage lists the presets along with what they do.
+func presetsUsage() string {
+	presets := nodebuilder.Presets()
+	usage := make([]string, len(presets))
+	for i, preset := range presets {
+		usage[i] = fmt.Sprintf("'%s' %s", preset, preset.Description())
+	}
+	return strings.Join(usage, ", ")
+}
+
 // DefaultNodeStorePath constructs the default node store path using the given
 // node type and network.
 func DefaultNodeStorePath(tp string, network string) (string, error) {
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder"
+)
+
+// Graph constructs a CLI command to print the dependency graph of the node.
+func Graph(fsets ...*flag.FlagSet) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Prints the dependency graph of the node",
+		Long: "Constructs the node with the given flags, without starting or calling any of its components, and " +
+			"prints the dependency graph of its constructors and the types they produce in the DOT language of " +
+			"Graphviz. Fails if a dependency of the node is missing. Other formats can be rendered from the " +
+			"output with Graphviz, e.g. 'dot -Tsvg' or 'dot -Tjson'.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			cfg, err := NodeConfig(ctx)
+			if err != nil {
+				return err
+			}
+			graph, err := nodebuilder.BuildGraph(NodeType(ctx), Network(ctx), &cfg, NodeOptions(ctx)...)
+			if err != nil {
+				return err
+			}
+			return graph.WriteDOT(cmd.OutOrStdout())
+		},
+	}
+
+	for _, set := range fsets {
+		cmd.Flags().AddFlagSet(set)
+	}
+	return cmd
+}
@@ -1,12 +1,52 @@
 package cmd
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client/input"
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
 	"github.com/celestiaorg/celestia-node/nodebuilder"
 )
 
+var (
+	initFromSnapshotFlag = "init-from-snapshot"
+	snapshotSHA256Flag   = "snapshot.sha256"
+	encryptStoreFlag     = "node.store.encrypt"
+	storeBackendFlag     = "node.store.backend"
+)
+
+// SnapshotFlags gives a set of flags to bootstrap the node store from a snapshot on Init.
+func SnapshotFlags() *flag.FlagSet {
+	flags := &flag.FlagSet{}
+
+	flags.String(
+		initFromSnapshotFlag,
+		"",
+		"Path or HTTP(S) URL of a trusted snapshot, created with 'snapshot create', to bootstrap "+
+			"the initialized store from instead of syncing from genesis",
+	)
+	flags.String(
+		snapshotSHA256Flag,
+		"",
+		"Hex SHA-256 checksum of the snapshot, as printed by 'snapshot create' and obtained from a "+
+			"trusted source. Required for snapshots downloaded over HTTP(S)",
+	)
+
+	return flags
+}
+
 // Init constructs a CLI command to initialize Celestia Node of any type with the given flags.
 func Init(fsets ...*flag.FlagSet) *cobra.Command {
 	cmd := &cobra.Command{
@@ -16,11 +56,179 @@ func Init(fsets ...*flag.FlagSet) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 
-			return nodebuilder.Init(NodeConfig(ctx), StorePath(ctx), NodeType(ctx))
+			cfg, err := NodeConfig(ctx)
+			if err != nil {
+				return err
+			}
+			err = nodebuilder.Init(cfg, StorePath(ctx), NodeType(ctx))
+			if err != nil {
+				return err
+			}
+
+			if backend, _ := cmd.Flags().GetString(storeBackendFlag); backend != "" {
+				b, err := nodebuilder.ParseStoreBackend(backend)
+				if err != nil {
+					return err
+				}
+				if err = nodebuilder.SetStoreBackend(StorePath(ctx), b); err != nil {
+					return err
+				}
+			}
+
+			if encrypt, _ := cmd.Flags().GetBool(encryptStoreFlag); encrypt {
+				if err = encryptStore(cmd, StorePath(ctx)); err != nil {
+					return err
+				}
+			}
+
+			snapshot := cmd.Flags().Lookup(initFromSnapshotFlag)
+			if snapshot == nil || snapshot.Value.String() == "" {
+				return nil
+			}
+			sum, _ := cmd.Flags().GetString(snapshotSHA256Flag)
+			return initFromSnapshot(cmd, snapshot.Value.String(), sum)
 		},
 	}
 	for _, set := range fsets {
 		cmd.Flags().AddFlagSet(set)
 	}
+	cmd.Flags().Bool(
+		encryptStoreFlag,
+		false,
+		"Encrypts the datastore at rest with a key derived from a passphrase, read from the "+
+			nodebuilder.EnvStorePassphrase+" environment variable or prompted for. "+
+			"The variable has to be set whenever the node is started",
+	)
+	cmd.Flags().String(
+		storeBackendFlag,
+		"",
+		fmt.Sprintf("Key-value engine the datastore is kept in: %s or %s (default %s). "+
+			"Stores with existing data are switched with 'store migrate'",
+			nodebuilder.StoreBackendBadger, nodebuilder.StoreBackendPebble, nodebuilder.StoreBackendBadger),
+	)
 	return cmd
 }
+
+// encryptStore encrypts the initialized store with the passphrase from the environment or, if
+// not set, prompted for.
+func encryptStore(cmd *cobra.Command, path string) error {
+	passphrase := os.Getenv(nodebuilder.EnvStorePassphrase)
+	if passphrase == "" {
+		buf := bufio.NewReader(cmd.InOrStdin())
+		var err error
+		passphrase, err = input.GetPassword("Enter store passphrase:", buf)
+		if err != nil {
+			return err
+		}
+		repeated, err := input.GetPassword("Repeat store passphrase:", buf)
+		if err != nil {
+			return err
+		}
+		if passphrase != repeated {
+			return errors.New("cmd: store passphrases do not match")
+		}
+	}
+	return nodebuilder.EncryptStore(path, []byte(passphrase))
+}
+
+// initFromSnapshot imports the snapshot under the given path or URL into the initialized store,
+// verifying its checksum first, if given. Snapshots downloaded from URLs must be given one.
+func initFromSnapshot(cmd *cobra.Command, src, sum string) error {
+	ctx := cmd.Context()
+	remote := strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://")
+	if remote && sum == "" {
+		return fmt.Errorf("cmd: --%s is required for snapshots downloaded over HTTP(S)", snapshotSHA256Flag)
+	}
+	var want []byte
+	if sum != "" {
+		var err error
+		want, err = hex.DecodeString(sum)
+		if err != nil || len(want) != sha256.Size {
+			return fmt.Errorf("cmd: --%s must be a hex SHA-256 checksum", snapshotSHA256Flag)
+		}
+	}
+
+	var (
+		r   io.ReadSeekCloser
+		err error
+	)
+	if remote {
+		r, err = downloadSnapshot(ctx, src)
+	} else {
+		r, err = os.Open(src)
+	}
+	if err != nil {
+		return fmt.Errorf("cmd: opening snapshot '%s': %w", src, err)
+	}
+	defer r.Close()
+	if want != nil {
+		if err = verifySnapshot(r, want); err != nil {
+			return fmt.Errorf("cmd: verifying snapshot '%s': %w", src, err)
+		}
+	}
+
+	store, err := nodebuilder.OpenStore(StorePath(ctx), nil)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	manifest, err := nodebuilder.ImportSnapshot(ctx, store, NodeType(ctx), r)
+	if err != nil {
+		return err
+	}
+	cmd.Printf("Imported snapshot of %d files taken at height %d\n", len(manifest.Files), manifest.Head)
+	return nil
+}
+
+// downloadSnapshot downloads the snapshot from the given URL into a temporary file, which is
+// removed once closed, so that it can be verified before importing.
+func downloadSnapshot(ctx context.Context, url string) (io.ReadSeekCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	f, err := os.CreateTemp("", "celestia-snapshot-*.tar")
+	if err != nil {
+		return nil, err
+	}
+	tmp := &tempFile{File: f}
+	if _, err = io.Copy(f, resp.Body); err != nil {
+		return nil, errors.Join(err, tmp.Close())
+	}
+	return tmp, nil
+}
+
+// verifySnapshot checks the snapshot against the checksum and rewinds it for importing.
+func verifySnapshot(f io.ReadSeeker, want []byte) error {
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	if got := h.Sum(nil); !bytes.Equal(got, want) {
+		return fmt.Errorf("checksum mismatch: got %x, expected %x", got, want)
+	}
+	_, err := f.Seek(0, io.SeekStart)
+	return err
+}
+
+// tempFile is a temporary file removed once closed.
+type tempFile struct {
+	*os.File
+}
+
+func (f *tempFile) Close() error {
+	return errors.Join(f.File.Close(), os.Remove(f.Name()))
+}
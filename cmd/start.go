@@ -1,22 +1,43 @@
 package cmd
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"syscall"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
+	"go.uber.org/fx"
 
 	"github.com/celestiaorg/celestia-app/app"
 	"github.com/celestiaorg/celestia-app/app/encoding"
 
+	"github.com/celestiaorg/celestia-node/libs/handover"
 	"github.com/celestiaorg/celestia-node/nodebuilder"
 )
 
+var (
+	safeModeFlag = "safe-mode"
+	handoverFlag = "handover"
+)
+
+const (
+	// handoverSocket is the Unix domain socket in the store directory the running Node hands its
+	// listeners over on to the Node taking its place.
+	handoverSocket = "handover.sock"
+	// handoverTimeout bounds the time the running Node is given to hand its listeners over.
+	handoverTimeout = time.Minute
+	// storeRetryInterval is the interval the store is retried to be opened at, while the Node
+	// handing its listeners over still has it open.
+	storeRetryInterval = 100 * time.Millisecond
+)
+
 // Start constructs a CLI command to start Celestia Node daemon of any type with the given flags.
 func Start(fsets ...*flag.FlagSet) *cobra.Command {
 	cmd := &cobra.Command{
@@ -30,7 +51,10 @@ Options passed on start override configuration options only on start and are not
 			ctx := cmd.Context()
 
 			// override config with all modifiers passed on start
-			cfg := NodeConfig(ctx)
+			cfg, err := NodeConfig(ctx)
+			if err != nil {
+				return err
+			}
 
 			storePath := StorePath(ctx)
 			keysPath := filepath.Join(storePath, "keys")
@@ -44,24 +68,64 @@ Options passed on start override configuration options only on start and are not
 				return err
 			}
 
-			store, err := nodebuilder.OpenStore(storePath, ring)
+			safeMode, err := cmd.Flags().GetBool(safeModeFlag)
+			if err != nil {
+				return err
+			}
+
+			openStore, newNode := nodebuilder.OpenStore, nodebuilder.NewWithConfig
+			if safeMode {
+				openStore, newNode = nodebuilder.OpenStoreReadOnly, nodebuilder.NewSafeMode
+			}
+
+			handoverPath := filepath.Join(storePath, handoverSocket)
+			listeners := handover.NewListeners()
+			takeOver, err := cmd.Flags().GetBool(handoverFlag)
 			if err != nil {
 				return err
 			}
+			var store nodebuilder.Store
+			if takeOver {
+				reqCtx, cancel := context.WithTimeout(ctx, handoverTimeout)
+				listeners, err = handover.Request(reqCtx, handoverPath)
+				cancel()
+				if err != nil {
+					return err
+				}
+				// the store is released by the Node handing its listeners over once it stops
+				store, err = waitStore(ctx, storePath, ring, openStore)
+			} else {
+				store, err = openStore(storePath, ring)
+			}
+			if err != nil {
+				return errors.Join(err, listeners.CloseUnused())
+			}
 			defer func() {
 				err = errors.Join(err, store.Close())
 			}()
 
-			nd, err := nodebuilder.NewWithConfig(NodeType(ctx), Network(ctx), store, &cfg, NodeOptions(ctx)...)
+			opts := append(NodeOptions(ctx), fx.Supply(listeners))
+			nd, err := newNode(NodeType(ctx), Network(ctx), store, &cfg, opts...)
 			if err != nil {
-				return err
+				return errors.Join(err, listeners.CloseUnused())
 			}
 
 			ctx, cancel := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
 			defer cancel()
 			err = nd.Start(ctx)
 			if err != nil {
-				return err
+				return errors.Join(err, listeners.CloseUnused())
+			}
+			if err := listeners.CloseUnused(); err != nil {
+				log.Warnw("closing unused inherited listeners", "err", err)
+			}
+
+			// the Node stops gracefully once its listeners are taken over by the next one
+			handoverServ, err := handover.Serve(handoverPath, listeners, cancel)
+			if err != nil {
+				log.Warnw("serving listener handover", "err", err)
+			} else {
+				defer handoverServ.Close() //nolint:errcheck
 			}
 
 			<-ctx.Done()
@@ -72,8 +136,36 @@ Options passed on start override configuration options only on start and are not
 			return nd.Stop(ctx)
 		},
 	}
+	cmd.Flags().Bool(handoverFlag, false, "Takes the RPC and gateway listeners over from the Node running on "+
+		"the same store, e.g. to upgrade it without downtime: the running Node stops gracefully, and this "+
+		"one starts serving on the same sockets once the store is released.")
+	cmd.Flags().Bool(safeModeFlag, false, "Starts the node in safe mode to diagnose and extract data from a "+
+		"damaged store: the store is opened read-only, the node does not connect to the network or run "+
+		"background jobs, and only the header and node services are served over RPC.")
 	for _, set := range fsets {
 		cmd.Flags().AddFlagSet(set)
 	}
 	return cmd
 }
+
+// waitStore opens the store at the given path, retrying while it is in use, until the context is
+// done.
+func waitStore(
+	ctx context.Context,
+	path string,
+	ring keyring.Keyring,
+	openStore func(string, keyring.Keyring) (nodebuilder.Store, error),
+) (nodebuilder.Store, error) {
+	for {
+		store, err := openStore(path, ring)
+		if !errors.Is(err, nodebuilder.ErrOpened) {
+			return store, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for the store to be released: %w", ctx.Err())
+		case <-time.After(storeRetryInterval):
+		}
+	}
+}
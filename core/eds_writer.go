@@ -0,0 +1,244 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds"
+)
+
+var meter = otel.Meter("core")
+
+var errWriterStopped = errors.New("EDS writer is stopped")
+
+const (
+	// maxWriteAttempts is the amount of times a queued EDS is tried to be stored before it is
+	// given up on.
+	maxWriteAttempts = 5
+	// maxWriteBackoff caps the delay between the attempts to store a queued EDS.
+	maxWriteBackoff = time.Second * 10
+)
+
+// writeBackoff is the delay before the second attempt to store a queued EDS, doubling with each
+// following one.
+var writeBackoff = time.Millisecond * 500
+
+// WriteStrategy is the way the Listener persists the EDSes of new blocks.
+type WriteStrategy string
+
+const (
+	// WriteSync stores the EDS of a block before broadcasting its header, so that the data of every
+	// announced header is already stored. A slow store delays the tracking of the head.
+	WriteSync WriteStrategy = "sync"
+	// WriteAsync broadcasts the header of a block right away and queues its EDS to be stored in the
+	// background, announcing the data hash over shrexsub once it is stored. Once the queue is full,
+	// new blocks wait for space in it. Failed writes are retried with backoff, but queued EDSes are
+	// lost if the node crashes before storing them.
+	WriteAsync WriteStrategy = "async"
+)
+
+// Validate checks the WriteStrategy is supported.
+func (s WriteStrategy) Validate() error {
+	switch s {
+	case WriteSync, WriteAsync:
+		return nil
+	default:
+		return fmt.Errorf("unsupported EDS write strategy %q, expected %q or %q", s, WriteSync, WriteAsync)
+	}
+}
+
+// edsWrite is an EDS queued to be stored.
+type edsWrite struct {
+	hash   share.DataHash
+	eds    *rsmt2d.ExtendedDataSquare
+	height int64
+	// onStored is called once the EDS is stored.
+	onStored func(context.Context)
+}
+
+// edsWriter stores EDSes either right away or through a bounded queue drained in the background.
+type edsWriter struct {
+	put func(context.Context, share.DataHash, *rsmt2d.ExtendedDataSquare) error
+
+	// queue is nil for synchronous writes.
+	queue  chan edsWrite
+	stopCh chan struct{}
+	done   chan struct{}
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	failed  metric.Int64Counter
+	retried metric.Int64Counter
+}
+
+func newEDSWriter(store *eds.Store, strategy WriteStrategy, queueSize int) *edsWriter {
+	w := &edsWriter{
+		put: func(ctx context.Context, hash share.DataHash, eds *rsmt2d.ExtendedDataSquare) error {
+			return storeEDS(ctx, hash, eds, store)
+		},
+	}
+	if strategy == WriteAsync {
+		w.queue = make(chan edsWrite, queueSize)
+	}
+	return w
+}
+
+func (w *edsWriter) start() {
+	if w.queue == nil {
+		return
+	}
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+	w.stopCh = make(chan struct{})
+	w.done = make(chan struct{})
+	go w.run()
+}
+
+// stop waits for the queued EDSes to be stored, aborting the writes once the context is done.
+func (w *edsWriter) stop(ctx context.Context) error {
+	if w.queue == nil {
+		return nil
+	}
+	close(w.stopCh)
+	defer w.cancel()
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		w.cancel()
+		<-w.done
+		return fmt.Errorf("core: storing queued EDSes: %w", ctx.Err())
+	}
+}
+
+// write stores the EDS or queues it to be stored, calling onStored once it is stored.
+func (w *edsWriter) write(
+	ctx context.Context,
+	hash share.DataHash,
+	eds *rsmt2d.ExtendedDataSquare,
+	height int64,
+	onStored func(context.Context),
+) error {
+	if eds == nil {
+		// nothing to store for empty blocks
+		onStored(ctx)
+		return nil
+	}
+	if w.queue == nil {
+		if err := w.put(ctx, hash, eds); err != nil {
+			return err
+		}
+		onStored(ctx)
+		return nil
+	}
+
+	select {
+	case w.queue <- edsWrite{hash: hash, eds: eds, height: height, onStored: onStored}:
+		return nil
+	case <-w.stopCh:
+		return errWriterStopped
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w *edsWriter) run() {
+	defer close(w.done)
+	for {
+		select {
+		case write := <-w.queue:
+			w.persist(write)
+		case <-w.stopCh:
+			for {
+				select {
+				case write := <-w.queue:
+					w.persist(write)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// persist stores the queued EDS, retrying with backoff as its header is already broadcasted.
+func (w *edsWriter) persist(write edsWrite) {
+	backoff := writeBackoff
+	for attempt := 1; ; attempt++ {
+		err := w.put(w.ctx, write.hash, write.eds)
+		if err == nil {
+			write.onStored(w.ctx)
+			return
+		}
+		if attempt == maxWriteAttempts || w.ctx.Err() != nil {
+			log.Errorw("storing queued EDS, giving up on it", "height", write.height,
+				"hash", write.hash.String(), "attempts", attempt, "err", err)
+			if w.failed != nil {
+				w.failed.Add(w.ctx, 1)
+			}
+			return
+		}
+
+		log.Warnw("storing queued EDS, retrying", "height", write.height, "hash", write.hash.String(),
+			"attempt", attempt, "retry_in", backoff, "err", err)
+		if w.retried != nil {
+			w.retried.Add(w.ctx, 1)
+		}
+		select {
+		case <-time.After(backoff):
+		case <-w.ctx.Done():
+		}
+		if backoff *= 2; backoff > maxWriteBackoff {
+			backoff = maxWriteBackoff
+		}
+	}
+}
+
+// withMetrics observes the depth of the queue and counts the retried and failed writes of the queued
+// EDSes.
+func (w *edsWriter) withMetrics() error {
+	if w.queue == nil {
+		return nil
+	}
+
+	depth, err := meter.Int64ObservableGauge("core_eds_write_queue_depth",
+		metric.WithDescription("amount of EDSes queued to be stored"))
+	if err != nil {
+		return err
+	}
+	capacity, err := meter.Int64ObservableGauge("core_eds_write_queue_capacity",
+		metric.WithDescription("maximum amount of EDSes queued to be stored"))
+	if err != nil {
+		return err
+	}
+	failed, err := meter.Int64Counter("core_eds_write_queue_failed",
+		metric.WithDescription("amount of queued EDSes that failed to be stored"))
+	if err != nil {
+		return err
+	}
+	w.failed = failed
+	retried, err := meter.Int64Counter("core_eds_write_queue_retried",
+		metric.WithDescription("amount of retried attempts to store queued EDSes"))
+	if err != nil {
+		return err
+	}
+	w.retried = retried
+
+	callback := func(ctx context.Context, observer metric.Observer) error {
+		observer.ObserveInt64(depth, int64(len(w.queue)))
+		observer.ObserveInt64(capacity, int64(cap(w.queue)))
+		return nil
+	}
+	if _, err := meter.RegisterCallback(callback, depth, capacity); err != nil {
+		return fmt.Errorf("registering metrics callback: %w", err)
+	}
+	return nil
+}
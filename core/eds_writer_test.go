@@ -0,0 +1,54 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds/edstest"
+)
+
+func TestEDSWriter_RetriesQueuedWrites(t *testing.T) {
+	backoff := writeBackoff
+	writeBackoff = time.Millisecond
+	t.Cleanup(func() { writeBackoff = backoff })
+
+	var attempts int
+	w := &edsWriter{
+		queue: make(chan edsWrite, 2),
+		put: func(context.Context, share.DataHash, *rsmt2d.ExtendedDataSquare) error {
+			attempts++
+			if attempts == 2 {
+				return nil
+			}
+			return errors.New("disk full")
+		},
+	}
+	w.start()
+
+	stored := make(chan int64, 2)
+	onStored := func(height int64) func(context.Context) {
+		return func(context.Context) { stored <- height }
+	}
+	ctx := context.Background()
+	eds := edstest.RandEDS(t, 4)
+	// the first write succeeds on its second attempt, the second one never does
+	require.NoError(t, w.write(ctx, share.DataHash{1}, eds, 1, onStored(1)))
+	require.NoError(t, w.write(ctx, share.DataHash{2}, eds, 2, onStored(2)))
+	require.NoError(t, w.stop(ctx))
+
+	close(stored)
+	var heights []int64
+	for height := range stored {
+		heights = append(heights, height)
+	}
+	assert.Equal(t, []int64{1}, heights)
+	assert.Equal(t, 2+maxWriteAttempts, attempts)
+}
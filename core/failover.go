@@ -0,0 +1,221 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// DefaultHealthCheckInterval is the interval the Failover checks the active Core endpoint at by
+// default.
+const DefaultHealthCheckInterval = 5 * time.Second
+
+// Endpoint is a Core endpoint the Failover can fetch blocks from.
+type Endpoint struct {
+	// Address identifies the endpoint in logs, metrics and the info of the node.
+	Address string
+	Client  Client
+}
+
+// Failover keeps the clients of several Core endpoints and serves the one that is active. It
+// health-checks the active endpoint at an interval and fails over to the next healthy one, in the
+// given order, once the active one becomes unreachable, e.g. when the Core node is restarted.
+type Failover struct {
+	endpoints []Endpoint
+	interval  time.Duration
+
+	lock   sync.RWMutex
+	active int
+	// switched is closed once the active endpoint changes, ending the subscriptions made to it
+	switched chan struct{}
+
+	failovers metric.Int64Counter
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewFailover creates a new Failover between the given endpoints, the first of them being active
+// until it fails. Endpoints are health-checked at the given interval, or at
+// DefaultHealthCheckInterval if it is zero.
+func NewFailover(interval time.Duration, endpoints ...Endpoint) *Failover {
+	if interval == 0 {
+		interval = DefaultHealthCheckInterval
+	}
+	return &Failover{
+		endpoints: endpoints,
+		interval:  interval,
+		switched:  make(chan struct{}),
+	}
+}
+
+// Start starts the clients of the endpoints and, if there are several of them, the health checks.
+// It fails only if none of the clients can be started.
+func (f *Failover) Start(ctx context.Context) error {
+	var errs []error
+	for _, endpoint := range f.endpoints {
+		if err := startClient(endpoint.Client); err != nil {
+			log.Errorw("failover: starting client", "endpoint", endpoint.Address, "err", err)
+			errs = append(errs, fmt.Errorf("%s: %w", endpoint.Address, err))
+		}
+	}
+	if len(errs) == len(f.endpoints) {
+		return fmt.Errorf("failover: starting clients: %w", errors.Join(errs...))
+	}
+	if len(f.endpoints) == 1 {
+		return nil
+	}
+
+	f.check(ctx)
+	runCtx, cancel := context.WithCancel(context.Background())
+	f.cancel = cancel
+	f.done = make(chan struct{})
+	go f.run(runCtx)
+	return nil
+}
+
+// Stop stops the health checks and the clients of the endpoints.
+func (f *Failover) Stop(ctx context.Context) error {
+	if f.cancel != nil {
+		f.cancel()
+		select {
+		case <-f.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	var err error
+	for _, endpoint := range f.endpoints {
+		if endpoint.Client.IsRunning() {
+			err = errors.Join(err, endpoint.Client.Stop())
+		}
+	}
+	return err
+}
+
+// Client returns the client of the active endpoint.
+func (f *Failover) Client() Client {
+	client, _ := f.current()
+	return client
+}
+
+// ActiveEndpoint returns the address of the active endpoint.
+func (f *Failover) ActiveEndpoint() string {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return f.endpoints[f.active].Address
+}
+
+// WithMetrics turns on metric collection in the Failover: the active endpoint and the amount of
+// failovers.
+func (f *Failover) WithMetrics() error {
+	active, err := meter.Int64ObservableGauge("core_endpoint_active",
+		metric.WithDescription("whether the Core endpoint is the active one (1) or not (0)"))
+	if err != nil {
+		return fmt.Errorf("failover: init metrics: %w", err)
+	}
+	failovers, err := meter.Int64Counter("core_endpoint_failovers",
+		metric.WithDescription("amount of failovers to another Core endpoint"))
+	if err != nil {
+		return fmt.Errorf("failover: init metrics: %w", err)
+	}
+	f.failovers = failovers
+
+	callback := func(ctx context.Context, observer metric.Observer) error {
+		f.lock.RLock()
+		defer f.lock.RUnlock()
+		for i, endpoint := range f.endpoints {
+			var value int64
+			if i == f.active {
+				value = 1
+			}
+			observer.ObserveInt64(active, value, metric.WithAttributes(attribute.String("endpoint", endpoint.Address)))
+		}
+		return nil
+	}
+	if _, err := meter.RegisterCallback(callback, active); err != nil {
+		return fmt.Errorf("failover: registering metrics callback: %w", err)
+	}
+	return nil
+}
+
+// current returns the client of the active endpoint along with a channel closed once another
+// endpoint becomes active.
+func (f *Failover) current() (Client, <-chan struct{}) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return f.endpoints[f.active].Client, f.switched
+}
+
+func (f *Failover) run(ctx context.Context) {
+	defer close(f.done)
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			f.check(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// check fails over to the next healthy endpoint if the active one is not healthy.
+func (f *Failover) check(ctx context.Context) {
+	f.lock.RLock()
+	active := f.active
+	f.lock.RUnlock()
+	if f.healthy(ctx, f.endpoints[active]) {
+		return
+	}
+
+	for i := 1; i < len(f.endpoints); i++ {
+		next := (active + i) % len(f.endpoints)
+		if !f.healthy(ctx, f.endpoints[next]) {
+			continue
+		}
+
+		f.lock.Lock()
+		f.active = next
+		close(f.switched)
+		f.switched = make(chan struct{})
+		f.lock.Unlock()
+		if f.failovers != nil {
+			f.failovers.Add(ctx, 1)
+		}
+		log.Warnw("failover: switched core endpoint",
+			"from", f.endpoints[active].Address, "to", f.endpoints[next].Address)
+		return
+	}
+	log.Errorw("failover: no healthy core endpoint", "active", f.endpoints[active].Address)
+}
+
+// healthy reports whether the endpoint serves requests, restarting its client if needed.
+func (f *Failover) healthy(ctx context.Context, endpoint Endpoint) bool {
+	if err := startClient(endpoint.Client); err != nil {
+		log.Debugw("failover: starting client", "endpoint", endpoint.Address, "err", err)
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, f.interval)
+	defer cancel()
+	if _, err := endpoint.Client.Status(ctx); err != nil {
+		log.Debugw("failover: unhealthy endpoint", "endpoint", endpoint.Address, "err", err)
+		return false
+	}
+	return true
+}
+
+func startClient(client Client) error {
+	if client.IsRunning() {
+		return nil
+	}
+	return client.Start()
+}
@@ -0,0 +1,83 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+func TestFailover(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	client := StartTestNode(t).Client
+	primary := &toggledClient{Client: client}
+	secondary := &toggledClient{Client: client}
+	failover := NewFailover(time.Millisecond*10,
+		Endpoint{Address: "primary", Client: primary},
+		Endpoint{Address: "secondary", Client: secondary},
+	)
+	require.NoError(t, failover.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, failover.Stop(ctx))
+	})
+	assert.Equal(t, "primary", failover.ActiveEndpoint())
+
+	fetcher := NewFailoverBlockFetcher(failover)
+	sub, err := fetcher.SubscribeNewBlockEvent(ctx)
+	require.NoError(t, err)
+	select {
+	case _, ok := <-sub:
+		require.True(t, ok)
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+
+	// the subscription to the unreachable endpoint ends once the failover switches to the next one
+	primary.down.Store(true)
+	for open := true; open; {
+		select {
+		case _, open = <-sub:
+		case <-ctx.Done():
+			t.Fatal(ctx.Err())
+		}
+	}
+	assert.Equal(t, "secondary", failover.ActiveEndpoint())
+	assert.Equal(t, secondary, failover.Client())
+
+	require.NoError(t, fetcher.UnsubscribeNewBlockEvent(ctx))
+	sub, err = fetcher.SubscribeNewBlockEvent(ctx)
+	require.NoError(t, err)
+	select {
+	case _, ok := <-sub:
+		require.True(t, ok)
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+	require.NoError(t, fetcher.UnsubscribeNewBlockEvent(ctx))
+}
+
+// toggledClient is a Client that can be made unreachable. Stopping it leaves the underlying
+// client running, as it is shared.
+type toggledClient struct {
+	Client
+
+	down atomic.Bool
+}
+
+func (c *toggledClient) Status(ctx context.Context) (*coretypes.ResultStatus, error) {
+	if c.down.Load() {
+		return nil, errors.New("unreachable")
+	}
+	return c.Client.Status(ctx)
+}
+
+func (c *toggledClient) Stop() error {
+	return nil
+}
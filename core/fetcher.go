@@ -19,7 +19,9 @@ var (
 )
 
 type BlockFetcher struct {
-	client Client
+	clients *Failover
+	// subscribed is the client subscribed to new block events, which may no longer be the active one
+	subscribed Client
 
 	doneCh chan struct{}
 	cancel context.CancelFunc
@@ -27,8 +29,14 @@ type BlockFetcher struct {
 
 // NewBlockFetcher returns a new `BlockFetcher`.
 func NewBlockFetcher(client Client) *BlockFetcher {
+	return NewFailoverBlockFetcher(NewFailover(0, Endpoint{Client: client}))
+}
+
+// NewFailoverBlockFetcher returns a new `BlockFetcher` fetching from the active endpoint of the
+// given Failover.
+func NewFailoverBlockFetcher(clients *Failover) *BlockFetcher {
 	return &BlockFetcher{
-		client: client,
+		clients: clients,
 	}
 }
 
@@ -54,7 +62,7 @@ func (f *BlockFetcher) GetBlockInfo(ctx context.Context, height *int64) (*types.
 
 // GetBlock queries Core for a `Block` at the given height.
 func (f *BlockFetcher) GetBlock(ctx context.Context, height *int64) (*types.Block, error) {
-	res, err := f.client.Block(ctx, height)
+	res, err := f.clients.Client().Block(ctx, height)
 	if err != nil {
 		return nil, err
 	}
@@ -67,7 +75,7 @@ func (f *BlockFetcher) GetBlock(ctx context.Context, height *int64) (*types.Bloc
 }
 
 func (f *BlockFetcher) GetBlockByHash(ctx context.Context, hash libhead.Hash) (*types.Block, error) {
-	res, err := f.client.BlockByHash(ctx, hash)
+	res, err := f.clients.Client().BlockByHash(ctx, hash)
 	if err != nil {
 		return nil, err
 	}
@@ -81,13 +89,13 @@ func (f *BlockFetcher) GetBlockByHash(ctx context.Context, hash libhead.Hash) (*
 
 // GetSignedBlock queries Core for a `Block` at the given height.
 func (f *BlockFetcher) GetSignedBlock(ctx context.Context, height *int64) (*coretypes.ResultSignedBlock, error) {
-	return f.client.SignedBlock(ctx, height)
+	return f.clients.Client().SignedBlock(ctx, height)
 }
 
 // Commit queries Core for a `Commit` from the block at
 // the given height.
 func (f *BlockFetcher) Commit(ctx context.Context, height *int64) (*types.Commit, error) {
-	res, err := f.client.Commit(ctx, height)
+	res, err := f.clients.Client().Commit(ctx, height)
 	if err != nil {
 		return nil, err
 	}
@@ -106,7 +114,7 @@ func (f *BlockFetcher) ValidatorSet(ctx context.Context, height *int64) (*types.
 
 	vals, total := make([]*types.Validator, 0), -1
 	for page := 1; len(vals) != total; page++ {
-		res, err := f.client.Validators(ctx, height, &page, &perPage)
+		res, err := f.clients.Client().Validators(ctx, height, &page, &perPage)
 		if err != nil {
 			return nil, err
 		}
@@ -125,16 +133,18 @@ func (f *BlockFetcher) ValidatorSet(ctx context.Context, height *int64) (*types.
 // SubscribeNewBlockEvent subscribes to new block events from Core, returning
 // a new block event channel on success.
 func (f *BlockFetcher) SubscribeNewBlockEvent(ctx context.Context) (<-chan types.EventDataSignedBlock, error) {
+	client, switched := f.clients.current()
 	// start the client if not started yet
-	if !f.client.IsRunning() {
+	if !client.IsRunning() {
 		return nil, fmt.Errorf("client not running")
 	}
+	f.subscribed = client
 
 	ctx, cancel := context.WithCancel(ctx)
 	f.cancel = cancel
 	f.doneCh = make(chan struct{})
 
-	eventChan, err := f.client.Subscribe(ctx, newBlockSubscriber, newDataSignedBlockQuery)
+	eventChan, err := client.Subscribe(ctx, newBlockSubscriber, newDataSignedBlockQuery)
 	if err != nil {
 		return nil, err
 	}
@@ -147,6 +157,9 @@ func (f *BlockFetcher) SubscribeNewBlockEvent(ctx context.Context) (<-chan types
 			select {
 			case <-ctx.Done():
 				return
+			case <-switched:
+				log.Warn("fetcher: core endpoint switched, ending new blocks subscription")
+				return
 			case newEvent, ok := <-eventChan:
 				if !ok {
 					log.Errorw("fetcher: new blocks subscription channel closed unexpectedly")
@@ -173,14 +186,20 @@ func (f *BlockFetcher) UnsubscribeNewBlockEvent(ctx context.Context) error {
 	case <-ctx.Done():
 		return fmt.Errorf("fetcher: unsubscribe from new block events: %w", ctx.Err())
 	}
-	return f.client.Unsubscribe(ctx, newBlockSubscriber, newDataSignedBlockQuery)
+	err := f.subscribed.Unsubscribe(ctx, newBlockSubscriber, newDataSignedBlockQuery)
+	if err != nil && f.subscribed != f.clients.Client() {
+		// the endpoint failed over from is likely unreachable, so the subscription is left behind
+		log.Warnw("fetcher: unsubscribe from the previous core endpoint", "err", err)
+		return nil
+	}
+	return err
 }
 
 // IsSyncing returns the sync status of the Core connection: true for
 // syncing, and false for already caught up. It can also return an error
 // in the case of a failed status request.
 func (f *BlockFetcher) IsSyncing(ctx context.Context) (bool, error) {
-	resp, err := f.client.Status(ctx)
+	resp, err := f.clients.Client().Status(ctx)
 	if err != nil {
 		return false, err
 	}
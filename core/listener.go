@@ -31,7 +31,7 @@ type Listener struct {
 	fetcher *BlockFetcher
 
 	construct header.ConstructFn
-	store     *eds.Store
+	writer    *edsWriter
 
 	headerBroadcaster libhead.Broadcaster[*header.ExtendedHeader]
 	hashBroadcaster   shrexsub.BroadcastFn
@@ -41,6 +41,23 @@ type Listener struct {
 	cancel context.CancelFunc
 }
 
+// ListenerOption configures the Listener.
+type ListenerOption func(*listenerParams)
+
+type listenerParams struct {
+	writeStrategy WriteStrategy
+	queueSize     int
+}
+
+// WithWriteStrategy sets the way the Listener persists the EDSes of new blocks, along with the size
+// of the queue for WriteAsync. EDSes are stored synchronously by default.
+func WithWriteStrategy(strategy WriteStrategy, queueSize int) ListenerOption {
+	return func(p *listenerParams) {
+		p.writeStrategy = strategy
+		p.queueSize = queueSize
+	}
+}
+
 func NewListener(
 	bcast libhead.Broadcaster[*header.ExtendedHeader],
 	fetcher *BlockFetcher,
@@ -48,17 +65,30 @@ func NewListener(
 	construct header.ConstructFn,
 	store *eds.Store,
 	blocktime time.Duration,
+	opts ...ListenerOption,
 ) *Listener {
+	params := listenerParams{writeStrategy: WriteSync}
+	for _, opt := range opts {
+		opt(&params)
+	}
 	return &Listener{
 		fetcher:           fetcher,
 		headerBroadcaster: bcast,
 		hashBroadcaster:   hashBroadcaster,
 		construct:         construct,
-		store:             store,
+		writer:            newEDSWriter(store, params.writeStrategy, params.queueSize),
 		listenerTimeout:   2 * blocktime,
 	}
 }
 
+// WithMetrics turns on metric collection in the Listener.
+func (cl *Listener) WithMetrics() error {
+	if err := cl.writer.withMetrics(); err != nil {
+		return fmt.Errorf("listener: init metrics: %w", err)
+	}
+	return nil
+}
+
 // Start kicks off the Listener listener loop.
 func (cl *Listener) Start(context.Context) error {
 	if cl.cancel != nil {
@@ -72,15 +102,16 @@ func (cl *Listener) Start(context.Context) error {
 	if err != nil {
 		return err
 	}
+	cl.writer.start()
 	go cl.runSubscriber(ctx, sub)
 	return nil
 }
 
-// Stop stops the listener loop.
-func (cl *Listener) Stop(context.Context) error {
+// Stop stops the listener loop, waiting for the queued EDSes to be stored.
+func (cl *Listener) Stop(ctx context.Context) error {
 	cl.cancel()
 	cl.cancel = nil
-	return nil
+	return cl.writer.stop(ctx)
 }
 
 // runSubscriber runs a subscriber to receive event data of new signed blocks. It will attempt to
@@ -160,20 +191,17 @@ func (cl *Listener) handleNewSignedBlock(ctx context.Context, b types.EventDataS
 		return fmt.Errorf("making extended header: %w", err)
 	}
 
-	// attempt to store block data if not empty
-	err = storeEDS(ctx, b.Header.DataHash.Bytes(), eds, cl.store)
-	if err != nil {
-		return fmt.Errorf("storing EDS: %w", err)
-	}
-
 	syncing, err := cl.fetcher.IsSyncing(ctx)
 	if err != nil {
 		return fmt.Errorf("getting sync state: %w", err)
 	}
 
-	// notify network of new EDS hash only if core is already synced
-	if !syncing {
-		err = cl.hashBroadcaster(ctx, shrexsub.Notification{
+	// notify network of new EDS hash only if core is already synced and the EDS is stored
+	onStored := func(ctx context.Context) {
+		if syncing {
+			return
+		}
+		err := cl.hashBroadcaster(ctx, shrexsub.Notification{
 			DataHash: eh.DataHash.Bytes(),
 			Height:   uint64(eh.Height()),
 		})
@@ -184,6 +212,12 @@ func (cl *Listener) handleNewSignedBlock(ctx context.Context, b types.EventDataS
 		}
 	}
 
+	// attempt to store block data if not empty
+	err = cl.writer.write(ctx, b.Header.DataHash.Bytes(), eds, b.Header.Height, onStored)
+	if err != nil {
+		return fmt.Errorf("storing EDS: %w", err)
+	}
+
 	// broadcast new ExtendedHeader, but if core is still syncing, notify only local subscribers
 	err = cl.headerBroadcaster.Broadcast(ctx, eh, pubsub.WithLocalPublication(syncing))
 	if err != nil && !errors.Is(err, context.Canceled) {
@@ -64,8 +64,17 @@ func TestListener(t *testing.T) {
 }
 
 // TestListenerWithNonEmptyBlocks ensures that non-empty blocks are actually
-// stored to eds.Store.
+// stored to eds.Store before their hashes are broadcasted, with both write strategies.
 func TestListenerWithNonEmptyBlocks(t *testing.T) {
+	for _, strategy := range []WriteStrategy{WriteSync, WriteAsync} {
+		strategy := strategy
+		t.Run(string(strategy), func(t *testing.T) {
+			testListenerWithNonEmptyBlocks(t, WithWriteStrategy(strategy, 4))
+		})
+	}
+}
+
+func testListenerWithNonEmptyBlocks(t *testing.T, opts ...ListenerOption) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	t.Cleanup(cancel)
 
@@ -86,7 +95,7 @@ func TestListenerWithNonEmptyBlocks(t *testing.T) {
 	})
 
 	// create Listener and start listening
-	cl := createListener(ctx, t, fetcher, ps0, eds, store)
+	cl := createListener(ctx, t, fetcher, ps0, eds, store, opts...)
 	err = cl.Start(ctx)
 	require.NoError(t, err)
 
@@ -160,6 +169,7 @@ func createListener(
 	ps *pubsub.PubSub,
 	edsSub *shrexsub.PubSub,
 	store *eds.Store,
+	opts ...ListenerOption,
 ) *Listener {
 	p2pSub := p2p.NewSubscriber[*header.ExtendedHeader](ps, header.MsgID, networkID)
 	err := p2pSub.Start(ctx)
@@ -168,7 +178,8 @@ func createListener(
 		require.NoError(t, p2pSub.Stop(ctx))
 	})
 
-	return NewListener(p2pSub, fetcher, edsSub.Broadcast, header.MakeExtendedHeader, store, nodep2p.BlockTime)
+	return NewListener(p2pSub, fetcher, edsSub.Broadcast, header.MakeExtendedHeader, store, nodep2p.BlockTime,
+		opts...)
 }
 
 func createEdsPubSub(ctx context.Context, t *testing.T) *shrexsub.PubSub {
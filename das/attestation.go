@@ -0,0 +1,155 @@
+package das
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"golang.org/x/time/rate"
+
+	libhead "github.com/celestiaorg/go-header"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// attestationDomain separates signatures over attestations from any other signature made with the
+// same key.
+const attestationDomain = "celestia-node/das-attestation/v1"
+
+const (
+	// attestRate bounds the rate of attestations per second, as each of them samples the data.
+	attestRate = 1
+	// attestBurst is the amount of attestations that can be made at once.
+	attestBurst = 5
+)
+
+var (
+	// ErrInvalidAttestation is returned when the signature of an Attestation does not verify.
+	ErrInvalidAttestation = errors.New("das: invalid attestation")
+	// ErrAttestRateLimited is returned when attestations are requested faster than they are made.
+	ErrAttestRateLimited = errors.New("das: attestations rate limited")
+)
+
+// Attestation is a statement signed by a node about the availability of the data committed to in
+// the header at the given height. External systems can collect attestations from many nodes and
+// aggregate them.
+type Attestation struct {
+	Height   uint64         `json:"height"`
+	DataRoot share.DataHash `json:"data_root"`
+	// Available reports whether sampling of the data succeeded.
+	Available bool      `json:"available"`
+	Timestamp time.Time `json:"timestamp"`
+	// Signer is the peer ID of the node, from which the public key verifying the Signature is
+	// derived.
+	Signer    peer.ID `json:"signer"`
+	Signature []byte  `json:"signature"`
+}
+
+// signBytes returns the deterministic encoding of the attestation being signed.
+func (a *Attestation) signBytes() []byte {
+	b := make([]byte, 0, len(attestationDomain)+8+len(a.DataRoot)+1+8)
+	b = append(b, attestationDomain...)
+	b = binary.BigEndian.AppendUint64(b, a.Height)
+	b = append(b, a.DataRoot...)
+	if a.Available {
+		b = append(b, 1)
+	} else {
+		b = append(b, 0)
+	}
+	return binary.BigEndian.AppendUint64(b, uint64(a.Timestamp.UnixNano()))
+}
+
+// Verify checks the attestation is signed by its Signer.
+func (a *Attestation) Verify() error {
+	pub, err := a.Signer.ExtractPublicKey()
+	if err != nil {
+		return fmt.Errorf("%w: extracting public key of %s: %w", ErrInvalidAttestation, a.Signer, err)
+	}
+	ok, err := pub.Verify(a.signBytes(), a.Signature)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidAttestation, err)
+	}
+	if !ok {
+		return fmt.Errorf("%w: signature mismatch", ErrInvalidAttestation)
+	}
+	return nil
+}
+
+// Attester samples the data of a header and signs an Attestation about its availability with the
+// node's p2p key.
+type Attester struct {
+	da      share.Availability
+	getter  libhead.Getter[*header.ExtendedHeader]
+	key     crypto.PrivKey
+	id      peer.ID
+	timeout time.Duration
+	limiter *rate.Limiter
+}
+
+// NewAttester creates a new Attester signing with the given key. Sampling a height for an
+// attestation may take up to the given timeout.
+func NewAttester(
+	da share.Availability,
+	getter libhead.Getter[*header.ExtendedHeader],
+	key crypto.PrivKey,
+	timeout time.Duration,
+) (*Attester, error) {
+	id, err := peer.IDFromPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return &Attester{
+		da:      da,
+		getter:  getter,
+		key:     key,
+		id:      id,
+		timeout: timeout,
+		limiter: rate.NewLimiter(attestRate, attestBurst),
+	}, nil
+}
+
+// Attest samples the data committed to in the header at the given height and returns a signed
+// Attestation about its availability. Heights sampled before are checked again, so the
+// attestation reflects the availability at the time it is made. Attestations requested faster than
+// attestRate fail with ErrAttestRateLimited.
+func (a *Attester) Attest(ctx context.Context, height uint64) (*Attestation, error) {
+	if !a.limiter.Allow() {
+		return nil, ErrAttestRateLimited
+	}
+
+	h, err := a.getter.GetByHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	sampleCtx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+	err = a.da.SharesAvailable(sampleCtx, h.DAH)
+	switch {
+	case err == nil, errors.Is(err, share.ErrNotAvailable):
+	case ctx.Err() != nil:
+		return nil, ctx.Err()
+	case errors.Is(err, context.DeadlineExceeded):
+		// sampling did not succeed in time
+	default:
+		return nil, fmt.Errorf("das: sampling height %d: %w", height, err)
+	}
+
+	att := &Attestation{
+		Height:    height,
+		DataRoot:  share.DataHash(h.DataHash),
+		Available: err == nil,
+		Timestamp: time.Now().UTC(),
+		Signer:    a.id,
+	}
+	att.Signature, err = a.key.Sign(att.signBytes())
+	if err != nil {
+		return nil, fmt.Errorf("das: signing attestation: %w", err)
+	}
+	return att, nil
+}
@@ -0,0 +1,67 @@
+package das
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/availability/mocks"
+)
+
+func TestAttester(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	store := headertest.NewStore(t)
+	key, _, err := crypto.GenerateEd25519Key(nil)
+	require.NoError(t, err)
+
+	avail := mocks.NewMockAvailability(gomock.NewController(t))
+	avail.EXPECT().SharesAvailable(gomock.Any(), gomock.Any()).Return(nil)
+	avail.EXPECT().SharesAvailable(gomock.Any(), gomock.Any()).Return(share.ErrNotAvailable)
+
+	attester, err := NewAttester(avail, store, key, time.Second)
+	require.NoError(t, err)
+
+	h, err := store.GetByHeight(ctx, 5)
+	require.NoError(t, err)
+	att, err := attester.Attest(ctx, 5)
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, att.Height)
+	assert.EqualValues(t, h.DataHash, att.DataRoot)
+	assert.True(t, att.Available)
+	assert.NoError(t, att.Verify())
+
+	// the signature survives encoding
+	raw, err := json.Marshal(att)
+	require.NoError(t, err)
+	decoded := &Attestation{}
+	require.NoError(t, json.Unmarshal(raw, decoded))
+	assert.NoError(t, decoded.Verify())
+
+	// tampering invalidates the signature
+	decoded.Available = false
+	assert.ErrorIs(t, decoded.Verify(), ErrInvalidAttestation)
+
+	att, err = attester.Attest(ctx, 5)
+	require.NoError(t, err)
+	assert.False(t, att.Available)
+	assert.NoError(t, att.Verify())
+
+	// sampling for attestations is rate limited
+	avail.EXPECT().SharesAvailable(gomock.Any(), gomock.Any()).Return(nil).Times(attestBurst - 2)
+	for i := 0; i < attestBurst-2; i++ {
+		_, err = attester.Attest(ctx, 5)
+		require.NoError(t, err)
+	}
+	_, err = attester.Attest(ctx, 5)
+	assert.ErrorIs(t, err, ErrAttestRateLimited)
+}
@@ -8,6 +8,7 @@ import (
 	libhead "github.com/celestiaorg/go-header"
 
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/supervisor"
 	"github.com/celestiaorg/celestia-node/share/p2p/shrexsub"
 )
 
@@ -69,8 +70,16 @@ func (sc *samplingCoordinator) run(ctx context.Context, cp checkpoint) {
 		sc.runWorker(ctx, sc.state.newJob(wk.JobType, wk.From, wk.To))
 	}
 
+	// the loop is restarted on panics, keeping the state and the workers in progress
+	supervisor.Run(ctx, "das/coordinator", sc.loop)
+	sc.workersWg.Wait()
+	sc.indicateDone()
+}
+
+// loop schedules sampling jobs and handles their results until the context is done.
+func (sc *samplingCoordinator) loop(ctx context.Context) {
 	for {
-		for !sc.concurrencyLimitReached() {
+		for !sc.state.paused && !sc.concurrencyLimitReached() {
 			next, found := sc.state.nextJob()
 			if !found {
 				break
@@ -81,7 +90,8 @@ func (sc *samplingCoordinator) run(ctx context.Context, cp checkpoint) {
 		select {
 		case head := <-sc.updHeadCh:
 			if sc.state.isNewHead(head.Height()) {
-				if !sc.recentJobsLimitReached() {
+				// while paused, the height is left to the catchup job after resuming
+				if !sc.state.paused && !sc.recentJobsLimitReached() {
 					sc.runWorker(ctx, sc.state.recentJob(head))
 				}
 				sc.state.updateHead(head.Height())
@@ -93,8 +103,6 @@ func (sc *samplingCoordinator) run(ctx context.Context, cp checkpoint) {
 		case wg := <-sc.waitCh:
 			wg.Wait()
 		case <-ctx.Done():
-			sc.workersWg.Wait()
-			sc.indicateDone()
 			return
 		}
 	}
@@ -122,7 +130,31 @@ func (sc *samplingCoordinator) listen(ctx context.Context, h *header.ExtendedHea
 }
 
 // stats pauses the coordinator to get stats in a concurrently safe manner
-func (sc *samplingCoordinator) stats(ctx context.Context) (SamplingStats, error) {
+func (sc *samplingCoordinator) stats(ctx context.Context) (stats SamplingStats, err error) {
+	err = sc.withState(ctx, func(state *coordinatorState) error {
+		stats = state.unsafeStats()
+		return nil
+	})
+	return stats, err
+}
+
+// setPaused pauses or resumes scheduling of new sampling jobs.
+func (sc *samplingCoordinator) setPaused(ctx context.Context, paused bool) error {
+	return sc.withState(ctx, func(state *coordinatorState) error {
+		state.paused = paused
+		return nil
+	})
+}
+
+// resample schedules the given range of heights to be sampled again.
+func (sc *samplingCoordinator) resample(ctx context.Context, from, to uint64) error {
+	return sc.withState(ctx, func(state *coordinatorState) error {
+		return state.addResample(from, to)
+	})
+}
+
+// withState pauses the coordinator to access its state in a concurrently safe manner
+func (sc *samplingCoordinator) withState(ctx context.Context, fn func(*coordinatorState) error) error {
 	var wg sync.WaitGroup
 	wg.Add(1)
 	defer wg.Done()
@@ -130,10 +162,10 @@ func (sc *samplingCoordinator) stats(ctx context.Context) (SamplingStats, error)
 	select {
 	case sc.waitCh <- &wg:
 	case <-ctx.Done():
-		return SamplingStats{}, ctx.Err()
+		return ctx.Err()
 	}
 
-	return sc.state.unsafeStats(), nil
+	return fn(&sc.state)
 }
 
 func (sc *samplingCoordinator) getCheckpoint(ctx context.Context) (checkpoint, error) {
@@ -298,6 +298,52 @@ func TestCoordinator(t *testing.T) {
 		st := coordinator.state.unsafeStats()
 		require.Equal(t, ch, newCheckpoint(st))
 	})
+
+	t.Run("pause, resume and resample", func(t *testing.T) {
+		testParams := defaultTestParams()
+
+		ctx, cancel := context.WithTimeout(context.Background(), testParams.timeoutDelay)
+		sampler := newMockSampler(testParams.sampleFrom, testParams.networkHead)
+		coordinator := newSamplingCoordinator(testParams.dasParams, getterStub{}, sampler.sample, newBroadcastMock(1))
+		go coordinator.run(ctx, sampler.checkpoint)
+
+		assert.NoError(t, sampler.finished(ctx), "not all headers were sampled")
+		assert.NoError(t, coordinator.state.waitCatchUp(ctx))
+
+		// no new heights are sampled while paused
+		require.NoError(t, coordinator.setPaused(ctx, true))
+		newhead := testParams.networkHead + 10
+		sampler.discover(ctx, newhead, coordinator.listen)
+		stats, err := coordinator.stats(ctx)
+		require.NoError(t, err)
+		assert.True(t, stats.Paused)
+		assert.Empty(t, stats.Workers)
+		assert.False(t, stats.CatchUpDone)
+		assert.False(t, sampler.heightIsDone(newhead))
+
+		require.NoError(t, coordinator.setPaused(ctx, false))
+		assert.NoError(t, sampler.finished(ctx), "not all headers were sampled")
+		assert.NoError(t, coordinator.state.waitCatchUp(ctx))
+
+		// heights above the network head can't be resampled
+		assert.Error(t, coordinator.resample(ctx, 1, newhead+1))
+		assert.Error(t, coordinator.resample(ctx, 10, 1))
+
+		require.NoError(t, coordinator.resample(ctx, 1, 20))
+		assert.NoError(t, coordinator.state.waitCatchUp(ctx))
+		sampler.lock.Lock()
+		for h := uint64(1); h <= 20; h++ {
+			assert.Equal(t, 2, sampler.done[h], "height %d", h)
+		}
+		assert.Equal(t, 1, sampler.done[21])
+		sampler.lock.Unlock()
+
+		cancel()
+		stopCtx, cancel := context.WithTimeout(context.Background(), testParams.timeoutDelay)
+		defer cancel()
+		assert.NoError(t, coordinator.wait(stopCtx))
+		assert.Equal(t, sampler.finalState(), newCheckpoint(coordinator.state.unsafeStats()))
+	})
 }
 
 func BenchmarkCoordinator(b *testing.B) {
@@ -167,7 +167,37 @@ func (d *DASer) SamplingStats(ctx context.Context) (SamplingStats, error) {
 	return d.sampler.stats(ctx)
 }
 
+// HeightSampled reports whether the header at the given height has been successfully sampled, along
+// with the amount of failed attempts to sample it. Heights below SampleFrom are never sampled,
+// while the recent heights sampled ahead of the catchup are reported once the catchup reaches them.
+func (d *DASer) HeightSampled(ctx context.Context, height uint64) (sampled bool, failures int, err error) {
+	stats, err := d.sampler.stats(ctx)
+	if err != nil {
+		return false, 0, err
+	}
+	failures = stats.Failed[height]
+	return height >= d.params.SampleFrom && failures == 0 && stats.isSampled(height), failures, nil
+}
+
 // WaitCatchUp waits for DASer to indicate catchup is done
 func (d *DASer) WaitCatchUp(ctx context.Context) error {
 	return d.sampler.state.waitCatchUp(ctx)
 }
+
+// Pause stops the DASer from sampling further heights until Resume is called. Already running
+// sampling jobs are finished, so the sampling is quiesced once SamplingStats reports no workers.
+func (d *DASer) Pause(ctx context.Context) error {
+	return d.sampler.setPaused(ctx, true)
+}
+
+// Resume resumes sampling paused by Pause, catching up on the heights received in the meantime.
+func (d *DASer) Resume(ctx context.Context) error {
+	return d.sampler.setPaused(ctx, false)
+}
+
+// Resample schedules the inclusive range of heights to be sampled again, e.g. after the stored data
+// was lost or corrupted. Heights must not be above the known network head. Scheduled heights are
+// not persisted in the checkpoint, so they are dropped on restart.
+func (d *DASer) Resample(ctx context.Context, from, to uint64) error {
+	return d.sampler.resample(ctx, from, to)
+}
@@ -74,6 +74,15 @@ func TestDASerLifecycle(t *testing.T) {
 
 	// give catch-up routine a second to finish up sampling last header
 	assert.NoError(t, daser.sampler.state.waitCatchUp(ctx))
+
+	sampled, failures, err := daser.HeightSampled(ctx, 10)
+	require.NoError(t, err)
+	assert.True(t, sampled)
+	assert.Zero(t, failures)
+	// heights past the network head are not sampled yet
+	sampled, _, err = daser.HeightSampled(ctx, 100)
+	require.NoError(t, err)
+	assert.False(t, sampled)
 }
 
 func TestDASer_Restart(t *testing.T) {
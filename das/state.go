@@ -2,6 +2,7 @@ package das
 
 import (
 	"context"
+	"fmt"
 	"sync/atomic"
 	"time"
 
@@ -33,6 +34,11 @@ type coordinatorState struct {
 	// networkHead is the height of the latest known network head
 	networkHead uint64
 
+	// paused stops new jobs from being scheduled
+	paused bool
+	// resample keeps height ranges requested to be sampled again
+	resample []heightRange
+
 	// catchUpDone indicates if all headers are sampled
 	catchUpDone atomic.Bool
 	// catchUpDoneCh blocks until all headers are sampled
@@ -47,6 +53,11 @@ type retryAttempt struct {
 	after time.Time
 }
 
+// heightRange is an inclusive range of heights.
+type heightRange struct {
+	from, to uint64
+}
+
 // newCoordinatorState initiates state for samplingCoordinator
 func newCoordinatorState(params Parameters) coordinatorState {
 	return coordinatorState{
@@ -83,7 +94,7 @@ func (s *coordinatorState) handleResult(res result) {
 	delete(s.inProgress, res.id)
 
 	switch res.jobType {
-	case recentJob, catchupJob:
+	case recentJob, catchupJob, resampleJob:
 		s.handleRecentOrCatchupResult(res)
 	case retryJob:
 		s.handleRetryResult(res)
@@ -168,13 +179,18 @@ func (s *coordinatorState) recentJob(header *header.ExtendedHeader) job {
 	}
 }
 
-// nextJob will return next catchup or retry job according to priority (retry -> catchup)
+// nextJob will return next catchup, resample or retry job according to priority
+// (retry -> resample -> catchup)
 func (s *coordinatorState) nextJob() (next job, found bool) {
 	// check for if any retry jobs are available
 	if job, found := s.retryJob(); found {
 		return job, found
 	}
 
+	if job, found := s.resampleJob(); found {
+		return job, found
+	}
+
 	// if no retry jobs, make a catchup job
 	return s.catchupJob()
 }
@@ -194,6 +210,40 @@ func (s *coordinatorState) catchupJob() (next job, found bool) {
 	return j, true
 }
 
+// resampleJob creates a job to sample again the next part of a requested range of heights
+func (s *coordinatorState) resampleJob() (next job, found bool) {
+	if len(s.resample) == 0 {
+		return job{}, false
+	}
+
+	r := &s.resample[0]
+	to := r.from + s.samplingRange - 1
+	if to > r.to {
+		to = r.to
+	}
+	j := s.newJob(resampleJob, r.from, to)
+	if to == r.to {
+		s.resample = s.resample[1:]
+	} else {
+		r.from = to + 1
+	}
+	return j, true
+}
+
+// addResample schedules the given range of already known heights to be sampled again.
+func (s *coordinatorState) addResample(from, to uint64) error {
+	if from == 0 || from > to {
+		return fmt.Errorf("das: invalid range of heights %d..%d", from, to)
+	}
+	if to > s.networkHead {
+		return fmt.Errorf("das: height %d is above the network head %d", to, s.networkHead)
+	}
+
+	s.resample = append(s.resample, heightRange{from: from, to: to})
+	s.checkDone()
+	return nil
+}
+
 // retryJob creates a job to retry previously failed header
 func (s *coordinatorState) retryJob() (next job, found bool) {
 	for h, attempt := range s.failed {
@@ -280,11 +330,12 @@ func (s *coordinatorState) unsafeStats() SamplingStats {
 		Concurrency:      len(workers),
 		CatchUpDone:      s.catchUpDone.Load(),
 		IsRunning:        len(workers) > 0 || s.catchUpDone.Load(),
+		Paused:           s.paused,
 	}
 }
 
 func (s *coordinatorState) checkDone() {
-	if len(s.inProgress) == 0 && len(s.failed) == 0 && s.next > s.networkHead {
+	if len(s.inProgress) == 0 && len(s.failed) == 0 && len(s.resample) == 0 && s.next > s.networkHead {
 		if s.catchUpDone.CompareAndSwap(false, true) {
 			close(s.catchUpDoneCh)
 		}
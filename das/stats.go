@@ -19,6 +19,8 @@ type SamplingStats struct {
 	CatchUpDone bool `json:"catch_up_done"`
 	// IsRunning tracks whether the DASer service is running
 	IsRunning bool `json:"is_running"`
+	// Paused indicates whether scheduling of new sampling jobs is paused
+	Paused bool `json:"paused"`
 }
 
 type WorkerStats struct {
@@ -30,12 +32,47 @@ type WorkerStats struct {
 	ErrMsg string `json:"error,omitempty"`
 }
 
+// isSampled reports whether the catchup has sampled the header at the given height, i.e. it is
+// neither failed nor still being sampled.
+func (s SamplingStats) isSampled(height uint64) bool {
+	if height > s.CatchupHead {
+		return false
+	}
+	if _, ok := s.Failed[height]; ok {
+		return false
+	}
+	for _, w := range s.Workers {
+		if w.JobType != recentJob && height >= w.Curr && height <= w.To {
+			return false
+		}
+	}
+	return true
+}
+
+// SampledUpTo returns the height up to which the catchup has sampled every header, i.e. the
+// height before the first one failed or still being sampled.
+func (s SamplingStats) SampledUpTo() uint64 {
+	upTo := s.CatchupHead
+	for height := range s.Failed {
+		if height <= upTo {
+			upTo = height - 1
+		}
+	}
+	for _, w := range s.Workers {
+		if w.JobType != recentJob && w.Curr <= upTo {
+			upTo = w.Curr - 1
+		}
+	}
+	return upTo
+}
+
 // totalSampled returns the total amount of sampled headers
 func (s SamplingStats) totalSampled() uint64 {
 	var inProgress uint64
 	for _, w := range s.Workers {
-		// don't count recent jobs, since heights they are working on are after catchup head
-		if w.JobType != recentJob {
+		// don't count recent jobs, since heights they are working on are after catchup head, and
+		// resample jobs, since heights they are working on were already counted as sampled
+		if w.JobType != recentJob && w.JobType != resampleJob {
 			inProgress += w.To - w.Curr + 1
 		}
 	}
@@ -0,0 +1,42 @@
+package das
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSamplingStats_isSampled(t *testing.T) {
+	stats := SamplingStats{
+		CatchupHead: 20,
+		Failed:      map[uint64]int{5: 2},
+		Workers: []WorkerStats{
+			{JobType: catchupJob, Curr: 12, From: 11, To: 15},
+			// recent headers are sampled apart from the catchup
+			{JobType: recentJob, Curr: 18, From: 18, To: 18},
+		},
+	}
+
+	tests := []struct {
+		height  uint64
+		sampled bool
+	}{
+		{height: 1, sampled: true},
+		{height: 5},                 // failed
+		{height: 11, sampled: true}, // already sampled by the worker
+		{height: 12},                // being sampled
+		{height: 15},                // to be sampled by the worker
+		{height: 18, sampled: true}, // behind the catchup head, recent job aside
+		{height: 20, sampled: true}, // catchup head
+		{height: 21},                // not reached by the catchup
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.sampled, stats.isSampled(tt.height), tt.height)
+	}
+	assert.EqualValues(t, 4, stats.SampledUpTo())
+
+	delete(stats.Failed, 5)
+	assert.EqualValues(t, 11, stats.SampledUpTo())
+	stats.Workers = nil
+	assert.EqualValues(t, 20, stats.SampledUpTo())
+}
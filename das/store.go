@@ -8,6 +8,8 @@ import (
 
 	"github.com/ipfs/go-datastore"
 	"github.com/ipfs/go-datastore/namespace"
+
+	"github.com/celestiaorg/celestia-node/libs/supervisor"
 )
 
 var (
@@ -42,6 +44,25 @@ func (s *checkpointStore) load(ctx context.Context) (checkpoint, error) {
 	return cp, err
 }
 
+// LoadCheckpoint loads the sampling progress the DASer stored in the given datastore, e.g. to
+// inspect the store of a stopped node. Only CatchupHead, NetworkHead and Failed are set.
+func LoadCheckpoint(ctx context.Context, ds datastore.Datastore) (SamplingStats, error) {
+	s := checkpointStore{Datastore: namespace.Wrap(ds, storePrefix)}
+	cp, err := s.load(ctx)
+	if err != nil {
+		return SamplingStats{}, err
+	}
+
+	stats := SamplingStats{
+		NetworkHead: cp.NetworkHead,
+		Failed:      cp.Failed,
+	}
+	if cp.SampleFrom > 0 {
+		stats.CatchupHead = cp.SampleFrom - 1
+	}
+	return stats, nil
+}
+
 // checkpointStore stores the given DAS checkpoint to disk.
 func (s *checkpointStore) store(ctx context.Context, cp checkpoint) error {
 	// checkpointStore latest DASed checkpoint to disk here to ensure that if DASer is not yet
@@ -74,28 +95,31 @@ func (s *checkpointStore) runBackgroundStore(
 		return
 	}
 
-	ticker := time.NewTicker(storeInterval)
-	defer ticker.Stop()
+	// the routine is restarted on panics
+	supervisor.Run(ctx, "das/checkpoint-store", func(ctx context.Context) {
+		ticker := time.NewTicker(storeInterval)
+		defer ticker.Stop()
 
-	var prev uint64
-	for {
-		// blocked by ticker to perform storing only once in a period
-		select {
-		case <-ticker.C:
-		case <-ctx.Done():
-			return
-		}
+		var prev uint64
+		for {
+			// blocked by ticker to perform storing only once in a period
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
 
-		cp, err := getCheckpoint(ctx)
-		if err != nil {
-			log.Debug("DASer coordinator checkpoint is unavailable")
-			continue
-		}
-		if cp.SampleFrom > prev {
-			if err = s.store(ctx, cp); err != nil {
-				log.Errorw("storing checkpoint to disk", "err", err)
+			cp, err := getCheckpoint(ctx)
+			if err != nil {
+				log.Debug("DASer coordinator checkpoint is unavailable")
+				continue
+			}
+			if cp.SampleFrom > prev {
+				if err = s.store(ctx, cp); err != nil {
+					log.Errorw("storing checkpoint to disk", "err", err)
+				}
+				prev = cp.SampleFrom
 			}
-			prev = cp.SampleFrom
 		}
-	}
+	})
 }
@@ -6,6 +6,7 @@ import (
 	libhead "github.com/celestiaorg/go-header"
 
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/supervisor"
 )
 
 // subscriber subscribes to notifications about new headers in the network to keep
@@ -22,6 +23,13 @@ func (s *subscriber) run(ctx context.Context, sub libhead.Subscription[*header.E
 	defer s.indicateDone()
 	defer sub.Cancel()
 
+	// the loop is restarted on panics, keeping the subscription
+	supervisor.Run(ctx, "das/subscriber", func(ctx context.Context) {
+		s.loop(ctx, sub, emit)
+	})
+}
+
+func (s *subscriber) loop(ctx context.Context, sub libhead.Subscription[*header.ExtendedHeader], emit listenFn) {
 	for {
 		h, err := sub.NextHeader(ctx)
 		if err != nil {
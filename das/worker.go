@@ -10,6 +10,7 @@ import (
 	libhead "github.com/celestiaorg/go-header"
 
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/supervisor"
 	"github.com/celestiaorg/celestia-node/share/p2p/shrexsub"
 )
 
@@ -17,12 +18,22 @@ const (
 	catchupJob jobType = "catchup"
 	recentJob  jobType = "recent"
 	retryJob   jobType = "retry"
+	// resampleJob samples again heights requested through DASer.Resample
+	resampleJob jobType = "resample"
 )
 
+// errSamplingPanicked marks the heights whose sampling panicked, to be retried later.
+var errSamplingPanicked = errors.New("sampling panicked")
+
 type worker struct {
 	lock  sync.Mutex
 	state workerState
 
+	// next is the next height to sample, and sampling reports whether it is being sampled. Both are
+	// accessed by the worker routine only, which is restarted after panics.
+	next     uint64
+	sampling bool
+
 	getter    libhead.Getter[*header.ExtendedHeader]
 	sampleFn  sampleFn
 	broadcast shrexsub.BroadcastFn
@@ -61,6 +72,7 @@ func newWorker(j job,
 		sampleFn:  sample,
 		broadcast: broadcast,
 		metrics:   metrics,
+		next:      j.from,
 		state: workerState{
 			curr: j.from,
 			result: result{
@@ -71,17 +83,32 @@ func newWorker(j job,
 	}
 }
 
+// run samples the heights of the job under supervision, restarting after panics.
 func (w *worker) run(ctx context.Context, timeout time.Duration, resultCh chan<- result) {
+	supervisor.Run(ctx, "das/worker", func(ctx context.Context) {
+		w.sampleJob(ctx, timeout, resultCh)
+	})
+}
+
+func (w *worker) sampleJob(ctx context.Context, timeout time.Duration, resultCh chan<- result) {
 	jobStart := time.Now()
-	log.Debugw("start sampling worker", "from", w.state.from, "to", w.state.to)
+	log.Debugw("start sampling worker", "from", w.next, "to", w.state.to)
 
-	for curr := w.state.from; curr <= w.state.to; curr++ {
-		err := w.sample(ctx, timeout, curr)
+	if w.sampling {
+		// the previous run panicked sampling the height, which is left to the retry job
+		w.setResult(w.next, errSamplingPanicked)
+		w.sampling = false
+		w.next++
+	}
+	for ; w.next <= w.state.to; w.next++ {
+		w.sampling = true
+		err := w.sample(ctx, timeout, w.next)
+		w.sampling = false
 		if errors.Is(err, context.Canceled) {
 			// sampling worker will resume upon restart
 			return
 		}
-		w.setResult(curr, err)
+		w.setResult(w.next, err)
 	}
 
 	if w.state.jobType != recentJob {
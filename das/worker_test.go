@@ -0,0 +1,38 @@
+package das
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+func TestWorkerRecoversPanics(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	sample := func(_ context.Context, h *header.ExtendedHeader) error {
+		if h.Height() == 3 {
+			panic("boom")
+		}
+		return nil
+	}
+	w := newWorker(job{jobType: catchupJob, from: 1, to: 5}, getterStub{}, sample, nil, nil)
+
+	resultCh := make(chan result, 1)
+	go w.run(ctx, time.Second, resultCh)
+
+	// the panicked height is left to the retry job, while the rest of the job is sampled
+	select {
+	case res := <-resultCh:
+		assert.Equal(t, map[uint64]int{3: 1}, res.failed)
+		assert.ErrorIs(t, res.err, errSamplingPanicked)
+	case <-ctx.Done():
+		require.FailNow(t, "worker did not finish the job after the panic")
+	}
+	assert.EqualValues(t, 5, w.getState().curr)
+}
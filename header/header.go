@@ -14,6 +14,8 @@ import (
 	"github.com/celestiaorg/celestia-app/pkg/da"
 	libhead "github.com/celestiaorg/go-header"
 	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/share"
 )
 
 // ConstructFn aliases a function that creates an ExtendedHeader.
@@ -190,7 +192,7 @@ func (eh *ExtendedHeader) UnmarshalBinary(data []byte) error {
 }
 
 // MarshalJSON marshals an ExtendedHeader to JSON. The ValidatorSet is wrapped with amino encoding,
-// to be able to unmarshal the crypto.PubKey type back from JSON.
+// to be able to unmarshal the crypto.PubKey type back from JSON. The DAH is encoded canonically.
 func (eh *ExtendedHeader) MarshalJSON() ([]byte, error) {
 	type Alias ExtendedHeader
 	validatorSet, err := tmjson.Marshal(eh.ValidatorSet)
@@ -201,24 +203,35 @@ func (eh *ExtendedHeader) MarshalJSON() ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	dah := json.RawMessage("null")
+	if eh.DAH != nil {
+		dah, err = share.MarshalRootJSON(eh.DAH)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return json.Marshal(&struct {
 		RawHeader    json.RawMessage `json:"header"`
 		ValidatorSet json.RawMessage `json:"validator_set"`
+		DAH          json.RawMessage `json:"dah"`
 		*Alias
 	}{
 		ValidatorSet: validatorSet,
 		RawHeader:    rawHeader,
+		DAH:          dah,
 		Alias:        (*Alias)(eh),
 	})
 }
 
 // UnmarshalJSON unmarshals an ExtendedHeader from JSON. The ValidatorSet is wrapped with amino
-// encoding, to be able to unmarshal the crypto.PubKey type back from JSON.
+// encoding, to be able to unmarshal the crypto.PubKey type back from JSON. The DAH is decoded
+// canonically.
 func (eh *ExtendedHeader) UnmarshalJSON(data []byte) error {
 	type Alias ExtendedHeader
 	aux := &struct {
 		RawHeader    json.RawMessage `json:"header"`
 		ValidatorSet json.RawMessage `json:"validator_set"`
+		DAH          json.RawMessage `json:"dah"`
 		*Alias
 	}{
 		Alias: (*Alias)(eh),
@@ -236,6 +249,15 @@ func (eh *ExtendedHeader) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	eh.DAH = nil
+	if len(aux.DAH) != 0 && string(aux.DAH) != "null" {
+		dah, err := share.UnmarshalRootJSON(aux.DAH)
+		if err != nil {
+			return err
+		}
+		eh.DAH = dah
+	}
+
 	eh.ValidatorSet = valSet
 	eh.RawHeader = *rawHeader
 	return nil
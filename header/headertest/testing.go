@@ -6,6 +6,7 @@ import (
 	"fmt"
 	mrand "math/rand"
 	"sort"
+	"sync"
 	"testing"
 	"time"
 
@@ -296,29 +297,72 @@ func RandBlockID(*testing.T) types.BlockID {
 	return bid
 }
 
-// FraudMaker creates a custom ConstructFn that breaks the block at the given height.
-func FraudMaker(t *testing.T, faultHeight int64, bServ blockservice.BlockService) header.ConstructFn {
+// FraudMaker creates a custom ConstructFn that breaks the block at the given height. As breaking
+// the block changes its hash, the chain is forked from it: the broken block and all the blocks
+// after it are committed to again by the given validators, which have to be the ones of the
+// validator set in the same order.
+func FraudMaker(
+	t *testing.T,
+	faultHeight int64,
+	bServ blockservice.BlockService,
+	signers ...types.PrivValidator,
+) header.ConstructFn {
 	log.Warn("Corrupting block...", "height", faultHeight)
+	var (
+		lock   sync.Mutex
+		fault  *header.ExtendedHeader
+		square *rsmt2d.ExtendedDataSquare
+		// hashes keeps the hashes of the forked blocks, so that the next ones can point to them
+		hashes = make(map[int64][]byte)
+	)
+	recommit := func(eh *header.ExtendedHeader) error {
+		blockID := eh.Commit.BlockID
+		blockID.Hash = eh.RawHeader.Hash()
+		voteSet := types.NewVoteSet(eh.ChainID(), eh.Height(), eh.Commit.Round, tmproto.PrecommitType, eh.ValidatorSet)
+		commit, err := MakeCommit(blockID, eh.Height(), eh.Commit.Round, voteSet, signers, eh.Time())
+		if err != nil {
+			return err
+		}
+		eh.Commit = commit
+		hashes[eh.Height()] = blockID.Hash
+		return nil
+	}
+
 	return func(ctx context.Context,
 		h *types.Header,
 		comm *types.Commit,
 		vals *types.ValidatorSet,
 		eds *rsmt2d.ExtendedDataSquare,
 	) (*header.ExtendedHeader, error) {
+		eh, err := header.MakeExtendedHeader(ctx, h, comm, vals, eds)
+		if err != nil || h.Height < faultHeight {
+			return eh, err
+		}
+
+		lock.Lock()
+		defer lock.Unlock()
 		if h.Height == faultHeight {
-			eh := &header.ExtendedHeader{
-				RawHeader:    *h,
-				Commit:       comm,
-				ValidatorSet: vals,
+			if fault == nil {
+				fault, square = CreateFraudExtHeader(t, eh, bServ)
+				if err := recommit(fault); err != nil {
+					return nil, err
+				}
 			}
-
-			eh, dataSq := CreateFraudExtHeader(t, eh, bServ)
 			if eds != nil {
-				*eds = *dataSq
+				*eds = *square
 			}
-			return eh, nil
+			return fault, nil
+		}
+
+		last, ok := hashes[h.Height-1]
+		if !ok {
+			return nil, fmt.Errorf("block at height %d was not forked yet", h.Height-1)
+		}
+		eh.RawHeader.LastBlockID.Hash = last
+		if err := recommit(eh); err != nil {
+			return nil, err
 		}
-		return header.MakeExtendedHeader(ctx, h, comm, vals, eds)
+		return eh, nil
 	}
 }
 
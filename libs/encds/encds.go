@@ -0,0 +1,157 @@
+// Package encds provides a Datastore wrapper encrypting values at rest.
+package encds
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+)
+
+// KeySize is the size of the key values are encrypted with.
+const KeySize = 32
+
+// ErrDecrypt is returned when a stored value can't be decrypted, e.g. because it was encrypted with
+// another key or tampered with.
+var ErrDecrypt = errors.New("encds: can't decrypt value")
+
+var _ datastore.Batching = (*Datastore)(nil)
+
+// Datastore wraps a Batching datastore, transparently encrypting values with AES-256-GCM on
+// writes and decrypting them on reads. Each value is bound to its key, so values can't be swapped
+// between keys unnoticed. Keys are stored in plaintext to keep prefix queries and ordering
+// working.
+type Datastore struct {
+	datastore.Batching
+	aead cipher.AEAD
+}
+
+// Wrap wraps the given datastore with encryption under the given key of KeySize bytes.
+func Wrap(ds datastore.Batching, key []byte) (*Datastore, error) {
+	aead, err := NewAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return &Datastore{Batching: ds, aead: aead}, nil
+}
+
+// NewAEAD constructs the AES-256-GCM cipher used by the Datastore from the given key.
+func NewAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("encds: key must be %d bytes, got %d", KeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (d *Datastore) Get(ctx context.Context, key datastore.Key) ([]byte, error) {
+	ciphertext, err := d.Batching.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return d.open(key, ciphertext)
+}
+
+func (d *Datastore) GetSize(ctx context.Context, key datastore.Key) (int, error) {
+	size, err := d.Batching.GetSize(ctx, key)
+	if err != nil {
+		return size, err
+	}
+	return size - d.aead.NonceSize() - d.aead.Overhead(), nil
+}
+
+func (d *Datastore) Put(ctx context.Context, key datastore.Key, value []byte) error {
+	ciphertext, err := d.seal(key, value)
+	if err != nil {
+		return err
+	}
+	return d.Batching.Put(ctx, key, ciphertext)
+}
+
+func (d *Datastore) Query(ctx context.Context, q query.Query) (query.Results, error) {
+	// filters and orders may need the plaintext values, so they are applied after decryption
+	child := query.Query{
+		Prefix:            q.Prefix,
+		KeysOnly:          q.KeysOnly,
+		ReturnExpirations: q.ReturnExpirations,
+		ReturnsSizes:      q.ReturnsSizes,
+	}
+	naive := q
+	if len(q.Filters) == 0 && len(q.Orders) == 0 {
+		child.Limit, child.Offset = q.Limit, q.Offset
+		naive.Limit, naive.Offset = 0, 0
+	}
+
+	results, err := d.Batching.Query(ctx, child)
+	if err != nil {
+		return nil, err
+	}
+	decrypted := query.ResultsFromIterator(q, query.Iterator{
+		Next: func() (query.Result, bool) {
+			r, ok := results.NextSync()
+			if !ok || r.Error != nil {
+				return r, ok
+			}
+			if !q.KeysOnly {
+				r.Value, r.Error = d.open(datastore.RawKey(r.Key), r.Value)
+				r.Size = len(r.Value)
+			} else if q.ReturnsSizes {
+				r.Size -= d.aead.NonceSize() + d.aead.Overhead()
+			}
+			return r, true
+		},
+		Close: results.Close,
+	})
+	return query.NaiveQueryApply(naive, decrypted), nil
+}
+
+func (d *Datastore) Batch(ctx context.Context) (datastore.Batch, error) {
+	b, err := d.Batching.Batch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &batch{Batch: b, ds: d}, nil
+}
+
+// seal encrypts the value under a random nonce prepended to the ciphertext.
+func (d *Datastore) seal(key datastore.Key, value []byte) ([]byte, error) {
+	nonce := make([]byte, d.aead.NonceSize(), d.aead.NonceSize()+len(value)+d.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return d.aead.Seal(nonce, nonce, value, key.Bytes()), nil
+}
+
+func (d *Datastore) open(key datastore.Key, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < d.aead.NonceSize() {
+		return nil, fmt.Errorf("%w: %s is too short", ErrDecrypt, key)
+	}
+	nonce, ciphertext := ciphertext[:d.aead.NonceSize()], ciphertext[d.aead.NonceSize():]
+	value, err := d.aead.Open(nil, nonce, ciphertext, key.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDecrypt, key)
+	}
+	return value, nil
+}
+
+// batch encrypts values put into the wrapped Batch.
+type batch struct {
+	datastore.Batch
+	ds *Datastore
+}
+
+func (b *batch) Put(ctx context.Context, key datastore.Key, value []byte) error {
+	ciphertext, err := b.ds.seal(key, value)
+	if err != nil {
+		return err
+	}
+	return b.Batch.Put(ctx, key, ciphertext)
+}
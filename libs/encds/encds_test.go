@@ -0,0 +1,65 @@
+package encds
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatastore(t *testing.T) {
+	ctx := context.Background()
+	key := bytes.Repeat([]byte{1}, KeySize)
+
+	raw := datastore.NewMapDatastore()
+	ds, err := Wrap(raw, key)
+	require.NoError(t, err)
+
+	k, v := datastore.NewKey("/a/1"), []byte("value")
+	require.NoError(t, ds.Put(ctx, k, v))
+
+	stored, err := raw.Get(ctx, k)
+	require.NoError(t, err)
+	assert.NotContains(t, string(stored), string(v))
+
+	got, err := ds.Get(ctx, k)
+	require.NoError(t, err)
+	assert.Equal(t, v, got)
+	size, err := ds.GetSize(ctx, k)
+	require.NoError(t, err)
+	assert.Equal(t, len(v), size)
+
+	b, err := ds.Batch(ctx)
+	require.NoError(t, err)
+	require.NoError(t, b.Put(ctx, datastore.NewKey("/a/2"), []byte("batched")))
+	require.NoError(t, b.Put(ctx, datastore.NewKey("/b/1"), []byte("other")))
+	require.NoError(t, b.Commit(ctx))
+
+	res, err := ds.Query(ctx, query.Query{
+		Prefix:  "/a",
+		Filters: []query.Filter{query.FilterValueCompare{Op: query.Equal, Value: []byte("batched")}},
+	})
+	require.NoError(t, err)
+	entries, err := res.Rest()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "/a/2", entries[0].Key)
+	assert.Equal(t, []byte("batched"), entries[0].Value)
+
+	// values can't be read with another key or under another key
+	other, err := Wrap(raw, bytes.Repeat([]byte{2}, KeySize))
+	require.NoError(t, err)
+	_, err = other.Get(ctx, k)
+	assert.ErrorIs(t, err, ErrDecrypt)
+
+	require.NoError(t, raw.Put(ctx, datastore.NewKey("/a/3"), stored))
+	_, err = ds.Get(ctx, datastore.NewKey("/a/3"))
+	assert.ErrorIs(t, err, ErrDecrypt)
+
+	_, err = Wrap(raw, []byte("short"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,81 @@
+//go:build darwin || freebsd || linux
+
+package handover
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandover(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	dir := t.TempDir()
+	sockPath := filepath.Join(dir, "gateway.sock")
+
+	listeners := NewListeners()
+	tcpLn, err := listeners.Listen("tcp", "127.0.0.1:0", func() (net.Listener, error) {
+		return net.Listen("tcp", "127.0.0.1:0")
+	})
+	require.NoError(t, err)
+	unixLn, err := listeners.Listen("unix", sockPath, func() (net.Listener, error) {
+		return net.Listen("unix", sockPath)
+	})
+	require.NoError(t, err)
+
+	handedOver := make(chan struct{})
+	srv, err := Serve(filepath.Join(dir, "handover.sock"), listeners, func() {
+		close(handedOver)
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, srv.Close())
+	})
+
+	inherited, err := Request(ctx, filepath.Join(dir, "handover.sock"))
+	require.NoError(t, err)
+	select {
+	case <-handedOver:
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+
+	// the previous process stops listening, which must neither close the sockets nor remove them
+	require.NoError(t, tcpLn.Close())
+	require.NoError(t, unixLn.Close())
+	_, err = os.Stat(sockPath)
+	require.NoError(t, err)
+
+	mustNotListen := func() (net.Listener, error) {
+		t.Fatal("listened instead of inheriting")
+		return nil, nil
+	}
+	newTCPLn, err := inherited.Listen("tcp", "127.0.0.1:0", mustNotListen)
+	require.NoError(t, err)
+	assert.Equal(t, tcpLn.Addr().String(), newTCPLn.Addr().String())
+	newUnixLn, err := inherited.Listen("unix", sockPath, mustNotListen)
+	require.NoError(t, err)
+
+	for _, ln := range []net.Listener{newTCPLn, newUnixLn} {
+		conn, err := net.Dial(ln.Addr().Network(), ln.Addr().String())
+		require.NoError(t, err)
+		accepted, err := ln.Accept()
+		require.NoError(t, err)
+		require.NoError(t, accepted.Close())
+		require.NoError(t, conn.Close())
+		require.NoError(t, ln.Close())
+	}
+	require.NoError(t, inherited.CloseUnused())
+
+	// the handover is served once
+	_, err = Request(ctx, filepath.Join(dir, "handover.sock"))
+	require.Error(t, err)
+}
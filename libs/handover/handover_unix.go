@@ -0,0 +1,213 @@
+//go:build darwin || freebsd || linux
+
+package handover
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+)
+
+// maxListeners is the amount of listeners that can be handed over at once.
+const maxListeners = 16
+
+// Server hands the Listeners over to the next node process requesting them over a Unix domain
+// socket. The file descriptors of the listening sockets are passed along, so both processes share
+// the sockets until the previous one stops.
+type Server struct {
+	ln         *net.UnixListener
+	listeners  *Listeners
+	handedOver func()
+
+	done chan struct{}
+}
+
+// Serve serves the handover of the Listeners on the Unix domain socket at the given path. Once
+// the listeners are handed over, the socket is closed and handedOver is called, for the process to
+// stop gracefully and release its store to the next one.
+func Serve(path string, listeners *Listeners, handedOver func()) (*Server, error) {
+	// a socket left by a previous run is removed first
+	if info, err := os.Stat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("handover: removing stale socket: %w", err)
+		}
+	}
+	ln, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	if err != nil {
+		return nil, fmt.Errorf("handover: listening: %w", err)
+	}
+	// only the user running the node can take its listeners over
+	if err := os.Chmod(path, 0600); err != nil {
+		ln.Close() //nolint:errcheck
+		return nil, fmt.Errorf("handover: restricting socket permissions: %w", err)
+	}
+
+	s := &Server{
+		ln:         ln,
+		listeners:  listeners,
+		handedOver: handedOver,
+		done:       make(chan struct{}),
+	}
+	go s.serve()
+	return s, nil
+}
+
+// Close stops serving the handover.
+func (s *Server) Close() error {
+	err := s.ln.Close()
+	<-s.done
+	if errors.Is(err, net.ErrClosed) {
+		// already closed after the handover
+		return nil
+	}
+	return err
+}
+
+func (s *Server) serve() {
+	defer close(s.done)
+	for {
+		conn, err := s.ln.AcceptUnix()
+		if err != nil {
+			return
+		}
+
+		err = s.handOver(conn)
+		conn.Close() //nolint:errcheck
+		if err != nil {
+			log.Errorw("handing listeners over", "err", err)
+			continue
+		}
+
+		log.Info("listeners handed over, stopping")
+		s.ln.Close() //nolint:errcheck
+		s.handedOver()
+		return
+	}
+}
+
+func (s *Server) handOver(conn *net.UnixConn) error {
+	s.listeners.lock.Lock()
+	defer s.listeners.lock.Unlock()
+	if len(s.listeners.active) > maxListeners {
+		return fmt.Errorf("too many listeners: %d", len(s.listeners.active))
+	}
+
+	names := make([]string, 0, len(s.listeners.active))
+	fds := make([]int, 0, len(s.listeners.active))
+	for name, ln := range s.listeners.active {
+		fl, ok := ln.(interface{ File() (*os.File, error) })
+		if !ok {
+			return fmt.Errorf("listener %s can not be handed over", name)
+		}
+		// the file is a duplicate of the socket, so closing it does not affect the listener
+		file, err := fl.File()
+		if err != nil {
+			return fmt.Errorf("listener %s: %w", name, err)
+		}
+		defer file.Close()
+
+		names = append(names, name)
+		fds = append(fds, int(file.Fd()))
+	}
+
+	data, err := json.Marshal(names)
+	if err != nil {
+		return err
+	}
+	_, _, err = conn.WriteMsgUnix(data, syscall.UnixRights(fds...), nil)
+	if err != nil {
+		return err
+	}
+
+	// the sockets are now served by the next process, so closing them must not remove them
+	for _, ln := range s.listeners.active {
+		if uln, ok := ln.(*net.UnixListener); ok {
+			uln.SetUnlinkOnClose(false)
+		}
+	}
+	return nil
+}
+
+// Request requests the listeners of the node process serving the handover on the Unix domain
+// socket at the given path. The returned Listeners serve the inherited listeners to the servers of
+// the new node.
+func Request(ctx context.Context, path string) (*Listeners, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("handover: requesting listeners: %w", err)
+	}
+	defer conn.Close()
+
+	// unblock the read once the context is done
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close() //nolint:errcheck
+		case <-done:
+		}
+	}()
+
+	buf := make([]byte, 4096)
+	oob := make([]byte, syscall.CmsgSpace(maxListeners*4))
+	n, oobn, flags, _, err := conn.(*net.UnixConn).ReadMsgUnix(buf, oob)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("handover: receiving listeners: %w", err)
+	}
+
+	fds, err := parseRights(oob[:oobn])
+	if err != nil {
+		return nil, fmt.Errorf("handover: receiving listeners: %w", err)
+	}
+	files := make([]*os.File, len(fds))
+	for i, fd := range fds {
+		files[i] = os.NewFile(uintptr(fd), "")
+		defer files[i].Close()
+	}
+	if flags&(syscall.MSG_TRUNC|syscall.MSG_CTRUNC) != 0 {
+		return nil, errors.New("handover: receiving listeners: message truncated")
+	}
+
+	var names []string
+	if err := json.Unmarshal(buf[:n], &names); err != nil {
+		return nil, fmt.Errorf("handover: unmarshalling listeners: %w", err)
+	}
+	if len(names) != len(files) {
+		return nil, fmt.Errorf("handover: got %d listeners for %d names", len(files), len(names))
+	}
+
+	listeners := NewListeners()
+	for i, file := range files {
+		ln, err := net.FileListener(file)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("handover: listener %s: %w", names[i], err), listeners.CloseUnused())
+		}
+		listeners.inherited[names[i]] = ln
+	}
+	return listeners, nil
+}
+
+func parseRights(oob []byte) ([]int, error) {
+	msgs, err := syscall.ParseSocketControlMessage(oob)
+	if err != nil {
+		return nil, err
+	}
+	var fds []int
+	for i := range msgs {
+		rights, err := syscall.ParseUnixRights(&msgs[i])
+		if err != nil {
+			return nil, err
+		}
+		fds = append(fds, rights...)
+	}
+	return fds, nil
+}
@@ -0,0 +1,80 @@
+package handover
+
+import (
+	"errors"
+	"net"
+	"sync"
+
+	logging "github.com/ipfs/go-log/v2"
+)
+
+var log = logging.Logger("handover")
+
+// Listeners keeps the listeners of the servers of a node, so that they can be handed over to a new
+// node process, e.g. on upgrades. The new process inherits the listening sockets instead of
+// listening anew, so the connections made while it starts up wait in their backlog instead of
+// being refused.
+//
+// A nil Listeners listens anew and keeps nothing.
+type Listeners struct {
+	lock sync.Mutex
+	// inherited are the listeners handed over by the previous process, not yet taken by a server
+	inherited map[string]net.Listener
+	// active are the listeners in use by the servers, to be handed over
+	active map[string]net.Listener
+}
+
+// NewListeners creates Listeners without inherited listeners.
+func NewListeners() *Listeners {
+	return &Listeners{
+		inherited: make(map[string]net.Listener),
+		active:    make(map[string]net.Listener),
+	}
+}
+
+// Listen returns the listener inherited for the network and address, if any, or listens with the
+// given function otherwise. The returned listener is handed over to the next process.
+func (l *Listeners) Listen(network, address string, listen func() (net.Listener, error)) (net.Listener, error) {
+	if l == nil {
+		return listen()
+	}
+
+	name := listenerName(network, address)
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	ln, ok := l.inherited[name]
+	if ok {
+		delete(l.inherited, name)
+		log.Infow("inherited listener", "network", network, "address", address)
+	} else {
+		var err error
+		ln, err = listen()
+		if err != nil {
+			return nil, err
+		}
+	}
+	l.active[name] = ln
+	return ln, nil
+}
+
+// CloseUnused closes the inherited listeners not taken by any server, e.g. because the address
+// of the server has changed.
+func (l *Listeners) CloseUnused() error {
+	if l == nil {
+		return nil
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	var err error
+	for name, ln := range l.inherited {
+		log.Infow("closing unused inherited listener", "listener", name)
+		err = errors.Join(err, ln.Close())
+		delete(l.inherited, name)
+	}
+	return err
+}
+
+func listenerName(network, address string) string {
+	return network + "://" + address
+}
@@ -7,6 +7,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 )
@@ -14,6 +15,10 @@ import (
 // ErrNotFound is returned when the key does not exist.
 var ErrNotFound = errors.New("keystore: key not found")
 
+// tmpPrefix prefixes the temporary files keys are written to before replacing the existing ones.
+// Base32 encoded key names never start with it.
+const tmpPrefix = "."
+
 // fsKeystore implements persistent Keystore over OS filesystem.
 type fsKeystore struct {
 	path string
@@ -85,6 +90,45 @@ func (f *fsKeystore) Get(n KeyName) (PrivKey, error) {
 	return key, nil
 }
 
+func (f *fsKeystore) Replace(n KeyName, pk PrivKey) error {
+	path := f.pathTo(n.Base32())
+
+	_, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("keystore: key '%s' not found", n)
+	} else if err != nil {
+		return fmt.Errorf("keystore: check before replacing key '%s' failed: %w", n, err)
+	}
+
+	data, err := json.Marshal(pk)
+	if err != nil {
+		return fmt.Errorf("keystore: failed to marshal key '%s': %w", n, err)
+	}
+
+	// the new key is written aside and renamed over the old one, so the key is never lost
+	tmp, err := os.CreateTemp(f.path, tmpPrefix+n.Base32())
+	if err != nil {
+		return fmt.Errorf("keystore: failed to create temporary file for key '%s': %w", n, err)
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("keystore: failed to write key '%s': %w", n, err)
+	}
+
+	err = os.Rename(tmp.Name(), path)
+	if err != nil {
+		return fmt.Errorf("keystore: failed to replace key '%s': %w", n, err)
+	}
+	return nil
+}
+
 func (f *fsKeystore) Delete(n KeyName) error {
 	path := f.pathTo(n.Base32())
 
@@ -108,8 +152,12 @@ func (f *fsKeystore) List() ([]KeyName, error) {
 		return nil, err
 	}
 
-	names := make([]KeyName, len(entries))
-	for i, e := range entries {
+	names := make([]KeyName, 0, len(entries))
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), tmpPrefix) {
+			// left over by a Replace that failed midway
+			continue
+		}
 		kn, err := KeyNameFromBase32(e.Name())
 		if err != nil {
 			return nil, err
@@ -119,7 +167,7 @@ func (f *fsKeystore) List() ([]KeyName, error) {
 			return nil, fmt.Errorf("keystore: permissions of key '%s' are too relaxed: %w", kn, err)
 		}
 
-		names[i] = kn
+		names = append(names, kn)
 	}
 
 	return names, nil
@@ -1,6 +1,8 @@
 package keystore
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -8,8 +10,11 @@ import (
 )
 
 func TestFSKeystore(t *testing.T) {
-	kstore, err := NewFSKeystore(t.TempDir()+"/keystore", nil)
+	path := t.TempDir() + "/keystore"
+	kstore, err := NewFSKeystore(path, nil)
 	require.NoError(t, err)
+	// a temporary file left over by a failed Replace is not listed
+	require.NoError(t, os.WriteFile(filepath.Join(path, tmpPrefix+"test"), nil, 0600))
 
 	err = kstore.Put("test", PrivKey{Body: []byte("test_private_key")})
 	require.NoError(t, err)
@@ -22,6 +27,13 @@ func TestFSKeystore(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, keys, 1)
 
+	err = kstore.Replace("test", PrivKey{Body: []byte("new_private_key")})
+	require.NoError(t, err)
+	key, err = kstore.Get("test")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("new_private_key"), key.Body)
+	require.Error(t, kstore.Replace("missing", PrivKey{Body: []byte("test_private_key")}))
+
 	err = kstore.Delete("test")
 	require.NoError(t, err)
 
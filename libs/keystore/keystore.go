@@ -27,6 +27,10 @@ type Keystore interface {
 	// Get reads PrivKey using given KeyName.
 	Get(KeyName) (PrivKey, error)
 
+	// Replace atomically overwrites the existing PrivKey of the given KeyName, so that either the
+	// old or the new PrivKey is stored if it fails midway.
+	Replace(KeyName, PrivKey) error
+
 	// Delete erases PrivKey using given KeyName.
 	Delete(name KeyName) error
 
@@ -50,6 +50,19 @@ func (m *mapKeystore) Get(n KeyName) (PrivKey, error) {
 	return k, nil
 }
 
+func (m *mapKeystore) Replace(n KeyName, k PrivKey) error {
+	m.keysLk.Lock()
+	defer m.keysLk.Unlock()
+
+	_, ok := m.keys[n]
+	if !ok {
+		return fmt.Errorf("keystore: key '%s' not found", n)
+	}
+
+	m.keys[n] = k
+	return nil
+}
+
 func (m *mapKeystore) Delete(n KeyName) error {
 	m.keysLk.Lock()
 	defer m.keysLk.Unlock()
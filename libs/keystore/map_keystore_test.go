@@ -20,6 +20,13 @@ func TestMapKeystore(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, keys, 1)
 
+	err = kstore.Replace("test", PrivKey{Body: []byte("new_private_key")})
+	require.NoError(t, err)
+	key, err = kstore.Get("test")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("new_private_key"), key.Body)
+	require.Error(t, kstore.Replace("missing", PrivKey{Body: []byte("test_private_key")}))
+
 	err = kstore.Delete("test")
 	require.NoError(t, err)
 
@@ -0,0 +1,95 @@
+// Package ntp implements a minimal SNTP (RFC 4330) client to measure the drift of the local clock.
+package ntp
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+const (
+	defaultPort = "123"
+	packetSize  = 48
+
+	// ntpEpochOffset is the amount of seconds between the NTP epoch (1900) and the Unix epoch (1970).
+	ntpEpochOffset = 2208988800
+
+	// version 4, client mode
+	clientHeader = 4<<3 | 3
+	serverMode   = 4
+)
+
+var (
+	ErrInvalidResponse = errors.New("ntp: invalid response")
+	ErrUnsynchronized  = errors.New("ntp: server is unsynchronized")
+)
+
+// Drift queries the NTP server at the given address and returns the drift of the local clock from
+// the server's clock. A positive drift means the local clock is ahead. The default NTP port is used
+// if the address has none.
+func Drift(ctx context.Context, addr string) (time.Duration, error) {
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, defaultPort)
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", addr)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = conn.SetDeadline(deadline); err != nil {
+			return 0, err
+		}
+	}
+
+	req := make([]byte, packetSize)
+	req[0] = clientHeader
+	sent := time.Now()
+	// the server echoes the transmit timestamp, so that the response can be matched to the request
+	binary.BigEndian.PutUint64(req[40:], toNTPTime(sent))
+	if _, err = conn.Write(req); err != nil {
+		return 0, err
+	}
+
+	resp := make([]byte, packetSize)
+	n, err := conn.Read(resp)
+	if err != nil {
+		return 0, err
+	}
+	// use the monotonic clock to measure the round trip
+	received := sent.Add(time.Since(sent))
+
+	if n < packetSize || resp[0]&0x7 != serverMode {
+		return 0, ErrInvalidResponse
+	}
+	if binary.BigEndian.Uint64(resp[24:]) != binary.BigEndian.Uint64(req[40:]) {
+		return 0, fmt.Errorf("%w: originate timestamp does not match", ErrInvalidResponse)
+	}
+	// stratum 0 is a kiss-of-death packet, and leap indicator 3 an unsynchronized clock
+	if resp[1] == 0 || resp[0]>>6 == 3 {
+		return 0, ErrUnsynchronized
+	}
+
+	serverReceived := fromNTPTime(binary.BigEndian.Uint64(resp[32:]))
+	serverSent := fromNTPTime(binary.BigEndian.Uint64(resp[40:]))
+	// the offset of the server's clock, as the average of the offsets seen in both directions
+	offset := (serverReceived.Sub(sent) + serverSent.Sub(received)) / 2
+	return -offset, nil
+}
+
+func toNTPTime(t time.Time) uint64 {
+	secs := uint64(t.Unix() + ntpEpochOffset)
+	frac := (uint64(t.Nanosecond()) << 32) / uint64(time.Second)
+	return secs<<32 | frac
+}
+
+func fromNTPTime(ts uint64) time.Time {
+	secs := int64(ts>>32) - ntpEpochOffset
+	nanos := (int64(ts&0xffffffff) * int64(time.Second)) >> 32
+	return time.Unix(secs, nanos)
+}
@@ -0,0 +1,59 @@
+package ntp
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrift(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	// the server's clock is a minute behind the local one
+	addr := fakeServer(t, -time.Minute, 1)
+	drift, err := Drift(ctx, addr)
+	require.NoError(t, err)
+	assert.InDelta(t, time.Minute, drift, float64(time.Second))
+
+	addr = fakeServer(t, 0, 0)
+	_, err = Drift(ctx, addr)
+	require.ErrorIs(t, err, ErrUnsynchronized)
+}
+
+func TestNTPTime(t *testing.T) {
+	now := time.Now()
+	assert.WithinDuration(t, now, fromNTPTime(toNTPTime(now)), time.Microsecond)
+}
+
+// fakeServer answers a single NTP request with its clock set off by the given offset.
+func fakeServer(t *testing.T, offset time.Duration, stratum byte) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close()
+	})
+
+	go func() {
+		req := make([]byte, packetSize)
+		_, addr, err := conn.ReadFrom(req)
+		if err != nil {
+			return
+		}
+		now := toNTPTime(time.Now().Add(offset))
+
+		resp := make([]byte, packetSize)
+		resp[0] = 4<<3 | serverMode
+		resp[1] = stratum
+		copy(resp[24:32], req[40:48])
+		binary.BigEndian.PutUint64(resp[32:], now)
+		binary.BigEndian.PutUint64(resp[40:], now)
+		_, _ = conn.WriteTo(resp, addr)
+	}()
+	return conn.LocalAddr().String()
+}
@@ -0,0 +1,114 @@
+// Package supervisor runs long-running routines of the node, isolating their panics so that a
+// single routine panicking does not take the whole node down.
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync/atomic"
+	"time"
+
+	logging "github.com/ipfs/go-log/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var (
+	log   = logging.Logger("supervisor")
+	meter = otel.Meter("supervisor")
+)
+
+var (
+	// minBackoff is the delay before the first restart of a panicked routine. It doubles with
+	// every consecutive panic up to maxBackoff.
+	minBackoff = time.Second
+	maxBackoff = time.Minute
+)
+
+// panics counts the recovered panics, once WithMetrics is called.
+var panics atomic.Pointer[metric.Int64Counter]
+
+// Go runs the routine in a new goroutine under supervision. See Run.
+func Go(ctx context.Context, name string, routine func(context.Context)) {
+	go Run(ctx, name, routine)
+}
+
+// Run runs the routine until it returns or, after a panic, the context is done. Whenever the routine panics, the
+// panic is recovered and reported, and the routine is restarted after a backoff growing with
+// every panic in a row. The routine has to be safe to restart, i.e. it must not rely on any state
+// released on its exit.
+func Run(ctx context.Context, name string, routine func(context.Context)) {
+	backoff := minBackoff
+	for {
+		started := time.Now()
+		if !runRecovered(ctx, name, routine) || ctx.Err() != nil {
+			return
+		}
+
+		// a routine running long enough before panicking is not considered to be panicking in a row
+		if time.Since(started) > maxBackoff {
+			backoff = minBackoff
+		}
+		log.Warnw("restarting routine after panic", "routine", name, "backoff", backoff)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// Recover runs the call, turning its panic into an error reported the same way as the panics of
+// the supervised routines. It isolates the calls made by routines owned by other libraries, which
+// retry on errors instead.
+func Recover(ctx context.Context, name string, call func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Errorw("call panicked", "routine", name, "panic", r, "stack", string(debug.Stack()))
+			observePanic(ctx, name)
+			err = fmt.Errorf("supervisor: %s panicked: %v", name, r)
+		}
+	}()
+	return call()
+}
+
+// runRecovered runs the routine, reporting whether it panicked.
+func runRecovered(ctx context.Context, name string, routine func(context.Context)) (panicked bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicked = true
+			log.Errorw("routine panicked", "routine", name, "panic", r, "stack", string(debug.Stack()))
+			observePanic(ctx, name)
+		}
+	}()
+
+	routine(ctx)
+	return false
+}
+
+// WithMetrics turns on metric collection of recovered panics.
+func WithMetrics() error {
+	counter, err := meter.Int64Counter("supervisor_panics_counter",
+		metric.WithDescription("amount of panics recovered in supervised routines, labeled by routine"))
+	if err != nil {
+		return err
+	}
+	panics.Store(&counter)
+	return nil
+}
+
+func observePanic(ctx context.Context, name string) {
+	counter := panics.Load()
+	if counter == nil {
+		return
+	}
+	if ctx.Err() != nil {
+		ctx = context.Background()
+	}
+	(*counter).Add(ctx, 1, metric.WithAttributes(attribute.String("routine", name)))
+}
@@ -0,0 +1,57 @@
+package supervisor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	prevMin, prevMax := minBackoff, maxBackoff
+	minBackoff, maxBackoff = time.Millisecond, time.Millisecond*10
+	t.Cleanup(func() {
+		minBackoff, maxBackoff = prevMin, prevMax
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	t.Cleanup(cancel)
+
+	// the routine is restarted after panics until it returns
+	var runs int
+	Run(ctx, "test", func(context.Context) {
+		runs++
+		if runs < 3 {
+			panic("boom")
+		}
+	})
+	assert.Equal(t, 3, runs)
+
+	// the routine is not restarted once the context is done
+	runCtx, runCancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Run(runCtx, "test", func(context.Context) {
+			runCancel()
+			panic("boom")
+		})
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		require.FailNow(t, "routine was restarted after the context is done")
+	}
+}
+
+func TestRecover(t *testing.T) {
+	ctx := context.Background()
+	require.ErrorContains(t, Recover(ctx, "test", func() error {
+		panic("boom")
+	}), "boom")
+
+	require.NoError(t, Recover(ctx, "test", func() error {
+		return nil
+	}))
+}
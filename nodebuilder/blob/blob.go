@@ -17,6 +17,11 @@ type Module interface {
 	// Allows sending multiple Blobs atomically synchronously.
 	// Uses default wallet registered on the Node.
 	Submit(_ context.Context, _ []*blob.Blob) (height uint64, _ error)
+	// SubmitAll sends Blobs, possibly under different namespaces, packed into as few PayForBlobs
+	// transactions as possible and reports the height in which each of them was included.
+	// If only some of the Blobs were included, a *blob.PartialSubmissionError carrying their
+	// heights is returned. Uses default wallet registered on the Node.
+	SubmitAll(_ context.Context, _ []*blob.Blob) (heights []uint64, _ error)
 	// Get retrieves the blob by commitment under the given namespace and height.
 	Get(_ context.Context, height uint64, _ share.Namespace, _ blob.Commitment) (*blob.Blob, error)
 	// GetAll returns all blobs under the given namespaces and height.
@@ -26,15 +31,54 @@ type Module interface {
 	// Included checks whether a blob's given commitment(Merkle subtree root) is included at
 	// given height and under the namespace.
 	Included(_ context.Context, height uint64, _ share.Namespace, _ *blob.Proof, _ blob.Commitment) (bool, error)
+	// Subscribe streams blobs under the given namespace for every height starting from fromHeight,
+	// replaying the stored history before following new blocks. Zero fromHeight starts after the
+	// local head.
+	Subscribe(_ context.Context, _ share.Namespace, fromHeight uint64) (<-chan *blob.SubscriptionResponse, error)
+	// Backfill starts fetching the blobs under the namespace between the given heights, inclusive,
+	// in the background at a limited rate and indexing them locally, so that GetAll serves them
+	// without retrieving them again. The backfill is resumed after restarts.
+	//
+	// The backfill methods are experimental and served over the RPC only with the
+	// features.BlobBackfill feature enabled.
+	Backfill(_ context.Context, _ share.Namespace, fromHeight, toHeight uint64) (*blob.BackfillStatus, error)
+	// BackfillStatus reports the progress of the backfill of the namespace.
+	BackfillStatus(context.Context, share.Namespace) (*blob.BackfillStatus, error)
+	// PauseBackfill pauses the backfill of the namespace, keeping its progress.
+	PauseBackfill(context.Context, share.Namespace) error
+	// ResumeBackfill resumes the paused or failed backfill of the namespace from where it stopped.
+	ResumeBackfill(context.Context, share.Namespace) error
+	// RemoveBackfill stops the backfill of the namespace, if running, and removes it along with the
+	// blobs it indexed.
+	RemoveBackfill(context.Context, share.Namespace) error
 }
 
 type API struct {
 	Internal struct {
-		Submit   func(context.Context, []*blob.Blob) (uint64, error)                                        `perm:"write"`
-		Get      func(context.Context, uint64, share.Namespace, blob.Commitment) (*blob.Blob, error)        `perm:"read"`
-		GetAll   func(context.Context, uint64, []share.Namespace) ([]*blob.Blob, error)                     `perm:"read"`
-		GetProof func(context.Context, uint64, share.Namespace, blob.Commitment) (*blob.Proof, error)       `perm:"read"`
-		Included func(context.Context, uint64, share.Namespace, *blob.Proof, blob.Commitment) (bool, error) `perm:"read"`
+		Submit    func(context.Context, []*blob.Blob) (uint64, error)                                        `perm:"write"`
+		SubmitAll func(context.Context, []*blob.Blob) ([]uint64, error)                                      `perm:"write"`
+		Get       func(context.Context, uint64, share.Namespace, blob.Commitment) (*blob.Blob, error)        `perm:"read"`
+		GetAll    func(context.Context, uint64, []share.Namespace) ([]*blob.Blob, error)                     `perm:"read"`
+		GetProof  func(context.Context, uint64, share.Namespace, blob.Commitment) (*blob.Proof, error)       `perm:"read"`
+		Included  func(context.Context, uint64, share.Namespace, *blob.Proof, blob.Commitment) (bool, error) `perm:"read"`
+		Subscribe func(
+			context.Context,
+			share.Namespace,
+			uint64,
+		) (<-chan *blob.SubscriptionResponse, error) `perm:"read"`
+		Backfill func(
+			context.Context,
+			share.Namespace,
+			uint64,
+			uint64,
+		) (*blob.BackfillStatus, error) `perm:"admin" feature:"blob-backfill"`
+		BackfillStatus func(
+			context.Context,
+			share.Namespace,
+		) (*blob.BackfillStatus, error) `perm:"read" feature:"blob-backfill"`
+		PauseBackfill  func(context.Context, share.Namespace) error `perm:"admin" feature:"blob-backfill"`
+		ResumeBackfill func(context.Context, share.Namespace) error `perm:"admin" feature:"blob-backfill"`
+		RemoveBackfill func(context.Context, share.Namespace) error `perm:"admin" feature:"blob-backfill"`
 	}
 }
 
@@ -42,6 +86,10 @@ func (api *API) Submit(ctx context.Context, blobs []*blob.Blob) (uint64, error)
 	return api.Internal.Submit(ctx, blobs)
 }
 
+func (api *API) SubmitAll(ctx context.Context, blobs []*blob.Blob) ([]uint64, error) {
+	return api.Internal.SubmitAll(ctx, blobs)
+}
+
 func (api *API) Get(
 	ctx context.Context,
 	height uint64,
@@ -73,3 +121,35 @@ func (api *API) Included(
 ) (bool, error) {
 	return api.Internal.Included(ctx, height, namespace, proof, commitment)
 }
+
+func (api *API) Subscribe(
+	ctx context.Context,
+	namespace share.Namespace,
+	fromHeight uint64,
+) (<-chan *blob.SubscriptionResponse, error) {
+	return api.Internal.Subscribe(ctx, namespace, fromHeight)
+}
+
+func (api *API) Backfill(
+	ctx context.Context,
+	namespace share.Namespace,
+	fromHeight, toHeight uint64,
+) (*blob.BackfillStatus, error) {
+	return api.Internal.Backfill(ctx, namespace, fromHeight, toHeight)
+}
+
+func (api *API) BackfillStatus(ctx context.Context, namespace share.Namespace) (*blob.BackfillStatus, error) {
+	return api.Internal.BackfillStatus(ctx, namespace)
+}
+
+func (api *API) PauseBackfill(ctx context.Context, namespace share.Namespace) error {
+	return api.Internal.PauseBackfill(ctx, namespace)
+}
+
+func (api *API) ResumeBackfill(ctx context.Context, namespace share.Namespace) error {
+	return api.Internal.ResumeBackfill(ctx, namespace)
+}
+
+func (api *API) RemoveBackfill(ctx context.Context, namespace share.Namespace) error {
+	return api.Internal.RemoveBackfill(ctx, namespace)
+}
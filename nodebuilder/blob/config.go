@@ -0,0 +1,34 @@
+package blob
+
+import "fmt"
+
+// Config combines all configuration fields for the blob module.
+type Config struct {
+	// BackfillRate is the amount of heights per second fetched over all the backfills of historical
+	// blobs, to bound the load they put on the node and its peers. Zero uses the default rate.
+	BackfillRate float64
+}
+
+const defaultBackfillRate = 10
+
+// DefaultConfig returns default configuration for the blob module.
+func DefaultConfig() Config {
+	return Config{
+		BackfillRate: defaultBackfillRate,
+	}
+}
+
+// Validate performs basic validation of the config.
+func (cfg *Config) Validate() error {
+	if cfg.BackfillRate < 0 {
+		return fmt.Errorf("module/blob: backfill rate must not be negative, got %v", cfg.BackfillRate)
+	}
+	return nil
+}
+
+func (cfg *Config) backfillRate() float64 {
+	if cfg.BackfillRate == 0 {
+		return defaultBackfillRate
+	}
+	return cfg.BackfillRate
+}
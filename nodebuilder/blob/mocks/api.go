@@ -37,6 +37,36 @@ func (m *MockModule) EXPECT() *MockModuleMockRecorder {
 	return m.recorder
 }
 
+// Backfill mocks base method.
+func (m *MockModule) Backfill(arg0 context.Context, arg1 share.Namespace, arg2 uint64, arg3 uint64) (*blob.BackfillStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Backfill", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*blob.BackfillStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Backfill indicates an expected call of Backfill.
+func (mr *MockModuleMockRecorder) Backfill(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Backfill", reflect.TypeOf((*MockModule)(nil).Backfill), arg0, arg1, arg2, arg3)
+}
+
+// BackfillStatus mocks base method.
+func (m *MockModule) BackfillStatus(arg0 context.Context, arg1 share.Namespace) (*blob.BackfillStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackfillStatus", arg0, arg1)
+	ret0, _ := ret[0].(*blob.BackfillStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BackfillStatus indicates an expected call of BackfillStatus.
+func (mr *MockModuleMockRecorder) BackfillStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackfillStatus", reflect.TypeOf((*MockModule)(nil).BackfillStatus), arg0, arg1)
+}
+
 // Get mocks base method.
 func (m *MockModule) Get(arg0 context.Context, arg1 uint64, arg2 share.Namespace, arg3 blob.Commitment) (*blob.Blob, error) {
 	m.ctrl.T.Helper()
@@ -97,6 +127,63 @@ func (mr *MockModuleMockRecorder) Included(arg0, arg1, arg2, arg3, arg4 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Included", reflect.TypeOf((*MockModule)(nil).Included), arg0, arg1, arg2, arg3, arg4)
 }
 
+// PauseBackfill mocks base method.
+func (m *MockModule) PauseBackfill(arg0 context.Context, arg1 share.Namespace) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PauseBackfill", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PauseBackfill indicates an expected call of PauseBackfill.
+func (mr *MockModuleMockRecorder) PauseBackfill(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseBackfill", reflect.TypeOf((*MockModule)(nil).PauseBackfill), arg0, arg1)
+}
+
+// RemoveBackfill mocks base method.
+func (m *MockModule) RemoveBackfill(arg0 context.Context, arg1 share.Namespace) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveBackfill", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveBackfill indicates an expected call of RemoveBackfill.
+func (mr *MockModuleMockRecorder) RemoveBackfill(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveBackfill", reflect.TypeOf((*MockModule)(nil).RemoveBackfill), arg0, arg1)
+}
+
+// ResumeBackfill mocks base method.
+func (m *MockModule) ResumeBackfill(arg0 context.Context, arg1 share.Namespace) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeBackfill", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResumeBackfill indicates an expected call of ResumeBackfill.
+func (mr *MockModuleMockRecorder) ResumeBackfill(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeBackfill", reflect.TypeOf((*MockModule)(nil).ResumeBackfill), arg0, arg1)
+}
+
+// Subscribe mocks base method.
+func (m *MockModule) Subscribe(arg0 context.Context, arg1 share.Namespace, arg2 uint64) (<-chan *blob.SubscriptionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", arg0, arg1, arg2)
+	ret0, _ := ret[0].(<-chan *blob.SubscriptionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockModuleMockRecorder) Subscribe(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockModule)(nil).Subscribe), arg0, arg1, arg2)
+}
+
 // Submit mocks base method.
 func (m *MockModule) Submit(arg0 context.Context, arg1 []*blob.Blob) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -111,3 +198,18 @@ func (mr *MockModuleMockRecorder) Submit(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Submit", reflect.TypeOf((*MockModule)(nil).Submit), arg0, arg1)
 }
+
+// SubmitAll mocks base method.
+func (m *MockModule) SubmitAll(arg0 context.Context, arg1 []*blob.Blob) ([]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitAll", arg0, arg1)
+	ret0, _ := ret[0].([]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitAll indicates an expected call of SubmitAll.
+func (mr *MockModuleMockRecorder) SubmitAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitAll", reflect.TypeOf((*MockModule)(nil).SubmitAll), arg0, arg1)
+}
@@ -3,6 +3,7 @@ package blob
 import (
 	"context"
 
+	"github.com/ipfs/go-datastore"
 	"go.uber.org/fx"
 
 	"github.com/celestiaorg/celestia-node/blob"
@@ -12,17 +13,51 @@ import (
 	"github.com/celestiaorg/celestia-node/state"
 )
 
-func ConstructModule() fx.Option {
+func ConstructModule(cfg *Config) fx.Option {
+	// sanitize config values before constructing module
+	cfgErr := cfg.Validate()
+
 	return fx.Module("blob",
+		fx.Supply(*cfg),
+		fx.Error(cfgErr),
 		fx.Provide(
 			func(service headerService.Module) func(context.Context, uint64) (*header.ExtendedHeader, error) {
 				return service.GetByHeight
 			}),
+		fx.Provide(
+			func(service headerService.Module) func(context.Context, uint64) (<-chan *header.ExtendedHeader, error) {
+				return service.SubscribeFrom
+			}),
 		fx.Provide(func(
-			state *state.CoreAccessor,
+			submitter submitter,
 			sGetter share.Getter,
 			getByHeightFn func(context.Context, uint64) (*header.ExtendedHeader, error),
-		) Module {
-			return blob.NewService(state, sGetter, getByHeightFn)
+			subscribeFn func(context.Context, uint64) (<-chan *header.ExtendedHeader, error),
+		) *blob.Service {
+			// blobs can't be submitted by Nodes without the state module
+			if submitter.State == nil {
+				return blob.NewService(nil, sGetter, getByHeightFn, subscribeFn)
+			}
+			return blob.NewService(submitter.State, sGetter, getByHeightFn, subscribeFn)
+		}),
+		fx.Provide(fx.Annotate(
+			func(cfg Config, service *blob.Service, ds datastore.Batching) *blob.Backfiller {
+				return blob.NewBackfiller(service, ds, cfg.backfillRate())
+			},
+			fx.OnStart(func(ctx context.Context, b *blob.Backfiller) error {
+				return b.Start(ctx)
+			}),
+			fx.OnStop(func(ctx context.Context, b *blob.Backfiller) error {
+				return b.Stop(ctx)
+			}),
+		)),
+		fx.Provide(func(service *blob.Service, _ *blob.Backfiller) Module {
+			return service
 		}))
 }
+
+type submitter struct {
+	fx.In
+
+	State *state.CoreAccessor `optional:"true"`
+}
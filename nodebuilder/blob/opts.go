@@ -0,0 +1,37 @@
+package blob
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+
+	libhead "github.com/celestiaorg/go-header"
+
+	"github.com/celestiaorg/celestia-node/blob"
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// WithNamespaceMetrics enables reporting of blobs published under the given namespaces and of
+// the time it takes to retrieve them.
+func WithNamespaceMetrics(namespaces []share.Namespace) fx.Option {
+	return fx.Invoke(func(
+		lc fx.Lifecycle,
+		getter share.Getter,
+		sub libhead.Subscriber[*header.ExtendedHeader],
+	) error {
+		m, err := blob.NewNamespaceMetrics(getter, sub, namespaces)
+		if err != nil {
+			return err
+		}
+		lc.Append(fx.Hook{
+			OnStart: func(ctx context.Context) error {
+				return m.Start(ctx)
+			},
+			OnStop: func(ctx context.Context) error {
+				return m.Stop(ctx)
+			},
+		})
+		return nil
+	})
+}
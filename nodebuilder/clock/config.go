@@ -0,0 +1,49 @@
+package clock
+
+import (
+	"fmt"
+	"time"
+)
+
+const defaultCheckInterval = 10 * time.Minute
+
+// Config combines all configuration fields for checking the drift of the local clock.
+type Config struct {
+	// NTPServer is the address of the NTP server to check the local clock against, e.g.
+	// "pool.ntp.org". If empty, the local clock is only checked against the timestamps of new
+	// headers, which reveals a clock running behind only.
+	NTPServer string
+	// CheckInterval is the interval between the checks against the NTP server.
+	CheckInterval time.Duration
+	// WarnDrift is the drift of the local clock over which warnings are logged. Zero disables
+	// warnings.
+	WarnDrift time.Duration
+	// MaxDrift is the drift of the local clock from the NTP server over which the node refuses to
+	// start, as header verification breaks with a skewed clock. Zero disables the check.
+	MaxDrift time.Duration
+}
+
+// DefaultConfig returns default configuration for checking the local clock.
+func DefaultConfig() Config {
+	return Config{
+		CheckInterval: defaultCheckInterval,
+		WarnDrift:     2 * time.Second,
+		// headers from up to 10 seconds in the future are accepted
+		MaxDrift: 10 * time.Second,
+	}
+}
+
+// Validate performs basic validation of the config.
+func (cfg *Config) Validate() error {
+	if cfg.CheckInterval <= 0 {
+		cfg.CheckInterval = defaultCheckInterval
+		log.Warnf("checkInterval is not valid. restoring to default value: %s", cfg.CheckInterval)
+	}
+	if cfg.WarnDrift < 0 || cfg.MaxDrift < 0 {
+		return fmt.Errorf("module/clock: drift thresholds must not be negative")
+	}
+	if cfg.MaxDrift > 0 && cfg.WarnDrift > cfg.MaxDrift {
+		return fmt.Errorf("module/clock: warn drift %s is above the max drift %s", cfg.WarnDrift, cfg.MaxDrift)
+	}
+	return nil
+}
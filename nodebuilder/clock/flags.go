@@ -0,0 +1,43 @@
+package clock
+
+import (
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	ntpServerFlag = "clock.ntp-server"
+	maxDriftFlag  = "clock.max-drift"
+)
+
+// Flags gives a set of clock flags.
+func Flags() *flag.FlagSet {
+	flags := &flag.FlagSet{}
+
+	flags.String(
+		ntpServerFlag,
+		"",
+		"Address of the NTP server to check the local clock against, e.g. pool.ntp.org",
+	)
+	flags.Duration(
+		maxDriftFlag,
+		DefaultConfig().MaxDrift,
+		"Drift of the local clock from the NTP server over which the node refuses to start. "+
+			"0 disables the check",
+	)
+
+	return flags
+}
+
+// ParseFlags parses clock flags from the given cmd and saves them to the passed config.
+func ParseFlags(cmd *cobra.Command, cfg *Config) {
+	if cmd.Flags().Changed(ntpServerFlag) {
+		cfg.NTPServer = cmd.Flag(ntpServerFlag).Value.String()
+	}
+	if cmd.Flags().Changed(maxDriftFlag) {
+		maxDrift, err := cmd.Flags().GetDuration(maxDriftFlag)
+		if err == nil {
+			cfg.MaxDrift = maxDrift
+		}
+	}
+}
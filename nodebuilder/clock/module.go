@@ -0,0 +1,32 @@
+package clock
+
+import (
+	"context"
+
+	logging "github.com/ipfs/go-log/v2"
+	"go.uber.org/fx"
+)
+
+var log = logging.Logger("module/clock")
+
+// ConstructModule collects the components checking the drift of the local clock.
+func ConstructModule(cfg *Config) fx.Option {
+	// sanitize config values before constructing module
+	cfgErr := cfg.Validate()
+
+	return fx.Module(
+		"clock",
+		fx.Supply(*cfg),
+		fx.Error(cfgErr),
+		fx.Provide(fx.Annotate(
+			newDriftMonitor,
+			fx.OnStart(func(ctx context.Context, m *driftMonitor) error {
+				return m.Start(ctx)
+			}),
+			fx.OnStop(func(ctx context.Context, m *driftMonitor) error {
+				return m.Stop(ctx)
+			}),
+		)),
+		fx.Invoke(func(*driftMonitor) {}),
+	)
+}
@@ -0,0 +1,176 @@
+package clock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	libhead "github.com/celestiaorg/go-header"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/ntp"
+)
+
+// ntpTimeout bounds the time to query the NTP server.
+const ntpTimeout = 5 * time.Second
+
+const (
+	sourceNTP    = "ntp"
+	sourceHeader = "header"
+)
+
+// driftMonitor checks the drift of the local clock against an NTP server and against the
+// timestamps of new headers. A skewed clock silently breaks header verification, as headers from
+// the future are rejected and the trusting period is measured by the local clock.
+type driftMonitor struct {
+	cfg Config
+	sub libhead.Subscriber[*header.ExtendedHeader]
+
+	// the last measured drifts, in nanoseconds
+	ntpDrift    atomic.Int64
+	headerDrift atomic.Int64
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newDriftMonitor(cfg Config, sub libhead.Subscriber[*header.ExtendedHeader]) *driftMonitor {
+	return &driftMonitor{
+		cfg: cfg,
+		sub: sub,
+	}
+}
+
+// Start checks the local clock against the NTP server, refusing to start if it drifted over the
+// maximum, and starts checking it periodically.
+func (m *driftMonitor) Start(ctx context.Context) error {
+	if m.cfg.NTPServer != "" {
+		drift, err := m.checkNTP(ctx)
+		switch {
+		case err != nil:
+			log.Warnw("checking the local clock against the NTP server", "server", m.cfg.NTPServer, "err", err)
+		case m.cfg.MaxDrift > 0 && drift.Abs() > m.cfg.MaxDrift:
+			return fmt.Errorf("module/clock: local clock drifted by %s from %s, which is over the maximum "+
+				"of %s: synchronize the clock", drift, m.cfg.NTPServer, m.cfg.MaxDrift)
+		}
+	}
+
+	sub, err := m.sub.Subscribe()
+	if err != nil {
+		return err
+	}
+
+	ctx, m.cancel = context.WithCancel(context.Background())
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		m.checkHeaders(ctx, sub)
+	}()
+	if m.cfg.NTPServer != "" {
+		m.wg.Add(1)
+		go func() {
+			defer m.wg.Done()
+			m.checkNTPPeriodically(ctx)
+		}()
+	}
+	return nil
+}
+
+func (m *driftMonitor) Stop(context.Context) error {
+	if m.cancel != nil {
+		m.cancel()
+	}
+	m.wg.Wait()
+	return nil
+}
+
+func (m *driftMonitor) checkNTPPeriodically(ctx context.Context) {
+	ticker := time.NewTicker(m.cfg.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := m.checkNTP(ctx); err != nil && ctx.Err() == nil {
+				log.Warnw("checking the local clock against the NTP server", "server", m.cfg.NTPServer, "err", err)
+			}
+		}
+	}
+}
+
+func (m *driftMonitor) checkNTP(ctx context.Context) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, ntpTimeout)
+	defer cancel()
+
+	drift, err := ntp.Drift(ctx, m.cfg.NTPServer)
+	if err != nil {
+		return 0, err
+	}
+	m.ntpDrift.Store(int64(drift))
+	m.report(sourceNTP, drift)
+	return drift, nil
+}
+
+// checkHeaders checks the local clock against the timestamps of new headers. A header always
+// arrives after its timestamp, so a header from the future shows the local clock running behind,
+// by at least the difference. A clock running ahead can not be told apart from a delayed header.
+func (m *driftMonitor) checkHeaders(ctx context.Context, sub libhead.Subscription[*header.ExtendedHeader]) {
+	defer sub.Cancel()
+	for {
+		h, err := sub.NextHeader(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+			log.Debugw("failed to get next header", "err", err)
+			continue
+		}
+
+		drift := time.Since(h.Time())
+		if drift > 0 {
+			drift = 0
+		}
+		m.headerDrift.Store(int64(drift))
+		m.report(sourceHeader, drift)
+	}
+}
+
+func (m *driftMonitor) report(source string, drift time.Duration) {
+	switch abs := drift.Abs(); {
+	case m.cfg.MaxDrift > 0 && abs > m.cfg.MaxDrift:
+		log.Errorw("local clock drifted over the maximum, header verification may fail",
+			"source", source, "drift", drift, "max", m.cfg.MaxDrift)
+	case m.cfg.WarnDrift > 0 && abs > m.cfg.WarnDrift:
+		log.Warnw("local clock is drifting", "source", source, "drift", drift)
+	}
+}
+
+// WithMetrics reports the last measured drifts of the local clock.
+func WithMetrics(m *driftMonitor) error {
+	meter := otel.Meter("clock")
+	drift, err := meter.Float64ObservableGauge("clock_drift_seconds",
+		metric.WithDescription("drift of the local clock, negative if it runs behind"))
+	if err != nil {
+		return err
+	}
+
+	callback := func(_ context.Context, observer metric.Observer) error {
+		observer.ObserveFloat64(drift, time.Duration(m.headerDrift.Load()).Seconds(),
+			metric.WithAttributes(attribute.String("source", sourceHeader)))
+		if m.cfg.NTPServer != "" {
+			observer.ObserveFloat64(drift, time.Duration(m.ntpDrift.Load()).Seconds(),
+				metric.WithAttributes(attribute.String("source", sourceNTP)))
+		}
+		return nil
+	}
+	_, err = meter.RegisterCallback(callback, drift)
+	return err
+}
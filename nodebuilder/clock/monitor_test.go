@@ -0,0 +1,93 @@
+package clock
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	libheadtest "github.com/celestiaorg/go-header/headertest"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+)
+
+func TestDriftMonitor_Headers(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	future := headertest.RandExtendedHeader(t)
+	future.RawHeader.Time = time.Now().Add(time.Minute)
+	past := headertest.RandExtendedHeader(t)
+	past.RawHeader.Time = time.Now().Add(-time.Minute)
+	sub := &libheadtest.Subscriber[*header.ExtendedHeader]{
+		Headers: []*header.ExtendedHeader{future, past},
+	}
+
+	m := newDriftMonitor(DefaultConfig(), sub)
+	require.NoError(t, m.Start(ctx))
+	// the subscription ends once the headers are over
+	m.wg.Wait()
+	require.NoError(t, m.Stop(ctx))
+
+	// a past header does not tell anything about the drift
+	assert.Zero(t, m.headerDrift.Load())
+
+	m = newDriftMonitor(DefaultConfig(), &libheadtest.Subscriber[*header.ExtendedHeader]{
+		Headers: []*header.ExtendedHeader{future},
+	})
+	require.NoError(t, m.Start(ctx))
+	m.wg.Wait()
+	require.NoError(t, m.Stop(ctx))
+	assert.InDelta(t, -time.Minute, m.headerDrift.Load(), float64(time.Second))
+}
+
+func TestDriftMonitor_NTP(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	cfg := DefaultConfig()
+	cfg.NTPServer = fakeNTPServer(t, time.Minute)
+	m := newDriftMonitor(cfg, &libheadtest.Subscriber[*header.ExtendedHeader]{})
+	require.ErrorContains(t, m.Start(ctx), "synchronize the clock")
+
+	// the node starts with the check disabled
+	cfg.NTPServer = fakeNTPServer(t, time.Minute)
+	cfg.MaxDrift = 0
+	m = newDriftMonitor(cfg, &libheadtest.Subscriber[*header.ExtendedHeader]{})
+	require.NoError(t, m.Start(ctx))
+	require.NoError(t, m.Stop(ctx))
+	assert.InDelta(t, -time.Minute, m.ntpDrift.Load(), float64(time.Second))
+}
+
+// fakeNTPServer answers a single NTP request with its clock set off by the given offset.
+func fakeNTPServer(t *testing.T, offset time.Duration) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close()
+	})
+
+	go func() {
+		req := make([]byte, 48)
+		_, addr, err := conn.ReadFrom(req)
+		if err != nil {
+			return
+		}
+		now := time.Now().Add(offset)
+		ts := uint64(now.Unix()+2208988800)<<32 | (uint64(now.Nanosecond())<<32)/uint64(time.Second)
+
+		resp := make([]byte, 48)
+		resp[0] = 4<<3 | 4 // version 4, server mode
+		resp[1] = 1        // stratum
+		copy(resp[24:32], req[40:48])
+		binary.BigEndian.PutUint64(resp[32:], ts)
+		binary.BigEndian.PutUint64(resp[40:], ts)
+		_, _ = conn.WriteTo(resp, addr)
+	}()
+	return conn.LocalAddr().String()
+}
@@ -1,22 +1,35 @@
 package nodebuilder
 
 import (
+	"errors"
+	"fmt"
 	"io"
+	"net/url"
 	"os"
 
 	"github.com/BurntSushi/toml"
 	"github.com/imdario/mergo"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
 
 	"github.com/celestiaorg/celestia-node/libs/fslock"
+	"github.com/celestiaorg/celestia-node/nodebuilder/blob"
+	"github.com/celestiaorg/celestia-node/nodebuilder/clock"
 	"github.com/celestiaorg/celestia-node/nodebuilder/core"
 	"github.com/celestiaorg/celestia-node/nodebuilder/das"
+	"github.com/celestiaorg/celestia-node/nodebuilder/features"
 	"github.com/celestiaorg/celestia-node/nodebuilder/gateway"
 	"github.com/celestiaorg/celestia-node/nodebuilder/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/health"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+	"github.com/celestiaorg/celestia-node/nodebuilder/pruner"
+	"github.com/celestiaorg/celestia-node/nodebuilder/quota"
 	"github.com/celestiaorg/celestia-node/nodebuilder/rpc"
 	"github.com/celestiaorg/celestia-node/nodebuilder/share"
 	"github.com/celestiaorg/celestia-node/nodebuilder/state"
+	"github.com/celestiaorg/celestia-node/nodebuilder/watchdog"
+	"github.com/celestiaorg/celestia-node/nodebuilder/watcher"
 )
 
 // ConfigLoader defines a function that loads a config from any source.
@@ -25,40 +38,124 @@ type ConfigLoader func() (*Config, error)
 // Config is main configuration structure for a Node.
 // It combines configuration units for all Node subsystems.
 type Config struct {
-	Node    node.Config
-	Core    core.Config
-	State   state.Config
-	P2P     p2p.Config
-	RPC     rpc.Config
-	Gateway gateway.Config
-	Share   share.Config
-	Header  header.Config
-	DASer   das.Config `toml:",omitempty"`
+	Node     node.Config
+	Core     core.Config
+	State    state.Config
+	P2P      p2p.Config
+	RPC      rpc.Config
+	Gateway  gateway.Config
+	Health   health.Config
+	Share    share.Config
+	Pruner   pruner.Config
+	Quota    quota.Config
+	Header   header.Config
+	Blob     blob.Config
+	Clock    clock.Config
+	Watchdog watchdog.Config
+	Features features.Config
+	Watcher  watcher.Config
+	DASer    das.Config `toml:",omitempty"`
 }
 
 // DefaultConfig provides a default Config for a given Node Type 'tp'.
 // NOTE: Currently, configs are identical, but this will change.
-func DefaultConfig(tp node.Type) *Config {
+func DefaultConfig(tp node.Type) (*Config, error) {
+	// custom node types use the defaults of the built-in type they build upon
+	tp = tp.Base()
+	switch tp {
+	case node.Bridge, node.Light, node.Full:
+	default:
+		return nil, fmt.Errorf("nodebuilder: invalid node type: %s", tp)
+	}
+
 	commonConfig := &Config{
-		Node:    node.DefaultConfig(tp),
-		Core:    core.DefaultConfig(),
-		State:   state.DefaultConfig(),
-		P2P:     p2p.DefaultConfig(tp),
-		RPC:     rpc.DefaultConfig(),
-		Gateway: gateway.DefaultConfig(),
-		Share:   share.DefaultConfig(tp),
-		Header:  header.DefaultConfig(tp),
+		Node:     node.DefaultConfig(tp),
+		Core:     core.DefaultConfig(),
+		State:    state.DefaultConfig(),
+		P2P:      p2p.DefaultConfig(tp),
+		RPC:      rpc.DefaultConfig(),
+		Gateway:  gateway.DefaultConfig(),
+		Health:   health.DefaultConfig(),
+		Share:    share.DefaultConfig(tp),
+		Pruner:   pruner.DefaultConfig(),
+		Quota:    quota.DefaultConfig(),
+		Header:   header.DefaultConfig(tp),
+		Blob:     blob.DefaultConfig(),
+		Clock:    clock.DefaultConfig(),
+		Watchdog: watchdog.DefaultConfig(),
+		Features: features.DefaultConfig(),
+		Watcher:  watcher.DefaultConfig(),
 	}
 
-	switch tp {
-	case node.Bridge:
-		return commonConfig
-	case node.Light, node.Full:
+	if tp != node.Bridge {
 		commonConfig.DASer = das.DefaultConfig(tp)
-		return commonConfig
-	default:
-		panic("node: invalid node type")
 	}
+	return commonConfig, nil
+}
+
+// Validate checks the values of the Config for the given Node Type 'tp' the same way modules do
+// on node construction, reporting all the invalid sections at once.
+func (cfg *Config) Validate(tp node.Type) error {
+	tp = tp.Base()
+	var errs []error
+	check := func(section string, err error) {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", section, err))
+		}
+	}
+
+	check("Node", cfg.Node.Validate())
+	check("Core", cfg.Core.Validate())
+	check("State", cfg.State.Validate())
+	check("P2P", cfg.P2P.Validate())
+	check("RPC", cfg.RPC.Validate())
+	if cfg.Gateway.Enabled {
+		check("Gateway", cfg.Gateway.Validate())
+	}
+	check("Share", cfg.Share.Validate(tp))
+	check("Pruner", cfg.Pruner.Validate())
+	check("Quota", cfg.Quota.Validate())
+	check("Header", cfg.Header.Validate(tp))
+	check("Blob", cfg.Blob.Validate())
+	check("Clock", cfg.Clock.Validate())
+	check("Watchdog", cfg.Watchdog.Validate())
+	check("Features", cfg.Features.Validate())
+	check("Watcher", cfg.Watcher.Validate())
+	// bridge node does not run DASer
+	if tp != node.Bridge {
+		check("DASer", cfg.DASer.Validate())
+	}
+	check("P2P", cfg.validateAllowedPeers())
+	return errors.Join(errs...)
+}
+
+// validateAllowedPeers checks that the configured trusted, mutual and protected peers are allowed in strict
+// peering mode, as the node would not be able to connect to them otherwise.
+func (cfg *Config) validateAllowedPeers() error {
+	if !cfg.P2P.StrictPeering {
+		return nil
+	}
+	var errs []error
+	check := func(kind string, addrs []string) {
+		for _, addr := range addrs {
+			maddr, err := ma.NewMultiaddr(addr)
+			if err != nil {
+				// malformed addresses are reported by the sections they belong to
+				continue
+			}
+			info, err := peer.AddrInfoFromP2pAddr(maddr)
+			if err != nil {
+				continue
+			}
+			if !cfg.P2P.IsPeerAllowed(info.ID) {
+				errs = append(errs, fmt.Errorf("%s peer %s is not in AllowedPeers", kind, info.ID))
+			}
+		}
+	}
+	check("trusted", cfg.Header.TrustedPeers)
+	check("mutual", cfg.P2P.MutualPeers)
+	check("protected", cfg.P2P.ProtectedPeers)
+	return errors.Join(errs...)
 }
 
 // SaveConfig saves Config 'cfg' under the given 'path'.
@@ -126,7 +223,10 @@ func UpdateConfig(tp node.Type, path string) (err error) {
 	}
 	defer flock.Unlock() //nolint: errcheck
 
-	newCfg := DefaultConfig(tp)
+	newCfg, err := DefaultConfig(tp)
+	if err != nil {
+		return
+	}
 
 	cfgPath := configPath(path)
 	cfg, err := LoadConfig(cfgPath)
@@ -164,6 +264,42 @@ func (cfg *Config) Encode(w io.Writer) error {
 	return toml.NewEncoder(w).Encode(cfg)
 }
 
+// redacted replaces the secrets of the Config.
+const redacted = "REDACTED"
+
+// Redacted returns a copy of the Config without its secrets, safe to share in bug reports: the
+// gateway tokens, along with the credentials, paths and queries of the configured URLs, which
+// often embed tokens.
+func (cfg *Config) Redacted() *Config {
+	out := *cfg
+	if len(cfg.Gateway.Auth.Tokens) > 0 {
+		out.Gateway.Auth.Tokens = make([]string, len(cfg.Gateway.Auth.Tokens))
+		for i := range out.Gateway.Auth.Tokens {
+			out.Gateway.Auth.Tokens[i] = redacted
+		}
+	}
+	out.Header.TrustedHeadURL = redactURL(cfg.Header.TrustedHeadURL)
+	out.Watcher.WebhookURL = redactURL(cfg.Watcher.WebhookURL)
+	out.State.Signer.RemoteURL = redactURL(cfg.State.Signer.RemoteURL)
+	return &out
+}
+
+// redactURL keeps only the scheme and the host of the URL.
+func redactURL(rawURL string) string {
+	if rawURL == "" {
+		return ""
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return redacted
+	}
+	out := u.Scheme + "://" + u.Host
+	if u.User != nil || u.Path != "" || u.RawQuery != "" || u.Fragment != "" {
+		out += "/" + redacted
+	}
+	return out
+}
+
 // Decode decodes a Config from a given reader r.
 func (cfg *Config) Decode(r io.Reader) error {
 	_, err := toml.NewDecoder(r).Decode(cfg)
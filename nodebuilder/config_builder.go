@@ -0,0 +1,202 @@
+package nodebuilder
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+// ConfigOption customizes a Config built with NewConfig. Options check the values they are given
+// and report the Config section they belong to on error.
+type ConfigOption func(*Config) error
+
+// NewConfig builds the default Config for the given Node Type 'tp' with the options applied and
+// validates the result, so that invalid settings are reported on construction instead of when
+// the Node is started.
+func NewConfig(tp node.Type, opts ...ConfigOption) (*Config, error) {
+	if !tp.IsValid() {
+		return nil, fmt.Errorf("node: invalid node type %s", tp)
+	}
+
+	cfg, err := DefaultConfig(tp)
+	if err != nil {
+		return nil, err
+	}
+	var errs []error
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(tp); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// WithCoreEndpoint sets the address and the ports of the celestia-core node to connect to.
+func WithCoreEndpoint(ip, rpcPort, grpcPort string) ConfigOption {
+	return func(cfg *Config) error {
+		if ip == "" {
+			return sectionErr("Core", errors.New("empty IP"))
+		}
+		cfg.Core.IP = ip
+		cfg.Core.RPCPort = rpcPort
+		cfg.Core.GRPCPort = grpcPort
+		return nil
+	}
+}
+
+// WithRPCEndpoint sets the address and the port the RPC server listens on.
+func WithRPCEndpoint(address, port string) ConfigOption {
+	return func(cfg *Config) error {
+		cfg.RPC.Address = address
+		cfg.RPC.Port = port
+		return nil
+	}
+}
+
+// WithGatewayEndpoint enables the gateway on the given address and port.
+func WithGatewayEndpoint(address, port string) ConfigOption {
+	return func(cfg *Config) error {
+		cfg.Gateway.Enabled = true
+		cfg.Gateway.Address = address
+		cfg.Gateway.Port = port
+		return nil
+	}
+}
+
+// WithTrustedHash sets the hash of the header to start header synchronization from.
+func WithTrustedHash(hash string) ConfigOption {
+	return func(cfg *Config) error {
+		cfg.Header.TrustedHash = hash
+		return nil
+	}
+}
+
+// WithTrustedPeers adds the given multiaddresses to the peers headers are fetched from.
+func WithTrustedPeers(addrs ...string) ConfigOption {
+	return func(cfg *Config) error {
+		if err := validateMultiaddrs(addrs); err != nil {
+			return sectionErr("Header", fmt.Errorf("invalid trusted peer: %w", err))
+		}
+		cfg.Header.TrustedPeers = append(cfg.Header.TrustedPeers, addrs...)
+		return nil
+	}
+}
+
+// WithListenAddresses replaces the multiaddresses the node listens on for P2P connections.
+func WithListenAddresses(addrs ...string) ConfigOption {
+	return func(cfg *Config) error {
+		if len(addrs) == 0 {
+			return sectionErr("P2P", errors.New("no listen addresses"))
+		}
+		if err := validateMultiaddrs(addrs); err != nil {
+			return sectionErr("P2P", fmt.Errorf("invalid listen address: %w", err))
+		}
+		cfg.P2P.ListenAddresses = addrs
+		return nil
+	}
+}
+
+// WithMutualPeers adds the given multiaddresses to the peers with a bidirectional peering
+// agreement.
+func WithMutualPeers(addrs ...string) ConfigOption {
+	return func(cfg *Config) error {
+		if err := validateMultiaddrs(addrs); err != nil {
+			return sectionErr("P2P", fmt.Errorf("invalid mutual peer: %w", err))
+		}
+		cfg.P2P.MutualPeers = append(cfg.P2P.MutualPeers, addrs...)
+		return nil
+	}
+}
+
+// WithProtectedPeers adds the given multiaddresses to the peers the node always stays connected
+// to.
+func WithProtectedPeers(addrs ...string) ConfigOption {
+	return func(cfg *Config) error {
+		if err := validateMultiaddrs(addrs); err != nil {
+			return sectionErr("P2P", fmt.Errorf("invalid protected peer: %w", err))
+		}
+		cfg.P2P.ProtectedPeers = append(cfg.P2P.ProtectedPeers, addrs...)
+		return nil
+	}
+}
+
+// WithPeerExchange sets whether the node shares peers with pruned peers.
+func WithPeerExchange(enabled bool) ConfigOption {
+	return func(cfg *Config) error {
+		cfg.P2P.PeerExchange = enabled
+		return nil
+	}
+}
+
+// WithStrictPeering restricts the node to connect only to the peers with the given IDs.
+func WithStrictPeering(ids ...string) ConfigOption {
+	return func(cfg *Config) error {
+		for _, id := range ids {
+			if _, err := peer.Decode(id); err != nil {
+				return sectionErr("P2P", fmt.Errorf("invalid allowed peer %s: %w", id, err))
+			}
+		}
+		cfg.P2P.StrictPeering = true
+		cfg.P2P.AllowedPeers = append(cfg.P2P.AllowedPeers, ids...)
+		return nil
+	}
+}
+
+// WithShareExchange sets whether the node retrieves data over share exchange, before falling
+// back to IPLD.
+func WithShareExchange(enabled bool) ConfigOption {
+	return func(cfg *Config) error {
+		cfg.Share.UseShareExchange = enabled
+		return nil
+	}
+}
+
+// WithKeyring sets the name of the account the node signs transactions with and the keyring
+// backend it is stored in.
+func WithKeyring(accName, backend string) ConfigOption {
+	return func(cfg *Config) error {
+		if backend == "" {
+			return sectionErr("State", errors.New("empty keyring backend"))
+		}
+		cfg.State.KeyringAccName = accName
+		cfg.State.KeyringBackend = backend
+		return nil
+	}
+}
+
+// WithTimeouts sets the time the node is given to start and to stop.
+func WithTimeouts(startup, shutdown time.Duration) ConfigOption {
+	return func(cfg *Config) error {
+		if startup <= 0 || shutdown <= 0 {
+			return sectionErr("Node", fmt.Errorf("timeouts must be positive, got %s and %s", startup, shutdown))
+		}
+		cfg.Node.StartupTimeout = startup
+		cfg.Node.ShutdownTimeout = shutdown
+		return nil
+	}
+}
+
+// sectionErr attributes the error to the given Config section, the same way Config.Validate does.
+func sectionErr(section string, err error) error {
+	return fmt.Errorf("%s: %w", section, err)
+}
+
+func validateMultiaddrs(addrs []string) error {
+	for _, addr := range addrs {
+		if _, err := ma.NewMultiaddr(addr); err != nil {
+			return fmt.Errorf("%s: %w", addr, err)
+		}
+	}
+	return nil
+}
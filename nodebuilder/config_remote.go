@@ -0,0 +1,147 @@
+package nodebuilder
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// remoteConfigTimeout bounds the time given to fetch a remote config.
+const remoteConfigTimeout = time.Second * 30
+
+// maxRemoteConfigSize limits the size of a remote config, to not read arbitrary responses into
+// memory.
+const maxRemoteConfigSize = 1 << 20
+
+// IsRemoteConfig reports whether the given config location is a URL LoadRemoteConfig can load
+// from, rather than a local path.
+func IsRemoteConfig(location string) bool {
+	u, err := url.Parse(location)
+	if err != nil {
+		return false
+	}
+	switch u.Scheme {
+	case "http", "https", "s3":
+		return true
+	default:
+		return false
+	}
+}
+
+// LoadRemoteConfig loads Config from the given HTTP(S) or S3 URL. S3 objects are fetched from the
+// bucket's HTTPS endpoint, so they must be publicly readable; private objects can be loaded through
+// a presigned HTTPS URL instead.
+//
+// If 'checksum' is set, the config must match the given hex encoded SHA-256 checksum. A valid
+// config is cached under the store 'path', and the cached copy is loaded whenever the remote one
+// cannot be fetched, so that the node can restart while the remote is unavailable.
+func LoadRemoteConfig(ctx context.Context, location, checksum, path string) (*Config, error) {
+	path, err := storePath(path)
+	if err != nil {
+		return nil, err
+	}
+	cachePath := remoteConfigPath(path)
+
+	data, err := fetchRemoteConfig(ctx, location)
+	if err != nil {
+		cached, cacheErr := os.ReadFile(cachePath)
+		if cacheErr != nil {
+			return nil, fmt.Errorf("fetching remote config: %w", err)
+		}
+		log.Warnw("fetching remote config, falling back to the cached one",
+			"url", location, "cache", cachePath, "err", err)
+		data = cached
+	}
+
+	if err = verifyChecksum(data, checksum); err != nil {
+		return nil, err
+	}
+	var cfg Config
+	if err = cfg.Decode(bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("decoding remote config: %w", err)
+	}
+
+	if err = cacheRemoteConfig(cachePath, data); err != nil {
+		log.Warnw("caching remote config", "path", cachePath, "err", err)
+	}
+	return &cfg, nil
+}
+
+func fetchRemoteConfig(ctx context.Context, location string) ([]byte, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "s3" {
+		u = &url.URL{
+			Scheme: "https",
+			Host:   u.Host + ".s3.amazonaws.com",
+			Path:   u.Path,
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, remoteConfigTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxRemoteConfigSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxRemoteConfigSize {
+		return nil, fmt.Errorf("config exceeds %d bytes", maxRemoteConfigSize)
+	}
+	return data, nil
+}
+
+func verifyChecksum(data []byte, checksum string) error {
+	if checksum == "" {
+		return nil
+	}
+	want, err := hex.DecodeString(strings.TrimPrefix(checksum, "sha256:"))
+	if err != nil {
+		return fmt.Errorf("decoding config checksum: %w", err)
+	}
+	got := sha256.Sum256(data)
+	if !bytes.Equal(got[:], want) {
+		return errors.New("config checksum mismatch")
+	}
+	return nil
+}
+
+// cacheRemoteConfig atomically replaces the cached remote config with the given one.
+func cacheRemoteConfig(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), perms); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func remoteConfigPath(base string) string {
+	return filepath.Join(base, "config.remote.toml")
+}
@@ -0,0 +1,53 @@
+package nodebuilder
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+func TestLoadRemoteConfig(t *testing.T) {
+	ctx := context.Background()
+
+	in, err := DefaultConfig(node.Light)
+	require.NoError(t, err)
+	in.RPC.Port = "7979"
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, in.Encode(buf))
+	data := buf.Bytes()
+	sum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(sum[:])
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(data)
+	}))
+	path := t.TempDir()
+
+	assert.True(t, IsRemoteConfig(srv.URL))
+	assert.False(t, IsRemoteConfig("/home/user/config.toml"))
+
+	_, err = LoadRemoteConfig(ctx, srv.URL, hex.EncodeToString(make([]byte, sha256.Size)), path)
+	require.Error(t, err)
+
+	out, err := LoadRemoteConfig(ctx, srv.URL, checksum, path)
+	require.NoError(t, err)
+	assert.EqualValues(t, in, out)
+
+	// the cached config is loaded once the remote is unreachable
+	srv.Close()
+	out, err = LoadRemoteConfig(ctx, srv.URL, checksum, path)
+	require.NoError(t, err)
+	assert.EqualValues(t, in, out)
+
+	_, err = LoadRemoteConfig(ctx, srv.URL, checksum, t.TempDir())
+	require.Error(t, err)
+}
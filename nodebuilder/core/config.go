@@ -1,17 +1,76 @@
 package core
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"os"
 	"strconv"
+	"time"
 
+	"github.com/celestiaorg/celestia-node/core"
 	"github.com/celestiaorg/celestia-node/libs/utils"
 )
 
+// defaultEDSWriteQueueSize is the amount of EDSes of new blocks queued to be stored with the async
+// write strategy, bounding the memory they take.
+const defaultEDSWriteQueueSize = 8
+
 // Config combines all configuration fields for managing the relationship with a Core node.
 type Config struct {
 	IP       string
 	RPCPort  string
 	GRPCPort string
+	// GRPCTLS secures the gRPC connection the state module makes to Core, so that nodes can reach
+	// remote Core nodes across untrusted networks. The bearer token authenticating the node to Core,
+	// if any, is read from the CELESTIA_CORE_GRPC_TOKEN environment variable and requires TLS.
+	// Neither applies to the RPC endpoint, which Bridge nodes fetch blocks from and the state module
+	// makes ABCI queries to, so it must stay reachable over a trusted network.
+	GRPCTLS TLSConfig
+
+	// Endpoints are the additional Core endpoints Bridge nodes fail over to, in order, once the
+	// active one becomes unreachable. Only the fetching of blocks fails over: the state module keeps
+	// querying state and submitting transactions through the gRPC and RPC endpoints of IP, and so
+	// is unavailable while those are, as reported by the core_state_endpoint of the node info.
+	Endpoints []Endpoint
+	// HealthCheckInterval is the interval Bridge nodes check the active Core endpoint at, if there
+	// are several of them. Zero means the default.
+	HealthCheckInterval time.Duration
+
+	// EDSWriteStrategy is the way Bridge nodes persist the EDSes of new blocks: "sync" stores the EDS
+	// before broadcasting the header of the block, while "async" broadcasts the header right away
+	// and stores the EDS in the background, trading durability for the latency of head tracking.
+	// Defaults to "sync".
+	EDSWriteStrategy core.WriteStrategy
+	// EDSWriteQueueSize is the maximum amount of EDSes queued to be stored with the "async"
+	// strategy. Zero means the default.
+	EDSWriteQueueSize int
+}
+
+// EnvGRPCToken is the environment variable the bearer token authenticating the node to the gRPC
+// endpoint of Core is read from.
+const EnvGRPCToken = "CELESTIA_CORE_GRPC_TOKEN"
+
+// TLSConfig configures TLS of the gRPC connection to Core.
+type TLSConfig struct {
+	// Enabled connects to Core over TLS, verifying its certificate against the system CAs unless
+	// CAPath is set.
+	Enabled bool
+	// CAPath is the path to the PEM encoded CA certificates to verify the certificate of Core against.
+	CAPath string
+	// CertPath is the path to the PEM encoded client certificate presented to Core (mTLS), along with
+	// the key at KeyPath.
+	CertPath string
+	KeyPath  string
+	// ServerName is the name the certificate of Core is verified for. Defaults to IP.
+	ServerName string
+}
+
+// Endpoint is an additional Core endpoint to fail over to.
+type Endpoint struct {
+	IP      string
+	RPCPort string
 }
 
 // DefaultConfig returns default configuration for managing the
@@ -21,6 +80,9 @@ func DefaultConfig() Config {
 		IP:       "0.0.0.0",
 		RPCPort:  "0",
 		GRPCPort: "0",
+
+		EDSWriteStrategy:  core.WriteSync,
+		EDSWriteQueueSize: defaultEDSWriteQueueSize,
 	}
 }
 
@@ -39,5 +101,102 @@ func (cfg *Config) Validate() error {
 	if err != nil {
 		return fmt.Errorf("nodebuilder/core: invalid grpc port: %s", err.Error())
 	}
+	if err := cfg.GRPCTLS.Validate(); err != nil {
+		return err
+	}
+	for i := range cfg.Endpoints {
+		ip, err := utils.ValidateAddr(cfg.Endpoints[i].IP)
+		if err != nil {
+			return fmt.Errorf("nodebuilder/core: invalid endpoint: %w", err)
+		}
+		cfg.Endpoints[i].IP = ip
+		_, err = strconv.Atoi(cfg.Endpoints[i].RPCPort)
+		if err != nil {
+			return fmt.Errorf("nodebuilder/core: invalid rpc port of endpoint %s: %s", ip, err.Error())
+		}
+	}
+	if cfg.HealthCheckInterval < 0 {
+		return fmt.Errorf("nodebuilder/core: HealthCheckInterval must not be negative, got %s", cfg.HealthCheckInterval)
+	}
+	if cfg.EDSWriteStrategy != "" {
+		if err := cfg.EDSWriteStrategy.Validate(); err != nil {
+			return fmt.Errorf("nodebuilder/core: %w", err)
+		}
+	}
+	if cfg.EDSWriteQueueSize < 0 {
+		return fmt.Errorf("nodebuilder/core: EDSWriteQueueSize must not be negative, got %d", cfg.EDSWriteQueueSize)
+	}
 	return nil
 }
+
+// listenerOptions gives the options of the Listener from the config, filling in the defaults of
+// the configs written before the options existed.
+func (cfg *Config) listenerOptions() []core.ListenerOption {
+	strategy := cfg.EDSWriteStrategy
+	if strategy == "" {
+		strategy = core.WriteSync
+	}
+	queueSize := cfg.EDSWriteQueueSize
+	if queueSize == 0 {
+		queueSize = defaultEDSWriteQueueSize
+	}
+	return []core.ListenerOption{core.WithWriteStrategy(strategy, queueSize)}
+}
+
+// Validate checks that the TLS files are set only with TLS enabled, the client certificate and
+// key are set together, and all the files exist.
+func (cfg *TLSConfig) Validate() error {
+	if !cfg.Enabled {
+		if cfg.CAPath != "" || cfg.CertPath != "" || cfg.KeyPath != "" {
+			return errors.New("nodebuilder/core: TLS files are set, but TLS is disabled")
+		}
+		return nil
+	}
+	if (cfg.CertPath == "") != (cfg.KeyPath == "") {
+		return errors.New("nodebuilder/core: TLS client certificate requires both a certificate and a key")
+	}
+	for _, path := range []string{cfg.CAPath, cfg.CertPath, cfg.KeyPath} {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("nodebuilder/core: invalid TLS file: %w", err)
+		}
+	}
+	return nil
+}
+
+// Load loads the TLS config of the connection to Core at the given address from the configured
+// files, or returns nil if TLS is disabled.
+func (cfg *TLSConfig) Load(ip string) (*tls.Config, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	tlsCfg := &tls.Config{
+		ServerName: cfg.ServerName,
+		MinVersion: tls.VersionTLS12,
+	}
+	if tlsCfg.ServerName == "" {
+		tlsCfg.ServerName = ip
+	}
+
+	if cfg.CAPath != "" {
+		pem, err := os.ReadFile(cfg.CAPath)
+		if err != nil {
+			return nil, fmt.Errorf("nodebuilder/core: reading TLS CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("nodebuilder/core: no certificates found in TLS CA %s", cfg.CAPath)
+		}
+		tlsCfg.RootCAs = pool
+	}
+	if cfg.CertPath != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertPath, cfg.KeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("nodebuilder/core: loading TLS client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+	return tlsCfg, nil
+}
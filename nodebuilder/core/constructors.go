@@ -1,9 +1,24 @@
 package core
 
 import (
+	"net"
+
 	"github.com/celestiaorg/celestia-node/core"
 )
 
-func remote(cfg Config) (core.Client, error) {
-	return core.NewRemote(cfg.IP, cfg.RPCPort)
+// failover constructs the clients of the Core endpoints of the config, the one of IP first.
+func failover(cfg Config) (*core.Failover, error) {
+	endpoints := append([]Endpoint{{IP: cfg.IP, RPCPort: cfg.RPCPort}}, cfg.Endpoints...)
+	remotes := make([]core.Endpoint, len(endpoints))
+	for i, endpoint := range endpoints {
+		client, err := core.NewRemote(endpoint.IP, endpoint.RPCPort)
+		if err != nil {
+			return nil, err
+		}
+		remotes[i] = core.Endpoint{
+			Address: net.JoinHostPort(endpoint.IP, endpoint.RPCPort),
+			Client:  client,
+		}
+	}
+	return core.NewFailover(cfg.HealthCheckInterval, remotes...), nil
 }
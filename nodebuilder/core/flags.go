@@ -2,15 +2,26 @@ package core
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
+
+	"github.com/celestiaorg/celestia-node/core"
 )
 
 var (
 	coreFlag     = "core.ip"
 	coreRPCFlag  = "core.rpc.port"
 	coreGRPCFlag = "core.grpc.port"
+
+	grpcTLSFlag     = "core.grpc.tls"
+	grpcTLSCAFlag   = "core.grpc.tls.ca"
+	grpcTLSCertFlag = "core.grpc.tls.cert"
+	grpcTLSKeyFlag  = "core.grpc.tls.key"
+
+	edsWriteFlag      = "core.eds-write"
+	edsWriteQueueFlag = "core.eds-write.queue"
 )
 
 // Flags gives a set of hardcoded Core flags.
@@ -22,7 +33,10 @@ func Flags() *flag.FlagSet {
 		"",
 		"Indicates node to connect to the given core node. "+
 			"Example: <ip>, 127.0.0.1. <dns>, subdomain.domain.tld "+
-			"Assumes RPC port 26657 and gRPC port 9090 as default unless otherwise specified.",
+			"Assumes RPC port 26657 and gRPC port 9090 as default unless otherwise specified. "+
+			"Bridge nodes accept a comma-separated list of addresses with the same ports to fail over "+
+			"between for fetching blocks, the first one being preferred. Transactions are always submitted "+
+			"through the first one.",
 	)
 	flags.String(
 		coreRPCFlag,
@@ -34,6 +48,40 @@ func Flags() *flag.FlagSet {
 		"9090",
 		"Set a custom gRPC port for the core node connection. The --core.ip flag must also be provided.",
 	)
+	flags.Bool(
+		grpcTLSFlag,
+		false,
+		fmt.Sprintf("Connects to the gRPC endpoint of the core node over TLS. The bearer token authenticating "+
+			"the node, if any, is read from the %s environment variable", EnvGRPCToken),
+	)
+	flags.String(
+		grpcTLSCAFlag,
+		"",
+		"Path to the PEM encoded CA certificates to verify the core node against (default: system CAs)",
+	)
+	flags.String(
+		grpcTLSCertFlag,
+		"",
+		"Path to the PEM encoded client certificate presented to the core node along with "+grpcTLSKeyFlag,
+	)
+	flags.String(
+		grpcTLSKeyFlag,
+		"",
+		"Path to the PEM encoded private key of the client certificate",
+	)
+	flags.String(
+		edsWriteFlag,
+		string(core.WriteSync),
+		fmt.Sprintf("Bridge nodes only. The way the EDSes of new blocks are persisted: '%s' stores them before "+
+			"broadcasting the headers, '%s' broadcasts the headers right away and stores the EDSes in the "+
+			"background, reducing head tracking latency at the cost of durability", core.WriteSync, core.WriteAsync),
+	)
+	flags.Int(
+		edsWriteQueueFlag,
+		defaultEDSWriteQueueSize,
+		fmt.Sprintf("Bridge nodes only. Maximum amount of EDSes queued to be stored with '--%s %s'",
+			edsWriteFlag, core.WriteAsync),
+	)
 	return flags
 }
 
@@ -42,6 +90,34 @@ func ParseFlags(
 	cmd *cobra.Command,
 	cfg *Config,
 ) error {
+	if cmd.Flag(edsWriteFlag).Changed {
+		cfg.EDSWriteStrategy = core.WriteStrategy(cmd.Flag(edsWriteFlag).Value.String())
+		if err := cfg.EDSWriteStrategy.Validate(); err != nil {
+			return fmt.Errorf("cmd: while parsing '%s': %w", edsWriteFlag, err)
+		}
+	}
+	if cmd.Flag(edsWriteQueueFlag).Changed {
+		queueSize, err := cmd.Flags().GetInt(edsWriteQueueFlag)
+		if err != nil {
+			return err
+		}
+		cfg.EDSWriteQueueSize = queueSize
+	}
+
+	tls, err := cmd.Flags().GetBool(grpcTLSFlag)
+	if cmd.Flags().Changed(grpcTLSFlag) && err == nil {
+		cfg.GRPCTLS.Enabled = tls
+	}
+	if ca := cmd.Flag(grpcTLSCAFlag).Value.String(); ca != "" {
+		cfg.GRPCTLS.CAPath = ca
+	}
+	if cert := cmd.Flag(grpcTLSCertFlag).Value.String(); cert != "" {
+		cfg.GRPCTLS.CertPath = cert
+	}
+	if key := cmd.Flag(grpcTLSKeyFlag).Value.String(); key != "" {
+		cfg.GRPCTLS.KeyPath = key
+	}
+
 	coreIP := cmd.Flag(coreFlag).Value.String()
 	if coreIP == "" {
 		if cmd.Flag(coreGRPCFlag).Changed || cmd.Flag(coreRPCFlag).Changed {
@@ -53,8 +129,13 @@ func ParseFlags(
 	rpc := cmd.Flag(coreRPCFlag).Value.String()
 	grpc := cmd.Flag(coreGRPCFlag).Value.String()
 
-	cfg.IP = coreIP
+	ips := strings.Split(coreIP, ",")
+	cfg.IP = strings.TrimSpace(ips[0])
 	cfg.RPCPort = rpc
 	cfg.GRPCPort = grpc
+	cfg.Endpoints = nil
+	for _, ip := range ips[1:] {
+		cfg.Endpoints = append(cfg.Endpoints, Endpoint{IP: strings.TrimSpace(ip), RPCPort: rpc})
+	}
 	return cfg.Validate()
 }
@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"fmt"
 
 	"go.uber.org/fx"
 
@@ -34,9 +35,10 @@ func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option
 	case node.Bridge:
 		return fx.Module("core",
 			baseComponents,
-			fx.Provide(core.NewBlockFetcher),
+			fx.Provide(core.NewFailoverBlockFetcher),
 			fxutil.ProvideAs(core.NewExchange, new(libhead.Exchange[*header.ExtendedHeader])),
-			fx.Invoke(fx.Annotate(
+			fx.Invoke(func(*core.Listener) {}),
+			fx.Provide(fx.Annotate(
 				func(
 					bcast libhead.Broadcaster[*header.ExtendedHeader],
 					fetcher *core.BlockFetcher,
@@ -44,7 +46,8 @@ func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option
 					construct header.ConstructFn,
 					store *eds.Store,
 				) *core.Listener {
-					return core.NewListener(bcast, fetcher, pubsub.Broadcast, construct, store, p2p.BlockTime)
+					return core.NewListener(bcast, fetcher, pubsub.Broadcast, construct, store, p2p.BlockTime,
+						cfg.listenerOptions()...)
 				},
 				fx.OnStart(func(ctx context.Context, listener *core.Listener) error {
 					return listener.Start(ctx)
@@ -54,16 +57,19 @@ func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option
 				}),
 			)),
 			fx.Provide(fx.Annotate(
-				remote,
-				fx.OnStart(func(ctx context.Context, client core.Client) error {
-					return client.Start()
+				failover,
+				fx.OnStart(func(ctx context.Context, failover *core.Failover) error {
+					return failover.Start(ctx)
 				}),
-				fx.OnStop(func(ctx context.Context, client core.Client) error {
-					return client.Stop()
+				fx.OnStop(func(ctx context.Context, failover *core.Failover) error {
+					return failover.Stop(ctx)
 				}),
 			)),
+			fx.Provide(func(failover *core.Failover) node.CoreEndpoint {
+				return failover
+			}),
 		)
 	default:
-		panic("invalid node type")
+		return fx.Error(fmt.Errorf("module/core: invalid node type: %s", tp))
 	}
 }
@@ -5,15 +5,23 @@ import (
 
 	"github.com/celestiaorg/celestia-node/core"
 	"github.com/celestiaorg/celestia-node/header"
-	"github.com/celestiaorg/celestia-node/libs/fxutil"
 )
 
 // WithClient sets custom client for core process
 func WithClient(client core.Client) fx.Option {
-	return fxutil.ReplaceAs(client, new(core.Client))
+	return fx.Replace(core.NewFailover(0, core.Endpoint{Client: client}))
 }
 
 // WithHeaderConstructFn sets custom func that creates extended header
 func WithHeaderConstructFn(construct header.ConstructFn) fx.Option {
 	return fx.Replace(construct)
 }
+
+// WithMetrics enables metrics of the Listener, e.g. the depth of the queue of EDSes to be stored,
+// and of the Failover between Core endpoints.
+func WithMetrics(listener *core.Listener, failover *core.Failover) error {
+	if err := listener.WithMetrics(); err != nil {
+		return err
+	}
+	return failover.WithMetrics()
+}
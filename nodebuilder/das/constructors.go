@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/ipfs/go-datastore"
+	"github.com/libp2p/go-libp2p/core/crypto"
 
 	"github.com/celestiaorg/go-fraud"
 	libhead "github.com/celestiaorg/go-header"
@@ -33,10 +34,46 @@ func (d daserStub) WaitCatchUp(context.Context) error {
 	return errStub
 }
 
+func (d daserStub) Pause(context.Context) error {
+	return errStub
+}
+
+func (d daserStub) Resume(context.Context) error {
+	return errStub
+}
+
+func (d daserStub) Resample(context.Context, uint64, uint64) error {
+	return errStub
+}
+
+func (d daserStub) Attest(context.Context, uint64) (*das.Attestation, error) {
+	return nil, errStub
+}
+
 func newDaserStub() Module {
 	return &daserStub{}
 }
 
+// module combines the DASer with the Attester to implement Module.
+type module struct {
+	*das.DASer
+	*das.Attester
+}
+
+func newModule(
+	daser *das.DASer,
+	da share.Availability,
+	store libhead.Store[*header.ExtendedHeader],
+	key crypto.PrivKey,
+	cfg Config,
+) (Module, error) {
+	attester, err := das.NewAttester(da, store, key, cfg.SampleTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return &module{DASer: daser, Attester: attester}, nil
+}
+
 func newDASer(
 	da share.Availability,
 	hsub libhead.Subscriber[*header.ExtendedHeader],
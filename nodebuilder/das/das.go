@@ -14,14 +14,29 @@ type Module interface {
 	SamplingStats(ctx context.Context) (das.SamplingStats, error)
 	// WaitCatchUp blocks until DASer finishes catching up to the network head.
 	WaitCatchUp(ctx context.Context) error
+	// Pause stops the DASer from sampling further heights until Resume is called.
+	// Already running sampling jobs are finished.
+	Pause(ctx context.Context) error
+	// Resume resumes sampling paused with Pause.
+	Resume(ctx context.Context) error
+	// Resample schedules the inclusive range of heights to be sampled again.
+	Resample(ctx context.Context, from, to uint64) error
+	// Attest samples the data at the given height and returns an attestation about its
+	// availability signed with the node's p2p key. Attestations are rate limited, as each of them
+	// samples the data.
+	Attest(ctx context.Context, height uint64) (*das.Attestation, error)
 }
 
 // API is a wrapper around Module for the RPC.
 // TODO(@distractedm1nd): These structs need to be autogenerated.
 type API struct {
 	Internal struct {
-		SamplingStats func(ctx context.Context) (das.SamplingStats, error) `perm:"read"`
-		WaitCatchUp   func(ctx context.Context) error                      `perm:"read"`
+		SamplingStats func(ctx context.Context) (das.SamplingStats, error)               `perm:"read"`
+		WaitCatchUp   func(ctx context.Context) error                                    `perm:"read"`
+		Pause         func(ctx context.Context) error                                    `perm:"admin"`
+		Resume        func(ctx context.Context) error                                    `perm:"admin"`
+		Resample      func(ctx context.Context, from, to uint64) error                   `perm:"admin"`
+		Attest        func(ctx context.Context, height uint64) (*das.Attestation, error) `perm:"write"`
 	}
 }
 
@@ -32,3 +47,19 @@ func (api *API) SamplingStats(ctx context.Context) (das.SamplingStats, error) {
 func (api *API) WaitCatchUp(ctx context.Context) error {
 	return api.Internal.WaitCatchUp(ctx)
 }
+
+func (api *API) Pause(ctx context.Context) error {
+	return api.Internal.Pause(ctx)
+}
+
+func (api *API) Resume(ctx context.Context) error {
+	return api.Internal.Resume(ctx)
+}
+
+func (api *API) Resample(ctx context.Context, from, to uint64) error {
+	return api.Internal.Resample(ctx, from, to)
+}
+
+func (api *API) Attest(ctx context.Context, height uint64) (*das.Attestation, error) {
+	return api.Internal.Attest(ctx, height)
+}
@@ -36,6 +36,63 @@ func (m *MockModule) EXPECT() *MockModuleMockRecorder {
 	return m.recorder
 }
 
+// Attest mocks base method.
+func (m *MockModule) Attest(arg0 context.Context, arg1 uint64) (*das.Attestation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Attest", arg0, arg1)
+	ret0, _ := ret[0].(*das.Attestation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Attest indicates an expected call of Attest.
+func (mr *MockModuleMockRecorder) Attest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attest", reflect.TypeOf((*MockModule)(nil).Attest), arg0, arg1)
+}
+
+// Pause mocks base method.
+func (m *MockModule) Pause(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pause", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Pause indicates an expected call of Pause.
+func (mr *MockModuleMockRecorder) Pause(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pause", reflect.TypeOf((*MockModule)(nil).Pause), arg0)
+}
+
+// Resample mocks base method.
+func (m *MockModule) Resample(arg0 context.Context, arg1, arg2 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resample", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resample indicates an expected call of Resample.
+func (mr *MockModuleMockRecorder) Resample(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resample", reflect.TypeOf((*MockModule)(nil).Resample), arg0, arg1, arg2)
+}
+
+// Resume mocks base method.
+func (m *MockModule) Resume(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resume", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resume indicates an expected call of Resume.
+func (mr *MockModuleMockRecorder) Resume(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resume", reflect.TypeOf((*MockModule)(nil).Resume), arg0)
+}
+
 // SamplingStats mocks base method.
 func (m *MockModule) SamplingStats(arg0 context.Context) (das.SamplingStats, error) {
 	m.ctrl.T.Helper()
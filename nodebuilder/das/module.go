@@ -2,6 +2,7 @@ package das
 
 import (
 	"context"
+	"fmt"
 
 	"go.uber.org/fx"
 
@@ -49,9 +50,7 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 				}),
 			)),
 			// Module is needed for the RPC handler
-			fx.Provide(func(das *das.DASer) Module {
-				return das
-			}),
+			fx.Provide(newModule),
 		)
 	case node.Bridge:
 		return fx.Module(
@@ -60,6 +59,6 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 			fx.Provide(newDaserStub),
 		)
 	default:
-		panic("invalid node type")
+		return fx.Error(fmt.Errorf("module/das: invalid node type: %s", tp))
 	}
 }
@@ -0,0 +1,46 @@
+package debug
+
+import (
+	"context"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+var _ Module = (*API)(nil)
+
+// Module exposes fault injection into the running node for integration tests and staging
+// environments. It is served only by nodes built with the 'debug' build tag.
+//
+//go:generate mockgen -destination=mocks/api.go -package=mocks . Module
+type Module interface {
+	// DropPeer closes the connections to the given peer, which may connect again afterwards.
+	DropPeer(ctx context.Context, id peer.ID) error
+	// DelayGetter delays every request of the share getter of the node by the given duration. Zero
+	// removes the delay.
+	DelayGetter(ctx context.Context, delay time.Duration) error
+	// FailSubmissions makes the next n transaction submissions of the node fail without reaching
+	// Core. Zero stops failing them.
+	FailSubmissions(ctx context.Context, n uint64) error
+}
+
+// API is a wrapper around Module for the RPC.
+type API struct {
+	Internal struct {
+		DropPeer        func(ctx context.Context, id peer.ID) error          `perm:"admin"`
+		DelayGetter     func(ctx context.Context, delay time.Duration) error `perm:"admin"`
+		FailSubmissions func(ctx context.Context, n uint64) error            `perm:"admin"`
+	}
+}
+
+func (api *API) DropPeer(ctx context.Context, id peer.ID) error {
+	return api.Internal.DropPeer(ctx, id)
+}
+
+func (api *API) DelayGetter(ctx context.Context, delay time.Duration) error {
+	return api.Internal.DelayGetter(ctx, delay)
+}
+
+func (api *API) FailSubmissions(ctx context.Context, n uint64) error {
+	return api.Internal.FailSubmissions(ctx, n)
+}
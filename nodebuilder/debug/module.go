@@ -0,0 +1,24 @@
+//go:build debug
+
+package debug
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// ConstructModule collects the debug module, served on the RPC of the nodes built with the 'debug'
+// build tag.
+func ConstructModule() fx.Option {
+	return fx.Options(
+		fx.Module("debug",
+			fx.Provide(newModule),
+		),
+		// the getter is decorated for every module using it, so the decoration can not be scoped to
+		// the debug module
+		fx.Decorate(func(getter share.Getter) share.Getter {
+			return newDelayedGetter(getter)
+		}),
+	)
+}
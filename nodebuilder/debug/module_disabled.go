@@ -0,0 +1,12 @@
+//go:build !debug
+
+package debug
+
+import (
+	"go.uber.org/fx"
+)
+
+// ConstructModule leaves the debug module out of the nodes built without the 'debug' build tag.
+func ConstructModule() fx.Option {
+	return fx.Options()
+}
@@ -0,0 +1,128 @@
+//go:build debug
+
+package debug
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	logging "github.com/ipfs/go-log/v2"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/state"
+)
+
+var log = logging.Logger("module/debug")
+
+var errNoState = errors.New("debug: the node does not submit transactions")
+
+// params are the components of the node the faults are injected into.
+type params struct {
+	fx.In
+
+	Host   p2p.HostBase
+	Getter share.Getter
+	State  *state.CoreAccessor `optional:"true"`
+}
+
+type module struct {
+	host   p2p.HostBase
+	getter *delayedGetter
+	state  *state.CoreAccessor
+}
+
+func newModule(p params) (Module, error) {
+	getter, ok := p.Getter.(*delayedGetter)
+	if !ok {
+		return nil, fmt.Errorf("debug: share getter is not delayable, got %T", p.Getter)
+	}
+	return &module{
+		host:   p.Host,
+		getter: getter,
+		state:  p.State,
+	}, nil
+}
+
+func (m *module) DropPeer(_ context.Context, id peer.ID) error {
+	log.Warnw("dropping peer", "peer", id)
+	return m.host.Network().ClosePeer(id)
+}
+
+func (m *module) DelayGetter(_ context.Context, delay time.Duration) error {
+	if delay < 0 {
+		return fmt.Errorf("debug: delay must not be negative, got %s", delay)
+	}
+	log.Warnw("delaying share getter", "delay", delay)
+	m.getter.delay.Store(int64(delay))
+	return nil
+}
+
+func (m *module) FailSubmissions(_ context.Context, n uint64) error {
+	if m.state == nil {
+		return errNoState
+	}
+	log.Warnw("failing submissions", "amount", n)
+	m.state.FailNextSubmissions(n)
+	return nil
+}
+
+var _ share.Getter = (*delayedGetter)(nil)
+
+// delayedGetter delays the requests of the wrapped share.Getter.
+type delayedGetter struct {
+	getter share.Getter
+	delay  atomic.Int64
+}
+
+func newDelayedGetter(getter share.Getter) *delayedGetter {
+	return &delayedGetter{getter: getter}
+}
+
+func (dg *delayedGetter) GetShare(ctx context.Context, root *share.Root, row, col int) (share.Share, error) {
+	if err := dg.wait(ctx); err != nil {
+		return nil, err
+	}
+	return dg.getter.GetShare(ctx, root, row, col)
+}
+
+func (dg *delayedGetter) GetEDS(ctx context.Context, root *share.Root) (*rsmt2d.ExtendedDataSquare, error) {
+	if err := dg.wait(ctx); err != nil {
+		return nil, err
+	}
+	return dg.getter.GetEDS(ctx, root)
+}
+
+func (dg *delayedGetter) GetSharesByNamespace(
+	ctx context.Context,
+	root *share.Root,
+	namespace share.Namespace,
+) (share.NamespacedShares, error) {
+	if err := dg.wait(ctx); err != nil {
+		return nil, err
+	}
+	return dg.getter.GetSharesByNamespace(ctx, root, namespace)
+}
+
+func (dg *delayedGetter) wait(ctx context.Context) error {
+	delay := time.Duration(dg.delay.Load())
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,298 @@
+package nodebuilder
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/celestiaorg/celestia-node/libs/ntp"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+)
+
+const (
+	// doctorTimeout bounds the time given to each network check of the doctor.
+	doctorTimeout = 10 * time.Second
+	// doctorNTPServer is the NTP server the clock is checked against, if none is configured.
+	doctorNTPServer = "pool.ntp.org"
+	// diskProbeSize is the amount of data written to measure the write speed of the disk.
+	diskProbeSize = 32 << 20
+	// minDiskSpeed is the write speed below which the disk is reported as slow, in bytes per second.
+	minDiskSpeed = 20 << 20
+	// minOpenFiles is the limit of open files below which connections and the stores may fail.
+	minOpenFiles = 8192
+)
+
+// minDiskSpace is the free disk space of the store below which the node would run out of it soon.
+var minDiskSpace = map[node.Type]uint64{
+	node.Light:  5 << 30,
+	node.Full:   500 << 30,
+	node.Bridge: 500 << 30,
+}
+
+// Severity is the severity of a Finding of the doctor.
+type Severity string
+
+const (
+	SeverityOK      Severity = "ok"
+	SeverityWarning Severity = "warning"
+	SeverityFailure Severity = "failure"
+)
+
+// Finding is the result of a check of the environment the node is about to run in.
+type Finding struct {
+	Check    string   `json:"check"`
+	Severity Severity `json:"severity"`
+	Detail   string   `json:"detail"`
+	// Advice suggests how to fix the problem found, if any.
+	Advice string `json:"advice,omitempty"`
+}
+
+// Doctor checks the environment a Node of the given type is about to run in with the given config
+// and Store path: the connectivity to the bootstrappers and the core node, the local clock, the
+// disk, the open-file limits and the availability of the configured ports. The Node must not be
+// running, as its ports would be reported as taken.
+func Doctor(ctx context.Context, tp node.Type, network p2p.Network, cfg *Config, storePath string) []Finding {
+	tp = tp.Base()
+	var findings []Finding
+	findings = append(findings, checkBootstrappers(ctx, tp, network))
+	findings = append(findings, checkCore(ctx, tp, cfg))
+	findings = append(findings, checkClock(ctx, cfg))
+	findings = append(findings, checkDiskSpace(tp, storePath))
+	findings = append(findings, checkDiskSpeed(storePath))
+	findings = append(findings, checkOpenFiles())
+	findings = append(findings, checkPorts(cfg)...)
+	return findings
+}
+
+func checkBootstrappers(ctx context.Context, tp node.Type, network p2p.Network) Finding {
+	const check = "bootstrappers"
+	ctx, cancel := context.WithTimeout(ctx, doctorTimeout)
+	defer cancel()
+
+	unreachable, total, err := p2p.UnreachableBootstrappers(ctx, network)
+	switch {
+	case err != nil:
+		return Finding{Check: check, Severity: SeverityFailure, Detail: err.Error()}
+	case total == 0 && tp == node.Bridge:
+		return Finding{Check: check, Severity: SeverityOK,
+			Detail: fmt.Sprintf("network %s has no bootstrappers", network)}
+	case total == 0:
+		return Finding{Check: check, Severity: SeverityWarning,
+			Detail: fmt.Sprintf("network %s has no bootstrappers", network),
+			Advice: "configure trusted peers to sync headers from"}
+	case len(unreachable) == total:
+		return Finding{Check: check, Severity: SeverityFailure,
+			Detail: fmt.Sprintf("none of the %d bootstrappers is reachable", total),
+			Advice: "check the internet connection and that outgoing TCP connections are allowed by the firewall"}
+	case len(unreachable) > 0:
+		ids := make([]string, 0, len(unreachable))
+		for _, b := range unreachable {
+			ids = append(ids, b.ID.String())
+		}
+		return Finding{Check: check, Severity: SeverityWarning,
+			Detail: fmt.Sprintf("%d of %d bootstrappers are unreachable: %s", len(unreachable), total,
+				strings.Join(ids, ", "))}
+	default:
+		return Finding{Check: check, Severity: SeverityOK,
+			Detail: fmt.Sprintf("all %d bootstrappers are reachable", total)}
+	}
+}
+
+func checkCore(ctx context.Context, tp node.Type, cfg *Config) Finding {
+	const check = "core"
+	if !coreConfigured(cfg) {
+		if tp == node.Bridge {
+			return Finding{Check: check, Severity: SeverityFailure, Detail: "core endpoint is not configured",
+				Advice: "set the core node with --core.ip, --core.rpc.port and --core.grpc.port"}
+		}
+		return Finding{Check: check, Severity: SeverityWarning,
+			Detail: "core endpoint is not configured, state access and blob submission will not work",
+			Advice: "set the core node with --core.ip, --core.rpc.port and --core.grpc.port, if needed"}
+	}
+
+	var errs []error
+	for _, port := range []string{cfg.Core.RPCPort, cfg.Core.GRPCPort} {
+		addr := net.JoinHostPort(cfg.Core.IP, port)
+		dialCtx, cancel := context.WithTimeout(ctx, doctorTimeout)
+		conn, err := (&net.Dialer{}).DialContext(dialCtx, "tcp", addr)
+		cancel()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		conn.Close()
+	}
+	if len(errs) != 0 {
+		return Finding{Check: check, Severity: SeverityFailure, Detail: errors.Join(errs...).Error(),
+			Advice: "check the core node is running and reachable at the configured address and ports"}
+	}
+	return Finding{Check: check, Severity: SeverityOK,
+		Detail: fmt.Sprintf("core node is reachable at %s", cfg.Core.IP)}
+}
+
+func checkClock(ctx context.Context, cfg *Config) Finding {
+	const check = "clock"
+	server := cfg.Clock.NTPServer
+	if server == "" {
+		server = doctorNTPServer
+	}
+	ctx, cancel := context.WithTimeout(ctx, doctorTimeout)
+	defer cancel()
+
+	drift, err := ntp.Drift(ctx, server)
+	if err != nil {
+		return Finding{Check: check, Severity: SeverityWarning,
+			Detail: fmt.Sprintf("could not query NTP server %s: %s", server, err),
+			Advice: "allow outgoing UDP traffic on port 123 or configure a reachable NTP server"}
+	}
+
+	detail := fmt.Sprintf("local clock drifted by %s from %s", drift, server)
+	const advice = "synchronize the local clock, e.g. by enabling an NTP daemon"
+	switch {
+	case cfg.Clock.MaxDrift > 0 && drift.Abs() > cfg.Clock.MaxDrift:
+		return Finding{Check: check, Severity: SeverityFailure, Detail: detail, Advice: advice}
+	case cfg.Clock.WarnDrift > 0 && drift.Abs() > cfg.Clock.WarnDrift:
+		return Finding{Check: check, Severity: SeverityWarning, Detail: detail, Advice: advice}
+	default:
+		return Finding{Check: check, Severity: SeverityOK, Detail: detail}
+	}
+}
+
+func checkDiskSpace(tp node.Type, storePath string) Finding {
+	const check = "disk space"
+	free, err := freeDiskSpace(existingParent(storePath))
+	if err != nil {
+		return Finding{Check: check, Severity: SeverityWarning, Detail: err.Error()}
+	}
+
+	detail := fmt.Sprintf("%.1f GiB free at %s", float64(free)/(1<<30), storePath)
+	if minSpace := minDiskSpace[tp]; free < minSpace {
+		return Finding{Check: check, Severity: SeverityWarning, Detail: detail,
+			Advice: fmt.Sprintf("%s nodes are recommended at least %d GiB of free space", tp, minSpace>>30)}
+	}
+	return Finding{Check: check, Severity: SeverityOK, Detail: detail}
+}
+
+func checkDiskSpeed(storePath string) Finding {
+	const check = "disk speed"
+	speed, err := diskWriteSpeed(existingParent(storePath))
+	if err != nil {
+		return Finding{Check: check, Severity: SeverityWarning, Detail: err.Error()}
+	}
+
+	detail := fmt.Sprintf("%.1f MiB/s synced writes", speed/(1<<20))
+	if speed < minDiskSpeed {
+		return Finding{Check: check, Severity: SeverityWarning, Detail: detail,
+			Advice: "the store on a slow disk may not keep up with the chain, prefer an SSD"}
+	}
+	return Finding{Check: check, Severity: SeverityOK, Detail: detail}
+}
+
+func checkOpenFiles() Finding {
+	const check = "open files"
+	limit, err := openFilesLimit()
+	if err != nil {
+		return Finding{Check: check, Severity: SeverityWarning, Detail: err.Error()}
+	}
+
+	detail := fmt.Sprintf("open files limit is %d", limit)
+	if limit < minOpenFiles {
+		return Finding{Check: check, Severity: SeverityWarning, Detail: detail,
+			Advice: fmt.Sprintf("raise the limit to at least %d, e.g. with 'ulimit -n %d'", minOpenFiles, minOpenFiles)}
+	}
+	return Finding{Check: check, Severity: SeverityOK, Detail: detail}
+}
+
+func checkPorts(cfg *Config) []Finding {
+	const check = "ports"
+	addrs := map[string]string{
+		"rpc": net.JoinHostPort(cfg.RPC.Address, cfg.RPC.Port),
+	}
+	if cfg.Gateway.Enabled {
+		addrs["gateway"] = net.JoinHostPort(cfg.Gateway.Address, cfg.Gateway.Port)
+	}
+
+	errs := make(map[string]error)
+	for name, addr := range addrs {
+		lis, err := net.Listen("tcp", addr)
+		if err != nil {
+			errs[name+" "+addr] = err
+			continue
+		}
+		lis.Close()
+	}
+	for addr, err := range cfg.P2P.UnboundListenAddresses() {
+		errs["p2p "+addr] = err
+	}
+
+	if len(errs) == 0 {
+		return []Finding{{Check: check, Severity: SeverityOK, Detail: "all the configured ports are available"}}
+	}
+	findings := make([]Finding, 0, len(errs))
+	for addr, err := range errs {
+		findings = append(findings, Finding{Check: check, Severity: SeverityFailure,
+			Detail: fmt.Sprintf("%s can not be bound: %s", addr, err),
+			Advice: "stop the process using the port or configure another one"})
+	}
+	sort.Slice(findings, func(i, j int) bool {
+		return findings[i].Detail < findings[j].Detail
+	})
+	return findings
+}
+
+func coreConfigured(cfg *Config) bool {
+	for _, port := range []string{cfg.Core.RPCPort, cfg.Core.GRPCPort} {
+		if port == "" || port == "0" {
+			return false
+		}
+	}
+	return true
+}
+
+// diskWriteSpeed measures the speed of synced writes to the directory, in bytes per second.
+func diskWriteSpeed(dir string) (float64, error) {
+	f, err := os.CreateTemp(dir, ".doctor-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	chunk := make([]byte, 1<<20)
+	if _, err := rand.Read(chunk); err != nil {
+		return 0, err
+	}
+	start := time.Now()
+	for written := 0; written < diskProbeSize; written += len(chunk) {
+		if _, err := f.Write(chunk); err != nil {
+			return 0, err
+		}
+	}
+	if err := f.Sync(); err != nil {
+		return 0, err
+	}
+	return float64(diskProbeSize) / time.Since(start).Seconds(), nil
+}
+
+// existingParent returns the path or its closest existing parent, as the store may not be
+// initialized yet.
+func existingParent(path string) string {
+	for {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return path
+		}
+		path = parent
+	}
+}
@@ -0,0 +1,65 @@
+package nodebuilder
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+func TestDoctor_Core(t *testing.T) {
+	ctx := context.Background()
+	cfg, err := DefaultConfig(node.Bridge)
+	require.NoError(t, err)
+	assert.Equal(t, SeverityFailure, checkCore(ctx, node.Bridge, cfg).Severity)
+	assert.Equal(t, SeverityWarning, checkCore(ctx, node.Light, cfg).Severity)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+	_, port, err := net.SplitHostPort(lis.Addr().String())
+	require.NoError(t, err)
+	cfg.Core.IP, cfg.Core.RPCPort, cfg.Core.GRPCPort = "127.0.0.1", port, port
+	assert.Equal(t, SeverityOK, checkCore(ctx, node.Bridge, cfg).Severity)
+
+	lis.Close()
+	assert.Equal(t, SeverityFailure, checkCore(ctx, node.Bridge, cfg).Severity)
+}
+
+func TestDoctor_Ports(t *testing.T) {
+	cfg, err := DefaultConfig(node.Light)
+	require.NoError(t, err)
+	cfg.P2P.ListenAddresses = []string{"/ip4/127.0.0.1/tcp/0", "/ip4/127.0.0.1/udp/0/quic-v1"}
+	cfg.P2P.Transports.TCP.ListenAddresses = nil
+	cfg.P2P.Transports.QUIC.ListenAddresses = nil
+	cfg.P2P.Transports.WebTransport.ListenAddresses = nil
+	cfg.RPC.Address, cfg.RPC.Port = "127.0.0.1", "0"
+
+	findings := checkPorts(cfg)
+	require.Len(t, findings, 1)
+	assert.Equal(t, SeverityOK, findings[0].Severity)
+
+	// the port of the rpc is taken
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+	_, cfg.RPC.Port, err = net.SplitHostPort(lis.Addr().String())
+	require.NoError(t, err)
+
+	findings = checkPorts(cfg)
+	require.Len(t, findings, 1)
+	assert.Equal(t, SeverityFailure, findings[0].Severity)
+	assert.Contains(t, findings[0].Detail, "rpc")
+}
+
+func TestDoctor_Disk(t *testing.T) {
+	// the store may not be initialized yet
+	path := t.TempDir() + "/store"
+	assert.NotEqual(t, SeverityFailure, checkDiskSpace(node.Light, path).Severity)
+	assert.NotEqual(t, SeverityFailure, checkDiskSpeed(path).Severity)
+	assert.NotEqual(t, SeverityFailure, checkOpenFiles().Severity)
+}
@@ -0,0 +1,25 @@
+//go:build darwin || freebsd || linux
+
+package nodebuilder
+
+import (
+	"syscall"
+)
+
+// freeDiskSpace reports the space available to unprivileged users on the file system of the path.
+func freeDiskSpace(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return stat.Bavail * uint64(stat.Bsize), nil
+}
+
+// openFilesLimit reports the soft limit of open files of the process.
+func openFilesLimit() (uint64, error) {
+	var limit syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &limit); err != nil {
+		return 0, err
+	}
+	return limit.Cur, nil
+}
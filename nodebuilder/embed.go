@@ -0,0 +1,86 @@
+package nodebuilder
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/celestia-node/libs/fxutil"
+	"github.com/celestiaorg/celestia-node/nodebuilder/blob"
+	"github.com/celestiaorg/celestia-node/nodebuilder/das"
+	"github.com/celestiaorg/celestia-node/nodebuilder/fraud"
+	"github.com/celestiaorg/celestia-node/nodebuilder/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+	"github.com/celestiaorg/celestia-node/nodebuilder/share"
+	"github.com/celestiaorg/celestia-node/nodebuilder/state"
+	libshare "github.com/celestiaorg/celestia-node/share"
+)
+
+// NewWithOptions assembles a new Node of the given type over the Store with the given config, to be
+// embedded in Go applications. The Node joins p2p.DefaultNetwork, unless another one is given with
+// WithNetwork.
+//
+// The options customize the Node: the Without options leave modules out of it, and any component
+// it is constructed with can be substituted with fx.Replace or fx.Decorate, or with helpers like
+// WithShareGetter. The constructed modules are accessible with the accessors of the Node.
+func NewWithOptions(tp node.Type, store Store, cfg *Config, options ...fx.Option) (*Node, error) {
+	network := p2p.DefaultNetwork
+	for _, opt := range options {
+		if n, ok := opt.(networkOption); ok {
+			network = n.network
+		}
+	}
+	return NewWithConfig(tp, network, store, cfg, options...)
+}
+
+// networkOption replaces the Network of the Node, while letting NewWithOptions know the Network
+// the Node is constructed for.
+type networkOption struct {
+	fx.Option
+	network p2p.Network
+}
+
+// WithShareGetter substitutes the share.Getter the Node retrieves shares with, e.g. to serve them
+// from an application's own storage.
+func WithShareGetter(getter libshare.Getter) fx.Option {
+	return fxutil.ReplaceAs(getter, new(libshare.Getter))
+}
+
+// Header returns the header module of the Node.
+func (n *Node) Header() header.Module {
+	return n.HeaderServ
+}
+
+// Share returns the share module of the Node, if it is constructed with one.
+func (n *Node) Share() share.Module {
+	return n.ShareServ
+}
+
+// State returns the state module of the Node, unless it is constructed with WithoutState.
+func (n *Node) State() state.Module {
+	return n.StateServ
+}
+
+// Blob returns the blob module of the Node, unless it is constructed with WithoutBlob.
+func (n *Node) Blob() blob.Module {
+	return n.BlobServ
+}
+
+// DAS returns the DAS module of the Node, unless it is constructed with WithoutDAS.
+func (n *Node) DAS() das.Module {
+	return n.DASer
+}
+
+// Fraud returns the fraud module of the Node, if it is constructed with one.
+func (n *Node) Fraud() fraud.Module {
+	return n.FraudServ
+}
+
+// P2P returns the p2p module of the Node, if it is constructed with one.
+func (n *Node) P2P() p2p.Module {
+	return n.P2PServ
+}
+
+// Admin returns the node module of the Node.
+func (n *Node) Admin() node.Module {
+	return n.AdminServ
+}
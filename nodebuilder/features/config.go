@@ -0,0 +1,35 @@
+package features
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Config combines all configuration fields for gating experimental features.
+type Config struct {
+	// Enabled are the names of the experimental features to enable, e.g. experimental RPC methods
+	// and modules, which are disabled by default.
+	Enabled []string
+}
+
+// DefaultConfig returns default configuration for gating experimental features.
+func DefaultConfig() Config {
+	return Config{
+		Enabled: []string{},
+	}
+}
+
+// Validate performs basic validation of the config.
+func (cfg *Config) Validate() error {
+	seen := make(map[string]bool, len(cfg.Enabled))
+	for _, name := range cfg.Enabled {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("module/features: feature name must not be empty")
+		}
+		if seen[name] {
+			return fmt.Errorf("module/features: feature %q is enabled more than once", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package features
+
+import "sort"
+
+// BlobBackfill gates the blob backfill methods of the RPC, e.g. blob.Backfill.
+const BlobBackfill = "blob-backfill"
+
+// Set is the set of experimental features enabled on the node.
+type Set map[string]struct{}
+
+// NewSet returns the Set of the given features.
+func NewSet(names ...string) Set {
+	set := make(Set, len(names))
+	for _, name := range names {
+		set[name] = struct{}{}
+	}
+	return set
+}
+
+// Enabled reports whether the given feature is enabled.
+func (s Set) Enabled(name string) bool {
+	_, ok := s[name]
+	return ok
+}
+
+// List returns the names of the enabled features in lexicographic order.
+func (s Set) List() []string {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
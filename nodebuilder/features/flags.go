@@ -0,0 +1,32 @@
+package features
+
+import (
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+)
+
+const enableFlag = "features.enable"
+
+// Flags gives a set of features flags.
+func Flags() *flag.FlagSet {
+	flags := &flag.FlagSet{}
+
+	flags.StringSlice(
+		enableFlag,
+		nil,
+		"Comma-separated names of experimental features to enable, e.g. "+BlobBackfill+
+			" serving the blob backfill RPC methods",
+	)
+
+	return flags
+}
+
+// ParseFlags parses features flags from the given cmd and saves them to the passed config.
+func ParseFlags(cmd *cobra.Command, cfg *Config) {
+	if cmd.Flags().Changed(enableFlag) {
+		enabled, err := cmd.Flags().GetStringSlice(enableFlag)
+		if err == nil {
+			cfg.Enabled = enabled
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package features
+
+import (
+	"go.uber.org/fx"
+)
+
+// ConstructModule provides the Set of the features enabled in the config.
+func ConstructModule(cfg *Config) fx.Option {
+	// sanitize config values before constructing module
+	cfgErr := cfg.Validate()
+
+	return fx.Module(
+		"features",
+		fx.Error(cfgErr),
+		fx.Supply(NewSet(cfg.Enabled...)),
+	)
+}
@@ -23,6 +23,7 @@ func newFraudService(syncerEnabled bool) func(
 	libhead.Store[*header.ExtendedHeader],
 	datastore.Batching,
 	p2p.Network,
+	p2p.Config,
 ) (Module, fraud.Service, error) {
 	return func(
 		lc fx.Lifecycle,
@@ -31,7 +32,14 @@ func newFraudService(syncerEnabled bool) func(
 		hstore libhead.Store[*header.ExtendedHeader],
 		ds datastore.Batching,
 		network p2p.Network,
+		p2pCfg p2p.Config,
 	) (Module, fraud.Service, error) {
+		if p2pCfg.TopicDisabled(p2p.FraudTopic) {
+			log.Warn("fraud topics disabled, fraud proofs are neither received from nor broadcasted to peers")
+			gossipless := newGossiplessService()
+			return &Service{Service: gossipless}, gossipless, nil
+		}
+
 		getter := func(ctx context.Context, height uint64) (libhead.Header, error) {
 			return hstore.GetByHeight(ctx, height)
 		}
@@ -0,0 +1,108 @@
+package fraud
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ipfs/go-datastore"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+
+	"github.com/celestiaorg/go-fraud"
+)
+
+var _ fraud.Service = (*gossiplessService)(nil)
+
+// gossiplessService stands in for the fraud proof service on nodes out of the fraud topics. It
+// neither receives fraud proofs from nor broadcasts them to peers, but delivers the ones found by
+// the node itself to its local subscribers, so that the node still halts on them.
+type gossiplessService struct {
+	lock   sync.Mutex
+	subs   map[fraud.ProofType]map[*gossiplessSubscription]struct{}
+	proofs map[fraud.ProofType][]fraud.Proof
+}
+
+func newGossiplessService() *gossiplessService {
+	return &gossiplessService{
+		subs:   make(map[fraud.ProofType]map[*gossiplessSubscription]struct{}),
+		proofs: make(map[fraud.ProofType][]fraud.Proof),
+	}
+}
+
+func (s *gossiplessService) Subscribe(proofType fraud.ProofType) (fraud.Subscription, error) {
+	sub := &gossiplessSubscription{
+		// a single pending proof is enough for the subscribers to react on
+		proofs: make(chan fraud.Proof, 1),
+		done:   make(chan struct{}),
+	}
+	sub.cancel = func() {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		delete(s.subs[proofType], sub)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.subs[proofType] == nil {
+		s.subs[proofType] = make(map[*gossiplessSubscription]struct{})
+	}
+	s.subs[proofType][sub] = struct{}{}
+	return sub, nil
+}
+
+func (s *gossiplessService) AddVerifier(fraud.ProofType, fraud.Verifier) error {
+	return nil
+}
+
+// Broadcast delivers the fraud proof found by the node to its local subscribers only.
+func (s *gossiplessService) Broadcast(_ context.Context, p fraud.Proof) error {
+	log.Warnw("not broadcasting fraud proof to peers, as the node is out of the fraud topics",
+		"type", p.Type(), "height", p.Height())
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.proofs[p.Type()] = append(s.proofs[p.Type()], p)
+	for sub := range s.subs[p.Type()] {
+		select {
+		case sub.proofs <- p:
+		default:
+		}
+	}
+	return nil
+}
+
+func (s *gossiplessService) Get(_ context.Context, proofType fraud.ProofType) ([]fraud.Proof, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	proofs := s.proofs[proofType]
+	if len(proofs) == 0 {
+		return nil, datastore.ErrNotFound
+	}
+	return append([]fraud.Proof(nil), proofs...), nil
+}
+
+// gossiplessSubscription receives the fraud proofs found by the node itself.
+type gossiplessSubscription struct {
+	proofs chan fraud.Proof
+	cancel func()
+
+	once sync.Once
+	done chan struct{}
+}
+
+func (s *gossiplessSubscription) Proof(ctx context.Context) (fraud.Proof, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-s.done:
+		return nil, pubsub.ErrSubscriptionCancelled
+	case p := <-s.proofs:
+		return p, nil
+	}
+}
+
+func (s *gossiplessSubscription) Cancel() {
+	s.once.Do(func() {
+		s.cancel()
+		close(s.done)
+	})
+}
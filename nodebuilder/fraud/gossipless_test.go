@@ -0,0 +1,31 @@
+package fraud
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/go-fraud/fraudtest"
+)
+
+func TestGossiplessServiceDeliversLocalProofs(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	t.Cleanup(cancel)
+
+	serv := newGossiplessService()
+	proof := fraudtest.NewValidProof()
+	sub, err := serv.Subscribe(proof.Type())
+	require.NoError(t, err)
+	defer sub.Cancel()
+
+	require.NoError(t, serv.Broadcast(ctx, proof))
+	received, err := sub.Proof(ctx)
+	require.NoError(t, err)
+	require.Equal(t, proof, received)
+
+	proofs, err := serv.Get(ctx, proof.Type())
+	require.NoError(t, err)
+	require.Len(t, proofs, 1)
+}
@@ -1,6 +1,7 @@
 package fraud
 
 import (
+	"fmt"
 	logging "github.com/ipfs/go-log/v2"
 	"go.uber.org/fx"
 
@@ -29,6 +30,6 @@ func ConstructModule(tp node.Type) fx.Option {
 			fx.Provide(newFraudService(false)),
 		)
 	default:
-		panic("invalid node type")
+		return fx.Error(fmt.Errorf("module/fraud: invalid node type: %s", tp))
 	}
 }
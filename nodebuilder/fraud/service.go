@@ -24,6 +24,7 @@ func (s *Service) Subscribe(ctx context.Context, proofType fraud.ProofType) (<-c
 	proofs := make(chan Proof)
 	go func() {
 		defer close(proofs)
+		defer subscription.Cancel()
 		for {
 			proof, err := subscription.Proof(ctx)
 			if err != nil {
@@ -0,0 +1,110 @@
+package nodebuilder
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// fxTracer records a span for every phase timed by the startupTimer: every function invoked while
+// the node is constructed, and every lifecycle hook executed while the node starts and stops.
+//
+// The fx version in use does not report the constructors it runs, so they get no spans of their
+// own, and the span of an invoke covers the constructors it depends on (see startupTimer).
+//
+// The TracerProvider is set by one of the invoked functions, so spans recorded during
+// construction are buffered and exported with their original timestamps once the fx.App is built.
+type fxTracer struct {
+	lock sync.Mutex
+	// tracer is taken from the global TracerProvider once the fx.App is built.
+	tracer trace.Tracer
+	// pending holds spans waiting for the fx.App to be built.
+	pending []startupPhase
+	// lifecycleCtx carries the span of the lifecycle phase in progress, if any.
+	lifecycleCtx context.Context
+}
+
+// newFxTracer creates an fxTracer recording the phases timed by the given startupTimer.
+func newFxTracer(timer *startupTimer) *fxTracer {
+	t := &fxTracer{tracer: trace.NewNoopTracerProvider().Tracer("")}
+	timer.observe(t.phase)
+	return t
+}
+
+// phase records the span of the given phase, or buffers it until the fx.App is built.
+func (t *fxTracer) phase(p startupPhase) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if p.kind == phaseInvoke {
+		t.pending = append(t.pending, p)
+		return
+	}
+	t.record(t.lifecycleCtx, p)
+}
+
+// constructed exports the spans buffered while the fx.App was being built under a single parent
+// span covering the whole construction.
+func (t *fxTracer) constructed(start time.Time, err error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.tracer = otel.Tracer("node/fx")
+	ctx, span := t.tracer.Start(context.Background(), "construct", trace.WithTimestamp(start))
+	for _, p := range t.pending {
+		t.record(ctx, p)
+	}
+	t.pending = nil
+	endSpan(span, time.Now(), err)
+}
+
+// traceLifecycle wraps the given lifecycle func with a span parenting spans of all the hooks
+// executed by it.
+func (t *fxTracer) traceLifecycle(name string, fn lifecycleFunc) lifecycleFunc {
+	return func(ctx context.Context) error {
+		t.lock.Lock()
+		ctx, span := t.tracer.Start(ctx, name)
+		t.lifecycleCtx = ctx
+		t.lock.Unlock()
+
+		err := fn(ctx)
+
+		t.lock.Lock()
+		t.lifecycleCtx = nil
+		t.lock.Unlock()
+		endSpan(span, time.Now(), err)
+		return err
+	}
+}
+
+func (t *fxTracer) record(ctx context.Context, p startupPhase) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var attrs []attribute.KeyValue
+	if p.module != "" {
+		attrs = append(attrs, attribute.String("module", p.module))
+	}
+	if p.caller != "" {
+		attrs = append(attrs, attribute.String("caller", p.caller))
+	}
+	_, span := t.tracer.Start(ctx, p.kind+" "+p.name,
+		trace.WithTimestamp(p.start),
+		trace.WithAttributes(attrs...),
+	)
+	endSpan(span, p.start.Add(p.duration), p.err)
+}
+
+func endSpan(span trace.Span, at time.Time, err error) {
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		span.SetStatus(codes.Ok, "")
+	}
+	span.End(trace.WithTimestamp(at))
+}
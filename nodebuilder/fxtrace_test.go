@@ -0,0 +1,111 @@
+package nodebuilder
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+func TestFxTracer(t *testing.T) {
+	collector := &traceCollector{}
+	srv := httptest.NewServer(collector)
+	t.Cleanup(srv.Close)
+
+	prev := otel.GetTracerProvider()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prev)
+	})
+
+	nd := TestNode(t, node.Light, WithTraces([]otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(strings.TrimPrefix(srv.URL, "http://")),
+		otlptracehttp.WithInsecure(),
+	}, nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, nd.Start(ctx))
+	// the spans are exported once the node has stopped, including the ones of stopping it
+	require.NoError(t, nd.Stop(ctx))
+
+	parents := make(map[string]string)
+	names := make(map[string]string)
+	for _, span := range collector.spans() {
+		names[string(span.SpanId)] = span.Name
+		parents[span.Name] = string(span.ParentSpanId)
+	}
+	require.Contains(t, parents, "construct")
+	require.Contains(t, parents, "start")
+	require.Contains(t, parents, "stop")
+
+	var invokes, starts, stops int
+	for name, parent := range parents {
+		switch {
+		case strings.HasPrefix(name, phaseInvoke+" "):
+			invokes++
+			require.Equal(t, "construct", names[parent])
+		case strings.HasPrefix(name, phaseOnStart+" "):
+			starts++
+			require.Equal(t, "start", names[parent])
+		case strings.HasPrefix(name, phaseOnStop+" "):
+			stops++
+			require.Equal(t, "stop", names[parent])
+		}
+	}
+	require.NotZero(t, invokes)
+	require.NotZero(t, starts)
+	require.NotZero(t, stops)
+}
+
+// traceCollector is an OTLP HTTP endpoint keeping the spans exported to it.
+type traceCollector struct {
+	lock     sync.Mutex
+	received []*tracepb.Span
+}
+
+func (c *traceCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	req := &coltracepb.ExportTraceServiceRequest{}
+	if err := proto.Unmarshal(body, req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c.lock.Lock()
+	for _, rs := range req.ResourceSpans {
+		for _, ss := range rs.ScopeSpans {
+			c.received = append(c.received, ss.Spans...)
+		}
+	}
+	c.lock.Unlock()
+
+	resp, err := proto.Marshal(&coltracepb.ExportTraceServiceResponse{})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	_, _ = w.Write(resp)
+}
+
+func (c *traceCollector) spans() []*tracepb.Span {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.received
+}
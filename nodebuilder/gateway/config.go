@@ -4,13 +4,22 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/celestiaorg/celestia-node/api/gateway"
 	"github.com/celestiaorg/celestia-node/libs/utils"
 )
 
 type Config struct {
-	Address             string
-	Port                string
-	Enabled             bool
+	Address string
+	Port    string
+	Enabled bool
+	// TLS makes the gateway serve HTTPS, when configured.
+	TLS gateway.TLSConfig
+	// Auth requires API tokens for the submit endpoints, when configured.
+	Auth gateway.AuthConfig
+	// CORS restricts the browser origins allowed to call the gateway.
+	CORS gateway.CORSConfig
+	// RateLimit limits the requests served per client IP and in total.
+	RateLimit           gateway.RateLimitConfig
 	deprecatedEndpoints bool
 }
 
@@ -20,12 +29,44 @@ func DefaultConfig() Config {
 		// do NOT expose the same port as celestia-core by default so that both can run on the same machine
 		Port:    "26659",
 		Enabled: false,
+		CORS:    gateway.DefaultCORSConfig(),
 	}
 }
 
 func (cfg *Config) Validate() error {
-	sanitizedAddress, err := utils.ValidateAddr(cfg.Address)
+	err := cfg.validateAddr()
 	if err != nil {
+		return err
+	}
+	if err = cfg.TLS.Validate(); err != nil {
+		return err
+	}
+	if err = cfg.Auth.Validate(); err != nil {
+		return err
+	}
+	if err = cfg.CORS.Validate(); err != nil {
+		return err
+	}
+	return cfg.RateLimit.Validate()
+}
+
+// validateAddr validates the listen address and port without resolving hostnames. Unix domain socket addresses are kept as is
+// and their port is ignored.
+func (cfg *Config) validateAddr() error {
+	if path, ok := gateway.UnixSocketPath(cfg.Address); ok {
+		if path == "" {
+			return fmt.Errorf("gateway: empty Unix socket path: %s", cfg.Address)
+		}
+		return nil
+	}
+
+	// hostnames are resolved once the gateway starts listening, so the DNS is not required to
+	// validate the config
+	sanitizedAddress, err := utils.SanitizeAddr(cfg.Address)
+	if err != nil {
+		return fmt.Errorf("gateway: invalid address: %w", err)
+	}
+	if err = gateway.ValidateHost(sanitizedAddress); err != nil {
 		return fmt.Errorf("gateway: invalid address: %w", err)
 	}
 	cfg.Address = sanitizedAddress
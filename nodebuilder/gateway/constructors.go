@@ -22,6 +22,24 @@ func Handler(
 	handler.RegisterMiddleware(serv)
 }
 
-func server(cfg *Config) *gateway.Server {
-	return gateway.NewServer(cfg.Address, cfg.Port)
+// HealthHandler registers the health probes of the node on the gateway.
+func HealthHandler(health *gateway.HealthHandler, serv *gateway.Server) {
+	health.RegisterEndpoints(serv)
+}
+
+func server(cfg *Config) (*gateway.Server, error) {
+	serv := gateway.NewServer(cfg.Address, cfg.Port)
+	serv.WithCORS(cfg.CORS)
+	if cfg.TLS.Enabled() {
+		if err := serv.WithTLS(cfg.TLS); err != nil {
+			return nil, err
+		}
+	}
+	if err := serv.SetRateLimit(cfg.RateLimit); err != nil {
+		return nil, err
+	}
+	if cfg.Auth.Enabled() {
+		serv.WithAuth(cfg.Auth)
+	}
+	return serv, nil
 }
@@ -10,6 +10,10 @@ var (
 	addrFlag            = "gateway.addr"
 	portFlag            = "gateway.port"
 	deprecatedEndpoints = "gateway.deprecated-endpoints"
+	tlsCertFlag         = "gateway.tls.cert"
+	tlsKeyFlag          = "gateway.tls.key"
+	tlsClientCAFlag     = "gateway.tls.client-ca"
+	corsOriginsFlag     = "gateway.cors.origins"
 )
 
 // Flags gives a set of hardcoded node/gateway package flags.
@@ -29,13 +33,35 @@ func Flags() *flag.FlagSet {
 	flags.String(
 		addrFlag,
 		"",
-		"Set a custom gateway listen address (default: localhost)",
+		"Set a custom gateway listen address or a Unix domain socket as unix:///path/to/sock "+
+			"(default: localhost)",
 	)
 	flags.String(
 		portFlag,
 		"",
 		"Set a custom gateway port (default: 26659)",
 	)
+	flags.String(
+		tlsCertFlag,
+		"",
+		"Path to the PEM encoded TLS certificate. Serves the gateway over HTTPS along with "+tlsKeyFlag,
+	)
+	flags.String(
+		tlsKeyFlag,
+		"",
+		"Path to the PEM encoded private key of the TLS certificate",
+	)
+	flags.String(
+		tlsClientCAFlag,
+		"",
+		"Path to the PEM encoded CA certificates to verify clients against, requiring clients to "+
+			"present a certificate (mTLS)",
+	)
+	flags.StringSlice(
+		corsOriginsFlag,
+		nil,
+		"Comma-separated list of browser origins allowed to call the gateway (default: any origin)",
+	)
 
 	return flags
 }
@@ -62,4 +88,17 @@ func ParseFlags(cmd *cobra.Command, cfg *Config) {
 	if portVal != "" {
 		cfg.Port = portVal
 	}
+	if cert := cmd.Flag(tlsCertFlag).Value.String(); cert != "" {
+		cfg.TLS.CertPath = cert
+	}
+	if key := cmd.Flag(tlsKeyFlag).Value.String(); key != "" {
+		cfg.TLS.KeyPath = key
+	}
+	if ca := cmd.Flag(tlsClientCAFlag).Value.String(); ca != "" {
+		cfg.TLS.ClientCAPath = ca
+	}
+	origins, err := cmd.Flags().GetStringSlice(corsOriginsFlag)
+	if cmd.Flags().Changed(corsOriginsFlag) && err == nil {
+		cfg.CORS.AllowedOrigins = origins
+	}
 }
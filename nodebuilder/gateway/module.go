@@ -2,12 +2,16 @@ package gateway
 
 import (
 	"context"
+	"fmt"
 
 	logging "github.com/ipfs/go-log/v2"
 	"go.uber.org/fx"
 
 	"github.com/celestiaorg/celestia-node/api/gateway"
+	"github.com/celestiaorg/celestia-node/das"
+	"github.com/celestiaorg/celestia-node/libs/handover"
 	headerServ "github.com/celestiaorg/celestia-node/nodebuilder/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/health"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 	shareServ "github.com/celestiaorg/celestia-node/nodebuilder/share"
 	stateServ "github.com/celestiaorg/celestia-node/nodebuilder/state"
@@ -25,15 +29,23 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 	baseComponents := fx.Options(
 		fx.Supply(cfg),
 		fx.Error(cfgErr),
-		fx.Provide(fx.Annotate(
-			server,
-			fx.OnStart(func(ctx context.Context, server *gateway.Server) error {
-				return server.Start(ctx)
-			}),
-			fx.OnStop(func(ctx context.Context, server *gateway.Server) error {
-				return server.Stop(ctx)
-			}),
-		)),
+		fx.Provide(server),
+		fx.Invoke(func(l listeners) {
+			l.Server.WithListeners(l.Listeners)
+		}),
+		// the server starts once the node is ready, if listening is gated
+		fx.Invoke(func(lc fx.Lifecycle, server *gateway.Server, gate *health.Gate) {
+			lc.Append(fx.Hook{
+				OnStart: func(ctx context.Context) error {
+					return gate.Start(ctx, "gateway", server.Start)
+				},
+				OnStop: func(ctx context.Context) error {
+					gate.Stop()
+					return server.Stop(ctx)
+				},
+			})
+		}),
+		fx.Invoke(HealthHandler),
 	)
 
 	switch tp {
@@ -41,23 +53,42 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 		return fx.Module(
 			"gateway",
 			baseComponents,
-			fx.Invoke(Handler),
+			fx.Invoke(func(s services) {
+				Handler(s.Config, s.State, s.Share, s.Header, s.DASer, s.Server)
+			}),
 		)
 	case node.Bridge:
 		return fx.Module(
 			"gateway",
 			baseComponents,
-			fx.Invoke(func(
-				cfg *Config,
-				state stateServ.Module,
-				share shareServ.Module,
-				header headerServ.Module,
-				serv *gateway.Server,
-			) {
-				Handler(cfg, state, share, header, nil, serv)
+			// bridge nodes do not sample
+			fx.Invoke(func(s services) {
+				Handler(s.Config, s.State, s.Share, s.Header, nil, s.Server)
 			}),
 		)
 	default:
-		panic("invalid node type")
+		return fx.Error(fmt.Errorf("module/gateway: invalid node type: %s", tp))
 	}
 }
+
+// services are the services the gateway serves. The services of the modules that can be left out
+// of the Node are optional, and their endpoints are only registered when they are present.
+type services struct {
+	fx.In
+
+	Config *Config
+	State  stateServ.Module `optional:"true"`
+	Share  shareServ.Module
+	Header headerServ.Module
+	DASer  *das.DASer `optional:"true"`
+	Server *gateway.Server
+}
+
+// listeners are the Listeners the server listens through, supplied to Nodes taking the listeners
+// over from the previous node process.
+type listeners struct {
+	fx.In
+
+	Listeners *handover.Listeners `optional:"true"`
+	Server    *gateway.Server
+}
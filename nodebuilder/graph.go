@@ -0,0 +1,87 @@
+package nodebuilder
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+	"github.com/celestiaorg/celestia-node/nodebuilder/state"
+)
+
+// errGraphBuilt stops the construction of the node once its graph is built.
+var errGraphBuilt = errors.New("graph built")
+
+// Graph is the dependency graph of the node, as visualized by fx in the DOT language of Graphviz.
+// It holds the constructors and the types of the root scope of the node's fx.App, so the
+// constructors provided privately within the modules are left out.
+type Graph struct {
+	DOT fx.DotGraph
+}
+
+// BuildGraph constructs the fx.App of the node without calling any of its constructors or invoked
+// functions and returns its dependency graph. It errors if the dependencies of the node cannot be
+// satisfied.
+//
+// The node is constructed on top of an in-memory Store, so that the graph can be built without
+// initializing and locking a store on disk.
+func BuildGraph(tp node.Type, network p2p.Network, cfg *Config, options ...fx.Option) (*Graph, error) {
+	store := NewMemStore()
+	ks, err := store.Keystore()
+	if err != nil {
+		return nil, err
+	}
+	// the signer of the node is constructed eagerly and requires the key it signs with
+	keyName := cfg.State.KeyringAccName
+	if keyName == "" {
+		keyName = state.DefaultAccountName
+	}
+	_, _, err = ks.Keyring().NewMnemonic(keyName, keyring.English, sdk.GetConfig().GetFullBIP44Path(),
+		keyring.DefaultBIP39Passphrase, hd.Secp256k1)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := append([]fx.Option{ConstructModule(tp, network, cfg, store, withoutModules(options)...)}, options...)
+	err = fx.ValidateApp(fx.NopLogger, fx.Populate(new(Node)), fx.Options(opts...))
+	if err != nil {
+		return nil, err
+	}
+
+	// fx.DotGraph is not provided while validating an fx.App, so the app is constructed for real
+	// instead. The custom logger is the first thing fx constructs, after registering all of the
+	// constructors and before calling any invoked function, so it gets the graph and fails the
+	// construction right away. The logger fx falls back to is silenced, as the failure is expected.
+	graph := new(Graph)
+	app := fx.New(
+		fx.Logger(discardPrinter{}), //nolint:staticcheck
+		fx.WithLogger(func(dot fx.DotGraph) (fxevent.Logger, error) {
+			graph.DOT = dot
+			return nil, errGraphBuilt
+		}),
+		fx.Populate(new(Node)),
+		fx.Options(opts...),
+	)
+	if err = app.Err(); !errors.Is(err, errGraphBuilt) {
+		return nil, fmt.Errorf("building graph: %w", err)
+	}
+	return graph, nil
+}
+
+// WriteDOT writes the Graph in the DOT language of Graphviz.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	_, err := io.WriteString(w, string(g.DOT))
+	return err
+}
+
+// discardPrinter is an fx.Printer discarding everything.
+type discardPrinter struct{}
+
+func (discardPrinter) Printf(string, ...interface{}) {}
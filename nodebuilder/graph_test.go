@@ -0,0 +1,40 @@
+package nodebuilder
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+)
+
+func TestBuildGraph(t *testing.T) {
+	for _, tp := range []node.Type{node.Bridge, node.Full, node.Light} {
+		t.Run(tp.String(), func(t *testing.T) {
+			cfg, err := DefaultConfig(tp)
+			require.NoError(t, err)
+			graph, err := BuildGraph(tp, p2p.Private, cfg)
+			require.NoError(t, err)
+
+			buf := new(bytes.Buffer)
+			require.NoError(t, graph.WriteDOT(buf))
+			assert.Contains(t, buf.String(), "digraph {")
+			assert.Contains(t, buf.String(), `"fx.Lifecycle"`)
+			assert.Contains(t, buf.String(), `"p2p.Bootstrappers"`)
+		})
+	}
+
+	// the constructors are not called while building the graph
+	type missing struct{}
+	cfg, err := DefaultConfig(node.Light)
+	require.NoError(t, err)
+	_, err = BuildGraph(node.Light, p2p.Private, cfg,
+		fx.Provide(func() (missing, error) { panic("constructor called") }),
+		fx.Invoke(func(missing) { panic("invoke called") }),
+	)
+	require.NoError(t, err)
+}
@@ -0,0 +1,361 @@
+package header
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	"golang.org/x/time/rate"
+
+	libhead "github.com/celestiaorg/go-header"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+var (
+	// ErrBackfillDisabled is returned by the backfill methods of a Service without a backfiller.
+	ErrBackfillDisabled = errors.New("header: backfill is disabled")
+
+	backfillKey = datastore.NewKey("header_backfill")
+	// storePrefix is the prefix the header store keeps the headers and their height index under.
+	storePrefix = datastore.NewKey("headers")
+)
+
+// BackfillState is the state of the backfill of historical headers.
+type BackfillState string
+
+const (
+	BackfillIdle    BackfillState = "idle"
+	BackfillRunning BackfillState = "running"
+	BackfillDone    BackfillState = "done"
+	BackfillFailed  BackfillState = "failed"
+)
+
+// BackfillStatus is the progress of the backfill of historical headers.
+type BackfillStatus struct {
+	// TargetHeight is the lowest height the headers are backfilled to.
+	TargetHeight uint64 `json:"target_height"`
+	// TailHeight is the lowest height of the headers in the store. All the headers between it and
+	// the head are stored.
+	TailHeight uint64        `json:"tail_height"`
+	State      BackfillState `json:"state"`
+	// Error is the error the backfill failed with, if any. Failed backfills can be started again.
+	Error string `json:"error,omitempty"`
+}
+
+// backfiller syncs the headers below the tail of the store backwards, e.g. the headers preceding
+// the trusted head the store was initialized from. Every fetched header is verified against the
+// hash linked by the header above it, so the backfilled history is as trusted as the tail. The
+// backfill is resumed after restarts.
+type backfiller struct {
+	ex    libhead.Exchange[*header.ExtendedHeader]
+	store libhead.Store[*header.ExtendedHeader]
+	// ds is the datastore the header store writes into
+	ds      datastore.Batching
+	meta    datastore.Datastore
+	limiter *rate.Limiter
+	cfg     BackfillConfig
+
+	lock   sync.Mutex
+	status BackfillStatus
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	ctx       context.Context
+	cancelAll context.CancelFunc
+}
+
+// newBackfiller constructs a backfiller of the InitStore, so that it is started once the store is
+// initialized.
+func newBackfiller(
+	cfg Config,
+	ex libhead.Exchange[*header.ExtendedHeader],
+	store InitStore,
+	ds datastore.Batching,
+) *backfiller {
+	return &backfiller{
+		ex:      ex,
+		store:   store,
+		ds:      namespace.Wrap(ds, storePrefix),
+		meta:    ds,
+		limiter: rate.NewLimiter(rate.Limit(cfg.Backfill.rate()), int(cfg.Backfill.batchSize())),
+		cfg:     cfg.Backfill,
+		status:  BackfillStatus{State: BackfillIdle},
+	}
+}
+
+// Start resumes the backfill that was running before the node stopped or, if enabled in the
+// config, starts the configured one.
+func (b *backfiller) Start(ctx context.Context) error {
+	b.ctx, b.cancelAll = context.WithCancel(context.Background())
+
+	data, err := b.meta.Get(ctx, backfillKey)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, &b.status); err != nil {
+			return fmt.Errorf("header: unmarshalling backfill: %w", err)
+		}
+	case !errors.Is(err, datastore.ErrNotFound):
+		return fmt.Errorf("header: loading backfill: %w", err)
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	switch {
+	case b.status.State == BackfillRunning:
+		log.Infow("resuming header backfill", "target", b.status.TargetHeight)
+		b.start(b.status.TargetHeight)
+	case b.cfg.Enabled:
+		b.start(b.cfg.targetHeight())
+	}
+	return nil
+}
+
+// Stop stops the running backfill, which is resumed on the next Start.
+func (b *backfiller) Stop(ctx context.Context) error {
+	b.cancelAll()
+
+	b.lock.Lock()
+	done := b.done
+	b.lock.Unlock()
+	if done == nil {
+		return nil
+	}
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Backfill starts syncing the headers backwards down to the given height, zero meaning genesis,
+// replacing the running backfill, if any.
+func (b *backfiller) Backfill(ctx context.Context, height uint64) (*BackfillStatus, error) {
+	if height == 0 {
+		height = 1
+	}
+
+	b.lock.Lock()
+	// another backfill may be started while waiting for the replaced one to stop
+	for b.done != nil {
+		b.cancel()
+		done := b.done
+		b.lock.Unlock()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		b.lock.Lock()
+	}
+	defer b.lock.Unlock()
+
+	b.status = BackfillStatus{
+		TargetHeight: height,
+		TailHeight:   b.status.TailHeight,
+		State:        BackfillRunning,
+	}
+	if err := b.persist(ctx, b.status); err != nil {
+		return nil, err
+	}
+	b.start(height)
+	status := b.status
+	return &status, nil
+}
+
+// Status reports the progress of the backfill.
+func (b *backfiller) Status(ctx context.Context) (*BackfillStatus, error) {
+	b.lock.Lock()
+	status := b.status
+	running := b.done != nil
+	b.lock.Unlock()
+	if running {
+		return &status, nil
+	}
+
+	tail, err := b.tail(ctx)
+	if err != nil {
+		return nil, err
+	}
+	status.TailHeight = uint64(tail.Height())
+	return &status, nil
+}
+
+// start runs the backfill in the background. It must be called with the lock held.
+func (b *backfiller) start(height uint64) {
+	ctx, cancel := context.WithCancel(b.ctx)
+	b.status.TargetHeight = height
+	b.status.State = BackfillRunning
+	b.status.Error = ""
+	b.cancel = cancel
+	b.done = make(chan struct{})
+	go b.run(ctx, height)
+}
+
+func (b *backfiller) run(ctx context.Context, target uint64) {
+	defer func() {
+		b.lock.Lock()
+		close(b.done)
+		b.done = nil
+		b.lock.Unlock()
+	}()
+
+	tail, err := b.tail(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			b.finish(fmt.Errorf("finding the tail of the store: %w", err))
+		}
+		return
+	}
+
+	for {
+		b.lock.Lock()
+		b.status.TailHeight = uint64(tail.Height())
+		status := b.status
+		b.lock.Unlock()
+		if status.TailHeight <= target {
+			b.finish(nil)
+			return
+		}
+		if err := b.persist(ctx, status); err != nil && ctx.Err() == nil {
+			log.Warnw("persisting header backfill progress", "err", err)
+		}
+
+		tail, err = b.fetch(ctx, tail, target)
+		if err != nil {
+			if ctx.Err() != nil {
+				// replaced or stopped
+				return
+			}
+			b.finish(fmt.Errorf("height %d: %w", status.TailHeight-1, err))
+			return
+		}
+	}
+}
+
+// fetch syncs the batch of headers below the given tail, down to the target at most, and returns
+// the new tail.
+func (b *backfiller) fetch(
+	ctx context.Context,
+	tail *header.ExtendedHeader,
+	target uint64,
+) (*header.ExtendedHeader, error) {
+	from, amount := target, uint64(tail.Height())-target
+	if batchSize := b.cfg.batchSize(); amount > batchSize {
+		from, amount = uint64(tail.Height())-batchSize, batchSize
+	}
+	if err := b.limiter.WaitN(ctx, int(amount)); err != nil {
+		return nil, err
+	}
+
+	headers, err := b.ex.GetRangeByHeight(ctx, from, amount)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(headers)) != amount {
+		return nil, fmt.Errorf("requested %d headers, got %d", amount, len(headers))
+	}
+
+	// the headers are verified from the tail down, each against the hash linked by the one above
+	next := tail
+	for i := len(headers) - 1; i >= 0; i-- {
+		h := headers[i]
+		if h.Height() != next.Height()-1 {
+			return nil, fmt.Errorf("expected header at height %d, got %d", next.Height()-1, h.Height())
+		}
+		if !bytes.Equal(h.Hash(), next.LastHeader()) {
+			return nil, fmt.Errorf("header at height %d does not match the hash linked by the header above it",
+				h.Height())
+		}
+		if err := h.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid header at height %d: %w", h.Height(), err)
+		}
+		next = h
+	}
+	return headers[0], b.write(ctx, headers)
+}
+
+// write stores the headers the same way the header store does, so that they are served by it.
+// The store offers no way to add headers below its tail, so the layout of its datastore is relied
+// on, as pinned by TestBackfill_StoreLayout.
+func (b *backfiller) write(ctx context.Context, headers []*header.ExtendedHeader) error {
+	batch, err := b.ds.Batch(ctx)
+	if err != nil {
+		return err
+	}
+	for _, h := range headers {
+		data, err := h.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		if err := batch.Put(ctx, datastore.NewKey(h.Hash().String()), data); err != nil {
+			return err
+		}
+		if err := batch.Put(ctx, datastore.NewKey(strconv.FormatInt(h.Height(), 10)), h.Hash()); err != nil {
+			return err
+		}
+	}
+	return batch.Commit(ctx)
+}
+
+// tail finds the lowest header in the store. The headers between it and the head are all stored,
+// so the lowest stored height is searched for.
+func (b *backfiller) tail(ctx context.Context) (*header.ExtendedHeader, error) {
+	head, err := b.store.Head(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tail := head
+	low, high := uint64(1), uint64(head.Height())
+	for low < high {
+		mid := low + (high-low)/2
+		h, err := b.store.GetByHeight(ctx, mid)
+		switch {
+		case err == nil:
+			tail, high = h, mid
+		case errors.Is(err, libhead.ErrNotFound):
+			low = mid + 1
+		default:
+			return nil, err
+		}
+	}
+	return tail, nil
+}
+
+// finish marks the backfill as done or, if err is given, failed.
+func (b *backfiller) finish(err error) {
+	b.lock.Lock()
+	b.status.State = BackfillDone
+	if err != nil {
+		b.status.State = BackfillFailed
+		b.status.Error = err.Error()
+	}
+	status := b.status
+	b.lock.Unlock()
+
+	if err != nil {
+		log.Errorw("header backfill failed", "tail", status.TailHeight, "err", err)
+	} else {
+		log.Infow("header backfill done", "tail", status.TailHeight)
+	}
+	if err := b.persist(context.Background(), status); err != nil {
+		log.Warnw("persisting header backfill state", "err", err)
+	}
+}
+
+func (b *backfiller) persist(ctx context.Context, status BackfillStatus) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	return b.meta.Put(ctx, backfillKey, data)
+}
@@ -0,0 +1,169 @@
+package header
+
+import (
+	"bytes"
+	"context"
+	"runtime/debug"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	ds_sync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	libhead "github.com/celestiaorg/go-header"
+	"github.com/celestiaorg/go-header/store"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+)
+
+func TestBackfill(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	suite := headertest.NewTestSuite(t, 3)
+	headers := suite.GenExtendedHeaders(100)
+
+	ds := ds_sync.MutexWrap(datastore.NewMapDatastore())
+	hstore, err := store.NewStore[*header.ExtendedHeader](ds)
+	require.NoError(t, err)
+	require.NoError(t, hstore.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, hstore.Stop(ctx))
+	})
+	// the store is initialized from a trusted head in the middle of the chain
+	require.NoError(t, hstore.Init(ctx, headers[59]))
+	require.NoError(t, hstore.Append(ctx, headers[60:]...))
+
+	cfg := Config{Backfill: BackfillConfig{Rate: 1000, BatchSize: 16}}
+	b := newBackfiller(cfg, &rangeExchange{headers: headers}, hstore, ds)
+	require.NoError(t, b.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, b.Stop(ctx))
+	})
+
+	status, err := b.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, BackfillIdle, status.State)
+	assert.EqualValues(t, 60, status.TailHeight)
+
+	_, err = b.Backfill(ctx, 10)
+	require.NoError(t, err)
+	status = waitBackfill(ctx, t, b)
+	assert.Equal(t, BackfillDone, status.State)
+	assert.EqualValues(t, 10, status.TailHeight)
+
+	// the backfilled headers are served by the store
+	h, err := hstore.GetByHeight(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, headers[9].Hash(), h.Hash())
+	_, err = hstore.GetByHeight(ctx, 9)
+	assert.ErrorIs(t, err, libhead.ErrNotFound)
+	rng, err := hstore.GetRangeByHeight(ctx, 10, 70)
+	require.NoError(t, err)
+	assert.Len(t, rng, 60)
+
+	// headers not linked to the stored ones are rejected
+	forged := headertest.NewTestSuite(t, 3).GenExtendedHeaders(9)
+	b.ex = &rangeExchange{headers: forged}
+	_, err = b.Backfill(ctx, 0)
+	require.NoError(t, err)
+	status = waitBackfill(ctx, t, b)
+	assert.Equal(t, BackfillFailed, status.State)
+	assert.EqualValues(t, 10, status.TailHeight)
+
+	b.ex = &rangeExchange{headers: headers}
+	_, err = b.Backfill(ctx, 0)
+	require.NoError(t, err)
+	status = waitBackfill(ctx, t, b)
+	assert.Equal(t, BackfillDone, status.State)
+	assert.EqualValues(t, 1, status.TailHeight)
+
+	h, err = hstore.GetByHeight(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, headers[0].Hash(), h.Hash())
+}
+
+// TestBackfill_StoreLayout pins the version of go-header the backfiller writes the datastore
+// layout of, as the layout is private to the header store and may change with any release.
+func TestBackfill_StoreLayout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	info, ok := debug.ReadBuildInfo()
+	require.True(t, ok)
+	var version string
+	for _, dep := range info.Deps {
+		if dep.Path == "github.com/celestiaorg/go-header" {
+			version = dep.Version
+			if dep.Replace != nil {
+				version = dep.Replace.Version
+			}
+		}
+	}
+	require.Equal(t, "v0.2.12", version,
+		"check backfiller.write still matches the datastore layout of the header store and update the version")
+
+	suite := headertest.NewTestSuite(t, 3)
+	headers := suite.GenExtendedHeaders(10)
+	ds := ds_sync.MutexWrap(datastore.NewMapDatastore())
+	hstore, err := store.NewStore[*header.ExtendedHeader](ds)
+	require.NoError(t, err)
+	require.NoError(t, hstore.Init(ctx, headers[len(headers)-1]))
+
+	// the headers written before the store starts are served by it, by height and by hash
+	b := newBackfiller(Config{}, nil, hstore, ds)
+	require.NoError(t, b.write(ctx, headers[:len(headers)-1]))
+	require.NoError(t, hstore.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, hstore.Stop(ctx))
+	})
+	for _, h := range headers {
+		got, err := hstore.GetByHeight(ctx, uint64(h.Height()))
+		require.NoError(t, err)
+		assert.Equal(t, h.Hash(), got.Hash())
+		got, err = hstore.Get(ctx, h.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, h.Height(), got.Height())
+	}
+}
+
+func waitBackfill(ctx context.Context, t *testing.T, b *backfiller) *BackfillStatus {
+	for {
+		status, err := b.Status(ctx)
+		require.NoError(t, err)
+		if status.State != BackfillRunning {
+			return status
+		}
+
+		select {
+		case <-ctx.Done():
+			t.Fatal(ctx.Err())
+		case <-time.After(time.Millisecond * 10):
+		}
+	}
+}
+
+type rangeExchange struct {
+	libhead.Exchange[*header.ExtendedHeader]
+
+	headers []*header.ExtendedHeader
+}
+
+func (e *rangeExchange) GetRangeByHeight(
+	_ context.Context,
+	from, amount uint64,
+) ([]*header.ExtendedHeader, error) {
+	return e.headers[from-1 : from-1+amount], nil
+}
+
+func (e *rangeExchange) Get(_ context.Context, hash libhead.Hash) (*header.ExtendedHeader, error) {
+	for _, h := range e.headers {
+		if bytes.Equal(h.Hash(), hash) {
+			return h, nil
+		}
+	}
+	return nil, libhead.ErrNotFound
+}
@@ -0,0 +1,123 @@
+package header
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/ipfs/go-datastore"
+	"go.uber.org/fx"
+
+	libfraud "github.com/celestiaorg/go-fraud"
+	libhead "github.com/celestiaorg/go-header"
+
+	"github.com/celestiaorg/celestia-node/das"
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/pruner"
+	"github.com/celestiaorg/celestia-node/share/availability/light"
+	"github.com/celestiaorg/celestia-node/share/eds/byzantine"
+)
+
+// Confidence tells whether the data committed to by the header at a height is safe to rely on,
+// as seen by the node.
+type Confidence struct {
+	Height uint64 `json:"height"`
+	// Synced reports whether the header is in the local store, i.e. it has been verified against
+	// the chain. Headers are final once committed, so a synced header is never reverted.
+	Synced bool `json:"synced"`
+	// Sampled reports whether the data of the height has been verified available by the node.
+	// Bridge nodes do not sample, as they store the data of every block they sync.
+	Sampled bool `json:"sampled"`
+	// Pruned reports whether the data of the height has been pruned by the node, after it was
+	// sampled, so that it is neither reported as sampled nor served by the node anymore.
+	Pruned bool `json:"pruned,omitempty"`
+	// Samples is the amount of shares successfully sampled, which is the whole square for full
+	// nodes and a random subset of it for light nodes.
+	Samples int `json:"samples"`
+	// SampleFailures is the amount of failed attempts to sample the height, which is retried.
+	SampleFailures int `json:"sample_failures,omitempty"`
+	// Probability is the probability of the data being available given the samples taken, which
+	// is 1 for full nodes reconstructing the whole square.
+	Probability float64 `json:"probability"`
+	// FraudProofs is the amount of fraud proofs received for the height.
+	FraudProofs int `json:"fraud_proofs"`
+	// Safe is the single answer to whether the data can be relied on: the header is synced, its
+	// data is sampled or pruned after sampling, unless the node is a bridge node, and no fraud is
+	// proven for it.
+	Safe bool `json:"safe"`
+}
+
+// confidenceSource are the components the Confidence of a height is collected from. Except for
+// the header store, they are missing in some node types or modes, e.g. there is no DASer in
+// bridge nodes.
+type confidenceSource struct {
+	fx.In
+
+	Store  libhead.Store[*header.ExtendedHeader]
+	DASer  *das.DASer               `optional:"true"`
+	Fraud  libfraud.Getter          `optional:"true"`
+	Light  *light.ShareAvailability `optional:"true"`
+	Pruner *pruner.Service          `optional:"true"`
+}
+
+// samples returns the amount of shares sampled from a square of the given width and the resulting
+// probability of its data being available.
+func (src confidenceSource) samples(width int) (int, float64) {
+	if src.Light == nil {
+		// full nodes reconstruct the whole square
+		return width * width, 1
+	}
+	// every share sampled from a square that can't be reconstructed has the 3/4 chance of being
+	// withheld at most
+	samples := src.Light.SampleAmount(width)
+	return samples, 1 - math.Pow(0.75, float64(samples))
+}
+
+// confidence collects the Confidence of a height.
+func (src confidenceSource) confidence(ctx context.Context, height uint64) (*Confidence, error) {
+	c := &Confidence{Height: height}
+	head, err := src.Store.Head(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if height == 0 || height > uint64(head.Height()) {
+		return c, nil
+	}
+	h, err := src.Store.GetByHeight(ctx, height)
+	switch {
+	case errors.Is(err, libhead.ErrNotFound):
+		// headers before the trusted one are never synced
+		return c, nil
+	case err != nil:
+		return nil, err
+	}
+	c.Synced = true
+
+	if src.Pruner != nil && height <= src.Pruner.LastPruned() {
+		// only sampled heights are pruned
+		c.Pruned = true
+	} else if src.DASer != nil {
+		c.Sampled, c.SampleFailures, err = src.DASer.HeightSampled(ctx, height)
+		if err != nil {
+			return nil, err
+		}
+		if c.Sampled {
+			c.Samples, c.Probability = src.samples(len(h.DAH.RowRoots))
+		}
+	}
+
+	if src.Fraud != nil {
+		proofs, err := src.Fraud.Get(ctx, byzantine.BadEncoding)
+		if err != nil && !errors.Is(err, datastore.ErrNotFound) {
+			return nil, err
+		}
+		for _, proof := range proofs {
+			if proof.Height() == height {
+				c.FraudProofs++
+			}
+		}
+	}
+
+	c.Safe = c.Synced && (c.Sampled || c.Pruned || src.DASer == nil) && c.FraudProofs == 0
+	return c, nil
+}
@@ -0,0 +1,124 @@
+package header
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	ds_sync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	libfraud "github.com/celestiaorg/go-fraud"
+	"github.com/celestiaorg/go-header/store"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/pruner"
+	"github.com/celestiaorg/celestia-node/share/availability/light"
+)
+
+func TestConfidence(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	hstore, err := store.NewStore[*header.ExtendedHeader](ds_sync.MutexWrap(datastore.NewMapDatastore()))
+	require.NoError(t, err)
+	require.NoError(t, hstore.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, hstore.Stop(ctx))
+	})
+
+	suite := headertest.NewTestSuite(t, 3)
+	headers := suite.GenExtendedHeaders(10)
+	require.NoError(t, hstore.Init(ctx, headers[2]))
+	require.NoError(t, hstore.Append(ctx, headers[3:5]...))
+	// appended headers are written asynchronously
+	_, err = hstore.GetByHeight(ctx, 5)
+	require.NoError(t, err)
+
+	// without a DASer, as in bridge nodes, synced heights are safe unless fraud is proven
+	serv := Service{confidence: confidenceSource{
+		Store: hstore,
+		Fraud: &fraudGetter{proofs: []libfraud.Proof{&heightProof{height: 4}}},
+	}}
+
+	tests := []struct {
+		height uint64
+		synced bool
+		fraud  int
+	}{
+		{height: 1},               // before the trusted header
+		{height: 3, synced: true}, // trusted header
+		{height: 4, synced: true, fraud: 1},
+		{height: 5, synced: true},
+		{height: 6}, // not synced yet
+	}
+	for _, tt := range tests {
+		c, err := serv.Confidence(ctx, tt.height)
+		require.NoError(t, err)
+		assert.Equal(t, tt.height, c.Height)
+		assert.Equal(t, tt.synced, c.Synced, tt.height)
+		assert.Equal(t, tt.fraud, c.FraudProofs, tt.height)
+		assert.Equal(t, tt.synced && tt.fraud == 0, c.Safe, tt.height)
+	}
+
+	// pruned heights are not reported as sampled, even though they were sampled before pruning
+	ds := ds_sync.MutexWrap(datastore.NewMapDatastore())
+	require.NoError(t, ds.Put(ctx, datastore.NewKey("pruner/checkpoint"), []byte(`{"last_pruned":3}`)))
+	noProgress := func(context.Context) (uint64, error) { return 0, nil }
+	prunerServ, err := pruner.NewService(nil, hstore, noProgress, ds, pruner.DefaultParams())
+	require.NoError(t, err)
+	require.NoError(t, prunerServ.Start(ctx))
+	require.NoError(t, prunerServ.Stop(ctx))
+
+	serv = Service{confidence: confidenceSource{Store: hstore, Pruner: prunerServ}}
+	c, err := serv.Confidence(ctx, 3)
+	require.NoError(t, err)
+	assert.True(t, c.Pruned)
+	assert.False(t, c.Sampled)
+	assert.Zero(t, c.Samples)
+	assert.True(t, c.Safe)
+	c, err = serv.Confidence(ctx, 4)
+	require.NoError(t, err)
+	assert.False(t, c.Pruned)
+}
+
+func TestConfidence_Samples(t *testing.T) {
+	// full nodes sample the whole square
+	samples, probability := confidenceSource{}.samples(4)
+	assert.Equal(t, 16, samples)
+	assert.EqualValues(t, 1, probability)
+
+	// light nodes sample a subset of it, unless it has fewer shares
+	src := confidenceSource{Light: light.NewShareAvailability(nil, light.WithSampleAmount(16))}
+	samples, probability = src.samples(8)
+	assert.Equal(t, 16, samples)
+	assert.InDelta(t, 1-math.Pow(0.75, 16), probability, 1e-9)
+	samples, probability = src.samples(2)
+	assert.Equal(t, 4, samples)
+	assert.InDelta(t, 1-math.Pow(0.75, 4), probability, 1e-9)
+}
+
+type fraudGetter struct {
+	proofs []libfraud.Proof
+}
+
+func (f *fraudGetter) Get(context.Context, libfraud.ProofType) ([]libfraud.Proof, error) {
+	if len(f.proofs) == 0 {
+		return nil, datastore.ErrNotFound
+	}
+	return f.proofs, nil
+}
+
+type heightProof struct {
+	libfraud.Proof
+
+	height uint64
+}
+
+func (p *heightProof) Height() uint64 {
+	return p.height
+}
@@ -3,6 +3,7 @@ package header
 import (
 	"encoding/hex"
 	"fmt"
+	"net/url"
 
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
@@ -21,6 +22,12 @@ type Config struct {
 	// TrustedHash is the Block/Header hash that Nodes use as starting point for header synchronization.
 	// Only affects the node once on initial sync.
 	TrustedHash string
+	// TrustedHeadURL is the HTTP endpoint serving the JSON encoded header Nodes initialize header
+	// synchronization from, e.g. the /head endpoint of the gateway of a trusted node. The header must
+	// be signed by a third of the validators of the header of the TrustedHash, fetched from the
+	// TrustedPeers. If the header can not be fetched or verified, Nodes fall back to the TrustedHash.
+	// Only affects the node once on initial sync.
+	TrustedHeadURL string
 	// TrustedPeers are the peers we trust to fetch headers from.
 	// Note: The trusted does *not* imply Headers are not verified, but trusted as reliable to fetch
 	// headers at any moment.
@@ -31,6 +38,49 @@ type Config struct {
 
 	Server p2p_exchange.ServerParameters
 	Client p2p_exchange.ClientParameters `toml:",omitempty"`
+
+	// Backfill configures syncing the headers preceding the trusted head backwards, e.g. to make
+	// a Node initialized from a trusted hash archival without re-initializing its store.
+	Backfill BackfillConfig
+}
+
+// BackfillConfig configures the backfill of historical headers.
+type BackfillConfig struct {
+	// Enabled starts the backfill down to the TargetHeight once the Node starts.
+	Enabled bool
+	// TargetHeight is the lowest height to backfill the headers to. Zero backfills to genesis.
+	TargetHeight uint64
+	// Rate is the amount of headers per second requested from the network, to bound the load the
+	// backfill puts on the node and its peers. Zero uses the default rate.
+	Rate float64
+	// BatchSize is the amount of headers requested at once. Zero uses the default size.
+	BatchSize uint64
+}
+
+const (
+	defaultBackfillRate      = 100
+	defaultBackfillBatchSize = 64
+)
+
+func (cfg *BackfillConfig) targetHeight() uint64 {
+	if cfg.TargetHeight == 0 {
+		return 1
+	}
+	return cfg.TargetHeight
+}
+
+func (cfg *BackfillConfig) rate() float64 {
+	if cfg.Rate == 0 {
+		return defaultBackfillRate
+	}
+	return cfg.Rate
+}
+
+func (cfg *BackfillConfig) batchSize() uint64 {
+	if cfg.BatchSize == 0 {
+		return defaultBackfillBatchSize
+	}
+	return cfg.BatchSize
 }
 
 func DefaultConfig(tp node.Type) Config {
@@ -40,17 +90,17 @@ func DefaultConfig(tp node.Type) Config {
 		Store:        store.DefaultParameters(),
 		Syncer:       sync.DefaultParameters(),
 		Server:       p2p_exchange.DefaultServerParameters(),
+		Backfill: BackfillConfig{
+			Rate:      defaultBackfillRate,
+			BatchSize: defaultBackfillBatchSize,
+		},
 	}
 
-	switch tp {
-	case node.Bridge:
-		return cfg
-	case node.Light, node.Full:
+	// invalid types are rejected once the module is constructed
+	if tp == node.Light || tp == node.Full {
 		cfg.Client = p2p_exchange.DefaultClientParameters()
-		return cfg
-	default:
-		panic("header: invalid node type")
 	}
+	return cfg
 }
 
 func (cfg *Config) trustedPeers(bpeers p2p.Bootstrappers) (infos []peer.AddrInfo, err error) {
@@ -102,6 +152,24 @@ func (cfg *Config) Validate(tp node.Type) error {
 		return fmt.Errorf("module/header: misconfiguration of p2p exchange server: %w", err)
 	}
 
+	if cfg.Backfill.Rate < 0 {
+		return fmt.Errorf("module/header: backfill rate must not be negative, got %v", cfg.Backfill.Rate)
+	}
+	if cfg.Backfill.BatchSize > libhead.MaxRangeRequestSize {
+		return fmt.Errorf("module/header: backfill batch size must not exceed %d, got %d",
+			libhead.MaxRangeRequestSize, cfg.Backfill.BatchSize)
+	}
+
+	if cfg.TrustedHeadURL != "" {
+		u, err := url.Parse(cfg.TrustedHeadURL)
+		if err != nil {
+			return fmt.Errorf("module/header: invalid trusted head URL: %w", err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("module/header: unsupported scheme of trusted head URL: %q", u.Scheme)
+		}
+	}
+
 	// we do not create a client for bridge nodes
 	if tp == node.Bridge {
 		return nil
@@ -3,6 +3,7 @@ package header
 import (
 	"context"
 
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/peerstore"
@@ -19,35 +20,47 @@ import (
 	modfraud "github.com/celestiaorg/celestia-node/nodebuilder/fraud"
 	modp2p "github.com/celestiaorg/celestia-node/nodebuilder/p2p"
 	"github.com/celestiaorg/celestia-node/share/eds/byzantine"
+	"github.com/celestiaorg/celestia-node/share/p2p/peers"
 )
 
+type exchangeParams struct {
+	fx.In
+
+	Lc        fx.Lifecycle
+	Bpeers    modp2p.Bootstrappers
+	Network   modp2p.Network
+	Host      host.Host
+	ConnGater *conngater.BasicConnectionGater
+	Cfg       Config
+	// Latency observes the latency of the peers serving headers, if provided.
+	Latency *peers.LatencyTracker `optional:"true"`
+}
+
 // newP2PExchange constructs a new Exchange for headers.
-func newP2PExchange(
-	lc fx.Lifecycle,
-	bpeers modp2p.Bootstrappers,
-	network modp2p.Network,
-	host host.Host,
-	conngater *conngater.BasicConnectionGater,
-	cfg Config,
-) (libhead.Exchange[*header.ExtendedHeader], error) {
-	peers, err := cfg.trustedPeers(bpeers)
+func newP2PExchange(p exchangeParams) (libhead.Exchange[*header.ExtendedHeader], error) {
+	trusted, err := p.Cfg.trustedPeers(p.Bpeers)
 	if err != nil {
 		return nil, err
 	}
-	ids := make([]peer.ID, len(peers))
-	for index, peer := range peers {
+	ids := make([]peer.ID, len(trusted))
+	for index, peer := range trusted {
 		ids[index] = peer.ID
-		host.Peerstore().AddAddrs(peer.ID, peer.Addrs, peerstore.PermanentAddrTTL)
+		p.Host.Peerstore().AddAddrs(peer.ID, peer.Addrs, peerstore.PermanentAddrTTL)
+	}
+
+	host := p.Host
+	if p.Latency != nil {
+		host = p.Latency.Host(host)
 	}
-	exchange, err := p2p.NewExchange[*header.ExtendedHeader](host, ids, conngater,
-		p2p.WithParams(cfg.Client),
-		p2p.WithNetworkID[p2p.ClientParameters](network.String()),
-		p2p.WithChainID(network.String()),
+	exchange, err := p2p.NewExchange[*header.ExtendedHeader](host, ids, p.ConnGater,
+		p2p.WithParams(p.Cfg.Client),
+		p2p.WithNetworkID[p2p.ClientParameters](p.Network.String()),
+		p2p.WithChainID(p.Network.String()),
 	)
 	if err != nil {
 		return nil, err
 	}
-	lc.Append(fx.Hook{
+	p.Lc.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
 			return exchange.Start(ctx)
 		},
@@ -59,6 +72,37 @@ func newP2PExchange(
 
 }
 
+type subscriberParams struct {
+	fx.In
+
+	Lc       fx.Lifecycle
+	PubSub   *pubsub.PubSub
+	Exchange libhead.Exchange[*header.ExtendedHeader]
+	Network  modp2p.Network
+	P2P      modp2p.Config `optional:"true"`
+}
+
+// newSubscriber constructs the Subscriber of new headers, gossiped over the header topic or, if
+// the node is out of it, polled from the trusted peers.
+func newSubscriber(p subscriberParams) libhead.Subscriber[*header.ExtendedHeader] {
+	if p.P2P.TopicDisabled(modp2p.HeaderTopic) {
+		log.Info("header topic disabled, polling the network head from the trusted peers")
+		poller := newHeadPoller(p.Exchange, modp2p.BlockTime)
+		p.Lc.Append(fx.Hook{
+			OnStart: poller.Start,
+			OnStop:  poller.Stop,
+		})
+		return poller
+	}
+
+	sub := p2p.NewSubscriber[*header.ExtendedHeader](p.PubSub, header.MsgID, p.Network.String())
+	p.Lc.Append(fx.Hook{
+		OnStart: sub.Start,
+		OnStop:  sub.Stop,
+	})
+	return sub
+}
+
 // newSyncer constructs new Syncer for headers.
 func newSyncer(
 	ex libhead.Exchange[*header.ExtendedHeader],
@@ -67,7 +111,10 @@ func newSyncer(
 	sub libhead.Subscriber[*header.ExtendedHeader],
 	cfg Config,
 ) (*sync.Syncer[*header.ExtendedHeader], *modfraud.ServiceBreaker[*sync.Syncer[*header.ExtendedHeader]], error) {
-	syncer, err := sync.NewSyncer[*header.ExtendedHeader](ex, store, sub,
+	syncer, err := sync.NewSyncer[*header.ExtendedHeader](
+		supervisedExchange{ex},
+		supervisedStore{store},
+		supervisedSubscriber{sub},
 		sync.WithParams(cfg.Syncer),
 		sync.WithBlockTime(modp2p.BlockTime),
 	)
@@ -93,14 +140,24 @@ func newInitStore(
 	net modp2p.Network,
 	s libhead.Store[*header.ExtendedHeader],
 	ex libhead.Exchange[*header.ExtendedHeader],
+	si subjectiveInit,
 ) (InitStore, error) {
 	trustedHash, err := cfg.trustedHash(net)
 	if err != nil {
 		return nil, err
 	}
 
+	provider := si.provider(cfg)
 	lc.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
+			if provider != nil {
+				err := initFromProvider(ctx, s, ex, provider, net, trustedHash)
+				if err == nil {
+					return nil
+				}
+				log.Warnw("initializing header store from the subjective head provider failed, "+
+					"falling back to the trusted peers", "err", err)
+			}
 			return store.Init(ctx, s, ex, trustedHash)
 		},
 	})
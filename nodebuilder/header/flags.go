@@ -3,6 +3,7 @@ package header
 import (
 	"encoding/hex"
 	"fmt"
+	"net/url"
 
 	"github.com/multiformats/go-multiaddr"
 	"github.com/spf13/cobra"
@@ -12,6 +13,7 @@ import (
 var (
 	headersTrustedHashFlag  = "headers.trusted-hash"
 	headersTrustedPeersFlag = "headers.trusted-peers"
+	headersTrustedHeadFlag  = "headers.trusted-head-url"
 )
 
 // Flags gives a set of hardcoded Header package flags.
@@ -20,6 +22,13 @@ func Flags() *flag.FlagSet {
 
 	flags.AddFlagSet(TrustedPeersFlags())
 	flags.AddFlagSet(TrustedHashFlags())
+	flags.String(
+		headersTrustedHeadFlag,
+		"",
+		"HTTP endpoint of a trusted node serving the JSON encoded header to subjectively initialize "+
+			"header synchronization from, e.g. its gateway /head endpoint. Falls back to the trusted "+
+			"peers if the header can not be fetched",
+	)
 
 	return flags
 }
@@ -29,6 +38,9 @@ func ParseFlags(cmd *cobra.Command, cfg *Config) error {
 	if err := ParseTrustedHashFlags(cmd, cfg); err != nil {
 		return err
 	}
+	if err := parseTrustedHeadFlag(cmd, cfg); err != nil {
+		return err
+	}
 	return ParseTrustedPeerFlags(cmd, cfg)
 }
 
@@ -93,3 +105,21 @@ func ParseTrustedHashFlags(
 	}
 	return nil
 }
+
+// parseTrustedHeadFlag parses the trusted head URL flag from the given cmd and saves it to the
+// passed config.
+func parseTrustedHeadFlag(cmd *cobra.Command, cfg *Config) error {
+	headURL := cmd.Flag(headersTrustedHeadFlag).Value.String()
+	if headURL == "" {
+		return nil
+	}
+	u, err := url.Parse(headURL)
+	if err != nil {
+		return fmt.Errorf("cmd: while parsing '%s': %w", headersTrustedHeadFlag, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("cmd: while parsing '%s': unsupported scheme %q", headersTrustedHeadFlag, u.Scheme)
+	}
+	cfg.TrustedHeadURL = headURL
+	return nil
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 
 	libhead "github.com/celestiaorg/go-header"
-	"github.com/celestiaorg/go-header/sync"
 
 	"github.com/celestiaorg/celestia-node/header"
 )
@@ -33,9 +32,20 @@ type Module interface {
 	// WaitForHeight blocks until the header at the given height has been processed
 	// by the store or context deadline is exceeded.
 	WaitForHeight(context.Context, uint64) (*header.ExtendedHeader, error)
-
-	// SyncState returns the current state of the header Syncer.
-	SyncState(context.Context) (sync.State, error)
+	// Confidence reports whether the data at the given height is safe to rely on: its header is
+	// synced, its data is sampled by the node and no fraud proofs exist for it.
+	Confidence(context.Context, uint64) (*Confidence, error)
+
+	// Backfill starts syncing the headers below the lowest stored one backwards, down to the given
+	// height or, if zero, to genesis, at the rate bounded by the config. The backfill is resumed
+	// after restarts and replaces the running one, if any.
+	Backfill(ctx context.Context, height uint64) (*BackfillStatus, error)
+	// BackfillStatus reports the progress of the backfill of historical headers.
+	BackfillStatus(context.Context) (*BackfillStatus, error)
+
+	// SyncState returns the current state of the header Syncer along with its progress towards the
+	// network head: the sync rate and the estimated time left to catch up.
+	SyncState(context.Context) (SyncState, error)
 	// SyncWait blocks until the header Syncer is synced to network head.
 	SyncWait(ctx context.Context) error
 	// NetworkHead provides the Syncer's view of the current network head.
@@ -43,6 +53,10 @@ type Module interface {
 
 	// Subscribe to recent ExtendedHeaders from the network.
 	Subscribe(ctx context.Context) (<-chan *header.ExtendedHeader, error)
+	// SubscribeFrom replays ExtendedHeaders starting from the given height and then continues
+	// with new ones as they are synced, without skipping any height. Zero height starts after the
+	// current local head.
+	SubscribeFrom(ctx context.Context, height uint64) (<-chan *header.ExtendedHeader, error)
 }
 
 // API is a wrapper around Module for the RPC.
@@ -59,12 +73,19 @@ type API struct {
 			*header.ExtendedHeader,
 			uint64,
 		) ([]*header.ExtendedHeader, error) `perm:"public"`
-		GetByHeight   func(context.Context, uint64) (*header.ExtendedHeader, error)    `perm:"public"`
-		WaitForHeight func(context.Context, uint64) (*header.ExtendedHeader, error)    `perm:"read"`
-		SyncState     func(ctx context.Context) (sync.State, error)                    `perm:"read"`
-		SyncWait      func(ctx context.Context) error                                  `perm:"read"`
-		NetworkHead   func(ctx context.Context) (*header.ExtendedHeader, error)        `perm:"public"`
-		Subscribe     func(ctx context.Context) (<-chan *header.ExtendedHeader, error) `perm:"public"`
+		GetByHeight    func(context.Context, uint64) (*header.ExtendedHeader, error)    `perm:"public"`
+		WaitForHeight  func(context.Context, uint64) (*header.ExtendedHeader, error)    `perm:"read"`
+		Confidence     func(context.Context, uint64) (*Confidence, error)               `perm:"public"`
+		Backfill       func(context.Context, uint64) (*BackfillStatus, error)           `perm:"admin"`
+		BackfillStatus func(context.Context) (*BackfillStatus, error)                   `perm:"read"`
+		SyncState      func(ctx context.Context) (SyncState, error)                     `perm:"read"`
+		SyncWait       func(ctx context.Context) error                                  `perm:"read"`
+		NetworkHead    func(ctx context.Context) (*header.ExtendedHeader, error)        `perm:"public"`
+		Subscribe      func(ctx context.Context) (<-chan *header.ExtendedHeader, error) `perm:"public"`
+		SubscribeFrom  func(
+			ctx context.Context,
+			height uint64,
+		) (<-chan *header.ExtendedHeader, error) `perm:"public"`
 	}
 }
 
@@ -88,11 +109,23 @@ func (api *API) WaitForHeight(ctx context.Context, u uint64) (*header.ExtendedHe
 	return api.Internal.WaitForHeight(ctx, u)
 }
 
+func (api *API) Confidence(ctx context.Context, height uint64) (*Confidence, error) {
+	return api.Internal.Confidence(ctx, height)
+}
+
 func (api *API) LocalHead(ctx context.Context) (*header.ExtendedHeader, error) {
 	return api.Internal.LocalHead(ctx)
 }
 
-func (api *API) SyncState(ctx context.Context) (sync.State, error) {
+func (api *API) Backfill(ctx context.Context, height uint64) (*BackfillStatus, error) {
+	return api.Internal.Backfill(ctx, height)
+}
+
+func (api *API) BackfillStatus(ctx context.Context) (*BackfillStatus, error) {
+	return api.Internal.BackfillStatus(ctx)
+}
+
+func (api *API) SyncState(ctx context.Context) (SyncState, error) {
 	return api.Internal.SyncState(ctx)
 }
 
@@ -107,3 +140,7 @@ func (api *API) NetworkHead(ctx context.Context) (*header.ExtendedHeader, error)
 func (api *API) Subscribe(ctx context.Context) (<-chan *header.ExtendedHeader, error) {
 	return api.Internal.Subscribe(ctx)
 }
+
+func (api *API) SubscribeFrom(ctx context.Context, height uint64) (<-chan *header.ExtendedHeader, error) {
+	return api.Internal.SubscribeFrom(ctx, height)
+}
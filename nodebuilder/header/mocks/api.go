@@ -11,8 +11,8 @@ import (
 	gomock "github.com/golang/mock/gomock"
 
 	header "github.com/celestiaorg/celestia-node/header"
+	header1 "github.com/celestiaorg/celestia-node/nodebuilder/header"
 	header0 "github.com/celestiaorg/go-header"
-	sync "github.com/celestiaorg/go-header/sync"
 )
 
 // MockModule is a mock of Module interface.
@@ -38,6 +38,51 @@ func (m *MockModule) EXPECT() *MockModuleMockRecorder {
 	return m.recorder
 }
 
+// Backfill mocks base method.
+func (m *MockModule) Backfill(arg0 context.Context, arg1 uint64) (*header1.BackfillStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Backfill", arg0, arg1)
+	ret0, _ := ret[0].(*header1.BackfillStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Backfill indicates an expected call of Backfill.
+func (mr *MockModuleMockRecorder) Backfill(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Backfill", reflect.TypeOf((*MockModule)(nil).Backfill), arg0, arg1)
+}
+
+// BackfillStatus mocks base method.
+func (m *MockModule) BackfillStatus(arg0 context.Context) (*header1.BackfillStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackfillStatus", arg0)
+	ret0, _ := ret[0].(*header1.BackfillStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BackfillStatus indicates an expected call of BackfillStatus.
+func (mr *MockModuleMockRecorder) BackfillStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackfillStatus", reflect.TypeOf((*MockModule)(nil).BackfillStatus), arg0)
+}
+
+// Confidence mocks base method.
+func (m *MockModule) Confidence(arg0 context.Context, arg1 uint64) (*header1.Confidence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Confidence", arg0, arg1)
+	ret0, _ := ret[0].(*header1.Confidence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Confidence indicates an expected call of Confidence.
+func (mr *MockModuleMockRecorder) Confidence(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confidence", reflect.TypeOf((*MockModule)(nil).Confidence), arg0, arg1)
+}
+
 // GetByHash mocks base method.
 func (m *MockModule) GetByHash(arg0 context.Context, arg1 header0.Hash) (*header.ExtendedHeader, error) {
 	m.ctrl.T.Helper()
@@ -128,11 +173,26 @@ func (mr *MockModuleMockRecorder) Subscribe(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockModule)(nil).Subscribe), arg0)
 }
 
+// SubscribeFrom mocks base method.
+func (m *MockModule) SubscribeFrom(arg0 context.Context, arg1 uint64) (<-chan *header.ExtendedHeader, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeFrom", arg0, arg1)
+	ret0, _ := ret[0].(<-chan *header.ExtendedHeader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeFrom indicates an expected call of SubscribeFrom.
+func (mr *MockModuleMockRecorder) SubscribeFrom(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeFrom", reflect.TypeOf((*MockModule)(nil).SubscribeFrom), arg0, arg1)
+}
+
 // SyncState mocks base method.
-func (m *MockModule) SyncState(arg0 context.Context) (sync.State, error) {
+func (m *MockModule) SyncState(arg0 context.Context) (header1.SyncState, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SyncState", arg0)
-	ret0, _ := ret[0].(sync.State)
+	ret0, _ := ret[0].(header1.SyncState)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -2,6 +2,7 @@ package header
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/ipfs/go-datastore"
 	logging "github.com/ipfs/go-log/v2"
@@ -42,9 +43,15 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 			}),
 		)),
 		fx.Provide(newInitStore),
-		fx.Provide(func(subscriber *p2p.Subscriber[*header.ExtendedHeader]) libhead.Subscriber[*header.ExtendedHeader] {
-			return subscriber
-		}),
+		fx.Provide(fx.Annotate(
+			newBackfiller,
+			fx.OnStart(func(ctx context.Context, b *backfiller) error {
+				return b.Start(ctx)
+			}),
+			fx.OnStop(func(ctx context.Context, b *backfiller) error {
+				return b.Stop(ctx)
+			}),
+		)),
 		fx.Provide(fx.Annotate(
 			newSyncer,
 			fx.OnStart(func(
@@ -61,14 +68,12 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 			}),
 		)),
 		fx.Provide(fx.Annotate(
-			func(ps *pubsub.PubSub, network modp2p.Network) *p2p.Subscriber[*header.ExtendedHeader] {
-				return p2p.NewSubscriber[*header.ExtendedHeader](ps, header.MsgID, network.String())
-			},
-			fx.OnStart(func(ctx context.Context, sub *p2p.Subscriber[*header.ExtendedHeader]) error {
-				return sub.Start(ctx)
+			newSyncProgress,
+			fx.OnStart(func(ctx context.Context, p *syncProgress) error {
+				return p.Start(ctx)
 			}),
-			fx.OnStop(func(ctx context.Context, sub *p2p.Subscriber[*header.ExtendedHeader]) error {
-				return sub.Stop(ctx)
+			fx.OnStop(func(ctx context.Context, p *syncProgress) error {
+				return p.Stop(ctx)
 			}),
 		)),
 		fx.Provide(fx.Annotate(
@@ -97,17 +102,32 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 			"header",
 			baseComponents,
 			fx.Provide(newP2PExchange),
+			fx.Provide(newSubscriber),
 		)
 	case node.Bridge:
 		return fx.Module(
 			"header",
 			baseComponents,
+			fx.Provide(fx.Annotate(
+				func(ps *pubsub.PubSub, network modp2p.Network) *p2p.Subscriber[*header.ExtendedHeader] {
+					return p2p.NewSubscriber[*header.ExtendedHeader](ps, header.MsgID, network.String())
+				},
+				fx.OnStart(func(ctx context.Context, sub *p2p.Subscriber[*header.ExtendedHeader]) error {
+					return sub.Start(ctx)
+				}),
+				fx.OnStop(func(ctx context.Context, sub *p2p.Subscriber[*header.ExtendedHeader]) error {
+					return sub.Stop(ctx)
+				}),
+			)),
+			fx.Provide(func(subscriber *p2p.Subscriber[*header.ExtendedHeader]) libhead.Subscriber[*header.ExtendedHeader] {
+				return subscriber
+			}),
 			fx.Provide(func(subscriber *p2p.Subscriber[*header.ExtendedHeader]) libhead.Broadcaster[*header.ExtendedHeader] {
 				return subscriber
 			}),
 			fx.Supply(header.MakeExtendedHeader),
 		)
 	default:
-		panic("invalid node type")
+		return fx.Error(fmt.Errorf("module/header: invalid node type: %s", tp))
 	}
 }
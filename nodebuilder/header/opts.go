@@ -13,6 +13,7 @@ func WithMetrics(
 	store libhead.Store[*header.ExtendedHeader],
 	ex libhead.Exchange[*header.ExtendedHeader],
 	sync *sync.Syncer[*header.ExtendedHeader],
+	progress *syncProgress,
 ) error {
 	if p2pex, ok := ex.(*p2p.Exchange[*header.ExtendedHeader]); ok {
 		if err := p2pex.InitMetrics(); err != nil {
@@ -24,5 +25,9 @@ func WithMetrics(
 		return err
 	}
 
+	if err := progress.WithMetrics(); err != nil {
+		return err
+	}
+
 	return libhead.WithMetrics[*header.ExtendedHeader](store)
 }
@@ -0,0 +1,161 @@
+package header
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+
+	libhead "github.com/celestiaorg/go-header"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+// pollSubscriptionBufSize is the amount of heads a subscription buffers before dropping the new
+// ones, as the gossip does for slow subscribers.
+const pollSubscriptionBufSize = 32
+
+var _ libhead.Subscriber[*header.ExtendedHeader] = (*headPoller)(nil)
+
+// headPoller stands in for the header topic on nodes out of it, requesting the network head from
+// the trusted peers every block time instead. Like the gossip, it runs the validators on every new
+// head and passes the accepted ones on to the subscriptions.
+type headPoller struct {
+	getter   libhead.Head[*header.ExtendedHeader]
+	interval time.Duration
+
+	lk         sync.Mutex
+	validators []func(context.Context, *header.ExtendedHeader) pubsub.ValidationResult
+	subs       map[*pollSubscription]struct{}
+	// last is the height of the latest polled head
+	last uint64
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newHeadPoller(getter libhead.Head[*header.ExtendedHeader], interval time.Duration) *headPoller {
+	return &headPoller{
+		getter:   getter,
+		interval: interval,
+		subs:     make(map[*pollSubscription]struct{}),
+	}
+}
+
+func (p *headPoller) Start(context.Context) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel, p.done = cancel, make(chan struct{})
+	go p.run(ctx)
+	return nil
+}
+
+func (p *headPoller) Stop(ctx context.Context) error {
+	p.cancel()
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *headPoller) AddValidator(val func(context.Context, *header.ExtendedHeader) pubsub.ValidationResult) error {
+	p.lk.Lock()
+	defer p.lk.Unlock()
+	p.validators = append(p.validators, val)
+	return nil
+}
+
+func (p *headPoller) Subscribe() (libhead.Subscription[*header.ExtendedHeader], error) {
+	sub := &pollSubscription{
+		poller:  p,
+		headers: make(chan *header.ExtendedHeader, pollSubscriptionBufSize),
+		done:    make(chan struct{}),
+	}
+	p.lk.Lock()
+	p.subs[sub] = struct{}{}
+	p.lk.Unlock()
+	return sub, nil
+}
+
+func (p *headPoller) run(ctx context.Context) {
+	defer close(p.done)
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	// the first poll waits for the interval, as the syncer requests the head on start itself
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := p.poll(ctx); err != nil && ctx.Err() == nil {
+			log.Warnw("polling network head", "err", err)
+		}
+	}
+}
+
+// poll requests the network head and processes it, if it is new.
+func (p *headPoller) poll(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, p.interval)
+	defer cancel()
+	head, err := p.getter.Head(ctx)
+	if err != nil {
+		return err
+	}
+
+	p.lk.Lock()
+	if uint64(head.Height()) <= p.last {
+		p.lk.Unlock()
+		return nil
+	}
+	p.last = uint64(head.Height())
+	validators := p.validators
+	p.lk.Unlock()
+
+	for _, val := range validators {
+		if val(ctx, head) != pubsub.ValidationAccept {
+			return nil
+		}
+	}
+
+	p.lk.Lock()
+	defer p.lk.Unlock()
+	for sub := range p.subs {
+		select {
+		case sub.headers <- head:
+		default:
+			log.Warnw("dropping polled head for a slow subscriber", "height", head.Height())
+		}
+	}
+	return nil
+}
+
+// pollSubscription receives the heads accepted by the headPoller.
+type pollSubscription struct {
+	poller  *headPoller
+	headers chan *header.ExtendedHeader
+	once    sync.Once
+	done    chan struct{}
+}
+
+func (s *pollSubscription) NextHeader(ctx context.Context) (*header.ExtendedHeader, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-s.done:
+		return nil, pubsub.ErrSubscriptionCancelled
+	case h := <-s.headers:
+		return h, nil
+	}
+}
+
+func (s *pollSubscription) Cancel() {
+	s.once.Do(func() {
+		s.poller.lk.Lock()
+		delete(s.poller.subs, s)
+		s.poller.lk.Unlock()
+		close(s.done)
+	})
+}
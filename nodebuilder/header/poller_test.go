@@ -0,0 +1,83 @@
+package header
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+)
+
+func TestHeadPoller(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	headers := headertest.NewTestSuite(t, 3).GenExtendedHeaders(3)
+	getter := &headGetter{head: headers[0]}
+	poller := newHeadPoller(getter, time.Millisecond*10)
+
+	var validated []uint64
+	err := poller.AddValidator(func(_ context.Context, h *header.ExtendedHeader) pubsub.ValidationResult {
+		validated = append(validated, uint64(h.Height()))
+		if h.Height() == 2 {
+			return pubsub.ValidationReject
+		}
+		return pubsub.ValidationAccept
+	})
+	require.NoError(t, err)
+	sub, err := poller.Subscribe()
+	require.NoError(t, err)
+	t.Cleanup(sub.Cancel)
+
+	require.NoError(t, poller.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, poller.Stop(ctx))
+	})
+
+	h, err := sub.NextHeader(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, h.Height())
+
+	// rejected heads are not passed on
+	getter.setHead(headers[1])
+	require.Eventually(t, func() bool {
+		poller.lk.Lock()
+		defer poller.lk.Unlock()
+		return poller.last == 2
+	}, time.Second, time.Millisecond)
+	getter.setHead(headers[2])
+	h, err = sub.NextHeader(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, h.Height())
+
+	// every head is validated once
+	require.NoError(t, poller.Stop(ctx))
+	assert.Equal(t, []uint64{1, 2, 3}, validated)
+
+	sub.Cancel()
+	_, err = sub.NextHeader(ctx)
+	require.ErrorIs(t, err, pubsub.ErrSubscriptionCancelled)
+}
+
+type headGetter struct {
+	lk   sync.Mutex
+	head *header.ExtendedHeader
+}
+
+func (g *headGetter) Head(context.Context) (*header.ExtendedHeader, error) {
+	g.lk.Lock()
+	defer g.lk.Unlock()
+	return g.head, nil
+}
+
+func (g *headGetter) setHead(h *header.ExtendedHeader) {
+	g.lk.Lock()
+	defer g.lk.Unlock()
+	g.head = h
+}
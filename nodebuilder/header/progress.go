@@ -0,0 +1,173 @@
+package header
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+
+	libsync "github.com/celestiaorg/go-header/sync"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+const (
+	// progressInterval is the interval the progress of the header sync is sampled at.
+	progressInterval = 5 * time.Second
+	// progressSmoothing is the weight of the latest sample in the sync rate, smoothing out the
+	// bursts of headers synced in batches.
+	progressSmoothing = 0.3
+)
+
+// SyncState is the state of the header Syncer along with its progress towards the network head.
+type SyncState struct {
+	libsync.State
+	// HeadersPerSecond is the recent rate of the header sync.
+	HeadersPerSecond float64 `json:"headers_per_second"`
+	// ETA is the estimated time left to catch up with the network head, zero once caught up. It is
+	// omitted while unknown, i.e. while no headers are being synced.
+	ETA *time.Duration `json:"eta,omitempty"`
+}
+
+// syncProgress samples the state of the Syncer to track the rate of the header sync and estimate
+// the time left to catch up with the network head.
+type syncProgress struct {
+	syncer   syncer
+	interval time.Duration
+
+	lock       sync.Mutex
+	lastHeight uint64
+	lastTime   time.Time
+	rate       float64
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newSyncProgress(syncer *libsync.Syncer[*header.ExtendedHeader]) *syncProgress {
+	return &syncProgress{
+		syncer:   syncer,
+		interval: progressInterval,
+	}
+}
+
+// Start starts sampling the progress of the header sync.
+func (p *syncProgress) Start(context.Context) error {
+	p.sample(time.Now())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.done = make(chan struct{})
+	go p.run(ctx)
+	return nil
+}
+
+// Stop stops sampling the progress of the header sync.
+func (p *syncProgress) Stop(ctx context.Context) error {
+	p.cancel()
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *syncProgress) run(ctx context.Context) {
+	defer close(p.done)
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			p.sample(now)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// sample updates the rate of the header sync with the height synced since the previous sample.
+func (p *syncProgress) sample(now time.Time) {
+	height := p.syncer.State().Height
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if !p.lastTime.IsZero() && now.After(p.lastTime) {
+		var synced uint64
+		if height > p.lastHeight {
+			synced = height - p.lastHeight
+		}
+		rate := float64(synced) / now.Sub(p.lastTime).Seconds()
+		p.rate = progressSmoothing*rate + (1-progressSmoothing)*p.rate
+	}
+	p.lastHeight = height
+	p.lastTime = now
+}
+
+// state reports the current state of the header sync along with its progress.
+func (p *syncProgress) state() SyncState {
+	state := SyncState{State: p.syncer.State()}
+
+	p.lock.Lock()
+	state.HeadersPerSecond = p.rate
+	p.lock.Unlock()
+
+	var eta time.Duration
+	switch {
+	case state.Finished():
+	case state.HeadersPerSecond > 0:
+		left := float64(state.ToHeight - state.Height)
+		eta = time.Duration(left / state.HeadersPerSecond * float64(time.Second))
+	default:
+		return state
+	}
+	state.ETA = &eta
+	return state
+}
+
+// WithMetrics reports the progress of the header sync: the local and network head heights, the
+// sync rate and the estimated time left to catch up.
+func (p *syncProgress) WithMetrics() error {
+	meter := otel.Meter("header/sync")
+	height, err := meter.Int64ObservableGauge("header_sync_height",
+		metric.WithDescription("height of the local head"))
+	if err != nil {
+		return err
+	}
+	networkHeight, err := meter.Int64ObservableGauge("header_sync_network_height",
+		metric.WithDescription("height of the network head known to the syncer"))
+	if err != nil {
+		return err
+	}
+	rate, err := meter.Float64ObservableGauge("header_sync_headers_per_second",
+		metric.WithDescription("recent rate of the header sync"))
+	if err != nil {
+		return err
+	}
+	eta, err := meter.Float64ObservableGauge("header_sync_eta_seconds",
+		metric.WithDescription("estimated time left to catch up with the network head"))
+	if err != nil {
+		return err
+	}
+
+	callback := func(_ context.Context, observer metric.Observer) error {
+		state := p.state()
+		// the network head known to the syncer lags behind the local one once caught up
+		toHeight := state.ToHeight
+		if toHeight < state.Height {
+			toHeight = state.Height
+		}
+		observer.ObserveInt64(height, int64(state.Height))
+		observer.ObserveInt64(networkHeight, int64(toHeight))
+		observer.ObserveFloat64(rate, state.HeadersPerSecond)
+		if state.ETA != nil {
+			observer.ObserveFloat64(eta, state.ETA.Seconds())
+		}
+		return nil
+	}
+	_, err = meter.RegisterCallback(callback, height, networkHeight, rate, eta)
+	return err
+}
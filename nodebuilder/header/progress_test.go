@@ -0,0 +1,70 @@
+package header
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/go-header/sync"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+func TestSyncState(t *testing.T) {
+	syncer := &stateSyncer{state: sync.State{Height: 100, ToHeight: 1100}}
+	p := &syncProgress{syncer: syncer}
+
+	// the ETA is unknown until the rate is measured
+	state := p.state()
+	assert.EqualValues(t, 100, state.Height)
+	assert.EqualValues(t, 1100, state.ToHeight)
+	assert.Nil(t, state.ETA)
+
+	now := time.Now()
+	p.sample(now)
+	syncer.state.Height = 200
+	p.sample(now.Add(time.Second))
+
+	state = p.state()
+	assert.InDelta(t, progressSmoothing*100, state.HeadersPerSecond, 0.001)
+	require.NotNil(t, state.ETA)
+	assert.Equal(t, time.Duration(900/(progressSmoothing*100)*float64(time.Second)), *state.ETA)
+	assert.False(t, state.Finished())
+
+	// the ETA is zero once caught up, even with a network head lagging behind the local one
+	syncer.state.Height = 1200
+	state = p.state()
+	assert.True(t, state.Finished())
+	require.NotNil(t, state.ETA)
+	assert.Zero(t, *state.ETA)
+
+	// the progress is reported along with the state of the syncer, which is kept without it
+	raw, err := json.Marshal(state)
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), `"to_height":1100`)
+	assert.Contains(t, string(raw), `"headers_per_second":`)
+
+	serv := &Service{syncer: syncer}
+	state, err = serv.SyncState(context.Background())
+	require.NoError(t, err)
+	assert.EqualValues(t, 1200, state.Height)
+	assert.Nil(t, state.ETA)
+}
+
+type stateSyncer struct {
+	errorSyncer[*header.ExtendedHeader]
+
+	state sync.State
+}
+
+func (s *stateSyncer) State() sync.State {
+	return s.state
+}
+
+func (s *stateSyncer) SyncWait(context.Context) error {
+	return nil
+}
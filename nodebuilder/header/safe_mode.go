@@ -0,0 +1,111 @@
+package header
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ipfs/go-datastore"
+	"go.uber.org/fx"
+
+	libhead "github.com/celestiaorg/go-header"
+	"github.com/celestiaorg/go-header/store"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+// ErrSafeMode is returned by methods requiring the network, while the node runs in safe mode.
+var ErrSafeMode = errors.New("header: unavailable in safe mode")
+
+// ConstructSafeModeModule collects the header module serving headers only from the local store,
+// without syncing or connecting to the network.
+func ConstructSafeModeModule(cfg *Config) fx.Option {
+	return fx.Module(
+		"header",
+		fx.Supply(*cfg),
+		fx.Provide(func(ds datastore.Batching) (libhead.Store[*header.ExtendedHeader], error) {
+			// the store is never started, as it is only read from
+			return store.NewStore[*header.ExtendedHeader](ds, store.WithParams(cfg.Store))
+		}),
+		fx.Provide(newSafeModeService),
+	)
+}
+
+// safeModeService is the header Module of a node running in safe mode. It serves the headers
+// already in the store and fails the methods requiring the network with ErrSafeMode.
+type safeModeService struct {
+	store libhead.Store[*header.ExtendedHeader]
+}
+
+func newSafeModeService(store libhead.Store[*header.ExtendedHeader]) Module {
+	return &safeModeService{store: store}
+}
+
+func (s *safeModeService) LocalHead(ctx context.Context) (*header.ExtendedHeader, error) {
+	return s.store.Head(ctx)
+}
+
+func (s *safeModeService) GetByHash(ctx context.Context, hash libhead.Hash) (*header.ExtendedHeader, error) {
+	return s.store.Get(ctx, hash)
+}
+
+func (s *safeModeService) GetVerifiedRangeByHeight(
+	ctx context.Context,
+	from *header.ExtendedHeader,
+	to uint64,
+) ([]*header.ExtendedHeader, error) {
+	return s.store.GetVerifiedRange(ctx, from, to)
+}
+
+func (s *safeModeService) GetByHeight(ctx context.Context, height uint64) (*header.ExtendedHeader, error) {
+	head, err := s.store.Head(ctx)
+	switch {
+	case err != nil:
+		return nil, err
+	case uint64(head.Height()) == height:
+		return head, nil
+	// the store never grows in safe mode, so waiting for the height would block forever
+	case uint64(head.Height()) < height:
+		return nil, fmt.Errorf("header: given height is above the local head in safe mode: "+
+			"localHeadHeight: %d, requestedHeight: %d", head.Height(), height)
+	default:
+		return s.store.GetByHeight(ctx, height)
+	}
+}
+
+func (s *safeModeService) WaitForHeight(ctx context.Context, height uint64) (*header.ExtendedHeader, error) {
+	return s.GetByHeight(ctx, height)
+}
+
+// Confidence fails with ErrSafeMode, as the sampling state is only known to the running DASer.
+func (s *safeModeService) Confidence(context.Context, uint64) (*Confidence, error) {
+	return nil, ErrSafeMode
+}
+
+func (s *safeModeService) Backfill(context.Context, uint64) (*BackfillStatus, error) {
+	return nil, ErrSafeMode
+}
+
+func (s *safeModeService) BackfillStatus(context.Context) (*BackfillStatus, error) {
+	return nil, ErrSafeMode
+}
+
+func (s *safeModeService) SyncState(context.Context) (SyncState, error) {
+	return SyncState{}, ErrSafeMode
+}
+
+func (s *safeModeService) SyncWait(context.Context) error {
+	return ErrSafeMode
+}
+
+func (s *safeModeService) NetworkHead(context.Context) (*header.ExtendedHeader, error) {
+	return nil, ErrSafeMode
+}
+
+func (s *safeModeService) Subscribe(context.Context) (<-chan *header.ExtendedHeader, error) {
+	return nil, ErrSafeMode
+}
+
+func (s *safeModeService) SubscribeFrom(context.Context, uint64) (<-chan *header.ExtendedHeader, error) {
+	return nil, ErrSafeMode
+}
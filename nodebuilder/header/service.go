@@ -2,6 +2,7 @@ package header
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	libhead "github.com/celestiaorg/go-header"
@@ -21,6 +22,10 @@ type Service struct {
 	sub       libhead.Subscriber[*header.ExtendedHeader]
 	p2pServer *p2p.ExchangeServer[*header.ExtendedHeader]
 	store     libhead.Store[*header.ExtendedHeader]
+
+	confidence confidenceSource
+	backfiller *backfiller
+	progress   *syncProgress
 }
 
 // syncer bare minimum Syncer interface for testing
@@ -38,13 +43,19 @@ func newHeaderService(
 	p2pServer *p2p.ExchangeServer[*header.ExtendedHeader],
 	ex libhead.Exchange[*header.ExtendedHeader],
 	store libhead.Store[*header.ExtendedHeader],
+	confidence confidenceSource,
+	backfiller *backfiller,
+	progress *syncProgress,
 ) Module {
 	return &Service{
-		syncer:    syncer,
-		sub:       sub,
-		p2pServer: p2pServer,
-		ex:        ex,
-		store:     store,
+		syncer:     syncer,
+		sub:        sub,
+		p2pServer:  p2pServer,
+		ex:         ex,
+		store:      store,
+		confidence: confidence,
+		backfiller: backfiller,
+		progress:   progress,
 	}
 }
 
@@ -93,12 +104,33 @@ func (s *Service) WaitForHeight(ctx context.Context, height uint64) (*header.Ext
 	return s.store.GetByHeight(ctx, height)
 }
 
+func (s *Service) Confidence(ctx context.Context, height uint64) (*Confidence, error) {
+	return s.confidence.confidence(ctx, height)
+}
+
 func (s *Service) LocalHead(ctx context.Context) (*header.ExtendedHeader, error) {
 	return s.store.Head(ctx)
 }
 
-func (s *Service) SyncState(context.Context) (sync.State, error) {
-	return s.syncer.State(), nil
+func (s *Service) Backfill(ctx context.Context, height uint64) (*BackfillStatus, error) {
+	if s.backfiller == nil {
+		return nil, ErrBackfillDisabled
+	}
+	return s.backfiller.Backfill(ctx, height)
+}
+
+func (s *Service) BackfillStatus(ctx context.Context) (*BackfillStatus, error) {
+	if s.backfiller == nil {
+		return nil, ErrBackfillDisabled
+	}
+	return s.backfiller.Status(ctx)
+}
+
+func (s *Service) SyncState(context.Context) (SyncState, error) {
+	if s.progress == nil {
+		return SyncState{State: s.syncer.State()}, nil
+	}
+	return s.progress.state(), nil
 }
 
 func (s *Service) SyncWait(ctx context.Context) error {
@@ -123,7 +155,7 @@ func (s *Service) Subscribe(ctx context.Context) (<-chan *header.ExtendedHeader,
 		for {
 			h, err := subscription.NextHeader(ctx)
 			if err != nil {
-				if err != context.DeadlineExceeded && err != context.Canceled {
+				if !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, context.Canceled) {
 					log.Errorw("fetching header from subscription", "err", err)
 				}
 				return
@@ -138,3 +170,49 @@ func (s *Service) Subscribe(ctx context.Context) (<-chan *header.ExtendedHeader,
 	}()
 	return headerCh, nil
 }
+
+func (s *Service) SubscribeFrom(ctx context.Context, height uint64) (<-chan *header.ExtendedHeader, error) {
+	head, err := s.store.Head(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if height == 0 {
+		height = uint64(head.Height()) + 1
+	}
+
+	// fail early if the history to replay is not in the store, e.g. below the node's trusted head
+	var first *header.ExtendedHeader
+	if height <= uint64(head.Height()) {
+		first, err = s.store.GetByHeight(ctx, height)
+		if err != nil {
+			return nil, fmt.Errorf("header: replaying from height %d: %w", height, err)
+		}
+	}
+
+	headerCh := make(chan *header.ExtendedHeader)
+	go func() {
+		defer close(headerCh)
+
+		// the store is written by the syncer as soon as new headers come from the network, so
+		// reading it height by height replays the history and then follows live headers without gaps
+		for h := first; ; height++ {
+			if h == nil {
+				h, err = s.store.GetByHeight(ctx, height)
+				if err != nil {
+					if !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, context.Canceled) {
+						log.Errorw("fetching header from store", "height", height, "err", err)
+					}
+					return
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case headerCh <- h:
+			}
+			h = nil
+		}
+	}()
+	return headerCh, nil
+}
@@ -4,13 +4,19 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/ipfs/go-datastore"
+	ds_sync "github.com/ipfs/go-datastore/sync"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	libhead "github.com/celestiaorg/go-header"
+	"github.com/celestiaorg/go-header/store"
 	"github.com/celestiaorg/go-header/sync"
 
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
 )
 
 func TestGetByHeightHandlesError(t *testing.T) {
@@ -25,6 +31,50 @@ func TestGetByHeightHandlesError(t *testing.T) {
 	})
 }
 
+func TestSubscribeFrom(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	hstore, err := store.NewStore[*header.ExtendedHeader](ds_sync.MutexWrap(datastore.NewMapDatastore()))
+	require.NoError(t, err)
+	require.NoError(t, hstore.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, hstore.Stop(ctx))
+	})
+
+	suite := headertest.NewTestSuite(t, 3)
+	headers := suite.GenExtendedHeaders(10)
+	require.NoError(t, hstore.Init(ctx, headers[0]))
+	require.NoError(t, hstore.Append(ctx, headers[1:5]...))
+
+	serv := Service{store: hstore}
+	subCtx, subCancel := context.WithCancel(ctx)
+	defer subCancel()
+
+	// heights above the local head are awaited
+	_, err = serv.SubscribeFrom(subCtx, 20)
+	require.NoError(t, err)
+	headerCh, err := serv.SubscribeFrom(subCtx, 3)
+	require.NoError(t, err)
+
+	// the stored history is replayed first
+	for height := 3; height <= 5; height++ {
+		h := <-headerCh
+		require.EqualValues(t, height, h.Height())
+	}
+
+	// then headers follow as they are stored
+	require.NoError(t, hstore.Append(ctx, headers[5:]...))
+	for height := 6; height <= 10; height++ {
+		h := <-headerCh
+		require.EqualValues(t, height, h.Height())
+	}
+
+	subCancel()
+	_, ok := <-headerCh
+	require.False(t, ok)
+}
+
 type errorSyncer[H libhead.Header] struct{}
 
 func (d *errorSyncer[H]) Head(context.Context) (H, error) {
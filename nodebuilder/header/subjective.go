@@ -0,0 +1,153 @@
+package header
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	tmmath "github.com/tendermint/tendermint/libs/math"
+	"go.uber.org/fx"
+
+	libhead "github.com/celestiaorg/go-header"
+
+	"github.com/celestiaorg/celestia-node/header"
+	modp2p "github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+)
+
+// trustLevel is the share of the voting power of the validators of the trusted header the
+// subjective head must be signed with, as by light clients of Core.
+var trustLevel = tmmath.Fraction{Numerator: 1, Denominator: 3}
+
+// subjectiveHeadTimeout bounds the time given to fetch the subjective head over HTTP.
+const subjectiveHeadTimeout = 30 * time.Second
+
+// SubjectiveHeadProvider provides the header a Node trusts to initialize its empty header store
+// with on cold start, e.g. fetched from an operator's trusted endpoint. If the provider fails, the
+// Node falls back to requesting the header of the trusted hash from the trusted peers.
+type SubjectiveHeadProvider interface {
+	SubjectiveHead(context.Context) (*header.ExtendedHeader, error)
+}
+
+// WithSubjectiveHeadProvider sets the SubjectiveHeadProvider of the Node, taking precedence over
+// the TrustedHeadURL of the config.
+func WithSubjectiveHeadProvider(provider SubjectiveHeadProvider) fx.Option {
+	return fx.Provide(func() SubjectiveHeadProvider {
+		return provider
+	})
+}
+
+// HTTPHeadProvider is a SubjectiveHeadProvider fetching the JSON encoded header from an HTTP
+// endpoint, e.g. the /head endpoint of the gateway of a trusted node.
+type HTTPHeadProvider struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPHeadProvider creates a new HTTPHeadProvider fetching the header from the URL.
+func NewHTTPHeadProvider(url string) *HTTPHeadProvider {
+	return &HTTPHeadProvider{
+		url:    url,
+		client: &http.Client{Timeout: subjectiveHeadTimeout},
+	}
+}
+
+// SubjectiveHead fetches the header from the URL.
+func (p *HTTPHeadProvider) SubjectiveHead(ctx context.Context) (*header.ExtendedHeader, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("unexpected status %s: %s", resp.Status, body)
+	}
+	var eh header.ExtendedHeader
+	if err := json.NewDecoder(resp.Body).Decode(&eh); err != nil {
+		return nil, fmt.Errorf("decoding header: %w", err)
+	}
+	return &eh, nil
+}
+
+// subjectiveInit is the SubjectiveHeadProvider optionally given to the Node.
+type subjectiveInit struct {
+	fx.In
+
+	Provider SubjectiveHeadProvider `optional:"true"`
+}
+
+// provider returns the SubjectiveHeadProvider given to the Node or configured with the
+// TrustedHeadURL, if any.
+func (si subjectiveInit) provider(cfg Config) SubjectiveHeadProvider {
+	if si.Provider != nil {
+		return si.Provider
+	}
+	if cfg.TrustedHeadURL != "" {
+		return NewHTTPHeadProvider(cfg.TrustedHeadURL)
+	}
+	return nil
+}
+
+// initFromProvider initializes the store with the subjective head of the provider, if the store is
+// empty. The subjective head must be the header of the trusted hash or be verifiable from it, so
+// that the provider can't put the node on another chain than the one it trusts.
+func initFromProvider(
+	ctx context.Context,
+	s libhead.Store[*header.ExtendedHeader],
+	ex libhead.Exchange[*header.ExtendedHeader],
+	provider SubjectiveHeadProvider,
+	net modp2p.Network,
+	trustedHash libhead.Hash,
+) error {
+	_, err := s.Head(ctx)
+	switch {
+	case err == nil:
+		return nil
+	case !errors.Is(err, libhead.ErrNoHead):
+		return err
+	}
+
+	head, err := provider.SubjectiveHead(ctx)
+	if err != nil {
+		return fmt.Errorf("getting subjective head: %w", err)
+	}
+	if err := head.Validate(); err != nil {
+		return fmt.Errorf("invalid subjective head: %w", err)
+	}
+	if head.ChainID() != net.String() {
+		return fmt.Errorf("subjective head of chain %s, expected %s", head.ChainID(), net)
+	}
+	if !bytes.Equal(head.Hash(), trustedHash) {
+		trusted, err := ex.Get(ctx, trustedHash)
+		if err != nil {
+			return fmt.Errorf("getting trusted header: %w", err)
+		}
+		if head.Height() <= trusted.Height() {
+			return fmt.Errorf("subjective head at height %d is not after the trusted header at height %d",
+				head.Height(), trusted.Height())
+		}
+		if err := trusted.Verify(head); err != nil {
+			return fmt.Errorf("verifying subjective head against trusted header: %w", err)
+		}
+		// the header is not adjacent, so it must be signed by enough of the validators trusted at the
+		// height of the trusted header
+		if err := trusted.ValidatorSet.VerifyCommitLightTrusting(head.ChainID(), head.Commit,
+			trustLevel); err != nil {
+			return fmt.Errorf("verifying subjective head against trusted validators: %w", err)
+		}
+	}
+
+	log.Infow("initializing header store with the subjective head", "height", head.Height(),
+		"hash", head.Hash().String())
+	return s.Init(ctx, head)
+}
@@ -0,0 +1,100 @@
+package header
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/require"
+
+	libhead "github.com/celestiaorg/go-header"
+	"github.com/celestiaorg/go-header/store"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	modp2p "github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+)
+
+func TestInitFromProvider(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	headers := headertest.NewTestSuite(t, 3).GenExtendedHeaders(5)
+	trusted, head := headers[0], headers[4]
+	net := modp2p.Network(head.ChainID())
+	ex := &rangeExchange{headers: headers}
+
+	var (
+		failing  atomic.Bool
+		provided atomic.Pointer[header.ExtendedHeader]
+	)
+	provided.Store(head)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		if err := json.NewEncoder(w).Encode(provided.Load()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	provider := NewHTTPHeadProvider(srv.URL)
+
+	t.Run("provider failure", func(t *testing.T) {
+		failing.Store(true)
+		defer failing.Store(false)
+
+		s := newTestStore(ctx, t)
+		require.Error(t, initFromProvider(ctx, s, ex, provider, net, trusted.Hash()))
+		_, err := s.Head(ctx)
+		require.ErrorIs(t, err, libhead.ErrNoHead)
+	})
+
+	t.Run("other chain", func(t *testing.T) {
+		s := newTestStore(ctx, t)
+		require.Error(t, initFromProvider(ctx, s, ex, provider, modp2p.Mocha, trusted.Hash()))
+		_, err := s.Head(ctx)
+		require.ErrorIs(t, err, libhead.ErrNoHead)
+	})
+
+	t.Run("untrusted head", func(t *testing.T) {
+		// signed by validators the trusted header doesn't know of
+		other := headertest.NewTestSuite(t, 3).GenExtendedHeaders(5)[4]
+		provided.Store(other)
+		defer provided.Store(head)
+
+		s := newTestStore(ctx, t)
+		require.Error(t, initFromProvider(ctx, s, ex, provider, modp2p.Network(other.ChainID()), trusted.Hash()))
+		_, err := s.Head(ctx)
+		require.ErrorIs(t, err, libhead.ErrNoHead)
+	})
+
+	t.Run("cold start", func(t *testing.T) {
+		s := newTestStore(ctx, t)
+		require.NoError(t, initFromProvider(ctx, s, ex, provider, net, trusted.Hash()))
+		got, err := s.Head(ctx)
+		require.NoError(t, err)
+		require.Equal(t, head.Hash(), got.Hash())
+
+		// the provider is not consulted once the store is initialized
+		failing.Store(true)
+		defer failing.Store(false)
+		require.NoError(t, initFromProvider(ctx, s, ex, provider, net, trusted.Hash()))
+	})
+}
+
+func newTestStore(ctx context.Context, t *testing.T) libhead.Store[*header.ExtendedHeader] {
+	s, err := store.NewStore[*header.ExtendedHeader](datastore.NewMapDatastore())
+	require.NoError(t, err)
+	require.NoError(t, s.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, s.Stop(ctx))
+	})
+	return s
+}
@@ -0,0 +1,90 @@
+package header
+
+import (
+	"context"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+
+	libhead "github.com/celestiaorg/go-header"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/supervisor"
+)
+
+// The routines of the Syncer are owned by go-header, so they can not be supervised directly.
+// Instead, the calls the Syncer makes into the exchange, the store and the header validation are
+// supervised, turning their panics into errors the Syncer retries on with the next network head.
+
+// supervisedExchange supervises the calls of the Syncer into the header exchange.
+type supervisedExchange struct {
+	libhead.Exchange[*header.ExtendedHeader]
+}
+
+func (e supervisedExchange) Head(ctx context.Context) (h *header.ExtendedHeader, err error) {
+	err = supervisor.Recover(ctx, "header/syncer/exchange", func() error {
+		h, err = e.Exchange.Head(ctx)
+		return err
+	})
+	return h, err
+}
+
+func (e supervisedExchange) GetVerifiedRange(
+	ctx context.Context,
+	from *header.ExtendedHeader,
+	amount uint64,
+) (hs []*header.ExtendedHeader, err error) {
+	err = supervisor.Recover(ctx, "header/syncer/exchange", func() error {
+		hs, err = e.Exchange.GetVerifiedRange(ctx, from, amount)
+		return err
+	})
+	return hs, err
+}
+
+// supervisedStore supervises the calls of the Syncer into the header store.
+type supervisedStore struct {
+	libhead.Store[*header.ExtendedHeader]
+}
+
+func (s supervisedStore) Head(ctx context.Context) (h *header.ExtendedHeader, err error) {
+	err = supervisor.Recover(ctx, "header/syncer/store", func() error {
+		h, err = s.Store.Head(ctx)
+		return err
+	})
+	return h, err
+}
+
+func (s supervisedStore) GetByHeight(ctx context.Context, height uint64) (h *header.ExtendedHeader, err error) {
+	err = supervisor.Recover(ctx, "header/syncer/store", func() error {
+		h, err = s.Store.GetByHeight(ctx, height)
+		return err
+	})
+	return h, err
+}
+
+func (s supervisedStore) Append(ctx context.Context, hs ...*header.ExtendedHeader) error {
+	return supervisor.Recover(ctx, "header/syncer/store", func() error {
+		return s.Store.Append(ctx, hs...)
+	})
+}
+
+// supervisedSubscriber supervises the validation of the network heads received by the Syncer,
+// ignoring the heads whose validation panics.
+type supervisedSubscriber struct {
+	libhead.Subscriber[*header.ExtendedHeader]
+}
+
+func (s supervisedSubscriber) AddValidator(
+	validate func(context.Context, *header.ExtendedHeader) pubsub.ValidationResult,
+) error {
+	return s.Subscriber.AddValidator(func(ctx context.Context, h *header.ExtendedHeader) pubsub.ValidationResult {
+		result := pubsub.ValidationIgnore
+		err := supervisor.Recover(ctx, "header/syncer/validation", func() error {
+			result = validate(ctx, h)
+			return nil
+		})
+		if err != nil {
+			return pubsub.ValidationIgnore
+		}
+		return result
+	})
+}
@@ -0,0 +1,55 @@
+package header
+
+import (
+	"context"
+	"testing"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	libhead "github.com/celestiaorg/go-header"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+func TestSupervisedSyncerCalls(t *testing.T) {
+	ctx := context.Background()
+
+	store := supervisedStore{panickingStore{}}
+	require.ErrorContains(t, store.Append(ctx, &header.ExtendedHeader{}), "panicked")
+	_, err := store.Head(ctx)
+	require.ErrorContains(t, err, "panicked")
+
+	sub := &validatorSubscriber{}
+	require.NoError(t, supervisedSubscriber{sub}.AddValidator(
+		func(context.Context, *header.ExtendedHeader) pubsub.ValidationResult {
+			panic("boom")
+		}))
+	assert.Equal(t, pubsub.ValidationIgnore, sub.validate(ctx, &header.ExtendedHeader{}))
+}
+
+type panickingStore struct {
+	libhead.Store[*header.ExtendedHeader]
+}
+
+func (panickingStore) Head(context.Context) (*header.ExtendedHeader, error) {
+	panic("boom")
+}
+
+func (panickingStore) Append(context.Context, ...*header.ExtendedHeader) error {
+	panic("boom")
+}
+
+type validatorSubscriber struct {
+	libhead.Subscriber[*header.ExtendedHeader]
+
+	validate func(context.Context, *header.ExtendedHeader) pubsub.ValidationResult
+}
+
+func (s *validatorSubscriber) AddValidator(
+	validate func(context.Context, *header.ExtendedHeader) pubsub.ValidationResult,
+) error {
+	s.validate = validate
+	return nil
+}
@@ -0,0 +1,26 @@
+package health
+
+import (
+	"github.com/celestiaorg/celestia-node/api/gateway"
+)
+
+// Config combines all configuration fields for the readiness of the node.
+type Config struct {
+	// Readiness selects the checks the node must pass to be ready. The readiness is reported on the
+	// readiness endpoints of the RPC and the gateway.
+	Readiness gateway.ReadinessConfig
+	// GateListen makes the RPC and the gateway start listening only once the node is ready, so that
+	// no request reaches a node that is still syncing.
+	GateListen bool
+}
+
+// DefaultConfig returns default configuration for the readiness of the node, requiring every
+// check available in the node type without delaying listening.
+func DefaultConfig() Config {
+	return Config{}
+}
+
+// Validate performs basic validation of the config.
+func (cfg *Config) Validate() error {
+	return cfg.Readiness.Validate()
+}
@@ -0,0 +1,52 @@
+package health
+
+import (
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	checksFlag     = "health.checks"
+	maxSyncLagFlag = "health.max-sync-lag"
+	gateListenFlag = "health.gate-listen"
+)
+
+// Flags gives a set of health flags.
+func Flags() *flag.FlagSet {
+	flags := &flag.FlagSet{}
+
+	flags.StringSlice(
+		checksFlag,
+		nil,
+		"Comma-separated list of the checks required for the node to be ready, out of p2p, header_sync, "+
+			"das and core (default: every check available in the node type)",
+	)
+	flags.Uint64(
+		maxSyncLagFlag,
+		0,
+		"Amount of heights header sync and sampling may lag behind the network head while the node is ready",
+	)
+	flags.Bool(
+		gateListenFlag,
+		false,
+		"Makes the RPC and the gateway start listening only once the node is ready",
+	)
+
+	return flags
+}
+
+// ParseFlags parses health flags from the given cmd and saves them to the passed config.
+func ParseFlags(cmd *cobra.Command, cfg *Config) {
+	checks, err := cmd.Flags().GetStringSlice(checksFlag)
+	if cmd.Flags().Changed(checksFlag) && err == nil {
+		cfg.Readiness.Checks = checks
+	}
+	lag, err := cmd.Flags().GetUint64(maxSyncLagFlag)
+	if cmd.Flags().Changed(maxSyncLagFlag) && err == nil {
+		cfg.Readiness.MaxSyncLag = lag
+	}
+	gate, err := cmd.Flags().GetBool(gateListenFlag)
+	if cmd.Flags().Changed(gateListenFlag) && err == nil {
+		cfg.GateListen = gate
+	}
+}
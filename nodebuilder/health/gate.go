@@ -0,0 +1,67 @@
+package health
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/celestia-node/api/gateway"
+)
+
+// Gate starts the servers of the node once it is ready, when listening is gated, or right away
+// otherwise.
+type Gate struct {
+	health     *gateway.HealthHandler
+	shutdowner fx.Shutdowner
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewGate constructs a Gate delaying the start of the servers until the given HealthHandler
+// reports the node ready. Nil HealthHandler never delays the start. As the delayed servers start
+// after the node has, a failure to start them shuts the node down through the Shutdowner.
+func NewGate(health *gateway.HealthHandler, shutdowner fx.Shutdowner) *Gate {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Gate{
+		health:     health,
+		shutdowner: shutdowner,
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+// Start runs start of the server of the given name right away, or in the background once the node
+// is ready, in which case the node is shut down if the server fails to start.
+func (g *Gate) Start(ctx context.Context, name string, start func(context.Context) error) error {
+	if g.health == nil {
+		return start(ctx)
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		log.Infow("waiting for the node to be ready before listening", "server", name)
+		if err := g.health.WaitReady(g.ctx); err != nil {
+			return
+		}
+		if err := start(g.ctx); err != nil {
+			log.Errorw("starting server once ready, shutting down", "server", name, "err", err)
+			if err := g.shutdowner.Shutdown(fx.ExitCode(1)); err != nil {
+				log.Errorw("shutting down", "err", err)
+			}
+			return
+		}
+		log.Infow("node is ready, listening", "server", name)
+	}()
+	return nil
+}
+
+// Stop stops waiting for the node to be ready, so that no server starts afterwards. The servers
+// already started have to be stopped separately.
+func (g *Gate) Stop() {
+	g.cancel()
+	g.wg.Wait()
+}
@@ -0,0 +1,78 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/go-header/sync"
+
+	"github.com/celestiaorg/celestia-node/api/gateway"
+	modheader "github.com/celestiaorg/celestia-node/nodebuilder/header"
+	headerMock "github.com/celestiaorg/celestia-node/nodebuilder/header/mocks"
+)
+
+func TestGate(t *testing.T) {
+	ctx := context.Background()
+	var started []string
+	start := func(name string) func(context.Context) error {
+		return func(context.Context) error {
+			started = append(started, name)
+			return nil
+		}
+	}
+
+	// servers start right away, unless gated
+	gate := NewGate(nil, nil)
+	require.NoError(t, gate.Start(ctx, "rpc", start("rpc")))
+	assert.Equal(t, []string{"rpc"}, started)
+	gate.Stop()
+
+	ctrl := gomock.NewController(t)
+	headerMod := headerMock.NewMockModule(ctrl)
+	state := modheader.SyncState{State: sync.State{Height: 5, ToHeight: 10}}
+	headerMod.EXPECT().SyncState(gomock.Any()).Return(state, nil).AnyTimes()
+	cfg := gateway.ReadinessConfig{Checks: []string{gateway.CheckHeaderSync}}
+
+	// gated servers never start while the node is not ready
+	gate = NewGate(gateway.NewHealthHandler(cfg, nil, headerMod, nil, nil), nil)
+	require.NoError(t, gate.Start(ctx, "gateway", start("gateway")))
+	gate.Stop()
+	assert.Equal(t, []string{"rpc"}, started)
+}
+
+func TestGate_StartFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	headerMod := headerMock.NewMockModule(ctrl)
+	state := modheader.SyncState{State: sync.State{Height: 10, ToHeight: 10}}
+	headerMod.EXPECT().SyncState(gomock.Any()).Return(state, nil).AnyTimes()
+	cfg := gateway.ReadinessConfig{Checks: []string{gateway.CheckHeaderSync}}
+
+	// the node shuts down once a gated server fails to start
+	shutdowner := &testShutdowner{done: make(chan struct{})}
+	gate := NewGate(gateway.NewHealthHandler(cfg, nil, headerMod, nil, nil), shutdowner)
+	require.NoError(t, gate.Start(context.Background(), "gateway", func(context.Context) error {
+		return errors.New("address already in use")
+	}))
+	select {
+	case <-shutdowner.done:
+	case <-time.After(time.Second * 5):
+		t.Fatal("node was not shut down")
+	}
+	gate.Stop()
+}
+
+type testShutdowner struct {
+	done chan struct{}
+}
+
+func (s *testShutdowner) Shutdown(...fx.ShutdownOption) error {
+	close(s.done)
+	return nil
+}
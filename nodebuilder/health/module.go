@@ -0,0 +1,77 @@
+package health
+
+import (
+	"fmt"
+
+	logging "github.com/ipfs/go-log/v2"
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/celestia-node/api/gateway"
+	"github.com/celestiaorg/celestia-node/core"
+	"github.com/celestiaorg/celestia-node/nodebuilder/das"
+	"github.com/celestiaorg/celestia-node/nodebuilder/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+)
+
+var log = logging.Logger("module/health")
+
+// ConstructModule collects the readiness checks of the node, served by the RPC and the gateway.
+func ConstructModule(tp node.Type, cfg *Config) fx.Option {
+	// sanitize config values before constructing module
+	cfgErr := cfg.Validate()
+
+	baseComponents := fx.Options(
+		fx.Supply(*cfg),
+		fx.Error(cfgErr),
+		fx.Provide(func(cfg Config, health *gateway.HealthHandler, shutdowner fx.Shutdowner) *Gate {
+			if !cfg.GateListen {
+				return NewGate(nil, shutdowner)
+			}
+			return NewGate(health, shutdowner)
+		}),
+	)
+
+	switch tp {
+	case node.Light, node.Full:
+		return fx.Module(
+			"health",
+			baseComponents,
+			fx.Provide(func(cfg Config, deps dependencies) *gateway.HealthHandler {
+				return gateway.NewHealthHandler(cfg.Readiness, deps.P2P, deps.Header, deps.DAS, nil)
+			}),
+		)
+	case node.Bridge:
+		return fx.Module(
+			"health",
+			baseComponents,
+			// bridge nodes do not sample, but depend on Core instead
+			fx.Provide(func(cfg Config, deps dependencies, fetcher *core.BlockFetcher) *gateway.HealthHandler {
+				return gateway.NewHealthHandler(cfg.Readiness, deps.P2P, deps.Header, nil, fetcher)
+			}),
+		)
+	default:
+		return fx.Error(fmt.Errorf("module/health: invalid node type: %s", tp))
+	}
+}
+
+// ConstructSafeModeModule collects the Gate of a node running in safe mode, which is never ready
+// by the checks and so starts its servers right away.
+func ConstructSafeModeModule() fx.Option {
+	return fx.Module(
+		"health",
+		fx.Provide(func(shutdowner fx.Shutdowner) *Gate {
+			return NewGate(nil, shutdowner)
+		}),
+	)
+}
+
+// dependencies are the modules the readiness of the node is checked against. DAS can be left out
+// of the Node.
+type dependencies struct {
+	fx.In
+
+	P2P    p2p.Module
+	Header header.Module
+	DAS    das.Module `optional:"true"`
+}
@@ -133,8 +133,9 @@ func IsInit(path string) bool {
 		return false
 	}
 
+	// the Datastore is only missing while an interrupted store backend migration swaps it
 	if utils.Exists(keysPath(path)) &&
-		utils.Exists(dataPath(path)) {
+		(utils.Exists(dataPath(path)) || utils.Exists(migratingPath(path))) {
 		return true
 	}
 
@@ -185,6 +186,10 @@ func initDir(path string) error {
 // if account keys already exist. If not, it will generate a new account key and
 // store it.
 func generateKeys(cfg Config, ksPath string) error {
+	if cfg.State.Signer.Type == state.SignerRemote {
+		// the account key is kept by the remote signer
+		return nil
+	}
 	encConf := encoding.MakeConfig(app.ModuleEncodingRegisters...)
 
 	if cfg.State.KeyringBackend == keyring.BackendTest {
@@ -22,7 +22,8 @@ func TestInit(t *testing.T) {
 	nodes := []node.Type{node.Light, node.Bridge}
 
 	for _, node := range nodes {
-		cfg := DefaultConfig(node)
+		cfg, err := DefaultConfig(node)
+		require.NoError(t, err)
 		require.NoError(t, Init(*cfg, dir, node))
 		assert.True(t, IsInit(dir))
 	}
@@ -33,7 +34,8 @@ func TestInitErrForInvalidPath(t *testing.T) {
 	nodes := []node.Type{node.Light, node.Bridge}
 
 	for _, node := range nodes {
-		cfg := DefaultConfig(node)
+		cfg, err := DefaultConfig(node)
+		require.NoError(t, err)
 		require.Error(t, Init(*cfg, path, node))
 	}
 }
@@ -64,7 +66,8 @@ func TestInitErrForLockedDir(t *testing.T) {
 	nodes := []node.Type{node.Light, node.Bridge}
 
 	for _, node := range nodes {
-		cfg := DefaultConfig(node)
+		cfg, err := DefaultConfig(node)
+		require.NoError(t, err)
 		require.Error(t, Init(*cfg, dir, node))
 	}
 }
@@ -72,7 +75,8 @@ func TestInitErrForLockedDir(t *testing.T) {
 // TestInit_generateNewKey tests to ensure new account is generated
 // correctly.
 func TestInit_generateNewKey(t *testing.T) {
-	cfg := DefaultConfig(node.Bridge)
+	cfg, err := DefaultConfig(node.Bridge)
+	require.NoError(t, err)
 
 	encConf := encoding.MakeConfig(app.ModuleEncodingRegisters...)
 	ring, err := keyring.New(app.Name, cfg.State.KeyringBackend, t.TempDir(), os.Stdin, encConf.Codec)
@@ -0,0 +1,125 @@
+package nodebuilder
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/celestia-node/api/gateway"
+	"github.com/celestiaorg/celestia-node/api/rpc"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+// limitsServers are the API servers whose limits are adjusted by the limitsTuner.
+type limitsServers struct {
+	fx.In
+
+	RPC     *rpc.Server
+	Gateway *gateway.Server `optional:"true"`
+}
+
+// limitsTuner adjusts the limits of the API servers of the running node and persists them to the
+// config in the Store on request, so that they hold after a restart.
+type limitsTuner struct {
+	store   Store
+	rpc     *rpc.Server
+	gateway *gateway.Server
+
+	lock sync.Mutex
+}
+
+func newLimitsTuner(store Store, servers limitsServers) node.LimitsTuner {
+	return &limitsTuner{
+		store:   store,
+		rpc:     servers.RPC,
+		gateway: servers.Gateway,
+	}
+}
+
+func (t *limitsTuner) Limits() node.Limits {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	limits := node.Limits{
+		RPC: &node.RPCLimits{MaxConcurrentRequests: t.rpc.MaxConcurrentRequests()},
+	}
+	if t.gateway != nil {
+		cfg := t.gateway.RateLimit()
+		limits.Gateway = &node.GatewayLimits{
+			GlobalRate:  cfg.GlobalRate,
+			GlobalBurst: cfg.GlobalBurst,
+			PerIPRate:   cfg.PerIPRate,
+			PerIPBurst:  cfg.PerIPBurst,
+		}
+	}
+	return limits
+}
+
+// SetLimits validates all the limits before applying any of them. The config is left as is.
+func (t *limitsTuner) SetLimits(limits node.Limits) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	var rateLimit gateway.RateLimitConfig
+	if limits.RPC != nil && limits.RPC.MaxConcurrentRequests < 0 {
+		return fmt.Errorf("node: rpc max concurrent requests must not be negative, got %d",
+			limits.RPC.MaxConcurrentRequests)
+	}
+	if limits.Gateway != nil {
+		if t.gateway == nil {
+			return errors.New("node: gateway is disabled")
+		}
+		rateLimit = gateway.RateLimitConfig{
+			GlobalRate:  limits.Gateway.GlobalRate,
+			GlobalBurst: limits.Gateway.GlobalBurst,
+			PerIPRate:   limits.Gateway.PerIPRate,
+			PerIPBurst:  limits.Gateway.PerIPBurst,
+		}
+		if err := rateLimit.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if limits.RPC != nil {
+		t.rpc.SetMaxConcurrentRequests(limits.RPC.MaxConcurrentRequests)
+		log.Infow("adjusted rpc limits", "max concurrent requests", limits.RPC.MaxConcurrentRequests)
+	}
+	if limits.Gateway != nil {
+		if err := t.gateway.SetRateLimit(rateLimit); err != nil {
+			return err
+		}
+		log.Infow("adjusted gateway rate limits",
+			"global rate", rateLimit.GlobalRate,
+			"global burst", rateLimit.GlobalBurst,
+			"per IP rate", rateLimit.PerIPRate,
+			"per IP burst", rateLimit.PerIPBurst,
+		)
+	}
+	return nil
+}
+
+// PersistLimits saves the limits the node currently serves its APIs with to the config.
+func (t *limitsTuner) PersistLimits() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	cfg, err := t.store.Config()
+	if err != nil {
+		return err
+	}
+	// in-memory Stores are not required to hold a config
+	if cfg == nil {
+		return errors.New("node: no config to persist the limits to")
+	}
+	cfg.RPC.MaxConcurrentRequests = t.rpc.MaxConcurrentRequests()
+	if t.gateway != nil {
+		cfg.Gateway.RateLimit = t.gateway.RateLimit()
+	}
+	if err = t.store.PutConfig(cfg); err != nil {
+		return err
+	}
+	log.Info("persisted api limits to the config")
+	return nil
+}
@@ -0,0 +1,61 @@
+package nodebuilder
+
+import (
+	"testing"
+
+	"github.com/cristalhq/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/api/gateway"
+	"github.com/celestiaorg/celestia-node/api/rpc"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+func TestLimitsTuner(t *testing.T) {
+	store := NewMemStore()
+	cfg, err := DefaultConfig(node.Full)
+	require.NoError(t, err)
+	require.NoError(t, store.PutConfig(cfg))
+
+	signer, err := jwt.NewHS256(make([]byte, 32))
+	require.NoError(t, err)
+	rpcServ := rpc.NewServer("127.0.0.1", "0", signer)
+	tuner := newLimitsTuner(store, limitsServers{RPC: rpcServ})
+
+	limits := node.Limits{RPC: &node.RPCLimits{MaxConcurrentRequests: 100}}
+	require.NoError(t, tuner.SetLimits(limits))
+	assert.Equal(t, limits, tuner.Limits())
+	assert.Equal(t, 100, rpcServ.MaxConcurrentRequests())
+	// the limits are only persisted on request
+	cfg, err = store.Config()
+	require.NoError(t, err)
+	assert.Zero(t, cfg.RPC.MaxConcurrentRequests)
+	require.NoError(t, tuner.PersistLimits())
+	cfg, err = store.Config()
+	require.NoError(t, err)
+	assert.Equal(t, 100, cfg.RPC.MaxConcurrentRequests)
+
+	// the gateway is disabled
+	err = tuner.SetLimits(node.Limits{Gateway: &node.GatewayLimits{PerIPRate: 10, PerIPBurst: 20}})
+	require.Error(t, err)
+
+	gatewayServ := gateway.NewServer("127.0.0.1", "0")
+	tuner = newLimitsTuner(store, limitsServers{RPC: rpcServ, Gateway: gatewayServ})
+	gatewayLimits := &node.GatewayLimits{PerIPRate: 10, PerIPBurst: 20}
+	require.NoError(t, tuner.SetLimits(node.Limits{Gateway: gatewayLimits}))
+	assert.Equal(t, node.Limits{RPC: limits.RPC, Gateway: gatewayLimits}, tuner.Limits())
+	require.NoError(t, tuner.PersistLimits())
+	cfg, err = store.Config()
+	require.NoError(t, err)
+	assert.Equal(t, 10.0, cfg.Gateway.RateLimit.PerIPRate)
+	assert.Equal(t, 100, cfg.RPC.MaxConcurrentRequests)
+
+	// invalid limits are not applied
+	err = tuner.SetLimits(node.Limits{
+		RPC:     &node.RPCLimits{MaxConcurrentRequests: 5},
+		Gateway: &node.GatewayLimits{PerIPRate: 10},
+	})
+	require.Error(t, err)
+	assert.Equal(t, 100, rpcServ.MaxConcurrentRequests())
+}
@@ -0,0 +1,132 @@
+package logging
+
+import (
+	"fmt"
+	"strings"
+
+	golog "github.com/ipfs/go-log/v2"
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	levelFlag       = "log.level"
+	levelModuleFlag = "log.level.module"
+	formatFlag      = "log.format"
+)
+
+// levels are the levels accepted by the level flags.
+var levels = []string{"debug", "info", "warn", "error", "dpanic", "panic", "fatal"}
+
+// Flags gives a set of logging flags.
+func Flags() *flag.FlagSet {
+	flags := &flag.FlagSet{}
+
+	flags.String(
+		levelFlag,
+		"INFO",
+		`DEBUG, INFO, WARN, ERROR, DPANIC, PANIC, FATAL
+and their lower-case forms`,
+	)
+	flags.StringSlice(
+		levelModuleFlag,
+		nil,
+		"Comma-separated <module>=<level> pairs overriding the level of modules and their submodules, "+
+			"e.g. share=debug,das=warn. The <module>:<level> form is accepted as well",
+	)
+	flags.String(
+		formatFlag,
+		"",
+		fmt.Sprintf("Format of the log output: '%s' or '%s'. Defaults to the GOLOG_LOG_FMT environment "+
+			"variable or '%s'", FormatText, FormatJSON, FormatText),
+	)
+
+	return flags
+}
+
+// ParseFlags parses logging flags from the given cmd into a Config.
+func ParseFlags(cmd *cobra.Command) (Config, error) {
+	cfg := Config{
+		Format: Format(cmd.Flag(formatFlag).Value.String()),
+		Level:  cmd.Flag(levelFlag).Value.String(),
+	}
+	if cfg.Format != "" {
+		if err := cfg.Format.Validate(); err != nil {
+			return cfg, fmt.Errorf("cmd: while parsing '%s': %w", formatFlag, err)
+		}
+	}
+	if cfg.Level != "" {
+		if _, err := golog.LevelFromString(cfg.Level); err != nil {
+			return cfg, fmt.Errorf("cmd: while parsing '%s': %w", levelFlag, err)
+		}
+	}
+
+	modules, err := cmd.Flags().GetStringSlice(levelModuleFlag)
+	if err != nil {
+		return cfg, err
+	}
+	for _, ml := range modules {
+		module, level, ok := cutModuleLevel(ml)
+		if !ok {
+			return cfg, fmt.Errorf("cmd: %s arg must be in form <module>=<level>, e.g. share=debug", levelModuleFlag)
+		}
+		if _, err := golog.LevelFromString(level); err != nil {
+			return cfg, fmt.Errorf("cmd: while parsing '%s': %w", levelModuleFlag, err)
+		}
+		if cfg.ModuleLevels == nil {
+			cfg.ModuleLevels = make(map[string]string)
+		}
+		cfg.ModuleLevels[module] = level
+	}
+	return cfg, nil
+}
+
+// FlagCompletions gives the completions of the values of the logging flags, keyed by the flag
+// names.
+func FlagCompletions() map[string]func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return map[string]func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective){
+		levelFlag:       cobra.FixedCompletions(levels, cobra.ShellCompDirectiveNoFileComp),
+		levelModuleFlag: completeModuleLevel,
+		formatFlag: cobra.FixedCompletions(
+			[]string{string(FormatText), string(FormatJSON)},
+			cobra.ShellCompDirectiveNoFileComp,
+		),
+	}
+}
+
+// completeModuleLevel completes the <module>=<level> values, first the names of the modules, then
+// the levels.
+func completeModuleLevel(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	// the flag is a comma-separated list, so complete its last element
+	prefix := ""
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		prefix, toComplete = toComplete[:i+1], toComplete[i+1:]
+	}
+
+	if module, _, ok := strings.Cut(toComplete, "="); ok {
+		completions := make([]string, 0, len(levels))
+		for _, level := range levels {
+			completions = append(completions, prefix+module+"="+level)
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	modules := golog.GetSubsystems()
+	completions := make([]string, 0, len(modules))
+	for _, module := range modules {
+		completions = append(completions, prefix+module+"=")
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+// cutModuleLevel splits the <module>=<level> or <module>:<level> pair. Module names may contain
+// colons themselves, e.g. "bs:sess", so the level follows the last one.
+func cutModuleLevel(s string) (module, level string, ok bool) {
+	if module, level, ok := strings.Cut(s, "="); ok {
+		return module, level, true
+	}
+	if i := strings.LastIndex(s, ":"); i >= 0 {
+		return s[:i], s[i+1:], true
+	}
+	return "", "", false
+}
@@ -0,0 +1,149 @@
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	golog "github.com/ipfs/go-log/v2"
+
+	"github.com/celestiaorg/celestia-node/logs"
+)
+
+// Format is the format of the log output.
+type Format string
+
+const (
+	// FormatText outputs human-readable lines, colorized on terminals.
+	FormatText Format = "text"
+	// FormatJSON outputs a JSON object per line, for log aggregation pipelines.
+	FormatJSON Format = "json"
+)
+
+// Validate checks the Format is supported.
+func (f Format) Validate() error {
+	switch f {
+	case FormatText, FormatJSON:
+		return nil
+	default:
+		return fmt.Errorf("logging: unsupported format %q, expected %q or %q", f, FormatText, FormatJSON)
+	}
+}
+
+// Config configures the logging of the node.
+type Config struct {
+	// Format is the format of the log output. If empty, the format set with the GOLOG_LOG_FMT
+	// environment variable is kept.
+	Format Format
+	// Level is the level of all the loggers, e.g. "info" or "debug". If empty, the levels are kept.
+	Level string
+	// ModuleLevels overrides the levels of the modules, keyed by module names. A module covers its
+	// submodules as well, e.g. "share" sets the level of "share/getters" and "share/discovery",
+	// unless they are overridden themselves, as the more specific modules take precedence.
+	ModuleLevels map[string]string
+}
+
+// Setup applies the Config to the loggers. Modules are matched against the loggers registered at
+// the time, so Setup should be called once the packages of the node are initialized.
+func Setup(cfg Config) error {
+	if cfg.Format != "" {
+		if err := cfg.Format.Validate(); err != nil {
+			return err
+		}
+		setFormat(cfg.Format)
+	}
+
+	if cfg.Level != "" {
+		level, err := golog.LevelFromString(cfg.Level)
+		if err != nil {
+			return fmt.Errorf("logging: %w", err)
+		}
+		logs.SetAllLoggers(level)
+	}
+
+	// the modules are set from the least to the most specific, so that the levels of submodules
+	// override the ones of their parents rather than the other way around
+	modules := make([]string, 0, len(cfg.ModuleLevels))
+	for module := range cfg.ModuleLevels {
+		modules = append(modules, module)
+	}
+	sort.Slice(modules, func(i, j int) bool {
+		di, dj := moduleDepth(modules[i]), moduleDepth(modules[j])
+		if di != dj {
+			return di < dj
+		}
+		return modules[i] < modules[j]
+	})
+
+	var errs []error
+	for _, module := range modules {
+		if err := SetModuleLevel(module, cfg.ModuleLevels[module]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// moduleDepth is the specificity of the module, i.e. the amount of modules it is nested in. The "*"
+// module is the least specific of all.
+func moduleDepth(module string) int {
+	if module == "*" {
+		return -1
+	}
+	return strings.Count(module, "/")
+}
+
+// setFormat switches the output of all the loggers to the format, keeping their levels.
+func setFormat(format Format) {
+	cfg := golog.GetConfig()
+	switch format {
+	case FormatJSON:
+		cfg.Format = golog.JSONOutput
+	case FormatText:
+		if cfg.Format == golog.JSONOutput {
+			cfg.Format = golog.PlaintextOutput
+		}
+	}
+	setup(cfg)
+}
+
+// setup sets up the output of all the loggers, keeping their levels.
+func setup(cfg golog.Config) {
+	// setting up the output resets the levels, so restore them afterwards
+	levels := make(map[string]string)
+	for _, name := range golog.GetSubsystems() {
+		levels[name] = golog.Logger(name).Level().String()
+	}
+	golog.SetupLogging(cfg)
+	for name, level := range levels {
+		_ = golog.SetLogLevel(name, level)
+	}
+}
+
+// SetModuleLevel sets the level of the loggers of the module and its submodules, e.g. "share" sets
+// the level of "share/getters" as well. The "*" module sets the level of all the loggers.
+func SetModuleLevel(module, level string) error {
+	if module == "*" {
+		return golog.SetLogLevel(module, level)
+	}
+
+	expr := "^" + regexp.QuoteMeta(module) + "(/.*)?$"
+	matcher := regexp.MustCompile(expr)
+	known := false
+	for _, name := range golog.GetSubsystems() {
+		if matcher.MatchString(name) {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return fmt.Errorf("logging: unknown module %s", module)
+	}
+
+	if err := golog.SetLogLevelRegex(expr, level); err != nil {
+		return fmt.Errorf("logging: module %s: %w", module, err)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package logging
+
+import (
+	"testing"
+
+	golog "github.com/ipfs/go-log/v2"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestParseFlags(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().AddFlagSet(Flags())
+	require.NoError(t, cmd.ParseFlags([]string{
+		"--log.format", "json",
+		"--log.level", "warn",
+		"--log.level.module", "share=debug,das=error",
+		"--log.level.module", "bs:sess:info",
+	}))
+
+	cfg, err := ParseFlags(cmd)
+	require.NoError(t, err)
+	require.Equal(t, Config{
+		Format: FormatJSON,
+		Level:  "warn",
+		ModuleLevels: map[string]string{
+			"share":   "debug",
+			"das":     "error",
+			"bs:sess": "info",
+		},
+	}, cfg)
+
+	for _, args := range [][]string{
+		{"--log.format", "xml"},
+		{"--log.level", "loud"},
+		{"--log.level.module", "share"},
+		{"--log.level.module", "share=loud"},
+	} {
+		cmd := &cobra.Command{}
+		cmd.Flags().AddFlagSet(Flags())
+		require.NoError(t, cmd.ParseFlags(args))
+		_, err := ParseFlags(cmd)
+		require.Error(t, err, args)
+	}
+}
+
+func TestSetup_ModuleLevels(t *testing.T) {
+	prev := golog.GetConfig()
+	t.Cleanup(func() {
+		setup(prev)
+	})
+
+	parent := golog.Logger("logtest")
+	child := golog.Logger("logtest/child")
+	other := golog.Logger("logtestother")
+
+	err := Setup(Config{
+		Level:        "info",
+		ModuleLevels: map[string]string{"logtest": "debug"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, zapcore.DebugLevel, parent.Level())
+	require.Equal(t, zapcore.DebugLevel, child.Level())
+	require.Equal(t, zapcore.InfoLevel, other.Level())
+
+	// the levels are kept when switching the format
+	require.NoError(t, Setup(Config{Format: FormatJSON}))
+	require.Equal(t, golog.JSONOutput, golog.GetConfig().Format)
+	require.Equal(t, zapcore.DebugLevel, child.Level())
+	require.Equal(t, zapcore.InfoLevel, other.Level())
+
+	// the submodules override their parents and "*" whatever the order of the map
+	for i := 0; i < 10; i++ {
+		err = Setup(Config{
+			ModuleLevels: map[string]string{"logtest/child": "error", "logtest": "warn", "*": "info"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, zapcore.WarnLevel, parent.Level())
+		require.Equal(t, zapcore.ErrorLevel, child.Level())
+		require.Equal(t, zapcore.InfoLevel, other.Level())
+	}
+
+	require.Error(t, Setup(Config{ModuleLevels: map[string]string{"logtest/unknown": "debug"}}))
+}
+
+func TestSetModuleLevel(t *testing.T) {
+	parent := golog.Logger("levelset")
+	child := golog.Logger("levelset/child")
+	other := golog.Logger("levelsetother")
+	for _, name := range []string{"levelset", "levelset/child", "levelsetother"} {
+		require.NoError(t, golog.SetLogLevel(name, "info"))
+	}
+
+	// the submodules are covered, but not the modules sharing the prefix
+	require.NoError(t, SetModuleLevel("levelset", "debug"))
+	require.Equal(t, zapcore.DebugLevel, parent.Level())
+	require.Equal(t, zapcore.DebugLevel, child.Level())
+	require.Equal(t, zapcore.InfoLevel, other.Level())
+
+	// a submodule is set on its own
+	require.NoError(t, SetModuleLevel("levelset/child", "error"))
+	require.Equal(t, zapcore.DebugLevel, parent.Level())
+	require.Equal(t, zapcore.ErrorLevel, child.Level())
+
+	require.Error(t, SetModuleLevel("levelset/unknown", "debug"))
+	require.Error(t, SetModuleLevel("levelset", "loud"))
+}
+
+func TestRecentLogs(t *testing.T) {
+	log := golog.Logger("recent-test")
+	require.NoError(t, golog.SetLogLevel("recent-test", "info"))
+
+	recent := NewRecentLogs(2)
+	recent.Start()
+	log.Info("first")
+	log.Info("second")
+	log.Info("third")
+	require.NoError(t, recent.Stop())
+
+	// only the most recent lines are kept, the oldest first
+	lines := recent.Lines()
+	require.Len(t, lines, 2)
+	require.Contains(t, lines[0], "second")
+	require.Contains(t, lines[1], "third")
+}
@@ -0,0 +1,81 @@
+package logging
+
+import (
+	"bufio"
+	"strings"
+	"sync"
+
+	golog "github.com/ipfs/go-log/v2"
+)
+
+// RecentLogs keeps the most recent log lines in memory, so that they can be included in bug
+// reports without access to the output of the node.
+type RecentLogs struct {
+	lock  sync.Mutex
+	lines []string
+	// next is the position of the next line in lines, which wraps around once full.
+	next int
+	full bool
+
+	pipe *golog.PipeReader
+	done chan struct{}
+}
+
+// NewRecentLogs creates a new RecentLogs keeping up to the given amount of lines.
+func NewRecentLogs(size int) *RecentLogs {
+	return &RecentLogs{lines: make([]string, size)}
+}
+
+// Start starts recording the logs. Setting the logging up again with Setup stops the recording,
+// so it must be started afterwards.
+func (r *RecentLogs) Start() {
+	r.pipe = golog.NewPipeReader(golog.PipeFormat(golog.PlaintextOutput))
+	r.done = make(chan struct{})
+	go r.record()
+}
+
+// Stop stops recording the logs.
+func (r *RecentLogs) Stop() error {
+	err := r.pipe.Close()
+	<-r.done
+	return err
+}
+
+// Lines returns the recorded lines, the oldest first.
+func (r *RecentLogs) Lines() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if !r.full {
+		return append([]string(nil), r.lines[:r.next]...)
+	}
+	return append(append([]string(nil), r.lines[r.next:]...), r.lines[:r.next]...)
+}
+
+func (r *RecentLogs) record() {
+	defer close(r.done)
+	// the pipe is synchronous, so it is read until closed to never block the loggers
+	reader := bufio.NewReader(r.pipe)
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			r.add(strings.TrimSuffix(line, "\n"))
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (r *RecentLogs) add(line string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if len(r.lines) == 0 {
+		return
+	}
+	r.lines[r.next] = line
+	r.next++
+	if r.next == len(r.lines) {
+		r.next = 0
+		r.full = true
+	}
+}
@@ -0,0 +1,233 @@
+package nodebuilder
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdk "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/fx"
+)
+
+// defaultMetricsCardinalityLimit is the default amount of unique attribute sets exported per
+// instrument.
+const defaultMetricsCardinalityLimit = 2000
+
+// overflowAttrs are the attributes the data points of the attribute sets over the cardinality
+// limit are aggregated under, as recommended by the OpenTelemetry specification.
+var overflowAttrs = attribute.NewSet(attribute.Bool("otel.metric.overflow", true))
+
+// metricsCardinality caps the unique attribute sets exported per instrument, zero meaning no cap.
+type metricsCardinality int
+
+// WithMetricsCardinalityLimit overrides the amount of unique attribute sets exported per
+// instrument, protecting the collector from series explosions, e.g. of metrics labeled with peer
+// IDs. Zero disables the limit. Depends on WithMetrics or WithMetricsGRPC.
+func WithMetricsCardinalityLimit(limit int) fx.Option {
+	if limit < 0 {
+		return fx.Error(fmt.Errorf("nodebuilder: metrics cardinality limit must not be negative, got %d", limit))
+	}
+	return fx.Replace(metricsCardinality(limit))
+}
+
+// instrumentKey identifies an instrument across the exports.
+type instrumentKey struct {
+	scope string
+	name  string
+}
+
+// cardinalityLimiter is an exporter capping the unique attribute sets exported per instrument.
+// The first attribute sets of an instrument, up to the limit, are exported as is. The data points
+// of the other ones are aggregated under overflowAttrs for sums and histograms, and dropped for
+// gauges, whose values can not be aggregated.
+type cardinalityLimiter struct {
+	sdk.Exporter
+	limit int
+
+	lock sync.Mutex
+	// seen are the attribute sets exported so far per instrument
+	seen map[instrumentKey]map[attribute.Distinct]struct{}
+	// overflowed are the instruments warned about going over the limit
+	overflowed map[instrumentKey]struct{}
+}
+
+func newCardinalityLimiter(exp sdk.Exporter, limit int) *cardinalityLimiter {
+	return &cardinalityLimiter{
+		Exporter:   exp,
+		limit:      limit,
+		seen:       make(map[instrumentKey]map[attribute.Distinct]struct{}),
+		overflowed: make(map[instrumentKey]struct{}),
+	}
+}
+
+func (l *cardinalityLimiter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	l.lock.Lock()
+	limited := &metricdata.ResourceMetrics{
+		Resource:     rm.Resource,
+		ScopeMetrics: make([]metricdata.ScopeMetrics, len(rm.ScopeMetrics)),
+	}
+	for i, sm := range rm.ScopeMetrics {
+		limited.ScopeMetrics[i] = metricdata.ScopeMetrics{
+			Scope:   sm.Scope,
+			Metrics: make([]metricdata.Metrics, len(sm.Metrics)),
+		}
+		for j, m := range sm.Metrics {
+			limited.ScopeMetrics[i].Metrics[j] = l.limitMetrics(instrumentKey{scope: sm.Scope.Name, name: m.Name}, m)
+		}
+	}
+	l.lock.Unlock()
+	return l.Exporter.Export(ctx, limited)
+}
+
+// limitMetrics returns the metrics of the instrument without the attribute sets over the limit.
+func (l *cardinalityLimiter) limitMetrics(key instrumentKey, m metricdata.Metrics) metricdata.Metrics {
+	var overflow int
+	switch data := m.Data.(type) {
+	case metricdata.Gauge[int64]:
+		data.DataPoints, overflow = limitDataPoints(l, key, data.DataPoints, false)
+		m.Data = data
+	case metricdata.Gauge[float64]:
+		data.DataPoints, overflow = limitDataPoints(l, key, data.DataPoints, false)
+		m.Data = data
+	case metricdata.Sum[int64]:
+		data.DataPoints, overflow = limitDataPoints(l, key, data.DataPoints, true)
+		m.Data = data
+	case metricdata.Sum[float64]:
+		data.DataPoints, overflow = limitDataPoints(l, key, data.DataPoints, true)
+		m.Data = data
+	case metricdata.Histogram[int64]:
+		data.DataPoints, overflow = limitHistogramDataPoints(l, key, data.DataPoints)
+		m.Data = data
+	case metricdata.Histogram[float64]:
+		data.DataPoints, overflow = limitHistogramDataPoints(l, key, data.DataPoints)
+		m.Data = data
+	}
+
+	if _, ok := l.overflowed[key]; overflow > 0 && !ok {
+		l.overflowed[key] = struct{}{}
+		log.Warnw("metrics: instrument went over the cardinality limit, aggregating or dropping the attribute "+
+			"sets over it",
+			"scope", key.scope, "instrument", key.name, "limit", l.limit, "overflow", overflow)
+	}
+	return m
+}
+
+// admit reports whether the attribute set of the instrument is exported, admitting it if the
+// instrument is under the limit.
+func (l *cardinalityLimiter) admit(key instrumentKey, attrs attribute.Set) bool {
+	seen, ok := l.seen[key]
+	if !ok {
+		seen = make(map[attribute.Distinct]struct{})
+		l.seen[key] = seen
+	}
+	if _, ok := seen[attrs.Equivalent()]; ok {
+		return true
+	}
+	if len(seen) >= l.limit {
+		return false
+	}
+	seen[attrs.Equivalent()] = struct{}{}
+	return true
+}
+
+// limitDataPoints returns the data points of the admitted attribute sets and, if aggregate is set,
+// the sum of the other ones, along with the amount of the data points over the limit.
+func limitDataPoints[N int64 | float64](
+	l *cardinalityLimiter,
+	key instrumentKey,
+	points []metricdata.DataPoint[N],
+	aggregate bool,
+) ([]metricdata.DataPoint[N], int) {
+	limited := make([]metricdata.DataPoint[N], 0, len(points))
+	var overflow *metricdata.DataPoint[N]
+	var dropped int
+	for _, point := range points {
+		if l.admit(key, point.Attributes) {
+			limited = append(limited, point)
+			continue
+		}
+
+		dropped++
+		switch {
+		case !aggregate:
+		case overflow == nil:
+			overflow = &metricdata.DataPoint[N]{
+				Attributes: overflowAttrs,
+				StartTime:  point.StartTime,
+				Time:       point.Time,
+				Value:      point.Value,
+			}
+		default:
+			overflow.Value += point.Value
+			if point.StartTime.Before(overflow.StartTime) {
+				overflow.StartTime = point.StartTime
+			}
+		}
+	}
+	if overflow != nil {
+		limited = append(limited, *overflow)
+	}
+	return limited, dropped
+}
+
+// limitHistogramDataPoints returns the data points of the admitted attribute sets and the merge
+// of the other ones, along with the amount of the data points over the limit.
+func limitHistogramDataPoints[N int64 | float64](
+	l *cardinalityLimiter,
+	key instrumentKey,
+	points []metricdata.HistogramDataPoint[N],
+) ([]metricdata.HistogramDataPoint[N], int) {
+	limited := make([]metricdata.HistogramDataPoint[N], 0, len(points))
+	var overflow *metricdata.HistogramDataPoint[N]
+	var dropped int
+	for _, point := range points {
+		if l.admit(key, point.Attributes) {
+			limited = append(limited, point)
+			continue
+		}
+
+		dropped++
+		if overflow == nil {
+			overflow = &metricdata.HistogramDataPoint[N]{
+				Attributes:   overflowAttrs,
+				StartTime:    point.StartTime,
+				Time:         point.Time,
+				Count:        point.Count,
+				Bounds:       point.Bounds,
+				BucketCounts: append([]uint64(nil), point.BucketCounts...),
+				Min:          point.Min,
+				Max:          point.Max,
+				Sum:          point.Sum,
+			}
+			continue
+		}
+		// the data points of an instrument share the bounds, unless its view changed in between
+		if len(point.BucketCounts) != len(overflow.BucketCounts) {
+			continue
+		}
+		for i, count := range point.BucketCounts {
+			overflow.BucketCounts[i] += count
+		}
+		overflow.Count += point.Count
+		overflow.Sum += point.Sum
+		if point.StartTime.Before(overflow.StartTime) {
+			overflow.StartTime = point.StartTime
+		}
+		if v, ok := point.Min.Value(); ok {
+			if min, ok := overflow.Min.Value(); !ok || v < min {
+				overflow.Min = metricdata.NewExtrema(v)
+			}
+		}
+		if v, ok := point.Max.Value(); ok {
+			if max, ok := overflow.Max.Value(); !ok || v > max {
+				overflow.Max = metricdata.NewExtrema(v)
+			}
+		}
+	}
+	if overflow != nil {
+		limited = append(limited, *overflow)
+	}
+	return limited, dropped
+}
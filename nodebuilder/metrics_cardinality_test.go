@@ -0,0 +1,112 @@
+package nodebuilder
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdk "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestCardinalityLimiter(t *testing.T) {
+	ctx := context.Background()
+
+	reader := sdk.NewManualReader()
+	provider := sdk.NewMeterProvider(sdk.WithReader(reader))
+	meter := provider.Meter("test")
+	counter, err := meter.Int64Counter("requests")
+	require.NoError(t, err)
+	histogram, err := meter.Float64Histogram("latency")
+	require.NoError(t, err)
+
+	record := func(peers int) {
+		for i := 0; i < peers; i++ {
+			attrs := metric.WithAttributes(attribute.String("peer", strconv.Itoa(i)))
+			counter.Add(ctx, 1, attrs)
+			histogram.Record(ctx, float64(i), attrs)
+		}
+	}
+
+	exp := &recordingExporter{}
+	limiter := newCardinalityLimiter(exp, 3)
+	export := func() map[string]metricdata.Aggregation {
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(ctx, &rm))
+		require.NoError(t, limiter.Export(ctx, &rm))
+		data := make(map[string]metricdata.Aggregation)
+		for _, m := range exp.last.ScopeMetrics[0].Metrics {
+			data[m.Name] = m.Data
+		}
+		return data
+	}
+
+	// under the limit, everything is exported as is
+	record(3)
+	data := export()
+	assert.Len(t, data["requests"].(metricdata.Sum[int64]).DataPoints, 3)
+	assert.Len(t, data["latency"].(metricdata.Histogram[float64]).DataPoints, 3)
+
+	// over the limit, the new attribute sets are aggregated, while the admitted ones stay
+	record(10)
+	data = export()
+	sum := data["requests"].(metricdata.Sum[int64])
+	require.Len(t, sum.DataPoints, 4)
+	var overflow int64
+	for _, point := range sum.DataPoints {
+		if point.Attributes.Equals(&overflowAttrs) {
+			overflow = point.Value
+			continue
+		}
+		assert.EqualValues(t, 2, point.Value)
+	}
+	assert.EqualValues(t, 7, overflow)
+
+	hist := data["latency"].(metricdata.Histogram[float64])
+	require.Len(t, hist.DataPoints, 4)
+	last := hist.DataPoints[3]
+	assert.True(t, last.Attributes.Equals(&overflowAttrs))
+	assert.EqualValues(t, 7, last.Count)
+	assert.EqualValues(t, 3+4+5+6+7+8+9, last.Sum)
+	min, _ := last.Min.Value()
+	max, _ := last.Max.Value()
+	assert.EqualValues(t, 3, min)
+	assert.EqualValues(t, 9, max)
+}
+
+func TestCardinalityLimiterGauge(t *testing.T) {
+	exp := &recordingExporter{}
+	limiter := newCardinalityLimiter(exp, 1)
+
+	points := []metricdata.DataPoint[float64]{
+		{Attributes: attribute.NewSet(attribute.String("peer", "a")), Value: 1},
+		{Attributes: attribute.NewSet(attribute.String("peer", "b")), Value: 2},
+	}
+	rm := &metricdata.ResourceMetrics{ScopeMetrics: []metricdata.ScopeMetrics{{
+		Metrics: []metricdata.Metrics{{Name: "peer_score", Data: metricdata.Gauge[float64]{DataPoints: points}}},
+	}}}
+	require.NoError(t, limiter.Export(context.Background(), rm))
+
+	// gauges can not be aggregated, so the attribute sets over the limit are dropped
+	gauge := exp.last.ScopeMetrics[0].Metrics[0].Data.(metricdata.Gauge[float64])
+	require.Len(t, gauge.DataPoints, 1)
+	assert.EqualValues(t, 1, gauge.DataPoints[0].Value)
+	// the input is left untouched
+	assert.Len(t, rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Gauge[float64]).DataPoints, 2)
+}
+
+// recordingExporter keeps the last exported metrics.
+type recordingExporter struct {
+	sdk.Exporter
+
+	last *metricdata.ResourceMetrics
+}
+
+func (e *recordingExporter) Export(_ context.Context, rm *metricdata.ResourceMetrics) error {
+	e.last = rm
+	return nil
+}
@@ -3,23 +3,40 @@ package nodebuilder
 import (
 	"context"
 
+	"github.com/ipfs/go-datastore"
 	"go.uber.org/fx"
 
 	"github.com/celestiaorg/celestia-node/libs/fxutil"
 	"github.com/celestiaorg/celestia-node/nodebuilder/blob"
+	"github.com/celestiaorg/celestia-node/nodebuilder/clock"
 	"github.com/celestiaorg/celestia-node/nodebuilder/core"
 	"github.com/celestiaorg/celestia-node/nodebuilder/das"
+	"github.com/celestiaorg/celestia-node/nodebuilder/debug"
+	"github.com/celestiaorg/celestia-node/nodebuilder/features"
 	"github.com/celestiaorg/celestia-node/nodebuilder/fraud"
 	"github.com/celestiaorg/celestia-node/nodebuilder/gateway"
 	"github.com/celestiaorg/celestia-node/nodebuilder/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/health"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+	"github.com/celestiaorg/celestia-node/nodebuilder/pruner"
+	"github.com/celestiaorg/celestia-node/nodebuilder/quota"
 	"github.com/celestiaorg/celestia-node/nodebuilder/rpc"
 	"github.com/celestiaorg/celestia-node/nodebuilder/share"
 	"github.com/celestiaorg/celestia-node/nodebuilder/state"
+	"github.com/celestiaorg/celestia-node/nodebuilder/watchdog"
+	"github.com/celestiaorg/celestia-node/nodebuilder/watcher"
 )
 
-func ConstructModule(tp node.Type, network p2p.Network, cfg *Config, store Store) fx.Option {
+// ConstructModule collects the modules of a Node of the given type, except the ones left out with
+// the Without options.
+func ConstructModule(
+	tp node.Type,
+	network p2p.Network,
+	cfg *Config,
+	store Store,
+	without ...optionalModule,
+) fx.Option {
 	log.Infow("Accessing keyring...")
 	ks, err := store.Keystore()
 	if err != nil {
@@ -30,8 +47,22 @@ func ConstructModule(tp node.Type, network p2p.Network, cfg *Config, store Store
 		fx.Error(err)
 	}
 
+	// custom node types run the modules of the built-in type they build upon
+	base := tp.Base()
+
+	skip := make(map[optionalModule]bool, len(without))
+	for _, module := range without {
+		skip[module] = true
+	}
+	optional := func(module optionalModule, opt fx.Option) fx.Option {
+		if skip[module] {
+			return fx.Options()
+		}
+		return opt
+	}
+
 	baseComponents := fx.Options(
-		fx.Supply(tp),
+		fx.Supply(base),
 		fx.Supply(network),
 		fx.Provide(p2p.BootstrappersFor),
 		fx.Provide(func(lc fx.Lifecycle) context.Context {
@@ -39,26 +70,70 @@ func ConstructModule(tp node.Type, network p2p.Network, cfg *Config, store Store
 		}),
 		fx.Supply(cfg),
 		fx.Supply(store.Config),
-		fx.Provide(store.Datastore),
+		// writes are refused over the hard disk quota
+		fx.Provide(func(m *quota.Monitor) (datastore.Batching, error) {
+			ds, err := store.Datastore()
+			if err != nil {
+				return nil, err
+			}
+			return m.Datastore(ds), nil
+		}),
 		fx.Provide(store.Keystore),
 		fx.Supply(node.StorePath(store.Path())),
 		fx.Supply(signer),
 		// modules provided by the node
-		p2p.ConstructModule(tp, &cfg.P2P),
-		state.ConstructModule(tp, &cfg.State),
-		header.ConstructModule(tp, &cfg.Header),
-		share.ConstructModule(tp, &cfg.Share),
-		rpc.ConstructModule(tp, &cfg.RPC),
-		gateway.ConstructModule(tp, &cfg.Gateway),
-		core.ConstructModule(tp, &cfg.Core),
-		das.ConstructModule(tp, &cfg.DASer),
-		fraud.ConstructModule(tp),
-		blob.ConstructModule(),
+		p2p.ConstructModule(base, &cfg.P2P),
+		optional(stateModule, state.ConstructModule(base, &cfg.State)),
+		header.ConstructModule(base, &cfg.Header),
+		clock.ConstructModule(&cfg.Clock),
+		watchdog.ConstructModule(&cfg.Watchdog),
+		features.ConstructModule(&cfg.Features),
+		share.ConstructModule(base, &cfg.Share),
+		rpc.ConstructModule(base, &cfg.RPC),
+		optional(gatewayModule, gateway.ConstructModule(base, &cfg.Gateway)),
+		health.ConstructModule(base, &cfg.Health),
+		core.ConstructModule(base, &cfg.Core),
+		optional(dasModule, das.ConstructModule(base, &cfg.DASer)),
+		pruner.ConstructModule(base, &cfg.Pruner),
+		quota.ConstructModule(&cfg.Quota),
+		fraud.ConstructModule(base),
+		optional(blobModule, blob.ConstructModule(&cfg.Blob)),
+		watcher.ConstructModule(&cfg.Watcher),
+		debug.ConstructModule(),
 		node.ConstructModule(tp),
+		fx.Provide(func(servers limitsServers) node.LimitsTuner {
+			return newLimitsTuner(store, servers)
+		}),
 	)
 
 	return fx.Module(
 		"node",
 		baseComponents,
+		fx.Options(customModulesOf(tp)...),
+	)
+}
+
+// ConstructSafeModeModule collects the modules of a Node running in safe mode. It omits everything
+// connecting to the network or running in the background, keeping only the header module
+// reading from the store and the rpc and admin modules to access it.
+func ConstructSafeModeModule(tp node.Type, network p2p.Network, cfg *Config, store Store) fx.Option {
+	return fx.Module(
+		"node",
+		fx.Supply(tp.Base()),
+		fx.Supply(network),
+		fx.Provide(p2p.BootstrappersFor),
+		fx.Provide(func(lc fx.Lifecycle) context.Context {
+			return fxutil.WithLifecycle(context.Background(), lc)
+		}),
+		fx.Supply(cfg),
+		fx.Supply(store.Config),
+		fx.Provide(store.Datastore),
+		fx.Provide(store.Keystore),
+		fx.Supply(node.StorePath(store.Path())),
+		header.ConstructSafeModeModule(&cfg.Header),
+		features.ConstructModule(&cfg.Features),
+		rpc.ConstructSafeModeModule(&cfg.RPC),
+		health.ConstructSafeModeModule(),
+		node.ConstructModule(tp),
 	)
 }
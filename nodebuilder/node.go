@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/ipfs/go-blockservice"
 	exchange "github.com/ipfs/go-ipfs-exchange-interface"
@@ -41,6 +42,7 @@ var (
 // * Bridge
 // * Light
 // * Full
+// Downstream builds can define custom types upon them with RegisterNodeType.
 type Node struct {
 	fx.In `ignore-unexported:"true"`
 
@@ -53,25 +55,30 @@ type Node struct {
 	RPCServer     *rpc.Server     // not optional
 	GatewayServer *gateway.Server `optional:"true"`
 
-	// p2p components
-	Host         host.Host
-	ConnGater    *conngater.BasicConnectionGater
-	Routing      routing.PeerRouting
-	DataExchange exchange.Interface
-	BlockService blockservice.BlockService
+	// p2p components, absent in safe mode
+	Host         host.Host                       `optional:"true"`
+	ConnGater    *conngater.BasicConnectionGater `optional:"true"`
+	Routing      routing.PeerRouting             `optional:"true"`
+	DataExchange exchange.Interface              `optional:"true"`
+	BlockService blockservice.BlockService       `optional:"true"`
 	// p2p protocols
-	PubSub *pubsub.PubSub
-	// services
-	ShareServ  share.Module  // not optional
+	PubSub *pubsub.PubSub `optional:"true"`
+	// services, only the header and admin services are available in safe mode
+	ShareServ  share.Module  `optional:"true"`
 	HeaderServ header.Module // not optional
-	StateServ  state.Module  // not optional
-	FraudServ  fraud.Module  // not optional
-	BlobServ   blob.Module   // not optional
-	DASer      das.Module    // not optional
+	StateServ  state.Module  `optional:"true"`
+	FraudServ  fraud.Module  `optional:"true"`
+	BlobServ   blob.Module   `optional:"true"`
+	DASer      das.Module    `optional:"true"`
+	P2PServ    p2p.Module    `optional:"true"`
 	AdminServ  node.Module   // not optional
 
+	// safeMode is set for Nodes assembled with NewSafeMode
+	safeMode bool
 	// start and stop control ref internal fx.App lifecycle funcs to be called from Start and Stop
 	start, stop lifecycleFunc
+	// shutdownTraces is set if traces are exported, to be called once the fx.App has stopped
+	shutdownTraces shutdownTraces
 }
 
 // New assembles a new Node with the given type 'tp' over Store 'store'.
@@ -87,8 +94,37 @@ func New(tp node.Type, network p2p.Network, store Store, options ...fx.Option) (
 // NewWithConfig assembles a new Node with the given type 'tp' over Store 'store' and a custom
 // config.
 func NewWithConfig(tp node.Type, network p2p.Network, store Store, cfg *Config, options ...fx.Option) (*Node, error) {
-	opts := append([]fx.Option{ConstructModule(tp, network, cfg, store)}, options...)
-	return newNode(opts...)
+	cfg, err := openStoreConfig(store, cfg)
+	if err != nil {
+		return nil, err
+	}
+	opts := append([]fx.Option{ConstructModule(tp, network, cfg, store, withoutModules(options)...)}, options...)
+	nd, err := newNode(opts...)
+	if err != nil {
+		return nil, err
+	}
+	// the modules are given the base type, so the custom type is set explicitly
+	nd.Type = tp
+	return nd, nil
+}
+
+// NewSafeMode assembles a new Node with the given type 'tp' over Store 'store' in safe mode, to
+// diagnose and extract data from a damaged Store. The Node does not connect to the network or run
+// any background jobs and serves only the header and admin services over RPC. The Store is expected
+// to be opened with OpenStoreReadOnly.
+func NewSafeMode(tp node.Type, network p2p.Network, store Store, cfg *Config, options ...fx.Option) (*Node, error) {
+	cfg, err := openStoreConfig(store, cfg)
+	if err != nil {
+		return nil, err
+	}
+	opts := append([]fx.Option{ConstructSafeModeModule(tp, network, cfg, store)}, options...)
+	nd, err := newNode(opts...)
+	if err != nil {
+		return nil, err
+	}
+	nd.Type = tp
+	nd.safeMode = true
+	return nd, nil
 }
 
 // Start launches the Node and all its components and services.
@@ -109,6 +145,11 @@ func (n *Node) Start(ctx context.Context) error {
 	log.Infof("\n\n/_____/  /_____/  /_____/  /_____/  /_____/ \n\nStarted celestia DA node \nnode "+
 		"type: 	%s\nnetwork: 	%s\n\n/_____/  /_____/  /_____/  /_____/  /_____/ \n", strings.ToLower(n.Type.String()),
 		n.Network)
+	if n.safeMode {
+		log.Warn("Node is running in safe mode: the store is read-only, the node is not connected to the " +
+			"network and only the header and node services are served over RPC")
+		return nil
+	}
 
 	addrs, err := peer.AddrInfoToP2pAddrs(host.InfoFromHost(n.Host))
 	if err != nil {
@@ -140,10 +181,16 @@ func (n *Node) Run(ctx context.Context) error {
 // remaining Modules/Services to close immediately.
 func (n *Node) Stop(ctx context.Context) error {
 	to := n.Config.Node.ShutdownTimeout
-	ctx, cancel := context.WithTimeout(ctx, to)
+	stopCtx, cancel := context.WithTimeout(ctx, to)
 	defer cancel()
 
-	err := n.stop(ctx)
+	err := n.stop(stopCtx)
+	if n.shutdownTraces != nil {
+		// the traces are shut down last, so that the spans of stopping the node are exported
+		if err := n.shutdownTraces(ctx); err != nil {
+			log.Warnw("shutting down traces", "err", err)
+		}
+	}
 	if err != nil {
 		log.Debugf("error stopping %s Node: %s", n.Type, err)
 		if errors.Is(err, context.DeadlineExceeded) {
@@ -162,20 +209,34 @@ func (n *Node) Stop(ctx context.Context) error {
 // Light, unless we decide to give package users the ability to create custom node types themselves.
 func newNode(opts ...fx.Option) (*Node, error) {
 	node := new(Node)
+	zl := &fxevent.ZapLogger{Logger: fxLog.Desugar()}
+	zl.UseLogLevel(zapcore.DebugLevel)
+	timer := newStartupTimer(zl)
+	fxTracer := newFxTracer(timer)
+	var traces struct {
+		fx.In
+
+		Shutdown shutdownTraces `optional:"true"`
+	}
+
+	start := time.Now()
 	app := fx.New(
 		fx.WithLogger(func() fxevent.Logger {
-			zl := &fxevent.ZapLogger{Logger: fxLog.Desugar()}
-			zl.UseLogLevel(zapcore.DebugLevel)
-			return zl
+			return timer
 		}),
 		fx.Populate(node),
 		fx.Options(opts...),
+		fx.Populate(&traces),
 	)
+	fxTracer.constructed(start, app.Err())
 	if err := app.Err(); err != nil {
 		return nil, err
 	}
+	timer.construction(time.Since(start))
 
-	node.start, node.stop = app.Start, app.Stop
+	node.start = timer.timeStart(fxTracer.traceLifecycle("start", app.Start))
+	node.stop = fxTracer.traceLifecycle("stop", app.Stop)
+	node.shutdownTraces = traces.Shutdown
 	return node, nil
 }
 
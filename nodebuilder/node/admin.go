@@ -5,22 +5,39 @@ import (
 
 	"github.com/cristalhq/jwt"
 	"github.com/filecoin-project/go-jsonrpc/auth"
-	logging "github.com/ipfs/go-log/v2"
+	"go.uber.org/fx"
 
 	"github.com/celestiaorg/celestia-node/libs/authtoken"
+	"github.com/celestiaorg/celestia-node/nodebuilder/features"
+	modlogging "github.com/celestiaorg/celestia-node/nodebuilder/logging"
 )
 
 const APIVersion = "v0.2.1"
 
 type module struct {
-	tp     Type
-	signer jwt.Signer
+	tp       Type
+	signer   jwt.Signer
+	features features.Set
+	debug    debugSources
+	limits   limitsSource
+	core     coreSource
 }
 
-func newModule(tp Type, signer jwt.Signer) Module {
+func newModule(
+	tp Type,
+	signer jwt.Signer,
+	features features.Set,
+	debug debugSources,
+	limits limitsSource,
+	core coreSource,
+) Module {
 	return &module{
-		tp:     tp,
-		signer: signer,
+		tp:       tp,
+		signer:   signer,
+		features: features,
+		debug:    debug,
+		limits:   limits,
+		core:     core,
 	}
 }
 
@@ -29,17 +46,45 @@ func newModule(tp Type, signer jwt.Signer) Module {
 type Info struct {
 	Type       Type   `json:"type"`
 	APIVersion string `json:"api_version"`
+	// CoreEndpoint is the address of the Core endpoint Bridge nodes are connected to.
+	CoreEndpoint string `json:"core_endpoint,omitempty"`
+	// CoreStateEndpoint is the address of the gRPC endpoint of Core the state module queries state
+	// and submits transactions through. Unlike CoreEndpoint, it does not fail over.
+	CoreStateEndpoint string `json:"core_state_endpoint,omitempty"`
+}
+
+// CoreEndpoint reports the active Core endpoint of Bridge nodes. It is provided by the core
+// module, which depends on this one.
+type CoreEndpoint interface {
+	ActiveEndpoint() string
+}
+
+// CoreStateEndpoint is the address of the gRPC endpoint of Core the state module is connected to.
+// It is provided by the state module, which depends on this one.
+type CoreStateEndpoint string
+
+// coreSource is the CoreEndpoint of the node, missing on nodes not connected to Core.
+type coreSource struct {
+	fx.In
+
+	Endpoint      CoreEndpoint      `optional:"true"`
+	StateEndpoint CoreStateEndpoint `optional:"true"`
 }
 
 func (m *module) Info(context.Context) (Info, error) {
-	return Info{
+	info := Info{
 		Type:       m.tp,
 		APIVersion: APIVersion,
-	}, nil
+	}
+	if m.core.Endpoint != nil {
+		info.CoreEndpoint = m.core.Endpoint.ActiveEndpoint()
+	}
+	info.CoreStateEndpoint = string(m.core.StateEndpoint)
+	return info, nil
 }
 
 func (m *module) LogLevelSet(_ context.Context, name, level string) error {
-	return logging.SetLogLevel(name, level)
+	return modlogging.SetModuleLevel(name, level)
 }
 
 func (m *module) AuthVerify(_ context.Context, token string) ([]auth.Permission, error) {
@@ -49,3 +94,7 @@ func (m *module) AuthVerify(_ context.Context, token string) ([]auth.Permission,
 func (m *module) AuthNew(_ context.Context, permissions []auth.Permission) (string, error) {
 	return authtoken.NewSignedJWT(m.signer, permissions)
 }
+
+func (m *module) Features(context.Context) ([]string, error) {
+	return m.features.List(), nil
+}
@@ -10,6 +10,12 @@ var defaultLifecycleTimeout = time.Minute * 2
 type Config struct {
 	StartupTimeout  time.Duration
 	ShutdownTimeout time.Duration
+	// RuntimeMetrics enables the metrics of the Go runtime (GC pauses, goroutines, heap) along with
+	// the metrics of the node, if those are enabled.
+	RuntimeMetrics bool
+	// HostMetrics enables the metrics of the host (CPU, memory, network and the disk space of the
+	// store) along with the metrics of the node, if those are enabled.
+	HostMetrics bool
 }
 
 // DefaultConfig returns the default node configuration for a given node type.
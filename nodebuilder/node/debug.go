@@ -0,0 +1,82 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"runtime/pprof"
+
+	sdk "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/fx"
+
+	modlogging "github.com/celestiaorg/celestia-node/nodebuilder/logging"
+)
+
+// recentLogsSize is the amount of the most recent log lines kept for DebugSnapshot.
+const recentLogsSize = 2000
+
+// DebugSnapshot is the state of the running node needed to make sense of a bug report.
+type DebugSnapshot struct {
+	// Goroutines is the stack trace of every goroutine of the node.
+	Goroutines string `json:"goroutines"`
+	// Logs are the most recent log lines of the node, the oldest first.
+	Logs []string `json:"logs"`
+	// Metrics is the JSON encoded snapshot of the metrics of the node, empty unless metrics are
+	// enabled.
+	Metrics json.RawMessage `json:"metrics,omitempty"`
+}
+
+// MetricsReader collects the metrics of the node on demand for DebugSnapshot. It is provided once
+// metrics are enabled.
+type MetricsReader interface {
+	sdk.Reader
+}
+
+// debugSources are the components the DebugSnapshot is taken from.
+type debugSources struct {
+	fx.In
+
+	Logs    *modlogging.RecentLogs
+	Metrics MetricsReader `optional:"true"`
+}
+
+// recentLogs records the most recent log lines for as long as the node runs.
+func recentLogs(lc fx.Lifecycle) *modlogging.RecentLogs {
+	logs := modlogging.NewRecentLogs(recentLogsSize)
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			logs.Start()
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			return logs.Stop()
+		},
+	})
+	return logs
+}
+
+func (m *module) DebugSnapshot(ctx context.Context) (DebugSnapshot, error) {
+	var goroutines bytes.Buffer
+	if err := pprof.Lookup("goroutine").WriteTo(&goroutines, 2); err != nil {
+		return DebugSnapshot{}, fmt.Errorf("dumping goroutines: %w", err)
+	}
+
+	snapshot := DebugSnapshot{
+		Goroutines: goroutines.String(),
+		Logs:       m.debug.Logs.Lines(),
+	}
+	if m.debug.Metrics != nil {
+		var metrics metricdata.ResourceMetrics
+		if err := m.debug.Metrics.Collect(ctx, &metrics); err != nil {
+			return DebugSnapshot{}, fmt.Errorf("collecting metrics: %w", err)
+		}
+		raw, err := json.Marshal(metrics)
+		if err != nil {
+			return DebugSnapshot{}, fmt.Errorf("encoding metrics: %w", err)
+		}
+		snapshot.Metrics = raw
+	}
+	return snapshot, nil
+}
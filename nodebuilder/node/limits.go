@@ -0,0 +1,70 @@
+package node
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/fx"
+)
+
+var errLimitsUnavailable = errors.New("node: limits cannot be adjusted on this node")
+
+// Limits are the limits the node serves its APIs with.
+type Limits struct {
+	// RPC are the limits of the RPC API.
+	RPC *RPCLimits `json:"rpc,omitempty"`
+	// Gateway are the limits of the gateway, nil if the gateway is disabled.
+	Gateway *GatewayLimits `json:"gateway,omitempty"`
+}
+
+// RPCLimits are the limits of the RPC API.
+type RPCLimits struct {
+	// MaxConcurrentRequests is the amount of requests served at once, excluding WebSocket
+	// connections. Zero means no limit.
+	MaxConcurrentRequests int `json:"max_concurrent_requests"`
+}
+
+// GatewayLimits are the rate limits of the gateway. A limit is disabled when its rate is zero.
+type GatewayLimits struct {
+	GlobalRate  float64 `json:"global_rate"`
+	GlobalBurst int     `json:"global_burst"`
+	PerIPRate   float64 `json:"per_ip_rate"`
+	PerIPBurst  int     `json:"per_ip_burst"`
+}
+
+// LimitsTuner adjusts the Limits of the running node. It is provided by the node, as the APIs are
+// constructed by the modules depending on this one.
+type LimitsTuner interface {
+	Limits() Limits
+	SetLimits(Limits) error
+	PersistLimits() error
+}
+
+// limitsSource is the LimitsTuner of the node, missing on nodes whose limits cannot be adjusted,
+// e.g. in safe mode.
+type limitsSource struct {
+	fx.In
+
+	Tuner LimitsTuner `optional:"true"`
+}
+
+func (m *module) Limits(context.Context) (Limits, error) {
+	if m.limits.Tuner == nil {
+		return Limits{}, errLimitsUnavailable
+	}
+	return m.limits.Tuner.Limits(), nil
+}
+
+func (m *module) SetLimits(_ context.Context, limits Limits) error {
+	if m.limits.Tuner == nil {
+		return errLimitsUnavailable
+	}
+	return m.limits.Tuner.SetLimits(limits)
+}
+
+func (m *module) PersistLimits(context.Context) error {
+	if m.limits.Tuner == nil {
+		return errLimitsUnavailable
+	}
+	return m.limits.Tuner.PersistLimits()
+}
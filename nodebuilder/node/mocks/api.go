@@ -67,6 +67,36 @@ func (mr *MockModuleMockRecorder) AuthVerify(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthVerify", reflect.TypeOf((*MockModule)(nil).AuthVerify), arg0, arg1)
 }
 
+// DebugSnapshot mocks base method.
+func (m *MockModule) DebugSnapshot(arg0 context.Context) (node.DebugSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DebugSnapshot", arg0)
+	ret0, _ := ret[0].(node.DebugSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DebugSnapshot indicates an expected call of DebugSnapshot.
+func (mr *MockModuleMockRecorder) DebugSnapshot(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DebugSnapshot", reflect.TypeOf((*MockModule)(nil).DebugSnapshot), arg0)
+}
+
+// Features mocks base method.
+func (m *MockModule) Features(arg0 context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Features", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Features indicates an expected call of Features.
+func (mr *MockModuleMockRecorder) Features(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Features", reflect.TypeOf((*MockModule)(nil).Features), arg0)
+}
+
 // Info mocks base method.
 func (m *MockModule) Info(arg0 context.Context) (node.Info, error) {
 	m.ctrl.T.Helper()
@@ -82,6 +112,21 @@ func (mr *MockModuleMockRecorder) Info(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*MockModule)(nil).Info), arg0)
 }
 
+// Limits mocks base method.
+func (m *MockModule) Limits(arg0 context.Context) (node.Limits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Limits", arg0)
+	ret0, _ := ret[0].(node.Limits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Limits indicates an expected call of Limits.
+func (mr *MockModuleMockRecorder) Limits(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Limits", reflect.TypeOf((*MockModule)(nil).Limits), arg0)
+}
+
 // LogLevelSet mocks base method.
 func (m *MockModule) LogLevelSet(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -95,3 +140,31 @@ func (mr *MockModuleMockRecorder) LogLevelSet(arg0, arg1, arg2 interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogLevelSet", reflect.TypeOf((*MockModule)(nil).LogLevelSet), arg0, arg1, arg2)
 }
+
+// PersistLimits mocks base method.
+func (m *MockModule) PersistLimits(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PersistLimits", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PersistLimits indicates an expected call of PersistLimits.
+func (mr *MockModuleMockRecorder) PersistLimits(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PersistLimits", reflect.TypeOf((*MockModule)(nil).PersistLimits), arg0)
+}
+
+// SetLimits mocks base method.
+func (m *MockModule) SetLimits(arg0 context.Context, arg1 node.Limits) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLimits", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLimits indicates an expected call of SetLimits.
+func (mr *MockModuleMockRecorder) SetLimits(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLimits", reflect.TypeOf((*MockModule)(nil).SetLimits), arg0, arg1)
+}
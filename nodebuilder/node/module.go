@@ -3,14 +3,23 @@ package node
 import (
 	"github.com/cristalhq/jwt"
 	"go.uber.org/fx"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/features"
 )
 
 func ConstructModule(tp Type) fx.Option {
 	return fx.Module(
 		"node",
-		fx.Provide(func(secret jwt.Signer) Module {
-			return newModule(tp, secret)
+		fx.Provide(func(
+			secret jwt.Signer,
+			features features.Set,
+			debug debugSources,
+			limits limitsSource,
+			core coreSource,
+		) Module {
+			return newModule(tp, secret, features, debug, limits, core)
 		}),
 		fx.Provide(secret),
+		fx.Provide(recentLogs),
 	)
 }
@@ -14,23 +14,47 @@ type Module interface {
 	// Info returns administrative information about the node.
 	Info(context.Context) (Info, error)
 
-	// LogLevelSet sets the given component log level to the given level.
+	// LogLevelSet sets the log level of the given component and its subcomponents on the running
+	// node, e.g. "shrex" covers "shrex/eds" and "shrex/nd". The "*" component sets the level of all
+	// the components.
 	LogLevelSet(ctx context.Context, name, level string) error
 
 	// AuthVerify returns the permissions assigned to the given token.
 	AuthVerify(ctx context.Context, token string) ([]auth.Permission, error)
 	// AuthNew signs and returns a new token with the given permissions.
 	AuthNew(ctx context.Context, perms []auth.Permission) (string, error)
+
+	// Features returns the names of the experimental features enabled on the node.
+	Features(context.Context) ([]string, error)
+
+	// DebugSnapshot returns the goroutines, the most recent logs and the metrics of the node, to be
+	// attached to bug reports.
+	DebugSnapshot(context.Context) (DebugSnapshot, error)
+
+	// Limits returns the limits the node serves its APIs with.
+	Limits(context.Context) (Limits, error)
+	// SetLimits adjusts the limits the node serves its APIs with, without restarting it. The APIs
+	// whose Limits are nil are left unchanged. The limits only hold until the node restarts,
+	// unless persisted with PersistLimits.
+	SetLimits(context.Context, Limits) error
+	// PersistLimits saves the limits the node currently serves its APIs with to its config, so that
+	// they hold after a restart.
+	PersistLimits(context.Context) error
 }
 
 var _ Module = (*API)(nil)
 
 type API struct {
 	Internal struct {
-		Info        func(context.Context) (Info, error)                                `perm:"admin"`
-		LogLevelSet func(ctx context.Context, name, level string) error                `perm:"admin"`
-		AuthVerify  func(ctx context.Context, token string) ([]auth.Permission, error) `perm:"admin"`
-		AuthNew     func(ctx context.Context, perms []auth.Permission) (string, error) `perm:"admin"`
+		Info          func(context.Context) (Info, error)                                `perm:"admin"`
+		LogLevelSet   func(ctx context.Context, name, level string) error                `perm:"admin"`
+		AuthVerify    func(ctx context.Context, token string) ([]auth.Permission, error) `perm:"admin"`
+		AuthNew       func(ctx context.Context, perms []auth.Permission) (string, error) `perm:"admin"`
+		Features      func(context.Context) ([]string, error)                            `perm:"admin"`
+		DebugSnapshot func(context.Context) (DebugSnapshot, error)                       `perm:"admin"`
+		Limits        func(context.Context) (Limits, error)                              `perm:"admin"`
+		SetLimits     func(context.Context, Limits) error                                `perm:"admin"`
+		PersistLimits func(context.Context) error                                        `perm:"admin"`
 	}
 }
 
@@ -49,3 +73,23 @@ func (api *API) AuthVerify(ctx context.Context, token string) ([]auth.Permission
 func (api *API) AuthNew(ctx context.Context, perms []auth.Permission) (string, error) {
 	return api.Internal.AuthNew(ctx, perms)
 }
+
+func (api *API) Features(ctx context.Context) ([]string, error) {
+	return api.Internal.Features(ctx)
+}
+
+func (api *API) DebugSnapshot(ctx context.Context) (DebugSnapshot, error) {
+	return api.Internal.DebugSnapshot(ctx)
+}
+
+func (api *API) Limits(ctx context.Context) (Limits, error) {
+	return api.Internal.Limits(ctx)
+}
+
+func (api *API) SetLimits(ctx context.Context, limits Limits) error {
+	return api.Internal.SetLimits(ctx, limits)
+}
+
+func (api *API) PersistLimits(ctx context.Context) error {
+	return api.Internal.PersistLimits(ctx)
+}
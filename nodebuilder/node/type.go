@@ -1,5 +1,11 @@
 package node
 
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
 // Type defines the Node type (e.g. `light`, `bridge`) for identity purposes.
 // The zero value for Type is invalid.
 type Type uint8
@@ -22,20 +28,37 @@ const (
 
 // String converts Type to its string representation.
 func (t Type) String() string {
-	if !t.IsValid() {
+	typesLock.RLock()
+	defer typesLock.RUnlock()
+	str, ok := typeToString[t]
+	if !ok {
 		return "unknown"
 	}
-	return typeToString[t]
+	return str
+}
+
+// Base returns the built-in Type the Type builds upon. Built-in Types are their own base.
+func (t Type) Base() Type {
+	typesLock.RLock()
+	defer typesLock.RUnlock()
+	if base, ok := baseOf[t]; ok {
+		return base
+	}
+	return t
 }
 
 // IsValid reports whether the Type is valid.
 func (t Type) IsValid() bool {
+	typesLock.RLock()
+	defer typesLock.RUnlock()
 	_, ok := typeToString[t]
 	return ok
 }
 
 // ParseType converts string in a type if possible.
 func ParseType(str string) Type {
+	typesLock.RLock()
+	defer typesLock.RUnlock()
 	tp, ok := stringToType[str]
 	if !ok {
 		return 0
@@ -44,6 +67,39 @@ func ParseType(str string) Type {
 	return tp
 }
 
+// RegisterType registers a custom Type with the given name, building upon the given built-in base
+// Type, so that downstream builds can define node types of their own. The name must be unique.
+// It is safe for concurrent use, but has to be called before any node of the type is constructed.
+func RegisterType(name string, base Type) (Type, error) {
+	if name == "" {
+		return 0, errors.New("node: type name must not be empty")
+	}
+	if !base.IsValid() || base.Base() != base {
+		return 0, fmt.Errorf("node: base type %s must be a built-in type", base)
+	}
+
+	typesLock.Lock()
+	defer typesLock.Unlock()
+	if _, ok := stringToType[name]; ok {
+		return 0, fmt.Errorf("node: type %s is already registered", name)
+	}
+
+	tp := Type(len(typeToString) + 1)
+	if tp == 0 {
+		return 0, errors.New("node: too many types registered")
+	}
+	typeToString[tp] = name
+	stringToType[name] = tp
+	baseOf[tp] = base
+	return tp, nil
+}
+
+// typesLock guards the registry of Types, which custom Types are registered into.
+var typesLock sync.RWMutex
+
+// baseOf maps the custom Types to the built-in Types they build upon.
+var baseOf = map[Type]Type{}
+
 // typeToString keeps string representations of all valid Types.
 var typeToString = map[Type]string{
 	Bridge: "Bridge",
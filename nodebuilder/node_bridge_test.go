@@ -14,7 +14,9 @@ import (
 
 func TestBridge_WithMockedCoreClient(t *testing.T) {
 	t.Skip("skipping") // consult https://github.com/celestiaorg/celestia-core/issues/667 for reasoning
-	repo := MockStore(t, DefaultConfig(node.Bridge))
+	cfg, err := DefaultConfig(node.Bridge)
+	require.NoError(t, err)
+	repo := MockStore(t, cfg)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	t.Cleanup(cancel)
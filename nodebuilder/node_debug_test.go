@@ -0,0 +1,55 @@
+//go:build debug
+
+package nodebuilder
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/nodebuilder/debug"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+	"github.com/celestiaorg/celestia-node/state"
+)
+
+func TestDebugModule(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshLinked(2)
+	require.NoError(t, err)
+	hst, other := net.Hosts()[0], net.Hosts()[1]
+
+	var mod debug.Module
+	nd := TestNode(t, node.Light, p2p.WithHost(hst), fx.Populate(&mod))
+	require.NoError(t, nd.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, nd.Stop(ctx))
+	})
+
+	// the getter used by the share module is delayed
+	require.NoError(t, mod.DelayGetter(ctx, time.Hour))
+	getCtx, getCancel := context.WithTimeout(ctx, time.Millisecond*100)
+	defer getCancel()
+	_, err = nd.ShareServ.GetEDS(getCtx, headertest.RandExtendedHeader(t).DAH)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.NoError(t, mod.DelayGetter(ctx, 0))
+
+	require.NoError(t, mod.FailSubmissions(ctx, 1))
+	_, err = nd.StateServ.SubmitTx(ctx, nil)
+	require.ErrorIs(t, err, state.ErrInjectedFault)
+
+	// the connection to the dropped peer is closed
+	// (the node may dial it again afterwards, so only the existing connection is checked)
+	conn, err := net.ConnectPeers(hst.ID(), other.ID())
+	require.NoError(t, err)
+	require.False(t, conn.IsClosed())
+	require.NoError(t, mod.DropPeer(ctx, other.ID()))
+	require.True(t, conn.IsClosed())
+}
@@ -31,7 +31,8 @@ func TestLight_WithMutualPeers(t *testing.T) {
 		"/ip6/100:0:114b:abc5:e13a:c32f:7a9e:f00a/tcp/2121/p2p/12D3KooWSRqDfpLsQxpyUhLC9oXHD2WuZ2y5FWzDri7LT4Dw9fSi",
 		"/ip4/192.168.1.10/tcp/2121/p2p/12D3KooWSRqDfpLsQxpyUhLC9oXHD2WuZ2y5FWzDri7LT4Dw9fSi",
 	}
-	cfg := DefaultConfig(nodebuilder.Light)
+	cfg, err := DefaultConfig(nodebuilder.Light)
+	require.NoError(t, err)
 	cfg.P2P.MutualPeers = peers
 	node := TestNodeWithConfig(t, nodebuilder.Light, cfg)
 
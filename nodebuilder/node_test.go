@@ -6,15 +6,35 @@ import (
 	"net/http/httptest"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/golang/mock/gomock"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	semconv "go.opentelemetry.io/otel/semconv/v1.11.0"
 	collectormetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"go.uber.org/fx"
 	"google.golang.org/protobuf/proto"
 
+	headerstore "github.com/celestiaorg/go-header/store"
+
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/libs/fxutil"
+	"github.com/celestiaorg/celestia-node/nodebuilder/core"
+	"github.com/celestiaorg/celestia-node/nodebuilder/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/health"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+	"github.com/celestiaorg/celestia-node/nodebuilder/state"
 	"github.com/celestiaorg/celestia-node/share"
+	sharemocks "github.com/celestiaorg/celestia-node/share/mocks"
+	"github.com/celestiaorg/celestia-node/share/sharetest"
 )
 
 func TestLifecycle(t *testing.T) {
@@ -82,6 +102,10 @@ func TestLifecycle_WithMetrics(t *testing.T) {
 					},
 					tt.tp,
 				),
+				WithNamespaceMetrics([]share.Namespace{sharetest.RandV0Namespace()}, tt.tp),
+				WithMetricsInstrumentation(true, true),
+				WithMetricsExport(time.Second, time.Second),
+				WithResourceAttributes(map[string]string{"region": "eu"}),
 			)
 			require.NotNil(t, node)
 			require.NotNil(t, node.Config)
@@ -108,6 +132,45 @@ func TestLifecycle_WithMetrics(t *testing.T) {
 	}
 }
 
+func TestLifecycle_WithTraces(t *testing.T) {
+	var exported atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/traces" {
+			exported.Add(1)
+		}
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	node := TestNode(
+		t,
+		node.Light,
+		WithTraces(
+			[]otlptracehttp.Option{
+				otlptracehttp.WithEndpoint(strings.ReplaceAll(server.URL, "http://", "")),
+				otlptracehttp.WithInsecure(),
+			},
+			nil,
+		),
+		WithResourceAttributes(map[string]string{"region": "eu"}),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := node.Start(ctx)
+	require.NoError(t, err)
+
+	_, span := otel.Tracer("test").Start(ctx, "span")
+	span.End()
+
+	err = node.Stop(ctx)
+	require.NoError(t, err)
+	// ensure buffered spans were flushed on stop
+	require.NotZero(t, exported.Load())
+}
+
 func StartMockOtelCollectorHTTPServer(t *testing.T) (string, func()) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/v1/metrics" && r.Method != http.MethodPost {
@@ -164,3 +227,180 @@ func TestEmptyBlockExists(t *testing.T) {
 	}
 
 }
+
+func TestSafeMode(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	dir := t.TempDir()
+	cfg, err := DefaultConfig(node.Light)
+	require.NoError(t, err)
+	cfg.RPC.Port = "0"
+	require.NoError(t, Init(*cfg, dir, node.Light))
+
+	// populate the header store of a regular Store
+	store, err := OpenStore(dir, nil)
+	require.NoError(t, err)
+	ds, err := store.Datastore()
+	require.NoError(t, err)
+	head := headertest.RandExtendedHeader(t)
+	hstore, err := headerstore.NewStoreWithHead(ctx, ds, head)
+	require.NoError(t, err)
+	require.NoError(t, hstore.Start(ctx))
+	require.NoError(t, hstore.Stop(ctx))
+	require.NoError(t, store.Close())
+
+	store, err = OpenStoreReadOnly(dir, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, store.Close())
+	})
+	require.ErrorIs(t, store.PutConfig(cfg), ErrReadOnly)
+
+	nd, err := NewSafeMode(node.Light, p2p.Private, store, cfg)
+	require.NoError(t, err)
+	require.Nil(t, nd.Host)
+	require.Nil(t, nd.DASer)
+	require.NoError(t, nd.Start(ctx))
+
+	localHead, err := nd.HeaderServ.LocalHead(ctx)
+	require.NoError(t, err)
+	require.Equal(t, head.Hash(), localHead.Hash())
+
+	_, err = nd.HeaderServ.GetByHeight(ctx, uint64(head.Height())+1)
+	require.Error(t, err)
+	_, err = nd.HeaderServ.NetworkHead(ctx)
+	require.ErrorIs(t, err, header.ErrSafeMode)
+
+	require.NoError(t, nd.Stop(ctx))
+}
+
+func TestLifecycle_CustomType(t *testing.T) {
+	var invoked bool
+	tp, err := RegisterNodeType("Archival", node.Full, fx.Invoke(func() { invoked = true }))
+	require.NoError(t, err)
+	require.Equal(t, "Archival", tp.String())
+	require.Equal(t, node.Full, tp.Base())
+	require.Equal(t, tp, node.ParseType("Archival"))
+
+	_, err = RegisterNodeType("Archival", node.Full)
+	require.Error(t, err)
+	_, err = RegisterNodeType("Derived", tp)
+	require.Error(t, err)
+
+	nd := TestNode(t, tp)
+	require.Equal(t, tp, nd.Type)
+	require.True(t, invoked)
+	// the custom type runs the modules of its base type
+	require.NotNil(t, nd.DASer)
+	require.NotNil(t, nd.ShareServ)
+
+	info, err := nd.AdminServ.Info(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, tp, info.Type)
+}
+
+func TestConstructModule_InvalidType(t *testing.T) {
+	// modules reject invalid types with an error instead of panicking
+	for _, opt := range []fx.Option{
+		core.ConstructModule(node.Type(0), new(core.Config)),
+		header.ConstructModule(node.Type(0), new(header.Config)),
+		health.ConstructModule(node.Type(0), new(health.Config)),
+		state.ConstructModule(node.Type(0), new(state.Config)),
+	} {
+		require.Error(t, fx.New(opt).Err())
+	}
+}
+
+func TestWithMetrics_InvalidType(t *testing.T) {
+	app := fx.New(WithMetrics(nil, node.Type(0)))
+	require.Error(t, app.Err())
+}
+
+func TestWithNamespaceMetrics_InvalidType(t *testing.T) {
+	app := fx.New(WithNamespaceMetrics([]share.Namespace{sharetest.RandV0Namespace()}, node.Type(0)))
+	require.Error(t, app.Err())
+}
+
+func TestWithMetricsExport_Invalid(t *testing.T) {
+	app := fx.New(WithMetricsExport(0, time.Second))
+	require.Error(t, app.Err())
+}
+
+func TestWithTracesShutdownTimeout_Invalid(t *testing.T) {
+	for _, timeout := range []time.Duration{0, -time.Second} {
+		app := fx.New(WithTracesShutdownTimeout(timeout))
+		require.Error(t, app.Err())
+	}
+}
+
+func TestNodeResource(t *testing.T) {
+	res := resourceParams{Attributes: resourceAttributes{
+		attribute.String("region", "eu"),
+		attribute.String(string(semconv.ServiceNameKey), "spoofed"),
+	}}
+	id := peer.ID("peer")
+	r := nodeResource(node.Light, p2p.Mocha, id, res)
+
+	region, ok := r.Set().Value("region")
+	require.True(t, ok)
+	require.Equal(t, "eu", region.AsString())
+	// the attributes of the node can not be overridden
+	name, ok := r.Set().Value(semconv.ServiceNameKey)
+	require.True(t, ok)
+	require.Equal(t, p2p.Mocha.String()+"/"+id.String(), name.AsString())
+}
+
+func TestLifecycle_WithoutModules(t *testing.T) {
+	cfg, err := DefaultConfig(node.Light)
+	require.NoError(t, err)
+	cfg.Gateway.Enabled = true
+	cfg.Gateway.Port = "0"
+
+	nd := TestNodeWithConfig(t, node.Light, cfg, WithoutGateway(), WithoutState(), WithoutDAS(), WithoutBlob())
+	require.Nil(t, nd.GatewayServer)
+	require.Nil(t, nd.StateServ)
+	require.Nil(t, nd.DASer)
+	require.Nil(t, nd.BlobServ)
+	require.NotNil(t, nd.HeaderServ)
+	require.NotNil(t, nd.ShareServ)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	require.NoError(t, nd.Start(ctx))
+	require.NoError(t, nd.Stop(ctx))
+}
+
+func TestNewWithOptions(t *testing.T) {
+	cfg, err := DefaultConfig(node.Light)
+	require.NoError(t, err)
+	cfg.RPC.Port = "0"
+	store := MockStore(t, cfg)
+	ks, err := store.Keystore()
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	getter := sharemocks.NewMockGetter(ctrl)
+	getter.EXPECT().GetShare(gomock.Any(), gomock.Any(), 0, 0).Return(share.Share("share"), nil)
+
+	nd, err := NewWithOptions(node.Light, store, cfg,
+		WithNetwork(p2p.Private),
+		WithShareGetter(getter),
+		WithoutState(),
+		state.WithKeyringSigner(TestKeyringSigner(t, ks.Keyring())),
+		fx.Replace(node.StorePath(t.TempDir())),
+		fxutil.ReplaceAs(headertest.NewStore(t), new(header.InitStore)),
+	)
+	require.NoError(t, err)
+	require.Equal(t, p2p.Private, nd.Network)
+	require.NotNil(t, nd.Header())
+	require.NotNil(t, nd.Share())
+	require.NotNil(t, nd.P2P())
+	require.NotNil(t, nd.Admin())
+	require.Nil(t, nd.State())
+
+	// the share module serves the injected getter
+	shr, err := nd.Share().GetShare(context.Background(), share.EmptyRoot(), 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, share.Share("share"), shr)
+}
@@ -0,0 +1,37 @@
+package nodebuilder
+
+import (
+	"sync"
+
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+var (
+	// customModules are the modules of the custom node types registered with RegisterNodeType.
+	customModules     = map[node.Type][]fx.Option{}
+	customModulesLock sync.RWMutex
+)
+
+// RegisterNodeType registers a custom node type with the given name, so that downstream builds can
+// define node types of their own. The custom type builds upon the given built-in base type: it
+// runs the modules and uses the config defaults of the base type, along with the given modules.
+// It is safe for concurrent use, but has to be called before any node of the type is constructed.
+func RegisterNodeType(name string, base node.Type, modules ...fx.Option) (node.Type, error) {
+	tp, err := node.RegisterType(name, base)
+	if err != nil {
+		return 0, err
+	}
+	customModulesLock.Lock()
+	defer customModulesLock.Unlock()
+	customModules[tp] = modules
+	return tp, nil
+}
+
+// customModulesOf returns the modules registered for the custom node type, if any.
+func customModulesOf(tp node.Type) []fx.Option {
+	customModulesLock.RLock()
+	defer customModulesLock.RUnlock()
+	return customModules[tp]
+}
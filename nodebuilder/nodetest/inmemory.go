@@ -0,0 +1,136 @@
+// Package nodetest runs celestia nodes in-process, for the integration tests of the applications
+// built on top of them, e.g. rollups.
+package nodetest
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-jsonrpc/auth"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/celestia-app/test/util/testnode"
+	apptypes "github.com/celestiaorg/celestia-app/x/blob/types"
+	libhead "github.com/celestiaorg/go-header"
+
+	"github.com/celestiaorg/celestia-node/api/rpc/client"
+	"github.com/celestiaorg/celestia-node/api/rpc/perms"
+	"github.com/celestiaorg/celestia-node/core"
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder"
+	coremodule "github.com/celestiaorg/celestia-node/nodebuilder/core"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+	"github.com/celestiaorg/celestia-node/nodebuilder/state"
+)
+
+// startTimeout bounds the time to start the node.
+const startTimeout = time.Minute
+
+// InMemoryNode is a Bridge Node running in-process, backed by an in-process core producing blocks.
+// It serves the standard module APIs, directly and over RPC, and submits transactions from an
+// account funded at genesis.
+//
+// The core is a single validator of celestia-app rather than a fake one, so that submitted blobs
+// are executed and laid out in the squares exactly as on a network. The headers, the keys and the
+// config of the node are kept in memory, while the extended squares are kept in a temporary
+// directory, as the EDS store is backed by files. The node has no network peers.
+type InMemoryNode struct {
+	*nodebuilder.Node
+
+	// Client accesses the node over RPC with admin permissions, the way applications access it.
+	Client *client.Client
+	// Core is the in-process core the node is backed by. Its Keyring holds the funded accounts.
+	Core testnode.Context
+}
+
+// NewInMemoryNode starts an InMemoryNode with the given options, stopping it once the test and
+// its subtests complete.
+//
+// The node starts once core has produced its first block, which are produced every 200ms.
+func NewInMemoryNode(t *testing.T, options ...fx.Option) *InMemoryNode {
+	ctx, cancel := context.WithTimeout(context.Background(), startTimeout)
+	defer cancel()
+
+	coreCfg := core.DefaultTestConfig()
+	cctx := core.StartTestNodeWithConfig(t, coreCfg)
+	_, err := cctx.WaitForHeightWithTimeout(1, startTimeout)
+	require.NoError(t, err)
+
+	cfg, err := nodebuilder.DefaultConfig(node.Bridge)
+	require.NoError(t, err)
+	cfg.Core.IP, cfg.Core.GRPCPort, err = net.SplitHostPort(coreCfg.App.GRPC.Address)
+	require.NoError(t, err)
+	// avoids port conflicts
+	cfg.RPC.Port = "0"
+	store := nodebuilder.MockStore(t, cfg)
+	ks, err := store.Keystore()
+	require.NoError(t, err)
+	key, err := p2p.Key(ks)
+	require.NoError(t, err)
+
+	// the node is the only peer of its network, so it opens no ports
+	network := mocknet.New()
+	t.Cleanup(func() {
+		require.NoError(t, network.Close())
+	})
+	host, err := network.AddPeer(key, ma.StringCast("/ip4/127.0.0.1/tcp/2121"))
+	require.NoError(t, err)
+
+	signer := apptypes.NewKeyringSigner(cctx.Keyring, coreCfg.Accounts[0], cctx.ChainID)
+	options = append([]fx.Option{
+		coremodule.WithClient(cctx.Client),
+		state.WithKeyringSigner(signer),
+		p2p.WithHost(host),
+		fx.Replace(node.StorePath(t.TempDir())),
+		// the headers are synced from the first block, which is empty, so nothing is stored
+		// before the node starts
+		fx.Invoke(func(
+			ctx context.Context,
+			store libhead.Store[*header.ExtendedHeader],
+			ex libhead.Exchange[*header.ExtendedHeader],
+		) error {
+			genesis, err := ex.GetByHeight(ctx, 1)
+			if err != nil {
+				return err
+			}
+			return store.Init(ctx, genesis)
+		}),
+	}, options...)
+	nd, err := nodebuilder.New(node.Bridge, p2p.Private, store, options...)
+	require.NoError(t, err)
+	require.NoError(t, nd.Start(ctx))
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), startTimeout)
+		defer cancel()
+		require.NoError(t, nd.Stop(ctx))
+	})
+
+	token, err := nd.AdminServ.AuthNew(ctx, perms.AllPerms)
+	require.NoError(t, err)
+	imn := &InMemoryNode{
+		Node: nd,
+		Core: cctx,
+	}
+	imn.Client, err = client.NewClient(ctx, imn.RPCAddr(), token)
+	require.NoError(t, err)
+	t.Cleanup(imn.Client.Close)
+	return imn
+}
+
+// RPCAddr returns the URL of the RPC API of the node.
+func (n *InMemoryNode) RPCAddr() string {
+	return "http://" + n.RPCServer.ListenAddr()
+}
+
+// AuthToken returns a token with the given permissions to access the RPC API of the node.
+func (n *InMemoryNode) AuthToken(t *testing.T, permissions ...auth.Permission) string {
+	token, err := n.AdminServ.AuthNew(context.Background(), permissions)
+	require.NoError(t, err)
+	return token
+}
@@ -0,0 +1,51 @@
+package nodetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/api/rpc/client"
+	"github.com/celestiaorg/celestia-node/blob"
+	"github.com/celestiaorg/celestia-node/blob/blobtest"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+func TestInMemoryNode(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	t.Cleanup(cancel)
+
+	nd := NewInMemoryNode(t)
+
+	appBlobs, err := blobtest.GenerateV0Blobs([]int{4}, false)
+	require.NoError(t, err)
+	b, err := blob.NewBlobV0(append([]byte{appBlobs[0].NamespaceVersion}, appBlobs[0].NamespaceID...),
+		appBlobs[0].Data)
+	require.NoError(t, err)
+
+	height, err := nd.Client.Blob.Submit(ctx, []*blob.Blob{b})
+	require.NoError(t, err)
+	_, err = nd.Client.Header.WaitForHeight(ctx, height)
+	require.NoError(t, err)
+
+	blobs, err := nd.Client.Blob.GetAll(ctx, height, []share.Namespace{b.Namespace()})
+	require.NoError(t, err)
+	require.Len(t, blobs, 1)
+	require.Equal(t, b.Commitment, blobs[0].Commitment)
+
+	// tokens with narrower permissions are issued for the clients under test
+	readClient, err := client.NewClient(ctx, nd.RPCAddr(), nd.AuthToken(t, "public", "read"))
+	require.NoError(t, err)
+	t.Cleanup(readClient.Close)
+	_, err = readClient.Blob.Submit(ctx, []*blob.Blob{b})
+	require.Error(t, err)
+	_, err = readClient.Header.NetworkHead(ctx)
+	require.NoError(t, err)
+
+	// the endpoints of core are reported, as the state module doesn't fail over between them
+	info, err := nd.Client.Node.Info(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, info.CoreStateEndpoint)
+}
@@ -14,7 +14,9 @@ import (
 	"github.com/libp2p/go-libp2p/core/protocol"
 	"go.uber.org/fx"
 
+	"github.com/celestiaorg/celestia-node/nodebuilder/quota"
 	"github.com/celestiaorg/celestia-node/share/eds"
+	sharep2p "github.com/celestiaorg/celestia-node/share/p2p"
 )
 
 const (
@@ -31,7 +33,7 @@ func dataExchange(params bitSwapParams) exchange.Interface {
 	prefix := protocol.ID(fmt.Sprintf("/celestia/%s", params.Net))
 	return bitswap.New(
 		params.Ctx,
-		network.NewFromIpfsHost(params.Host, &routinghelpers.Null{}, network.Prefix(prefix)),
+		network.NewFromIpfsHost(params.Limiter.Host(params.Host), &routinghelpers.Null{}, network.Prefix(prefix)),
 		params.Bs,
 		bitswap.ProvideEnabled(false),
 		// NOTE: These below ar required for our protocol to work reliably.
@@ -41,16 +43,29 @@ func dataExchange(params bitSwapParams) exchange.Interface {
 	)
 }
 
-func blockstoreFromDatastore(ctx context.Context, ds datastore.Batching) (blockstore.Blockstore, error) {
-	return blockstore.CachedBlockstore(
-		ctx,
-		blockstore.NewBlockstore(ds),
+type datastoreBlockstoreParams struct {
+	fx.In
+
+	Ctx context.Context
+	Ds  datastore.Batching
+	// Quota refuses new blocks over the hard disk quota, if the quota module provides it.
+	Quota *quota.Monitor `optional:"true"`
+}
+
+func blockstoreFromDatastore(params datastoreBlockstoreParams) (blockstore.Blockstore, error) {
+	bs, err := blockstore.CachedBlockstore(
+		params.Ctx,
+		blockstore.NewBlockstore(params.Ds),
 		blockstore.CacheOpts{
 			HasBloomFilterSize:   defaultBloomFilterSize,
 			HasBloomFilterHashes: defaultBloomFilterHashes,
 			HasARCCacheSize:      defaultARCCacheSize,
 		},
 	)
+	if err != nil {
+		return nil, err
+	}
+	return params.Quota.Blockstore(bs), nil
 }
 
 func blockstoreFromEDSStore(ctx context.Context, store *eds.Store) (blockstore.Blockstore, error) {
@@ -70,4 +85,7 @@ type bitSwapParams struct {
 	Net  Network
 	Host hst.Host
 	Bs   blockstore.Blockstore
+	// Limiter caps the bandwidth of serving blocks over inbound streams, if the share module
+	// provides it. Bitswap sends most of the blocks over the streams it opens, which are not shaped.
+	Limiter *sharep2p.BandwidthLimiter `optional:"true"`
 }
@@ -14,8 +14,16 @@ const defaultRoutingRefreshPeriod = time.Minute
 
 // Config combines all configuration fields for P2P subsystem.
 type Config struct {
-	// ListenAddresses - Addresses to listen to on local NIC.
+	// ListenAddresses - Addresses to listen to on local NIC. Addresses of disabled Transports are
+	// skipped.
 	ListenAddresses []string
+	// Transports selects the transports to dial and listen with. TCP, QUIC and WebTransport are
+	// enabled by default.
+	Transports transportsConfig
+	// Relay configures circuit relay v2 for nodes that are not publicly dialable.
+	Relay relayConfig
+	// ResourceManager overrides the connection, stream and memory limits of the resource manager.
+	ResourceManager resourceManagerConfig
 	// AnnounceAddresses - Addresses to be announced/advertised for peers to connect to
 	AnnounceAddresses []string
 	// NoAnnounceAddresses - Addresses the P2P subsystem may know about, but that should not be
@@ -25,6 +33,11 @@ type Config struct {
 	// Connections with those peers are protected from being trimmed, dropped or negatively scored.
 	// NOTE: Any two peers must bidirectionally configure each other on their MutualPeers field.
 	MutualPeers []string
+	// ProtectedPeers are the multiaddresses of the peers the node always stays connected to,
+	// redialing them with backoff whenever the connection is lost, e.g. light nodes glued to the
+	// operator's own full nodes. Connections with those peers are protected from being trimmed.
+	// Unlike MutualPeers, the peers do not need to configure the node back.
+	ProtectedPeers []string
 	// PeerExchange configures the node, whether it should share some peers to a pruned peer.
 	// This is enabled by default for Bootstrappers.
 	PeerExchange bool
@@ -34,6 +47,22 @@ type Config struct {
 
 	// Allowlist for IPColocation PubSub parameter, a list of string CIDRs
 	IPColocationWhitelist []string
+
+	// PubSubTracing enables counting of published, received and dropped pubsub messages per topic,
+	// to debug propagation of headers and fraud proofs. The counts are served by the p2p.PubSubTrace
+	// admin RPC method and reported as metrics, if enabled.
+	PubSubTracing bool
+	// DisabledTopics are the gossip topics the node stays out of, to save resources in special
+	// purpose deployments, e.g. "fraud" on private light nodes or "header" on replicas only
+	// serving the RPC API. Full and bridge nodes can not disable "fraud".
+	DisabledTopics []Topic
+
+	// StrictPeering restricts the node to connect only to the AllowedPeers, for environments where
+	// the node must not talk to arbitrary peers. Bootstrappers, mutual and trusted peers have to be
+	// allowlisted explicitly.
+	StrictPeering bool
+	// AllowedPeers are the IDs of the peers the node may connect to in strict peering mode.
+	AllowedPeers []string
 }
 
 // DefaultConfig returns default configuration for P2P subsystem.
@@ -55,6 +84,7 @@ func DefaultConfig(tp node.Type) Config {
 			"/ip6/::/tcp/2121",
 		},
 		MutualPeers:               []string{},
+		ProtectedPeers:            []string{},
 		PeerExchange:              tp == node.Bridge || tp == node.Full,
 		ConnManager:               defaultConnManagerConfig(tp),
 		RoutingTableRefreshPeriod: defaultRoutingRefreshPeriod,
@@ -79,5 +109,28 @@ func (cfg *Config) Validate() error {
 		cfg.RoutingTableRefreshPeriod = defaultRoutingRefreshPeriod
 		log.Warnf("routingTableRefreshPeriod is not valid. restoring to default value: %d", cfg.RoutingTableRefreshPeriod)
 	}
+	if err := cfg.Transports.validate(); err != nil {
+		return err
+	}
+	if _, err := cfg.protectedPeers(); err != nil {
+		return err
+	}
+	if _, err := cfg.Relay.staticRelays(); err != nil {
+		return err
+	}
+	if err := cfg.ResourceManager.validate(); err != nil {
+		return err
+	}
+	if err := cfg.validateTopics(); err != nil {
+		return err
+	}
+	if cfg.StrictPeering {
+		if len(cfg.AllowedPeers) == 0 {
+			return fmt.Errorf("strict peering requires at least one allowed peer")
+		}
+		if _, err := cfg.allowedPeers(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
@@ -0,0 +1,242 @@
+package p2p
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/connmgr"
+	"github.com/libp2p/go-libp2p/core/control"
+	hst "github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	ma "github.com/multiformats/go-multiaddr"
+	"go.uber.org/fx"
+)
+
+// connEventsBuffer is the amount of events buffered for a subscriber. Events are dropped for
+// subscribers that fall further behind, so that a slow consumer never stalls the network.
+const connEventsBuffer = 256
+
+// ConnEventType is the kind of change in the connections of the node.
+type ConnEventType string
+
+const (
+	// ConnEventConnected is a new connection with a peer.
+	ConnEventConnected ConnEventType = "connected"
+	// ConnEventDisconnected is a closed connection with a peer.
+	ConnEventDisconnected ConnEventType = "disconnected"
+	// ConnEventDialFailed is a failure to dial a peer.
+	ConnEventDialFailed ConnEventType = "dial_failed"
+	// ConnEventGated is a connection denied by the connection gater, e.g. with a blocked peer.
+	ConnEventGated ConnEventType = "gated"
+)
+
+// ConnEvent is a change in the connections of the node, consumable by external tooling like
+// intrusion detection or netflow collectors.
+type ConnEvent struct {
+	Type ConnEventType `json:"type"`
+	Time time.Time     `json:"time"`
+	// Peer is the remote peer, unknown for inbound connections gated before the handshake.
+	Peer peer.ID `json:"peer,omitempty"`
+	// Addr is the remote address of the connection, if known.
+	Addr string `json:"addr,omitempty"`
+	// Direction is the direction of the connection, if known.
+	Direction string `json:"direction,omitempty"`
+	// Reason is the error of a failed dial or the stage a gated connection was denied at.
+	Reason string `json:"reason,omitempty"`
+}
+
+// connEvents broadcasts the ConnEvents of the node to its subscribers.
+type connEvents struct {
+	lock sync.Mutex
+	subs map[chan ConnEvent]struct{}
+}
+
+func newConnEvents() *connEvents {
+	return &connEvents{subs: make(map[chan ConnEvent]struct{})}
+}
+
+// Subscribe returns the channel of the ConnEvents happening from now on, closed once the context
+// is done.
+func (ce *connEvents) Subscribe(ctx context.Context) <-chan ConnEvent {
+	ch := make(chan ConnEvent, connEventsBuffer)
+	ce.lock.Lock()
+	ce.subs[ch] = struct{}{}
+	ce.lock.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		ce.lock.Lock()
+		defer ce.lock.Unlock()
+		delete(ce.subs, ch)
+		close(ch)
+	}()
+	return ch
+}
+
+func (ce *connEvents) publish(evt ConnEvent) {
+	ce.lock.Lock()
+	defer ce.lock.Unlock()
+	if len(ce.subs) == 0 {
+		return
+	}
+
+	evt.Time = time.Now()
+	for ch := range ce.subs {
+		select {
+		case ch <- evt:
+		default:
+			log.Debugw("dropped connection event for slow subscriber", "type", evt.Type, "peer", evt.Peer)
+		}
+	}
+}
+
+// notifiee reports the connections opened and closed by the network.
+func (ce *connEvents) notifiee() network.Notifiee {
+	connEvent := func(tp ConnEventType, conn network.Conn) ConnEvent {
+		return ConnEvent{
+			Type:      tp,
+			Peer:      conn.RemotePeer(),
+			Addr:      conn.RemoteMultiaddr().String(),
+			Direction: conn.Stat().Direction.String(),
+		}
+	}
+	return &network.NotifyBundle{
+		ConnectedF: func(_ network.Network, conn network.Conn) {
+			ce.publish(connEvent(ConnEventConnected, conn))
+		},
+		DisconnectedF: func(_ network.Network, conn network.Conn) {
+			ce.publish(connEvent(ConnEventDisconnected, conn))
+		},
+	}
+}
+
+// gater wraps the ConnectionGater, so that the connections it denies are reported.
+func (ce *connEvents) gater(gater connmgr.ConnectionGater) connmgr.ConnectionGater {
+	return &notifyingGater{ConnectionGater: gater, events: ce}
+}
+
+// host wraps the Host, so that the dials failing when connecting to peers or opening streams with
+// them are reported.
+func (ce *connEvents) host(h hst.Host) hst.Host {
+	return &notifyingHost{Host: h, events: ce}
+}
+
+// notifyConnEvents registers the notifiee of the ConnEvents with the network of the Host.
+func notifyConnEvents(lc fx.Lifecycle, host HostBase, events *connEvents) {
+	notifiee := events.notifiee()
+	host.Network().Notify(notifiee)
+	lc.Append(fx.Hook{OnStop: func(context.Context) error {
+		host.Network().StopNotify(notifiee)
+		return nil
+	}})
+}
+
+type notifyingGater struct {
+	connmgr.ConnectionGater
+	events *connEvents
+}
+
+func (g *notifyingGater) InterceptPeerDial(p peer.ID) bool {
+	allow := g.ConnectionGater.InterceptPeerDial(p)
+	if !allow {
+		g.events.publish(ConnEvent{
+			Type:      ConnEventGated,
+			Peer:      p,
+			Direction: network.DirOutbound.String(),
+			Reason:    "peer dial",
+		})
+	}
+	return allow
+}
+
+func (g *notifyingGater) InterceptAddrDial(p peer.ID, addr ma.Multiaddr) bool {
+	allow := g.ConnectionGater.InterceptAddrDial(p, addr)
+	if !allow {
+		g.events.publish(ConnEvent{
+			Type:      ConnEventGated,
+			Peer:      p,
+			Addr:      addr.String(),
+			Direction: network.DirOutbound.String(),
+			Reason:    "address dial",
+		})
+	}
+	return allow
+}
+
+func (g *notifyingGater) InterceptAccept(cma network.ConnMultiaddrs) bool {
+	allow := g.ConnectionGater.InterceptAccept(cma)
+	if !allow {
+		g.events.publish(ConnEvent{
+			Type:      ConnEventGated,
+			Addr:      cma.RemoteMultiaddr().String(),
+			Direction: network.DirInbound.String(),
+			Reason:    "accept",
+		})
+	}
+	return allow
+}
+
+func (g *notifyingGater) InterceptSecured(dir network.Direction, p peer.ID, cma network.ConnMultiaddrs) bool {
+	allow := g.ConnectionGater.InterceptSecured(dir, p, cma)
+	if !allow {
+		g.events.publish(ConnEvent{
+			Type:      ConnEventGated,
+			Peer:      p,
+			Addr:      cma.RemoteMultiaddr().String(),
+			Direction: dir.String(),
+			Reason:    "secured",
+		})
+	}
+	return allow
+}
+
+func (g *notifyingGater) InterceptUpgraded(conn network.Conn) (bool, control.DisconnectReason) {
+	allow, reason := g.ConnectionGater.InterceptUpgraded(conn)
+	if !allow {
+		g.events.publish(ConnEvent{
+			Type:      ConnEventGated,
+			Peer:      conn.RemotePeer(),
+			Addr:      conn.RemoteMultiaddr().String(),
+			Direction: conn.Stat().Direction.String(),
+			Reason:    "upgraded",
+		})
+	}
+	return allow, reason
+}
+
+type notifyingHost struct {
+	hst.Host
+	events *connEvents
+}
+
+func (h *notifyingHost) Connect(ctx context.Context, pi peer.AddrInfo) error {
+	err := h.Host.Connect(ctx, pi)
+	h.dialed(ctx, pi.ID, err)
+	return err
+}
+
+func (h *notifyingHost) NewStream(ctx context.Context, p peer.ID, pids ...protocol.ID) (network.Stream, error) {
+	stream, err := h.Host.NewStream(ctx, p, pids...)
+	h.dialed(ctx, p, err)
+	return stream, err
+}
+
+// dialed reports the dial as failed if it errored without the peer being connected, which tells
+// dial failures apart from failures to negotiate a protocol over an established connection.
+func (h *notifyingHost) dialed(ctx context.Context, p peer.ID, err error) {
+	if err == nil || ctx.Err() != nil {
+		return
+	}
+	if h.Network().Connectedness(p) == network.Connected {
+		return
+	}
+	h.events.publish(ConnEvent{
+		Type:      ConnEventDialFailed,
+		Peer:      p,
+		Direction: network.DirOutbound.String(),
+		Reason:    err.Error(),
+	})
+}
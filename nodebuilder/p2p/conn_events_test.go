@@ -0,0 +1,82 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	ds_sync "github.com/ipfs/go-datastore/sync"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/net/conngater"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnEvents(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshLinked(2)
+	require.NoError(t, err)
+	t.Cleanup(func() { net.Close() }) //nolint:errcheck
+	hosts := net.Hosts()
+
+	events := newConnEvents()
+	hosts[0].Network().Notify(events.notifiee())
+	sub := events.Subscribe(ctx)
+
+	err = hosts[0].Connect(ctx, peer.AddrInfo{ID: hosts[1].ID()})
+	require.NoError(t, err)
+	evt := nextConnEvent(ctx, t, sub)
+	require.Equal(t, ConnEventConnected, evt.Type)
+	require.Equal(t, hosts[1].ID(), evt.Peer)
+	require.NotEmpty(t, evt.Addr)
+
+	err = hosts[0].Network().ClosePeer(hosts[1].ID())
+	require.NoError(t, err)
+	evt = nextConnEvent(ctx, t, sub)
+	require.Equal(t, ConnEventDisconnected, evt.Type)
+	require.Equal(t, hosts[1].ID(), evt.Peer)
+
+	// a peer without a link to the host can not be dialed
+	unlinked, err := net.GenPeer()
+	require.NoError(t, err)
+	err = events.host(hosts[0]).Connect(ctx, peer.AddrInfo{ID: unlinked.ID(), Addrs: unlinked.Addrs()})
+	require.Error(t, err)
+	evt = nextConnEvent(ctx, t, sub)
+	require.Equal(t, ConnEventDialFailed, evt.Type)
+	require.Equal(t, unlinked.ID(), evt.Peer)
+	require.NotEmpty(t, evt.Reason)
+
+	gater, err := conngater.NewBasicConnectionGater(ds_sync.MutexWrap(datastore.NewMapDatastore()))
+	require.NoError(t, err)
+	require.NoError(t, gater.BlockPeer(hosts[1].ID()))
+	require.False(t, events.gater(gater).InterceptPeerDial(hosts[1].ID()))
+	evt = nextConnEvent(ctx, t, sub)
+	require.Equal(t, ConnEventGated, evt.Type)
+	require.Equal(t, hosts[1].ID(), evt.Peer)
+
+	// the subscription is closed once its context is done
+	subCtx, subCancel := context.WithCancel(ctx)
+	sub = events.Subscribe(subCtx)
+	subCancel()
+	require.Eventually(t, func() bool {
+		select {
+		case _, ok := <-sub:
+			return !ok
+		default:
+			return false
+		}
+	}, time.Second, 10*time.Millisecond)
+}
+
+func nextConnEvent(ctx context.Context, t *testing.T, sub <-chan ConnEvent) ConnEvent {
+	select {
+	case evt := <-sub:
+		return evt
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for connection event")
+		return ConnEvent{}
+	}
+}
@@ -0,0 +1,124 @@
+package p2p
+
+import (
+	"context"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	madns "github.com/multiformats/go-multiaddr-dns"
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+// UnreachableBootstrappers dials the bootstrappers of the network over TCP and reports the ones
+// none of the addresses of could be reached, along with the total amount of bootstrappers. Only
+// the reachability of the bootstrappers is checked, without a libp2p handshake.
+func UnreachableBootstrappers(ctx context.Context, net Network) ([]peer.AddrInfo, int, error) {
+	bootstrappers, err := BootstrappersFor(net)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var (
+		lk          sync.Mutex
+		wg          sync.WaitGroup
+		unreachable []peer.AddrInfo
+	)
+	for _, b := range bootstrappers {
+		wg.Add(1)
+		go func(b peer.AddrInfo) {
+			defer wg.Done()
+			if !dialAny(ctx, b.Addrs) {
+				lk.Lock()
+				unreachable = append(unreachable, b)
+				lk.Unlock()
+			}
+		}(b)
+	}
+	wg.Wait()
+	return unreachable, len(bootstrappers), nil
+}
+
+// UnboundListenAddresses binds the listen addresses of the enabled transports and reports the
+// errors of the ones that could not be bound, e.g. as the port is taken by another process.
+func (cfg *Config) UnboundListenAddresses() map[string]error {
+	errs := make(map[string]error)
+	for _, addr := range cfg.listenAddresses() {
+		maddr, err := ma.NewMultiaddr(addr)
+		if err != nil {
+			errs[addr] = err
+			continue
+		}
+		netAddr, ok := transportPrefix(maddr)
+		if !ok {
+			// other transports are not bound by the node
+			continue
+		}
+
+		if _, err := netAddr.ValueForProtocol(ma.P_UDP); err == nil {
+			conn, err := manet.ListenPacket(netAddr)
+			if err != nil {
+				errs[addr] = err
+				continue
+			}
+			conn.Close()
+			continue
+		}
+		lis, err := manet.Listen(netAddr)
+		if err != nil {
+			errs[addr] = err
+			continue
+		}
+		lis.Close()
+	}
+	return errs
+}
+
+// dialAny reports whether any of the addresses could be dialed over TCP.
+func dialAny(ctx context.Context, addrs []ma.Multiaddr) bool {
+	var dialer manet.Dialer
+	for _, addr := range addrs {
+		resolved, err := madns.DefaultResolver.Resolve(ctx, addr)
+		if err != nil {
+			log.Debugw("resolving bootstrapper address", "addr", addr.String(), "err", err)
+			continue
+		}
+		for _, raddr := range resolved {
+			netAddr, ok := transportPrefix(raddr)
+			if !ok {
+				continue
+			}
+			if _, err := netAddr.ValueForProtocol(ma.P_TCP); err != nil {
+				continue
+			}
+			conn, err := dialer.DialContext(ctx, netAddr)
+			if err != nil {
+				log.Debugw("dialing bootstrapper", "addr", netAddr.String(), "err", err)
+				continue
+			}
+			conn.Close()
+			return true
+		}
+	}
+	return false
+}
+
+// transportPrefix returns the part of the multiaddress up to its TCP or UDP component, e.g.
+// "/ip4/1.2.3.4/udp/2121" of "/ip4/1.2.3.4/udp/2121/quic-v1/p2p/<id>".
+func transportPrefix(maddr ma.Multiaddr) (ma.Multiaddr, bool) {
+	var (
+		prefix ma.Multiaddr
+		found  bool
+	)
+	ma.ForEach(maddr, func(c ma.Component) bool {
+		if prefix == nil {
+			prefix = &c
+		} else {
+			prefix = prefix.Encapsulate(&c)
+		}
+		code := c.Protocol().Code
+		found = code == ma.P_TCP || code == ma.P_UDP
+		return !found
+	})
+	return prefix, found
+}
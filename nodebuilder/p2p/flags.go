@@ -3,8 +3,10 @@ package p2p
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
@@ -14,8 +16,12 @@ import (
 const EnvCustomNetwork = "CELESTIA_CUSTOM"
 
 const (
-	networkFlag = "p2p.network"
-	mutualFlag  = "p2p.mutual"
+	networkFlag      = "p2p.network"
+	networksFileFlag = "p2p.networks-file"
+	mutualFlag       = "p2p.mutual"
+	protectedFlag    = "p2p.protected"
+	allowedPeersFlag = "p2p.allowed-peers"
+	topicsFlag       = "p2p.disabled-topics"
 )
 
 // Flags gives a set of p2p flags.
@@ -30,6 +36,26 @@ Such connection is immune to peer scoring slashing and connection module trimmin
 Peers must bidirectionally point to each other. (Format: multiformats.io/multiaddr)
 `,
 	)
+	flags.StringSlice(
+		protectedFlag,
+		nil,
+		`Comma-separated multiaddresses of peers to always stay connected to, redialing them whenever
+the connection is lost. Such connection is immune to connection module trimming.
+Unlike mutual peers, the peers do not need to point back. (Format: multiformats.io/multiaddr)
+`,
+	)
+	flags.StringSlice(
+		allowedPeersFlag,
+		nil,
+		`Comma-separated IDs of the only peers to connect to. Enables strict peering, so that the node
+does not connect to any other peer, including bootstrappers.
+`,
+	)
+	flags.StringSlice(
+		topicsFlag,
+		nil,
+		fmt.Sprintf("Comma-separated gossip topics to stay out of, out of %s and %s", HeaderTopic, FraudTopic),
+	)
 	flags.String(
 		networkFlag,
 		"",
@@ -37,10 +63,49 @@ Peers must bidirectionally point to each other. (Format: multiformats.io/multiad
 			listProvidedNetworks()+
 			". Must be passed on both init and start to take effect.",
 	)
+	flags.String(
+		networksFileFlag,
+		"",
+		fmt.Sprintf(`Path to a JSON network registry file defining networks in addition to the ones built in,
+e.g. testnets launched after the release. Can also be set with %s.`, EnvNetworksFile),
+	)
 
 	return flags
 }
 
+// FlagCompletions gives the completions of the values of the p2p flags, keyed by the flag names.
+func FlagCompletions() map[string]func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return map[string]func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective){
+		networkFlag: completeNetwork,
+		networksFileFlag: func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+			return []string{"json"}, cobra.ShellCompDirectiveFilterFileExt
+		},
+	}
+}
+
+// completeNetwork completes the names and aliases of the known networks, including the ones
+// defined in the network registry file given with the flag or the environment.
+func completeNetwork(cmd *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if err := loadNetworksFile(cmd); err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	networks := make([]string, 0, len(networksList)+len(networkAliases))
+	for net := range networksList {
+		// "private" network isn't really a choosable option, so skip
+		if net != Private {
+			networks = append(networks, string(net))
+		}
+	}
+	for alias, net := range networkAliases {
+		if net != Private {
+			networks = append(networks, alias)
+		}
+	}
+	sort.Strings(networks)
+	return networks, cobra.ShellCompDirectiveNoFileComp
+}
+
 // ParseFlags parses P2P flags from the given cmd and saves them to the passed config.
 func ParseFlags(
 	cmd *cobra.Command,
@@ -51,8 +116,8 @@ func ParseFlags(
 		return err
 	}
 
-	for _, peer := range mutualPeers {
-		_, err = multiaddr.NewMultiaddr(peer)
+	for _, maddr := range mutualPeers {
+		_, err = multiaddr.NewMultiaddr(maddr)
 		if err != nil {
 			return fmt.Errorf("cmd: while parsing '%s': %w", mutualFlag, err)
 		}
@@ -61,12 +126,58 @@ func ParseFlags(
 	if len(mutualPeers) != 0 {
 		cfg.MutualPeers = mutualPeers
 	}
+
+	protectedPeers, err := cmd.Flags().GetStringSlice(protectedFlag)
+	if err != nil {
+		return err
+	}
+	for _, maddr := range protectedPeers {
+		_, err = multiaddr.NewMultiaddr(maddr)
+		if err != nil {
+			return fmt.Errorf("cmd: while parsing '%s': %w", protectedFlag, err)
+		}
+	}
+	if len(protectedPeers) != 0 {
+		cfg.ProtectedPeers = protectedPeers
+	}
+
+	allowedPeers, err := cmd.Flags().GetStringSlice(allowedPeersFlag)
+	if err != nil {
+		return err
+	}
+	for _, id := range allowedPeers {
+		if _, err = peer.Decode(id); err != nil {
+			return fmt.Errorf("cmd: while parsing '%s': %w", allowedPeersFlag, err)
+		}
+	}
+	if len(allowedPeers) != 0 {
+		cfg.StrictPeering = true
+		cfg.AllowedPeers = allowedPeers
+	}
+
+	if cmd.Flags().Changed(topicsFlag) {
+		topics, err := cmd.Flags().GetStringSlice(topicsFlag)
+		if err != nil {
+			return err
+		}
+		cfg.DisabledTopics = make([]Topic, len(topics))
+		for i, topic := range topics {
+			cfg.DisabledTopics[i] = Topic(topic)
+		}
+		if err = cfg.validateTopics(); err != nil {
+			return fmt.Errorf("cmd: while parsing '%s': %w", topicsFlag, err)
+		}
+	}
 	return nil
 }
 
 // ParseNetwork tries to parse the network from the flags and environment,
 // and returns either the parsed network or the build's default network
 func ParseNetwork(cmd *cobra.Command) (Network, error) {
+	if err := loadNetworksFile(cmd); err != nil {
+		return "", err
+	}
+
 	parsed := cmd.Flag(networkFlag).Value.String()
 	// no network set through the flags, so check if there is an override in the env
 	if parsed == "" {
@@ -85,6 +196,19 @@ func ParseNetwork(cmd *cobra.Command) (Network, error) {
 	return "", fmt.Errorf("invalid network specified: %s", parsed)
 }
 
+// loadNetworksFile registers the networks from the registry file passed through the flags or the
+// environment, if any.
+func loadNetworksFile(cmd *cobra.Command) error {
+	path := os.Getenv(EnvNetworksFile)
+	if f := cmd.Flag(networksFileFlag); f != nil && f.Value.String() != "" {
+		path = f.Value.String()
+	}
+	if path == "" {
+		return nil
+	}
+	return LoadNetworks(path)
+}
+
 // parseNetworkFromEnv tries to parse the network from the environment.
 // If no network is set, it returns an empty string.
 func parseNetworkFromEnv() (Network, error) {
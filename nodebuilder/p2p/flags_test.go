@@ -1,6 +1,8 @@
 package p2p
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -58,6 +60,47 @@ func TestParsedNetwork_invalidNetwork(t *testing.T) {
 	assert.Equal(t, Network(""), net)
 }
 
+// TestParseNetwork_loadsNetworksFile checks to ensure networks defined in
+// the registry file passed through the environment can be selected.
+func TestParseNetwork_loadsNetworksFile(t *testing.T) {
+	cmd := createCmdWithNetworkFlag()
+
+	path := filepath.Join(t.TempDir(), "networks.json")
+	registry := `[{
+		"network": "newnet-1",
+		"aliases": ["newnet"],
+		"genesis_hash": "00aa",
+		"default_gas_price": 0.2,
+		"bootstrappers": ["/dns4/bootstr.newnet.org/tcp/2121/p2p/12D3KooWNzdKcHagtvvr6qtjcPTAdCN6ZBiBLH8FBHbihxqu4GZx"]
+	}]`
+	require.NoError(t, os.WriteFile(path, []byte(registry), 0600))
+	t.Setenv(EnvNetworksFile, path)
+
+	err := cmd.Flags().Set(networkFlag, "newnet")
+	require.NoError(t, err)
+
+	net, err := ParseNetwork(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, Network("newnet-1"), net)
+
+	gen, err := GenesisFor(net)
+	require.NoError(t, err)
+	assert.Equal(t, "00AA", gen)
+	gasPrice, err := DefaultGasPriceFor(net)
+	require.NoError(t, err)
+	assert.Equal(t, 0.2, gasPrice)
+	bs, err := BootstrappersFor(net)
+	require.NoError(t, err)
+	assert.Len(t, bs, 1)
+
+	// invalid registries are rejected as a whole
+	require.NoError(t, os.WriteFile(path, []byte(`[{"network": "broken", "bootstrappers": ["invalid"]}]`), 0600))
+	_, err = ParseNetwork(cmd)
+	assert.Error(t, err)
+	_, err = Network("broken").Validate()
+	assert.ErrorIs(t, err, ErrInvalidNetwork)
+}
+
 func createCmdWithNetworkFlag() *cobra.Command {
 	cmd := &cobra.Command{}
 	flags := &flag.FlagSet{}
@@ -2,7 +2,6 @@ package p2p
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/libp2p/go-libp2p"
 	p2pconfig "github.com/libp2p/go-libp2p/config"
@@ -24,27 +23,31 @@ import (
 
 // routedHost constructs a wrapped Host that may fallback to address discovery,
 // if any top-level operation on the Host is provided with PeerID(Hash(PbK)) only.
-func routedHost(base HostBase, r routing.PeerRouting) hst.Host {
-	return routedhost.Wrap(base, r)
+func routedHost(base HostBase, r routing.PeerRouting, events *connEvents) hst.Host {
+	return events.host(routedhost.Wrap(base, r))
 }
 
 // host returns constructor for Host.
 func host(params hostParams) (HostBase, error) {
+	gater, err := connectionGaterFor(params.Cfg, params.ConnGater)
+	if err != nil {
+		return nil, err
+	}
+
 	opts := []libp2p.Option{
 		libp2p.NoListenAddrs, // do not listen automatically
 		libp2p.AddrsFactory(params.AddrF),
 		libp2p.Identity(params.Key),
 		libp2p.Peerstore(params.PStore),
 		libp2p.ConnectionManager(params.ConnMngr),
-		libp2p.ConnectionGater(params.ConnGater),
-		libp2p.UserAgent(fmt.Sprintf("celestia-%s", params.Net)),
+		libp2p.ConnectionGater(params.ConnEvents.gater(gater)),
+		libp2p.UserAgent(userAgent(params.Net, params.Tp)),
 		libp2p.NATPortMap(), // enables upnp
-		libp2p.DisableRelay(),
 		libp2p.BandwidthReporter(params.Bandwidth),
 		libp2p.ResourceManager(params.ResourceManager),
 		// to clearly define what defaults we rely upon
 		libp2p.DefaultSecurity,
-		libp2p.DefaultTransports,
+		params.Cfg.Transports.options(),
 		libp2p.DefaultMuxers,
 	}
 
@@ -54,6 +57,12 @@ func host(params hostParams) (HostBase, error) {
 		opts = append(opts, libp2p.DisableMetrics())
 	}
 
+	relayOpts, err := relayOptions(params.Cfg.Relay, params.Tp, params.Bootstrappers)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, relayOpts...)
+
 	// All node types except light (bridge, full) will enable NATService
 	if params.Tp != node.Light {
 		opts = append(opts, libp2p.EnableNATService())
@@ -77,6 +86,7 @@ type hostParams struct {
 	fx.In
 
 	Net             Network
+	Cfg             Config
 	Lc              fx.Lifecycle
 	ID              peer.ID
 	Key             crypto.PrivKey
@@ -84,9 +94,11 @@ type hostParams struct {
 	PStore          peerstore.Peerstore
 	ConnMngr        connmgr.ConnManager
 	ConnGater       *conngater.BasicConnectionGater
+	ConnEvents      *connEvents
 	Bandwidth       *metrics.BandwidthCounter
 	ResourceManager network.ResourceManager
 	Registry        prometheus.Registerer `optional:"true"`
+	Bootstrappers   Bootstrappers
 
 	Tp node.Type
 }
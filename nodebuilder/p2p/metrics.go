@@ -6,9 +6,13 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/libp2p/go-libp2p/core/metrics"
 	rcmgrObs "github.com/libp2p/go-libp2p/p2p/host/resource-manager/obs"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/fx"
 )
 
@@ -16,8 +20,12 @@ import (
 func WithMetrics() fx.Option {
 	return fx.Options(
 		fx.Provide(resourceManagerOpt(traceReporter)),
+		fx.Provide(resourceManagerOpt(newMetricsReporter)),
 		fx.Provide(prometheusRegisterer),
 		fx.Invoke(prometheusMetrics),
+		fx.Invoke(pubSubTraceMetrics),
+		fx.Invoke(relayMetrics),
+		fx.Invoke(bandwidthMetrics),
 	)
 }
 
@@ -64,3 +72,30 @@ func prometheusMetrics(lifecycle fx.Lifecycle, registerer prometheus.Registerer)
 func prometheusRegisterer() prometheus.Registerer {
 	return prometheus.NewRegistry()
 }
+
+// bandwidthMetrics reports the amount of bytes sent and received by the node per protocol, e.g.
+// shrex, header exchange and gossipsub, as accounted by the bandwidth counter of the host.
+func bandwidthMetrics(bw *metrics.BandwidthCounter) error {
+	meter := otel.Meter("p2p/bandwidth")
+	total, err := meter.Int64ObservableCounter("p2p_bandwidth_bytes_counter",
+		metric.WithDescription("amount of bytes transferred per protocol and direction"))
+	if err != nil {
+		return err
+	}
+
+	callback := func(_ context.Context, observer metric.Observer) error {
+		for proto, stats := range bw.GetBandwidthByProtocol() {
+			observer.ObserveInt64(total, stats.TotalIn, metric.WithAttributes(
+				attribute.String("protocol", string(proto)),
+				attribute.String("direction", "inbound"),
+			))
+			observer.ObserveInt64(total, stats.TotalOut, metric.WithAttributes(
+				attribute.String("protocol", string(proto)),
+				attribute.String("direction", "outbound"),
+			))
+		}
+		return nil
+	}
+	_, err = meter.RegisterCallback(callback, total)
+	return err
+}
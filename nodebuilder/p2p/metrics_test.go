@@ -0,0 +1,52 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/metrics"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestBandwidthMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	prev := otel.GetMeterProvider()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	t.Cleanup(func() {
+		otel.SetMeterProvider(prev)
+	})
+
+	bw := metrics.NewBandwidthCounter()
+	require.NoError(t, bandwidthMetrics(bw))
+	bw.LogSentMessageStream(100, "/shrex/eds/v0.0.1", peer.ID("peer"))
+	bw.LogRecvMessageStream(40, "/shrex/eds/v0.0.1", peer.ID("peer"))
+
+	// the bandwidth counter accounts the bytes in the background
+	bytes := make(map[string]int64)
+	require.Eventually(t, func() bool {
+		var data metricdata.ResourceMetrics
+		if err := reader.Collect(context.Background(), &data); err != nil {
+			return false
+		}
+		for _, scope := range data.ScopeMetrics {
+			for _, m := range scope.Metrics {
+				sum, ok := m.Data.(metricdata.Sum[int64])
+				if !ok || m.Name != "p2p_bandwidth_bytes_counter" {
+					continue
+				}
+				for _, point := range sum.DataPoints {
+					proto, _ := point.Attributes.Value("protocol")
+					direction, _ := point.Attributes.Value("direction")
+					bytes[proto.AsString()+" "+direction.AsString()] = point.Value
+				}
+			}
+		}
+		return bytes["/shrex/eds/v0.0.1 outbound"] == 100
+	}, time.Second*5, time.Millisecond*100)
+	require.Equal(t, int64(40), bytes["/shrex/eds/v0.0.1 inbound"])
+}
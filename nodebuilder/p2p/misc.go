@@ -48,6 +48,10 @@ func connectionManager(cfg Config, bpeers Bootstrappers) (connmgri.ConnManager,
 	if err != nil {
 		return nil, err
 	}
+	ppeers, err := cfg.protectedPeers()
+	if err != nil {
+		return nil, err
+	}
 	cm, err := connmgr.NewConnManager(
 		cfg.ConnManager.Low,
 		cfg.ConnManager.High,
@@ -59,6 +63,9 @@ func connectionManager(cfg Config, bpeers Bootstrappers) (connmgri.ConnManager,
 	for _, info := range fpeers {
 		cm.Protect(info.ID, "protected-mutual")
 	}
+	for _, info := range ppeers {
+		cm.Protect(info.ID, protectedTag)
+	}
 	for _, info := range bpeers {
 		cm.Protect(info.ID, "protected-bootstrap")
 	}
@@ -8,6 +8,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	p2p "github.com/celestiaorg/celestia-node/nodebuilder/p2p"
 	gomock "github.com/golang/mock/gomock"
 	metrics "github.com/libp2p/go-libp2p/core/metrics"
 	network "github.com/libp2p/go-libp2p/core/network"
@@ -231,6 +232,21 @@ func (mr *MockModuleMockRecorder) Peers(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Peers", reflect.TypeOf((*MockModule)(nil).Peers), arg0)
 }
 
+// PeersByVersion mocks base method.
+func (m *MockModule) PeersByVersion(arg0 context.Context) ([]p2p.PeersVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PeersByVersion", arg0)
+	ret0, _ := ret[0].([]p2p.PeersVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PeersByVersion indicates an expected call of PeersByVersion.
+func (mr *MockModuleMockRecorder) PeersByVersion(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeersByVersion", reflect.TypeOf((*MockModule)(nil).PeersByVersion), arg0)
+}
+
 // Protect mocks base method.
 func (m *MockModule) Protect(arg0 context.Context, arg1 peer.ID, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -260,6 +276,21 @@ func (mr *MockModuleMockRecorder) PubSubPeers(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PubSubPeers", reflect.TypeOf((*MockModule)(nil).PubSubPeers), arg0, arg1)
 }
 
+// PubSubTrace mocks base method.
+func (m *MockModule) PubSubTrace(arg0 context.Context) (map[string]p2p.PubSubTopicTrace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PubSubTrace", arg0)
+	ret0, _ := ret[0].(map[string]p2p.PubSubTopicTrace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PubSubTrace indicates an expected call of PubSubTrace.
+func (mr *MockModuleMockRecorder) PubSubTrace(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PubSubTrace", reflect.TypeOf((*MockModule)(nil).PubSubTrace), arg0)
+}
+
 // ResourceState mocks base method.
 func (m *MockModule) ResourceState(arg0 context.Context) (rcmgr.ResourceManagerStat, error) {
 	m.ctrl.T.Helper()
@@ -275,6 +306,21 @@ func (mr *MockModuleMockRecorder) ResourceState(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceState", reflect.TypeOf((*MockModule)(nil).ResourceState), arg0)
 }
 
+// SubscribeConnEvents mocks base method.
+func (m *MockModule) SubscribeConnEvents(arg0 context.Context) (<-chan p2p.ConnEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeConnEvents", arg0)
+	ret0, _ := ret[0].(<-chan p2p.ConnEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeConnEvents indicates an expected call of SubscribeConnEvents.
+func (mr *MockModuleMockRecorder) SubscribeConnEvents(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeConnEvents", reflect.TypeOf((*MockModule)(nil).SubscribeConnEvents), arg0)
+}
+
 // UnblockPeer mocks base method.
 func (m *MockModule) UnblockPeer(arg0 context.Context, arg1 peer.ID) error {
 	m.ctrl.T.Helper()
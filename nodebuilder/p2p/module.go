@@ -1,6 +1,7 @@
 package p2p
 
 import (
+	"fmt"
 	logging "github.com/ipfs/go-log/v2"
 	"github.com/libp2p/go-libp2p/core/metrics"
 	"go.uber.org/fx"
@@ -14,6 +15,9 @@ var log = logging.Logger("module/p2p")
 func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 	// sanitize config values before constructing module
 	cfgErr := cfg.Validate()
+	if cfgErr == nil {
+		cfgErr = cfg.validateTopicsFor(tp)
+	}
 
 	baseComponents := fx.Options(
 		fx.Supply(*cfg),
@@ -23,8 +27,10 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 		fx.Provide(peerStore),
 		fx.Provide(connectionManager),
 		fx.Provide(connectionGater),
+		fx.Provide(newConnEvents),
 		fx.Provide(host),
 		fx.Provide(routedHost),
+		fx.Provide(newPubSubTracer),
 		fx.Provide(pubSub),
 		fx.Provide(dataExchange),
 		fx.Provide(blockService),
@@ -33,7 +39,9 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 		fx.Provide(addrsFactory(cfg.AnnounceAddresses, cfg.NoAnnounceAddresses)),
 		fx.Provide(metrics.NewBandwidthCounter),
 		fx.Provide(newModule),
-		fx.Invoke(Listen(cfg.ListenAddresses)),
+		fx.Invoke(Listen(cfg.listenAddresses())),
+		fx.Invoke(keepProtectedPeers),
+		fx.Invoke(notifyConnEvents),
 		fx.Provide(resourceManager),
 		fx.Provide(resourceManagerOpt(allowList)),
 	)
@@ -54,6 +62,6 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 			fx.Provide(autoscaleResources),
 		)
 	default:
-		panic("invalid node type")
+		return fx.Error(fmt.Errorf("module/p2p: invalid node type: %s", tp))
 	}
 }
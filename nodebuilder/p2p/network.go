@@ -7,7 +7,8 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
-// NOTE: Every time we add a new long-running network, it has to be added here.
+// NOTE: Long-running networks are defined in the embedded registry (networks.json). Constants are
+// kept here only for networks referenced from code.
 const (
 	// DefaultNetwork is the default network of the current build.
 	DefaultNetwork = Mocha
@@ -50,23 +51,12 @@ func (n Network) String() string {
 	return string(n)
 }
 
-// networksList is a strict list of all known long-standing networks.
-var networksList = map[Network]struct{}{
-	Arabica:        {},
-	Mocha:          {},
-	BlockspaceRace: {},
-	Private:        {},
-}
+// networksList is a list of all known networks. It is filled from the network registry.
+var networksList = map[Network]struct{}{}
 
-// networkAliases is a strict list of all known long-standing networks
-// mapped from the string representation of their *alias* (rather than
-// their actual value) to the Network.
-var networkAliases = map[string]Network{
-	"arabica":        Arabica,
-	"mocha":          Mocha,
-	"blockspacerace": BlockspaceRace,
-	"private":        Private,
-}
+// networkAliases maps the string representation of network *aliases* (rather than
+// their actual value) to the Network. It is filled from the network registry.
+var networkAliases = map[string]Network{}
 
 // listProvidedNetworks provides a string listing all known long-standing networks for things like
 // command hints.
@@ -77,6 +77,18 @@ type Module interface {
 	// PubSubPeers returns the peer IDs of the peers joined on
 	// the given topic.
 	PubSubPeers(ctx context.Context, topic string) ([]peer.ID, error)
+	// PubSubTrace returns the amount of published, received and dropped messages per pubsub topic.
+	// Requires pubsub tracing to be enabled in the config.
+	PubSubTrace(context.Context) (map[string]PubSubTopicTrace, error)
+
+	// PeersByVersion summarizes the connected peers by the network, node type and version they
+	// identified with, the most common first, to track the adoption of network upgrades.
+	PeersByVersion(context.Context) ([]PeersVersion, error)
+
+	// SubscribeConnEvents streams the changes in the connections of the node as they happen:
+	// connected and disconnected peers, failed dials and connections denied by the connection gater.
+	// Events are dropped for subscribers falling too far behind.
+	SubscribeConnEvents(context.Context) (<-chan ConnEvent, error)
 }
 
 // module contains all components necessary to access information and
@@ -87,6 +99,8 @@ type module struct {
 	connGater *conngater.BasicConnectionGater
 	bw        *metrics.BandwidthCounter
 	rm        network.ResourceManager
+	tracer    *pubSubTracer
+	events    *connEvents
 }
 
 func newModule(
@@ -95,6 +109,8 @@ func newModule(
 	cg *conngater.BasicConnectionGater,
 	bw *metrics.BandwidthCounter,
 	rm network.ResourceManager,
+	tracer *pubSubTracer,
+	events *connEvents,
 ) Module {
 	return &module{
 		host:      host,
@@ -102,6 +118,8 @@ func newModule(
 		connGater: cg,
 		bw:        bw,
 		rm:        rm,
+		tracer:    tracer,
+		events:    events,
 	}
 }
 
@@ -188,6 +206,21 @@ func (m *module) PubSubPeers(_ context.Context, topic string) ([]peer.ID, error)
 	return m.ps.ListPeers(topic), nil
 }
 
+func (m *module) PubSubTrace(context.Context) (map[string]PubSubTopicTrace, error) {
+	if m.tracer == nil {
+		return nil, errPubSubTracingDisabled
+	}
+	return m.tracer.Traces(), nil
+}
+
+func (m *module) PeersByVersion(context.Context) ([]PeersVersion, error) {
+	return peersByVersion(m.host), nil
+}
+
+func (m *module) SubscribeConnEvents(ctx context.Context) (<-chan ConnEvent, error) {
+	return m.events.Subscribe(ctx), nil
+}
+
 // API is a wrapper around Module for the RPC.
 // TODO(@distractedm1nd): These structs need to be autogenerated.
 //
@@ -212,6 +245,9 @@ type API struct {
 		BandwidthForProtocol func(ctx context.Context, proto protocol.ID) (metrics.Stats, error)  `perm:"admin"`
 		ResourceState        func(context.Context) (rcmgr.ResourceManagerStat, error)             `perm:"admin"`
 		PubSubPeers          func(ctx context.Context, topic string) ([]peer.ID, error)           `perm:"admin"`
+		PubSubTrace          func(context.Context) (map[string]PubSubTopicTrace, error)           `perm:"admin"`
+		PeersByVersion       func(context.Context) ([]PeersVersion, error)                        `perm:"admin"`
+		SubscribeConnEvents  func(context.Context) (<-chan ConnEvent, error)                      `perm:"admin"`
 	}
 }
 
@@ -286,3 +322,15 @@ func (api *API) ResourceState(ctx context.Context) (rcmgr.ResourceManagerStat, e
 func (api *API) PubSubPeers(ctx context.Context, topic string) ([]peer.ID, error) {
 	return api.Internal.PubSubPeers(ctx, topic)
 }
+
+func (api *API) PubSubTrace(ctx context.Context) (map[string]PubSubTopicTrace, error) {
+	return api.Internal.PubSubTrace(ctx)
+}
+
+func (api *API) PeersByVersion(ctx context.Context) ([]PeersVersion, error) {
+	return api.Internal.PeersByVersion(ctx)
+}
+
+func (api *API) SubscribeConnEvents(ctx context.Context) (<-chan ConnEvent, error) {
+	return api.Internal.SubscribeConnEvents(ctx)
+}
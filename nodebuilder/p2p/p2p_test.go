@@ -17,6 +17,8 @@ import (
 	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 )
 
 // TestP2PModule_Host tests P2P Module methods on
@@ -26,7 +28,7 @@ func TestP2PModule_Host(t *testing.T) {
 	require.NoError(t, err)
 	host, peer := net.Hosts()[0], net.Hosts()[1]
 
-	mgr := newModule(host, nil, nil, nil, nil)
+	mgr := newModule(host, nil, nil, nil, nil, nil, nil)
 
 	ctx := context.Background()
 
@@ -61,7 +63,7 @@ func TestP2PModule_ConnManager(t *testing.T) {
 	peer, err := libp2p.New()
 	require.NoError(t, err)
 
-	mgr := newModule(host, nil, nil, nil, nil)
+	mgr := newModule(host, nil, nil, nil, nil, nil, nil)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	t.Cleanup(cancel)
@@ -89,7 +91,7 @@ func TestP2PModule_Autonat(t *testing.T) {
 	host, err := libp2p.New(libp2p.EnableNATService())
 	require.NoError(t, err)
 
-	mgr := newModule(host, nil, nil, nil, nil)
+	mgr := newModule(host, nil, nil, nil, nil, nil, nil)
 
 	status, err := mgr.NATStatus(context.Background())
 	assert.NoError(t, err)
@@ -121,7 +123,7 @@ func TestP2PModule_Bandwidth(t *testing.T) {
 		require.NoError(t, err)
 	})
 
-	mgr := newModule(host, nil, nil, bw, nil)
+	mgr := newModule(host, nil, nil, bw, nil, nil, nil)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	t.Cleanup(cancel)
@@ -185,7 +187,7 @@ func TestP2PModule_Pubsub(t *testing.T) {
 	gs, err := pubsub.NewGossipSub(ctx, host)
 	require.NoError(t, err)
 
-	mgr := newModule(host, gs, nil, nil, nil)
+	mgr := newModule(host, gs, nil, nil, nil, nil, nil)
 
 	topicStr := "test-topic"
 
@@ -215,13 +217,58 @@ func TestP2PModule_Pubsub(t *testing.T) {
 	assert.Equal(t, len(topic.ListPeers()), len(psPeers))
 }
 
+// TestP2PModule_PubSubTrace tests P2P Module methods on
+// the pubsub tracer.
+func TestP2PModule_PubSubTrace(t *testing.T) {
+	net, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	_, err = newModule(nil, nil, nil, nil, nil, newPubSubTracer(Config{}), nil).PubSubTrace(ctx)
+	require.ErrorIs(t, err, errPubSubTracingDisabled)
+
+	tracer := newPubSubTracer(Config{PubSubTracing: true})
+	gs, err := pubsub.NewGossipSub(ctx, net.Hosts()[0], pubsub.WithEventTracer(tracer))
+	require.NoError(t, err)
+	mgr := newModule(net.Hosts()[0], gs, nil, nil, nil, tracer, nil)
+
+	topicStr := "test-topic"
+	topic, err := gs.Join(topicStr)
+	require.NoError(t, err)
+	sub, err := topic.Subscribe()
+	require.NoError(t, err)
+
+	peerGs, err := pubsub.NewGossipSub(ctx, net.Hosts()[1])
+	require.NoError(t, err)
+	peerTopic, err := peerGs.Join(topicStr)
+	require.NoError(t, err)
+	_, err = peerTopic.Subscribe()
+	require.NoError(t, err)
+
+	// give for some peers to properly join the topic
+	time.Sleep(1 * time.Second)
+
+	require.NoError(t, topic.Publish(ctx, []byte("local")))
+	require.NoError(t, peerTopic.Publish(ctx, []byte("remote")))
+	for i := 0; i < 2; i++ {
+		_, err = sub.Next(ctx)
+		require.NoError(t, err)
+	}
+
+	traces, err := mgr.PubSubTrace(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, PubSubTopicTrace{Published: 1, Received: 1}, traces[topicStr])
+}
+
 // TestP2PModule_ConnGater tests P2P Module methods on
 // the instance of ConnectionGater.
 func TestP2PModule_ConnGater(t *testing.T) {
 	gater, err := connectionGater(datastore.NewMapDatastore())
 	require.NoError(t, err)
 
-	mgr := newModule(nil, nil, gater, nil, nil)
+	mgr := newModule(nil, nil, gater, nil, nil, nil, nil)
 
 	ctx := context.Background()
 
@@ -242,10 +289,39 @@ func TestP2PModule_ResourceManager(t *testing.T) {
 	rm, err := rcmgr.NewResourceManager(rcmgr.NewFixedLimiter(rcmgr.DefaultLimits.AutoScale()))
 	require.NoError(t, err)
 
-	mgr := newModule(nil, nil, nil, nil, rm)
+	mgr := newModule(nil, nil, nil, nil, rm, nil, nil)
 
 	state, err := mgr.ResourceState(context.Background())
 	require.NoError(t, err)
 
 	assert.NotNil(t, state)
 }
+
+// TestP2PModule_PeersByVersion tests the summary of connected peers by the user agent they
+// identified with.
+func TestP2PModule_PeersByVersion(t *testing.T) {
+	net, err := mocknet.FullMeshConnected(5)
+	require.NoError(t, err)
+	hosts := net.Hosts()
+	agents := []string{
+		"celestia-private/full/v0.12.0",
+		"celestia-private/full/v0.12.0",
+		"celestia-private",
+		"go-libp2p",
+	}
+	for i, agent := range agents {
+		err = hosts[0].Peerstore().Put(hosts[i+1].ID(), "AgentVersion", agent)
+		require.NoError(t, err)
+	}
+
+	summary, err := newModule(hosts[0], nil, nil, nil, nil, nil, nil).PeersByVersion(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []PeersVersion{
+		{Network: "private", NodeType: "full", Version: "v0.12.0", Peers: 2},
+		{Version: unknownVersion, Peers: 1},
+		{Network: "private", Version: unknownVersion, Peers: 1},
+	}, summary)
+
+	assert.Equal(t, PeersVersion{Network: "private", NodeType: "light", Version: unknownVersion},
+		parseUserAgent(userAgent(Private, node.Light)))
+}
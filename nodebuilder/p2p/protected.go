@@ -0,0 +1,172 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/event"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	"go.uber.org/fx"
+)
+
+// protectedTag is the connection manager tag protecting the connections with the protected peers.
+const protectedTag = "protected-static"
+
+var (
+	// protectedMinBackoff is the interval before redialing a protected peer after a failed dial,
+	// doubled after each failure up to protectedMaxBackoff.
+	protectedMinBackoff = time.Second
+	protectedMaxBackoff = time.Minute * 5
+)
+
+func (cfg *Config) protectedPeers() (_ []peer.AddrInfo, err error) {
+	maddrs := make([]ma.Multiaddr, len(cfg.ProtectedPeers))
+	for i, addr := range cfg.ProtectedPeers {
+		maddrs[i], err = ma.NewMultiaddr(addr)
+		if err != nil {
+			return nil, fmt.Errorf("failure to parse config.P2P.ProtectedPeers: %s", err)
+		}
+	}
+
+	return peer.AddrInfosFromP2pAddrs(maddrs...)
+}
+
+// peerKeeper keeps the node connected to the protected peers, redialing them with backoff whenever
+// the connection is lost. Unlike mutual peers, protected peers do not need to configure the node
+// back.
+type peerKeeper struct {
+	host  HostBase
+	peers []peer.AddrInfo
+
+	// disconnected signals the routine keeping the connection with a peer that it was lost
+	disconnected map[peer.ID]chan struct{}
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newPeerKeeper(host HostBase, peers []peer.AddrInfo) *peerKeeper {
+	disconnected := make(map[peer.ID]chan struct{}, len(peers))
+	for _, info := range peers {
+		disconnected[info.ID] = make(chan struct{}, 1)
+	}
+	return &peerKeeper{
+		host:         host,
+		peers:        peers,
+		disconnected: disconnected,
+	}
+}
+
+func (k *peerKeeper) Start(context.Context) error {
+	sub, err := k.host.EventBus().Subscribe(&event.EvtPeerConnectednessChanged{})
+	if err != nil {
+		return fmt.Errorf("subscribing for connection events: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	k.cancel = cancel
+
+	k.wg.Add(1)
+	go func() {
+		defer k.wg.Done()
+		defer sub.Close()
+		k.listen(ctx, sub)
+	}()
+	for _, info := range k.peers {
+		// the addresses are kept for as long as the node runs, so that the peer can always be redialed
+		k.host.Peerstore().AddAddrs(info.ID, info.Addrs, peerstore.PermanentAddrTTL)
+
+		k.wg.Add(1)
+		go func(info peer.AddrInfo) {
+			defer k.wg.Done()
+			k.keep(ctx, info)
+		}(info)
+	}
+	return nil
+}
+
+func (k *peerKeeper) Stop(context.Context) error {
+	k.cancel()
+	k.wg.Wait()
+	return nil
+}
+
+// listen signals the disconnections from the protected peers.
+func (k *peerKeeper) listen(ctx context.Context, sub event.Subscription) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-sub.Out():
+			if !ok {
+				return
+			}
+			evt := e.(event.EvtPeerConnectednessChanged)
+			ch, ok := k.disconnected[evt.Peer]
+			if !ok || evt.Connectedness == network.Connected {
+				continue
+			}
+			select {
+			case ch <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+// keep dials the peer whenever the node is not connected to it.
+func (k *peerKeeper) keep(ctx context.Context, info peer.AddrInfo) {
+	backoff := protectedMinBackoff
+	for {
+		if k.host.Network().Connectedness(info.ID) != network.Connected {
+			err := k.host.Connect(ctx, info)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				log.Warnw("dialing protected peer", "peer", info.ID, "retry_in", backoff, "err", err)
+				select {
+				case <-time.After(backoff):
+				case <-ctx.Done():
+					return
+				}
+				backoff *= 2
+				if backoff > protectedMaxBackoff {
+					backoff = protectedMaxBackoff
+				}
+				continue
+			}
+			log.Debugw("connected to protected peer", "peer", info.ID)
+			backoff = protectedMinBackoff
+		}
+
+		select {
+		case <-k.disconnected[info.ID]:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// keepProtectedPeers keeps the node connected to the protected peers, if any.
+func keepProtectedPeers(lc fx.Lifecycle, cfg Config, host HostBase) error {
+	peers, err := cfg.protectedPeers()
+	if err != nil {
+		return err
+	}
+	if len(peers) == 0 {
+		return nil
+	}
+
+	keeper := newPeerKeeper(host, peers)
+	lc.Append(fx.Hook{
+		OnStart: keeper.Start,
+		OnStop:  keeper.Stop,
+	})
+	return nil
+}
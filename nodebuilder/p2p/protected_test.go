@@ -0,0 +1,48 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	libhost "github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerKeeper(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+	prevBackoff := protectedMinBackoff
+	protectedMinBackoff = time.Millisecond * 10
+	t.Cleanup(func() {
+		protectedMinBackoff = prevBackoff
+	})
+
+	newHost := func() libhost.Host {
+		h, err := libp2p.New(libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+		require.NoError(t, err)
+		t.Cleanup(func() { h.Close() })
+		return h
+	}
+	h, protected := newHost(), newHost()
+
+	keeper := newPeerKeeper(h, []peer.AddrInfo{*libhost.InfoFromHost(protected)})
+	require.NoError(t, keeper.Start(ctx))
+	t.Cleanup(func() { require.NoError(t, keeper.Stop(ctx)) })
+
+	connected := func() bool {
+		return h.Network().Connectedness(protected.ID()) == network.Connected
+	}
+	require.Eventually(t, connected, time.Second*5, time.Millisecond*10)
+
+	// the connection is restored after being lost
+	lost := h.Network().ConnsToPeer(protected.ID())[0]
+	require.NoError(t, h.Network().ClosePeer(protected.ID()))
+	require.Eventually(t, func() bool {
+		conns := h.Network().ConnsToPeer(protected.ID())
+		return len(conns) > 0 && conns[0] != lost
+	}, time.Second*5, time.Millisecond*10)
+}
@@ -66,7 +66,7 @@ func pubSub(cfg Config, params pubSubParams) (*pubsub.PubSub, error) {
 	//	* https://github.com/libp2p/specs/blob/master/pubsub/gossipsub/gossipsub-v1.1.md#peer-scoring
 	//  * lotus
 	//  * prysm
-	topicScores := topicScoreParams(params.Network)
+	topicScores := topicScoreParams(params.Network, cfg)
 	peerScores, err := peerScoreParams(params.Bootstrappers, cfg)
 	if err != nil {
 		return nil, err
@@ -85,6 +85,12 @@ func pubSub(cfg Config, params pubSubParams) (*pubsub.PubSub, error) {
 		// floodsub(because gossipsub supports floodsub protocol by default).
 		pubsub.WithGossipSubProtocols([]protocol.ID{pubsub.GossipSubID_v11}, pubsub.GossipSubDefaultFeatures),
 	}
+	if params.Tracer != nil {
+		opts = append(opts, pubsub.WithEventTracer(params.Tracer))
+	}
+	if filter := newTopicFilter(cfg, params.Network); filter != nil {
+		opts = append(opts, pubsub.WithSubscriptionFilter(filter))
+	}
 
 	return pubsub.NewGossipSub(
 		params.Ctx,
@@ -105,15 +111,19 @@ type pubSubParams struct {
 	Host          hst.Host
 	Bootstrappers Bootstrappers
 	Network       Network
+	Tracer        *pubSubTracer `optional:"true"`
 }
 
-func topicScoreParams(network Network) map[string]*pubsub.TopicScoreParams {
-	mp := map[string]*pubsub.TopicScoreParams{
-		headp2p.PubsubTopicID(network.String()): &headp2p.GossibSubScore,
+func topicScoreParams(network Network, cfg Config) map[string]*pubsub.TopicScoreParams {
+	mp := make(map[string]*pubsub.TopicScoreParams)
+	if !cfg.TopicDisabled(HeaderTopic) {
+		mp[headp2p.PubsubTopicID(network.String())] = &headp2p.GossibSubScore
 	}
 
-	for _, pt := range fraud.Registered() {
-		mp[fraudserv.PubsubTopicID(pt.String(), network.String())] = &fraudserv.GossibSubScore
+	if !cfg.TopicDisabled(FraudTopic) {
+		for _, pt := range fraud.Registered() {
+			mp[fraudserv.PubsubTopicID(pt.String(), network.String())] = &fraudserv.GossibSubScore
+		}
 	}
 
 	return mp
@@ -0,0 +1,124 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsub_pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var errPubSubTracingDisabled = errors.New("p2p: pubsub tracing is disabled, enable P2P.PubSubTracing in the config")
+
+// PubSubTopicTrace counts the messages of a pubsub topic seen by the node.
+type PubSubTopicTrace struct {
+	// Published is the amount of messages published by the node.
+	Published uint64 `json:"published"`
+	// Received is the amount of valid messages received from peers.
+	Received uint64 `json:"received"`
+	// Duplicates is the amount of messages received again after they were seen.
+	Duplicates uint64 `json:"duplicates"`
+	// Rejected is the amount of messages that failed or were ignored by validation.
+	Rejected uint64 `json:"rejected"`
+	// Dropped is the amount of messages that were not sent to peers, as their queues were full.
+	Dropped uint64 `json:"dropped"`
+}
+
+var _ pubsub.EventTracer = (*pubSubTracer)(nil)
+
+// pubSubTracer collects the PubSubTopicTrace of every topic from gossipsub trace events.
+type pubSubTracer struct {
+	lock   sync.Mutex
+	topics map[string]*PubSubTopicTrace
+}
+
+// newPubSubTracer constructs a pubSubTracer if tracing is enabled in the config.
+func newPubSubTracer(cfg Config) *pubSubTracer {
+	if !cfg.PubSubTracing {
+		return nil
+	}
+	return &pubSubTracer{topics: make(map[string]*PubSubTopicTrace)}
+}
+
+// Trace counts the given event towards its topic.
+func (t *pubSubTracer) Trace(evt *pubsub_pb.TraceEvent) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	switch evt.GetType() {
+	case pubsub_pb.TraceEvent_PUBLISH_MESSAGE:
+		t.topic(evt.GetPublishMessage().GetTopic()).Published++
+	case pubsub_pb.TraceEvent_DELIVER_MESSAGE:
+		// messages published by the node are delivered to its own subscriptions as well
+		if msg := evt.GetDeliverMessage(); string(msg.GetReceivedFrom()) != string(evt.GetPeerID()) {
+			t.topic(msg.GetTopic()).Received++
+		}
+	case pubsub_pb.TraceEvent_DUPLICATE_MESSAGE:
+		t.topic(evt.GetDuplicateMessage().GetTopic()).Duplicates++
+	case pubsub_pb.TraceEvent_REJECT_MESSAGE:
+		t.topic(evt.GetRejectMessage().GetTopic()).Rejected++
+	case pubsub_pb.TraceEvent_DROP_RPC:
+		for _, msg := range evt.GetDropRPC().GetMeta().GetMessages() {
+			t.topic(msg.GetTopic()).Dropped++
+		}
+	}
+}
+
+func (t *pubSubTracer) topic(topic string) *PubSubTopicTrace {
+	trace, ok := t.topics[topic]
+	if !ok {
+		trace = &PubSubTopicTrace{}
+		t.topics[topic] = trace
+	}
+	return trace
+}
+
+// Traces returns the traces of all the topics the node has seen messages of.
+func (t *pubSubTracer) Traces() map[string]PubSubTopicTrace {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	traces := make(map[string]PubSubTopicTrace, len(t.topics))
+	for topic, trace := range t.topics {
+		traces[topic] = *trace
+	}
+	return traces
+}
+
+// pubSubTraceMetrics reports the collected traces as OTLP metrics, if tracing is enabled.
+func pubSubTraceMetrics(tracer *pubSubTracer) error {
+	if tracer == nil {
+		return nil
+	}
+
+	meter := otel.Meter("p2p/pubsub")
+	messages, err := meter.Int64ObservableCounter("p2p_pubsub_messages_counter",
+		metric.WithDescription("amount of pubsub messages per topic and event"))
+	if err != nil {
+		return err
+	}
+
+	callback := func(_ context.Context, observer metric.Observer) error {
+		for topic, trace := range tracer.Traces() {
+			for event, count := range map[string]uint64{
+				"published":  trace.Published,
+				"received":   trace.Received,
+				"duplicates": trace.Duplicates,
+				"rejected":   trace.Rejected,
+				"dropped":    trace.Dropped,
+			} {
+				observer.ObserveInt64(messages, int64(count), metric.WithAttributes(
+					attribute.String("topic", topic),
+					attribute.String("event", event),
+				))
+			}
+		}
+		return nil
+	}
+	_, err = meter.RegisterCallback(callback, messages)
+	return err
+}
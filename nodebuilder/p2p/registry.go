@@ -0,0 +1,112 @@
+package p2p
+
+import (
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// EnvNetworksFile is the environment variable name used for setting the path of a network
+// registry file loaded on top of the embedded one.
+const EnvNetworksFile = "CELESTIA_NETWORKS_FILE"
+
+// embeddedNetworks is the registry of long-running networks known at build time.
+//
+//go:embed networks.json
+var embeddedNetworks []byte
+
+// NetworkInfo describes a network in the network registry.
+type NetworkInfo struct {
+	// Network is the ID of the network, matching the chain ID of the network.
+	Network Network `json:"network"`
+	// Aliases are the alternative names the network can be selected with.
+	Aliases []string `json:"aliases,omitempty"`
+	// GenesisHash is the hash of the first block of the network.
+	GenesisHash string `json:"genesis_hash,omitempty"`
+	// DefaultGasPrice is the price per unit of gas in utia paid by transactions submitted without
+	// a fee. It has to be kept at or above the minimum gas price of the network's validators.
+	DefaultGasPrice float64 `json:"default_gas_price,omitempty"`
+	// Bootstrappers are multiaddresses of the bootstrap peers of the network.
+	Bootstrappers []string `json:"bootstrappers,omitempty"`
+}
+
+func init() {
+	if err := registerNetworks(embeddedNetworks); err != nil {
+		panic(fmt.Sprintf("params: embedded network registry: %s", err))
+	}
+}
+
+// LoadNetworks registers the networks from the registry file under the given path, so that
+// networks defined after the release of the binary can be used. Networks already known are
+// overridden by the file.
+func LoadNetworks(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("params: reading network registry: %w", err)
+	}
+	if err = registerNetworks(data); err != nil {
+		return fmt.Errorf("params: network registry %s: %w", path, err)
+	}
+	return nil
+}
+
+// registerNetworks decodes the registry and registers all the networks in it. Nothing is
+// registered if any of the networks is invalid.
+func registerNetworks(data []byte) error {
+	var infos []NetworkInfo
+	if err := json.Unmarshal(data, &infos); err != nil {
+		return err
+	}
+	for _, info := range infos {
+		if err := info.validate(); err != nil {
+			return err
+		}
+	}
+	for _, info := range infos {
+		info.register()
+	}
+	return nil
+}
+
+func (info NetworkInfo) validate() error {
+	if info.Network == "" {
+		return errors.New("network ID must not be empty")
+	}
+	if _, err := hex.DecodeString(info.GenesisHash); err != nil {
+		return fmt.Errorf("network %s: invalid genesis hash: %w", info.Network, err)
+	}
+	if info.DefaultGasPrice < 0 {
+		return fmt.Errorf("network %s: default gas price must not be negative", info.Network)
+	}
+	if _, err := parseAddrInfos(info.Bootstrappers); err != nil {
+		return fmt.Errorf("network %s: invalid bootstrapper: %w", info.Network, err)
+	}
+	return nil
+}
+
+func (info NetworkInfo) register() {
+	networksList[info.Network] = struct{}{}
+	genesisList[info.Network] = strings.ToUpper(info.GenesisHash)
+	bootstrapList[info.Network] = info.Bootstrappers
+	gasPriceList[info.Network] = info.DefaultGasPrice
+	for _, alias := range info.Aliases {
+		networkAliases[alias] = info.Network
+	}
+}
+
+// gasPriceList maps networks to their default gas prices. It is filled from the network registry.
+var gasPriceList = map[Network]float64{}
+
+// DefaultGasPriceFor reports the default gas price of the given network. Zero means the network
+// does not define one.
+func DefaultGasPriceFor(net Network) (float64, error) {
+	net, err := net.Validate()
+	if err != nil {
+		return 0, err
+	}
+	return gasPriceList[net], nil
+}
@@ -0,0 +1,99 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	relayv2 "github.com/libp2p/go-libp2p/p2p/protocol/circuitv2/relay"
+	ma "github.com/multiformats/go-multiaddr"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+// relayConfig configures circuit relay v2, through which peers can reach nodes that are not
+// publicly dialable, e.g. behind a NAT.
+type relayConfig struct {
+	// Client makes the node reserve slots on relays and announce its relayed addresses whenever it
+	// is not publicly reachable, so that peers can dial it through the relays.
+	Client bool
+	// StaticRelays are the multiaddresses of the relays the client reserves slots on. The
+	// bootstrappers are used if empty.
+	StaticRelays []string
+	// Service makes a publicly reachable bridge or full node serve as a relay for other nodes.
+	// Relayed connections are limited in duration and transferred data.
+	Service bool
+}
+
+func (cfg *relayConfig) staticRelays() (_ []peer.AddrInfo, err error) {
+	maddrs := make([]ma.Multiaddr, len(cfg.StaticRelays))
+	for i, addr := range cfg.StaticRelays {
+		maddrs[i], err = ma.NewMultiaddr(addr)
+		if err != nil {
+			return nil, fmt.Errorf("failure to parse config.P2P.Relay.StaticRelays: %s", err)
+		}
+	}
+	return peer.AddrInfosFromP2pAddrs(maddrs...)
+}
+
+// relayOptions returns the libp2p options enabling the configured relay roles.
+func relayOptions(cfg relayConfig, tp node.Type, bootstrappers Bootstrappers) ([]libp2p.Option, error) {
+	if !cfg.Client && !cfg.Service {
+		return []libp2p.Option{libp2p.DisableRelay()}, nil
+	}
+
+	// the relay transport is needed to dial and accept relayed connections
+	opts := []libp2p.Option{libp2p.EnableRelay()}
+	if cfg.Client {
+		relays, err := cfg.staticRelays()
+		if err != nil {
+			return nil, err
+		}
+		if len(relays) == 0 {
+			relays = bootstrappers
+		}
+		opts = append(opts, libp2p.EnableAutoRelayWithStaticRelays(relays))
+	}
+	if cfg.Service {
+		if tp == node.Light {
+			return nil, fmt.Errorf("light node cannot serve as a relay")
+		}
+		opts = append(opts, libp2p.EnableRelayService(relayv2.WithResources(relayv2.DefaultResources())))
+	}
+	return opts, nil
+}
+
+// relayMetrics reports the amount of relayed connections of the node, if a relay role is enabled.
+// The relay service and the relay client report their own metrics through libp2p.
+func relayMetrics(cfg Config, h HostBase) error {
+	if !cfg.Relay.Client && !cfg.Relay.Service {
+		return nil
+	}
+
+	meter := otel.Meter("p2p/relay")
+	conns, err := meter.Int64ObservableGauge("p2p_relayed_connections",
+		metric.WithDescription("amount of open connections through a relay per direction"))
+	if err != nil {
+		return err
+	}
+
+	callback := func(_ context.Context, observer metric.Observer) error {
+		counts := map[network.Direction]int64{network.DirInbound: 0, network.DirOutbound: 0}
+		for _, conn := range h.Network().Conns() {
+			if _, err := conn.RemoteMultiaddr().ValueForProtocol(ma.P_CIRCUIT); err == nil {
+				counts[conn.Stat().Direction]++
+			}
+		}
+		for dir, count := range counts {
+			observer.ObserveInt64(conns, count, metric.WithAttributes(attribute.String("direction", dir.String())))
+		}
+		return nil
+	}
+	_, err = meter.RegisterCallback(callback, conns)
+	return err
+}
@@ -0,0 +1,57 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ipfs/go-datastore"
+	ds_sync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+
+	"github.com/celestiaorg/celestia-node/libs/keystore"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+func TestRelayOptions(t *testing.T) {
+	opts, err := relayOptions(relayConfig{}, node.Light, nil)
+	require.NoError(t, err)
+	require.Len(t, opts, 1)
+
+	cfg := relayConfig{Client: true, StaticRelays: []string{"/ip4/127.0.0.1/tcp/2121"}}
+	_, err = relayOptions(cfg, node.Light, nil)
+	require.Error(t, err)
+
+	cfg = relayConfig{Service: true}
+	_, err = relayOptions(cfg, node.Light, nil)
+	require.Error(t, err)
+	opts, err = relayOptions(cfg, node.Full, nil)
+	require.NoError(t, err)
+	require.Len(t, opts, 2)
+}
+
+func TestModuleBuild_WithRelay(t *testing.T) {
+	for _, tp := range []node.Type{node.Full, node.Light} {
+		t.Run(tp.String(), func(t *testing.T) {
+			cfg := DefaultConfig(tp)
+			cfg.Relay.Client = true
+			cfg.Relay.Service = tp != node.Light
+			app := fxtest.New(t,
+				fx.NopLogger,
+				ConstructModule(tp, &cfg),
+				fx.Provide(context.Background),
+				fx.Supply(Private),
+				fx.Supply(Bootstrappers{}),
+				fx.Supply(tp),
+				fx.Provide(keystore.NewMapKeystore),
+				fx.Supply(fx.Annotate(ds_sync.MutexWrap(datastore.NewMapDatastore()), fx.As(new(datastore.Batching)))),
+				fx.Invoke(func(cfg Config, _ HostBase) {
+					require.True(t, cfg.Relay.Client)
+				}),
+			)
+			app.RequireStart()
+			app.RequireStop()
+		})
+	}
+}
@@ -2,6 +2,8 @@ package p2p
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p/core/network"
@@ -9,11 +11,93 @@ import (
 	rcmgrObs "github.com/libp2p/go-libp2p/p2p/host/resource-manager/obs"
 	ma "github.com/multiformats/go-multiaddr"
 	madns "github.com/multiformats/go-multiaddr-dns"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/fx"
 )
 
+// resourceManagerConfig overrides the limits of the resource manager scopes, which otherwise
+// default to the limits of the node type: scaled to the system resources for light nodes and
+// unlimited for bridge and full nodes.
+type resourceManagerConfig struct {
+	// System limits the resources used by the node as a whole.
+	System scopeLimits
+	// Transient limits the resources of the connections and streams not yet attributed to a peer
+	// or protocol.
+	Transient scopeLimits
+	// Peer limits the resources used by each peer.
+	Peer scopeLimits
+	// Protocol limits the resources used by each protocol.
+	Protocol scopeLimits
+}
+
+// scopeLimits are the limits of a resource manager scope. Zero keeps the default limit and -1
+// removes the limit.
+type scopeLimits struct {
+	Conns           int
+	ConnsInbound    int
+	ConnsOutbound   int
+	Streams         int
+	StreamsInbound  int
+	StreamsOutbound int
+	// Memory is the amount of bytes that can be reserved.
+	Memory int64
+}
+
+func (l scopeLimits) validate() error {
+	for _, limit := range []int64{
+		int64(l.Conns), int64(l.ConnsInbound), int64(l.ConnsOutbound),
+		int64(l.Streams), int64(l.StreamsInbound), int64(l.StreamsOutbound),
+		l.Memory,
+	} {
+		if limit < -1 {
+			return fmt.Errorf("limit %d is below -1", limit)
+		}
+	}
+	return nil
+}
+
+func (l scopeLimits) resourceLimits() rcmgr.ResourceLimits {
+	return rcmgr.ResourceLimits{
+		Conns:           rcmgr.LimitVal(l.Conns),
+		ConnsInbound:    rcmgr.LimitVal(l.ConnsInbound),
+		ConnsOutbound:   rcmgr.LimitVal(l.ConnsOutbound),
+		Streams:         rcmgr.LimitVal(l.Streams),
+		StreamsInbound:  rcmgr.LimitVal(l.StreamsInbound),
+		StreamsOutbound: rcmgr.LimitVal(l.StreamsOutbound),
+		Memory:          rcmgr.LimitVal64(l.Memory),
+	}
+}
+
+func (cfg *resourceManagerConfig) validate() error {
+	for scope, limits := range map[string]scopeLimits{
+		"System":    cfg.System,
+		"Transient": cfg.Transient,
+		"Peer":      cfg.Peer,
+		"Protocol":  cfg.Protocol,
+	} {
+		if err := limits.validate(); err != nil {
+			return fmt.Errorf("config.P2P.ResourceManager.%s: %w", scope, err)
+		}
+	}
+	return nil
+}
+
+// limits applies the configured limits over the given default ones.
+func (cfg *resourceManagerConfig) limits(defaults rcmgr.ConcreteLimitConfig) rcmgr.ConcreteLimitConfig {
+	partial := rcmgr.PartialLimitConfig{
+		System:          cfg.System.resourceLimits(),
+		Transient:       cfg.Transient.resourceLimits(),
+		PeerDefault:     cfg.Peer.resourceLimits(),
+		ProtocolDefault: cfg.Protocol.resourceLimits(),
+	}
+	return partial.Build(defaults)
+}
+
 func resourceManager(params resourceManagerParams) (network.ResourceManager, error) {
-	return rcmgr.NewResourceManager(rcmgr.NewFixedLimiter(params.Limits))
+	limits := params.Cfg.ResourceManager.limits(params.Limits)
+	return rcmgr.NewResourceManager(rcmgr.NewFixedLimiter(limits), params.Opts...)
 }
 
 func infiniteResources() rcmgr.ConcreteLimitConfig {
@@ -31,10 +115,15 @@ func allowList(ctx context.Context, cfg Config, bootstrappers Bootstrappers) (rc
 	if err != nil {
 		return nil, err
 	}
+	protected, err := cfg.protectedPeers()
+	if err != nil {
+		return nil, err
+	}
+	peers := append(mutual, protected...)
 
 	// TODO(@Wondertan): We should resolve their addresses only once, but currently
 	//  we resolve it here and libp2p stuck does that as well internally
-	allowlist := make([]ma.Multiaddr, 0, len(bootstrappers)+len(mutual))
+	allowlist := make([]ma.Multiaddr, 0, len(bootstrappers)+len(peers))
 	for _, b := range bootstrappers {
 		for _, baddr := range b.Addrs {
 			resolved, err := madns.DefaultResolver.Resolve(ctx, baddr)
@@ -45,11 +134,11 @@ func allowList(ctx context.Context, cfg Config, bootstrappers Bootstrappers) (rc
 			allowlist = append(allowlist, resolved...)
 		}
 	}
-	for _, m := range mutual {
+	for _, m := range peers {
 		for _, maddr := range m.Addrs {
 			resolved, err := madns.DefaultResolver.Resolve(ctx, maddr)
 			if err != nil {
-				log.Warnw("error resolving mutual peer DNS", "addr", maddr.String(), "err", err)
+				log.Warnw("error resolving mutual or protected peer DNS", "addr", maddr.String(), "err", err)
 				continue
 			}
 			allowlist = append(allowlist, resolved...)
@@ -68,9 +157,63 @@ func traceReporter() rcmgr.Option {
 	return rcmgr.WithTraceReporter(str)
 }
 
+// metricsReporter counts the connections, streams and memory reservations allowed and blocked by
+// the resource manager per scope.
+type metricsReporter struct {
+	requests metric.Int64Counter
+}
+
+func newMetricsReporter() (rcmgr.Option, error) {
+	meter := otel.Meter("p2p/rcmgr")
+	requests, err := meter.Int64Counter("p2p_rcmgr_requests_counter",
+		metric.WithDescription("amount of resources requested from the resource manager"))
+	if err != nil {
+		return nil, err
+	}
+	return rcmgr.WithTraceReporter(&metricsReporter{requests: requests}), nil
+}
+
+func (r *metricsReporter) ConsumeEvent(evt rcmgr.TraceEvt) {
+	var resource string
+	var blocked bool
+	switch evt.Type {
+	case rcmgr.TraceAddConnEvt, rcmgr.TraceBlockAddConnEvt:
+		resource = "conn"
+		blocked = evt.Type == rcmgr.TraceBlockAddConnEvt
+	case rcmgr.TraceAddStreamEvt, rcmgr.TraceBlockAddStreamEvt:
+		resource = "stream"
+		blocked = evt.Type == rcmgr.TraceBlockAddStreamEvt
+	case rcmgr.TraceReserveMemoryEvt, rcmgr.TraceBlockReserveMemoryEvt:
+		resource = "memory"
+		blocked = evt.Type == rcmgr.TraceBlockReserveMemoryEvt
+	default:
+		return
+	}
+
+	r.requests.Add(context.Background(), 1, metric.WithAttributes(
+		attribute.String("resource", resource),
+		attribute.String("scope", scopeKind(evt.Name)),
+		attribute.Bool("blocked", blocked),
+	))
+}
+
+// scopeKind returns the kind of the scope with the given name, e.g. "peer" for "peer:<id>" and
+// "protocol_peer" for "protocol:<id>.peer:<id>", to not report metrics per peer or connection.
+func scopeKind(name string) string {
+	kind := name
+	if i := strings.IndexAny(name, ":-"); i >= 0 {
+		kind = name[:i]
+	}
+	if kind != "peer" && strings.Contains(name, ".peer:") {
+		kind += "_peer"
+	}
+	return kind
+}
+
 type resourceManagerParams struct {
 	fx.In
 
+	Cfg    Config
 	Limits rcmgr.ConcreteLimitConfig
 	Opts   []rcmgr.Option `group:"rcmgr-opts"`
 }
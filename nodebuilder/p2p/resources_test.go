@@ -0,0 +1,36 @@
+package p2p
+
+import (
+	"testing"
+
+	rcmgr "github.com/libp2p/go-libp2p/p2p/host/resource-manager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceManagerConfig(t *testing.T) {
+	cfg := resourceManagerConfig{
+		System: scopeLimits{Conns: 10, Memory: 1 << 20},
+		Peer:   scopeLimits{Streams: -1},
+	}
+	require.NoError(t, cfg.validate())
+
+	limits := cfg.limits(autoscaleResources()).ToPartialLimitConfig()
+	assert.EqualValues(t, 10, limits.System.Conns)
+	assert.EqualValues(t, 1<<20, limits.System.Memory)
+	assert.Equal(t, rcmgr.Unlimited, limits.PeerDefault.Streams)
+	// the limits not configured keep their defaults
+	defaults := autoscaleResources().ToPartialLimitConfig()
+	assert.Equal(t, defaults.System.Streams, limits.System.Streams)
+	assert.Equal(t, defaults.Transient, limits.Transient)
+
+	cfg.Transient.ConnsInbound = -2
+	require.Error(t, cfg.validate())
+}
+
+func TestScopeKind(t *testing.T) {
+	assert.Equal(t, "system", scopeKind("system"))
+	assert.Equal(t, "conn", scopeKind("conn-12"))
+	assert.Equal(t, "peer", scopeKind("peer:12D3KooW"))
+	assert.Equal(t, "protocol_peer", scopeKind("protocol:/shrex/nd/v0.0.3.peer:12D3KooW"))
+}
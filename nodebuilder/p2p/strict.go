@@ -0,0 +1,84 @@
+package p2p
+
+import (
+	"fmt"
+
+	"github.com/libp2p/go-libp2p/core/connmgr"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/net/conngater"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// strictGater extends the BasicConnectionGater to deny connections with any peer outside the
+// allowlist. As every protocol (header exchange, shrex, gossip, etc.) runs over a connection, the
+// node does not talk to any other peer.
+type strictGater struct {
+	*conngater.BasicConnectionGater
+
+	allowed map[peer.ID]struct{}
+}
+
+var _ connmgr.ConnectionGater = (*strictGater)(nil)
+
+// connectionGaterFor returns the ConnectionGater of the Host, restricting it to the allowed peers
+// in strict peering mode.
+func connectionGaterFor(cfg Config, gater *conngater.BasicConnectionGater) (connmgr.ConnectionGater, error) {
+	if !cfg.StrictPeering {
+		return gater, nil
+	}
+	allowed, err := cfg.allowedPeers()
+	if err != nil {
+		return nil, err
+	}
+	log.Infow("strict peering enabled", "allowed peers", len(allowed))
+	return &strictGater{BasicConnectionGater: gater, allowed: allowed}, nil
+}
+
+func (sg *strictGater) InterceptPeerDial(p peer.ID) bool {
+	return sg.isAllowed(p) && sg.BasicConnectionGater.InterceptPeerDial(p)
+}
+
+func (sg *strictGater) InterceptAddrDial(p peer.ID, addr ma.Multiaddr) bool {
+	return sg.isAllowed(p) && sg.BasicConnectionGater.InterceptAddrDial(p, addr)
+}
+
+// InterceptSecured gates inbound connections, as their peer is only known once the connection is
+// secured.
+func (sg *strictGater) InterceptSecured(dir network.Direction, p peer.ID, cma network.ConnMultiaddrs) bool {
+	return sg.isAllowed(p) && sg.BasicConnectionGater.InterceptSecured(dir, p, cma)
+}
+
+func (sg *strictGater) isAllowed(p peer.ID) bool {
+	_, ok := sg.allowed[p]
+	if !ok {
+		log.Debugw("denied connection with peer outside the allowlist", "peer", p)
+	}
+	return ok
+}
+
+// IsPeerAllowed reports whether the node may connect to the given peer. Any peer is allowed unless
+// strict peering is enabled.
+func (cfg *Config) IsPeerAllowed(p peer.ID) bool {
+	if !cfg.StrictPeering {
+		return true
+	}
+	for _, id := range cfg.AllowedPeers {
+		if id == p.String() {
+			return true
+		}
+	}
+	return false
+}
+
+func (cfg *Config) allowedPeers() (map[peer.ID]struct{}, error) {
+	allowed := make(map[peer.ID]struct{}, len(cfg.AllowedPeers))
+	for _, id := range cfg.AllowedPeers {
+		p, err := peer.Decode(id)
+		if err != nil {
+			return nil, fmt.Errorf("failure to parse config.P2P.AllowedPeers: %s", err)
+		}
+		allowed[p] = struct{}{}
+	}
+	return allowed, nil
+}
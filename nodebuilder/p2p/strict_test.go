@@ -0,0 +1,71 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/libp2p/go-libp2p"
+	libhost "github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+func TestStrictPeering(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	newHost := func(opts ...libp2p.Option) libhost.Host {
+		opts = append(opts, libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+		h, err := libp2p.New(opts...)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, h.Close())
+		})
+		return h
+	}
+	allowed, denied := newHost(), newHost()
+
+	cfg := DefaultConfig(node.Light)
+	cfg.StrictPeering = true
+	cfg.AllowedPeers = []string{allowed.ID().String()}
+	require.NoError(t, cfg.Validate())
+
+	basic, err := connectionGater(datastore.NewMapDatastore())
+	require.NoError(t, err)
+	gater, err := connectionGaterFor(cfg, basic)
+	require.NoError(t, err)
+	strict := newHost(libp2p.ConnectionGater(gater))
+
+	// outbound
+	require.NoError(t, strict.Connect(ctx, *libhost.InfoFromHost(allowed)))
+	assert.Error(t, strict.Connect(ctx, *libhost.InfoFromHost(denied)))
+
+	// inbound
+	require.NoError(t, allowed.Connect(ctx, *libhost.InfoFromHost(strict)))
+	// the dialer learns about the denial only once the strict host closes the connection
+	_ = denied.Connect(ctx, *libhost.InfoFromHost(strict))
+	assert.NotEqual(t, network.Connected, strict.Network().Connectedness(denied.ID()))
+	assert.Eventually(t, func() bool {
+		return denied.Network().Connectedness(strict.ID()) != network.Connected
+	}, time.Second, 10*time.Millisecond)
+	assert.False(t, cfg.IsPeerAllowed(denied.ID()))
+
+	// blocked peers stay blocked even if allowed
+	require.NoError(t, basic.BlockPeer(allowed.ID()))
+	require.NoError(t, strict.Network().ClosePeer(allowed.ID()))
+	assert.Error(t, strict.Connect(ctx, *libhost.InfoFromHost(allowed)))
+}
+
+func TestStrictPeering_Validate(t *testing.T) {
+	cfg := DefaultConfig(node.Light)
+	cfg.StrictPeering = true
+	assert.Error(t, cfg.Validate())
+
+	cfg.AllowedPeers = []string{"invalid"}
+	assert.Error(t, cfg.Validate())
+}
@@ -0,0 +1,105 @@
+package p2p
+
+import (
+	"fmt"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsub_pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/celestiaorg/go-fraud"
+	"github.com/celestiaorg/go-fraud/fraudserv"
+	headp2p "github.com/celestiaorg/go-header/p2p"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+// Topic identifies a gossip topic the node can stay out of.
+type Topic string
+
+const (
+	// HeaderTopic gossips new headers. Light and full nodes out of it poll the network head from
+	// their trusted peers instead, while bridge nodes publish the headers over it.
+	HeaderTopic Topic = "header"
+	// FraudTopic gossips fraud proofs of every type. Light nodes out of it neither receive nor
+	// broadcast fraud proofs. Full and bridge nodes can not leave it, as they would keep serving
+	// the data of a fraudulent block they detected.
+	FraudTopic Topic = "fraud"
+)
+
+// TopicDisabled reports whether the node stays out of the given gossip topic.
+func (cfg *Config) TopicDisabled(topic Topic) bool {
+	for _, disabled := range cfg.DisabledTopics {
+		if disabled == topic {
+			return true
+		}
+	}
+	return false
+}
+
+func (cfg *Config) validateTopics() error {
+	for _, topic := range cfg.DisabledTopics {
+		if topic != HeaderTopic && topic != FraudTopic {
+			return fmt.Errorf("unknown gossip topic %q in DisabledTopics, must be one of %s, %s",
+				topic, HeaderTopic, FraudTopic)
+		}
+	}
+	return nil
+}
+
+// validateTopicsFor checks that the node type can stay out of the disabled topics.
+func (cfg *Config) validateTopicsFor(tp node.Type) error {
+	if tp.Base() == node.Bridge && cfg.TopicDisabled(HeaderTopic) {
+		return fmt.Errorf("bridge nodes publish headers, so the %s topic cannot be disabled", HeaderTopic)
+	}
+	if tp.Base() != node.Light && cfg.TopicDisabled(FraudTopic) {
+		return fmt.Errorf("%s nodes detect and broadcast fraud proofs, so the %s topic cannot be disabled",
+			tp.Base(), FraudTopic)
+	}
+	return nil
+}
+
+// topicIDs returns the IDs of the pubsub topics behind the given gossip topic.
+func topicIDs(topic Topic, network Network) []string {
+	switch topic {
+	case HeaderTopic:
+		return []string{headp2p.PubsubTopicID(network.String())}
+	case FraudTopic:
+		ids := make([]string, 0, len(fraud.Registered()))
+		for _, pt := range fraud.Registered() {
+			ids = append(ids, fraudserv.PubsubTopicID(pt.String(), network.String()))
+		}
+		return ids
+	default:
+		return nil
+	}
+}
+
+// topicFilter keeps the node out of the disabled topics, neither joining them nor tracking the
+// peers subscribed to them.
+type topicFilter map[string]bool
+
+// newTopicFilter returns the filter of the disabled topics, or nil if none is disabled.
+func newTopicFilter(cfg Config, network Network) pubsub.SubscriptionFilter {
+	if len(cfg.DisabledTopics) == 0 {
+		return nil
+	}
+	filter := make(topicFilter)
+	for _, topic := range cfg.DisabledTopics {
+		for _, id := range topicIDs(topic, network) {
+			filter[id] = true
+		}
+	}
+	return filter
+}
+
+func (f topicFilter) CanSubscribe(topic string) bool {
+	return !f[topic]
+}
+
+func (f topicFilter) FilterIncomingSubscriptions(
+	_ peer.ID,
+	subs []*pubsub_pb.RPC_SubOpts,
+) ([]*pubsub_pb.RPC_SubOpts, error) {
+	return pubsub.FilterSubscriptions(subs, f.CanSubscribe), nil
+}
@@ -0,0 +1,63 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsub_pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	headp2p "github.com/celestiaorg/go-header/p2p"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+func TestDisabledTopics(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	cfg := DefaultConfig(node.Light)
+	require.Nil(t, newTopicFilter(cfg, Private))
+
+	cfg.DisabledTopics = []Topic{"shrex"}
+	require.ErrorContains(t, cfg.Validate(), "unknown gossip topic")
+
+	cfg.DisabledTopics = []Topic{HeaderTopic, FraudTopic}
+	require.NoError(t, cfg.Validate())
+	require.NoError(t, cfg.validateTopicsFor(node.Light))
+	require.Error(t, cfg.validateTopicsFor(node.Bridge))
+	cfg.DisabledTopics = []Topic{FraudTopic}
+	require.NoError(t, cfg.validateTopicsFor(node.Light))
+	require.ErrorContains(t, cfg.validateTopicsFor(node.Full), "fraud")
+	require.ErrorContains(t, cfg.validateTopicsFor(node.Bridge), "fraud")
+	cfg.DisabledTopics = []Topic{HeaderTopic, FraudTopic}
+	assert.True(t, cfg.TopicDisabled(HeaderTopic))
+	assert.NotContains(t, topicScoreParams(Private, cfg), headp2p.PubsubTopicID(Private.String()))
+
+	h, err := libp2p.New(libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, h.Close())
+	})
+	filter := newTopicFilter(cfg, Private)
+	ps, err := pubsub.NewGossipSub(ctx, h, pubsub.WithSubscriptionFilter(filter))
+	require.NoError(t, err)
+
+	// the node neither joins the disabled topics nor tracks the peers subscribed to them
+	headerTopic := headp2p.PubsubTopicID(Private.String())
+	_, err = ps.Join(headerTopic)
+	require.Error(t, err)
+	_, err = ps.Join("/eds-sub/0.0.1")
+	require.NoError(t, err)
+
+	subscribe := true
+	subs, err := filter.FilterIncomingSubscriptions("", []*pubsub_pb.RPC_SubOpts{
+		{Topicid: &headerTopic, Subscribe: &subscribe},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, subs)
+}
@@ -0,0 +1,141 @@
+package p2p
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/libp2p/go-libp2p"
+	libp2pquic "github.com/libp2p/go-libp2p/p2p/transport/quic"
+	"github.com/libp2p/go-libp2p/p2p/transport/tcp"
+	ws "github.com/libp2p/go-libp2p/p2p/transport/websocket"
+	webtransport "github.com/libp2p/go-libp2p/p2p/transport/webtransport"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// transport identifies the transports that can be selected in the config.
+type transport string
+
+const (
+	transportTCP          transport = "TCP"
+	transportQUIC         transport = "QUIC"
+	transportWebTransport transport = "WebTransport"
+	// transportOther stands for the transports that are always enabled, e.g. WebSocket.
+	transportOther transport = ""
+)
+
+// transportsConfig selects the transports the node dials and listens with.
+type transportsConfig struct {
+	TCP  transportConfig
+	QUIC transportConfig
+	// WebTransport allows browser nodes to dial the node. It does not listen by default, which
+	// requires an address like "/ip4/0.0.0.0/udp/2122/quic-v1/webtransport" in its ListenAddresses.
+	WebTransport transportConfig
+}
+
+// transportConfig configures a single transport.
+type transportConfig struct {
+	// Disabled turns off dialing and listening with the transport. Listen addresses of a disabled
+	// transport are ignored.
+	Disabled bool
+	// ListenAddresses are the addresses to listen on with the transport, in addition to the
+	// ListenAddresses of the P2P config.
+	ListenAddresses []string
+}
+
+// transportOf returns the transport that listens on or dials the given address.
+func transportOf(maddr ma.Multiaddr) transport {
+	protos := make(map[int]bool)
+	for _, p := range maddr.Protocols() {
+		protos[p.Code] = true
+	}
+	switch {
+	case protos[ma.P_WEBTRANSPORT]:
+		return transportWebTransport
+	case protos[ma.P_QUIC_V1], protos[ma.P_QUIC]:
+		return transportQUIC
+	case protos[ma.P_WS], protos[ma.P_WSS]:
+		return transportOther
+	case protos[ma.P_TCP]:
+		return transportTCP
+	default:
+		return transportOther
+	}
+}
+
+func (cfg *transportsConfig) get(tr transport) *transportConfig {
+	switch tr {
+	case transportTCP:
+		return &cfg.TCP
+	case transportQUIC:
+		return &cfg.QUIC
+	case transportWebTransport:
+		return &cfg.WebTransport
+	default:
+		return nil
+	}
+}
+
+func (cfg *transportsConfig) enabled(tr transport) bool {
+	trCfg := cfg.get(tr)
+	return trCfg == nil || !trCfg.Disabled
+}
+
+// options returns the libp2p options enabling the selected transports.
+func (cfg *transportsConfig) options() libp2p.Option {
+	opts := []libp2p.Option{libp2p.Transport(ws.New)}
+	if !cfg.TCP.Disabled {
+		opts = append(opts, libp2p.Transport(tcp.NewTCPTransport))
+	}
+	if !cfg.QUIC.Disabled {
+		opts = append(opts, libp2p.Transport(libp2pquic.NewTransport))
+	}
+	if !cfg.WebTransport.Disabled {
+		opts = append(opts, libp2p.Transport(webtransport.New))
+	}
+	return libp2p.ChainOptions(opts...)
+}
+
+// validate checks that at least one of the selectable transports is enabled and that the listen
+// addresses of each transport belong to it.
+func (cfg *transportsConfig) validate() error {
+	if cfg.TCP.Disabled && cfg.QUIC.Disabled && cfg.WebTransport.Disabled {
+		return errors.New("at least one of TCP, QUIC or WebTransport transports must be enabled")
+	}
+	for _, tr := range []transport{transportTCP, transportQUIC, transportWebTransport} {
+		for _, addr := range cfg.get(tr).ListenAddresses {
+			maddr, err := ma.NewMultiaddr(addr)
+			if err != nil {
+				return fmt.Errorf("failure to parse config.P2P.Transports.%s.ListenAddresses: %w", tr, err)
+			}
+			if transportOf(maddr) != tr {
+				return fmt.Errorf("config.P2P.Transports.%s.ListenAddresses: %s is not a %s address", tr, addr, tr)
+			}
+		}
+	}
+	return nil
+}
+
+// listenAddresses returns the addresses to listen on with the enabled transports.
+func (cfg *Config) listenAddresses() []string {
+	addrs := make([]string, 0, len(cfg.ListenAddresses))
+	for _, addr := range cfg.ListenAddresses {
+		maddr, err := ma.NewMultiaddr(addr)
+		if err != nil {
+			// keep it for Listen to report
+			addrs = append(addrs, addr)
+			continue
+		}
+		if tr := transportOf(maddr); !cfg.Transports.enabled(tr) {
+			log.Infow("skipping listen address of a disabled transport", "addr", addr, "transport", tr)
+			continue
+		}
+		addrs = append(addrs, addr)
+	}
+
+	for _, tr := range []transport{transportTCP, transportQUIC, transportWebTransport} {
+		if trCfg := cfg.Transports.get(tr); !trCfg.Disabled {
+			addrs = append(addrs, trCfg.ListenAddresses...)
+		}
+	}
+	return addrs
+}
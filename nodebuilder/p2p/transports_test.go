@@ -0,0 +1,32 @@
+package p2p
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+func TestTransportsConfig(t *testing.T) {
+	cfg := DefaultConfig(node.Light)
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, cfg.ListenAddresses, cfg.listenAddresses())
+
+	cfg.Transports.TCP.Disabled = true
+	cfg.Transports.WebTransport.ListenAddresses = []string{"/ip4/0.0.0.0/udp/2122/quic-v1/webtransport"}
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, []string{
+		"/ip4/0.0.0.0/udp/2121/quic-v1",
+		"/ip6/::/udp/2121/quic-v1",
+		"/ip4/0.0.0.0/udp/2122/quic-v1/webtransport",
+	}, cfg.listenAddresses())
+
+	cfg.Transports.QUIC.ListenAddresses = []string{"/ip4/0.0.0.0/tcp/2122"}
+	require.Error(t, cfg.Validate())
+
+	cfg.Transports.QUIC = transportConfig{Disabled: true}
+	cfg.Transports.WebTransport.Disabled = true
+	require.Error(t, cfg.Validate())
+}
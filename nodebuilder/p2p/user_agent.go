@@ -0,0 +1,90 @@
+package p2p
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+// unknownVersion is reported for the peers whose user agent does not carry a version, e.g. nodes
+// predating it or other libp2p implementations.
+const unknownVersion = "unknown"
+
+// PeersVersion is the amount of connected peers of a node type, running a version of the node on
+// a network.
+type PeersVersion struct {
+	Network  string `json:"network"`
+	NodeType string `json:"node_type"`
+	Version  string `json:"version"`
+	Peers    int    `json:"peers"`
+}
+
+// userAgent returns the user agent the node identifies itself with to its peers, in the
+// "celestia-<network>/<node type>/<version>" format.
+func userAgent(net Network, tp node.Type) string {
+	version := node.GetBuildInfo().SemanticVersion
+	if version == "" {
+		version = unknownVersion
+	}
+	return fmt.Sprintf("celestia-%s/%s/%s", net, strings.ToLower(tp.String()), version)
+}
+
+// parseUserAgent parses the network, node type and version of the peer out of its user agent.
+// Unknown parts are left empty, except for the version.
+func parseUserAgent(agent string) PeersVersion {
+	pv := PeersVersion{Version: unknownVersion}
+	rest, ok := strings.CutPrefix(agent, "celestia-")
+	if !ok {
+		return pv
+	}
+
+	parts := strings.SplitN(rest, "/", 3)
+	pv.Network = parts[0]
+	if len(parts) == 3 {
+		pv.NodeType, pv.Version = parts[1], parts[2]
+	}
+	return pv
+}
+
+// peersByVersion summarizes the connected peers by their network, node type and version, the most
+// common first.
+func peersByVersion(h HostBase) []PeersVersion {
+	counts := make(map[PeersVersion]int)
+	for _, id := range h.Network().Peers() {
+		counts[parseUserAgent(agentOf(h, id))]++
+	}
+
+	summary := make([]PeersVersion, 0, len(counts))
+	for pv, count := range counts {
+		pv.Peers = count
+		summary = append(summary, pv)
+	}
+	sort.Slice(summary, func(i, j int) bool {
+		a, b := summary[i], summary[j]
+		if a.Peers != b.Peers {
+			return a.Peers > b.Peers
+		}
+		if a.Network != b.Network {
+			return a.Network < b.Network
+		}
+		if a.NodeType != b.NodeType {
+			return a.NodeType < b.NodeType
+		}
+		return a.Version < b.Version
+	})
+	return summary
+}
+
+// agentOf returns the user agent the peer identified itself with, if any.
+func agentOf(h HostBase, id peer.ID) string {
+	agent, err := h.Peerstore().Get(id, "AgentVersion")
+	if err != nil {
+		return ""
+	}
+	s, _ := agent.(string)
+	return s
+}
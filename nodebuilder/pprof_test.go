@@ -0,0 +1,55 @@
+package nodebuilder
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPprofConfig_Validate(t *testing.T) {
+	tests := []struct {
+		cfg   PprofConfig
+		valid bool
+	}{
+		{cfg: DefaultPprofConfig(), valid: true},
+		{cfg: PprofConfig{Address: "127.0.0.1:6000"}, valid: true},
+		{cfg: PprofConfig{Address: "[::1]:6000"}, valid: true},
+		{cfg: PprofConfig{Address: "0.0.0.0:6000", User: "user", Password: "password"}, valid: true},
+		{cfg: PprofConfig{Address: "0.0.0.0:6000"}},
+		{cfg: PprofConfig{Address: ":6000"}},
+		{cfg: PprofConfig{Address: "localhost"}},
+		{cfg: PprofConfig{Address: "localhost:6000", Password: "password"}},
+	}
+
+	for _, tt := range tests {
+		err := tt.cfg.Validate()
+		if tt.valid {
+			assert.NoError(t, err, tt.cfg.Address)
+			continue
+		}
+		assert.Error(t, err, tt.cfg.Address)
+	}
+}
+
+func TestBasicAuth(t *testing.T) {
+	handler := basicAuth("user", "password", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for creds, code := range map[[2]string]int{
+		{"user", "password"}: http.StatusOK,
+		{"user", "wrong"}:    http.StatusUnauthorized,
+		{"", ""}:             http.StatusUnauthorized,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/debug/pprof/heap", nil)
+		if creds[0] != "" {
+			req.SetBasicAuth(creds[0], creds[1])
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		require.Equal(t, code, rec.Code, creds)
+	}
+}
@@ -0,0 +1,132 @@
+package nodebuilder
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/share/p2p/discovery"
+)
+
+// Preset is the name of a curated set of Config values for a common way of running a node, applied
+// across the modules at once.
+type Preset string
+
+const (
+	// PresetPublicGateway serves the gateway to the public with rate limits, while keeping the RPC
+	// API, which can submit transactions, local.
+	PresetPublicGateway Preset = "public-gateway"
+	// PresetRollupOperator is tuned for a rollup reading and submitting its blobs through the RPC
+	// API of a node on the same machine, favoring low retrieval latency.
+	PresetRollupOperator Preset = "rollup-operator"
+	// PresetArchival makes a full or bridge node keep and serve the whole history to the network.
+	PresetArchival Preset = "archival"
+)
+
+// presetGetterCacheSize is enough to keep the extended squares of a few large blocks, which are
+// requested repeatedly from public gateways.
+const presetGetterCacheSize = 256 << 20
+
+var presets = map[Preset]struct {
+	description string
+	apply       func(*Config, node.Type) error
+}{
+	PresetPublicGateway: {
+		description: "serves the rate limited gateway to the public, keeping the RPC API local",
+		apply: func(cfg *Config, _ node.Type) error {
+			cfg.Gateway.Enabled = true
+			cfg.Gateway.Address = "0.0.0.0"
+			cfg.Gateway.RateLimit.GlobalRate = 200
+			cfg.Gateway.RateLimit.GlobalBurst = 400
+			cfg.Gateway.RateLimit.PerIPRate = 10
+			cfg.Gateway.RateLimit.PerIPBurst = 20
+			cfg.RPC.Address = "127.0.0.1"
+			if cfg.Share.GetterCacheSize < presetGetterCacheSize {
+				cfg.Share.GetterCacheSize = presetGetterCacheSize
+			}
+			return nil
+		},
+	},
+	PresetRollupOperator: {
+		description: "serves the RPC API to a rollup on the same machine with low retrieval latency",
+		apply: func(cfg *Config, _ node.Type) error {
+			cfg.RPC.Address = "127.0.0.1"
+			cfg.Gateway.Enabled = false
+			cfg.Share.UseShareExchange = true
+			cfg.Share.GetterHedgeDelay = 2 * time.Second
+			cfg.Share.PeerManagerParams.EnableLatencyRouting = true
+			return nil
+		},
+	},
+	PresetArchival: {
+		description: "keeps a full or bridge node serving the whole history to the network",
+		apply: func(cfg *Config, tp node.Type) error {
+			if tp.Base() == node.Light {
+				return errors.New("light nodes do not store the history")
+			}
+			cfg.Share.UseShareExchange = true
+			cfg.Pruner.Window = 0
+			advertiseArchival(&cfg.Share.Discovery)
+			cfg.Share.Bandwidth.PeerOutboundRate = 0
+			cfg.Share.Bandwidth.OutboundRate = 0
+			cfg.P2P.PeerExchange = true
+			if cfg.P2P.ConnManager.High < 1500 {
+				cfg.P2P.ConnManager.Low = 1000
+				cfg.P2P.ConnManager.High = 1500
+			}
+			if tp.Base() == node.Full {
+				// sample the whole chain, so that all of it is stored
+				cfg.DASer.SampleFrom = 1
+			}
+			return nil
+		},
+	},
+}
+
+// advertiseArchival makes the node advertise itself as archival to the peers looking for history,
+// and discover the other archival nodes to fetch the history it is missing from.
+func advertiseArchival(cfg *discovery.Parameters) {
+	cfg.AdvertisedPoints = appendPoint(cfg.AdvertisedPoints, discovery.ArchivalRendezvousPoint)
+	cfg.RendezvousPoints = appendPoint(cfg.RendezvousPoints, discovery.ArchivalRendezvousPoint)
+}
+
+func appendPoint(points []string, point string) []string {
+	for _, p := range points {
+		if p == point {
+			return points
+		}
+	}
+	return append(points, point)
+}
+
+// Presets returns the names of the available Presets.
+func Presets() []Preset {
+	names := make([]Preset, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
+
+// Description describes the values the Preset applies.
+func (p Preset) Description() string {
+	return presets[p].description
+}
+
+// WithPreset applies the values of the named Preset for the Node Type 'tp'. Values set after
+// the Preset, e.g. by flags, take precedence over it.
+func WithPreset(name Preset, tp node.Type) ConfigOption {
+	return func(cfg *Config) error {
+		preset, ok := presets[name]
+		if !ok {
+			return fmt.Errorf("node: unknown preset %q, must be one of %v", name, Presets())
+		}
+		if err := preset.apply(cfg, tp); err != nil {
+			return fmt.Errorf("node: preset %s: %w", name, err)
+		}
+		return nil
+	}
+}
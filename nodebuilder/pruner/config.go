@@ -0,0 +1,54 @@
+package pruner
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/celestiaorg/celestia-node/pruner"
+)
+
+// Config combines all configuration fields for pruning of the block data.
+type Config struct {
+	// Window is how long back from now the block data is kept. Older block data is pruned once it
+	// is synced and sampled. Zero makes the node archival, keeping the whole history.
+	Window time.Duration
+	// Interval is the time between two pruning rounds.
+	Interval time.Duration
+	// MinWindow is the shortest the Window is temporarily shrunk to, while the disk usage of the
+	// store stays over Quota.SoftLimit, rather than running into Quota.HardLimit. It must still
+	// cover the block data light nodes sample, so can't be shorter than pruner.SamplingWindow. Zero
+	// never shrinks the Window.
+	MinWindow time.Duration
+}
+
+// DefaultConfig returns default configuration for pruning, keeping the whole history.
+func DefaultConfig() Config {
+	return Config{
+		Interval: pruner.DefaultParams().Interval,
+	}
+}
+
+// Archival reports whether the node keeps the whole history.
+func (cfg *Config) Archival() bool {
+	return cfg.Window == 0
+}
+
+// Validate performs basic validation of the config.
+func (cfg *Config) Validate() error {
+	if cfg.Window < 0 {
+		return fmt.Errorf("module/pruner: window must not be negative, got %s", cfg.Window)
+	}
+	if cfg.Archival() {
+		return nil
+	}
+	params := cfg.params()
+	return params.Validate()
+}
+
+func (cfg *Config) params() pruner.Params {
+	return pruner.Params{
+		Window:    cfg.Window,
+		Interval:  cfg.Interval,
+		MinWindow: cfg.MinWindow,
+	}
+}
@@ -0,0 +1,62 @@
+package pruner
+
+import (
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/celestiaorg/celestia-node/pruner"
+)
+
+const (
+	windowFlag    = "pruner.window"
+	intervalFlag  = "pruner.interval"
+	minWindowFlag = "pruner.min-window"
+)
+
+// Flags gives a set of pruner flags.
+func Flags() *flag.FlagSet {
+	flags := &flag.FlagSet{}
+
+	flags.Duration(
+		windowFlag,
+		0,
+		"How long back from now the block data is kept, e.g. 720h. Older block data is pruned. "+
+			"0 keeps the whole history (archival)",
+	)
+	flags.Duration(
+		intervalFlag,
+		DefaultConfig().Interval,
+		"Time between two pruning rounds",
+	)
+	flags.Duration(
+		minWindowFlag,
+		0,
+		"Shortest the window is temporarily shrunk to while the store stays over the soft disk quota. "+
+			"It can't be shorter than the sampling window of light nodes ("+pruner.SamplingWindow.String()+"). "+
+			"0 never shrinks the window",
+	)
+
+	return flags
+}
+
+// ParseFlags parses pruner flags from the given cmd and saves them to the passed config.
+func ParseFlags(cmd *cobra.Command, cfg *Config) {
+	if cmd.Flags().Changed(windowFlag) {
+		window, err := cmd.Flags().GetDuration(windowFlag)
+		if err == nil {
+			cfg.Window = window
+		}
+	}
+	if cmd.Flags().Changed(intervalFlag) {
+		interval, err := cmd.Flags().GetDuration(intervalFlag)
+		if err == nil {
+			cfg.Interval = interval
+		}
+	}
+	if cmd.Flags().Changed(minWindowFlag) {
+		minWindow, err := cmd.Flags().GetDuration(minWindowFlag)
+		if err == nil {
+			cfg.MinWindow = minWindow
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package pruner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ipfs/go-datastore"
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
+	"go.uber.org/fx"
+
+	libhead "github.com/celestiaorg/go-header"
+
+	"github.com/celestiaorg/celestia-node/das"
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/pruner"
+	"github.com/celestiaorg/celestia-node/pruner/full"
+	"github.com/celestiaorg/celestia-node/pruner/light"
+	"github.com/celestiaorg/celestia-node/share/eds"
+)
+
+// ConstructModule collects the pruning Service, unless the node is archival.
+func ConstructModule(tp node.Type, cfg *Config) fx.Option {
+	// sanitize config values before constructing module
+	cfgErr := cfg.Validate()
+	baseComponents := fx.Options(
+		fx.Supply(*cfg),
+		fx.Error(cfgErr),
+	)
+	if cfg.Archival() {
+		return fx.Module("pruner", baseComponents)
+	}
+
+	service := fx.Options(
+		fx.Provide(fx.Annotate(
+			newService,
+			fx.OnStart(func(ctx context.Context, s *pruner.Service) error {
+				return s.Start(ctx)
+			}),
+			fx.OnStop(func(ctx context.Context, s *pruner.Service) error {
+				return s.Stop(ctx)
+			}),
+		)),
+		fx.Invoke(func(*pruner.Service) {}),
+	)
+
+	switch tp {
+	case node.Light:
+		return fx.Module(
+			"pruner",
+			baseComponents,
+			fx.Provide(func(ctx context.Context, bs blockstore.Blockstore) (pruner.Pruner, error) {
+				return light.NewPruner(ctx, bs)
+			}),
+			fx.Provide(samplingProgress),
+			service,
+		)
+	case node.Full:
+		return fx.Module(
+			"pruner",
+			baseComponents,
+			fx.Provide(func(store *eds.Store) pruner.Pruner {
+				return full.NewPruner(store)
+			}),
+			fx.Provide(samplingProgress),
+			service,
+		)
+	case node.Bridge:
+		return fx.Module(
+			"pruner",
+			baseComponents,
+			fx.Provide(func(store *eds.Store) pruner.Pruner {
+				return full.NewPruner(store)
+			}),
+			fx.Provide(syncProgress),
+			service,
+		)
+	default:
+		return fx.Error(fmt.Errorf("module/pruner: invalid node type: %s", tp))
+	}
+}
+
+func newService(
+	cfg Config,
+	p pruner.Pruner,
+	getter libhead.Store[*header.ExtendedHeader],
+	progress pruner.ProgressFn,
+	ds datastore.Batching,
+) (*pruner.Service, error) {
+	return pruner.NewService(p, getter, progress, ds, cfg.params())
+}
+
+// samplingProgress limits pruning to the sampled heights, so that no block data is pruned while it
+// is still being sampled. Without the DASer, e.g. when it was left out with WithoutDAS, the synced
+// heights are pruned instead.
+func samplingProgress(p struct {
+	fx.In
+
+	Getter libhead.Store[*header.ExtendedHeader]
+	DASer  *das.DASer `optional:"true"`
+}) pruner.ProgressFn {
+	if p.DASer == nil {
+		return syncProgress(p.Getter)
+	}
+	return func(ctx context.Context) (uint64, error) {
+		stats, err := p.DASer.SamplingStats(ctx)
+		if err != nil {
+			return 0, err
+		}
+		return stats.SampledUpTo(), nil
+	}
+}
+
+// syncProgress limits pruning to the synced heights, for node types storing block data along with
+// the headers.
+func syncProgress(getter libhead.Store[*header.ExtendedHeader]) pruner.ProgressFn {
+	return func(ctx context.Context) (uint64, error) {
+		head, err := getter.Head(ctx)
+		if err != nil {
+			return 0, err
+		}
+		return uint64(head.Height()), nil
+	}
+}
+
+// WithMetrics enables metrics of the pruning Service, unless the node is archival.
+func WithMetrics(p struct {
+	fx.In
+
+	Service *pruner.Service `optional:"true"`
+}) error {
+	if p.Service == nil {
+		return nil
+	}
+	return p.Service.WithMetrics()
+}
@@ -0,0 +1,222 @@
+package nodebuilder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pyroscope-io/client/pyroscope"
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+const (
+	// EnvPyroscopeAuthToken is the environment variable the bearer token Pyroscope profiles are
+	// uploaded with is read from, unless the PyroscopeConfig sets a token or basic auth.
+	EnvPyroscopeAuthToken = "CELESTIA_PYROSCOPE_AUTH_TOKEN"
+	// EnvPyroscopeBasicAuthPassword is the environment variable the basic auth password Pyroscope
+	// profiles are uploaded with is read from, if the PyroscopeConfig sets a basic auth user only.
+	EnvPyroscopeBasicAuthPassword = "CELESTIA_PYROSCOPE_BASIC_AUTH_PASSWORD"
+)
+
+// pyroscopeProfileTypes are the profile types supported by Pyroscope.
+var pyroscopeProfileTypes = map[pyroscope.ProfileType]struct{}{
+	pyroscope.ProfileCPU:           {},
+	pyroscope.ProfileInuseObjects:  {},
+	pyroscope.ProfileAllocObjects:  {},
+	pyroscope.ProfileInuseSpace:    {},
+	pyroscope.ProfileAllocSpace:    {},
+	pyroscope.ProfileGoroutines:    {},
+	pyroscope.ProfileMutexCount:    {},
+	pyroscope.ProfileMutexDuration: {},
+	pyroscope.ProfileBlockCount:    {},
+	pyroscope.ProfileBlockDuration: {},
+}
+
+// PyroscopeConfig configures the continuous profiling of the node with Pyroscope.
+type PyroscopeConfig struct {
+	// Endpoint is the HTTP endpoint of the Pyroscope server.
+	Endpoint string
+	// ApplicationName is the name the profiles are reported under.
+	ApplicationName string
+	// ProfileTypes are the types of the reported profiles, e.g. "cpu", "goroutines",
+	// "mutex_count" or "block_duration".
+	ProfileTypes []string
+	// MutexProfileFraction reports on average 1/n of the mutex contention events, once any mutex
+	// profile type is reported.
+	MutexProfileFraction int
+	// BlockProfileRate reports on average one blocking event per the given nanoseconds spent
+	// blocked, once any block profile type is reported.
+	BlockProfileRate int
+	// UploadRate is the time between two uploads of the profiles.
+	UploadRate time.Duration
+
+	// AuthToken authenticates the node with a bearer token. If neither the token nor basic auth is
+	// set, it is read from the EnvPyroscopeAuthToken environment variable.
+	AuthToken string
+	// BasicAuthUser and BasicAuthPassword authenticate the node with HTTP basic auth, e.g. with the
+	// instance ID and an API key on Grafana Cloud. If only the user is set, the password is read
+	// from the EnvPyroscopeBasicAuthPassword environment variable.
+	BasicAuthUser     string
+	BasicAuthPassword string
+	// TenantID is the tenant the profiles belong to on multi-tenant servers.
+	TenantID string
+	// Headers are the extra HTTP headers sent with every upload.
+	Headers map[string]string
+	// Tags are the extra tags of the profiles.
+	Tags map[string]string
+}
+
+// DefaultPyroscopeConfig returns the default PyroscopeConfig reporting CPU and memory profiles.
+func DefaultPyroscopeConfig() PyroscopeConfig {
+	return PyroscopeConfig{
+		Endpoint:        "http://localhost:4040",
+		ApplicationName: "celestia.da-node",
+		ProfileTypes: []string{
+			string(pyroscope.ProfileCPU),
+			string(pyroscope.ProfileAllocObjects),
+			string(pyroscope.ProfileAllocSpace),
+			string(pyroscope.ProfileInuseObjects),
+			string(pyroscope.ProfileInuseSpace),
+		},
+		MutexProfileFraction: 5,
+		BlockProfileRate:     5,
+		UploadRate:           15 * time.Second,
+	}
+}
+
+// Validate performs basic validation of the config.
+func (cfg *PyroscopeConfig) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("nodebuilder: pyroscope endpoint must be set")
+	}
+	if cfg.ApplicationName == "" {
+		return errors.New("nodebuilder: pyroscope application name must be set")
+	}
+	if len(cfg.ProfileTypes) == 0 {
+		return errors.New("nodebuilder: at least one pyroscope profile type must be set")
+	}
+	for _, tp := range cfg.ProfileTypes {
+		if _, ok := pyroscopeProfileTypes[pyroscope.ProfileType(tp)]; !ok {
+			return fmt.Errorf("nodebuilder: unsupported pyroscope profile type %q", tp)
+		}
+	}
+	if cfg.MutexProfileFraction < 0 || cfg.BlockProfileRate < 0 {
+		return errors.New("nodebuilder: pyroscope sampling rates must not be negative")
+	}
+	if cfg.UploadRate <= 0 {
+		return errors.New("nodebuilder: pyroscope upload rate must be positive")
+	}
+	if cfg.AuthToken != "" && cfg.BasicAuthUser != "" {
+		return errors.New("nodebuilder: pyroscope auth token and basic auth are mutually exclusive")
+	}
+	return nil
+}
+
+// readSecrets reads the secrets missing from the config from the environment, depending on the
+// way the node authenticates.
+func (cfg *PyroscopeConfig) readSecrets() {
+	switch {
+	case cfg.BasicAuthUser != "":
+		if cfg.BasicAuthPassword == "" {
+			cfg.BasicAuthPassword = os.Getenv(EnvPyroscopeBasicAuthPassword)
+		}
+	case cfg.AuthToken == "":
+		cfg.AuthToken = os.Getenv(EnvPyroscopeAuthToken)
+	}
+}
+
+// WithPyroscope enables pyroscope profiling for the node, reporting the default profiles to the
+// endpoint. Use WithPyroscopeConfig to configure the profiling further.
+func WithPyroscope(endpoint string, nodeType node.Type) fx.Option {
+	cfg := DefaultPyroscopeConfig()
+	cfg.Endpoint = endpoint
+	return WithPyroscopeConfig(cfg, nodeType)
+}
+
+// WithPyroscopeConfig enables pyroscope profiling for the node with the given config.
+func WithPyroscopeConfig(cfg PyroscopeConfig, nodeType node.Type) fx.Option {
+	cfg.readSecrets()
+	if err := cfg.Validate(); err != nil {
+		return fx.Error(err)
+	}
+
+	return fx.Options(
+		fx.Invoke(func(lc fx.Lifecycle, peerID peer.ID) error {
+			profileTypes := make([]pyroscope.ProfileType, len(cfg.ProfileTypes))
+			for i, tp := range cfg.ProfileTypes {
+				profileTypes[i] = pyroscope.ProfileType(tp)
+			}
+
+			// the tags of the node come last to take precedence over the extra ones
+			tags := make(map[string]string, len(cfg.Tags)+2)
+			for key, value := range cfg.Tags {
+				tags[key] = value
+			}
+			tags["type"] = nodeType.String()
+			tags["peerId"] = peerID.String()
+
+			resetRates := setProfileRates(cfg, profileTypes)
+			profiler, err := pyroscope.Start(pyroscope.Config{
+				ApplicationName:   cfg.ApplicationName,
+				ServerAddress:     cfg.Endpoint,
+				AuthToken:         cfg.AuthToken,
+				BasicAuthUser:     cfg.BasicAuthUser,
+				BasicAuthPassword: cfg.BasicAuthPassword,
+				TenantID:          cfg.TenantID,
+				HTTPHeaders:       cfg.Headers,
+				UploadRate:        cfg.UploadRate,
+				Tags:              tags,
+				Logger:            nil,
+				ProfileTypes:      profileTypes,
+			})
+			if err != nil {
+				resetRates()
+				return err
+			}
+			lc.Append(fx.Hook{
+				OnStop: func(context.Context) error {
+					defer resetRates()
+					return profiler.Stop()
+				},
+			})
+			return nil
+		}),
+	)
+}
+
+// setProfileRates enables sampling of the mutex and blocking events, which the runtime does not
+// record by default, if their profiles are reported. The returned function restores the rates.
+func setProfileRates(cfg PyroscopeConfig, profileTypes []pyroscope.ProfileType) func() {
+	var mutex, block bool
+	for _, tp := range profileTypes {
+		switch tp {
+		case pyroscope.ProfileMutexCount, pyroscope.ProfileMutexDuration:
+			mutex = true
+		case pyroscope.ProfileBlockCount, pyroscope.ProfileBlockDuration:
+			block = true
+		}
+	}
+
+	prevMutex := -1
+	if mutex {
+		prevMutex = runtime.SetMutexProfileFraction(cfg.MutexProfileFraction)
+	}
+	if block {
+		runtime.SetBlockProfileRate(cfg.BlockProfileRate)
+	}
+	return func() {
+		if mutex {
+			runtime.SetMutexProfileFraction(prevMutex)
+		}
+		// the runtime does not report the previous block rate, which is off by default
+		if block {
+			runtime.SetBlockProfileRate(0)
+		}
+	}
+}
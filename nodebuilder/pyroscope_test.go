@@ -0,0 +1,105 @@
+package nodebuilder
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/pyroscope-io/client/pyroscope"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPyroscopeConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*PyroscopeConfig)
+		valid  bool
+	}{
+		{
+			name:   "default",
+			modify: func(*PyroscopeConfig) {},
+			valid:  true,
+		},
+		{
+			name: "grafana cloud",
+			modify: func(cfg *PyroscopeConfig) {
+				cfg.ProfileTypes = append(cfg.ProfileTypes, "goroutines", "mutex_count", "block_duration")
+				cfg.BasicAuthUser = "123456"
+				cfg.BasicAuthPassword = "glc_key"
+				cfg.TenantID = "tenant"
+				cfg.Tags = map[string]string{"region": "eu"}
+			},
+			valid: true,
+		},
+		{
+			name:   "unknown profile type",
+			modify: func(cfg *PyroscopeConfig) { cfg.ProfileTypes = []string{"cpu", "mutex"} },
+		},
+		{
+			name:   "no profile types",
+			modify: func(cfg *PyroscopeConfig) { cfg.ProfileTypes = nil },
+		},
+		{
+			name:   "negative sampling rate",
+			modify: func(cfg *PyroscopeConfig) { cfg.BlockProfileRate = -1 },
+		},
+		{
+			name:   "no upload rate",
+			modify: func(cfg *PyroscopeConfig) { cfg.UploadRate = 0 },
+		},
+		{
+			name: "token and basic auth",
+			modify: func(cfg *PyroscopeConfig) {
+				cfg.AuthToken = "token"
+				cfg.BasicAuthUser = "user"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultPyroscopeConfig()
+			tt.modify(&cfg)
+			err := cfg.Validate()
+			if tt.valid {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestPyroscopeConfig_ReadSecrets(t *testing.T) {
+	t.Setenv(EnvPyroscopeAuthToken, "env-token")
+	t.Setenv(EnvPyroscopeBasicAuthPassword, "env-password")
+
+	cfg := DefaultPyroscopeConfig()
+	cfg.readSecrets()
+	require.Equal(t, "env-token", cfg.AuthToken)
+	require.NoError(t, cfg.Validate())
+
+	// basic auth takes the password from the environment instead of the token
+	cfg = DefaultPyroscopeConfig()
+	cfg.BasicAuthUser = "user"
+	cfg.readSecrets()
+	require.Empty(t, cfg.AuthToken)
+	require.Equal(t, "env-password", cfg.BasicAuthPassword)
+	require.NoError(t, cfg.Validate())
+
+	// the secrets of the config take precedence
+	cfg = DefaultPyroscopeConfig()
+	cfg.AuthToken = "token"
+	cfg.readSecrets()
+	require.Equal(t, "token", cfg.AuthToken)
+}
+
+func TestSetProfileRates(t *testing.T) {
+	prevMutex := runtime.SetMutexProfileFraction(-1)
+	cfg := DefaultPyroscopeConfig()
+	cfg.MutexProfileFraction = prevMutex + 3
+
+	reset := setProfileRates(cfg, []pyroscope.ProfileType{pyroscope.ProfileMutexCount, pyroscope.ProfileBlockCount})
+	require.Equal(t, prevMutex+3, runtime.SetMutexProfileFraction(-1))
+	reset()
+	require.Equal(t, prevMutex, runtime.SetMutexProfileFraction(-1))
+}
@@ -0,0 +1,36 @@
+package quota
+
+import (
+	"context"
+
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
+	blocks "github.com/ipfs/go-libipfs/blocks"
+)
+
+// Blockstore wraps the given blockstore to refuse new blocks while the disk usage is over the hard
+// limit. A nil Monitor leaves the blockstore as is.
+func (m *Monitor) Blockstore(bs blockstore.Blockstore) blockstore.Blockstore {
+	if m == nil {
+		return bs
+	}
+	return &quotaBlockstore{Blockstore: bs, monitor: m}
+}
+
+type quotaBlockstore struct {
+	blockstore.Blockstore
+	monitor *Monitor
+}
+
+func (bs *quotaBlockstore) Put(ctx context.Context, blk blocks.Block) error {
+	if err := bs.monitor.Err(); err != nil {
+		return err
+	}
+	return bs.Blockstore.Put(ctx, blk)
+}
+
+func (bs *quotaBlockstore) PutMany(ctx context.Context, blks []blocks.Block) error {
+	if err := bs.monitor.Err(); err != nil {
+		return err
+	}
+	return bs.Blockstore.PutMany(ctx, blks)
+}
@@ -0,0 +1,38 @@
+package quota
+
+import (
+	"fmt"
+	"time"
+)
+
+// Config combines all configuration fields for the disk quota of the node store.
+type Config struct {
+	// SoftLimit is the disk usage of the datastore and the EDS store in bytes from which warnings
+	// are logged and garbage collection is triggered. Zero disables the limit.
+	SoftLimit uint64
+	// HardLimit is the disk usage of the datastore and the EDS store in bytes from which no new
+	// block data is written, until enough space is reclaimed. Zero disables the limit.
+	HardLimit uint64
+	// CheckInterval is the interval between two measurements of the disk usage.
+	CheckInterval time.Duration
+}
+
+// DefaultConfig returns default configuration for the disk quota, measuring the disk usage
+// without any limit.
+func DefaultConfig() Config {
+	return Config{
+		CheckInterval: time.Minute,
+	}
+}
+
+// Validate performs basic validation of the config.
+func (cfg *Config) Validate() error {
+	if cfg.CheckInterval <= 0 {
+		return fmt.Errorf("module/quota: check interval must be positive, got %s", cfg.CheckInterval)
+	}
+	if cfg.SoftLimit > 0 && cfg.HardLimit > 0 && cfg.SoftLimit >= cfg.HardLimit {
+		return fmt.Errorf("module/quota: soft limit (%d) must be below the hard limit (%d)",
+			cfg.SoftLimit, cfg.HardLimit)
+	}
+	return nil
+}
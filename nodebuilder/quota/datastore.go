@@ -0,0 +1,81 @@
+package quota
+
+import (
+	"context"
+
+	"github.com/ipfs/go-datastore"
+
+	"github.com/celestiaorg/celestia-node/pruner"
+	"github.com/celestiaorg/celestia-node/share/eds"
+)
+
+// Datastore wraps the given datastore to refuse writes while the disk usage is over the hard limit,
+// e.g. of new headers. Deletions are accepted, as are the writes of the EDS store, whose new block
+// data is refused by the EDS store itself, and of the pruner, which reclaims space. If the datastore
+// needs garbage collection to free space, it is collected when the disk usage reaches the soft limit.
+// A nil Monitor leaves the datastore as is.
+func (m *Monitor) Datastore(ds datastore.Batching) datastore.Batching {
+	if m == nil {
+		return ds
+	}
+	if gcds, ok := ds.(datastore.GCFeature); ok {
+		m.OnSoftLimit(func(ctx context.Context) {
+			if err := gcds.CollectGarbage(ctx); err != nil {
+				log.Errorw("collecting datastore garbage", "err", err)
+			}
+		})
+	}
+	return &quotaDatastore{
+		Batching: ds,
+		monitor:  m,
+		exempt:   append(eds.DatastorePrefixes(), pruner.DatastorePrefix()),
+	}
+}
+
+type quotaDatastore struct {
+	datastore.Batching
+	monitor *Monitor
+	// exempt are the namespaces whose writes are accepted over the hard limit
+	exempt []datastore.Key
+}
+
+func (ds *quotaDatastore) Put(ctx context.Context, key datastore.Key, value []byte) error {
+	if err := ds.check(key); err != nil {
+		return err
+	}
+	return ds.Batching.Put(ctx, key, value)
+}
+
+func (ds *quotaDatastore) Batch(ctx context.Context) (datastore.Batch, error) {
+	batch, err := ds.Batching.Batch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &quotaBatch{Batch: batch, ds: ds}, nil
+}
+
+// check returns ErrHardLimit for the writes refused over the hard limit.
+func (ds *quotaDatastore) check(key datastore.Key) error {
+	err := ds.monitor.Err()
+	if err == nil {
+		return nil
+	}
+	for _, prefix := range ds.exempt {
+		if prefix.Equal(key) || prefix.IsAncestorOf(key) {
+			return nil
+		}
+	}
+	return err
+}
+
+type quotaBatch struct {
+	datastore.Batch
+	ds *quotaDatastore
+}
+
+func (b *quotaBatch) Put(ctx context.Context, key datastore.Key, value []byte) error {
+	if err := b.ds.check(key); err != nil {
+		return err
+	}
+	return b.Batch.Put(ctx, key, value)
+}
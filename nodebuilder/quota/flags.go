@@ -0,0 +1,46 @@
+package quota
+
+import (
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	softLimitFlag = "quota.soft-limit"
+	hardLimitFlag = "quota.hard-limit"
+)
+
+// Flags gives a set of disk quota flags.
+func Flags() *flag.FlagSet {
+	flags := &flag.FlagSet{}
+
+	flags.Uint64(
+		softLimitFlag,
+		0,
+		"Disk usage of the store in bytes from which warnings are logged and garbage collection is triggered. "+
+			"0 disables the limit",
+	)
+	flags.Uint64(
+		hardLimitFlag,
+		0,
+		"Disk usage of the store in bytes from which no new block data is written. 0 disables the limit",
+	)
+
+	return flags
+}
+
+// ParseFlags parses disk quota flags from the given cmd and saves them to the passed config.
+func ParseFlags(cmd *cobra.Command, cfg *Config) {
+	if cmd.Flags().Changed(softLimitFlag) {
+		limit, err := cmd.Flags().GetUint64(softLimitFlag)
+		if err == nil {
+			cfg.SoftLimit = limit
+		}
+	}
+	if cmd.Flags().Changed(hardLimitFlag) {
+		limit, err := cmd.Flags().GetUint64(hardLimitFlag)
+		if err == nil {
+			cfg.HardLimit = limit
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package quota
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var meter = otel.Meter("quota")
+
+// WithMetrics registers the metrics of the disk usage of the store.
+func WithMetrics(m *Monitor) error {
+	datastoreSize, err := meter.Int64ObservableGauge("store_datastore_size",
+		metric.WithDescription("disk usage of the datastore"),
+		metric.WithUnit("By"))
+	if err != nil {
+		return err
+	}
+	edsSize, err := meter.Int64ObservableGauge("store_eds_size",
+		metric.WithDescription("disk usage of the EDS store"),
+		metric.WithUnit("By"))
+	if err != nil {
+		return err
+	}
+	writesPaused, err := meter.Int64ObservableGauge("store_writes_paused",
+		metric.WithDescription("whether block data is refused for the disk usage being over the hard limit"))
+	if err != nil {
+		return err
+	}
+
+	callback := func(ctx context.Context, observer metric.Observer) error {
+		datastore, eds := m.Usage()
+		observer.ObserveInt64(datastoreSize, datastore)
+		if m.edsStore != nil {
+			observer.ObserveInt64(edsSize, eds)
+		}
+		var paused int64
+		if m.Err() != nil {
+			paused = 1
+		}
+		observer.ObserveInt64(writesPaused, paused)
+		return nil
+	}
+	_, err = meter.RegisterCallback(callback, datastoreSize, edsSize, writesPaused)
+	return err
+}
@@ -0,0 +1,69 @@
+package quota
+
+import (
+	"context"
+	"path/filepath"
+
+	logging "github.com/ipfs/go-log/v2"
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/pruner"
+	"github.com/celestiaorg/celestia-node/share/eds"
+)
+
+var log = logging.Logger("module/quota")
+
+// ConstructModule collects the Monitor of the disk usage of the store.
+func ConstructModule(cfg *Config) fx.Option {
+	// sanitize config values before constructing module
+	cfgErr := cfg.Validate()
+
+	return fx.Module(
+		"quota",
+		fx.Supply(*cfg),
+		fx.Error(cfgErr),
+		fx.Provide(fx.Annotate(
+			func(cfg Config, path node.StorePath) *Monitor {
+				return NewMonitor(cfg, dataPath(string(path)))
+			},
+			fx.OnStart(func(ctx context.Context, m *Monitor) error {
+				return m.Start(ctx)
+			}),
+			fx.OnStop(func(ctx context.Context, m *Monitor) error {
+				return m.Stop(ctx)
+			}),
+		)),
+		// registered after construction, as the EDS store and the pruner depend on the datastore and
+		// the blockstore guarded by the Monitor
+		fx.Invoke(func(p struct {
+			fx.In
+
+			Monitor  *Monitor
+			EDSStore *eds.Store      `optional:"true"`
+			Pruner   *pruner.Service `optional:"true"`
+		}) {
+			if p.EDSStore != nil {
+				p.Monitor.WatchEDSStore(p.EDSStore)
+			}
+			if p.Pruner != nil {
+				r := &retention{pruner: p.Pruner}
+				p.Monitor.OnSoftLimit(r.collectGarbage)
+				p.Monitor.OnSoftLimitRecovered(r.restore)
+				return
+			}
+			if cfg.SoftLimit > 0 || cfg.HardLimit > 0 {
+				log.Warn("the node keeps the whole history, so only compaction reclaims space over the disk " +
+					"quota; set Pruner.Window to prune the block data outside of it")
+			}
+		}),
+	)
+}
+
+// dataPath returns the path of the datastore of the node store, empty for in-memory stores.
+func dataPath(storePath string) string {
+	if storePath == "" {
+		return ""
+	}
+	return filepath.Join(storePath, "data")
+}
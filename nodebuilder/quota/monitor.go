@@ -0,0 +1,218 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/celestiaorg/celestia-node/share/eds"
+)
+
+// ErrHardLimit is returned for the writes of block data refused while the disk usage of the store
+// is over the hard limit.
+var ErrHardLimit = errors.New("quota: disk usage of the store is over the hard limit")
+
+// gcCooldown is the minimal time between two garbage collections triggered by the soft limit, as
+// reclaiming space takes a while to show in the disk usage.
+var gcCooldown = 10 * time.Minute
+
+// Monitor measures the disk usage of the datastore and the EDS store. Over the soft limit it
+// triggers garbage collection, and over the hard limit it refuses new block data and headers, so
+// that the node does not run out of disk space in the middle of a write.
+type Monitor struct {
+	cfg      Config
+	dataPath string
+	edsStore *eds.Store
+	// gc are the garbage collections triggered over the soft limit
+	gc []func(context.Context)
+	// recovered are notified once the disk usage drops back under the soft limit
+	recovered []func(context.Context)
+
+	datastoreSize atomic.Int64
+	edsSize       atomic.Int64
+	overHard      atomic.Bool
+	overSoft      bool
+	lastGC        time.Time
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewMonitor constructs a new Monitor of the datastore under dataPath.
+func NewMonitor(cfg Config, dataPath string) *Monitor {
+	return &Monitor{
+		cfg:      cfg,
+		dataPath: dataPath,
+	}
+}
+
+// WatchEDSStore makes the Monitor measure the disk usage of the EDS store, refuse its new block
+// data over the hard limit and compact it over the soft limit. It must be called before Start.
+func (m *Monitor) WatchEDSStore(edsStore *eds.Store) {
+	m.edsStore = edsStore
+	m.OnSoftLimit(func(ctx context.Context) {
+		res, err := edsStore.Compact(ctx)
+		if err != nil {
+			log.Errorw("compacting EDS store", "err", err)
+			return
+		}
+		log.Infow("compacted EDS store", "orphans", res.Orphans, "reclaimed_bytes", res.Reclaimed)
+	})
+}
+
+// OnSoftLimit registers a garbage collection to trigger when the disk usage reaches the soft
+// limit. It must be called before Start.
+func (m *Monitor) OnSoftLimit(gc func(context.Context)) {
+	m.gc = append(m.gc, gc)
+}
+
+// OnSoftLimitRecovered registers a function to notify once the disk usage drops back under the
+// soft limit, e.g. to undo the measures taken over it. It must be called before Start.
+func (m *Monitor) OnSoftLimitRecovered(fn func(context.Context)) {
+	m.recovered = append(m.recovered, fn)
+}
+
+func (m *Monitor) Start(context.Context) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel, m.done = cancel, make(chan struct{})
+	// the hard limit is enforced before anything is written
+	m.check(ctx)
+	go m.run(ctx)
+	return nil
+}
+
+func (m *Monitor) Stop(ctx context.Context) error {
+	m.cancel()
+	select {
+	case <-m.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Err returns ErrHardLimit while the disk usage is over the hard limit, and nil otherwise.
+func (m *Monitor) Err() error {
+	if m == nil || !m.overHard.Load() {
+		return nil
+	}
+	return ErrHardLimit
+}
+
+// Usage returns the last measured disk usage in bytes of the datastore and the EDS store.
+func (m *Monitor) Usage() (datastore, edsStore int64) {
+	return m.datastoreSize.Load(), m.edsSize.Load()
+}
+
+func (m *Monitor) run(ctx context.Context) {
+	defer close(m.done)
+	ticker := time.NewTicker(m.cfg.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.check(ctx)
+		}
+	}
+}
+
+// check measures the disk usage and enforces the limits.
+func (m *Monitor) check(ctx context.Context) {
+	dsSize, err := dirSize(m.dataPath)
+	if err != nil {
+		log.Errorw("measuring datastore size", "path", m.dataPath, "err", err)
+		return
+	}
+	m.datastoreSize.Store(dsSize)
+	if m.edsStore != nil {
+		edsSize, err := m.edsStore.DiskUsage()
+		if err != nil {
+			log.Errorw("measuring EDS store size", "err", err)
+			return
+		}
+		m.edsSize.Store(edsSize)
+	}
+
+	usage := uint64(m.datastoreSize.Load() + m.edsSize.Load())
+	m.enforceHardLimit(usage)
+	overSoft := m.cfg.SoftLimit > 0 && usage >= m.cfg.SoftLimit
+	if overSoft {
+		log.Warnw("disk usage of the store over the soft limit", "usage", usage, "limit", m.cfg.SoftLimit)
+		m.collectGarbage(ctx)
+	} else if m.overSoft {
+		log.Infow("disk usage of the store back under the soft limit", "usage", usage, "limit", m.cfg.SoftLimit)
+		for _, fn := range m.recovered {
+			fn(ctx)
+		}
+	}
+	m.overSoft = overSoft
+}
+
+// enforceHardLimit pauses the writes of block data over the hard limit and resumes them once
+// enough space is reclaimed.
+func (m *Monitor) enforceHardLimit(usage uint64) {
+	over := m.cfg.HardLimit > 0 && usage >= m.cfg.HardLimit
+	if m.overHard.Swap(over) == over {
+		return
+	}
+
+	if over {
+		log.Errorw("disk usage of the store over the hard limit, no new block data or headers are stored",
+			"usage", usage, "limit", m.cfg.HardLimit)
+		if m.edsStore != nil {
+			m.edsStore.PauseWrites(ErrHardLimit)
+		}
+		return
+	}
+	log.Infow("disk usage of the store back under the hard limit, storing block data again",
+		"usage", usage, "limit", m.cfg.HardLimit)
+	if m.edsStore != nil {
+		m.edsStore.ResumeWrites()
+	}
+}
+
+func (m *Monitor) collectGarbage(ctx context.Context) {
+	if time.Since(m.lastGC) < gcCooldown {
+		return
+	}
+	m.lastGC = time.Now()
+	for _, gc := range m.gc {
+		gc(ctx)
+	}
+}
+
+// dirSize returns the total size of the regular files under the given directory. A missing
+// directory, e.g. of an in-memory store, is empty.
+func dirSize(path string) (int64, error) {
+	if path == "" {
+		return 0, nil
+	}
+	var size int64
+	err := filepath.WalkDir(path, func(_ string, entry fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			// removed while walking, e.g. by compaction of the datastore
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
@@ -0,0 +1,134 @@
+package quota
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	ds_sync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-app/pkg/da"
+
+	"github.com/celestiaorg/celestia-node/pruner"
+	"github.com/celestiaorg/celestia-node/share/eds"
+	"github.com/celestiaorg/celestia-node/share/eds/edstest"
+)
+
+func TestMonitor(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	edsStore, err := eds.NewStore(t.TempDir(), ds_sync.MutexWrap(datastore.NewMapDatastore()))
+	require.NoError(t, err)
+	require.NoError(t, edsStore.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, edsStore.Stop(ctx))
+	})
+
+	dataPath := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dataPath, "data"), make([]byte, 1024), 0600))
+
+	m := NewMonitor(Config{SoftLimit: 512, HardLimit: 64 << 10, CheckInterval: time.Hour}, dataPath)
+	m.WatchEDSStore(edsStore)
+	var collected int
+	m.OnSoftLimit(func(context.Context) {
+		collected++
+	})
+	require.NoError(t, m.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, m.Stop(ctx))
+	})
+
+	// over the soft limit garbage is collected, but writes are accepted
+	datastoreSize, _ := m.Usage()
+	assert.EqualValues(t, 1024, datastoreSize)
+	assert.Equal(t, 1, collected)
+	require.NoError(t, m.Err())
+	square := edstest.RandEDS(t, 4)
+	stored, err := da.NewDataAvailabilityHeader(square)
+	require.NoError(t, err)
+	require.NoError(t, edsStore.Put(ctx, stored.Hash(), square))
+
+	// over the hard limit writes are refused, while garbage is only collected after the cooldown
+	m.check(ctx)
+	require.ErrorIs(t, m.Err(), ErrHardLimit)
+	assert.Equal(t, 1, collected)
+	square = edstest.RandEDS(t, 4)
+	dah, err := da.NewDataAvailabilityHeader(square)
+	require.NoError(t, err)
+	require.ErrorIs(t, edsStore.Put(ctx, dah.Hash(), square), ErrHardLimit)
+
+	// writes resume once the space is reclaimed
+	require.NoError(t, edsStore.Remove(ctx, stored.Hash()))
+	m.check(ctx)
+	require.NoError(t, m.Err())
+	require.NoError(t, edsStore.Put(ctx, dah.Hash(), square))
+}
+
+func TestMonitor_Datastore(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	dataPath := t.TempDir()
+	m := NewMonitor(Config{HardLimit: 512, CheckInterval: time.Hour}, dataPath)
+	ds := m.Datastore(ds_sync.MutexWrap(datastore.NewMapDatastore()))
+	header := datastore.NewKey("/header_store/1")
+	require.NoError(t, ds.Put(ctx, header, []byte("header")))
+
+	// over the hard limit writes are refused, except for the ones reclaiming space
+	require.NoError(t, os.WriteFile(filepath.Join(dataPath, "data"), make([]byte, 1024), 0600))
+	m.check(ctx)
+	require.ErrorIs(t, ds.Put(ctx, datastore.NewKey("/header_store/2"), []byte("header")), ErrHardLimit)
+	batch, err := ds.Batch(ctx)
+	require.NoError(t, err)
+	require.ErrorIs(t, batch.Put(ctx, datastore.NewKey("/header_store/2"), []byte("header")), ErrHardLimit)
+	require.NoError(t, ds.Put(ctx, pruner.DatastorePrefix().ChildString("checkpoint"), []byte("{}")))
+	require.NoError(t, ds.Delete(ctx, header))
+
+	// writes resume once the space is reclaimed
+	require.NoError(t, os.Remove(filepath.Join(dataPath, "data")))
+	m.check(ctx)
+	require.NoError(t, ds.Put(ctx, datastore.NewKey("/header_store/2"), []byte("header")))
+}
+
+func TestMonitor_Retention(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	dataPath := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dataPath, "data"), make([]byte, 1024), 0600))
+
+	params := pruner.Params{Window: 2 * pruner.SamplingWindow, Interval: time.Hour, MinWindow: pruner.SamplingWindow}
+	serv, err := pruner.NewService(nil, nil, nil, ds_sync.MutexWrap(datastore.NewMapDatastore()), params)
+	require.NoError(t, err)
+	r := &retention{pruner: serv}
+
+	m := NewMonitor(Config{SoftLimit: 512, CheckInterval: time.Hour}, dataPath)
+	m.OnSoftLimit(r.collectGarbage)
+	m.OnSoftLimitRecovered(r.restore)
+	cooldown := gcCooldown
+	gcCooldown = 0
+	t.Cleanup(func() {
+		gcCooldown = cooldown
+	})
+
+	// the block data is pruned within the configured window first
+	m.check(ctx)
+	assert.Equal(t, 2*pruner.SamplingWindow, serv.Window())
+
+	// then the window is shrunk, down to the minimal one
+	m.check(ctx)
+	assert.Equal(t, pruner.SamplingWindow, serv.Window())
+	m.check(ctx)
+	assert.Equal(t, pruner.SamplingWindow, serv.Window())
+
+	// and restored once the disk usage drops under the soft limit
+	require.NoError(t, os.Truncate(filepath.Join(dataPath, "data"), 0))
+	m.check(ctx)
+	assert.Equal(t, 2*pruner.SamplingWindow, serv.Window())
+}
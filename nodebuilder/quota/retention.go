@@ -0,0 +1,38 @@
+package quota
+
+import (
+	"context"
+
+	"github.com/celestiaorg/celestia-node/pruner"
+)
+
+// retention degrades the retention of the block data while the disk usage of the store stays over
+// the soft limit. The first garbage collection over it prunes the block data outside of the
+// configured window, while every following one shrinks the window further, down to the minimal
+// one. The configured window is restored once the disk usage drops back under the soft limit.
+//
+// It is only called from the Monitor, so needs no synchronization.
+type retention struct {
+	pruner *pruner.Service
+	// pruned is whether the block data was pruned since the disk usage reached the soft limit
+	pruned bool
+}
+
+func (r *retention) collectGarbage(context.Context) {
+	if !r.pruned {
+		r.pruned = true
+		r.pruner.Trigger()
+		return
+	}
+
+	if window, ok := r.pruner.ShrinkWindow(); !ok {
+		log.Errorw("disk usage of the store stays over the soft limit with the pruning window at its "+
+			"minimum; free disk space, raise the quota or lower Pruner.MinWindow, down to the sampling "+
+			"window, before the hard limit pauses storing of the block data", "window", window)
+	}
+}
+
+func (r *retention) restore(context.Context) {
+	r.pruned = false
+	r.pruner.RestoreWindow()
+}
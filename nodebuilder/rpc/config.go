@@ -10,6 +10,9 @@ import (
 type Config struct {
 	Address string
 	Port    string
+	// MaxConcurrentRequests is the amount of requests served at once, excluding WebSocket
+	// connections. Zero means no limit.
+	MaxConcurrentRequests int
 }
 
 func DefaultConfig() Config {
@@ -31,5 +34,9 @@ func (cfg *Config) Validate() error {
 	if err != nil {
 		return fmt.Errorf("service/rpc: invalid port: %s", err.Error())
 	}
+	if cfg.MaxConcurrentRequests < 0 {
+		return fmt.Errorf("service/rpc: max concurrent requests must not be negative, got %d",
+			cfg.MaxConcurrentRequests)
+	}
 	return nil
 }
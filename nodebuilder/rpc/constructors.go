@@ -2,10 +2,13 @@ package rpc
 
 import (
 	"github.com/cristalhq/jwt"
+	"go.uber.org/fx"
 
 	"github.com/celestiaorg/celestia-node/api/rpc"
 	"github.com/celestiaorg/celestia-node/nodebuilder/blob"
 	"github.com/celestiaorg/celestia-node/nodebuilder/das"
+	"github.com/celestiaorg/celestia-node/nodebuilder/debug"
+	"github.com/celestiaorg/celestia-node/nodebuilder/features"
 	"github.com/celestiaorg/celestia-node/nodebuilder/fraud"
 	"github.com/celestiaorg/celestia-node/nodebuilder/header"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
@@ -14,28 +17,59 @@ import (
 	"github.com/celestiaorg/celestia-node/nodebuilder/state"
 )
 
+// endpoints are the services served on the rpc. The services of the modules that can be left out
+// of the Node are optional, and their namespaces are only registered when they are present.
+type endpoints struct {
+	fx.In
+
+	State  state.Module `optional:"true"`
+	Share  share.Module
+	Fraud  fraud.Module
+	Header header.Module
+	DASer  das.Module `optional:"true"`
+	P2P    p2p.Module
+	Node   node.Module
+	Blob   blob.Module `optional:"true"`
+	// Debug is served only by the nodes built with the 'debug' build tag
+	Debug debug.Module `optional:"true"`
+	Serv  *rpc.Server
+}
+
 // registerEndpoints registers the given services on the rpc.
-func registerEndpoints(
-	stateMod state.Module,
-	shareMod share.Module,
-	fraudMod fraud.Module,
+func registerEndpoints(e endpoints) {
+	serv := e.Serv
+	serv.RegisterAuthedService("fraud", e.Fraud, &fraud.API{})
+	if e.DASer != nil {
+		serv.RegisterAuthedService("das", e.DASer, &das.API{})
+	}
+	serv.RegisterAuthedService("header", e.Header, &header.API{})
+	if e.State != nil {
+		serv.RegisterAuthedService("state", e.State, &state.API{})
+	}
+	serv.RegisterAuthedService("share", e.Share, &share.API{})
+	serv.RegisterAuthedService("p2p", e.P2P, &p2p.API{})
+	serv.RegisterAuthedService("node", e.Node, &node.API{})
+	if e.Blob != nil {
+		serv.RegisterAuthedService("blob", e.Blob, &blob.API{})
+	}
+	if e.Debug != nil {
+		serv.RegisterAuthedService("debug", e.Debug, &debug.API{})
+	}
+}
+
+// registerSafeModeEndpoints registers the services available in safe mode on the rpc.
+func registerSafeModeEndpoints(
 	headerMod header.Module,
-	daserMod das.Module,
-	p2pMod p2p.Module,
 	nodeMod node.Module,
-	blobMod blob.Module,
 	serv *rpc.Server,
 ) {
-	serv.RegisterAuthedService("fraud", fraudMod, &fraud.API{})
-	serv.RegisterAuthedService("das", daserMod, &das.API{})
 	serv.RegisterAuthedService("header", headerMod, &header.API{})
-	serv.RegisterAuthedService("state", stateMod, &state.API{})
-	serv.RegisterAuthedService("share", shareMod, &share.API{})
-	serv.RegisterAuthedService("p2p", p2pMod, &p2p.API{})
 	serv.RegisterAuthedService("node", nodeMod, &node.API{})
-	serv.RegisterAuthedService("blob", blobMod, &blob.API{})
 }
 
-func server(cfg *Config, auth jwt.Signer) *rpc.Server {
-	return rpc.NewServer(cfg.Address, cfg.Port, auth)
+func server(cfg *Config, auth jwt.Signer, features features.Set) *rpc.Server {
+	srv := rpc.NewServer(cfg.Address, cfg.Port, auth)
+	srv.EnableFeatures(features.List()...)
+	srv.SetMaxConcurrentRequests(cfg.MaxConcurrentRequests)
+	return srv
 }
@@ -2,39 +2,75 @@ package rpc
 
 import (
 	"context"
+	"fmt"
 
 	"go.uber.org/fx"
 
+	"github.com/celestiaorg/celestia-node/api/gateway"
 	"github.com/celestiaorg/celestia-node/api/rpc"
+	"github.com/celestiaorg/celestia-node/libs/handover"
+	"github.com/celestiaorg/celestia-node/nodebuilder/health"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 )
 
 func ConstructModule(tp node.Type, cfg *Config) fx.Option {
-	// sanitize config values before constructing module
-	cfgErr := cfg.Validate()
-
-	baseComponents := fx.Options(
-		fx.Supply(cfg),
-		fx.Error(cfgErr),
-		fx.Provide(fx.Annotate(
-			server,
-			fx.OnStart(func(ctx context.Context, server *rpc.Server) error {
-				return server.Start(ctx)
-			}),
-			fx.OnStop(func(ctx context.Context, server *rpc.Server) error {
-				return server.Stop(ctx)
-			}),
-		)),
-	)
-
 	switch tp {
 	case node.Light, node.Full, node.Bridge:
 		return fx.Module(
 			"rpc",
-			baseComponents,
+			baseComponents(cfg),
 			fx.Invoke(registerEndpoints),
+			fx.Invoke(registerStreamedEndpoints),
+			fx.Invoke(func(serv *rpc.Server, health *gateway.HealthHandler) {
+				serv.ServeReadiness(health.ServeReadiness)
+			}),
 		)
 	default:
-		panic("invalid node type")
+		return fx.Error(fmt.Errorf("module/rpc: invalid node type: %s", tp))
 	}
 }
+
+// ConstructSafeModeModule collects the rpc module exposing only the services available in safe
+// mode.
+func ConstructSafeModeModule(cfg *Config) fx.Option {
+	return fx.Module(
+		"rpc",
+		baseComponents(cfg),
+		fx.Invoke(registerSafeModeEndpoints),
+	)
+}
+
+func baseComponents(cfg *Config) fx.Option {
+	// sanitize config values before constructing module
+	cfgErr := cfg.Validate()
+
+	return fx.Options(
+		fx.Supply(cfg),
+		fx.Error(cfgErr),
+		fx.Provide(server),
+		fx.Invoke(func(l listeners) {
+			l.Server.WithListeners(l.Listeners)
+		}),
+		// the server starts once the node is ready, if listening is gated
+		fx.Invoke(func(lc fx.Lifecycle, server *rpc.Server, gate *health.Gate) {
+			lc.Append(fx.Hook{
+				OnStart: func(ctx context.Context) error {
+					return gate.Start(ctx, "rpc", server.Start)
+				},
+				OnStop: func(ctx context.Context) error {
+					gate.Stop()
+					return server.Stop(ctx)
+				},
+			})
+		}),
+	)
+}
+
+// listeners are the Listeners the server listens through, supplied to Nodes taking the listeners
+// over from the previous node process.
+type listeners struct {
+	fx.In
+
+	Listeners *handover.Listeners `optional:"true"`
+	Server    *rpc.Server
+}
@@ -0,0 +1,66 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/filecoin-project/go-jsonrpc/auth"
+
+	"github.com/celestiaorg/celestia-node/api/rpc"
+	"github.com/celestiaorg/celestia-node/nodebuilder/share"
+	sharepkg "github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds"
+)
+
+// registerStreamedEndpoints registers the methods with large results, which are streamed instead
+// of being encoded in memory at once.
+func registerStreamedEndpoints(shareMod share.Module, serv *rpc.Server) error {
+	// encoding a 128x128 EDS at once takes hundreds of megabytes
+	perm, err := permOf(share.API{}, "GetEDS")
+	if err != nil {
+		return err
+	}
+	serv.RegisterStreamedMethod("share.GetEDS", perm, streamEDS(shareMod))
+	return nil
+}
+
+// streamEDS serves share.GetEDS, writing the EDS row by row.
+func streamEDS(shareMod share.Module) rpc.StreamedMethod {
+	return func(ctx context.Context, params json.RawMessage) (func(io.Writer) error, error) {
+		var args []json.RawMessage
+		if err := json.Unmarshal(params, &args); err != nil {
+			return nil, fmt.Errorf("unmarshaling params: %w", err)
+		}
+		if len(args) != 1 {
+			return nil, fmt.Errorf("expected 1 param, got %d", len(args))
+		}
+		var root *sharepkg.Root
+		if err := json.Unmarshal(args[0], &root); err != nil {
+			return nil, fmt.Errorf("unmarshaling root: %w", err)
+		}
+
+		square, err := shareMod.GetEDS(ctx, root)
+		if err != nil {
+			return nil, err
+		}
+		return func(w io.Writer) error {
+			return eds.WriteJSON(w, square)
+		}, nil
+	}
+}
+
+// permOf returns the permission required to call the method of the API struct.
+func permOf(api any, method string) (auth.Permission, error) {
+	field, ok := reflect.TypeOf(api).FieldByName("Internal")
+	if !ok {
+		return "", fmt.Errorf("rpc: API struct %T without Internal field", api)
+	}
+	fn, ok := field.Type.FieldByName(method)
+	if !ok {
+		return "", fmt.Errorf("rpc: API struct %T without %s method", api, method)
+	}
+	return auth.Permission(fn.Tag.Get("perm")), nil
+}
@@ -3,15 +3,21 @@ package nodebuilder
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/libp2p/go-libp2p/core/peer"
-	"github.com/pyroscope-io/client/pyroscope"
 	otelpyroscope "github.com/pyroscope-io/otel-profiling-go"
+	hostmetrics "go.opentelemetry.io/contrib/instrumentation/host"
+	runtimemetrics "go.opentelemetry.io/contrib/instrumentation/runtime"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/metric"
 	sdk "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	tracesdk "go.opentelemetry.io/otel/sdk/trace"
@@ -21,11 +27,19 @@ import (
 
 	"github.com/celestiaorg/go-fraud"
 
+	dassvc "github.com/celestiaorg/celestia-node/das"
+	"github.com/celestiaorg/celestia-node/libs/supervisor"
+	"github.com/celestiaorg/celestia-node/nodebuilder/blob"
+	"github.com/celestiaorg/celestia-node/nodebuilder/clock"
+	modcore "github.com/celestiaorg/celestia-node/nodebuilder/core"
 	"github.com/celestiaorg/celestia-node/nodebuilder/das"
 	modheader "github.com/celestiaorg/celestia-node/nodebuilder/header"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+	"github.com/celestiaorg/celestia-node/nodebuilder/pruner"
+	"github.com/celestiaorg/celestia-node/nodebuilder/quota"
 	"github.com/celestiaorg/celestia-node/nodebuilder/share"
+	libshare "github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/state"
 )
 
@@ -33,7 +47,7 @@ import (
 // WARNING: Use this option with caution and never run the Node with different networks over the
 // same persisted Store.
 func WithNetwork(net p2p.Network) fx.Option {
-	return fx.Replace(net)
+	return networkOption{Option: fx.Replace(net), network: net}
 }
 
 // WithBootstrappers sets custom bootstrap peers.
@@ -41,52 +55,137 @@ func WithBootstrappers(peers p2p.Bootstrappers) fx.Option {
 	return fx.Replace(peers)
 }
 
-// WithPyroscope enables pyroscope profiling for the node.
-func WithPyroscope(endpoint string, nodeType node.Type) fx.Option {
-	return fx.Options(
-		fx.Invoke(func(peerID peer.ID) error {
-			_, err := pyroscope.Start(pyroscope.Config{
-				ApplicationName: "celestia.da-node",
-				ServerAddress:   endpoint,
-				Tags: map[string]string{
-					"type":   nodeType.String(),
-					"peerId": peerID.String(),
-				},
-				Logger: nil,
-				ProfileTypes: []pyroscope.ProfileType{
-					pyroscope.ProfileCPU,
-					pyroscope.ProfileAllocObjects,
-					pyroscope.ProfileAllocSpace,
-					pyroscope.ProfileInuseObjects,
-					pyroscope.ProfileInuseSpace,
-				},
-			})
-			return err
+// WithMetrics enables metrics exporting for the node over OTLP HTTP.
+func WithMetrics(metricOpts []otlpmetrichttp.Option, nodeType node.Type) fx.Option {
+	return withMetrics(
+		fx.Provide(func(ctx context.Context) (sdk.Exporter, error) {
+			return otlpmetrichttp.New(ctx, metricOpts...)
 		}),
+		nodeType,
 	)
 }
 
-// WithMetrics enables metrics exporting for the node.
-func WithMetrics(metricOpts []otlpmetrichttp.Option, nodeType node.Type) fx.Option {
+// WithMetricsGRPC enables metrics exporting for the node over OTLP gRPC.
+func WithMetricsGRPC(metricOpts []otlpmetricgrpc.Option, nodeType node.Type) fx.Option {
+	return withMetrics(
+		fx.Provide(func(ctx context.Context) (sdk.Exporter, error) {
+			return otlpmetricgrpc.New(ctx, metricOpts...)
+		}),
+		nodeType,
+	)
+}
+
+const (
+	// defaultMetricsExportInterval is the time between two exports of the metrics.
+	defaultMetricsExportInterval = time.Minute
+	// defaultMetricsExportTimeout bounds the time given to a single export of the metrics.
+	defaultMetricsExportTimeout = 2 * time.Second
+)
+
+// metricsExport configures the periodic export of the metrics.
+type metricsExport struct {
+	interval time.Duration
+	timeout  time.Duration
+}
+
+// WithMetricsExport overrides the time between two exports of the metrics and the time given to a
+// single export. Depends on WithMetrics or WithMetricsGRPC.
+func WithMetricsExport(interval, timeout time.Duration) fx.Option {
+	if interval <= 0 || timeout <= 0 {
+		return fx.Error(fmt.Errorf("nodebuilder: metrics export interval and timeout must be positive, "+
+			"got %s and %s", interval, timeout))
+	}
+	return fx.Replace(metricsExport{interval: interval, timeout: timeout})
+}
+
+// resourceAttributes are the extra attributes of the resource the metrics and traces of the node
+// are reported with.
+type resourceAttributes []attribute.KeyValue
+
+// WithResourceAttributes adds the given attributes, e.g. the region or the operator of the node,
+// to the resource the metrics and traces of the node are reported with. The attributes describing
+// the node itself, like the service name, can not be overridden.
+func WithResourceAttributes(attrs map[string]string) fx.Option {
+	keys := make([]string, 0, len(attrs))
+	for key := range attrs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	kvs := make(resourceAttributes, 0, len(attrs))
+	for _, key := range keys {
+		kvs = append(kvs, attribute.String(key, attrs[key]))
+	}
+	return fx.Supply(kvs)
+}
+
+// resourceParams are the optional extra attributes of the resource.
+type resourceParams struct {
+	fx.In
+
+	Attributes resourceAttributes `optional:"true"`
+}
+
+// nodeResource returns the resource describing the node the metrics and traces are reported for.
+func nodeResource(nodeType node.Type, network p2p.Network, peerID peer.ID, res resourceParams) *resource.Resource {
+	// the attributes of the node come last to take precedence over the extra ones
+	attrs := append(append([]attribute.KeyValue(nil), res.Attributes...),
+		semconv.ServiceNamespaceKey.String(nodeType.String()),
+		semconv.ServiceNameKey.String(fmt.Sprintf("%s/%s", network.String(), peerID.String())),
+	)
+	return resource.NewWithAttributes(semconv.SchemaURL, attrs...)
+}
+
+// metricsInstrumentation toggles the metrics of the Go runtime and of the host of the node. It
+// defaults to the RuntimeMetrics and HostMetrics of the node config.
+type metricsInstrumentation struct {
+	runtime bool
+	host    bool
+}
+
+// WithMetricsInstrumentation enables the metrics of the Go runtime (GC pauses, goroutines, heap)
+// and of the host (CPU, memory, network and the disk space of the store) next to the node metrics.
+// Overrides the RuntimeMetrics and HostMetrics of the node config. Depends on WithMetrics or
+// WithMetricsGRPC.
+func WithMetricsInstrumentation(runtime, host bool) fx.Option {
+	return fx.Replace(metricsInstrumentation{runtime: runtime, host: host})
+}
+
+func withMetrics(exporter fx.Option, nodeType node.Type) fx.Option {
 	baseComponents := fx.Options(
-		fx.Supply(metricOpts),
+		exporter,
+		fx.Provide(func(cfg *Config) metricsInstrumentation {
+			return metricsInstrumentation{runtime: cfg.Node.RuntimeMetrics, host: cfg.Node.HostMetrics}
+		}),
+		fx.Supply(metricsExport{interval: defaultMetricsExportInterval, timeout: defaultMetricsExportTimeout}),
+		fx.Supply(metricsCardinality(defaultMetricsCardinalityLimit)),
+		fx.Provide(func() node.MetricsReader {
+			return sdk.NewManualReader()
+		}),
 		fx.Invoke(initializeMetrics),
-		fx.Invoke(state.WithMetrics),
+		fx.Invoke(withStateMetrics),
 		fx.Invoke(fraud.WithMetrics),
 		fx.Invoke(node.WithMetrics),
 		fx.Invoke(modheader.WithMetrics),
+		fx.Invoke(clock.WithMetrics),
 		fx.Invoke(share.WithDiscoveryMetrics),
+		fx.Invoke(supervisor.WithMetrics),
+		fx.Invoke(pruner.WithMetrics),
+		fx.Invoke(quota.WithMetrics),
 	)
 
 	samplingMetrics := fx.Options(
-		fx.Invoke(das.WithMetrics),
+		fx.Invoke(withSamplingMetrics),
 		fx.Invoke(share.WithPeerManagerMetrics),
 		fx.Invoke(share.WithShrexClientMetrics),
 		fx.Invoke(share.WithShrexGetterMetrics),
+		fx.Invoke(share.WithCascadeGetterMetrics),
+		fx.Invoke(share.WithCacheGetterMetrics),
+		fx.Invoke(share.WithBlockCacheMetrics),
 	)
 
 	var opts fx.Option
-	switch nodeType {
+	switch nodeType.Base() {
 	case node.Full:
 		opts = fx.Options(
 			baseComponents,
@@ -102,53 +201,136 @@ func WithMetrics(metricOpts []otlpmetrichttp.Option, nodeType node.Type) fx.Opti
 		opts = fx.Options(
 			baseComponents,
 			fx.Invoke(share.WithShrexServerMetrics),
+			fx.Invoke(modcore.WithMetrics),
 		)
 	default:
-		panic("invalid node type")
+		return fx.Error(fmt.Errorf("nodebuilder: invalid node type: %s", nodeType))
 	}
 	return opts
 }
 
+// withStateMetrics enables the metrics of the state module, unless it was left out with WithoutState.
+func withStateMetrics(p struct {
+	fx.In
+
+	CoreAccessor *state.CoreAccessor `optional:"true"`
+}) {
+	if p.CoreAccessor != nil {
+		state.WithMetrics(p.CoreAccessor)
+	}
+}
+
+// withSamplingMetrics enables the metrics of the DASer, unless it was left out with WithoutDAS.
+func withSamplingMetrics(p struct {
+	fx.In
+
+	DASer *dassvc.DASer `optional:"true"`
+}) error {
+	if p.DASer == nil {
+		return nil
+	}
+	return das.WithMetrics(p.DASer)
+}
+
+// WithNamespaceMetrics enables metrics on DA usage of the given namespaces: blobs and bytes
+// published per block, the time from header arrival until their data is retrieved and, for node
+// types serving shares, shrex/nd requests served.
+// Depends on WithMetrics or WithMetricsGRPC.
+func WithNamespaceMetrics(namespaces []libshare.Namespace, nodeType node.Type) fx.Option {
+	opts := blob.WithNamespaceMetrics(namespaces)
+	switch nodeType.Base() {
+	case node.Full, node.Bridge:
+		return fx.Options(
+			opts,
+			fx.Invoke(share.WithShrexServerNamespaceMetrics(namespaces)),
+		)
+	case node.Light:
+		return opts
+	default:
+		return fx.Error(fmt.Errorf("nodebuilder: invalid node type: %s", nodeType))
+	}
+}
+
+// DefaultTracesShutdownTimeout is the default time given to flush buffered spans on node stop.
+const DefaultTracesShutdownTimeout = 5 * time.Second
+
+// tracesShutdownTimeout is the time given to the TracerProvider to flush and shut down.
+type tracesShutdownTimeout time.Duration
+
+// WithTracesShutdownTimeout overrides the time given to flush buffered spans on node stop.
+// Depends on WithTraces or WithTracesGRPC.
+func WithTracesShutdownTimeout(timeout time.Duration) fx.Option {
+	if timeout <= 0 {
+		return fx.Error(fmt.Errorf("nodebuilder: traces shutdown timeout must be positive, got %s", timeout))
+	}
+	return fx.Replace(tracesShutdownTimeout(timeout))
+}
+
+// WithTraces enables traces exporting for the node over OTLP HTTP.
 func WithTraces(opts []otlptracehttp.Option, pyroOpts []otelpyroscope.Option) fx.Option {
+	return withTraces(otlptracehttp.NewClient(opts...), pyroOpts)
+}
+
+// WithTracesGRPC enables traces exporting for the node over OTLP gRPC.
+func WithTracesGRPC(opts []otlptracegrpc.Option, pyroOpts []otelpyroscope.Option) fx.Option {
+	return withTraces(otlptracegrpc.NewClient(opts...), pyroOpts)
+}
+
+func withTraces(client otlptrace.Client, pyroOpts []otelpyroscope.Option) fx.Option {
 	options := fx.Options(
-		fx.Supply(opts),
+		fx.Provide(func() otlptrace.Client {
+			return client
+		}),
 		fx.Supply(pyroOpts),
-		fx.Invoke(initializeTraces),
+		fx.Supply(tracesShutdownTimeout(DefaultTracesShutdownTimeout)),
+		fx.Provide(initializeTraces),
 	)
 	return options
 }
 
+// shutdownTraces flushes the spans buffered by the TracerProvider and shuts it down. The Node calls
+// it once the fx.App has stopped, rather than from an OnStop hook, so that the spans of stopping the
+// node are exported as well.
+type shutdownTraces func(context.Context) error
+
+// initializeTraces initializes the global tracer provider.
 func initializeTraces(
 	ctx context.Context,
 	nodeType node.Type,
 	peerID peer.ID,
 	network p2p.Network,
-	opts []otlptracehttp.Option,
+	client otlptrace.Client,
 	pyroOpts []otelpyroscope.Option,
-) error {
-	client := otlptracehttp.NewClient(opts...)
+	shutdownTimeout tracesShutdownTimeout,
+	res resourceParams,
+) (shutdownTraces, error) {
 	exporter, err := otlptrace.New(ctx, client)
 	if err != nil {
-		return fmt.Errorf("creating OTLP trace exporter: %w", err)
+		return nil, fmt.Errorf("creating OTLP trace exporter: %w", err)
 	}
 
-	var tp trace.TracerProvider
-	tp = tracesdk.NewTracerProvider(
+	provider := tracesdk.NewTracerProvider(
 		tracesdk.WithSampler(tracesdk.AlwaysSample()),
 		// Always be sure to batch in production.
 		tracesdk.WithBatcher(exporter),
 		// Record information about this application in a Resource.
-		tracesdk.WithResource(resource.NewWithAttributes(
-			semconv.SchemaURL,
-			semconv.ServiceNamespaceKey.String(nodeType.String()),
-			semconv.ServiceNameKey.String(fmt.Sprintf("%s/%s", network.String(), peerID.String()))),
-		))
+		tracesdk.WithResource(nodeResource(nodeType, network, peerID, res)),
+	)
 
+	var tp trace.TracerProvider = provider
 	if len(pyroOpts) > 0 {
 		tp = otelpyroscope.NewTracerProvider(tp, pyroOpts...)
 	}
 	otel.SetTracerProvider(tp)
-	return nil
+	return func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, time.Duration(shutdownTimeout))
+		defer cancel()
+		// flush explicitly, so spans buffered by the batcher are exported before the exporter closes
+		if err := provider.ForceFlush(ctx); err != nil {
+			log.Warnw("flushing traces", "err", err)
+		}
+		return provider.Shutdown(ctx)
+	}, nil
 }
 
 // initializeMetrics initializes the global meter provider.
@@ -158,24 +340,68 @@ func initializeMetrics(
 	peerID peer.ID,
 	nodeType node.Type,
 	network p2p.Network,
-	opts []otlpmetrichttp.Option,
+	exp sdk.Exporter,
+	instrumentation metricsInstrumentation,
+	storePath node.StorePath,
+	debugReader node.MetricsReader,
+	export metricsExport,
+	cardinality metricsCardinality,
+	res resourceParams,
 ) error {
-	exp, err := otlpmetrichttp.New(ctx, opts...)
-	if err != nil {
-		return err
+	if cardinality > 0 {
+		exp = newCardinalityLimiter(exp, int(cardinality))
 	}
-
 	provider := sdk.NewMeterProvider(
-		sdk.WithReader(sdk.NewPeriodicReader(exp, sdk.WithTimeout(2*time.Second))),
-		sdk.WithResource(resource.NewWithAttributes(
-			semconv.SchemaURL,
-			semconv.ServiceNamespaceKey.String(nodeType.String()),
-			semconv.ServiceNameKey.String(fmt.Sprintf("%s/%s", network.String(), peerID.String())))))
+		sdk.WithReader(sdk.NewPeriodicReader(exp,
+			sdk.WithInterval(export.interval),
+			sdk.WithTimeout(export.timeout),
+		)),
+		// collects the metrics on demand for the debug snapshots of the node
+		sdk.WithReader(debugReader),
+		sdk.WithResource(nodeResource(nodeType, network, peerID, res)))
 	lc.Append(fx.Hook{
 		OnStop: func(ctx context.Context) error {
 			return provider.Shutdown(ctx)
 		},
 	})
 	otel.SetMeterProvider(provider)
+
+	if instrumentation.runtime {
+		if err := runtimemetrics.Start(runtimemetrics.WithMeterProvider(provider)); err != nil {
+			return fmt.Errorf("starting runtime metrics: %w", err)
+		}
+	}
+	if instrumentation.host {
+		if err := hostmetrics.Start(hostmetrics.WithMeterProvider(provider)); err != nil {
+			return fmt.Errorf("starting host metrics: %w", err)
+		}
+		if err := withDiskMetrics(provider, string(storePath)); err != nil {
+			return fmt.Errorf("starting disk metrics: %w", err)
+		}
+	}
 	return nil
 }
+
+// withDiskMetrics observes the space left on the file system of the store, which the host metrics
+// do not cover.
+func withDiskMetrics(provider metric.MeterProvider, storePath string) error {
+	meter := provider.Meter("nodebuilder")
+	free, err := meter.Int64ObservableGauge("system_disk_free",
+		metric.WithDescription("space available to the node on the file system of its store"),
+		metric.WithUnit("By"))
+	if err != nil {
+		return err
+	}
+
+	callback := func(ctx context.Context, observer metric.Observer) error {
+		space, err := freeDiskSpace(storePath)
+		if err != nil {
+			log.Warnw("reading free disk space", "path", storePath, "err", err)
+			return nil
+		}
+		observer.ObserveInt64(free, int64(space))
+		return nil
+	}
+	_, err = meter.RegisterCallback(callback, free)
+	return err
+}
@@ -2,15 +2,27 @@ package share
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/availability/light"
+	"github.com/celestiaorg/celestia-node/share/getters"
+	"github.com/celestiaorg/celestia-node/share/ipld"
+	"github.com/celestiaorg/celestia-node/share/p2p"
 	"github.com/celestiaorg/celestia-node/share/p2p/discovery"
 	"github.com/celestiaorg/celestia-node/share/p2p/peers"
 	"github.com/celestiaorg/celestia-node/share/p2p/shrexeds"
 	"github.com/celestiaorg/celestia-node/share/p2p/shrexnd"
 )
 
+// defaultLightGetterCacheSize is enough to keep an extended square of 64x64 original shares.
+const defaultLightGetterCacheSize = 4 * 64 * 64 * share.Size
+
+// defaultBlockCacheSize is enough to keep the leaves and inner nodes of a few rows of an extended
+// square of 128x128 original shares.
+const defaultBlockCacheSize = 16 << 10
+
 // TODO: some params are pointers and other are not, Let's fix this.
 type Config struct {
 	UseShareExchange bool
@@ -20,9 +32,41 @@ type Config struct {
 	ShrExNDParams *shrexnd.Parameters
 	// PeerManagerParams sets peer-manager configuration parameters
 	PeerManagerParams peers.Parameters
+	// Bandwidth caps the bandwidth of serving data over the inbound shrex and bitswap streams.
+	Bandwidth p2p.BandwidthParameters
 
 	LightAvailability light.Parameters `toml:",omitempty"`
 	Discovery         discovery.Parameters
+
+	// GetterHedgeDelay enables hedged requests of data when positive, launching the next getter
+	// (e.g. IPLD after share exchange) after the delay, while the previous one is still in flight.
+	// Zero delay tries getters strictly one after another.
+	GetterHedgeDelay time.Duration
+	// AdaptiveGetterOrder prefers the network getters that have recently been the fastest and most
+	// successful, instead of keeping their static order (e.g. share exchange, then IPLD). The local
+	// store is always tried first.
+	AdaptiveGetterOrder bool
+	// GetterFallbackOn are the classes of errors after which the next getter is tried: "not_found",
+	// "rate_limited", "invalid_response", "timeout" or "other". Getters failing with errors of other
+	// classes fail the request right away. Empty falls back after any error.
+	GetterFallbackOn []getters.ErrorClass
+
+	// GetterCacheSize is the maximum total size in bytes of EDSes and shares kept in memory after
+	// being retrieved, so that repeated requests for the same blocks are served without
+	// fetching them again. Zero disables the cache.
+	GetterCacheSize int
+
+	// BlockCacheSize is the maximum amount of IPLD blocks kept in memory after being read from the
+	// blockstore by the IPLD getter, so that repeated reads of the same rows, e.g. during
+	// reconstruction, do not hit the disk. Zero disables the cache.
+	BlockCacheSize int
+	// BlockCachePolicy is the eviction policy of the block cache: "arc" or "2q".
+	BlockCachePolicy ipld.CachePolicy
+
+	// StoreGCInterval compacts the store of full and bridge nodes in the background at the given
+	// interval, while the EDSes outside the Pruner.Window are removed by the pruner. Zero disables
+	// it, leaving it to the 'store gc' command.
+	StoreGCInterval time.Duration
 }
 
 func DefaultConfig(tp node.Type) Config {
@@ -32,10 +76,18 @@ func DefaultConfig(tp node.Type) Config {
 		ShrExNDParams:     shrexnd.DefaultParameters(),
 		UseShareExchange:  true,
 		PeerManagerParams: peers.DefaultParameters(),
+		BlockCachePolicy:  ipld.CachePolicyARC,
+	}
+
+	// bridge nodes do not retrieve data over IPLD
+	if tp != node.Bridge {
+		cfg.BlockCacheSize = defaultBlockCacheSize
 	}
 
 	if tp == node.Light {
 		cfg.LightAvailability = light.DefaultParameters()
+		// light nodes do not store the data they retrieve
+		cfg.GetterCacheSize = defaultLightGetterCacheSize
 	}
 
 	return cfg
@@ -65,5 +117,33 @@ func (cfg *Config) Validate(tp node.Type) error {
 		return fmt.Errorf("nodebuilder/share: %w", err)
 	}
 
+	if cfg.GetterHedgeDelay < 0 {
+		return fmt.Errorf("nodebuilder/share: GetterHedgeDelay must not be negative, got %s", cfg.GetterHedgeDelay)
+	}
+
+	for _, class := range cfg.GetterFallbackOn {
+		if err := class.Validate(); err != nil {
+			return fmt.Errorf("nodebuilder/share: GetterFallbackOn: %w", err)
+		}
+	}
+
+	if cfg.GetterCacheSize < 0 {
+		return fmt.Errorf("nodebuilder/share: GetterCacheSize must not be negative, got %d", cfg.GetterCacheSize)
+	}
+
+	if cfg.BlockCacheSize < 0 {
+		return fmt.Errorf("nodebuilder/share: BlockCacheSize must not be negative, got %d", cfg.BlockCacheSize)
+	}
+
+	if cfg.BlockCacheSize > 0 {
+		if err := cfg.BlockCachePolicy.Validate(); err != nil {
+			return fmt.Errorf("nodebuilder/share: %w", err)
+		}
+	}
+
+	if cfg.StoreGCInterval < 0 {
+		return fmt.Errorf("nodebuilder/share: StoreGCInterval must not be negative, got %s", cfg.StoreGCInterval)
+	}
+
 	return nil
 }
@@ -7,13 +7,19 @@ import (
 	"github.com/filecoin-project/dagstore"
 	"github.com/ipfs/go-blockservice"
 	"github.com/ipfs/go-datastore"
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
+	exchange "github.com/ipfs/go-ipfs-exchange-interface"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/routing"
 	routingdisc "github.com/libp2p/go-libp2p/p2p/discovery/routing"
+	"github.com/libp2p/go-libp2p/p2p/net/conngater"
 	"go.uber.org/fx"
 
 	"github.com/celestiaorg/celestia-app/pkg/da"
+	libhead "github.com/celestiaorg/go-header"
 
+	"github.com/celestiaorg/celestia-node/header"
+	modprune "github.com/celestiaorg/celestia-node/nodebuilder/pruner"
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/availability/cache"
 	"github.com/celestiaorg/celestia-node/share/availability/light"
@@ -21,18 +27,30 @@ import (
 	"github.com/celestiaorg/celestia-node/share/getters"
 	"github.com/celestiaorg/celestia-node/share/ipld"
 	disc "github.com/celestiaorg/celestia-node/share/p2p/discovery"
+	"github.com/celestiaorg/celestia-node/share/p2p/peers"
+	"github.com/celestiaorg/celestia-node/share/p2p/shrexsub"
 )
 
-func newDiscovery(cfg Config) func(routing.ContentRouting, host.Host) *disc.Discovery {
-	return func(
-		r routing.ContentRouting,
-		h host.Host,
-	) *disc.Discovery {
+type discoveryParams struct {
+	fx.In
+
+	Routing routing.ContentRouting
+	Host    host.Host
+	Pruner  modprune.Config `optional:"true"`
+}
+
+func newDiscovery(cfg Config) func(discoveryParams) *disc.Discovery {
+	return func(p discoveryParams) *disc.Discovery {
 		return disc.NewDiscovery(
-			h,
-			routingdisc.NewRoutingDiscovery(r),
+			p.Host,
+			routingdisc.NewRoutingDiscovery(p.Routing),
 			disc.WithPeersLimit(cfg.Discovery.PeersLimit),
 			disc.WithAdvertiseInterval(cfg.Discovery.AdvertiseInterval),
+			disc.WithRendezvousPoints(cfg.Discovery.RendezvousPoints...),
+			disc.WithAdvertisedPoints(cfg.Discovery.AdvertisedPoints...),
+			// nodes pruning block data are not advertised as full or archival, so that peers do
+			// not request the pruned block data from them
+			disc.WithPruning(!p.Pruner.Archival()),
 		)
 	}
 }
@@ -46,8 +64,43 @@ func cacheAvailability(lc fx.Lifecycle, ds datastore.Batching, avail *light.Shar
 	return ca
 }
 
-func newModule(getter share.Getter, avail share.Availability) Module {
-	return &module{getter, avail}
+// newPeerManager constructs the peers.Manager, sharing the LatencyTracker with the other components
+// observing the latency of peers, e.g. the header exchange.
+func newPeerManager(
+	params peers.Parameters,
+	headerSub libhead.Subscriber[*header.ExtendedHeader],
+	shrexSub *shrexsub.PubSub,
+	discovery *disc.Discovery,
+	host host.Host,
+	connGater *conngater.BasicConnectionGater,
+	latency *peers.LatencyTracker,
+) (*peers.Manager, error) {
+	manager, err := peers.NewManager(params, headerSub, shrexSub, discovery, host, connGater)
+	if err != nil {
+		return nil, err
+	}
+	manager.WithLatencyTracker(latency)
+	return manager, nil
+}
+
+type moduleParams struct {
+	fx.In
+
+	Getter      share.Getter
+	Avail       share.Availability
+	PeerManager *peers.Manager `optional:"true"`
+	Headers     libhead.Store[*header.ExtendedHeader]
+	EDSStore    *eds.Store `optional:"true"`
+}
+
+func newModule(params moduleParams) Module {
+	return &module{
+		Getter:       params.Getter,
+		Availability: params.Avail,
+		peerManager:  params.PeerManager,
+		headers:      params.Headers,
+		edsStore:     params.EDSStore,
+	}
 }
 
 // ensureEmptyCARExists adds an empty EDS to the provided EDS store.
@@ -74,17 +127,30 @@ func ensureEmptyEDSInBS(ctx context.Context, bServ blockservice.BlockService) er
 	return err
 }
 
+// cascadeOptions configures the cascade of getters, the first pinned of which are local and are
+// always tried first.
+func cascadeOptions(cfg Config, pinned int) []getters.CascadeOption {
+	opts := []getters.CascadeOption{getters.WithHedgeDelay(cfg.GetterHedgeDelay)}
+	if cfg.AdaptiveGetterOrder {
+		opts = append(opts, getters.WithAdaptiveOrder(pinned))
+	}
+	if len(cfg.GetterFallbackOn) > 0 {
+		opts = append(opts, getters.WithFallbackOn(cfg.GetterFallbackOn...))
+	}
+	return opts
+}
+
 func lightGetter(
 	shrexGetter *getters.ShrexGetter,
 	ipldGetter *getters.IPLDGetter,
 	cfg Config,
-) share.Getter {
+) *getters.CascadeGetter {
 	var cascade []share.Getter
 	if cfg.UseShareExchange {
 		cascade = append(cascade, shrexGetter)
 	}
 	cascade = append(cascade, ipldGetter)
-	return getters.NewCascadeGetter(cascade)
+	return getters.NewCascadeGetter(cascade, cascadeOptions(cfg, 0)...)
 }
 
 func fullGetter(
@@ -93,12 +159,41 @@ func fullGetter(
 	shrexGetter *getters.ShrexGetter,
 	ipldGetter *getters.IPLDGetter,
 	cfg Config,
-) share.Getter {
+) *getters.CascadeGetter {
 	var cascade []share.Getter
 	cascade = append(cascade, storeGetter)
 	if cfg.UseShareExchange {
 		cascade = append(cascade, getters.NewTeeGetter(shrexGetter, store))
 	}
 	cascade = append(cascade, getters.NewTeeGetter(ipldGetter, store))
-	return getters.NewCascadeGetter(cascade)
+	// the store getter is pinned first, as no network getter is faster than reading local data
+	return getters.NewCascadeGetter(cascade, cascadeOptions(cfg, 1)...)
+}
+
+// cacheGetter wraps the cascade of getters with an in-memory cache of retrieved data, if enabled.
+func cacheGetter(cascade *getters.CascadeGetter, cfg Config) (share.Getter, error) {
+	if cfg.GetterCacheSize == 0 {
+		return cascade, nil
+	}
+	return getters.NewCacheGetter(cascade, cfg.GetterCacheSize)
+}
+
+// blockCache constructs the in-memory cache of IPLD blocks read by the IPLD getter, if enabled.
+func blockCache(bs blockstore.Blockstore, cfg Config) (*ipld.BlockCache, error) {
+	if cfg.BlockCacheSize == 0 {
+		return nil, nil
+	}
+	return ipld.NewBlockCache(bs, cfg.BlockCachePolicy, cfg.BlockCacheSize)
+}
+
+// ipldGetter constructs the IPLD getter reading blocks through the block cache, if enabled.
+func ipldGetter(
+	bServ blockservice.BlockService,
+	ex exchange.Interface,
+	cache *ipld.BlockCache,
+) *getters.IPLDGetter {
+	if cache == nil {
+		return getters.NewIPLDGetter(bServ)
+	}
+	return getters.NewIPLDGetter(blockservice.New(cache, ex))
 }
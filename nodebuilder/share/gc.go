@@ -0,0 +1,111 @@
+package share
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	logging "github.com/ipfs/go-log/v2"
+
+	"github.com/celestiaorg/celestia-node/pruner"
+	"github.com/celestiaorg/celestia-node/share/eds"
+)
+
+var log = logging.Logger("module/share")
+
+// GCResult describes the outcome of a garbage collection of the EDS store.
+type GCResult struct {
+	// Pruned is the height up to which the block data outside the pruning window is removed.
+	Pruned uint64 `json:"pruned,omitempty"`
+	// Removed is the amount of heights whose block data was removed by the collection.
+	Removed uint64 `json:"removed"`
+	eds.CompactResult
+}
+
+// CollectGarbage prunes the block data outside the window of the given pruning Service, unless it
+// is nil, as for archival nodes, then compacts the EDS store.
+func CollectGarbage(ctx context.Context, edsStore *eds.Store, p *pruner.Service) (*GCResult, error) {
+	before, err := edsStore.DiskUsage()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &GCResult{}
+	if p != nil {
+		res.Removed, err = p.Prune(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("pruning block data: %w", err)
+		}
+		res.Pruned = p.LastPruned()
+	}
+
+	compacted, err := edsStore.Compact(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("compacting EDS store: %w", err)
+	}
+	res.CompactResult = *compacted
+
+	after, err := edsStore.DiskUsage()
+	if err != nil {
+		return nil, err
+	}
+	res.Reclaimed = before - after
+	return res, nil
+}
+
+// storeGC periodically compacts the EDS store of a running node, while the block data outside the
+// pruning window is removed by the pruning Service.
+type storeGC struct {
+	edsStore *eds.Store
+	interval time.Duration
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newStoreGC(cfg Config, edsStore *eds.Store) *storeGC {
+	return &storeGC{
+		edsStore: edsStore,
+		interval: cfg.StoreGCInterval,
+	}
+}
+
+func (gc *storeGC) Start(context.Context) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	gc.cancel = cancel
+	gc.wg.Add(1)
+	go func() {
+		defer gc.wg.Done()
+		ticker := time.NewTicker(gc.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				res, err := CollectGarbage(ctx, gc.edsStore, nil)
+				if err != nil {
+					if ctx.Err() == nil {
+						log.Errorw("collecting EDS store garbage", "err", err)
+					}
+					continue
+				}
+				log.Infow("collected EDS store garbage",
+					"reindexed", res.Reindexed,
+					"orphans", res.Orphans,
+					"reclaimed_bytes", res.Reclaimed,
+				)
+			}
+		}
+	}()
+	return nil
+}
+
+func (gc *storeGC) Stop(context.Context) error {
+	if gc.cancel != nil {
+		gc.cancel()
+	}
+	gc.wg.Wait()
+	return nil
+}
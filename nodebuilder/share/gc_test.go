@@ -0,0 +1,77 @@
+package share
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	ds_sync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	libheadtest "github.com/celestiaorg/go-header/headertest"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/pruner"
+	"github.com/celestiaorg/celestia-node/pruner/full"
+	"github.com/celestiaorg/celestia-node/share/eds"
+	"github.com/celestiaorg/celestia-node/share/eds/edstest"
+)
+
+func TestCollectGarbage(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	edsStore, err := eds.NewStore(t.TempDir(), ds_sync.MutexWrap(datastore.NewMapDatastore()))
+	require.NoError(t, err)
+	require.NoError(t, edsStore.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, edsStore.Stop(ctx))
+	})
+
+	// the first two headers are outside of the retention window
+	headers := &libheadtest.Store[*header.ExtendedHeader]{Headers: make(map[int64]*header.ExtendedHeader)}
+	for height := uint64(1); height <= 3; height++ {
+		square := edstest.RandEDS(t, 4)
+		h := headertest.ExtendedHeaderFromEDS(t, height, square)
+		h.RawHeader.Time = time.Now().Add(-time.Hour * time.Duration(4-height))
+		headers.Headers[int64(height)] = h
+		headers.HeadHeight = int64(height)
+		require.NoError(t, edsStore.Put(ctx, h.DAH.Hash(), square))
+	}
+
+	progress := func(context.Context) (uint64, error) {
+		return uint64(headers.HeadHeight), nil
+	}
+	ds := ds_sync.MutexWrap(datastore.NewMapDatastore())
+	params := pruner.Params{Window: time.Hour + time.Minute, Interval: time.Hour}
+	p, err := pruner.NewService(full.NewPruner(edsStore), headers, progress, ds, params)
+	require.NoError(t, err)
+
+	res, err := CollectGarbage(ctx, edsStore, p)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, res.Pruned)
+	assert.EqualValues(t, 2, res.Removed)
+	assert.Positive(t, res.Reclaimed)
+
+	for height, h := range headers.Headers {
+		has, err := edsStore.Has(ctx, h.DAH.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, height == 3, has, height)
+	}
+
+	// the pruning progress persists across the collections
+	p, err = pruner.NewService(full.NewPruner(edsStore), headers, progress, ds, params)
+	require.NoError(t, err)
+	res, err = CollectGarbage(ctx, edsStore, p)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, res.Pruned)
+	assert.Zero(t, res.Removed)
+
+	// archival nodes only compact
+	res, err = CollectGarbage(ctx, edsStore, nil)
+	require.NoError(t, err)
+	assert.Zero(t, res.Pruned)
+}
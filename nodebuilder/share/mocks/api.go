@@ -11,7 +11,9 @@ import (
 	gomock "github.com/golang/mock/gomock"
 
 	da "github.com/celestiaorg/celestia-app/pkg/da"
+	share0 "github.com/celestiaorg/celestia-node/nodebuilder/share"
 	share "github.com/celestiaorg/celestia-node/share"
+	peers "github.com/celestiaorg/celestia-node/share/p2p/peers"
 	rsmt2d "github.com/celestiaorg/rsmt2d"
 )
 
@@ -83,6 +85,21 @@ func (mr *MockModuleMockRecorder) GetSharesByNamespace(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharesByNamespace", reflect.TypeOf((*MockModule)(nil).GetSharesByNamespace), arg0, arg1, arg2)
 }
 
+// PeerLatencies mocks base method.
+func (m *MockModule) PeerLatencies(arg0 context.Context) ([]peers.PeerLatency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PeerLatencies", arg0)
+	ret0, _ := ret[0].([]peers.PeerLatency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PeerLatencies indicates an expected call of PeerLatencies.
+func (mr *MockModuleMockRecorder) PeerLatencies(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerLatencies", reflect.TypeOf((*MockModule)(nil).PeerLatencies), arg0)
+}
+
 // ProbabilityOfAvailability mocks base method.
 func (m *MockModule) ProbabilityOfAvailability(arg0 context.Context) float64 {
 	m.ctrl.T.Helper()
@@ -110,3 +127,18 @@ func (mr *MockModuleMockRecorder) SharesAvailable(arg0, arg1 interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SharesAvailable", reflect.TypeOf((*MockModule)(nil).SharesAvailable), arg0, arg1)
 }
+
+// StoredSquares mocks base method.
+func (m *MockModule) StoredSquares(arg0 context.Context, arg1, arg2 uint64) (*share0.StoredSquaresPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoredSquares", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*share0.StoredSquaresPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StoredSquares indicates an expected call of StoredSquares.
+func (mr *MockModuleMockRecorder) StoredSquares(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoredSquares", reflect.TypeOf((*MockModule)(nil).StoredSquares), arg0, arg1, arg2)
+}
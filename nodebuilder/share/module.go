@@ -2,6 +2,7 @@ package share
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/ipfs/go-datastore"
 	"github.com/libp2p/go-libp2p/core/host"
@@ -15,6 +16,7 @@ import (
 	"github.com/celestiaorg/celestia-node/share/availability/light"
 	"github.com/celestiaorg/celestia-node/share/eds"
 	"github.com/celestiaorg/celestia-node/share/getters"
+	"github.com/celestiaorg/celestia-node/share/p2p"
 	disc "github.com/celestiaorg/celestia-node/share/p2p/discovery"
 	"github.com/celestiaorg/celestia-node/share/p2p/peers"
 	"github.com/celestiaorg/celestia-node/share/p2p/shrexeds"
@@ -31,6 +33,9 @@ func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option
 		fx.Error(cfgErr),
 		fx.Options(options...),
 		fx.Provide(newModule),
+		fx.Provide(func() *p2p.BandwidthLimiter {
+			return p2p.NewBandwidthLimiter(cfg.Bandwidth)
+		}),
 		fx.Invoke(func(disc *disc.Discovery) {}),
 		fx.Provide(fx.Annotate(
 			newDiscovery(*cfg),
@@ -52,9 +57,14 @@ func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option
 		fx.Provide(getters.NewStoreGetter),
 		fx.Invoke(func(edsSrv *shrexeds.Server, ndSrc *shrexnd.Server) {}),
 		fx.Provide(fx.Annotate(
-			func(host host.Host, store *eds.Store, network modp2p.Network) (*shrexeds.Server, error) {
+			func(
+				host host.Host,
+				store *eds.Store,
+				network modp2p.Network,
+				limiter *p2p.BandwidthLimiter,
+			) (*shrexeds.Server, error) {
 				cfg.ShrExEDSParams.WithNetworkID(network.String())
-				return shrexeds.NewServer(cfg.ShrExEDSParams, host, store)
+				return shrexeds.NewServer(cfg.ShrExEDSParams, limiter.Host(host), store)
 			},
 			fx.OnStart(func(ctx context.Context, server *shrexeds.Server) error {
 				return server.Start(ctx)
@@ -69,9 +79,10 @@ func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option
 				store *eds.Store,
 				getter *getters.StoreGetter,
 				network modp2p.Network,
+				limiter *p2p.BandwidthLimiter,
 			) (*shrexnd.Server, error) {
 				cfg.ShrExNDParams.WithNetworkID(network.String())
-				return shrexnd.NewServer(cfg.ShrExNDParams, host, store, getter)
+				return shrexnd.NewServer(cfg.ShrExNDParams, limiter.Host(host), store, getter)
 			},
 			fx.OnStart(func(ctx context.Context, server *shrexnd.Server) error {
 				return server.Start(ctx)
@@ -112,11 +123,28 @@ func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option
 		}),
 	)
 
+	storeGCComponents := fx.Options()
+	if cfg.StoreGCInterval > 0 {
+		storeGCComponents = fx.Options(
+			fx.Invoke(func(*storeGC) {}),
+			fx.Provide(fx.Annotate(
+				newStoreGC,
+				fx.OnStart(func(ctx context.Context, gc *storeGC) error {
+					return gc.Start(ctx)
+				}),
+				fx.OnStop(func(ctx context.Context, gc *storeGC) error {
+					return gc.Stop(ctx)
+				}),
+			)),
+		)
+	}
+
 	shrexGetterComponents := fx.Options(
 		fx.Provide(func() peers.Parameters {
 			return cfg.PeerManagerParams
 		}),
-		fx.Provide(peers.NewManager),
+		fx.Provide(peers.NewLatencyTracker),
+		fx.Provide(newPeerManager),
 		fx.Provide(
 			func(host host.Host, network modp2p.Network) (*shrexnd.Client, error) {
 				cfg.ShrExNDParams.WithNetworkID(network.String())
@@ -146,6 +174,7 @@ func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option
 			"share",
 			baseComponents,
 			bridgeAndFullComponents,
+			storeGCComponents,
 			fxutil.ProvideAs(func(getter *getters.StoreGetter) share.Getter {
 				return getter
 			}),
@@ -162,9 +191,12 @@ func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option
 			"share",
 			baseComponents,
 			bridgeAndFullComponents,
+			storeGCComponents,
 			shrexGetterComponents,
-			fx.Provide(getters.NewIPLDGetter),
+			fx.Provide(blockCache),
+			fx.Provide(ipldGetter),
 			fx.Provide(fullGetter),
+			fx.Provide(cacheGetter),
 		)
 	case node.Light:
 		return fx.Module(
@@ -177,8 +209,10 @@ func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option
 			}),
 			shrexGetterComponents,
 			fx.Invoke(ensureEmptyEDSInBS),
-			fx.Provide(getters.NewIPLDGetter),
+			fx.Provide(blockCache),
+			fx.Provide(ipldGetter),
 			fx.Provide(lightGetter),
+			fx.Provide(cacheGetter),
 			// shrexsub broadcaster stub for daser
 			fx.Provide(func() shrexsub.BroadcastFn {
 				return func(context.Context, shrexsub.Notification) error {
@@ -191,6 +225,6 @@ func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option
 			fx.Provide(cacheAvailability),
 		)
 	default:
-		panic("invalid node type")
+		return fx.Error(fmt.Errorf("module/share: invalid node type: %s", tp))
 	}
 }
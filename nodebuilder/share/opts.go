@@ -1,7 +1,9 @@
 package share
 
 import (
+	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/getters"
+	"github.com/celestiaorg/celestia-node/share/ipld"
 	disc "github.com/celestiaorg/celestia-node/share/p2p/discovery"
 	"github.com/celestiaorg/celestia-node/share/p2p/peers"
 	"github.com/celestiaorg/celestia-node/share/p2p/shrexeds"
@@ -38,6 +40,38 @@ func WithShrexServerMetrics(edsServer *shrexeds.Server, ndServer *shrexnd.Server
 	return ndServer.WithMetrics()
 }
 
+// WithShrexServerNamespaceMetrics returns a utility function to turn on counting of shrex/nd
+// requests served for the given namespaces, that is expected to be "invoked" by the fx lifecycle.
+func WithShrexServerNamespaceMetrics(namespaces []share.Namespace) func(*shrexnd.Server) error {
+	return func(ndServer *shrexnd.Server) error {
+		return ndServer.WithNamespaceMetrics(namespaces)
+	}
+}
+
 func WithShrexGetterMetrics(sg *getters.ShrexGetter) error {
 	return sg.WithMetrics()
 }
+
+// WithCascadeGetterMetrics turns on metrics of the getters cascaded by the node's share.Getter.
+func WithCascadeGetterMetrics(cg *getters.CascadeGetter) error {
+	return cg.WithMetrics()
+}
+
+// WithCacheGetterMetrics turns on hit and miss metrics of the node's share.Getter cache, if it is
+// enabled.
+func WithCacheGetterMetrics(getter share.Getter) error {
+	cg, ok := getter.(*getters.CacheGetter)
+	if !ok {
+		return nil
+	}
+	return cg.WithMetrics()
+}
+
+// WithBlockCacheMetrics turns on hit and miss metrics of the IPLD getter's block cache, if it is
+// enabled.
+func WithBlockCacheMetrics(cache *ipld.BlockCache) error {
+	if cache == nil {
+		return nil
+	}
+	return cache.WithMetrics()
+}
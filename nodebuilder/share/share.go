@@ -3,9 +3,13 @@ package share
 import (
 	"context"
 
+	libhead "github.com/celestiaorg/go-header"
 	"github.com/celestiaorg/rsmt2d"
 
+	"github.com/celestiaorg/celestia-node/header"
 	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds"
+	"github.com/celestiaorg/celestia-node/share/p2p/peers"
 )
 
 var _ Module = (*API)(nil)
@@ -40,6 +44,14 @@ type Module interface {
 	// GetSharesByNamespace gets all shares from an EDS within the given namespace.
 	// Shares are returned in a row-by-row order if the namespace spans multiple rows.
 	GetSharesByNamespace(ctx context.Context, root *share.Root, namespace share.Namespace) (share.NamespacedShares, error)
+	// PeerLatencies returns the observed request latency and throughput of peers serving shares and
+	// headers, fastest first.
+	PeerLatencies(context.Context) ([]peers.PeerLatency, error)
+	// StoredSquares describes the EDSes the node stores for the heights within the given inclusive
+	// range, where 'to' = 0 stands for the stored head. Ranges over MaxStoredSquaresRange heights
+	// are listed by pages, each continuing from the Next height of the previous one. Light nodes
+	// store no EDSes.
+	StoredSquares(ctx context.Context, from, to uint64) (*StoredSquaresPage, error)
 }
 
 // API is a wrapper around Module for the RPC.
@@ -62,6 +74,8 @@ type API struct {
 			root *share.Root,
 			namespace share.Namespace,
 		) (share.NamespacedShares, error) `perm:"public"`
+		PeerLatencies func(context.Context) ([]peers.PeerLatency, error)                     `perm:"admin"`
+		StoredSquares func(ctx context.Context, from, to uint64) (*StoredSquaresPage, error) `perm:"admin"`
 	}
 }
 
@@ -89,11 +103,32 @@ func (api *API) GetSharesByNamespace(
 	return api.Internal.GetSharesByNamespace(ctx, root, namespace)
 }
 
+func (api *API) PeerLatencies(ctx context.Context) ([]peers.PeerLatency, error) {
+	return api.Internal.PeerLatencies(ctx)
+}
+
+func (api *API) StoredSquares(ctx context.Context, from, to uint64) (*StoredSquaresPage, error) {
+	return api.Internal.StoredSquares(ctx, from, to)
+}
+
 type module struct {
 	share.Getter
 	share.Availability
+
+	// peerManager is nil for node types that do not fetch shares over shrex.
+	peerManager *peers.Manager
+	headers     libhead.Store[*header.ExtendedHeader]
+	// edsStore is nil for node types that do not store EDSes.
+	edsStore *eds.Store
 }
 
 func (m module) SharesAvailable(ctx context.Context, root *share.Root) error {
 	return m.Availability.SharesAvailable(ctx, root)
 }
+
+func (m module) PeerLatencies(context.Context) ([]peers.PeerLatency, error) {
+	if m.peerManager == nil {
+		return []peers.PeerLatency{}, nil
+	}
+	return m.peerManager.Latencies(), nil
+}
@@ -0,0 +1,84 @@
+package share
+
+import (
+	"context"
+	"errors"
+
+	libhead "github.com/celestiaorg/go-header"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share/eds"
+)
+
+// errNoEDSStore is returned by light nodes, which keep no EDSes.
+var errNoEDSStore = errors.New("share: node does not store EDSes")
+
+// StoredSquare describes the EDS stored for a height.
+type StoredSquare struct {
+	Height uint64 `json:"height"`
+	eds.SquareInfo
+}
+
+// MaxStoredSquaresRange is the amount of heights listed at most by a single ListStoredSquares.
+const MaxStoredSquaresRange = 1000
+
+// StoredSquaresPage is a page of the EDSes stored for a range of heights.
+type StoredSquaresPage struct {
+	Squares []StoredSquare `json:"squares"`
+	// Next is the height to continue listing the range from, or zero if the range is listed.
+	Next uint64 `json:"next,omitempty"`
+}
+
+// ListStoredSquares describes the EDSes stored for the heights within the given inclusive range.
+// The range is clamped to the stored head, and 'to' = 0 stands for the stored head. Heights without
+// a stored header or EDS are skipped. Ranges over MaxStoredSquaresRange heights are listed by
+// pages, each continuing from the Next height of the previous one.
+func ListStoredSquares(
+	ctx context.Context,
+	headers libhead.Store[*header.ExtendedHeader],
+	edsStore *eds.Store,
+	from, to uint64,
+) (*StoredSquaresPage, error) {
+	head, err := headers.Head(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if headHeight := uint64(head.Height()); to == 0 || to > headHeight {
+		to = headHeight
+	}
+	if from == 0 {
+		from = 1
+	}
+
+	page := &StoredSquaresPage{Squares: make([]StoredSquare, 0)}
+	if from <= to && to-from >= MaxStoredSquaresRange {
+		to = from + MaxStoredSquaresRange - 1
+		page.Next = to + 1
+	}
+	for height := from; height <= to; height++ {
+		h, err := headers.GetByHeight(ctx, height)
+		switch {
+		case errors.Is(err, libhead.ErrNotFound):
+			continue
+		case err != nil:
+			return nil, err
+		}
+
+		info, err := edsStore.Inspect(ctx, h.DAH.Hash())
+		switch {
+		case errors.Is(err, eds.ErrNotFound):
+			continue
+		case err != nil:
+			return nil, err
+		}
+		page.Squares = append(page.Squares, StoredSquare{Height: height, SquareInfo: *info})
+	}
+	return page, nil
+}
+
+func (m module) StoredSquares(ctx context.Context, from, to uint64) (*StoredSquaresPage, error) {
+	if m.edsStore == nil {
+		return nil, errNoEDSStore
+	}
+	return ListStoredSquares(ctx, m.headers, m.edsStore, from, to)
+}
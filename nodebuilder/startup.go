@@ -0,0 +1,232 @@
+package nodebuilder
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/fx/fxevent"
+)
+
+// startupSummarySize is the amount of the slowest phases logged once the node has started. The
+// time of all of them is reported as metrics, by the kind of the phase.
+const startupSummarySize = 15
+
+const (
+	phaseInvoke  = "invoke"
+	phaseOnStart = "on start"
+	phaseOnStop  = "on stop"
+)
+
+// startupPhase is a step of the startup, or of the shutdown, of the node along with the time it
+// took.
+type startupPhase struct {
+	kind string
+	name string
+	// module is the fx module the function was invoked from, if any.
+	module string
+	// caller is the constructor that registered the lifecycle hook, if any.
+	caller   string
+	start    time.Time
+	duration time.Duration
+	err      error
+}
+
+// startupTimer is an fxevent.Logger timing every function invoked while the node is constructed,
+// and every OnStart hook executed while the node starts, to report the slowest of them once the
+// node has started. The OnStop hooks are timed as well, but only passed to the observers.
+//
+// The fx version in use does not report the constructors it runs (fxevent.Run appears in fx v1.20),
+// which run lazily when the first function depending on them is invoked, so constructors can't be
+// timed on their own, and the time of an invoke includes the time of the constructors it depends on.
+type startupTimer struct {
+	fxevent.Logger
+
+	lock sync.Mutex
+	// phaseStart is the time the currently executed invoke or OnStart hook has started at.
+	phaseStart time.Time
+	phases     []startupPhase
+	// observers are notified of every phase once it is done.
+	observers []func(startupPhase)
+	// constructed and started are the time taken to construct and to start the node.
+	constructed, started time.Duration
+
+	metricsOnce sync.Once
+}
+
+func newStartupTimer(logger fxevent.Logger) *startupTimer {
+	return &startupTimer{Logger: logger}
+}
+
+// observe registers a function to notify of every phase once it is done. It must be called before
+// the node is constructed.
+func (t *startupTimer) observe(fn func(startupPhase)) {
+	t.observers = append(t.observers, fn)
+}
+
+// LogEvent implements fxevent.Logger.
+func (t *startupTimer) LogEvent(event fxevent.Event) {
+	t.Logger.LogEvent(event)
+
+	var phase startupPhase
+	switch e := event.(type) {
+	case *fxevent.Invoking, *fxevent.OnStartExecuting, *fxevent.OnStopExecuting:
+		t.lock.Lock()
+		t.phaseStart = time.Now()
+		t.lock.Unlock()
+		return
+	case *fxevent.Invoked:
+		t.lock.Lock()
+		start := t.phaseStart
+		t.lock.Unlock()
+		phase = startupPhase{
+			kind:     phaseInvoke,
+			name:     e.FunctionName,
+			module:   e.ModuleName,
+			start:    start,
+			duration: time.Since(start),
+			err:      e.Err,
+		}
+	case *fxevent.OnStartExecuted:
+		phase = hookPhase(phaseOnStart, e.FunctionName, e.CallerName, e.Runtime, e.Err)
+	case *fxevent.OnStopExecuted:
+		phase = hookPhase(phaseOnStop, e.FunctionName, e.CallerName, e.Runtime, e.Err)
+	default:
+		return
+	}
+
+	if phase.kind != phaseOnStop {
+		t.lock.Lock()
+		t.phases = append(t.phases, phase)
+		t.lock.Unlock()
+	}
+	for _, observer := range t.observers {
+		observer(phase)
+	}
+}
+
+// hookPhase describes a lifecycle hook which has just finished executing after the given runtime.
+func hookPhase(kind, name, caller string, runtime time.Duration, err error) startupPhase {
+	return startupPhase{
+		kind:     kind,
+		name:     name,
+		caller:   caller,
+		start:    time.Now().Add(-runtime),
+		duration: runtime,
+		err:      err,
+	}
+}
+
+// construction records the time taken to construct the node.
+func (t *startupTimer) construction(took time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.constructed = took
+}
+
+// timeStart wraps the given lifecycle func starting the node, so that the startup is reported
+// once the node has started.
+func (t *startupTimer) timeStart(start lifecycleFunc) lifecycleFunc {
+	return func(ctx context.Context) error {
+		// only the last start of a restarted node is reported
+		t.lock.Lock()
+		phases := t.phases[:0]
+		for _, p := range t.phases {
+			if p.kind != phaseOnStart {
+				phases = append(phases, p)
+			}
+		}
+		t.phases = phases
+		t.lock.Unlock()
+
+		began := time.Now()
+		if err := start(ctx); err != nil {
+			return err
+		}
+
+		t.lock.Lock()
+		t.started = time.Since(began)
+		t.lock.Unlock()
+
+		log.Info(t.summary())
+		t.metricsOnce.Do(func() {
+			if err := t.withMetrics(); err != nil {
+				log.Errorw("registering startup metrics", "err", err)
+			}
+		})
+		return nil
+	}
+}
+
+// summary describes the time taken to construct and to start the node, along with the slowest
+// phases of it.
+func (t *startupTimer) summary() string {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	phases := make([]startupPhase, len(t.phases))
+	copy(phases, t.phases)
+	sort.SliceStable(phases, func(i, j int) bool {
+		return phases[i].duration > phases[j].duration
+	})
+	if len(phases) > startupSummarySize {
+		phases = phases[:startupSummarySize]
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "node started in %s (constructed in %s, started in %s), the slowest phases:",
+		(t.constructed + t.started).Round(time.Millisecond),
+		t.constructed.Round(time.Millisecond),
+		t.started.Round(time.Millisecond),
+	)
+	for _, p := range phases {
+		fmt.Fprintf(&b, "\n  %10s  %-8s  %s", p.duration.Round(time.Millisecond), p.kind, p.name)
+		if p.caller != "" {
+			fmt.Fprintf(&b, " (registered by %s)", p.caller)
+		}
+	}
+	return b.String()
+}
+
+// withMetrics reports the time taken by the startup and the distribution of the time taken by its
+// phases. The phases are labeled by their kind only, as the names of the functions are unbounded
+// and are logged in the summary instead.
+func (t *startupTimer) withMetrics() error {
+	meter := otel.Meter("node/startup")
+	total, err := meter.Float64ObservableGauge("node_startup_seconds",
+		metric.WithDescription("time taken to construct and to start the node"))
+	if err != nil {
+		return err
+	}
+	phase, err := meter.Float64Histogram("node_startup_phase_seconds",
+		metric.WithDescription("time taken by every invoke and OnStart hook while the node starts"))
+	if err != nil {
+		return err
+	}
+
+	t.lock.Lock()
+	for _, p := range t.phases {
+		phase.Record(context.Background(), p.duration.Seconds(),
+			metric.WithAttributes(attribute.String("kind", p.kind)))
+	}
+	t.lock.Unlock()
+
+	callback := func(_ context.Context, observer metric.Observer) error {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+
+		observer.ObserveFloat64(total, t.constructed.Seconds(),
+			metric.WithAttributes(attribute.String("stage", "construct")))
+		observer.ObserveFloat64(total, t.started.Seconds(),
+			metric.WithAttributes(attribute.String("stage", "start")))
+		return nil
+	}
+	_, err = meter.RegisterCallback(callback, total)
+	return err
+}
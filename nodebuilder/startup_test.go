@@ -0,0 +1,95 @@
+package nodebuilder
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/fx/fxevent"
+)
+
+func TestStartupTimer(t *testing.T) {
+	timer := newStartupTimer(fxevent.NopLogger)
+	observed := make(map[string]int)
+	timer.observe(func(p startupPhase) {
+		observed[p.kind]++
+	})
+	timer.LogEvent(&fxevent.Invoking{FunctionName: "invokeFn"})
+	timer.LogEvent(&fxevent.Invoked{FunctionName: "invokeFn"})
+	timer.construction(time.Second)
+
+	start := timer.timeStart(func(context.Context) error {
+		for name, runtime := range map[string]time.Duration{
+			"fastHook": time.Second,
+			"slowHook": time.Minute,
+		} {
+			timer.LogEvent(&fxevent.OnStartExecuting{FunctionName: name, CallerName: "ctor"})
+			timer.LogEvent(&fxevent.OnStartExecuted{FunctionName: name, CallerName: "ctor", Runtime: runtime})
+		}
+		return nil
+	})
+	// a restarted node reports only the hooks of the last start
+	require.NoError(t, start(context.Background()))
+	require.NoError(t, start(context.Background()))
+	require.Len(t, timer.phases, 3)
+
+	// the OnStop hooks are passed to the observers only
+	timer.LogEvent(&fxevent.OnStopExecuting{FunctionName: "stopHook", CallerName: "ctor"})
+	timer.LogEvent(&fxevent.OnStopExecuted{FunctionName: "stopHook", CallerName: "ctor", Runtime: time.Second})
+	require.Len(t, timer.phases, 3)
+	assert.Equal(t, map[string]int{phaseInvoke: 1, phaseOnStart: 4, phaseOnStop: 1}, observed)
+
+	lines := strings.Split(timer.summary(), "\n")
+	require.Len(t, lines, 4)
+	assert.Contains(t, lines[0], "constructed in 1s")
+	assert.Contains(t, lines[1], "slowHook (registered by ctor)")
+	assert.Contains(t, lines[2], "fastHook")
+	assert.Contains(t, lines[3], "invokeFn")
+}
+
+func TestStartupTimer_Metrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	prev := otel.GetMeterProvider()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	t.Cleanup(func() {
+		otel.SetMeterProvider(prev)
+	})
+
+	timer := newStartupTimer(fxevent.NopLogger)
+	for _, name := range []string{"invokeA", "invokeB"} {
+		timer.LogEvent(&fxevent.Invoking{FunctionName: name})
+		timer.LogEvent(&fxevent.Invoked{FunctionName: name})
+	}
+	start := timer.timeStart(func(context.Context) error {
+		timer.LogEvent(&fxevent.OnStartExecuting{FunctionName: "hook", CallerName: "ctor"})
+		timer.LogEvent(&fxevent.OnStartExecuted{FunctionName: "hook", CallerName: "ctor", Runtime: time.Second})
+		return nil
+	})
+	require.NoError(t, start(context.Background()))
+
+	var data metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &data))
+
+	// the phases are only labeled by their kind, whatever the amount of functions
+	counts := make(map[string]uint64)
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			hist, ok := m.Data.(metricdata.Histogram[float64])
+			if !ok || m.Name != "node_startup_phase_seconds" {
+				continue
+			}
+			for _, point := range hist.DataPoints {
+				require.Equal(t, 1, point.Attributes.Len())
+				kind, _ := point.Attributes.Value("kind")
+				counts[kind.AsString()] += point.Count
+			}
+		}
+	}
+	assert.Equal(t, map[string]uint64{phaseInvoke: 2, phaseOnStart: 1}, counts)
+}
@@ -1,24 +1,119 @@
 package state
 
-import "github.com/cosmos/cosmos-sdk/crypto/keyring"
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+
+	"github.com/celestiaorg/celestia-node/state"
+)
 
 var defaultKeyringBackend = keyring.BackendTest
 
+// SignerType defines where the key signing the node's transactions is kept.
+type SignerType string
+
+const (
+	// SignerLocal signs with a key from the node's keyring.
+	SignerLocal SignerType = "local"
+	// SignerRemote delegates signing to an external service, see state.RemoteSigner.
+	SignerRemote SignerType = "remote"
+)
+
 // Config contains configuration parameters for constructing
 // the node's keyring signer.
 type Config struct {
 	KeyringAccName string
 	KeyringBackend string
+
+	// ForwardedCoreMetadata lists the gRPC metadata keys (e.g. "authorization") the callers of the
+	// node's API may forward to core with their requests by setting the key as an HTTP header
+	// prefixed with "Core-". No metadata is forwarded by default.
+	ForwardedCoreMetadata []string
+
+	// DefaultGasPrice is the price per unit of gas in utia paid by transactions submitted without a
+	// fee, e.g. blobs submitted through the blob module. Zero uses the default gas price of the
+	// network from the network registry.
+	DefaultGasPrice float64
+
+	// CoreBreaker fails the submissions of transactions fast while core is unreachable.
+	CoreBreaker CoreBreakerConfig
+
+	Signer SignerConfig
+}
+
+// CoreBreakerConfig configures when the submissions of transactions fail fast.
+type CoreBreakerConfig struct {
+	// Threshold is the amount of submissions in a row failing to reach core, after which the
+	// submissions fail fast. Zero disables failing fast.
+	Threshold int
+	// Cooldown is the time the submissions fail fast for, before core is tried again.
+	Cooldown time.Duration
+}
+
+// SignerConfig configures the signer of the node's transactions.
+type SignerConfig struct {
+	Type SignerType
+	// RemoteURL is the http(s) URL of the remote signer service. The bearer token authenticating the node
+	// to the service is read from the CELESTIA_REMOTE_SIGNER_TOKEN environment variable.
+	RemoteURL string
+	// RemoteTimeout limits the time of a single call to the remote signer service.
+	RemoteTimeout time.Duration
 }
 
 func DefaultConfig() Config {
 	return Config{
 		KeyringAccName: "",
 		KeyringBackend: defaultKeyringBackend,
+		CoreBreaker: CoreBreakerConfig{
+			Threshold: 5,
+			Cooldown:  time.Second * 30,
+		},
+		Signer: SignerConfig{
+			Type:          SignerLocal,
+			RemoteTimeout: time.Second * 10,
+		},
 	}
 }
 
 // Validate performs basic validation of the config.
 func (cfg *Config) Validate() error {
-	return nil
+	if cfg.DefaultGasPrice < 0 {
+		return fmt.Errorf("module/state: default gas price must not be negative")
+	}
+	if cfg.CoreBreaker.Threshold < 0 {
+		return fmt.Errorf("module/state: core breaker threshold must not be negative")
+	}
+	if cfg.CoreBreaker.Threshold > 0 && cfg.CoreBreaker.Cooldown <= 0 {
+		return fmt.Errorf("module/state: core breaker cooldown must be positive")
+	}
+	if err := cfg.Signer.Validate(); err != nil {
+		return err
+	}
+	return state.ValidateForwardedMetadata(cfg.ForwardedCoreMetadata)
+}
+
+// Validate performs basic validation of the signer config.
+func (cfg *SignerConfig) Validate() error {
+	switch cfg.Type {
+	case SignerLocal, "":
+		return nil
+	case SignerRemote:
+		if cfg.RemoteURL == "" {
+			return fmt.Errorf("module/state: remote signer URL must be set")
+		}
+		// the remote signer speaks HTTP only
+		u, err := url.Parse(cfg.RemoteURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("module/state: remote signer URL must be an http(s) URL, got %q", cfg.RemoteURL)
+		}
+		if cfg.RemoteTimeout <= 0 {
+			return fmt.Errorf("module/state: remote signer timeout must be positive")
+		}
+		return nil
+	default:
+		return fmt.Errorf("module/state: unknown signer type %q, must be %q or %q", cfg.Type, SignerLocal, SignerRemote)
+	}
 }
@@ -1,6 +1,10 @@
 package state
 
 import (
+	"fmt"
+	"net"
+	"os"
+
 	apptypes "github.com/celestiaorg/celestia-app/x/blob/types"
 	libfraud "github.com/celestiaorg/go-fraud"
 	"github.com/celestiaorg/go-header/sync"
@@ -8,6 +12,8 @@ import (
 	"github.com/celestiaorg/celestia-node/header"
 	"github.com/celestiaorg/celestia-node/nodebuilder/core"
 	modfraud "github.com/celestiaorg/celestia-node/nodebuilder/fraud"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
 	"github.com/celestiaorg/celestia-node/share/eds/byzantine"
 	"github.com/celestiaorg/celestia-node/state"
 )
@@ -16,15 +22,49 @@ import (
 // a celestia-core connection.
 func coreAccessor(
 	corecfg core.Config,
+	cfg Config,
 	signer *apptypes.KeyringSigner,
 	sync *sync.Syncer[*header.ExtendedHeader],
 	fraudServ libfraud.Service,
-) (*state.CoreAccessor, *modfraud.ServiceBreaker[*state.CoreAccessor]) {
-	ca := state.NewCoreAccessor(signer, sync, corecfg.IP, corecfg.RPCPort, corecfg.GRPCPort)
+	network p2p.Network,
+) (*state.CoreAccessor, *modfraud.ServiceBreaker[*state.CoreAccessor], error) {
+	gasPrice := cfg.DefaultGasPrice
+	if gasPrice == 0 {
+		var err error
+		gasPrice, err = p2p.DefaultGasPriceFor(network)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	tlsCfg, err := corecfg.GRPCTLS.Load(corecfg.IP)
+	if err != nil {
+		return nil, nil, err
+	}
+	token := os.Getenv(core.EnvGRPCToken)
+	if token != "" && tlsCfg == nil {
+		return nil, nil, fmt.Errorf("module/state: %s requires TLS of the core gRPC connection", core.EnvGRPCToken)
+	}
+
+	ca := state.NewCoreAccessor(signer, sync, corecfg.IP, corecfg.RPCPort, corecfg.GRPCPort,
+		state.WithForwardedMetadata(cfg.ForwardedCoreMetadata...),
+		state.WithDefaultGasPrice(gasPrice),
+		state.WithSubmitBreaker(cfg.CoreBreaker.Threshold, cfg.CoreBreaker.Cooldown),
+		state.WithGRPCTLS(tlsCfg),
+		state.WithGRPCAuthToken(token))
 
 	return ca, &modfraud.ServiceBreaker[*state.CoreAccessor]{
 		Service:   ca,
 		FraudType: byzantine.BadEncoding,
 		FraudServ: fraudServ,
+	}, nil
+}
+
+// stateEndpoint reports the gRPC endpoint of Core the CoreAccessor is connected to, which is not
+// failed over along with the endpoints of Bridge nodes. It is empty if no port is set.
+func stateEndpoint(corecfg core.Config) node.CoreStateEndpoint {
+	if corecfg.GRPCPort == "0" {
+		return ""
 	}
+	return node.CoreStateEndpoint(net.JoinHostPort(corecfg.IP, corecfg.GRPCPort))
 }
@@ -10,6 +10,8 @@ import (
 var (
 	keyringAccNameFlag = "keyring.accname"
 	keyringBackendFlag = "keyring.backend"
+	signerFlag         = "signer"
+	signerURLFlag      = "signer.url"
 )
 
 // Flags gives a set of hardcoded State flags.
@@ -20,6 +22,10 @@ func Flags() *flag.FlagSet {
 		"given string.")
 	flags.String(keyringBackendFlag, defaultKeyringBackend, fmt.Sprintf("Directs node's keyring signer to use the given "+
 		"backend. Default is %s.", defaultKeyringBackend))
+	flags.String(signerFlag, "", fmt.Sprintf("Directs node to sign transactions with the key in the %s "+
+		"keyring or delegate signing to a %s signer service.", SignerLocal, SignerRemote))
+	flags.String(signerURLFlag, "", "HTTP(S) URL of the remote signer service. "+
+		"The bearer token is read from "+EnvRemoteSignerToken+".")
 
 	return flags
 }
@@ -32,4 +38,11 @@ func ParseFlags(cmd *cobra.Command, cfg *Config) {
 	}
 
 	cfg.KeyringBackend = cmd.Flag(keyringBackendFlag).Value.String()
+
+	if signer := cmd.Flag(signerFlag).Value.String(); signer != "" {
+		cfg.Signer.Type = SignerType(signer)
+	}
+	if url := cmd.Flag(signerURLFlag).Value.String(); url != "" {
+		cfg.Signer.RemoteURL = url
+	}
 }
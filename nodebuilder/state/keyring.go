@@ -1,20 +1,32 @@
 package state
 
 import (
+	"context"
+	"os"
+
 	kr "github.com/cosmos/cosmos-sdk/crypto/keyring"
 
 	apptypes "github.com/celestiaorg/celestia-app/x/blob/types"
 
 	"github.com/celestiaorg/celestia-node/libs/keystore"
 	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+	"github.com/celestiaorg/celestia-node/state"
 )
 
 const DefaultAccountName = "my_celes_key"
 
+// EnvRemoteSignerToken is the environment variable the bearer token authenticating the node to
+// the remote signer service is read from.
+const EnvRemoteSignerToken = "CELESTIA_REMOTE_SIGNER_TOKEN"
+
 // KeyringSigner constructs a new keyring signer.
 // NOTE: we construct keyring signer before constructing node for easier UX
 // as having keyring-backend set to `file` prompts user for password.
 func KeyringSigner(cfg Config, ks keystore.Keystore, net p2p.Network) (*apptypes.KeyringSigner, error) {
+	if cfg.Signer.Type == SignerRemote {
+		return remoteKeyringSigner(cfg, net)
+	}
+
 	ring := ks.Keyring()
 	var info *kr.Record
 	// if custom keyringAccName provided, find key for that name
@@ -34,6 +46,10 @@ func KeyringSigner(cfg Config, ks keystore.Keystore, net p2p.Network) (*apptypes
 		}
 		info = keyInfo
 	}
+	if info.GetType() == kr.TypeLedger {
+		log.Infow("account is kept on a Ledger device, transactions have to be confirmed on the device",
+			"key name", info.Name)
+	}
 	// construct signer using the default key found / generated above
 	signer := apptypes.NewKeyringSigner(ring, info.Name, string(net))
 	signerInfo := signer.GetSignerInfo()
@@ -42,3 +58,29 @@ func KeyringSigner(cfg Config, ks keystore.Keystore, net p2p.Network) (*apptypes
 
 	return signer, nil
 }
+
+// remoteKeyringSigner constructs a keyring signer delegating signing to the remote signer service.
+func remoteKeyringSigner(cfg Config, net p2p.Network) (*apptypes.KeyringSigner, error) {
+	name := cfg.KeyringAccName
+	if name == "" {
+		name = DefaultAccountName
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Signer.RemoteTimeout)
+	defer cancel()
+	remote := state.NewRemoteSigner(cfg.Signer.RemoteURL, os.Getenv(EnvRemoteSignerToken), cfg.Signer.RemoteTimeout)
+	ring, err := state.NewSignerKeyring(ctx, name, remote)
+	if err != nil {
+		log.Errorw("could not access remote signer", "url", cfg.Signer.RemoteURL, "err", err)
+		return nil, err
+	}
+
+	signer := apptypes.NewKeyringSigner(ring, name, string(net))
+	addr, err := signer.GetSignerInfo().GetAddress()
+	if err != nil {
+		return nil, err
+	}
+	log.Infow("constructed remote signer", "url", cfg.Signer.RemoteURL, "address", addr.String(),
+		"chain-id", string(net))
+	return signer, nil
+}
@@ -131,6 +131,36 @@ func (mr *MockModuleMockRecorder) Delegate(arg0, arg1, arg2, arg3, arg4 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delegate", reflect.TypeOf((*MockModule)(nil).Delegate), arg0, arg1, arg2, arg3, arg4)
 }
 
+// EstimateFee mocks base method.
+func (m *MockModule) EstimateFee(arg0 context.Context, arg1 types1.Tx) (*state.FeeEstimate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EstimateFee", arg0, arg1)
+	ret0, _ := ret[0].(*state.FeeEstimate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EstimateFee indicates an expected call of EstimateFee.
+func (mr *MockModuleMockRecorder) EstimateFee(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateFee", reflect.TypeOf((*MockModule)(nil).EstimateFee), arg0, arg1)
+}
+
+// EstimateGas mocks base method.
+func (m *MockModule) EstimateGas(arg0 context.Context, arg1 types1.Tx) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EstimateGas", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EstimateGas indicates an expected call of EstimateGas.
+func (mr *MockModuleMockRecorder) EstimateGas(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateGas", reflect.TypeOf((*MockModule)(nil).EstimateGas), arg0, arg1)
+}
+
 // IsStopped mocks base method.
 func (m *MockModule) IsStopped(arg0 context.Context) bool {
 	m.ctrl.T.Helper()
@@ -205,6 +235,21 @@ func (mr *MockModuleMockRecorder) SubmitPayForBlob(arg0, arg1, arg2, arg3 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitPayForBlob", reflect.TypeOf((*MockModule)(nil).SubmitPayForBlob), arg0, arg1, arg2, arg3)
 }
 
+// SubmitRawTx mocks base method.
+func (m *MockModule) SubmitRawTx(arg0 context.Context, arg1 types1.Tx) (*types.TxResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitRawTx", arg0, arg1)
+	ret0, _ := ret[0].(*types.TxResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitRawTx indicates an expected call of SubmitRawTx.
+func (mr *MockModuleMockRecorder) SubmitRawTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitRawTx", reflect.TypeOf((*MockModule)(nil).SubmitRawTx), arg0, arg1)
+}
+
 // SubmitTx mocks base method.
 func (m *MockModule) SubmitTx(arg0 context.Context, arg1 types1.Tx) (*types.TxResponse, error) {
 	m.ctrl.T.Helper()
@@ -220,6 +265,21 @@ func (mr *MockModuleMockRecorder) SubmitTx(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitTx", reflect.TypeOf((*MockModule)(nil).SubmitTx), arg0, arg1)
 }
 
+// SubscribeTxStatus mocks base method.
+func (m *MockModule) SubscribeTxStatus(arg0 context.Context, arg1 string) (<-chan *state.TxStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeTxStatus", arg0, arg1)
+	ret0, _ := ret[0].(<-chan *state.TxStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeTxStatus indicates an expected call of SubscribeTxStatus.
+func (mr *MockModuleMockRecorder) SubscribeTxStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeTxStatus", reflect.TypeOf((*MockModule)(nil).SubscribeTxStatus), arg0, arg1)
+}
+
 // Transfer mocks base method.
 func (m *MockModule) Transfer(arg0 context.Context, arg1 types.AccAddress, arg2, arg3 math.Int, arg4 uint64) (*types.TxResponse, error) {
 	m.ctrl.T.Helper()
@@ -235,6 +295,21 @@ func (mr *MockModuleMockRecorder) Transfer(arg0, arg1, arg2, arg3, arg4 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfer", reflect.TypeOf((*MockModule)(nil).Transfer), arg0, arg1, arg2, arg3, arg4)
 }
 
+// TxStatus mocks base method.
+func (m *MockModule) TxStatus(arg0 context.Context, arg1 string) (*state.TxStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TxStatus", arg0, arg1)
+	ret0, _ := ret[0].(*state.TxStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TxStatus indicates an expected call of TxStatus.
+func (mr *MockModuleMockRecorder) TxStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TxStatus", reflect.TypeOf((*MockModule)(nil).TxStatus), arg0, arg1)
+}
+
 // Undelegate mocks base method.
 func (m *MockModule) Undelegate(arg0 context.Context, arg1 types.ValAddress, arg2, arg3 math.Int, arg4 uint64) (*types.TxResponse, error) {
 	m.ctrl.T.Helper()
@@ -2,6 +2,7 @@ package state
 
 import (
 	"context"
+	"fmt"
 
 	logging "github.com/ipfs/go-log/v2"
 	"go.uber.org/fx"
@@ -35,6 +36,7 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 		fx.Provide(func(ca *state.CoreAccessor) Module {
 			return ca
 		}),
+		fx.Provide(stateEndpoint),
 	)
 
 	switch tp {
@@ -44,6 +46,6 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 			baseComponents,
 		)
 	default:
-		panic("invalid node type")
+		return fx.Error(fmt.Errorf("module/state: invalid node type: %s", tp))
 	}
 }
@@ -42,7 +42,23 @@ type Module interface {
 	// Celestia network and blocks until the tx is included in
 	// a block.
 	SubmitTx(ctx context.Context, tx state.Tx) (*state.TxResponse, error)
-	// SubmitPayForBlob builds, signs and submits a PayForBlob transaction.
+	// SubmitRawTx broadcasts an externally constructed and signed PayForBlob transaction. It
+	// returns once the transaction is accepted into the mempool, without waiting for inclusion.
+	SubmitRawTx(ctx context.Context, tx state.Tx) (*state.TxResponse, error)
+	// TxStatus reports whether the transaction with the given hash is pending, committed or
+	// failed.
+	TxStatus(ctx context.Context, hash string) (*state.TxStatus, error)
+	// SubscribeTxStatus streams the status of the transaction with the given hash, starting with
+	// its current status. The stream ends once the transaction is committed or failed.
+	SubscribeTxStatus(ctx context.Context, hash string) (<-chan *state.TxStatus, error)
+	// EstimateGas simulates the given signed transaction against core and returns the amount of
+	// gas it uses.
+	EstimateGas(ctx context.Context, tx state.Tx) (uint64, error)
+	// EstimateFee simulates the given signed transaction against core and returns the recommended
+	// gas limit and gas price to submit it with.
+	EstimateFee(ctx context.Context, tx state.Tx) (*state.FeeEstimate, error)
+	// SubmitPayForBlob builds, signs and submits a PayForBlob transaction. A zero gas limit is
+	// replaced by an estimate, and a zero fee by the fee of the gas limit at the default gas price.
 	SubmitPayForBlob(
 		ctx context.Context,
 		fee state.Int,
@@ -110,8 +126,13 @@ type API struct {
 			fee state.Int,
 			gasLimit uint64,
 		) (*state.TxResponse, error) `perm:"write"`
-		SubmitTx         func(ctx context.Context, tx state.Tx) (*state.TxResponse, error) `perm:"write"`
-		SubmitPayForBlob func(
+		SubmitTx          func(ctx context.Context, tx state.Tx) (*state.TxResponse, error)      `perm:"write"`
+		SubmitRawTx       func(ctx context.Context, tx state.Tx) (*state.TxResponse, error)      `perm:"write"`
+		TxStatus          func(ctx context.Context, hash string) (*state.TxStatus, error)        `perm:"read"`
+		SubscribeTxStatus func(ctx context.Context, hash string) (<-chan *state.TxStatus, error) `perm:"read"`
+		EstimateGas       func(ctx context.Context, tx state.Tx) (uint64, error)                 `perm:"read"`
+		EstimateFee       func(ctx context.Context, tx state.Tx) (*state.FeeEstimate, error)     `perm:"read"`
+		SubmitPayForBlob  func(
 			ctx context.Context,
 			fee state.Int,
 			gasLim uint64,
@@ -189,6 +210,26 @@ func (api *API) SubmitTx(ctx context.Context, tx state.Tx) (*state.TxResponse, e
 	return api.Internal.SubmitTx(ctx, tx)
 }
 
+func (api *API) SubmitRawTx(ctx context.Context, tx state.Tx) (*state.TxResponse, error) {
+	return api.Internal.SubmitRawTx(ctx, tx)
+}
+
+func (api *API) TxStatus(ctx context.Context, hash string) (*state.TxStatus, error) {
+	return api.Internal.TxStatus(ctx, hash)
+}
+
+func (api *API) SubscribeTxStatus(ctx context.Context, hash string) (<-chan *state.TxStatus, error) {
+	return api.Internal.SubscribeTxStatus(ctx, hash)
+}
+
+func (api *API) EstimateGas(ctx context.Context, tx state.Tx) (uint64, error) {
+	return api.Internal.EstimateGas(ctx, tx)
+}
+
+func (api *API) EstimateFee(ctx context.Context, tx state.Tx) (*state.FeeEstimate, error) {
+	return api.Internal.EstimateFee(ctx, tx)
+}
+
 func (api *API) SubmitPayForBlob(
 	ctx context.Context,
 	fee state.Int,
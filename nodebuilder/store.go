@@ -12,6 +12,7 @@ import (
 	dsbadger "github.com/ipfs/go-ds-badger2"
 	"github.com/mitchellh/go-homedir"
 
+	"github.com/celestiaorg/celestia-node/libs/encds"
 	"github.com/celestiaorg/celestia-node/libs/fslock"
 	"github.com/celestiaorg/celestia-node/libs/keystore"
 )
@@ -21,6 +22,8 @@ var (
 	ErrOpened = errors.New("node: store is in use")
 	// ErrNotInited is thrown on attempt to open Store without initialization.
 	ErrNotInited = errors.New("node: store is not initialized")
+	// ErrReadOnly is thrown on attempt to alter Store opened with OpenStoreReadOnly.
+	ErrReadOnly = errors.New("node: store is read-only")
 )
 
 // Store encapsulates storage for the Node. Basically, it is the Store of all Stores.
@@ -50,6 +53,17 @@ type Store interface {
 // OpenStore takes a file Lock on directory, hence only one Store can be opened at a time under the
 // given 'path', otherwise ErrOpened is thrown.
 func OpenStore(path string, ring keyring.Keyring) (Store, error) {
+	return openStore(path, ring, false)
+}
+
+// OpenStoreReadOnly opens the Store under the given 'path' like OpenStore, but guarantees the
+// Datastore and Config are never modified, e.g. to inspect a damaged Store without making it worse.
+// Unlike OpenStore, corrupted Datastore data is not truncated, so opening may fail instead.
+func OpenStoreReadOnly(path string, ring keyring.Keyring) (Store, error) {
+	return openStore(path, ring, true)
+}
+
+func openStore(path string, ring keyring.Keyring, readOnly bool) (Store, error) {
 	path, err := storePath(path)
 	if err != nil {
 		return nil, err
@@ -74,10 +88,37 @@ func OpenStore(path string, ring keyring.Keyring) (Store, error) {
 		return nil, err
 	}
 
+	key, err := storeKey(path)
+	if err != nil {
+		flock.Unlock() //nolint: errcheck
+		return nil, err
+	}
+	if key != nil {
+		ks, err = newEncryptedKeystore(ks, key, readOnly)
+		if err != nil {
+			flock.Unlock() //nolint: errcheck
+			return nil, err
+		}
+	}
+
+	// read-only Stores are left as is, so their interrupted migrations are not finished
+	openBackend := openStoreBackend
+	if readOnly {
+		openBackend = storeBackend
+	}
+	backend, err := openBackend(path)
+	if err != nil {
+		flock.Unlock() //nolint: errcheck
+		return nil, err
+	}
+
 	return &fsStore{
-		path:    path,
-		dirLock: flock,
-		keys:    ks,
+		path:     path,
+		readOnly: readOnly,
+		dirLock:  flock,
+		keys:     ks,
+		backend:  backend,
+		dataKey:  key,
 	}, nil
 }
 
@@ -90,11 +131,23 @@ func (f *fsStore) Config() (*Config, error) {
 	if err != nil {
 		return nil, fmt.Errorf("node: can't load Config: %w", err)
 	}
-
+	if f.dataKey != nil {
+		return openConfig(cfg, f.dataKey)
+	}
 	return cfg, nil
 }
 
 func (f *fsStore) PutConfig(cfg *Config) error {
+	if f.readOnly {
+		return ErrReadOnly
+	}
+
+	if f.dataKey != nil {
+		var err error
+		if cfg, err = sealConfig(cfg, f.dataKey); err != nil {
+			return err
+		}
+	}
 	err := SaveConfig(configPath(f.path), cfg)
 	if err != nil {
 		return fmt.Errorf("node: can't save Config: %w", err)
@@ -117,6 +170,23 @@ func (f *fsStore) Datastore() (datastore.Batching, error) {
 		return f.data, nil
 	}
 
+	ds, err := openDatastore(dataPath(f.path), f.backend, f.readOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	f.data = ds
+	if f.dataKey != nil {
+		f.data, err = encds.Wrap(ds, f.dataKey)
+		if err != nil {
+			return nil, errors.Join(err, ds.Close())
+		}
+	}
+	return f.data, nil
+}
+
+// openBadger opens the Badger Datastore under the given path.
+func openBadger(path string, readOnly bool) (datastore.Batching, error) {
 	opts := dsbadger.DefaultOptions // this should be copied
 
 	// Badger sets ValueThreshold to 1K by default and this makes shares being stored in LSM tree
@@ -137,13 +207,17 @@ func (f *fsStore) Datastore() (datastore.Batching, error) {
 	// Bigger values constantly takes more RAM
 	// TODO(@Wondertan): Make configurable with more conservative defaults for Light Node
 	opts.MaxTableSize = 64 << 20
+	if readOnly {
+		opts.ReadOnly = true
+		opts.Truncate = false
+		// garbage collection rewrites the value log
+		opts.GcInterval = 0
+	}
 
-	ds, err := dsbadger.NewDatastore(dataPath(f.path), &opts)
+	ds, err := dsbadger.NewDatastore(path, &opts)
 	if err != nil {
 		return nil, fmt.Errorf("node: can't open Badger Datastore: %w", err)
 	}
-
-	f.data = ds
 	return ds, nil
 }
 
@@ -158,12 +232,18 @@ func (f *fsStore) Close() (err error) {
 }
 
 type fsStore struct {
-	path string
+	path     string
+	readOnly bool
 
 	dataMu  sync.Mutex
 	data    datastore.Batching
 	keys    keystore.Keystore
 	dirLock *fslock.Locker // protects directory
+
+	// backend is the engine the Datastore is kept in
+	backend StoreBackend
+	// dataKey encrypts the Datastore, if the Store is encrypted
+	dataKey []byte
 }
 
 func storePath(path string) (string, error) {
@@ -0,0 +1,358 @@
+package nodebuilder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	dspebble "github.com/ipfs/go-ds-pebble"
+
+	"github.com/celestiaorg/celestia-node/libs/fslock"
+	"github.com/celestiaorg/celestia-node/libs/utils"
+)
+
+// StoreBackend is the key-value engine the Datastore of a Store is kept in.
+type StoreBackend string
+
+const (
+	// StoreBackendBadger keeps the Datastore in Badger. It is the default, and the backend of every
+	// Store initialized before the backend became selectable.
+	StoreBackendBadger StoreBackend = "badger"
+	// StoreBackendPebble keeps the Datastore in Pebble, which compacts in the background without
+	// stalling writes, as Badger does on large stores.
+	StoreBackendPebble StoreBackend = "pebble"
+)
+
+// migrateBatchSize is the amount of entries copied at once by MigrateStoreBackend.
+const migrateBatchSize = 4096
+
+// ParseStoreBackend parses the StoreBackend from its name.
+func ParseStoreBackend(name string) (StoreBackend, error) {
+	switch backend := StoreBackend(name); backend {
+	case StoreBackendBadger, StoreBackendPebble:
+		return backend, nil
+	default:
+		return "", fmt.Errorf("node: unknown store backend %q, must be one of %s, %s",
+			name, StoreBackendBadger, StoreBackendPebble)
+	}
+}
+
+// storeDatastore is the backend of the Datastore of a Store, persisted in its root.
+type storeDatastore struct {
+	Backend StoreBackend `json:"backend"`
+	// MigratingTo is the backend the Datastore is being migrated to, set once the Datastore is fully
+	// copied into it. The migration is finished from there on if interrupted.
+	MigratingTo StoreBackend `json:"migrating_to,omitempty"`
+}
+
+// SetStoreBackend selects the backend of the Datastore of a freshly initialized Store under the
+// given path. Stores with existing data have to be migrated with MigrateStoreBackend instead.
+func SetStoreBackend(path string, backend StoreBackend) error {
+	path, err := storePath(path)
+	if err != nil {
+		return err
+	}
+	if _, err = ParseStoreBackend(string(backend)); err != nil {
+		return err
+	}
+	if !isEmptyDir(dataPath(path)) {
+		return errors.New("node: can't change store backend with existing data, migrate it instead")
+	}
+	return writeStoreBackend(path, backend)
+}
+
+// MigrateStoreBackend copies the Datastore of the Store under the given path into the backend
+// 'to' and swaps it with the original one, which is removed afterwards. The Store must not be
+// opened, and needs enough free disk space to hold both copies of the Datastore while migrating.
+// The stored entries are copied as is, so encrypted Stores do not require the passphrase.
+//
+// The migration is resumable: if interrupted while copying, the copy is discarded and the original
+// Datastore is kept, while once copied, the swap is finished by the next migration or the next time
+// the Store is opened.
+func MigrateStoreBackend(ctx context.Context, path string, to StoreBackend) error {
+	path, err := storePath(path)
+	if err != nil {
+		return err
+	}
+	if _, err = ParseStoreBackend(string(to)); err != nil {
+		return err
+	}
+
+	flock, err := fslock.Lock(lockPath(path))
+	if err != nil {
+		if err == fslock.ErrLocked {
+			return ErrOpened
+		}
+		return err
+	}
+	defer flock.Unlock() //nolint: errcheck
+
+	if !IsInit(path) {
+		return ErrNotInited
+	}
+	sd, err := readStoreDatastore(path)
+	if err != nil {
+		return err
+	}
+	if sd.MigratingTo != "" {
+		log.Infow("finishing interrupted store backend migration", "from", sd.Backend, "to", sd.MigratingTo)
+		if sd, err = finishMigration(path, sd); err != nil {
+			return err
+		}
+		if sd.Backend == to {
+			return nil
+		}
+	}
+	from := sd.Backend
+	if from == to {
+		return fmt.Errorf("node: store backend is already %s", to)
+	}
+
+	// leftovers of a migration interrupted while copying are discarded, as the original is kept
+	migrating := migratingPath(path)
+	if err = os.RemoveAll(migrating); err != nil {
+		return err
+	}
+	n, err := copyDatastore(ctx, dataPath(path), from, migrating, to)
+	if err != nil {
+		return errors.Join(err, os.RemoveAll(migrating))
+	}
+	// from here on, the migration is finished even if interrupted
+	sd.MigratingTo = to
+	if err = writeStoreDatastore(path, sd); err != nil {
+		return errors.Join(err, os.RemoveAll(migrating))
+	}
+	if _, err = finishMigration(path, sd); err != nil {
+		return err
+	}
+	log.Infow("migrated store backend", "from", from, "to", to, "entries", n)
+	return nil
+}
+
+// finishMigration swaps the original Datastore with its copy in the backend the Store is migrating
+// to, and removes the original. Each step is either atomic or skipped if already done, so it
+// can be repeated until it succeeds.
+func finishMigration(path string, sd *storeDatastore) (*storeDatastore, error) {
+	migrating, old := migratingPath(path), dataPath(path)+"."+string(sd.Backend)
+	if utils.Exists(migrating) {
+		// the original is still in place unless the previous attempt was interrupted after moving it
+		if utils.Exists(dataPath(path)) {
+			if err := os.Rename(dataPath(path), old); err != nil {
+				return nil, err
+			}
+		}
+		if err := os.Rename(migrating, dataPath(path)); err != nil {
+			return nil, err
+		}
+		if err := syncDir(path); err != nil {
+			return nil, err
+		}
+	}
+
+	finished := &storeDatastore{Backend: sd.MigratingTo}
+	if err := writeStoreDatastore(path, finished); err != nil {
+		return nil, err
+	}
+	return finished, os.RemoveAll(old)
+}
+
+// copyDatastore copies every entry of the Datastore under 'src' into a new Datastore under 'dst'
+// and returns the amount of copied entries.
+func copyDatastore(
+	ctx context.Context,
+	src string,
+	srcBackend StoreBackend,
+	dst string,
+	dstBackend StoreBackend,
+) (n int, err error) {
+	from, err := openDatastore(src, srcBackend, true)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		err = errors.Join(err, from.Close())
+	}()
+	to, err := openDatastore(dst, dstBackend, false)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		err = errors.Join(err, to.Close())
+	}()
+
+	results, err := from.Query(ctx, query.Query{})
+	if err != nil {
+		return 0, err
+	}
+	defer results.Close()
+
+	batch, err := to.Batch(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for entry := range results.Next() {
+		if entry.Error != nil {
+			return n, entry.Error
+		}
+		if err = batch.Put(ctx, datastore.RawKey(entry.Key), entry.Value); err != nil {
+			return n, err
+		}
+		n++
+		if n%migrateBatchSize != 0 {
+			continue
+		}
+		if err = batch.Commit(ctx); err != nil {
+			return n, err
+		}
+		if batch, err = to.Batch(ctx); err != nil {
+			return n, err
+		}
+	}
+	if err = batch.Commit(ctx); err != nil {
+		return n, err
+	}
+	return n, to.Sync(ctx, datastore.NewKey("/"))
+}
+
+// openDatastore opens the Datastore under the given path in the given backend.
+func openDatastore(path string, backend StoreBackend, readOnly bool) (datastore.Batching, error) {
+	switch backend {
+	case StoreBackendBadger:
+		return openBadger(path, readOnly)
+	case StoreBackendPebble:
+		return openPebble(path, readOnly)
+	default:
+		return nil, fmt.Errorf("node: unknown store backend %q", backend)
+	}
+}
+
+// openPebble opens the Pebble Datastore under the given path.
+func openPebble(path string, readOnly bool) (datastore.Batching, error) {
+	opts := &pebble.Options{}
+	opts.EnsureDefaults()
+	// the Datastore overrides the Split of the Comparer, so the global default must not be shared
+	comparer := *pebble.DefaultComparer
+	opts.Comparer = &comparer
+	// MemTableSize matches the MaxTableSize of Badger
+	opts.MemTableSize = 64 << 20
+	opts.ReadOnly = readOnly
+	if readOnly {
+		opts.ErrorIfNotExists = true
+	}
+
+	ds, err := dspebble.NewDatastore(path, opts)
+	if err != nil {
+		return nil, fmt.Errorf("node: can't open Pebble Datastore: %w", err)
+	}
+	return ds, nil
+}
+
+// storeBackend reads the backend of the Store under the given path. Stores without the backend
+// persisted predate it and are kept in Badger.
+func storeBackend(path string) (StoreBackend, error) {
+	sd, err := readStoreDatastore(path)
+	if err != nil {
+		return "", err
+	}
+	if sd.MigratingTo != "" {
+		return "", fmt.Errorf("node: store backend migration to %s is not finished", sd.MigratingTo)
+	}
+	return sd.Backend, nil
+}
+
+// openStoreBackend reads the backend of the Store under the given path, which is locked, finishing
+// its migration if interrupted.
+func openStoreBackend(path string) (StoreBackend, error) {
+	sd, err := readStoreDatastore(path)
+	if err != nil {
+		return "", err
+	}
+	if sd.MigratingTo != "" {
+		log.Infow("finishing interrupted store backend migration", "from", sd.Backend, "to", sd.MigratingTo)
+		if sd, err = finishMigration(path, sd); err != nil {
+			return "", fmt.Errorf("node: finishing store backend migration: %w", err)
+		}
+	}
+	return sd.Backend, nil
+}
+
+func readStoreDatastore(path string) (*storeDatastore, error) {
+	raw, err := os.ReadFile(backendPath(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return &storeDatastore{Backend: StoreBackendBadger}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	sd := &storeDatastore{}
+	if err = json.Unmarshal(raw, sd); err != nil {
+		return nil, fmt.Errorf("node: decoding store backend: %w", err)
+	}
+	if _, err = ParseStoreBackend(string(sd.Backend)); err != nil {
+		return nil, err
+	}
+	if sd.MigratingTo != "" {
+		if _, err = ParseStoreBackend(string(sd.MigratingTo)); err != nil {
+			return nil, err
+		}
+	}
+	return sd, nil
+}
+
+func writeStoreBackend(path string, backend StoreBackend) error {
+	if backend == StoreBackendBadger && !utils.Exists(backendPath(path)) {
+		return nil
+	}
+	return writeStoreDatastore(path, &storeDatastore{Backend: backend})
+}
+
+// writeStoreDatastore replaces the persisted backend atomically, so that it is never left partially
+// written.
+func writeStoreDatastore(path string, sd *storeDatastore) error {
+	raw, err := json.MarshalIndent(sd, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(path, filepath.Base(backendPath(path))+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) //nolint: errcheck
+	if _, err = tmp.Write(raw); err != nil {
+		return errors.Join(err, tmp.Close())
+	}
+	if err = tmp.Sync(); err != nil {
+		return errors.Join(err, tmp.Close())
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp.Name(), backendPath(path)); err != nil {
+		return err
+	}
+	return syncDir(path)
+}
+
+// syncDir flushes the entries of the directory, e.g. the files renamed in it, to disk.
+func syncDir(path string) error {
+	dir, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	return errors.Join(dir.Sync(), dir.Close())
+}
+
+func backendPath(base string) string {
+	return filepath.Join(base, "datastore.json")
+}
+
+func migratingPath(base string) string {
+	return dataPath(base) + ".migrating"
+}
@@ -0,0 +1,84 @@
+package nodebuilder
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+func TestStoreBackend(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	cfg, err := DefaultConfig(node.Bridge)
+	require.NoError(t, err)
+	require.NoError(t, Init(*cfg, dir, node.Bridge))
+	require.NoError(t, SetStoreBackend(dir, StoreBackendPebble))
+
+	s, err := OpenStore(dir, nil)
+	require.NoError(t, err)
+	ds, err := s.Datastore()
+	require.NoError(t, err)
+	const entries = migrateBatchSize + 10
+	for i := 0; i < entries; i++ {
+		require.NoError(t, ds.Put(ctx, datastore.NewKey(fmt.Sprint(i)), []byte(fmt.Sprint("value", i))))
+	}
+
+	// the store must not be opened
+	assert.ErrorIs(t, MigrateStoreBackend(ctx, dir, StoreBackendBadger), ErrOpened)
+	require.NoError(t, s.Close())
+
+	// stores with data have to be migrated
+	assert.Error(t, SetStoreBackend(dir, StoreBackendBadger))
+	assert.Error(t, MigrateStoreBackend(ctx, dir, StoreBackendPebble))
+
+	for _, backend := range []StoreBackend{StoreBackendBadger, StoreBackendPebble} {
+		require.NoError(t, MigrateStoreBackend(ctx, dir, backend))
+		got, err := storeBackend(dir)
+		require.NoError(t, err)
+		assert.Equal(t, backend, got)
+
+		s, err = OpenStore(dir, nil)
+		require.NoError(t, err)
+		ds, err = s.Datastore()
+		require.NoError(t, err)
+		for i := 0; i < entries; i++ {
+			value, err := ds.Get(ctx, datastore.NewKey(fmt.Sprint(i)))
+			require.NoError(t, err)
+			assert.Equal(t, []byte(fmt.Sprint("value", i)), value)
+		}
+		require.NoError(t, s.Close())
+	}
+
+	// a migration interrupted once the Datastore is copied is finished on the next open
+	_, err = copyDatastore(ctx, dataPath(dir), StoreBackendPebble, migratingPath(dir), StoreBackendBadger)
+	require.NoError(t, err)
+	sd := &storeDatastore{Backend: StoreBackendPebble, MigratingTo: StoreBackendBadger}
+	require.NoError(t, writeStoreDatastore(dir, sd))
+	require.NoError(t, os.Rename(dataPath(dir), dataPath(dir)+"."+string(StoreBackendPebble)))
+	_, err = OpenStoreReadOnly(dir, nil)
+	require.Error(t, err)
+
+	s, err = OpenStore(dir, nil)
+	require.NoError(t, err)
+	got, err := storeBackend(dir)
+	require.NoError(t, err)
+	assert.Equal(t, StoreBackendBadger, got)
+	assert.NoDirExists(t, migratingPath(dir))
+	assert.NoDirExists(t, dataPath(dir)+"."+string(StoreBackendPebble))
+	ds, err = s.Datastore()
+	require.NoError(t, err)
+	value, err := ds.Get(ctx, datastore.NewKey(fmt.Sprint(entries-1)))
+	require.NoError(t, err)
+	assert.Equal(t, []byte(fmt.Sprint("value", entries-1)), value)
+	require.NoError(t, s.Close())
+
+	_, err = ParseStoreBackend("leveldb")
+	assert.Error(t, err)
+}
@@ -0,0 +1,316 @@
+package nodebuilder
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+
+	"github.com/celestiaorg/celestia-node/libs/encds"
+	"github.com/celestiaorg/celestia-node/libs/keystore"
+	"github.com/celestiaorg/celestia-node/libs/utils"
+)
+
+// EnvStorePassphrase is the environment variable the passphrase of an encrypted Store is read
+// from when the Store is opened.
+const EnvStorePassphrase = "CELESTIA_NODE_STORE_PASSPHRASE"
+
+const kdfArgon2id = "argon2id"
+
+// encryptionCheck is encrypted with the derived key to tell a wrong passphrase on open apart from
+// corrupted data.
+var encryptionCheck = []byte("celestia-node store encryption")
+
+var (
+	// ErrEncrypted is thrown on attempt to open an encrypted Store without a passphrase.
+	ErrEncrypted = errors.New("node: store is encrypted, set " + EnvStorePassphrase)
+	// ErrPassphrase is thrown on attempt to open an encrypted Store with a wrong passphrase.
+	ErrPassphrase = errors.New("node: wrong store passphrase")
+)
+
+// storeEncryption is the key derivation scheme of an encrypted Store, persisted in its root.
+type storeEncryption struct {
+	KDF     string `json:"kdf"`
+	Salt    []byte `json:"salt"`
+	Time    uint32 `json:"time"`
+	Memory  uint32 `json:"memory"`
+	Threads uint8  `json:"threads"`
+	// Check is encryptionCheck encrypted with the derived key.
+	Check []byte `json:"check"`
+}
+
+// EncryptStore turns on encryption at rest of the Datastore of a freshly initialized Store under
+// the given path, along with the keys of the Keystore and the secrets of the config, e.g. the gateway
+// tokens. The encryption key is derived from the passphrase with Argon2id, so the same passphrase has
+// to be provided through EnvStorePassphrase whenever the Store is opened. The account keys are
+// protected by the keyring backend, and the EDS files are kept in plaintext, as they hold public
+// chain data.
+func EncryptStore(path string, passphrase []byte) error {
+	path, err := storePath(path)
+	if err != nil {
+		return err
+	}
+	if len(passphrase) == 0 {
+		return errors.New("node: store passphrase must not be empty")
+	}
+	if utils.Exists(encryptionPath(path)) {
+		return errors.New("node: store is already encrypted")
+	}
+	if !isEmptyDir(dataPath(path)) {
+		return errors.New("node: can't encrypt store with existing data, reset it first")
+	}
+
+	enc := &storeEncryption{
+		KDF:     kdfArgon2id,
+		Salt:    make([]byte, 16),
+		Time:    3,
+		Memory:  64 << 10,
+		Threads: 4,
+	}
+	if _, err = rand.Read(enc.Salt); err != nil {
+		return err
+	}
+	key := enc.deriveKey(passphrase)
+	aead, err := encds.NewAEAD(key)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return err
+	}
+	enc.Check = aead.Seal(nonce, nonce, encryptionCheck, nil)
+
+	raw, err := json.MarshalIndent(enc, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(encryptionPath(path), raw, 0600)
+	if err != nil {
+		return err
+	}
+
+	// the secrets are written in plaintext by Init, and stay readable if sealing them fails
+	cfg, err := LoadConfig(configPath(path))
+	if err != nil {
+		return err
+	}
+	if cfg, err = sealConfig(cfg, key); err != nil {
+		return err
+	}
+	if err = SaveConfig(configPath(path), cfg); err != nil {
+		return err
+	}
+	log.Info("Node Store encrypted")
+	return nil
+}
+
+// storeKey derives the key of the Store under the given path from the passphrase in
+// EnvStorePassphrase. It returns nil if the Store is not encrypted.
+func storeKey(path string) ([]byte, error) {
+	raw, err := os.ReadFile(encryptionPath(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	enc := &storeEncryption{}
+	if err = json.Unmarshal(raw, enc); err != nil {
+		return nil, fmt.Errorf("node: decoding store encryption: %w", err)
+	}
+	if enc.KDF != kdfArgon2id {
+		return nil, fmt.Errorf("node: unknown store key derivation function %s", enc.KDF)
+	}
+
+	passphrase := os.Getenv(EnvStorePassphrase)
+	if passphrase == "" {
+		return nil, ErrEncrypted
+	}
+	key := enc.deriveKey([]byte(passphrase))
+	aead, err := encds.NewAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(enc.Check) < aead.NonceSize() {
+		return nil, errors.New("node: store encryption check is corrupted")
+	}
+	nonce, check := enc.Check[:aead.NonceSize()], enc.Check[aead.NonceSize():]
+	if _, err = aead.Open(nil, nonce, check, nil); err != nil {
+		return nil, ErrPassphrase
+	}
+	return key, nil
+}
+
+func (e *storeEncryption) deriveKey(passphrase []byte) []byte {
+	return argon2.IDKey(passphrase, e.Salt, e.Time, e.Memory, e.Threads, encds.KeySize)
+}
+
+func encryptionPath(base string) string {
+	return filepath.Join(base, "encryption.json")
+}
+
+// sealedPrefix marks the config secrets and the keys sealed with the key of the Store.
+const sealedPrefix = "encrypted:"
+
+// configSecrets returns the secrets of the Config, sealed at rest in encrypted Stores: the gateway
+// tokens and the URLs which often embed credentials.
+func configSecrets(cfg *Config) []*string {
+	secrets := []*string{&cfg.Header.TrustedHeadURL, &cfg.Watcher.WebhookURL, &cfg.State.Signer.RemoteURL}
+	for i := range cfg.Gateway.Auth.Tokens {
+		secrets = append(secrets, &cfg.Gateway.Auth.Tokens[i])
+	}
+	return secrets
+}
+
+// sealConfig returns a copy of the Config with its secrets sealed with the key.
+func sealConfig(cfg *Config, key []byte) (*Config, error) {
+	aead, err := encds.NewAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	out := *cfg
+	out.Gateway.Auth.Tokens = append([]string(nil), cfg.Gateway.Auth.Tokens...)
+	for _, secret := range configSecrets(&out) {
+		if *secret == "" || strings.HasPrefix(*secret, sealedPrefix) {
+			continue
+		}
+		sealed, err := seal(aead, []byte(*secret), nil)
+		if err != nil {
+			return nil, err
+		}
+		*secret = sealedPrefix + base64.StdEncoding.EncodeToString(sealed)
+	}
+	return &out, nil
+}
+
+// openConfig returns a copy of the Config with its secrets opened with the key. Secrets kept in
+// plaintext, e.g. edited by hand, are returned as is and sealed on the next save.
+func openConfig(cfg *Config, key []byte) (*Config, error) {
+	aead, err := encds.NewAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	out := *cfg
+	out.Gateway.Auth.Tokens = append([]string(nil), cfg.Gateway.Auth.Tokens...)
+	for _, secret := range configSecrets(&out) {
+		encoded, ok := strings.CutPrefix(*secret, sealedPrefix)
+		if !ok {
+			continue
+		}
+		sealed, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("node: decoding config secret: %w", err)
+		}
+		plain, err := open(aead, sealed, nil)
+		if err != nil {
+			return nil, fmt.Errorf("node: decrypting config secret: %w", err)
+		}
+		*secret = string(plain)
+	}
+	return &out, nil
+}
+
+// openStoreConfig opens the secrets of the Config for the Store, if it is encrypted.
+func openStoreConfig(store Store, cfg *Config) (*Config, error) {
+	fs, ok := store.(*fsStore)
+	if !ok || fs.dataKey == nil {
+		return cfg, nil
+	}
+	return openConfig(cfg, fs.dataKey)
+}
+
+// encryptedKeystore seals the keys of the wrapped Keystore with the key of the Store. The keys put
+// before the Store was encrypted are sealed once read.
+type encryptedKeystore struct {
+	keystore.Keystore
+	aead     cipher.AEAD
+	readOnly bool
+}
+
+func newEncryptedKeystore(ks keystore.Keystore, key []byte, readOnly bool) (keystore.Keystore, error) {
+	aead, err := encds.NewAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return &encryptedKeystore{Keystore: ks, aead: aead, readOnly: readOnly}, nil
+}
+
+func (ks *encryptedKeystore) Put(name keystore.KeyName, pk keystore.PrivKey) error {
+	sealed, err := ks.seal(name, pk)
+	if err != nil {
+		return err
+	}
+	return ks.Keystore.Put(name, sealed)
+}
+
+func (ks *encryptedKeystore) Replace(name keystore.KeyName, pk keystore.PrivKey) error {
+	sealed, err := ks.seal(name, pk)
+	if err != nil {
+		return err
+	}
+	return ks.Keystore.Replace(name, sealed)
+}
+
+func (ks *encryptedKeystore) Get(name keystore.KeyName) (keystore.PrivKey, error) {
+	pk, err := ks.Keystore.Get(name)
+	if err != nil {
+		return keystore.PrivKey{}, err
+	}
+	sealed, ok := bytes.CutPrefix(pk.Body, []byte(sealedPrefix))
+	if !ok {
+		if !ks.readOnly {
+			ks.reseal(name, pk)
+		}
+		return pk, nil
+	}
+	body, err := open(ks.aead, sealed, []byte(name))
+	if err != nil {
+		return keystore.PrivKey{}, fmt.Errorf("node: decrypting key '%s': %w", name, err)
+	}
+	return keystore.PrivKey{Body: body}, nil
+}
+
+// reseal replaces the plaintext key with the sealed one. The plaintext key is kept if it fails.
+func (ks *encryptedKeystore) reseal(name keystore.KeyName, pk keystore.PrivKey) {
+	if err := ks.Replace(name, pk); err != nil {
+		log.Warnw("sealing plaintext key", "name", name, "err", err)
+	}
+}
+
+func (ks *encryptedKeystore) seal(name keystore.KeyName, pk keystore.PrivKey) (keystore.PrivKey, error) {
+	sealed, err := seal(ks.aead, pk.Body, []byte(name))
+	if err != nil {
+		return keystore.PrivKey{}, err
+	}
+	return keystore.PrivKey{Body: append([]byte(sealedPrefix), sealed...)}, nil
+}
+
+func seal(aead cipher.AEAD, plaintext, data []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, data), nil
+}
+
+func open(aead cipher.AEAD, sealed, data []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, encds.ErrDecrypt
+	}
+	plain, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], data)
+	if err != nil {
+		return nil, encds.ErrDecrypt
+	}
+	return plain, nil
+}
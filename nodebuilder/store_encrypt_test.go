@@ -0,0 +1,136 @@
+package nodebuilder
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/libs/keystore"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+func TestEncryptStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	cfg, err := DefaultConfig(node.Light)
+	require.NoError(t, err)
+	require.NoError(t, Init(*cfg, dir, node.Light))
+	require.NoError(t, EncryptStore(dir, []byte("passphrase")))
+	assert.Error(t, EncryptStore(dir, []byte("passphrase")))
+
+	_, err = OpenStore(dir, nil)
+	assert.ErrorIs(t, err, ErrEncrypted)
+
+	t.Setenv(EnvStorePassphrase, "wrong")
+	_, err = OpenStore(dir, nil)
+	assert.ErrorIs(t, err, ErrPassphrase)
+
+	t.Setenv(EnvStorePassphrase, "passphrase")
+	s, err := OpenStore(dir, nil)
+	require.NoError(t, err)
+	ds, err := s.Datastore()
+	require.NoError(t, err)
+	key, value := datastore.NewKey("key"), []byte("value")
+	require.NoError(t, ds.Put(ctx, key, value))
+	require.NoError(t, s.Close())
+
+	s, err = OpenStore(dir, nil)
+	require.NoError(t, err)
+	defer s.Close()
+	ds, err = s.Datastore()
+	require.NoError(t, err)
+	got, err := ds.Get(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, value, got)
+
+	// the keys are sealed at rest
+	ks, err := s.Keystore()
+	require.NoError(t, err)
+	require.NoError(t, ks.Put("key", keystore.PrivKey{Body: value}))
+	pk, err := ks.Get("key")
+	require.NoError(t, err)
+	assert.Equal(t, value, pk.Body)
+	raw, err := os.ReadFile(filepath.Join(keysPath(dir), keystore.KeyName("key").Base32()))
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), base64.StdEncoding.EncodeToString(value))
+
+	// so are the config secrets
+	cfg, err = s.Config()
+	require.NoError(t, err)
+	cfg.Gateway.Auth.Tokens = []string{"token"}
+	require.NoError(t, s.PutConfig(cfg))
+	raw, err = os.ReadFile(configPath(dir))
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), `"token"`)
+	cfg, err = s.Config()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"token"}, cfg.Gateway.Auth.Tokens)
+}
+
+func TestEncryptedKeystore_Reseal(t *testing.T) {
+	key := make([]byte, 32)
+	plain := keystore.NewMapKeystore()
+	require.NoError(t, plain.Put("key", keystore.PrivKey{Body: []byte("value")}))
+
+	// the plaintext key is kept if writing the sealed one fails
+	ks, err := newEncryptedKeystore(failingKeystore{plain}, key, false)
+	require.NoError(t, err)
+	pk, err := ks.Get("key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), pk.Body)
+	pk, err = plain.Get("key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), pk.Body)
+
+	// and replaced by the sealed one otherwise
+	ks, err = newEncryptedKeystore(plain, key, false)
+	require.NoError(t, err)
+	pk, err = ks.Get("key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), pk.Body)
+	pk, err = plain.Get("key")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(pk.Body), sealedPrefix))
+	pk, err = ks.Get("key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), pk.Body)
+}
+
+// failingKeystore is a Keystore failing to write keys.
+type failingKeystore struct {
+	keystore.Keystore
+}
+
+func (failingKeystore) Put(keystore.KeyName, keystore.PrivKey) error {
+	return errors.New("put failed")
+}
+
+func (failingKeystore) Replace(keystore.KeyName, keystore.PrivKey) error {
+	return errors.New("replace failed")
+}
+
+func TestEncryptStore_Invalid(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	cfg, err := DefaultConfig(node.Light)
+	require.NoError(t, err)
+	require.NoError(t, Init(*cfg, dir, node.Light))
+	assert.Error(t, EncryptStore(dir, nil))
+
+	// stores with data can't be encrypted
+	s, err := OpenStore(dir, nil)
+	require.NoError(t, err)
+	ds, err := s.Datastore()
+	require.NoError(t, err)
+	require.NoError(t, ds.Put(ctx, datastore.NewKey("key"), []byte("value")))
+	require.NoError(t, s.Close())
+	assert.Error(t, EncryptStore(dir, []byte("passphrase")))
+}
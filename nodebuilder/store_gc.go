@@ -0,0 +1,86 @@
+package nodebuilder
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+
+	libhead "github.com/celestiaorg/go-header"
+	"github.com/celestiaorg/go-header/store"
+
+	"github.com/celestiaorg/celestia-node/das"
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/nodebuilder/share"
+	"github.com/celestiaorg/celestia-node/pruner"
+	"github.com/celestiaorg/celestia-node/pruner/full"
+	"github.com/celestiaorg/celestia-node/share/eds"
+)
+
+// CollectStoreGarbage prunes the block data kept in the Store of a full or bridge node outside the
+// pruning window, where zero window keeps the whole history, and compacts the EDS store afterwards.
+// Pruning resumes from the progress of the node's pruner and stops at the heights the node has not
+// sampled yet. The node must be stopped.
+func CollectStoreGarbage(
+	ctx context.Context,
+	s Store,
+	tp node.Type,
+	window time.Duration,
+) (*share.GCResult, error) {
+	ds, err := s.Datastore()
+	if err != nil {
+		return nil, err
+	}
+	hstore, err := store.NewStore[*header.ExtendedHeader](ds)
+	if err != nil {
+		return nil, err
+	}
+	edsStore, err := eds.NewStore(s.Path(), ds)
+	if err != nil {
+		return nil, err
+	}
+	if err = edsStore.Start(ctx); err != nil {
+		return nil, err
+	}
+	defer edsStore.Stop(ctx) //nolint:errcheck
+
+	var p *pruner.Service
+	if window > 0 {
+		params := pruner.DefaultParams()
+		params.Window = window
+		p, err = pruner.NewService(full.NewPruner(edsStore), hstore, storeProgress(hstore, ds, tp), ds, params)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return share.CollectGarbage(ctx, edsStore, p)
+}
+
+// storeProgress limits pruning of a stopped node to the heights it sampled as of its DASer
+// checkpoint, or synced for bridge nodes, as the running pruner does.
+func storeProgress(
+	hstore libhead.Store[*header.ExtendedHeader],
+	ds datastore.Datastore,
+	tp node.Type,
+) pruner.ProgressFn {
+	return func(ctx context.Context) (uint64, error) {
+		if tp.Base() == node.Bridge {
+			head, err := hstore.Head(ctx)
+			if err != nil {
+				return 0, err
+			}
+			return uint64(head.Height()), nil
+		}
+
+		stats, err := das.LoadCheckpoint(ctx, ds)
+		if errors.Is(err, datastore.ErrNotFound) {
+			return 0, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		return stats.SampledUpTo(), nil
+	}
+}
@@ -0,0 +1,35 @@
+package nodebuilder
+
+import (
+	"context"
+
+	"github.com/celestiaorg/go-header/store"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/share"
+	"github.com/celestiaorg/celestia-node/share/eds"
+)
+
+// ListStoredSquares describes the EDSes kept in the Store for the heights within the given
+// inclusive range, where 'to' = 0 stands for the stored head, by pages as share.ListStoredSquares
+// does. The node must be stopped.
+func ListStoredSquares(ctx context.Context, s Store, from, to uint64) (*share.StoredSquaresPage, error) {
+	ds, err := s.Datastore()
+	if err != nil {
+		return nil, err
+	}
+	hstore, err := store.NewStore[*header.ExtendedHeader](ds)
+	if err != nil {
+		return nil, err
+	}
+	edsStore, err := eds.NewStore(s.Path(), ds)
+	if err != nil {
+		return nil, err
+	}
+	if err = edsStore.Start(ctx); err != nil {
+		return nil, err
+	}
+	defer edsStore.Stop(ctx) //nolint:errcheck
+
+	return share.ListStoredSquares(ctx, hstore, edsStore, from, to)
+}
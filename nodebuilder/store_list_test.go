@@ -0,0 +1,121 @@
+package nodebuilder
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-app/pkg/da"
+	"github.com/celestiaorg/go-header/store"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	modshare "github.com/celestiaorg/celestia-node/nodebuilder/share"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds"
+)
+
+func TestListStoredSquares(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	dir := t.TempDir()
+	cfg, err := DefaultConfig(node.Full)
+	require.NoError(t, err)
+	require.NoError(t, Init(*cfg, dir, node.Full))
+	s, err := OpenStore(dir, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, s.Close())
+	})
+	ds, err := s.Datastore()
+	require.NoError(t, err)
+
+	// all the generated headers commit to the empty EDS
+	headers := headertest.NewTestSuite(t, 3).GenExtendedHeaders(5)
+	hstore, err := store.NewStore[*header.ExtendedHeader](ds)
+	require.NoError(t, err)
+	require.NoError(t, hstore.Start(ctx))
+	require.NoError(t, hstore.Init(ctx, headers[0]))
+	require.NoError(t, hstore.Append(ctx, headers[1:]...))
+	require.NoError(t, hstore.Stop(ctx))
+
+	page, err := ListStoredSquares(ctx, s, 0, 0)
+	require.NoError(t, err)
+	assert.Empty(t, page.Squares)
+	assert.Zero(t, page.Next)
+
+	edsStore, err := eds.NewStore(s.Path(), ds)
+	require.NoError(t, err)
+	require.NoError(t, edsStore.Start(ctx))
+	empty := share.EmptyExtendedDataSquare()
+	dah, err := da.NewDataAvailabilityHeader(empty)
+	require.NoError(t, err)
+	require.NoError(t, edsStore.Put(ctx, dah.Hash(), empty))
+	require.NoError(t, edsStore.Stop(ctx))
+
+	page, err = ListStoredSquares(ctx, s, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, page.Squares, 5)
+	for i, sq := range page.Squares {
+		assert.EqualValues(t, i+1, sq.Height)
+		assert.Equal(t, share.DataHash(dah.Hash()), sq.DataHash)
+		assert.Equal(t, 1, sq.SquareSize)
+		assert.Positive(t, sq.DiskSize)
+		assert.Len(t, sq.Namespaces, 1)
+	}
+
+	page, err = ListStoredSquares(ctx, s, 3, 3)
+	require.NoError(t, err)
+	require.Len(t, page.Squares, 1)
+	assert.EqualValues(t, 3, page.Squares[0].Height)
+}
+
+func TestListStoredSquares_Pages(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	t.Cleanup(cancel)
+
+	dir := t.TempDir()
+	cfg, err := DefaultConfig(node.Full)
+	require.NoError(t, err)
+	require.NoError(t, Init(*cfg, dir, node.Full))
+	s, err := OpenStore(dir, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, s.Close())
+	})
+	ds, err := s.Datastore()
+	require.NoError(t, err)
+
+	headers := headertest.NewTestSuite(t, 3).GenExtendedHeaders(modshare.MaxStoredSquaresRange + 5)
+	hstore, err := store.NewStore[*header.ExtendedHeader](ds)
+	require.NoError(t, err)
+	require.NoError(t, hstore.Start(ctx))
+	require.NoError(t, hstore.Init(ctx, headers[0]))
+	require.NoError(t, hstore.Append(ctx, headers[1:]...))
+	require.NoError(t, hstore.Stop(ctx))
+
+	edsStore, err := eds.NewStore(s.Path(), ds)
+	require.NoError(t, err)
+	require.NoError(t, edsStore.Start(ctx))
+	empty := share.EmptyExtendedDataSquare()
+	dah, err := da.NewDataAvailabilityHeader(empty)
+	require.NoError(t, err)
+	require.NoError(t, edsStore.Put(ctx, dah.Hash(), empty))
+	require.NoError(t, edsStore.Stop(ctx))
+
+	page, err := ListStoredSquares(ctx, s, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, page.Squares, modshare.MaxStoredSquaresRange)
+	assert.EqualValues(t, modshare.MaxStoredSquaresRange+1, page.Next)
+
+	page, err = ListStoredSquares(ctx, s, page.Next, 0)
+	require.NoError(t, err)
+	require.Len(t, page.Squares, 5)
+	assert.EqualValues(t, modshare.MaxStoredSquaresRange+1, page.Squares[0].Height)
+	assert.Zero(t, page.Next)
+}
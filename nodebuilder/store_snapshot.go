@@ -0,0 +1,351 @@
+package nodebuilder
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+
+	"github.com/celestiaorg/go-header/store"
+
+	"github.com/celestiaorg/celestia-node/das"
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+// snapshotVersion is the version of the snapshot format produced by ExportSnapshot.
+const snapshotVersion = 1
+
+// snapshotManifestName is the name of the manifest entry, which is always the last one in the
+// snapshot archive.
+const snapshotManifestName = "MANIFEST.json"
+
+// snapshotDirs are the Store directories, relative to its root, a snapshot consists of. The DAS
+// checkpoint is kept in the Datastore, so it is covered by the 'data' directory.
+var snapshotDirs = []string{"data", "blocks", "index"}
+
+// ErrSnapshotCorrupted is thrown when the snapshot being imported does not match its manifest.
+var ErrSnapshotCorrupted = errors.New("node: snapshot is corrupted")
+
+// SnapshotFile describes a file contained in a snapshot.
+type SnapshotFile struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// SnapshotManifest describes the contents of a snapshot and is stored as its last entry.
+type SnapshotManifest struct {
+	Version  int       `json:"version"`
+	NodeType string    `json:"node_type"`
+	Created  time.Time `json:"created"`
+	// Head is the height of the stored head the snapshot was taken at.
+	Head uint64 `json:"head"`
+	// SampledHead is the height the DAS checkpoint was caught up to, if any.
+	SampledHead uint64 `json:"sampled_head,omitempty"`
+	// Backend is the backend of the archived Datastore, empty for snapshots predating it.
+	Backend StoreBackend   `json:"backend,omitempty"`
+	Files   []SnapshotFile `json:"files"`
+}
+
+// ExportSnapshot writes a tar archive of the Datastore, the EDS store and the DAS checkpoint of
+// the given Store to 'w', followed by a manifest with the checksums of every archived file.
+// The Store should be opened with OpenStoreReadOnly and the node must be stopped.
+func ExportSnapshot(ctx context.Context, s Store, tp node.Type, w io.Writer) (*SnapshotManifest, error) {
+	if tp == node.Light {
+		return nil, fmt.Errorf("node: snapshots are not supported for %s nodes", tp)
+	}
+
+	manifest := &SnapshotManifest{
+		Version:  snapshotVersion,
+		NodeType: tp.String(),
+		Created:  time.Now().UTC(),
+	}
+	if err := snapshotHeights(ctx, s, tp, manifest); err != nil {
+		return nil, err
+	}
+	backend, err := storeBackend(s.Path())
+	if err != nil {
+		return nil, err
+	}
+	manifest.Backend = backend
+
+	tw := tar.NewWriter(w)
+	for _, dir := range snapshotDirs {
+		root := filepath.Join(s.Path(), dir)
+		err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+			switch {
+			case errors.Is(err, fs.ErrNotExist) && p == root:
+				return nil
+			case err != nil:
+				return err
+			case d.IsDir() || isSnapshotSkipped(d.Name()):
+				return nil
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			rel, err := filepath.Rel(s.Path(), p)
+			if err != nil {
+				return err
+			}
+			file, err := archiveFile(tw, p, filepath.ToSlash(rel))
+			if err != nil {
+				return fmt.Errorf("node: archiving %s: %w", rel, err)
+			}
+			manifest.Files = append(manifest.Files, file)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	raw, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	err = tw.WriteHeader(&tar.Header{
+		Name:    snapshotManifestName,
+		Mode:    0644,
+		Size:    int64(len(raw)),
+		ModTime: manifest.Created,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err = tw.Write(raw); err != nil {
+		return nil, err
+	}
+	return manifest, tw.Close()
+}
+
+// ImportSnapshot extracts a snapshot produced by ExportSnapshot from 'r' into the given Store.
+// The Store must be freshly initialized and of the same node type the snapshot was taken of.
+// Every file is checked against the manifest checksums before the Store is altered, so a corrupted
+// snapshot leaves the Store untouched. The Store adopts the backend of the archived Datastore.
+func ImportSnapshot(ctx context.Context, s Store, tp node.Type, r io.Reader) (*SnapshotManifest, error) {
+	for _, dir := range snapshotDirs {
+		if !isEmptyDir(filepath.Join(s.Path(), dir)) {
+			return nil, fmt.Errorf("node: can't import snapshot into non-empty store directory '%s'", dir)
+		}
+	}
+
+	staging := filepath.Join(s.Path(), ".snapshot")
+	if err := os.RemoveAll(staging); err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(staging) //nolint:errcheck
+
+	var (
+		manifest *SnapshotManifest
+		sums     = make(map[string]SnapshotFile)
+		tr       = tar.NewReader(r)
+	)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrSnapshotCorrupted, err)
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if manifest != nil {
+			return nil, fmt.Errorf("%w: unexpected entry %s after the manifest", ErrSnapshotCorrupted, hdr.Name)
+		}
+
+		if hdr.Name == snapshotManifestName {
+			manifest = &SnapshotManifest{}
+			if err = json.NewDecoder(tr).Decode(manifest); err != nil {
+				return nil, fmt.Errorf("%w: decoding manifest: %w", ErrSnapshotCorrupted, err)
+			}
+			continue
+		}
+		if hdr.Typeflag != tar.TypeReg || !isSnapshotPath(hdr.Name) {
+			return nil, fmt.Errorf("%w: unexpected entry %s", ErrSnapshotCorrupted, hdr.Name)
+		}
+		file, err := extractFile(tr, filepath.Join(staging, filepath.FromSlash(hdr.Name)), hdr.Name)
+		if err != nil {
+			return nil, fmt.Errorf("node: extracting %s: %w", hdr.Name, err)
+		}
+		sums[file.Path] = file
+	}
+
+	if err := verifyManifest(manifest, sums, tp); err != nil {
+		return nil, err
+	}
+
+	for _, dir := range snapshotDirs {
+		from, to := filepath.Join(staging, dir), filepath.Join(s.Path(), dir)
+		if _, err := os.Stat(from); errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err := os.RemoveAll(to); err != nil {
+			return nil, err
+		}
+		if err := os.Rename(from, to); err != nil {
+			return nil, err
+		}
+	}
+
+	backend := manifest.Backend
+	if backend == "" {
+		backend = StoreBackendBadger
+	}
+	if err := writeStoreBackend(s.Path(), backend); err != nil {
+		return nil, err
+	}
+	if fs, ok := s.(*fsStore); ok {
+		fs.dataMu.Lock()
+		fs.backend = backend
+		fs.dataMu.Unlock()
+	}
+	return manifest, nil
+}
+
+// verifyManifest checks the files extracted from a snapshot match exactly the ones in its
+// manifest.
+func verifyManifest(manifest *SnapshotManifest, sums map[string]SnapshotFile, tp node.Type) error {
+	switch {
+	case manifest == nil:
+		return fmt.Errorf("%w: manifest is missing", ErrSnapshotCorrupted)
+	case manifest.Version != snapshotVersion:
+		return fmt.Errorf("node: unsupported snapshot version %d", manifest.Version)
+	case manifest.NodeType != tp.String():
+		return fmt.Errorf("node: snapshot of a %s node can't be imported into a %s node", manifest.NodeType, tp)
+	case manifest.Backend != "" && manifest.Backend != StoreBackendBadger && manifest.Backend != StoreBackendPebble:
+		return fmt.Errorf("node: unsupported snapshot store backend %s", manifest.Backend)
+	case len(manifest.Files) != len(sums):
+		return fmt.Errorf("%w: manifest lists %d files, but %d were archived",
+			ErrSnapshotCorrupted, len(manifest.Files), len(sums))
+	}
+
+	for _, want := range manifest.Files {
+		got, ok := sums[want.Path]
+		if !ok {
+			return fmt.Errorf("%w: %s is missing", ErrSnapshotCorrupted, want.Path)
+		}
+		if got != want {
+			return fmt.Errorf("%w: %s checksum mismatch", ErrSnapshotCorrupted, want.Path)
+		}
+	}
+	return nil
+}
+
+// snapshotHeights fills the manifest with the heights of the stored head and the DAS checkpoint.
+func snapshotHeights(ctx context.Context, s Store, tp node.Type, manifest *SnapshotManifest) error {
+	ds, err := s.Datastore()
+	if err != nil {
+		return err
+	}
+	hstore, err := store.NewStore[*header.ExtendedHeader](ds)
+	if err != nil {
+		return err
+	}
+	head, err := hstore.Head(ctx)
+	if err != nil {
+		return fmt.Errorf("node: loading stored head: %w", err)
+	}
+	manifest.Head = uint64(head.Height())
+
+	if tp == node.Bridge {
+		return nil
+	}
+	checkpoint, err := das.LoadCheckpoint(ctx, ds)
+	switch {
+	case err == nil:
+		manifest.SampledHead = checkpoint.CatchupHead
+	case !errors.Is(err, datastore.ErrNotFound):
+		return fmt.Errorf("node: loading DAS checkpoint: %w", err)
+	}
+	return nil
+}
+
+// archiveFile writes the file under 'src' to the archive as 'name' and returns its description.
+func archiveFile(tw *tar.Writer, src, name string) (SnapshotFile, error) {
+	f, err := os.Open(src)
+	if err != nil {
+		return SnapshotFile{}, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return SnapshotFile{}, err
+	}
+	err = tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    int64(info.Mode().Perm()),
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+	})
+	if err != nil {
+		return SnapshotFile{}, err
+	}
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(tw, h), f)
+	if err != nil {
+		return SnapshotFile{}, err
+	}
+	return SnapshotFile{Path: name, Size: n, SHA256: hex.EncodeToString(h.Sum(nil))}, nil
+}
+
+// extractFile writes the current archive entry to 'dst' and returns its description.
+func extractFile(r io.Reader, dst, name string) (SnapshotFile, error) {
+	if err := os.MkdirAll(filepath.Dir(dst), perms); err != nil {
+		return SnapshotFile{}, err
+	}
+	f, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return SnapshotFile{}, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(f, h), r)
+	if err != nil {
+		return SnapshotFile{}, err
+	}
+	return SnapshotFile{Path: name, Size: n, SHA256: hex.EncodeToString(h.Sum(nil))}, f.Close()
+}
+
+// isSnapshotPath checks the archived path is a clean relative path within one of snapshotDirs.
+func isSnapshotPath(name string) bool {
+	if path.Clean(name) != name || path.IsAbs(name) || strings.HasPrefix(name, "../") {
+		return false
+	}
+	for _, dir := range snapshotDirs {
+		if strings.HasPrefix(name, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// isSnapshotSkipped reports whether the file is runtime state that must not be archived.
+func isSnapshotSkipped(name string) bool {
+	// Badger holds a lock file while opened
+	return name == "LOCK"
+}
+
+func isEmptyDir(path string) bool {
+	entries, err := os.ReadDir(path)
+	return errors.Is(err, fs.ErrNotExist) || (err == nil && len(entries) == 0)
+}
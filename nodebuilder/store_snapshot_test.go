@@ -0,0 +1,137 @@
+package nodebuilder
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/go-header/store"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+func TestSnapshot(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	src := t.TempDir()
+	cfg, err := DefaultConfig(node.Full)
+	require.NoError(t, err)
+	require.NoError(t, Init(*cfg, src, node.Full))
+	s, err := OpenStore(src, nil)
+	require.NoError(t, err)
+	ds, err := s.Datastore()
+	require.NoError(t, err)
+	headers := headertest.NewTestSuite(t, 3).GenExtendedHeaders(5)
+	hstore, err := store.NewStore[*header.ExtendedHeader](ds)
+	require.NoError(t, err)
+	require.NoError(t, hstore.Start(ctx))
+	require.NoError(t, hstore.Init(ctx, headers[0]))
+	require.NoError(t, hstore.Append(ctx, headers[1:]...))
+	require.NoError(t, hstore.Stop(ctx))
+	require.NoError(t, s.Close())
+
+	require.NoError(t, os.MkdirAll(blocksPath(src), perms))
+	car := []byte("not really a CAR file")
+	require.NoError(t, os.WriteFile(filepath.Join(blocksPath(src), "block"), car, 0644))
+
+	s, err = OpenStoreReadOnly(src, nil)
+	require.NoError(t, err)
+	buf := &bytes.Buffer{}
+	manifest, err := ExportSnapshot(ctx, s, node.Full, buf)
+	require.NoError(t, err)
+	require.NoError(t, s.Close())
+	assert.EqualValues(t, 5, manifest.Head)
+	assert.NotEmpty(t, manifest.Files)
+
+	t.Run("Import", func(t *testing.T) {
+		dst := t.TempDir()
+		cfg, err := DefaultConfig(node.Full)
+		require.NoError(t, err)
+		require.NoError(t, Init(*cfg, dst, node.Full))
+		s, err := OpenStore(dst, nil)
+		require.NoError(t, err)
+		defer s.Close()
+
+		_, err = ImportSnapshot(ctx, s, node.Full, bytes.NewReader(buf.Bytes()))
+		require.NoError(t, err)
+
+		got, err := os.ReadFile(filepath.Join(blocksPath(dst), "block"))
+		require.NoError(t, err)
+		assert.Equal(t, car, got)
+
+		ds, err := s.Datastore()
+		require.NoError(t, err)
+		hstore, err := store.NewStore[*header.ExtendedHeader](ds)
+		require.NoError(t, err)
+		head, err := hstore.Head(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, headers[4].Hash(), head.Hash())
+
+		// the store is not empty anymore
+		_, err = ImportSnapshot(ctx, s, node.Full, bytes.NewReader(buf.Bytes()))
+		assert.Error(t, err)
+	})
+
+	t.Run("WrongNodeType", func(t *testing.T) {
+		dst := t.TempDir()
+		cfg, err := DefaultConfig(node.Bridge)
+		require.NoError(t, err)
+		require.NoError(t, Init(*cfg, dst, node.Bridge))
+		s, err := OpenStore(dst, nil)
+		require.NoError(t, err)
+		defer s.Close()
+
+		_, err = ImportSnapshot(ctx, s, node.Bridge, bytes.NewReader(buf.Bytes()))
+		assert.Error(t, err)
+	})
+
+	t.Run("Corrupted", func(t *testing.T) {
+		dst := t.TempDir()
+		cfg, err := DefaultConfig(node.Full)
+		require.NoError(t, err)
+		require.NoError(t, Init(*cfg, dst, node.Full))
+		s, err := OpenStore(dst, nil)
+		require.NoError(t, err)
+		defer s.Close()
+
+		corrupted := rewriteSnapshot(t, buf.Bytes(), "blocks/block", []byte("tampered"))
+		_, err = ImportSnapshot(ctx, s, node.Full, bytes.NewReader(corrupted))
+		assert.ErrorIs(t, err, ErrSnapshotCorrupted)
+		// the store is left untouched
+		assert.NoFileExists(t, filepath.Join(blocksPath(dst), "block"))
+	})
+}
+
+// rewriteSnapshot replaces the contents of the named entry in the snapshot.
+func rewriteSnapshot(t *testing.T, snapshot []byte, name string, data []byte) []byte {
+	out := &bytes.Buffer{}
+	tr, tw := tar.NewReader(bytes.NewReader(snapshot)), tar.NewWriter(out)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		if hdr.Name == name {
+			content, hdr.Size = data, int64(len(data))
+		}
+		require.NoError(t, tw.WriteHeader(hdr))
+		_, err = tw.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	return out.Bytes()
+}
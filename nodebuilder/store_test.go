@@ -24,7 +24,9 @@ func TestRepo(t *testing.T) {
 			_, err := OpenStore(dir, nil)
 			assert.ErrorIs(t, err, ErrNotInited)
 
-			err = Init(*DefaultConfig(tt.tp), dir, tt.tp)
+			cfg, err := DefaultConfig(tt.tp)
+			require.NoError(t, err)
+			err = Init(*cfg, dir, tt.tp)
 			require.NoError(t, err)
 
 			store, err := OpenStore(dir, nil)
@@ -41,7 +43,7 @@ func TestRepo(t *testing.T) {
 			assert.NoError(t, err)
 			assert.NotNil(t, data)
 
-			cfg, err := store.Config()
+			cfg, err = store.Config()
 			assert.NoError(t, err)
 			assert.NotNil(t, cfg)
 
@@ -0,0 +1,200 @@
+package nodebuilder
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ipfs/go-datastore"
+
+	libhead "github.com/celestiaorg/go-header"
+	"github.com/celestiaorg/go-header/store"
+
+	"github.com/celestiaorg/celestia-node/das"
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/share/eds"
+)
+
+const (
+	repairResync    = "resync the store with unsafe-reset-store"
+	repairResample  = "resample the height, e.g. by resyncing the store with unsafe-reset-store"
+	repairAutomatic = "none, the height is retried on start"
+)
+
+// StoreIssue is an inconsistency found in the Store by VerifyStore.
+type StoreIssue struct {
+	// Height is the height the issue was found at, if any.
+	Height uint64 `json:"height,omitempty"`
+	// Problem describes the issue.
+	Problem string `json:"problem"`
+	// Repair describes how to repair the issue.
+	Repair string `json:"repair"`
+}
+
+// StoreReport is the result of VerifyStore.
+type StoreReport struct {
+	From uint64 `json:"from"`
+	To   uint64 `json:"to"`
+	// Headers is the amount of headers checked.
+	Headers int `json:"headers"`
+	// EDSes is the amount of EDSes checked.
+	EDSes  int          `json:"edses"`
+	Issues []StoreIssue `json:"issues,omitempty"`
+}
+
+func (r *StoreReport) issue(height uint64, repair, problem string, args ...any) {
+	r.Issues = append(r.Issues, StoreIssue{
+		Height:  height,
+		Problem: fmt.Sprintf(problem, args...),
+		Repair:  repair,
+	})
+}
+
+// VerifyStore cross-checks the stored headers within the given range of heights against each
+// other, against the EDSes stored for them and against the DAS checkpoint. The range is clamped to
+// the stored head, and 'to' = 0 stands for the stored head. Heights below the first stored header
+// are skipped, as headers before the trusted one are never synced. The node must be stopped.
+func VerifyStore(ctx context.Context, s Store, tp node.Type, from, to uint64) (*StoreReport, error) {
+	ds, err := s.Datastore()
+	if err != nil {
+		return nil, err
+	}
+	hstore, err := store.NewStore[*header.ExtendedHeader](ds)
+	if err != nil {
+		return nil, err
+	}
+	head, err := hstore.Head(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("node: loading stored head: %w", err)
+	}
+	headHeight := uint64(head.Height())
+	if to == 0 || to > headHeight {
+		to = headHeight
+	}
+	if from == 0 {
+		from = 1
+	}
+	report := &StoreReport{From: from, To: to}
+
+	// light nodes do not store EDSes and bridge nodes do not sample
+	var edsStore *eds.Store
+	if tp != node.Light {
+		edsStore, err = eds.NewStore(s.Path(), ds)
+		if err != nil {
+			return nil, err
+		}
+		if err = edsStore.Start(ctx); err != nil {
+			return nil, err
+		}
+		defer edsStore.Stop(ctx) //nolint:errcheck
+	}
+	var checkpoint *das.SamplingStats
+	if tp != node.Bridge {
+		stats, err := das.LoadCheckpoint(ctx, ds)
+		switch {
+		case err == nil:
+			checkpoint = &stats
+			verifyCheckpoint(report, stats, headHeight)
+		case !errors.Is(err, datastore.ErrNotFound):
+			report.issue(0, repairResync, "DAS checkpoint is unreadable: %v", err)
+		}
+	}
+
+	var prev *header.ExtendedHeader
+	for height := from; height <= to; height++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		h, err := hstore.GetByHeight(ctx, height)
+		switch {
+		case errors.Is(err, libhead.ErrNotFound):
+			// heights before the first stored header were never synced
+			if prev != nil {
+				report.issue(height, repairResync, "header is missing")
+			}
+			continue
+		case err != nil:
+			report.issue(height, repairResync, "header is unreadable: %v", err)
+			continue
+		}
+		report.Headers++
+
+		if prev != nil && uint64(prev.Height())+1 == height && !bytes.Equal(h.LastHeader(), prev.Hash()) {
+			report.issue(height, repairResync, "header does not link to the previous header %X", prev.Hash())
+		}
+		prev = h
+		if !bytes.Equal(h.DAH.Hash(), h.DataHash) {
+			report.issue(height, repairResync, "DAH %X does not match the data hash %X", h.DAH.Hash(), h.DataHash)
+			continue
+		}
+		if err = h.Validate(); err != nil {
+			report.issue(height, repairResync, "header is invalid: %v", err)
+			continue
+		}
+
+		if edsStore != nil {
+			verifyEDS(ctx, report, edsStore, h, tp == node.Bridge || sampled(checkpoint, height))
+		}
+	}
+
+	sort.SliceStable(report.Issues, func(i, j int) bool {
+		return report.Issues[i].Height < report.Issues[j].Height
+	})
+	return report, nil
+}
+
+// verifyEDS checks that the EDS of the given header is stored, if it has to be, and matches the
+// header's DAH.
+func verifyEDS(
+	ctx context.Context,
+	report *StoreReport,
+	edsStore *eds.Store,
+	h *header.ExtendedHeader,
+	stored bool,
+) {
+	root, height := h.DAH.Hash(), uint64(h.Height())
+	has, err := edsStore.Has(ctx, root)
+	if err != nil {
+		report.issue(height, repairResample, "EDS %X is unavailable: %v", root, err)
+		return
+	}
+	if !has {
+		if stored {
+			report.issue(height, repairResample, "EDS %X is missing", root)
+		}
+		return
+	}
+
+	report.EDSes++
+	// GetDAH verifies the roots stored along the EDS hash to the requested one
+	if _, err = edsStore.GetDAH(ctx, root); err != nil {
+		report.issue(height, repairResample, "EDS %X is corrupted: %v", root, err)
+	}
+}
+
+// verifyCheckpoint checks the DAS checkpoint against the stored head.
+func verifyCheckpoint(report *StoreReport, checkpoint das.SamplingStats, head uint64) {
+	if checkpoint.CatchupHead > head {
+		report.issue(0, repairResync, "DAS checkpoint at height %d is ahead of the stored head %d",
+			checkpoint.CatchupHead, head)
+	}
+	for height, tries := range checkpoint.Failed {
+		if height < report.From || height > report.To {
+			continue
+		}
+		report.issue(height, repairAutomatic, "sampling failed %d times", tries)
+	}
+}
+
+// sampled reports whether the DASer sampled the given height, so its EDS must be stored.
+func sampled(checkpoint *das.SamplingStats, height uint64) bool {
+	if checkpoint == nil || height > checkpoint.CatchupHead {
+		return false
+	}
+	_, failed := checkpoint.Failed[height]
+	return !failed
+}
@@ -0,0 +1,64 @@
+package nodebuilder
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/go-header/store"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+func TestVerifyStore(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	dir := t.TempDir()
+	cfg, err := DefaultConfig(node.Light)
+	require.NoError(t, err)
+	require.NoError(t, Init(*cfg, dir, node.Light))
+	s, err := OpenStore(dir, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, s.Close())
+	})
+	ds, err := s.Datastore()
+	require.NoError(t, err)
+
+	headers := headertest.NewTestSuite(t, 3).GenExtendedHeaders(10)
+	hstore, err := store.NewStore[*header.ExtendedHeader](ds)
+	require.NoError(t, err)
+	require.NoError(t, hstore.Start(ctx))
+	require.NoError(t, hstore.Init(ctx, headers[0]))
+	require.NoError(t, hstore.Append(ctx, headers[1:]...))
+	require.NoError(t, hstore.Stop(ctx))
+
+	report, err := VerifyStore(ctx, s, node.Light, 0, 0)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, report.From)
+	assert.EqualValues(t, 10, report.To)
+	assert.Equal(t, 10, report.Headers)
+	assert.Empty(t, report.Issues)
+
+	// a DAS checkpoint ahead of the stored headers and with failed heights is reported
+	checkpoint := []byte(`{"sample_from": 20, "network_head": 20, "failed": {"5": 2, "15": 1}}`)
+	require.NoError(t, ds.Put(ctx, datastore.NewKey("das/checkpoint"), checkpoint))
+
+	report, err = VerifyStore(ctx, s, node.Light, 3, 100)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, report.From)
+	assert.EqualValues(t, 10, report.To)
+	assert.Equal(t, 8, report.Headers)
+	require.Len(t, report.Issues, 2)
+	assert.Zero(t, report.Issues[0].Height)
+	assert.Contains(t, report.Issues[0].Problem, "ahead of the stored head")
+	assert.EqualValues(t, 5, report.Issues[1].Height)
+	assert.Equal(t, repairAutomatic, report.Issues[1].Repair)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/celestiaorg/celestia-node/core"
 	"github.com/celestiaorg/celestia-node/header/headertest"
 	"github.com/celestiaorg/celestia-node/libs/fxutil"
+	modcore "github.com/celestiaorg/celestia-node/nodebuilder/core"
 	"github.com/celestiaorg/celestia-node/nodebuilder/header"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
@@ -29,7 +30,9 @@ func MockStore(t *testing.T, cfg *Config) Store {
 }
 
 func TestNode(t *testing.T, tp node.Type, opts ...fx.Option) *Node {
-	return TestNodeWithConfig(t, tp, DefaultConfig(tp), opts...)
+	cfg, err := DefaultConfig(tp)
+	require.NoError(t, err)
+	return TestNodeWithConfig(t, tp, cfg, opts...)
 }
 
 func TestNodeWithConfig(t *testing.T, tp node.Type, cfg *Config, opts ...fx.Option) *Node {
@@ -55,7 +58,7 @@ func TestNodeWithConfig(t *testing.T, tp node.Type, cfg *Config, opts ...fx.Opti
 	if tp == node.Bridge {
 		cctx := core.StartTestNode(t)
 		opts = append(opts,
-			fxutil.ReplaceAs(cctx.Client, new(core.Client)),
+			modcore.WithClient(cctx.Client),
 		)
 	}
 
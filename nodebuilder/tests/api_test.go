@@ -53,6 +53,9 @@ func TestNodeModule(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, perms, writePerms)
 
+	snapshot, err := client.Node.DebugSnapshot(ctx)
+	require.NoError(t, err)
+	require.Contains(t, snapshot.Goroutines, "goroutine")
 }
 
 func TestGetByHeight(t *testing.T) {
@@ -137,7 +140,8 @@ func TestHeaderSubscription(t *testing.T) {
 	err := bridge.Start(ctx)
 	require.NoError(t, err)
 
-	cfg := nodebuilder.DefaultConfig(node.Light)
+	cfg, err := nodebuilder.DefaultConfig(node.Light)
+	require.NoError(t, err)
 	addrs, err := peer.AddrInfoToP2pAddrs(host.InfoFromHost(bridge.Host))
 	require.NoError(t, err)
 	cfg.Header.TrustedPeers = append(cfg.Header.TrustedPeers, addrs[0].String())
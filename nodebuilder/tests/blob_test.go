@@ -120,6 +120,32 @@ func TestBlobModule(t *testing.T) {
 				require.ErrorIs(t, err, blob.ErrBlobNotFound)
 			},
 		},
+		{
+			name: "SubmitAll",
+			doFn: func(t *testing.T) {
+				appBlobs, err := blobtest.GenerateV0Blobs([]int{2, 3}, false)
+				require.NoError(t, err)
+				newBlobs := make([]*blob.Blob, len(appBlobs))
+				for i, b := range appBlobs {
+					newBlobs[i], err = blob.NewBlob(b.ShareVersion, append([]byte{b.NamespaceVersion}, b.NamespaceID...), b.Data)
+					require.NoError(t, err)
+				}
+
+				heights, err := fullNode.BlobServ.SubmitAll(ctx, newBlobs)
+				require.NoError(t, err)
+				require.Len(t, heights, len(newBlobs))
+				// the blobs are small enough to share a single PFB
+				require.Equal(t, heights[0], heights[1])
+
+				_, err = fullNode.HeaderServ.WaitForHeight(ctx, heights[0])
+				require.NoError(t, err)
+				for _, b := range newBlobs {
+					got, err := fullNode.BlobServ.Get(ctx, heights[0], b.Namespace(), b.Commitment)
+					require.NoError(t, err)
+					require.Equal(t, b.Commitment, got.Commitment)
+				}
+			},
+		},
 	}
 
 	for _, tt := range test {
@@ -5,17 +5,13 @@ import (
 	"testing"
 	"time"
 
-	mdutils "github.com/ipfs/go-merkledag/test"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/require"
 
-	"github.com/celestiaorg/celestia-node/header/headertest"
 	"github.com/celestiaorg/celestia-node/nodebuilder"
-	"github.com/celestiaorg/celestia-node/nodebuilder/core"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 	"github.com/celestiaorg/celestia-node/nodebuilder/tests/swamp"
-	"github.com/celestiaorg/celestia-node/share/eds/byzantine"
 )
 
 /*
@@ -28,12 +24,11 @@ Steps:
 3. Create a Full Node(FN) with a connection to BN as a trusted peer.
 4. Start a FN.
 5. Subscribe to a fraud proof and wait when it will be received.
-6. Check FN is not synced to 15.
-Note: 15 is not available because DASer will be stopped before reaching this height due to receiving a fraud proof.
-Another note: this test disables share exchange to speed up test results.
+6. Check FN stopped sampling after receiving a fraud proof.
+7. Check FN refuses to restart over the stored fraud proof.
+Note: this test disables share exchange to speed up test results.
 */
 func TestFraudProofBroadcasting(t *testing.T) {
-	t.Skip("requires BEFP generation on app side to work")
 	ctx, cancel := context.WithTimeout(context.Background(), swamp.DefaultTestTimeout)
 	t.Cleanup(cancel)
 
@@ -46,18 +41,16 @@ func TestFraudProofBroadcasting(t *testing.T) {
 	sw := swamp.NewSwamp(t, swamp.WithBlockTime(blockTime))
 	fillDn := swamp.FillBlocks(ctx, sw.ClientContext, sw.Accounts, blockSize, blocks)
 
-	cfg := nodebuilder.DefaultConfig(node.Bridge)
+	cfg, err := nodebuilder.DefaultConfig(node.Bridge)
+	require.NoError(t, err)
 	cfg.Share.UseShareExchange = false
-	bridge := sw.NewNodeWithConfig(
-		node.Bridge,
-		cfg,
-		core.WithHeaderConstructFn(headertest.FraudMaker(t, 10, mdutils.Bserv())),
-	)
+	bridge := sw.NewByzantineBridgeNode(cfg, 10)
 
-	err := bridge.Start(ctx)
+	err = bridge.Start(ctx)
 	require.NoError(t, err)
 
-	cfg = nodebuilder.DefaultConfig(node.Full)
+	cfg, err = nodebuilder.DefaultConfig(node.Full)
+	require.NoError(t, err)
 	cfg.Share.UseShareExchange = false
 	addrs, err := peer.AddrInfoToP2pAddrs(host.InfoFromHost(bridge.Host))
 	require.NoError(t, err)
@@ -71,32 +64,14 @@ func TestFraudProofBroadcasting(t *testing.T) {
 
 	// subscribe to fraud proof before node starts helps
 	// to prevent flakiness when fraud proof is propagating before subscribing on it
-	subscr, err := full.FraudServ.Subscribe(ctx, byzantine.BadEncoding)
-	require.NoError(t, err)
-
-	select {
-	case p := <-subscr:
-		require.Equal(t, 10, int(p.Height()))
-	case <-ctx.Done():
-		t.Fatal("fraud proof was not received in time")
-	}
-	// This is an obscure way to check if the Syncer was stopped.
-	// If we cannot get a height header within a timeframe it means the syncer was stopped
+	subscr, unsubscribe := swamp.SubscribeFraudProofs(ctx, t, full)
+	swamp.RequireFraudProof(ctx, t, subscr, 10)
+	unsubscribe()
 	// FIXME: Eventually, this should be a check on service registry managing and keeping
 	//  lifecycles of each Module.
-	syncCtx, syncCancel := context.WithTimeout(context.Background(), btime)
-	_, err = full.HeaderServ.WaitForHeight(syncCtx, 100)
-	require.ErrorIs(t, err, context.DeadlineExceeded)
-	syncCancel()
-
-	sw.StopNode(ctx, full)
+	swamp.RequireHalted(ctx, t, full, blockTime*3)
 
-	full = sw.NewNodeWithStore(node.Full, store)
-
-	require.Error(t, full.Start(ctx))
-	proofs, err := full.FraudServ.Get(ctx, byzantine.BadEncoding)
-	require.NoError(t, err)
-	require.NotNil(t, proofs)
+	sw.RequireRestartRefused(ctx, t, full, store)
 	require.NoError(t, <-fillDn)
 }
 
@@ -115,8 +90,6 @@ Steps:
 Note: this test disables share exchange to speed up test results.
 */
 func TestFraudProofSyncing(t *testing.T) {
-	t.Skip("requires BEFP generation on app side to work")
-
 	const (
 		blocks = 15
 		bsize  = 2
@@ -127,39 +100,32 @@ func TestFraudProofSyncing(t *testing.T) {
 	t.Cleanup(cancel)
 
 	fillDn := swamp.FillBlocks(ctx, sw.ClientContext, sw.Accounts, bsize, blocks)
-	cfg := nodebuilder.DefaultConfig(node.Bridge)
+	cfg, err := nodebuilder.DefaultConfig(node.Bridge)
+	require.NoError(t, err)
 	cfg.Share.UseShareExchange = false
-	store := nodebuilder.MockStore(t, cfg)
-	bridge := sw.NewNodeWithStore(
-		node.Bridge,
-		store,
-		core.WithHeaderConstructFn(headertest.FraudMaker(t, 10, mdutils.Bserv())),
-	)
+	bridge := sw.NewByzantineBridgeNode(cfg, 10)
 
-	err := bridge.Start(ctx)
+	err = bridge.Start(ctx)
 	require.NoError(t, err)
 	addr := host.InfoFromHost(bridge.Host)
 	addrs, err := peer.AddrInfoToP2pAddrs(addr)
 	require.NoError(t, err)
 
-	fullCfg := nodebuilder.DefaultConfig(node.Full)
+	fullCfg, err := nodebuilder.DefaultConfig(node.Full)
+	require.NoError(t, err)
 	fullCfg.Share.UseShareExchange = false
 	fullCfg.Header.TrustedPeers = append(fullCfg.Header.TrustedPeers, addrs[0].String())
 	full := sw.NewNodeWithStore(node.Full, nodebuilder.MockStore(t, fullCfg))
 
-	lightCfg := nodebuilder.DefaultConfig(node.Light)
+	lightCfg, err := nodebuilder.DefaultConfig(node.Light)
+	require.NoError(t, err)
 	lightCfg.Header.TrustedPeers = append(lightCfg.Header.TrustedPeers, addrs[0].String())
 	ln := sw.NewNodeWithStore(node.Light, nodebuilder.MockStore(t, lightCfg))
 	require.NoError(t, full.Start(ctx))
 
-	subsFN, err := full.FraudServ.Subscribe(ctx, byzantine.BadEncoding)
-	require.NoError(t, err)
-
-	select {
-	case <-subsFN:
-	case <-ctx.Done():
-		t.Fatal("full node didn't get FP in time")
-	}
+	subsFN, unsubscribeFN := swamp.SubscribeFraudProofs(ctx, t, full)
+	defer unsubscribeFN()
+	swamp.RequireFraudProof(ctx, t, subsFN, 10)
 
 	// start LN to enforce syncing logic, not the PubSub's broadcasting
 	err = ln.Start(ctx)
@@ -167,18 +133,14 @@ func TestFraudProofSyncing(t *testing.T) {
 
 	// internal subscription for the fraud proof is done in order to ensure that light node
 	// receives the BEFP.
-	subsLN, err := ln.FraudServ.Subscribe(ctx, byzantine.BadEncoding)
-	require.NoError(t, err)
+	subsLN, unsubscribeLN := swamp.SubscribeFraudProofs(ctx, t, ln)
+	defer unsubscribeLN()
 
 	// ensure that the full and light node are connected to speed up test
 	// alternatively, they would discover each other
 	err = ln.Host.Connect(ctx, *host.InfoFromHost(full.Host))
 	require.NoError(t, err)
 
-	select {
-	case <-subsLN:
-	case <-ctx.Done():
-		t.Fatal("light node didn't get FP in time")
-	}
+	swamp.RequireFraudProof(ctx, t, subsLN, 10)
 	require.NoError(t, <-fillDn)
 }
@@ -90,7 +90,8 @@ Steps:
 */
 func TestBootstrapNodesFromBridgeNode(t *testing.T) {
 	sw := swamp.NewSwamp(t)
-	cfg := nodebuilder.DefaultConfig(node.Bridge)
+	cfg, err := nodebuilder.DefaultConfig(node.Bridge)
+	require.NoError(t, err)
 	const defaultTimeInterval = time.Second * 10
 	setTimeInterval(cfg, defaultTimeInterval)
 
@@ -99,11 +100,12 @@ func TestBootstrapNodesFromBridgeNode(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), swamp.DefaultTestTimeout)
 	t.Cleanup(cancel)
 
-	err := bridge.Start(ctx)
+	err = bridge.Start(ctx)
 	require.NoError(t, err)
 	bridgeAddr := host.InfoFromHost(bridge.Host)
 
-	cfg = nodebuilder.DefaultConfig(node.Full)
+	cfg, err = nodebuilder.DefaultConfig(node.Full)
+	require.NoError(t, err)
 	setTimeInterval(cfg, defaultTimeInterval)
 	full := sw.NewNodeWithConfig(
 		node.Full,
@@ -111,7 +113,8 @@ func TestBootstrapNodesFromBridgeNode(t *testing.T) {
 		nodebuilder.WithBootstrappers([]peer.AddrInfo{*bridgeAddr}),
 	)
 
-	cfg = nodebuilder.DefaultConfig(node.Light)
+	cfg, err = nodebuilder.DefaultConfig(node.Light)
+	require.NoError(t, err)
 	setTimeInterval(cfg, defaultTimeInterval)
 	cfg.P2P.PeerExchange = true
 	light := sw.NewNodeWithConfig(
@@ -166,7 +169,8 @@ Steps:
 */
 func TestRestartNodeDiscovery(t *testing.T) {
 	sw := swamp.NewSwamp(t)
-	cfg := nodebuilder.DefaultConfig(node.Bridge)
+	cfg, err := nodebuilder.DefaultConfig(node.Bridge)
+	require.NoError(t, err)
 	const defaultTimeInterval = time.Second * 2
 	const fullNodes = 2
 
@@ -177,12 +181,13 @@ func TestRestartNodeDiscovery(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), swamp.DefaultTestTimeout)
 	t.Cleanup(cancel)
 
-	err := bridge.Start(ctx)
+	err = bridge.Start(ctx)
 	require.NoError(t, err)
 	bridgeAddr := host.InfoFromHost(bridge.Host)
 
 	nodes := make([]*nodebuilder.Node, fullNodes)
-	cfg = nodebuilder.DefaultConfig(node.Full)
+	cfg, err = nodebuilder.DefaultConfig(node.Full)
+	require.NoError(t, err)
 	setTimeInterval(cfg, defaultTimeInterval)
 	cfg.Share.Discovery.PeersLimit = fullNodes
 	nodesConfig := nodebuilder.WithBootstrappers([]peer.AddrInfo{*bridgeAddr})
@@ -199,7 +204,8 @@ func TestRestartNodeDiscovery(t *testing.T) {
 	require.True(t, nodes[0].Host.Network().Connectedness(nodes[1].Host.ID()) == network.Connected)
 
 	// create one more node with disabled discovery
-	cfg = nodebuilder.DefaultConfig(node.Full)
+	cfg, err = nodebuilder.DefaultConfig(node.Full)
+	require.NoError(t, err)
 	setTimeInterval(cfg, defaultTimeInterval)
 	cfg.Share.Discovery.PeersLimit = 0
 	node := sw.NewNodeWithConfig(node.Full, cfg, nodesConfig)
@@ -53,7 +53,8 @@ func TestFullReconstructFromBridge(t *testing.T) {
 	err := bridge.Start(ctx)
 	require.NoError(t, err)
 
-	cfg := nodebuilder.DefaultConfig(node.Full)
+	cfg, err := nodebuilder.DefaultConfig(node.Full)
+	require.NoError(t, err)
 	cfg.Share.UseShareExchange = false
 	cfg.Header.TrustedPeers = append(cfg.Header.TrustedPeers, getMultiAddr(t, bridge.Host))
 	full := sw.NewNodeWithConfig(node.Full, cfg)
@@ -109,7 +110,8 @@ func TestFullReconstructFromLights(t *testing.T) {
 	fillDn := swamp.FillBlocks(ctx, sw.ClientContext, sw.Accounts, bsize, blocks)
 
 	const defaultTimeInterval = time.Second * 5
-	cfg := nodebuilder.DefaultConfig(node.Full)
+	cfg, err := nodebuilder.DefaultConfig(node.Full)
+	require.NoError(t, err)
 	setTimeInterval(cfg, defaultTimeInterval)
 
 	bridge := sw.NewBridgeNode()
@@ -125,7 +127,8 @@ func TestFullReconstructFromLights(t *testing.T) {
 	require.NoError(t, bridge.Start(ctx))
 	bootstrapperAddr := host.InfoFromHost(bootstrapper.Host)
 
-	cfg = nodebuilder.DefaultConfig(node.Full)
+	cfg, err = nodebuilder.DefaultConfig(node.Full)
+	require.NoError(t, err)
 	setTimeInterval(cfg, defaultTimeInterval)
 	cfg.Share.UseShareExchange = false
 	cfg.Header.TrustedPeers = append(cfg.Header.TrustedPeers, addrsBridge[0].String())
@@ -139,7 +142,8 @@ func TestFullReconstructFromLights(t *testing.T) {
 	for i := 0; i < lnodes; i++ {
 		i := i
 		errg.Go(func() error {
-			lnConfig := nodebuilder.DefaultConfig(node.Light)
+			lnConfig, err := nodebuilder.DefaultConfig(node.Light)
+			require.NoError(t, err)
 			setTimeInterval(lnConfig, defaultTimeInterval)
 			lnConfig.Header.TrustedPeers = append(lnConfig.Header.TrustedPeers, addrsBridge[0].String())
 			light := sw.NewNodeWithConfig(node.Light, lnConfig, nodesConfig)
@@ -0,0 +1,103 @@
+package swamp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	mdutils "github.com/ipfs/go-merkledag/test"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/privval"
+	"github.com/tendermint/tendermint/types"
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/nodebuilder"
+	coremodule "github.com/celestiaorg/celestia-node/nodebuilder/core"
+	"github.com/celestiaorg/celestia-node/nodebuilder/fraud"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/share/eds/byzantine"
+)
+
+// NewByzantineBridgeNode creates a new instance of a BridgeNode with the given config that
+// produces a header committing to an incorrectly extended data square at the given height, so
+// that nodes sampling it generate a BadEncoding fraud proof. The header is committed to with the
+// key of the Core validator, so that it is accepted by other nodes.
+func (s *Swamp) NewByzantineBridgeNode(
+	cfg *nodebuilder.Config,
+	faultHeight int64,
+	options ...fx.Option,
+) *nodebuilder.Node {
+	pv := privval.LoadFilePVEmptyState(s.cfg.Tendermint.PrivValidatorKeyFile(), "")
+	signer := types.NewMockPVWithParams(pv.Key.PrivKey, false, false)
+	options = append(options, coremodule.WithHeaderConstructFn(
+		headertest.FraudMaker(s.t, faultHeight, mdutils.Bserv(), signer),
+	))
+	return s.NewNodeWithConfig(node.Bridge, cfg, options...)
+}
+
+// SubscribeFraudProofs subscribes to BadEncoding fraud proofs received by the node. Subscribe
+// before the proof is expected, so that a proof broadcasted earlier is not missed. The returned
+// func ends the subscription and has to be called before the node is stopped.
+func SubscribeFraudProofs(ctx context.Context, t *testing.T, nd *nodebuilder.Node) (<-chan fraud.Proof, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	sub, err := nd.FraudServ.Subscribe(ctx, byzantine.BadEncoding)
+	require.NoError(t, err)
+	return sub, func() {
+		cancel()
+		for range sub {
+			// drain the proofs until the subscription is closed
+		}
+	}
+}
+
+// RequireFraudProof waits until a fraud proof arrives on the subscription and requires it to be
+// about the given height.
+func RequireFraudProof(ctx context.Context, t *testing.T, sub <-chan fraud.Proof, height uint64) fraud.Proof {
+	select {
+	case p, ok := <-sub:
+		require.True(t, ok, "fraud proof subscription closed")
+		require.Equal(t, height, p.Height())
+		return p
+	case <-ctx.Done():
+		t.Fatal("fraud proof was not received in time")
+		return fraud.Proof{}
+	}
+}
+
+// RequireHalted requires the node to have stopped sampling after a fraud proof, i.e. its DASer no
+// longer answers within the given time. The headers are not checked, as the node keeps storing the
+// adjacent ones received over the header subscription.
+func RequireHalted(ctx context.Context, t *testing.T, nd *nodebuilder.Node, wait time.Duration) {
+	// the DASer is stopped asynchronously once the proof is received
+	for ctx.Err() == nil {
+		statsCtx, cancel := context.WithTimeout(ctx, wait)
+		_, err := nd.DASer.SamplingStats(statsCtx)
+		cancel()
+		if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			return
+		}
+		time.Sleep(wait / 10)
+	}
+	t.Fatal("node kept sampling after the fraud proof")
+}
+
+// RequireRestartRefused stops the node and requires a node of the same type created over the
+// same store to refuse to start, as it has the fraud proof stored.
+func (s *Swamp) RequireRestartRefused(
+	ctx context.Context,
+	t *testing.T,
+	nd *nodebuilder.Node,
+	store nodebuilder.Store,
+) *nodebuilder.Node {
+	s.StopNode(ctx, nd)
+
+	restarted := s.NewNodeWithStore(nd.Type, store)
+	require.Error(t, restarted.Start(ctx), "node started over a stored fraud proof")
+
+	proofs, err := restarted.FraudServ.Get(ctx, byzantine.BadEncoding)
+	require.NoError(t, err)
+	require.NotEmpty(t, proofs)
+	return restarted
+}
@@ -186,7 +186,8 @@ func (s *Swamp) setupGenesis() {
 
 // DefaultTestConfig creates a test config with the access to the core node for the tp
 func (s *Swamp) DefaultTestConfig(tp node.Type) *nodebuilder.Config {
-	cfg := nodebuilder.DefaultConfig(tp)
+	cfg, err := nodebuilder.DefaultConfig(tp)
+	require.NoError(s.t, err)
 
 	ip, port, err := net.SplitHostPort(s.cfg.App.GRPC.Address)
 	require.NoError(s.t, err)
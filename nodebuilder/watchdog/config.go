@@ -0,0 +1,61 @@
+package watchdog
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Config combines all configuration fields for the profiling watchdog.
+type Config struct {
+	// Enabled makes the watchdog capture heap and goroutine profiles into the profiles directory of
+	// the node store whenever one of the thresholds is crossed.
+	Enabled bool
+	// CheckInterval is the interval between checks of the thresholds.
+	CheckInterval time.Duration
+	// MaxHeapBytes is the size of the heap in use over which profiles are captured. Zero disables
+	// the threshold.
+	MaxHeapBytes uint64
+	// MaxGoroutines is the amount of goroutines over which profiles are captured. Zero disables the
+	// threshold.
+	MaxGoroutines int
+	// MaxRPCLatency is the time to serve an RPC request over which profiles are captured. Zero
+	// disables the threshold.
+	MaxRPCLatency time.Duration
+	// Cooldown is the minimal time between two captures, so that a lasting anomaly does not fill up
+	// the disk.
+	Cooldown time.Duration
+	// MaxCaptures is the amount of captures kept in the profiles directory. The oldest captures are
+	// removed first.
+	MaxCaptures int
+}
+
+// DefaultConfig returns default configuration for the profiling watchdog.
+func DefaultConfig() Config {
+	return Config{
+		Enabled:       false,
+		CheckInterval: 10 * time.Second,
+		MaxHeapBytes:  4 << 30,
+		MaxGoroutines: 20000,
+		MaxRPCLatency: 10 * time.Second,
+		Cooldown:      10 * time.Minute,
+		MaxCaptures:   10,
+	}
+}
+
+// Validate performs basic validation of the config.
+func (cfg *Config) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.CheckInterval <= 0 {
+		return fmt.Errorf("module/watchdog: check interval must be positive, got %s", cfg.CheckInterval)
+	}
+	if cfg.MaxHeapBytes == 0 && cfg.MaxGoroutines <= 0 && cfg.MaxRPCLatency <= 0 {
+		return errors.New("module/watchdog: no threshold configured")
+	}
+	if cfg.MaxCaptures <= 0 {
+		return fmt.Errorf("module/watchdog: max captures must be positive, got %d", cfg.MaxCaptures)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package watchdog
+
+import (
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+)
+
+const enabledFlag = "watchdog"
+
+// Flags gives a set of watchdog flags.
+func Flags() *flag.FlagSet {
+	flags := &flag.FlagSet{}
+
+	flags.Bool(
+		enabledFlag,
+		false,
+		"Enables the profiling watchdog, capturing heap and goroutine profiles into the profiles "+
+			"directory of the node store when memory, goroutines or RPC latency cross the configured thresholds",
+	)
+
+	return flags
+}
+
+// ParseFlags parses watchdog flags from the given cmd and saves them to the passed config.
+func ParseFlags(cmd *cobra.Command, cfg *Config) {
+	enabled, err := cmd.Flags().GetBool(enabledFlag)
+	if cmd.Flags().Changed(enabledFlag) && err == nil {
+		cfg.Enabled = enabled
+	}
+}
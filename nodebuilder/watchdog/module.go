@@ -0,0 +1,47 @@
+package watchdog
+
+import (
+	"context"
+	"path/filepath"
+
+	logging "github.com/ipfs/go-log/v2"
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/celestia-node/api/rpc"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+var log = logging.Logger("module/watchdog")
+
+// ConstructModule collects the profiling watchdog, if enabled.
+func ConstructModule(cfg *Config) fx.Option {
+	// sanitize config values before constructing module
+	cfgErr := cfg.Validate()
+	if !cfg.Enabled {
+		return fx.Options()
+	}
+
+	return fx.Module(
+		"watchdog",
+		fx.Supply(*cfg),
+		fx.Error(cfgErr),
+		fx.Provide(fx.Annotate(
+			func(cfg Config, path node.StorePath) *watchdog {
+				return newWatchdog(cfg, profilesPath(string(path)))
+			},
+			fx.OnStart(func(ctx context.Context, w *watchdog) error {
+				return w.Start(ctx)
+			}),
+			fx.OnStop(func(ctx context.Context, w *watchdog) error {
+				return w.Stop(ctx)
+			}),
+		)),
+		fx.Invoke(func(w *watchdog, serv *rpc.Server) {
+			serv.ObserveLatency(w.observeLatency)
+		}),
+	)
+}
+
+func profilesPath(base string) string {
+	return filepath.Join(base, "profiles")
+}
@@ -0,0 +1,216 @@
+package watchdog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// baselineDir holds the profiles captured on start, to diff the captures against with
+	// `go tool pprof -diff_base`.
+	baselineDir  = "baseline"
+	snapshotFile = "snapshot.json"
+	// captureTimeFormat names the captures, so that they sort by time.
+	captureTimeFormat = "20060102T150405Z"
+)
+
+const (
+	triggerHeap       = "heap"
+	triggerGoroutines = "goroutines"
+	triggerRPCLatency = "rpc_latency"
+)
+
+// profiles are the runtime profiles captured.
+var profiles = []string{"heap", "goroutine"}
+
+// Snapshot is the state of the metrics the watchdog checks at the time of a check. It is stored
+// along the profiles it triggered.
+type Snapshot struct {
+	Time       time.Time `json:"time"`
+	HeapBytes  uint64    `json:"heap_bytes"`
+	Goroutines int       `json:"goroutines"`
+	// MaxRPCLatency is the longest time taken to serve an RPC request since the previous check.
+	MaxRPCLatency time.Duration `json:"max_rpc_latency"`
+	// Triggers are the metrics over their thresholds.
+	Triggers []string `json:"triggers,omitempty"`
+}
+
+// watchdog periodically checks the heap size, the amount of goroutines and the RPC latency, and
+// captures profiles when any of them crosses its threshold.
+type watchdog struct {
+	cfg Config
+	dir string
+
+	// maxLatency is the longest RPC request latency since the last check, in nanoseconds
+	maxLatency  atomic.Int64
+	lastCapture time.Time
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newWatchdog(cfg Config, dir string) *watchdog {
+	return &watchdog{
+		cfg: cfg,
+		dir: dir,
+	}
+}
+
+// Start captures the baseline profiles and starts checking the thresholds.
+func (w *watchdog) Start(context.Context) error {
+	if err := os.MkdirAll(w.dir, 0755); err != nil {
+		return fmt.Errorf("module/watchdog: creating profiles directory: %w", err)
+	}
+	snapshot := takeSnapshot(time.Now(), 0)
+	if err := w.capture(baselineDir, snapshot); err != nil {
+		return fmt.Errorf("module/watchdog: capturing baseline profiles: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		ticker := time.NewTicker(w.cfg.CheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if err := w.check(now); err != nil {
+					log.Errorw("capturing profiles", "err", err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+func (w *watchdog) Stop(context.Context) error {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	w.wg.Wait()
+	return nil
+}
+
+// observeLatency records the time taken to serve an RPC request.
+func (w *watchdog) observeLatency(latency time.Duration) {
+	for {
+		prev := w.maxLatency.Load()
+		if int64(latency) <= prev || w.maxLatency.CompareAndSwap(prev, int64(latency)) {
+			return
+		}
+	}
+}
+
+// check captures profiles, if any threshold is crossed and the cooldown since the previous
+// capture has passed.
+func (w *watchdog) check(now time.Time) error {
+	snapshot := takeSnapshot(now, time.Duration(w.maxLatency.Swap(0)))
+	if w.cfg.MaxHeapBytes > 0 && snapshot.HeapBytes > w.cfg.MaxHeapBytes {
+		snapshot.Triggers = append(snapshot.Triggers, triggerHeap)
+	}
+	if w.cfg.MaxGoroutines > 0 && snapshot.Goroutines > w.cfg.MaxGoroutines {
+		snapshot.Triggers = append(snapshot.Triggers, triggerGoroutines)
+	}
+	if w.cfg.MaxRPCLatency > 0 && snapshot.MaxRPCLatency > w.cfg.MaxRPCLatency {
+		snapshot.Triggers = append(snapshot.Triggers, triggerRPCLatency)
+	}
+	if len(snapshot.Triggers) == 0 {
+		return nil
+	}
+	if !w.lastCapture.IsZero() && now.Sub(w.lastCapture) < w.cfg.Cooldown {
+		log.Debugw("skipping capture during cooldown", "triggers", snapshot.Triggers)
+		return nil
+	}
+	w.lastCapture = now
+
+	name := now.UTC().Format(captureTimeFormat) + "-" + strings.Join(snapshot.Triggers, "+")
+	log.Warnw("thresholds crossed, capturing profiles",
+		"triggers", snapshot.Triggers,
+		"heap_bytes", snapshot.HeapBytes,
+		"goroutines", snapshot.Goroutines,
+		"max_rpc_latency", snapshot.MaxRPCLatency,
+		"path", filepath.Join(w.dir, name),
+	)
+	if err := w.capture(name, snapshot); err != nil {
+		return err
+	}
+	return w.prune()
+}
+
+func takeSnapshot(now time.Time, maxLatency time.Duration) Snapshot {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return Snapshot{
+		Time:          now,
+		HeapBytes:     stats.HeapInuse,
+		Goroutines:    runtime.NumGoroutine(),
+		MaxRPCLatency: maxLatency,
+	}
+}
+
+// capture writes the profiles and the snapshot into the named directory.
+func (w *watchdog) capture(name string, snapshot Snapshot) error {
+	dir := filepath.Join(w.dir, name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for _, profile := range profiles {
+		if err := writeProfile(filepath.Join(dir, profile+".pprof"), profile); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, snapshotFile), data, 0644) //nolint:gosec
+}
+
+func writeProfile(path, profile string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	err = pprof.Lookup(profile).WriteTo(f, 0)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// prune removes the oldest captures over the maximum.
+func (w *watchdog) prune() error {
+	entries, err := os.ReadDir(w.dir)
+	if err != nil {
+		return err
+	}
+	captures := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() && entry.Name() != baselineDir {
+			captures = append(captures, entry.Name())
+		}
+	}
+	sort.Strings(captures)
+	for len(captures) > w.cfg.MaxCaptures {
+		if err := os.RemoveAll(filepath.Join(w.dir, captures[0])); err != nil {
+			return err
+		}
+		captures = captures[1:]
+	}
+	return nil
+}
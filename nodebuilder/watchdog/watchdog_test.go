@@ -0,0 +1,92 @@
+package watchdog
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchdog(t *testing.T) {
+	ctx := context.Background()
+	cfg := DefaultConfig()
+	cfg.Enabled = true
+	cfg.CheckInterval = time.Hour
+	cfg.MaxGoroutines = 0
+	cfg.MaxHeapBytes = 0
+	cfg.MaxRPCLatency = time.Second
+	cfg.MaxCaptures = 2
+	require.NoError(t, cfg.Validate())
+
+	dir := t.TempDir()
+	w := newWatchdog(cfg, dir)
+	require.NoError(t, w.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, w.Stop(ctx))
+	})
+	for _, profile := range profiles {
+		assert.FileExists(t, filepath.Join(dir, baselineDir, profile+".pprof"))
+	}
+
+	captures := func() []string {
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		var names []string
+		for _, entry := range entries {
+			if entry.Name() != baselineDir {
+				names = append(names, entry.Name())
+			}
+		}
+		return names
+	}
+
+	// below the threshold
+	now := time.Now()
+	w.observeLatency(time.Millisecond)
+	require.NoError(t, w.check(now))
+	assert.Empty(t, captures())
+
+	// over the threshold, with the snapshot stored along the profiles
+	w.observeLatency(2 * time.Second)
+	w.observeLatency(time.Millisecond)
+	require.NoError(t, w.check(now))
+	require.Len(t, captures(), 1)
+	data, err := os.ReadFile(filepath.Join(dir, captures()[0], snapshotFile))
+	require.NoError(t, err)
+	var snapshot Snapshot
+	require.NoError(t, json.Unmarshal(data, &snapshot))
+	assert.Equal(t, 2*time.Second, snapshot.MaxRPCLatency)
+	assert.Equal(t, []string{triggerRPCLatency}, snapshot.Triggers)
+	for _, profile := range profiles {
+		assert.FileExists(t, filepath.Join(dir, captures()[0], profile+".pprof"))
+	}
+
+	// no capture during the cooldown
+	w.observeLatency(2 * time.Second)
+	require.NoError(t, w.check(now.Add(time.Minute)))
+	assert.Len(t, captures(), 1)
+
+	// the oldest captures are pruned
+	for i := 1; i <= 3; i++ {
+		w.observeLatency(2 * time.Second)
+		require.NoError(t, w.check(now.Add(time.Duration(i)*cfg.Cooldown)))
+	}
+	names := captures()
+	require.Len(t, names, cfg.MaxCaptures)
+	assert.Equal(t, now.Add(2*cfg.Cooldown).UTC().Format(captureTimeFormat)+"-"+triggerRPCLatency, names[0])
+}
+
+func TestConfig_Validate(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxHeapBytes, cfg.MaxGoroutines, cfg.MaxRPCLatency = 0, 0, 0
+	// disabled watchdogs are not validated
+	require.NoError(t, cfg.Validate())
+
+	cfg.Enabled = true
+	require.Error(t, cfg.Validate())
+}
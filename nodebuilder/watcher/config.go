@@ -0,0 +1,70 @@
+package watcher
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// Config combines all configuration fields for the namespace watcher.
+type Config struct {
+	// Namespaces are the hex encoded namespaces whose blobs are exported as blocks arrive. The
+	// watcher is disabled if empty.
+	Namespaces []string
+	// Directory is the directory the blobs are written to, one JSON file per namespace and height.
+	// Relative paths are resolved against the node store.
+	Directory string
+	// WebhookURL is the URL the blobs are posted to as JSON, once per namespace and height.
+	WebhookURL string
+}
+
+// DefaultConfig returns default configuration for the namespace watcher.
+func DefaultConfig() Config {
+	return Config{
+		Namespaces: []string{},
+	}
+}
+
+// Validate performs basic validation of the config.
+func (cfg *Config) Validate() error {
+	if len(cfg.Namespaces) == 0 {
+		return nil
+	}
+	if _, err := cfg.namespaces(); err != nil {
+		return err
+	}
+	if cfg.Directory == "" && cfg.WebhookURL == "" {
+		return errors.New("module/watcher: no sink configured, set a directory or a webhook URL")
+	}
+	if cfg.WebhookURL != "" {
+		u, err := url.Parse(cfg.WebhookURL)
+		if err != nil {
+			return fmt.Errorf("module/watcher: invalid webhook URL: %w", err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("module/watcher: webhook URL must be http(s), got %q", cfg.WebhookURL)
+		}
+	}
+	return nil
+}
+
+func (cfg *Config) namespaces() ([]share.Namespace, error) {
+	namespaces := make([]share.Namespace, len(cfg.Namespaces))
+	for i, ns := range cfg.Namespaces {
+		b, err := hex.DecodeString(ns)
+		if err != nil {
+			return nil, fmt.Errorf("module/watcher: namespace %q is not hex: %w", ns, err)
+		}
+		namespaces[i], err = share.NamespaceFromBytes(b)
+		if err != nil {
+			return nil, fmt.Errorf("module/watcher: namespace %q: %w", ns, err)
+		}
+		if err = namespaces[i].ValidateForBlob(); err != nil {
+			return nil, fmt.Errorf("module/watcher: namespace %q: %w", ns, err)
+		}
+	}
+	return namespaces, nil
+}
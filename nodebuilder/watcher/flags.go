@@ -0,0 +1,57 @@
+package watcher
+
+import (
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	namespacesFlag = "watcher.namespaces"
+	directoryFlag  = "watcher.dir"
+	webhookFlag    = "watcher.webhook"
+)
+
+// Flags gives a set of namespace watcher flags.
+func Flags() *flag.FlagSet {
+	flags := &flag.FlagSet{}
+
+	flags.StringSlice(
+		namespacesFlag,
+		nil,
+		"Comma-separated hex encoded namespaces whose blobs are exported to the watcher sinks as blocks arrive",
+	)
+	flags.String(
+		directoryFlag,
+		"",
+		"Directory the watched blobs are written to, one JSON file per namespace and height",
+	)
+	flags.String(
+		webhookFlag,
+		"",
+		"URL the watched blobs are posted to as JSON, once per namespace and height",
+	)
+
+	return flags
+}
+
+// ParseFlags parses namespace watcher flags from the given cmd and saves them to the passed config.
+func ParseFlags(cmd *cobra.Command, cfg *Config) {
+	if cmd.Flags().Changed(namespacesFlag) {
+		namespaces, err := cmd.Flags().GetStringSlice(namespacesFlag)
+		if err == nil {
+			cfg.Namespaces = namespaces
+		}
+	}
+	if cmd.Flags().Changed(directoryFlag) {
+		dir, err := cmd.Flags().GetString(directoryFlag)
+		if err == nil {
+			cfg.Directory = dir
+		}
+	}
+	if cmd.Flags().Changed(webhookFlag) {
+		webhook, err := cmd.Flags().GetString(webhookFlag)
+		if err == nil {
+			cfg.WebhookURL = webhook
+		}
+	}
+}
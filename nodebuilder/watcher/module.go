@@ -0,0 +1,79 @@
+package watcher
+
+import (
+	"context"
+	"path/filepath"
+
+	"go.uber.org/fx"
+
+	libhead "github.com/celestiaorg/go-header"
+
+	"github.com/celestiaorg/celestia-node/blob"
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// ConstructModule collects the namespace watcher, if any namespace is configured.
+func ConstructModule(cfg *Config) fx.Option {
+	// sanitize config values before constructing module
+	cfgErr := cfg.Validate()
+	if len(cfg.Namespaces) == 0 {
+		return fx.Options()
+	}
+
+	return fx.Module(
+		"watcher",
+		fx.Supply(*cfg),
+		fx.Error(cfgErr),
+		fx.Provide(fx.Annotate(
+			func(
+				cfg Config,
+				path node.StorePath,
+				getter share.Getter,
+				sub libhead.Subscriber[*header.ExtendedHeader],
+			) (*blob.Watcher, error) {
+				namespaces, err := cfg.namespaces()
+				if err != nil {
+					return nil, err
+				}
+				sink, err := newSink(cfg, string(path))
+				if err != nil {
+					return nil, err
+				}
+				return blob.NewWatcher(getter, sub, namespaces, sink), nil
+			},
+			fx.OnStart(func(ctx context.Context, w *blob.Watcher) error {
+				return w.Start(ctx)
+			}),
+			fx.OnStop(func(ctx context.Context, w *blob.Watcher) error {
+				return w.Stop(ctx)
+			}),
+		)),
+		fx.Invoke(func(*blob.Watcher) {}),
+	)
+}
+
+// newSink creates the sinks configured in the config.
+func newSink(cfg Config, storePath string) (blob.Sink, error) {
+	var sinks blob.MultiSink
+	if cfg.Directory != "" {
+		dir := cfg.Directory
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(storePath, dir)
+		}
+		sink, err := blob.NewDirSink(dir)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	if cfg.WebhookURL != "" {
+		sinks = append(sinks, blob.NewWebhookSink(cfg.WebhookURL))
+	}
+
+	if len(sinks) == 1 {
+		return sinks[0], nil
+	}
+	return sinks, nil
+}
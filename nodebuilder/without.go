@@ -0,0 +1,59 @@
+package nodebuilder
+
+import (
+	"go.uber.org/fx"
+)
+
+// optionalModule names a module that can be left out of the Node with the Without options.
+type optionalModule string
+
+const (
+	gatewayModule optionalModule = "gateway"
+	stateModule   optionalModule = "state"
+	dasModule     optionalModule = "das"
+	blobModule    optionalModule = "blob"
+)
+
+// withoutOption is passed to New along with the other options, but instead of being applied to
+// the fx.App, it leaves its module out of the modules constructed for the Node.
+type withoutOption struct {
+	fx.Option
+	module optionalModule
+}
+
+func without(module optionalModule) fx.Option {
+	return withoutOption{Option: fx.Options(), module: module}
+}
+
+// WithoutGateway leaves the gateway out of the Node, even if it is enabled in the config.
+func WithoutGateway() fx.Option {
+	return without(gatewayModule)
+}
+
+// WithoutState leaves the state module out of the Node, removing its RPC namespace and gateway
+// endpoints. The Node does not connect to the core node for state access and can't submit blobs.
+func WithoutState() fx.Option {
+	return without(stateModule)
+}
+
+// WithoutDAS leaves the DASer out of the Node, removing its RPC namespace. The Node does not sample
+// the data of the headers it syncs.
+func WithoutDAS() fx.Option {
+	return without(dasModule)
+}
+
+// WithoutBlob leaves the blob module out of the Node, removing its RPC namespace.
+func WithoutBlob() fx.Option {
+	return without(blobModule)
+}
+
+// withoutModules collects the modules left out with the Without options among the given options.
+func withoutModules(options []fx.Option) []optionalModule {
+	var modules []optionalModule
+	for _, opt := range options {
+		if w, ok := opt.(withoutOption); ok {
+			modules = append(modules, w.module)
+		}
+	}
+	return modules
+}
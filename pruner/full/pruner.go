@@ -0,0 +1,41 @@
+package full
+
+import (
+	"context"
+	"errors"
+
+	"github.com/filecoin-project/dagstore"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/pruner"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds"
+)
+
+var _ pruner.Pruner = (*Pruner)(nil)
+
+// Pruner removes the EDSes of the pruned blocks from the EDS store of full and bridge nodes.
+type Pruner struct {
+	store *eds.Store
+}
+
+// NewPruner constructs a new Pruner over the given EDS store.
+func NewPruner(store *eds.Store) *Pruner {
+	return &Pruner{store: store}
+}
+
+// Prune removes the EDS of the given header. The EDS of the empty block is shared by every empty
+// height, so it is always kept.
+func (p *Pruner) Prune(ctx context.Context, h *header.ExtendedHeader) error {
+	root := share.DataHash(h.DAH.Hash())
+	if root.IsEmptyRoot() {
+		return nil
+	}
+
+	err := p.store.Remove(ctx, root)
+	if errors.Is(err, dagstore.ErrShardUnknown) {
+		// never stored, already removed or shared with a pruned height
+		return nil
+	}
+	return err
+}
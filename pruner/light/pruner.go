@@ -0,0 +1,113 @@
+package light
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ipfs/go-blockservice"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
+	offline "github.com/ipfs/go-ipfs-exchange-offline"
+
+	"github.com/celestiaorg/celestia-app/pkg/shares"
+	"github.com/celestiaorg/celestia-app/pkg/wrapper"
+	"github.com/celestiaorg/nmt"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/pruner"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/ipld"
+)
+
+var _ pruner.Pruner = (*Pruner)(nil)
+
+// Pruner removes the samples of the pruned blocks, along with the proofs fetched for them, from
+// the blockstore of light nodes.
+type Pruner struct {
+	bs blockstore.Blockstore
+	// shared holds the multihashes of the nodes found in the blocks of any height, which are
+	// stored once and so are kept in the blockstore for the heights still in the window
+	shared map[string]struct{}
+}
+
+// NewPruner constructs a new Pruner over the given blockstore.
+func NewPruner(ctx context.Context, bs blockstore.Blockstore) (*Pruner, error) {
+	shared, err := emptyNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for width := 1; width <= share.MaxSquareSize; width *= 2 {
+		if err = paddingNodes(width, shared); err != nil {
+			return nil, fmt.Errorf("computing padding nodes: %w", err)
+		}
+	}
+	return &Pruner{bs: bs, shared: shared}, nil
+}
+
+// Prune removes the nodes of the row and column trees of the given header.
+func (p *Pruner) Prune(ctx context.Context, h *header.ExtendedHeader) error {
+	if share.DataHash(h.DAH.Hash()).IsEmptyRoot() {
+		return nil
+	}
+
+	roots := make([][]byte, 0, len(h.DAH.RowRoots)+len(h.DAH.ColumnRoots))
+	roots = append(roots, h.DAH.RowRoots...)
+	roots = append(roots, h.DAH.ColumnRoots...)
+	for _, root := range roots {
+		_, err := ipld.DeleteNodes(ctx, p.bs, ipld.MustCidFromNamespacedSha256(root), p.keep)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Pruner) keep(id cid.Cid) bool {
+	_, ok := p.shared[string(id.Hash())]
+	return ok
+}
+
+// emptyNodes collects the multihashes of all the nodes of the empty block data square.
+func emptyNodes(ctx context.Context) (map[string]struct{}, error) {
+	bs := blockstore.NewBlockstore(dssync.MutexWrap(datastore.NewMapDatastore()))
+	bServ := blockservice.New(bs, offline.Exchange(bs))
+	if _, err := ipld.AddShares(ctx, share.EmptyBlockShares(), bServ); err != nil {
+		return nil, fmt.Errorf("computing empty block nodes: %w", err)
+	}
+
+	keys, err := bs.AllKeysChan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	empty := make(map[string]struct{})
+	for key := range keys {
+		// the blockstore is keyed by multihash, so the listed CIDs do not carry the NMT codec
+		empty[string(key.Hash())] = struct{}{}
+	}
+	return empty, nil
+}
+
+// paddingNodes adds the multihashes of the nodes of a row consisting of tail padding in a square of
+// the given width to the given set. As the tail padding is the same in every block, so are the
+// subtrees over it, found in the rows and columns of every square not filled up with data.
+func paddingNodes(width int, nodes map[string]struct{}) error {
+	row := shares.ToBytes(shares.TailPaddingShares(width))
+	parity, err := share.DefaultRSMT2DCodec().Encode(row)
+	if err != nil {
+		return err
+	}
+
+	tree := wrapper.NewErasuredNamespacedMerkleTree(uint64(width), 0,
+		nmt.NodeVisitor(func(hash []byte, _ ...[]byte) {
+			nodes[string(ipld.MustCidFromNamespacedSha256(hash).Hash())] = struct{}{}
+		}))
+	for _, sh := range append(row, parity...) {
+		if err = tree.Push(sh); err != nil {
+			return err
+		}
+	}
+	_, err = tree.Root()
+	return err
+}
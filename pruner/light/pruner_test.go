@@ -0,0 +1,69 @@
+package light
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-blockservice"
+	"github.com/ipfs/go-datastore"
+	ds_sync "github.com/ipfs/go-datastore/sync"
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
+	offline "github.com/ipfs/go-ipfs-exchange-offline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-app/pkg/shares"
+
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/ipld"
+	"github.com/celestiaorg/celestia-node/share/sharetest"
+)
+
+func TestPruner(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	bs := blockstore.NewBlockstore(ds_sync.MutexWrap(datastore.NewMapDatastore()))
+	bServ := blockservice.New(bs, offline.Exchange(bs))
+	_, err := ipld.AddShares(ctx, share.EmptyBlockShares(), bServ)
+	require.NoError(t, err)
+	empty := countBlocks(ctx, t, bs)
+
+	eds, err := ipld.AddShares(ctx, sharetest.RandShares(t, 16), bServ)
+	require.NoError(t, err)
+	require.Greater(t, countBlocks(ctx, t, bs), empty)
+
+	p, err := NewPruner(ctx, bs)
+	require.NoError(t, err)
+
+	// pruning the empty block keeps its data, shared by every empty height
+	require.NoError(t, p.Prune(ctx, headertest.ExtendedHeaderFromEDS(t, 1, share.EmptyExtendedDataSquare())))
+	require.NoError(t, p.Prune(ctx, headertest.ExtendedHeaderFromEDS(t, 2, eds)))
+	assert.Equal(t, empty, countBlocks(ctx, t, bs))
+
+	// pruning again is a no-op
+	require.NoError(t, p.Prune(ctx, headertest.ExtendedHeaderFromEDS(t, 2, eds)))
+	assert.Equal(t, empty, countBlocks(ctx, t, bs))
+
+	// pruning a square keeps the padding it shares with the squares of other heights
+	padding := shares.ToBytes(shares.TailPaddingShares(4))
+	_, err = ipld.AddShares(ctx, append(sharetest.RandShares(t, 16)[:12], padding...), bServ)
+	require.NoError(t, err)
+	kept := countBlocks(ctx, t, bs)
+	eds, err = ipld.AddShares(ctx, append(sharetest.RandShares(t, 16)[:12], padding...), bServ)
+	require.NoError(t, err)
+	require.NoError(t, p.Prune(ctx, headertest.ExtendedHeaderFromEDS(t, 3, eds)))
+	assert.Equal(t, kept, countBlocks(ctx, t, bs))
+}
+
+func countBlocks(ctx context.Context, t *testing.T, bs blockstore.Blockstore) int {
+	keys, err := bs.AllKeysChan(ctx)
+	require.NoError(t, err)
+	var count int
+	for range keys {
+		count++
+	}
+	return count
+}
@@ -0,0 +1,76 @@
+package pruner
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var (
+	meter = otel.Meter("pruner")
+)
+
+type metrics struct {
+	pruned metric.Int64Counter
+	shrunk metric.Int64Counter
+}
+
+// WithMetrics turns on metric collection in the pruning Service.
+func (s *Service) WithMetrics() error {
+	pruned, err := meter.Int64Counter("pruner_pruned_blocks_counter",
+		metric.WithDescription("amount of blocks whose data was pruned"))
+	if err != nil {
+		return err
+	}
+
+	shrunk, err := meter.Int64Counter("pruner_window_shrunk_counter",
+		metric.WithDescription("amount of times the pruning window was shrunk for the disk running full"))
+	if err != nil {
+		return err
+	}
+
+	window, err := meter.Int64ObservableGauge("pruner_window",
+		metric.WithDescription("how long back from now the block data is kept"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return err
+	}
+
+	lastPruned, err := meter.Int64ObservableGauge("pruner_last_pruned_height",
+		metric.WithDescription("height up to which the block data is pruned"))
+	if err != nil {
+		return err
+	}
+
+	callback := func(ctx context.Context, observer metric.Observer) error {
+		observer.ObserveInt64(lastPruned, int64(s.LastPruned()))
+		observer.ObserveInt64(window, int64(s.Window().Seconds()))
+		return nil
+	}
+	_, err = meter.RegisterCallback(callback, lastPruned, window)
+	if err != nil {
+		return fmt.Errorf("pruner: registering metrics callback: %w", err)
+	}
+
+	s.metrics = &metrics{pruned: pruned, shrunk: shrunk}
+	return nil
+}
+
+func (m *metrics) observePrune(ctx context.Context) {
+	if m == nil {
+		return
+	}
+	if ctx.Err() != nil {
+		ctx = context.Background()
+	}
+	m.pruned.Add(ctx, 1)
+}
+
+func (m *metrics) observeShrink(ctx context.Context) {
+	if m == nil {
+		return
+	}
+	m.shrunk.Add(ctx, 1)
+}
@@ -0,0 +1,48 @@
+package pruner
+
+import (
+	"fmt"
+	"time"
+)
+
+// SamplingWindow is how long back from now light nodes sample the block data, by the time of its
+// headers. The block data within it is never pruned, even when the disk is running full.
+const SamplingWindow = 30 * 24 * time.Hour
+
+// Params configures the pruning Service.
+type Params struct {
+	// Window is how long back from now, by the time of their headers, block data is kept.
+	Window time.Duration
+	// Interval is the time between two runs of pruning.
+	Interval time.Duration
+	// MinWindow is the shortest the Window is shrunk to when the disk is running full, which is
+	// never shorter than the SamplingWindow. Zero disables shrinking of the Window.
+	MinWindow time.Duration
+}
+
+// DefaultParams returns the default Params of the pruning Service, keeping the block data of the
+// last 30 days.
+func DefaultParams() Params {
+	return Params{
+		Window:   SamplingWindow,
+		Interval: 5 * time.Minute,
+	}
+}
+
+// Validate validates the values in Params.
+func (p *Params) Validate() error {
+	if p.Window <= 0 {
+		return fmt.Errorf("pruner: window must be positive, got %s", p.Window)
+	}
+	if p.Interval <= 0 {
+		return fmt.Errorf("pruner: interval must be positive, got %s", p.Interval)
+	}
+	if p.MinWindow == 0 {
+		return nil
+	}
+	if p.MinWindow < SamplingWindow || p.MinWindow > p.Window {
+		return fmt.Errorf("pruner: min window must be zero or between the sampling window (%s) and "+
+			"the window (%s), got %s", SamplingWindow, p.Window, p.MinWindow)
+	}
+	return nil
+}
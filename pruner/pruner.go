@@ -0,0 +1,19 @@
+// Package pruner removes the block data stored by the node once it falls out of the availability
+// window, so that long-running nodes do not need ever growing disks.
+package pruner
+
+import (
+	"context"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+// Pruner removes the data of a single block from the storage of the node.
+type Pruner interface {
+	// Prune removes the data of the block of the given header. Data already removed is not an error.
+	Prune(context.Context, *header.ExtendedHeader) error
+}
+
+// ProgressFn returns the height up to which every block is processed by the node, e.g. sampled by
+// the DASer, so that its data is not needed anymore besides serving it.
+type ProgressFn func(context.Context) (uint64, error)
@@ -0,0 +1,330 @@
+package pruner
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	logging "github.com/ipfs/go-log/v2"
+
+	libhead "github.com/celestiaorg/go-header"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+var log = logging.Logger("pruner")
+
+var (
+	storePrefix   = datastore.NewKey("pruner")
+	checkpointKey = datastore.NewKey("checkpoint")
+	// latestPrefix keys the latest height of each data root not yet pruned
+	latestPrefix = datastore.NewKey("latest")
+)
+
+// DatastorePrefix returns the namespace of the keys the Service writes to its datastore.
+func DatastorePrefix() datastore.Key {
+	return storePrefix
+}
+
+// checkpoint is the progress of pruning persisted across restarts.
+type checkpoint struct {
+	LastPruned uint64 `json:"last_pruned"`
+	// LastIndexed is the height up to which the latest heights of the data roots are indexed.
+	LastIndexed uint64 `json:"last_indexed,omitempty"`
+}
+
+// Service periodically prunes the block data older than the window. Only the blocks the node
+// already synced the headers of and processed, as reported by ProgressFn, are pruned, so that
+// pruning never races with sampling or storing of the block data. Identical blocks share their data
+// root and so their stored data, which is pruned along with the latest of them only.
+type Service struct {
+	pruner   Pruner
+	getter   libhead.Store[*header.ExtendedHeader]
+	progress ProgressFn
+	ds       datastore.Datastore
+	params   Params
+
+	// lastPruned is the height up to which the block data is pruned
+	lastPruned atomic.Uint64
+	// lastIndexed is the height up to which the latest heights of the data roots are indexed
+	lastIndexed uint64
+	// shrunk is the window in nanoseconds while it is shrunk below the configured one, zero otherwise
+	shrunk atomic.Int64
+
+	metrics *metrics
+
+	trigger chan struct{}
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// NewService constructs a new pruning Service.
+func NewService(
+	p Pruner,
+	getter libhead.Store[*header.ExtendedHeader],
+	progress ProgressFn,
+	ds datastore.Datastore,
+	params Params,
+) (*Service, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	return &Service{
+		pruner:   p,
+		getter:   getter,
+		progress: progress,
+		ds:       namespace.Wrap(ds, storePrefix),
+		params:   params,
+		trigger:  make(chan struct{}, 1),
+	}, nil
+}
+
+func (s *Service) Start(ctx context.Context) error {
+	cp, err := s.loadCheckpoint(ctx)
+	if err != nil {
+		return err
+	}
+	s.lastPruned.Store(cp.LastPruned)
+	s.lastIndexed = cp.LastIndexed
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel, s.done = cancel, make(chan struct{})
+	go s.run(ctx)
+	return nil
+}
+
+func (s *Service) Stop(ctx context.Context) error {
+	s.cancel()
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Trigger makes the Service prune without waiting for the next interval, e.g. when the disk is
+// running full.
+func (s *Service) Trigger() {
+	select {
+	case s.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// Prune runs a single round of pruning, resuming from the persisted progress, and returns the amount
+// of heights pruned, e.g. to prune the store of a stopped node. It must not be called while the
+// Service is started.
+func (s *Service) Prune(ctx context.Context) (uint64, error) {
+	cp, err := s.loadCheckpoint(ctx)
+	if err != nil {
+		return 0, err
+	}
+	s.lastPruned.Store(cp.LastPruned)
+	s.lastIndexed = cp.LastIndexed
+	err = s.prune(ctx)
+	return s.LastPruned() - cp.LastPruned, err
+}
+
+// LastPruned returns the height up to which the block data is pruned.
+func (s *Service) LastPruned() uint64 {
+	return s.lastPruned.Load()
+}
+
+// Window returns how long back from now the block data is kept, which is shorter than the
+// configured window while it is shrunk.
+func (s *Service) Window() time.Duration {
+	if shrunk := s.shrunk.Load(); shrunk > 0 {
+		return time.Duration(shrunk)
+	}
+	return s.params.Window
+}
+
+// ShrinkWindow halves the window the block data is kept for, down to the MinWindow, and prunes
+// without waiting for the next interval, degrading retention rather than running out of disk
+// space. It returns the resulting window, and false if the window can't be shrunk any further.
+func (s *Service) ShrinkWindow() (time.Duration, bool) {
+	window := s.Window()
+	if window <= s.params.MinWindow || s.params.MinWindow == 0 {
+		return window, false
+	}
+
+	shrunk := window / 2
+	if shrunk < s.params.MinWindow {
+		shrunk = s.params.MinWindow
+	}
+	s.shrunk.Store(int64(shrunk))
+	s.metrics.observeShrink(context.Background())
+	log.Errorw("shrinking pruning window: the block data older than the window is pruned and no "+
+		"longer served until the window is restored", "window", shrunk, "configured", s.params.Window)
+	s.Trigger()
+	return shrunk, true
+}
+
+// RestoreWindow restores the configured window after it was shrunk.
+func (s *Service) RestoreWindow() {
+	if s.shrunk.Swap(0) == 0 {
+		return
+	}
+	log.Warnw("restored pruning window", "window", s.params.Window)
+}
+
+func (s *Service) run(ctx context.Context) {
+	defer close(s.done)
+	ticker := time.NewTicker(s.params.Interval)
+	defer ticker.Stop()
+	for {
+		if err := s.prune(ctx); err != nil && ctx.Err() == nil {
+			log.Errorw("pruning block data", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.trigger:
+		}
+	}
+}
+
+// prune prunes the block data of the processed heights older than the window and persists the
+// progress.
+func (s *Service) prune(ctx context.Context) error {
+	head, err := s.getter.Head(ctx)
+	if err != nil {
+		return err
+	}
+	upTo, err := s.progress(ctx)
+	if err != nil {
+		return fmt.Errorf("getting progress: %w", err)
+	}
+	if upTo > uint64(head.Height()) {
+		upTo = uint64(head.Height())
+	}
+
+	start, indexed, cutoff := s.LastPruned(), s.lastIndexed, time.Now().Add(-s.Window())
+	defer func() {
+		if s.LastPruned() == start && s.lastIndexed == indexed {
+			return
+		}
+		// progress is kept even if pruning failed midway
+		cp := checkpoint{LastPruned: s.LastPruned(), LastIndexed: s.lastIndexed}
+		if err := s.storeCheckpoint(ctx, cp); err != nil {
+			log.Errorw("storing pruner checkpoint", "err", err)
+		}
+		if s.LastPruned() != start {
+			log.Infow("pruned block data", "from", start+1, "to", s.LastPruned())
+		}
+	}()
+
+	if err = s.index(ctx, uint64(head.Height())); err != nil {
+		return fmt.Errorf("indexing data roots: %w", err)
+	}
+
+	for height := start + 1; height <= upTo; height++ {
+		h, err := s.getter.GetByHeight(ctx, height)
+		switch {
+		case errors.Is(err, libhead.ErrNotFound):
+			// heights before the trusted header are not synced, so no data is stored for them
+			s.lastPruned.Store(height)
+			continue
+		case err != nil:
+			return err
+		}
+		if !h.Time().Before(cutoff) {
+			return nil
+		}
+
+		shared, err := s.sharedWithLater(ctx, h)
+		if err != nil {
+			return err
+		}
+		if !shared {
+			if err = s.pruner.Prune(ctx, h); err != nil {
+				return fmt.Errorf("pruning height %d: %w", height, err)
+			}
+			if err = s.ds.Delete(ctx, latestKey(h)); err != nil {
+				return err
+			}
+		}
+		s.lastPruned.Store(height)
+		s.metrics.observePrune(ctx)
+	}
+	return nil
+}
+
+// index records the latest height of the data roots of the heights up to the given one, skipping
+// the already pruned heights.
+func (s *Service) index(ctx context.Context, upTo uint64) error {
+	if s.lastIndexed < s.LastPruned() {
+		s.lastIndexed = s.LastPruned()
+	}
+	for height := s.lastIndexed + 1; height <= upTo; height++ {
+		h, err := s.getter.GetByHeight(ctx, height)
+		switch {
+		case errors.Is(err, libhead.ErrNotFound):
+			s.lastIndexed = height
+			continue
+		case err != nil:
+			return err
+		}
+
+		if !share.DataHash(h.DAH.Hash()).IsEmptyRoot() {
+			value := make([]byte, 8)
+			binary.BigEndian.PutUint64(value, height)
+			if err = s.ds.Put(ctx, latestKey(h), value); err != nil {
+				return err
+			}
+		}
+		s.lastIndexed = height
+	}
+	return nil
+}
+
+// sharedWithLater reports whether the data root of the header is shared with a later height, whose
+// pruning then prunes the shared data. The data root of the empty block is shared by every empty
+// height and left to the Pruner to keep.
+func (s *Service) sharedWithLater(ctx context.Context, h *header.ExtendedHeader) (bool, error) {
+	if share.DataHash(h.DAH.Hash()).IsEmptyRoot() {
+		return false, nil
+	}
+	value, err := s.ds.Get(ctx, latestKey(h))
+	switch {
+	case errors.Is(err, datastore.ErrNotFound):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+	return binary.BigEndian.Uint64(value) > uint64(h.Height()), nil
+}
+
+func latestKey(h *header.ExtendedHeader) datastore.Key {
+	return latestPrefix.ChildString(share.DataHash(h.DAH.Hash()).String())
+}
+
+func (s *Service) loadCheckpoint(ctx context.Context) (checkpoint, error) {
+	raw, err := s.ds.Get(ctx, checkpointKey)
+	if errors.Is(err, datastore.ErrNotFound) {
+		return checkpoint{}, nil
+	}
+	if err != nil {
+		return checkpoint{}, err
+	}
+	cp := checkpoint{}
+	err = json.Unmarshal(raw, &cp)
+	return cp, err
+}
+
+func (s *Service) storeCheckpoint(ctx context.Context, cp checkpoint) error {
+	raw, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	return s.ds.Put(ctx, checkpointKey, raw)
+}
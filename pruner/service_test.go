@@ -0,0 +1,180 @@
+package pruner
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	ds_sync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	libheadtest "github.com/celestiaorg/go-header/headertest"
+	"github.com/celestiaorg/go-header/store"
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/share/eds/edstest"
+)
+
+func TestService(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	hstore, err := store.NewStore[*header.ExtendedHeader](ds_sync.MutexWrap(datastore.NewMapDatastore()))
+	require.NoError(t, err)
+	require.NoError(t, hstore.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, hstore.Stop(ctx))
+	})
+
+	suite := headertest.NewTestSuite(t, 3)
+	headers := suite.GenExtendedHeaders(10)
+	require.NoError(t, hstore.Init(ctx, headers[0]))
+	require.NoError(t, hstore.Append(ctx, headers[1:]...))
+	// appended headers are written asynchronously
+	_, err = hstore.GetByHeight(ctx, 10)
+	require.NoError(t, err)
+
+	var progress uint64 = 5
+	progressFn := func(context.Context) (uint64, error) {
+		return progress, nil
+	}
+	ds := ds_sync.MutexWrap(datastore.NewMapDatastore())
+	p := &pruner{}
+	serv, err := NewService(p, hstore, progressFn, ds, Params{Window: time.Nanosecond, Interval: time.Hour})
+	require.NoError(t, err)
+
+	// only the processed heights are pruned
+	require.NoError(t, serv.Start(ctx))
+	require.Eventually(t, func() bool {
+		return serv.LastPruned() == 5
+	}, time.Second*5, time.Millisecond*10)
+	require.NoError(t, serv.Stop(ctx))
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5}, p.pruned())
+
+	// the progress persists across restarts, and heights within the window are kept
+	progress = 10
+	serv, err = NewService(p, hstore, progressFn, ds, Params{Window: time.Hour, Interval: time.Hour})
+	require.NoError(t, err)
+	require.NoError(t, serv.Start(ctx))
+	require.NoError(t, serv.Stop(ctx))
+	assert.EqualValues(t, 5, serv.LastPruned())
+
+	serv.params.Window = time.Nanosecond
+	require.NoError(t, serv.prune(ctx))
+	assert.EqualValues(t, 10, serv.LastPruned())
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, p.pruned())
+}
+
+func TestService_SharedDataRoot(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	// the first and the last height have identical blocks, of which only the last is in the window
+	shared, other := edstest.RandEDS(t, 4), edstest.RandEDS(t, 4)
+	headers := &libheadtest.Store[*header.ExtendedHeader]{Headers: make(map[int64]*header.ExtendedHeader)}
+	for height, square := range []*rsmt2d.ExtendedDataSquare{shared, other, shared} {
+		h := headertest.ExtendedHeaderFromEDS(t, uint64(height+1), square)
+		h.RawHeader.Time = time.Now().Add(-time.Hour * time.Duration(3-height))
+		headers.Headers[h.Height()] = h
+		headers.HeadHeight = h.Height()
+	}
+	progressFn := func(context.Context) (uint64, error) {
+		return 3, nil
+	}
+
+	p := &pruner{}
+	ds := ds_sync.MutexWrap(datastore.NewMapDatastore())
+	serv, err := NewService(p, headers, progressFn, ds, Params{Window: time.Hour + time.Minute, Interval: time.Hour})
+	require.NoError(t, err)
+
+	// the data of the first height is kept for the last one
+	pruned, err := serv.Prune(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, pruned)
+	assert.Equal(t, []uint64{2}, p.pruned())
+
+	// and pruned along with it
+	headers.Headers[3].RawHeader.Time = time.Now().Add(-time.Hour * 2)
+	pruned, err = serv.Prune(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, pruned)
+	assert.Equal(t, []uint64{2, 3}, p.pruned())
+}
+
+func TestParams_Validate(t *testing.T) {
+	params := DefaultParams()
+	require.NoError(t, params.Validate())
+
+	params.Window = 0
+	require.Error(t, params.Validate())
+
+	params = DefaultParams()
+	params.Interval = 0
+	require.Error(t, params.Validate())
+
+	params = DefaultParams()
+	params.MinWindow = params.Window + 1
+	require.Error(t, params.Validate())
+
+	// the block data light nodes sample is never pruned
+	params = DefaultParams()
+	params.Window = 2 * SamplingWindow
+	params.MinWindow = SamplingWindow - time.Hour
+	require.Error(t, params.Validate())
+	params.MinWindow = SamplingWindow
+	require.NoError(t, params.Validate())
+}
+
+func TestService_ShrinkWindow(t *testing.T) {
+	ds := ds_sync.MutexWrap(datastore.NewMapDatastore())
+	params := Params{Window: 3 * SamplingWindow, Interval: time.Hour, MinWindow: SamplingWindow}
+	serv, err := NewService(&pruner{}, nil, nil, ds, params)
+	require.NoError(t, err)
+
+	// the window is halved down to the minimal one
+	window, ok := serv.ShrinkWindow()
+	assert.True(t, ok)
+	assert.Equal(t, 3*SamplingWindow/2, window)
+	window, ok = serv.ShrinkWindow()
+	assert.True(t, ok)
+	assert.Equal(t, SamplingWindow, window)
+	window, ok = serv.ShrinkWindow()
+	assert.False(t, ok)
+	assert.Equal(t, SamplingWindow, window)
+	assert.Equal(t, SamplingWindow, serv.Window())
+
+	serv.RestoreWindow()
+	assert.Equal(t, 3*SamplingWindow, serv.Window())
+
+	// without the minimal window, the window is never shrunk
+	params.MinWindow = 0
+	serv, err = NewService(&pruner{}, nil, nil, ds, params)
+	require.NoError(t, err)
+	window, ok = serv.ShrinkWindow()
+	assert.False(t, ok)
+	assert.Equal(t, 3*SamplingWindow, window)
+}
+
+// pruner records the pruned heights.
+type pruner struct {
+	lk      sync.Mutex
+	heights []uint64
+}
+
+func (p *pruner) Prune(_ context.Context, h *header.ExtendedHeader) error {
+	p.lk.Lock()
+	defer p.lk.Unlock()
+	p.heights = append(p.heights, uint64(h.Height()))
+	return nil
+}
+
+func (p *pruner) pruned() []uint64 {
+	p.lk.Lock()
+	defer p.lk.Unlock()
+	return append([]uint64(nil), p.heights...)
+}
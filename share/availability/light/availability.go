@@ -106,3 +106,12 @@ func (la *ShareAvailability) SharesAvailable(ctx context.Context, dah *share.Roo
 func (la *ShareAvailability) ProbabilityOfAvailability(context.Context) float64 {
 	return 1 - math.Pow(0.75, float64(la.params.SampleAmount))
 }
+
+// SampleAmount returns the amount of shares sampled from a data square of the given width, which
+// is the configured SampleAmount unless the square has fewer shares.
+func (la *ShareAvailability) SampleAmount(squareWidth int) int {
+	if total := squareWidth * squareWidth; total < int(la.params.SampleAmount) {
+		return total
+	}
+	return int(la.params.SampleAmount)
+}
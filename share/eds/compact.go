@@ -0,0 +1,166 @@
+package eds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/filecoin-project/dagstore"
+	"github.com/filecoin-project/dagstore/mount"
+	"github.com/filecoin-project/dagstore/shard"
+
+	"github.com/celestiaorg/celestia-node/libs/utils"
+)
+
+// indexSuffix is the suffix of the CAR index files kept by the DAGStore.
+const indexSuffix = ".full.idx"
+
+// CompactResult describes the outcome of Store.Compact.
+type CompactResult struct {
+	// Reindexed is the amount of EDSes whose CAR index was rewritten, as it was missing or failed.
+	Reindexed int `json:"reindexed"`
+	// Orphans is the amount of CAR and index files removed for not belonging to any EDS.
+	Orphans int `json:"orphans"`
+	// Reclaimed is the amount of bytes freed on disk.
+	Reclaimed int64 `json:"reclaimed"`
+}
+
+// Compact rewrites the CAR indexes of the EDSes that failed or lost them, and removes the CAR and
+// index files left behind by EDSes that are not registered in the Store anymore, e.g. after an
+// unclean shutdown in the middle of Put or Remove.
+func (s *Store) Compact(ctx context.Context) (_ *CompactResult, err error) {
+	ctx, span := tracer.Start(ctx, "store/compact")
+	defer func() {
+		utils.SetStatusAndEnd(span, err)
+	}()
+
+	before, err := s.DiskUsage()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &CompactResult{}
+	for key, info := range s.dgstr.AllShardsInfo() {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		stat, err := s.carIdx.StatFullIndex(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat index for %s: %w", key, err)
+		}
+		if info.ShardState != dagstore.ShardStateErrored && stat.Exists {
+			continue
+		}
+		if err = s.reindex(ctx, key, info); err != nil {
+			log.Warnw("failed to reindex EDS", "key", key, "err", err)
+			continue
+		}
+		res.Reindexed++
+	}
+
+	res.Orphans, err = s.removeOrphans()
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := s.DiskUsage()
+	if err != nil {
+		return nil, err
+	}
+	res.Reclaimed = before - after
+	return res, nil
+}
+
+// DiskUsage returns the total size in bytes of the CAR and index files of the Store.
+func (s *Store) DiskUsage() (int64, error) {
+	var size int64
+	for _, dir := range []string{blocksPath, indexPath} {
+		entries, err := os.ReadDir(s.basepath + dir)
+		if err != nil {
+			return 0, err
+		}
+		for _, entry := range entries {
+			info, err := entry.Info()
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return 0, err
+			}
+			if info.Mode().IsRegular() {
+				size += info.Size()
+			}
+		}
+	}
+	return size, nil
+}
+
+// reindex rewrites the CAR index of the shard from its CAR file. Errored shards are recovered by
+// the DAGStore, while the ones that lost the index are registered anew.
+func (s *Store) reindex(ctx context.Context, key shard.Key, info dagstore.ShardInfo) error {
+	ch := make(chan dagstore.ShardResult, 1)
+	if info.ShardState == dagstore.ShardStateErrored {
+		if err := s.dgstr.RecoverShard(ctx, key, ch, dagstore.RecoverOpts{}); err != nil {
+			return err
+		}
+		return waitShard(ctx, ch)
+	}
+
+	if err := s.dgstr.DestroyShard(ctx, key, ch, dagstore.DestroyOpts{}); err != nil {
+		return err
+	}
+	if err := waitShard(ctx, ch); err != nil {
+		return err
+	}
+	err := s.dgstr.RegisterShard(ctx, key, &mount.FileMount{
+		Path: s.basepath + blocksPath + key.String(),
+	}, ch, dagstore.RegisterOpts{})
+	if err != nil {
+		return err
+	}
+	return waitShard(ctx, ch)
+}
+
+// removeOrphans removes the CAR and index files of unregistered shards and returns their amount.
+func (s *Store) removeOrphans() (int, error) {
+	// CAR files are written before their shards are registered
+	s.orphanLk.Lock()
+	defer s.orphanLk.Unlock()
+
+	shards := s.dgstr.AllShardsInfo()
+	var removed int
+	for dir, suffix := range map[string]string{blocksPath: "", indexPath: indexSuffix} {
+		entries, err := os.ReadDir(s.basepath + dir)
+		if err != nil {
+			return removed, err
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if entry.IsDir() || !strings.HasSuffix(name, suffix) {
+				continue
+			}
+			if _, ok := shards[shard.KeyFromString(strings.TrimSuffix(name, suffix))]; ok {
+				continue
+			}
+			err = os.Remove(filepath.Join(s.basepath+dir, name))
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return removed, fmt.Errorf("failed to remove orphaned %s: %w", name, err)
+			}
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+func waitShard(ctx context.Context, ch chan dagstore.ShardResult) error {
+	select {
+	case result := <-ch:
+		return result.Error
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -302,7 +302,8 @@ func ReadEDS(ctx context.Context, r io.Reader, root share.DataHash) (eds *rsmt2d
 	}
 	if !bytes.Equal(newDah.Hash(), root) {
 		return nil, fmt.Errorf(
-			"share: content integrity mismatch: imported root %s doesn't match expected root %s",
+			"%w: imported root %s doesn't match expected root %s",
+			share.ErrIntegrityMismatch,
 			newDah.Hash(),
 			root,
 		)
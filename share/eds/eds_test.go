@@ -214,6 +214,7 @@ func TestReadEDSContentIntegrityMismatch(t *testing.T) {
 	defer f.Close()
 
 	_, err = ReadEDS(context.Background(), f, dah.Hash())
+	require.ErrorIs(t, err, share.ErrIntegrityMismatch)
 	require.ErrorContains(t, err, "share: content integrity mismatch: imported root")
 }
 
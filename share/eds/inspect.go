@@ -0,0 +1,101 @@
+package eds
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/ipld/go-car"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// SquareInfo describes an EDS kept in the Store.
+type SquareInfo struct {
+	DataHash share.DataHash `json:"data_hash"`
+	// SquareSize is the width of the original data square.
+	SquareSize int `json:"square_size"`
+	// DiskSize is the size of the EDS file in bytes.
+	DiskSize int64 `json:"disk_size"`
+	// Namespaces are the distinct namespaces of the shares in the original data square, in
+	// ascending order.
+	Namespaces []share.Namespace `json:"namespaces"`
+}
+
+// Inspect describes the EDS identified by the given DataHash. Only the original data square is
+// read from the file.
+func (s *Store) Inspect(ctx context.Context, root share.DataHash) (*SquareInfo, error) {
+	ctx, span := tracer.Start(ctx, "store/inspect", trace.WithAttributes(attribute.String("root", root.String())))
+	defer span.End()
+
+	stat, err := os.Stat(s.basepath + blocksPath + root.String())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	dah, err := s.GetDAH(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	f, err := s.GetCAR(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	namespaces, err := readNamespaces(f)
+	if err != nil {
+		return nil, fmt.Errorf("eds/store: failed to read namespaces from CAR: %w", err)
+	}
+
+	return &SquareInfo{
+		DataHash:   root,
+		SquareSize: len(dah.RowRoots) / 2,
+		DiskSize:   stat.Size(),
+		Namespaces: namespaces,
+	}, nil
+}
+
+// readNamespaces reads the distinct namespaces of the original data square from the CARv1 file of
+// an EDS.
+func readNamespaces(carReader io.Reader) ([]share.Namespace, error) {
+	odsReader, err := ODSReader(carReader)
+	if err != nil {
+		return nil, err
+	}
+	r, err := car.NewCarReader(odsReader)
+	if err != nil {
+		return nil, err
+	}
+
+	var namespaces []share.Namespace
+	for {
+		block, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		// leaves are stored with their namespace prepended, see prependNamespace
+		namespaces = append(namespaces, block.RawData()[:share.NamespaceSize])
+	}
+
+	sort.Slice(namespaces, func(i, j int) bool {
+		return namespaces[i].IsLess(namespaces[j])
+	})
+	distinct := namespaces[:0]
+	for _, ns := range namespaces {
+		if len(distinct) == 0 || !bytes.Equal(distinct[len(distinct)-1], ns) {
+			distinct = append(distinct, ns)
+		}
+	}
+	return distinct, nil
+}
@@ -12,6 +12,9 @@ import (
 	"github.com/multiformats/go-multihash"
 )
 
+// invertedIndexPrefix is the datastore namespace of the inverted index.
+var invertedIndexPrefix = ds.NewKey("/inverted/index")
+
 // simpleInvertedIndex is an inverted index that only stores a single shard key per multihash. Its
 // implementation is modified from the default upstream implementation in dagstore/index.
 type simpleInvertedIndex struct {
@@ -23,7 +26,7 @@ type simpleInvertedIndex struct {
 // don't care which shard is used to serve a cid.
 func newSimpleInvertedIndex(dts ds.Batching) *simpleInvertedIndex {
 	return &simpleInvertedIndex{
-		ds: namespace.Wrap(dts, ds.NewKey("/inverted/index")),
+		ds: namespace.Wrap(dts, invertedIndexPrefix),
 	}
 }
 
@@ -0,0 +1,68 @@
+package eds
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// rowBufPool pools the buffers rows of EDSes are JSON encoded into.
+var rowBufPool = sync.Pool{
+	New: func() any {
+		return new(bytes.Buffer)
+	},
+}
+
+// WriteJSON writes the JSON encoding of the EDS to the given writer row by row, producing the same
+// output as the EDS's MarshalJSON without holding the whole encoding in memory.
+func WriteJSON(w io.Writer, eds *rsmt2d.ExtendedDataSquare) error {
+	// the codec is not exposed by the EDS, but the node extends all EDSes with the default one
+	codec, err := json.Marshal(share.DefaultRSMT2DCodec().Name())
+	if err != nil {
+		return err
+	}
+
+	buf := rowBufPool.Get().(*bytes.Buffer)
+	defer rowBufPool.Put(buf)
+	buf.Reset()
+
+	buf.WriteString(`{"data_square":[`)
+	shares, width := eds.Flattened(), int(eds.Width())
+	for row := 0; row < width; row++ {
+		for col := 0; col < width; col++ {
+			if row > 0 || col > 0 {
+				buf.WriteByte(',')
+			}
+			writeJSONBytes(buf, shares[row*width+col])
+		}
+		if _, err = w.Write(buf.Bytes()); err != nil {
+			return err
+		}
+		buf.Reset()
+	}
+	buf.WriteString(`],"codec":`)
+	buf.Write(codec)
+	buf.WriteByte('}')
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// writeJSONBytes writes the byte slice the way encoding/json encodes it: as a base64 string or
+// null, if it is nil.
+func writeJSONBytes(buf *bytes.Buffer, b []byte) {
+	if b == nil {
+		buf.WriteString("null")
+		return
+	}
+	buf.WriteByte('"')
+	enc := base64.NewEncoder(base64.StdEncoding, buf)
+	_, _ = enc.Write(b)
+	_ = enc.Close()
+	buf.WriteByte('"')
+}
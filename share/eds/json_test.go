@@ -0,0 +1,22 @@
+package eds
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/share/eds/edstest"
+)
+
+func TestWriteJSON(t *testing.T) {
+	eds := edstest.RandEDS(t, 4)
+	expected, err := json.Marshal(eds)
+	require.NoError(t, err)
+
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, WriteJSON(buf, eds))
+	assert.Equal(t, string(expected), buf.String())
+}
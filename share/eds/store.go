@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -57,10 +58,19 @@ type Store struct {
 
 	basepath   string
 	gcInterval time.Duration
+	// orphanLk keeps Compact from removing the CAR files written by Put before they are registered.
+	orphanLk sync.RWMutex
+	// pausedBy is the reason Put fails with while writes are paused.
+	pausedBy atomic.Pointer[error]
 	// lastGCResult is only stored on the store for testing purposes.
 	lastGCResult atomic.Pointer[dagstore.GCResult]
 }
 
+// DatastorePrefixes returns the namespaces of the keys the Store writes to its datastore.
+func DatastorePrefixes() []datastore.Key {
+	return []datastore.Key{dagstore.StoreNamespace, invertedIndexPrefix}
+}
+
 // NewStore creates a new EDS Store under the given basepath and datastore.
 func NewStore(basepath string, ds datastore.Batching) (*Store, error) {
 	err := setupPath(basepath)
@@ -166,6 +176,9 @@ func (s *Store) Put(ctx context.Context, root share.DataHash, square *rsmt2d.Ext
 	if has {
 		return dagstore.ErrShardExists
 	}
+	if reason := s.pausedBy.Load(); reason != nil {
+		return *reason
+	}
 
 	ctx, span := tracer.Start(ctx, "store/put", trace.WithAttributes(
 		attribute.String("root", root.String()),
@@ -175,6 +188,9 @@ func (s *Store) Put(ctx context.Context, root share.DataHash, square *rsmt2d.Ext
 		utils.SetStatusAndEnd(span, err)
 	}()
 
+	s.orphanLk.RLock()
+	defer s.orphanLk.RUnlock()
+
 	key := root.String()
 	f, err := os.OpenFile(s.basepath+blocksPath+key, os.O_CREATE|os.O_WRONLY, 0600)
 	if err != nil {
@@ -225,6 +241,17 @@ func (s *Store) GetCAR(ctx context.Context, root share.DataHash) (io.Reader, err
 	return accessor.sa.Reader(), nil
 }
 
+// PauseWrites makes Put fail with the given reason instead of writing new EDSes, until
+// ResumeWrites is called, e.g. to keep from running out of disk space midway.
+func (s *Store) PauseWrites(reason error) {
+	s.pausedBy.Store(&reason)
+}
+
+// ResumeWrites lets Put write new EDSes again after PauseWrites.
+func (s *Store) ResumeWrites() {
+	s.pausedBy.Store(nil)
+}
+
 // Blockstore returns an IPFS blockstore providing access to individual shares/nodes of all EDS
 // registered on the Store. NOTE: The blockstore does not store whole Celestia Blocks but IPFS
 // blocks. We represent `shares` and NMT Merkle proofs as IPFS blocks and IPLD nodes so Bitswap can
@@ -137,6 +137,31 @@ func TestEDSStore(t *testing.T) {
 		assert.True(t, ok)
 	})
 
+	t.Run("Inspect", func(t *testing.T) {
+		eds, dah := randomEDS(t)
+
+		_, err := edsStore.Inspect(ctx, dah.Hash())
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		err = edsStore.Put(ctx, dah.Hash(), eds)
+		require.NoError(t, err)
+
+		info, err := edsStore.Inspect(ctx, dah.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, share.DataHash(dah.Hash()), info.DataHash)
+		assert.Equal(t, 4, info.SquareSize)
+
+		stat, err := os.Stat(edsStore.basepath + blocksPath + dah.String())
+		require.NoError(t, err)
+		assert.Equal(t, stat.Size(), info.DiskSize)
+
+		// every share of the random ODS has its own namespace
+		require.Len(t, info.Namespaces, 16)
+		for i := 1; i < len(info.Namespaces); i++ {
+			assert.True(t, info.Namespaces[i-1].IsLess(info.Namespaces[i]))
+		}
+	})
+
 	t.Run("BlockstoreCache", func(t *testing.T) {
 		eds, dah := randomEDS(t)
 
@@ -191,6 +216,48 @@ func TestEDSStore_GC(t *testing.T) {
 	assert.Nil(t, edsStore.lastGCResult.Load().Shards[shardKey])
 }
 
+func TestEDSStore_Compact(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	edsStore, err := newStore(t)
+	require.NoError(t, err)
+	err = edsStore.Start(ctx)
+	require.NoError(t, err)
+
+	eds, dah := randomEDS(t)
+	err = edsStore.Put(ctx, dah.Hash(), eds)
+	require.NoError(t, err)
+
+	// the index of the EDS is lost, and files of an unknown EDS are left behind
+	key := shard.KeyFromString(dah.String())
+	_, err = edsStore.carIdx.DropFullIndex(key)
+	require.NoError(t, err)
+	orphan := []byte("orphan")
+	err = os.WriteFile(edsStore.basepath+blocksPath+"orphan", orphan, 0600)
+	require.NoError(t, err)
+	err = os.WriteFile(edsStore.basepath+indexPath+"orphan"+indexSuffix, orphan, 0600)
+	require.NoError(t, err)
+
+	res, err := edsStore.Compact(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.Reindexed)
+	assert.Equal(t, 2, res.Orphans)
+	assert.NoFileExists(t, edsStore.basepath+blocksPath+"orphan")
+	assert.NoFileExists(t, edsStore.basepath+indexPath+"orphan"+indexSuffix)
+
+	stat, err := edsStore.carIdx.StatFullIndex(key)
+	require.NoError(t, err)
+	assert.True(t, stat.Exists)
+	_, err = edsStore.Get(ctx, dah.Hash())
+	require.NoError(t, err)
+
+	// nothing is left to compact
+	res, err = edsStore.Compact(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, &CompactResult{}, res)
+}
+
 func Test_BlockstoreCache(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	t.Cleanup(cancel)
@@ -2,6 +2,7 @@ package share
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -14,6 +15,18 @@ import (
 var (
 	// ErrNotFound is used to indicate that requested data could not be found.
 	ErrNotFound = errors.New("share: data not found")
+	// ErrIntegrityMismatch is used to indicate that the data received from a source does not match
+	// the Root it was requested by.
+	ErrIntegrityMismatch = errors.New("share: content integrity mismatch")
+	// ErrRateLimited is used to indicate that the source of the data rejected the request, as it
+	// serves too many requests at the moment.
+	ErrRateLimited = errors.New("share: rate limited")
+	// ErrInvalidResponse is used to indicate that the source of the data responded invalidly or
+	// failed internally.
+	ErrInvalidResponse = errors.New("share: invalid response")
+	// ErrTimeout is used to indicate that the source did not serve the data within the time given to
+	// it.
+	ErrTimeout = errors.New("share: timeout")
 )
 
 // Getter interface provides a set of accessors for shares by the Root.
@@ -76,6 +89,42 @@ func (ns NamespacedShares) Verify(root *Root, namespace Namespace) error {
 	return nil
 }
 
+type jsonNamespacedRow struct {
+	Shares []Share
+	Proof  json.RawMessage
+}
+
+// MarshalJSON encodes the row with the canonical JSON of its proof, keeping the field names the
+// row was always encoded with.
+func (row NamespacedRow) MarshalJSON() ([]byte, error) {
+	proof := json.RawMessage("null")
+	if row.Proof != nil {
+		var err error
+		proof, err = MarshalNMTProofJSON(row.Proof)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(&jsonNamespacedRow{Shares: row.Shares, Proof: proof})
+}
+
+// UnmarshalJSON decodes the row with the canonical JSON of its proof.
+func (row *NamespacedRow) UnmarshalJSON(data []byte) error {
+	var r jsonNamespacedRow
+	if err := json.Unmarshal(data, &r); err != nil {
+		return err
+	}
+	row.Shares, row.Proof = r.Shares, nil
+	if len(r.Proof) != 0 && string(r.Proof) != "null" {
+		proof, err := UnmarshalNMTProofJSON(r.Proof)
+		if err != nil {
+			return err
+		}
+		row.Proof = proof
+	}
+	return nil
+}
+
 // verify validates the row using nmt inclusion proof.
 func (row *NamespacedRow) verify(rowRoot []byte, namespace Namespace) bool {
 	// construct nmt leaves from shares by prepending namespace
@@ -0,0 +1,204 @@
+package getters
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/libs/utils"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+var _ share.Getter = (*CacheGetter)(nil)
+
+// CacheGetter is a share.Getter that wraps a getter and keeps the most recently retrieved EDSes,
+// shares and namespaced shares in memory, bounded by their total size in bytes. Data is keyed
+// by the DataHash of the given root, so repeated requests for the same block are served without
+// hitting the wrapped getter.
+type CacheGetter struct {
+	getter share.Getter
+
+	lock sync.Mutex
+	// cache evicts the least recently used entries once the total size exceeds maxSize.
+	cache   *lru.Cache
+	size    int
+	maxSize int
+
+	metrics *cacheMetrics
+}
+
+// cacheEntry is the value that we store in the cache
+type cacheEntry struct {
+	value any
+	size  int
+}
+
+// NewCacheGetter creates a new CacheGetter keeping up to maxSize bytes of data.
+func NewCacheGetter(getter share.Getter, maxSize int) (*CacheGetter, error) {
+	if maxSize <= 0 {
+		return nil, fmt.Errorf("getter/cache: size must be positive, got %d", maxSize)
+	}
+
+	cg := &CacheGetter{
+		getter:  getter,
+		maxSize: maxSize,
+	}
+	// the cache is bounded by the size of entries instead of their amount
+	cache, err := lru.NewWithEvict(math.MaxInt32, func(_, val interface{}) {
+		cg.size -= val.(*cacheEntry).size
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getter/cache: failed to instantiate cache: %w", err)
+	}
+	cg.cache = cache
+	return cg, nil
+}
+
+func (cg *CacheGetter) GetShare(ctx context.Context, root *share.Root, row, col int) (shr share.Share, err error) {
+	ctx, span := tracer.Start(ctx, "cache/get-share", trace.WithAttributes(
+		attribute.String("root", root.String()),
+		attribute.Int("row", row),
+		attribute.Int("col", col),
+	))
+	defer func() {
+		utils.SetStatusAndEnd(span, err)
+	}()
+
+	// serve the share from the whole square, if it was retrieved before
+	if val, ok := cg.get(edsKey(root)); ok {
+		eds := val.(*rsmt2d.ExtendedDataSquare)
+		if uint(row) < eds.Width() && uint(col) < eds.Width() {
+			cg.metrics.observe(ctx, "GetShare", true)
+			span.SetAttributes(attribute.Bool("cache-hit", true))
+			return eds.GetCell(uint(row), uint(col)), nil
+		}
+	}
+
+	key := fmt.Sprintf("share/%X/%d/%d", root.Hash(), row, col)
+	if val, ok := cg.get(key); ok {
+		cg.metrics.observe(ctx, "GetShare", true)
+		span.SetAttributes(attribute.Bool("cache-hit", true))
+		return val.(share.Share), nil
+	}
+	cg.metrics.observe(ctx, "GetShare", false)
+
+	shr, err = cg.getter.GetShare(ctx, root, row, col)
+	if err != nil {
+		return nil, err
+	}
+	cg.add(key, shr, len(shr))
+	return shr, nil
+}
+
+func (cg *CacheGetter) GetEDS(ctx context.Context, root *share.Root) (eds *rsmt2d.ExtendedDataSquare, err error) {
+	ctx, span := tracer.Start(ctx, "cache/get-eds", trace.WithAttributes(
+		attribute.String("root", root.String()),
+	))
+	defer func() {
+		utils.SetStatusAndEnd(span, err)
+	}()
+
+	key := edsKey(root)
+	if val, ok := cg.get(key); ok {
+		cg.metrics.observe(ctx, "GetEDS", true)
+		span.SetAttributes(attribute.Bool("cache-hit", true))
+		return val.(*rsmt2d.ExtendedDataSquare), nil
+	}
+	cg.metrics.observe(ctx, "GetEDS", false)
+
+	eds, err = cg.getter.GetEDS(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	width := int(eds.Width())
+	cg.add(key, eds, width*width*share.Size)
+	return eds, nil
+}
+
+func (cg *CacheGetter) GetSharesByNamespace(
+	ctx context.Context,
+	root *share.Root,
+	namespace share.Namespace,
+) (shares share.NamespacedShares, err error) {
+	ctx, span := tracer.Start(ctx, "cache/get-shares-by-namespace", trace.WithAttributes(
+		attribute.String("root", root.String()),
+		attribute.String("namespace", namespace.String()),
+	))
+	defer func() {
+		utils.SetStatusAndEnd(span, err)
+	}()
+
+	key := fmt.Sprintf("nd/%X/%X", root.Hash(), []byte(namespace))
+	if val, ok := cg.get(key); ok {
+		cg.metrics.observe(ctx, "GetSharesByNamespace", true)
+		span.SetAttributes(attribute.Bool("cache-hit", true))
+		return val.(share.NamespacedShares), nil
+	}
+	cg.metrics.observe(ctx, "GetSharesByNamespace", false)
+
+	shares, err = cg.getter.GetSharesByNamespace(ctx, root, namespace)
+	if err != nil {
+		return nil, err
+	}
+	cg.add(key, shares, namespacedSharesSize(shares))
+	return shares, nil
+}
+
+func (cg *CacheGetter) get(key string) (any, bool) {
+	cg.lock.Lock()
+	defer cg.lock.Unlock()
+
+	val, ok := cg.cache.Get(key)
+	if !ok {
+		return nil, false
+	}
+	return val.(*cacheEntry).value, true
+}
+
+// add puts the value into the cache, evicting the least recently used entries until the total
+// size fits. Values larger than the whole cache are not kept.
+func (cg *CacheGetter) add(key string, value any, size int) {
+	if size > cg.maxSize {
+		return
+	}
+
+	cg.lock.Lock()
+	defer cg.lock.Unlock()
+
+	if cg.cache.Contains(key) {
+		return
+	}
+	cg.cache.Add(key, &cacheEntry{value: value, size: size})
+	cg.size += size
+	for cg.size > cg.maxSize {
+		cg.cache.RemoveOldest()
+	}
+}
+
+func edsKey(root *share.Root) string {
+	return fmt.Sprintf("eds/%X", root.Hash())
+}
+
+// namespacedSharesSize estimates the memory taken by the shares and proofs. Empty results still
+// take a share's worth of space, so that the cache stays bounded.
+func namespacedSharesSize(shares share.NamespacedShares) int {
+	size := share.Size
+	for _, row := range shares {
+		for _, shr := range row.Shares {
+			size += len(shr)
+		}
+		if row.Proof != nil {
+			for _, node := range row.Proof.Nodes() {
+				size += len(node)
+			}
+		}
+	}
+	return size
+}
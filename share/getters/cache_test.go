@@ -0,0 +1,86 @@
+package getters
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-app/pkg/da"
+
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds/edstest"
+	"github.com/celestiaorg/celestia-node/share/mocks"
+)
+
+func TestCacheGetter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	ctrl := gomock.NewController(t)
+	square := edstest.RandEDS(t, 4)
+	dah, err := da.NewDataAvailabilityHeader(square)
+	require.NoError(t, err)
+	root := &dah
+
+	getter := mocks.NewMockGetter(ctrl)
+	// every method hits the wrapped getter only once
+	getter.EXPECT().GetShare(gomock.Any(), root, 1, 1).Return(square.GetCell(1, 1), nil).Times(1)
+	getter.EXPECT().GetEDS(gomock.Any(), root).Return(square, nil).Times(1)
+	getter.EXPECT().GetSharesByNamespace(gomock.Any(), root, gomock.Any()).
+		Return(share.NamespacedShares{}, nil).Times(1)
+
+	width := int(square.Width())
+	cg, err := NewCacheGetter(getter, width*width*share.Size)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		sh, err := cg.GetShare(ctx, root, 1, 1)
+		require.NoError(t, err)
+		require.Equal(t, square.GetCell(1, 1), sh)
+	}
+
+	for i := 0; i < 2; i++ {
+		eds, err := cg.GetEDS(ctx, root)
+		require.NoError(t, err)
+		require.Equal(t, square, eds)
+	}
+
+	// once the square is cached, any share is served from it
+	sh, err := cg.GetShare(ctx, root, 2, 3)
+	require.NoError(t, err)
+	require.Equal(t, square.GetCell(2, 3), sh)
+
+	namespace := share.Namespace(square.GetCell(0, 0)[:share.NamespaceSize])
+	for i := 0; i < 2; i++ {
+		_, err := cg.GetSharesByNamespace(ctx, root, namespace)
+		require.NoError(t, err)
+	}
+
+	// the namespaced shares evicted the least recently used share, while the square fills the cache
+	// up to its size
+	require.LessOrEqual(t, cg.size, cg.maxSize)
+	_, ok := cg.get(edsKey(root))
+	require.False(t, ok)
+}
+
+func TestCacheGetter_Errors(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	_, err := NewCacheGetter(nil, 0)
+	require.Error(t, err)
+
+	ctrl := gomock.NewController(t)
+	getter := mocks.NewMockGetter(ctrl)
+	// failures are not cached
+	getter.EXPECT().GetEDS(gomock.Any(), gomock.Any()).Return(nil, share.ErrNotFound).Times(2)
+
+	cg, err := NewCacheGetter(getter, share.Size)
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		_, err = cg.GetEDS(ctx, &share.Root{})
+		require.ErrorIs(t, err, share.ErrNotFound)
+	}
+}
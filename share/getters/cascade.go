@@ -3,6 +3,8 @@ package getters
 import (
 	"context"
 	"errors"
+	"sync/atomic"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -21,13 +23,53 @@ var _ share.Getter = (*CascadeGetter)(nil)
 // See cascade func for details on cascading.
 type CascadeGetter struct {
 	getters []share.Getter
+	// hedgeDelay enables hedged cascading when positive. See hedgedCascadeGetters for details.
+	hedgeDelay time.Duration
+	// stats enables adaptive ordering of getters when set. See WithAdaptiveOrder for details.
+	stats map[share.Getter]*getterStats
+	// pinned is the amount of the first getters kept in place by the adaptive ordering
+	pinned int
+	// requests counts the ordered requests, to explore demoted getters at an interval
+	requests atomic.Uint64
+	// fallbackOn are the classes of errors the next getter is tried after, or any if nil. See
+	// WithFallbackOn for details.
+	fallbackOn map[ErrorClass]bool
+
+	metrics *cascadeMetrics
+}
+
+// CascadeOption is a function that configures CascadeGetter.
+type CascadeOption func(*CascadeGetter)
+
+// WithHedgeDelay makes CascadeGetter launch the next getter after the given delay, while the
+// previous ones are still in flight. Zero delay keeps getters strictly sequential.
+func WithHedgeDelay(delay time.Duration) CascadeOption {
+	return func(cg *CascadeGetter) {
+		cg.hedgeDelay = delay
+	}
 }
 
-// NewCascadeGetter instantiates a new CascadeGetter from given share.Getters with given interval.
-func NewCascadeGetter(getters []share.Getter) *CascadeGetter {
-	return &CascadeGetter{
+// WithFallbackOn makes CascadeGetter try the next getter only after failures with errors of the
+// given classes, and return the error right away after the others, e.g. not to try every getter in
+// turn for data none of them serves. By default, the next getter is tried after any failure.
+func WithFallbackOn(classes ...ErrorClass) CascadeOption {
+	return func(cg *CascadeGetter) {
+		cg.fallbackOn = make(map[ErrorClass]bool, len(classes))
+		for _, class := range classes {
+			cg.fallbackOn[class] = true
+		}
+	}
+}
+
+// NewCascadeGetter instantiates a new CascadeGetter from given share.Getters with given options.
+func NewCascadeGetter(getters []share.Getter, opts ...CascadeOption) *CascadeGetter {
+	cg := &CascadeGetter{
 		getters: getters,
 	}
+	for _, opt := range opts {
+		opt(cg)
+	}
+	return cg
 }
 
 // GetShare gets a share from any of registered share.Getters in cascading order.
@@ -42,8 +84,12 @@ func (cg *CascadeGetter) GetShare(ctx context.Context, root *share.Root, row, co
 	get := func(ctx context.Context, get share.Getter) (share.Share, error) {
 		return get.GetShare(ctx, root, row, col)
 	}
+	get = track(cg.stats, instrument(cg.metrics, "GetShare", get))
 
-	return cascadeGetters(ctx, cg.getters, get)
+	if cg.hedgeDelay > 0 {
+		return hedgedCascadeGetters(ctx, cg.order(), cg.fallback, cg.hedgeDelay, get)
+	}
+	return cascadeGetters(ctx, cg.order(), cg.fallback, get)
 }
 
 // GetEDS gets a full EDS from any of registered share.Getters in cascading order.
@@ -56,8 +102,12 @@ func (cg *CascadeGetter) GetEDS(ctx context.Context, root *share.Root) (*rsmt2d.
 	get := func(ctx context.Context, get share.Getter) (*rsmt2d.ExtendedDataSquare, error) {
 		return get.GetEDS(ctx, root)
 	}
+	get = track(cg.stats, instrument(cg.metrics, "GetEDS", get))
 
-	return cascadeGetters(ctx, cg.getters, get)
+	if cg.hedgeDelay > 0 {
+		return hedgedCascadeGetters(ctx, cg.order(), cg.fallback, cg.hedgeDelay, get)
+	}
+	return cascadeGetters(ctx, cg.order(), cg.fallback, get)
 }
 
 // GetSharesByNamespace gets NamespacedShares from any of registered share.Getters in cascading
@@ -76,8 +126,25 @@ func (cg *CascadeGetter) GetSharesByNamespace(
 	get := func(ctx context.Context, get share.Getter) (share.NamespacedShares, error) {
 		return get.GetSharesByNamespace(ctx, root, namespace)
 	}
+	get = track(cg.stats, instrument(cg.metrics, "GetSharesByNamespace", get))
 
-	return cascadeGetters(ctx, cg.getters, get)
+	if cg.hedgeDelay > 0 {
+		return hedgedCascadeGetters(ctx, cg.order(), cg.fallback, cg.hedgeDelay, get)
+	}
+	return cascadeGetters(ctx, cg.order(), cg.fallback, get)
+}
+
+// fallback reports whether the next getter is tried after a getter failed with the given error.
+func (cg *CascadeGetter) fallback(err error) bool {
+	if cg.fallbackOn == nil {
+		return true
+	}
+	for _, class := range ErrorClassesOf(err) {
+		if cg.fallbackOn[class] {
+			return true
+		}
+	}
+	return false
 }
 
 // cascade implements a cascading retry algorithm for getting a value from multiple sources.
@@ -85,12 +152,14 @@ func (cg *CascadeGetter) GetSharesByNamespace(
 // given interval until either:
 //   - One of the sources returns the value
 //   - All of the sources errors
+//   - One of the sources errors with an error the fallback function, if given, rejects
 //   - Context is canceled
 //
 // NOTE: New source attempts after interval do suspend running sources in progress.
 func cascadeGetters[V any](
 	ctx context.Context,
 	getters []share.Getter,
+	fallback func(error) bool,
 	get func(context.Context, share.Getter) (V, error),
 ) (V, error) {
 	var (
@@ -133,6 +202,117 @@ func cascadeGetters[V any](
 		if ctx.Err() != nil {
 			return zero, err
 		}
+		if fallback != nil && !fallback(getErr) {
+			log.Debugw("cascade: not falling back after the error", "getter_idx", i, "err", getErr)
+			return zero, err
+		}
 	}
 	return zero, err
 }
+
+// hedgedCascadeGetters is a variant of cascadeGetters that does not wait for a getter to fail
+// before launching the next one. The next getter is launched once the given delay passes or the
+// previous getter fails, whichever comes first, while the getters in flight keep running. The first
+// value returned by any of the getters wins and the rest of them are canceled, as they are once any
+// of them fails with an error the fallback function, if given, rejects.
+func hedgedCascadeGetters[V any](
+	ctx context.Context,
+	getters []share.Getter,
+	fallback func(error) bool,
+	delay time.Duration,
+	get func(context.Context, share.Getter) (V, error),
+) (V, error) {
+	var (
+		zero V
+		err  error
+	)
+
+	if len(getters) == 0 {
+		return zero, errors.New("no getters provided")
+	}
+
+	ctx, span := tracer.Start(ctx, "hedged-cascade", trace.WithAttributes(
+		attribute.Int("total-getters", len(getters)),
+		attribute.String("delay", delay.String()),
+	))
+	defer func() {
+		if err != nil {
+			utils.SetStatusAndEnd(span, errors.New("all getters failed"))
+		}
+	}()
+
+	// cancel getters still in flight once any of them succeeds
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		idx int
+		val V
+		err error
+	}
+	// buffered, so getters finishing after return do not block
+	results := make(chan result, len(getters))
+
+	var (
+		next, inFlight int
+		timer          = time.NewTimer(delay)
+	)
+	defer timer.Stop()
+	launch := func() {
+		idx := next
+		log.Debugf("hedged cascade: launching getter #%d", idx)
+		span.AddEvent("getter launched", trace.WithAttributes(attribute.Int("getter_idx", idx)))
+
+		go func() {
+			val, getErr := get(ctx, getters[idx])
+			results <- result{idx: idx, val: val, err: getErr}
+		}()
+		next++
+		inFlight++
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		if next < len(getters) {
+			timer.Reset(delay)
+		}
+	}
+
+	launch()
+	for {
+		select {
+		case res := <-results:
+			inFlight--
+			if res.err == nil {
+				return res.val, nil
+			}
+
+			if !errors.Is(res.err, errOperationNotSupported) {
+				err = errors.Join(err, res.err)
+				span.RecordError(res.err, trace.WithAttributes(attribute.Int("getter_idx", res.idx)))
+				if fallback != nil && !fallback(res.err) {
+					log.Debugw("hedged cascade: not falling back after the error", "getter_idx", res.idx, "err", res.err)
+					return zero, err
+				}
+			}
+			if next < len(getters) {
+				// no reason to wait for the delay, as one less getter is in flight
+				launch()
+				continue
+			}
+			if inFlight == 0 {
+				return zero, err
+			}
+		case <-timer.C:
+			if next < len(getters) {
+				launch()
+			}
+		case <-ctx.Done():
+			err = errors.Join(err, ctx.Err())
+			return zero, err
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package getters
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+const (
+	// statsWindow is the amount of the most recent requests the stats of a getter are computed from.
+	statsWindow = 100
+	// minStatsSamples is the amount of requests every getter has to serve before the cascade is
+	// reordered.
+	minStatsSamples = 10
+	// exploreEvery is the interval, in requests, at which a getter is tried first regardless of its
+	// score, so that demoted getters keep getting samples and can recover their score.
+	exploreEvery = 20
+)
+
+// getterStats tracks the success rate and the p95 latency of the recent requests to a getter.
+type getterStats struct {
+	lock sync.Mutex
+	// samples is a ring buffer of the most recent requests
+	samples []sample
+	next    int
+
+	successRate float64
+	p95         time.Duration
+}
+
+type sample struct {
+	took    time.Duration
+	success bool
+}
+
+func newGetterStats() *getterStats {
+	return &getterStats{samples: make([]sample, 0, statsWindow)}
+}
+
+// record adds the result of a request to the stats.
+func (s *getterStats) record(took time.Duration, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	smpl := sample{took: took, success: err == nil}
+	if len(s.samples) < statsWindow {
+		s.samples = append(s.samples, smpl)
+	} else {
+		s.samples[s.next] = smpl
+		s.next = (s.next + 1) % statsWindow
+	}
+
+	var succeeded int
+	latencies := make([]time.Duration, len(s.samples))
+	for i, smpl := range s.samples {
+		if smpl.success {
+			succeeded++
+		}
+		latencies[i] = smpl.took
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	s.successRate = float64(succeeded) / float64(len(s.samples))
+	s.p95 = latencies[(len(latencies)*95-1)/100]
+}
+
+// score estimates the cost of trying the getter, as the time it takes to get a successful result.
+// Lower is better. Getters without enough samples are not scored.
+func (s *getterStats) score() (float64, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if len(s.samples) < minStatsSamples {
+		return 0, false
+	}
+	if s.successRate == 0 {
+		return math.Inf(1), true
+	}
+	return float64(s.p95) / s.successRate, true
+}
+
+// WithAdaptiveOrder makes CascadeGetter track the success rate and p95 latency of each getter and
+// try the getters that are expected to serve requests the fastest first. The first pinned getters,
+// e.g. the local store, keep their place ahead of the reordered ones. The given order is kept until
+// every getter served enough requests to be compared, and every exploreEvery requests the next
+// getter, round-robin, is tried first, so that a demoted getter can recover its score.
+func WithAdaptiveOrder(pinned int) CascadeOption {
+	return func(cg *CascadeGetter) {
+		cg.pinned = pinned
+		cg.stats = make(map[share.Getter]*getterStats, len(cg.getters))
+		for _, getter := range cg.getters {
+			cg.stats[getter] = newGetterStats()
+		}
+	}
+}
+
+// order returns the getters in the order to try them in.
+func (cg *CascadeGetter) order() []share.Getter {
+	if cg.stats == nil || cg.pinned >= len(cg.getters)-1 {
+		return cg.getters
+	}
+
+	reordered := cg.getters[cg.pinned:]
+	scores := make(map[share.Getter]float64, len(reordered))
+	for _, getter := range reordered {
+		score, ok := cg.stats[getter].score()
+		if !ok {
+			return cg.getters
+		}
+		scores[getter] = score
+	}
+
+	ordered := make([]share.Getter, len(cg.getters))
+	copy(ordered, cg.getters)
+	sort.SliceStable(ordered[cg.pinned:], func(i, j int) bool {
+		return scores[ordered[cg.pinned+i]] < scores[ordered[cg.pinned+j]]
+	})
+
+	if n := cg.requests.Add(1); n%exploreEvery == 0 {
+		explored := reordered[(n/exploreEvery)%uint64(len(reordered))]
+		for i := cg.pinned; i < len(ordered); i++ {
+			if ordered[i] == explored {
+				copy(ordered[cg.pinned+1:i+1], ordered[cg.pinned:i])
+				ordered[cg.pinned] = explored
+				break
+			}
+		}
+	}
+	return ordered
+}
+
+// track wraps a request to a cascaded getter, recording its result into the getter's stats.
+func track[V any](
+	stats map[share.Getter]*getterStats,
+	get func(context.Context, share.Getter) (V, error),
+) func(context.Context, share.Getter) (V, error) {
+	if stats == nil {
+		return get
+	}
+	return func(ctx context.Context, getter share.Getter) (V, error) {
+		start := time.Now()
+		val, err := get(ctx, getter)
+		// unsupported operations and requests canceled by the cascade say nothing about the getter
+		if !errors.Is(err, errOperationNotSupported) && !errors.Is(err, context.Canceled) {
+			stats[getter].record(time.Since(start), err)
+		}
+		return val, err
+	}
+}
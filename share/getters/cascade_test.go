@@ -3,8 +3,10 @@ package getters
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -72,31 +74,31 @@ func TestCascade(t *testing.T) {
 
 	t.Run("SuccessFirst", func(t *testing.T) {
 		getters := []share.Getter{successGetter, timeoutGetter, immediateFailGetter}
-		_, err := cascadeGetters(ctx, getters, get)
+		_, err := cascadeGetters(ctx, getters, nil, get)
 		assert.NoError(t, err)
 	})
 
 	t.Run("SuccessSecond", func(t *testing.T) {
 		getters := []share.Getter{immediateFailGetter, successGetter}
-		_, err := cascadeGetters(ctx, getters, get)
+		_, err := cascadeGetters(ctx, getters, nil, get)
 		assert.NoError(t, err)
 	})
 
 	t.Run("SuccessSecondAfterFirst", func(t *testing.T) {
 		getters := []share.Getter{timeoutGetter, successGetter}
-		_, err := cascadeGetters(ctx, getters, get)
+		_, err := cascadeGetters(ctx, getters, nil, get)
 		assert.NoError(t, err)
 	})
 
 	t.Run("SuccessAfterMultipleTimeouts", func(t *testing.T) {
 		getters := []share.Getter{timeoutGetter, immediateFailGetter, timeoutGetter, timeoutGetter, successGetter}
-		_, err := cascadeGetters(ctx, getters, get)
+		_, err := cascadeGetters(ctx, getters, nil, get)
 		assert.NoError(t, err)
 	})
 
 	t.Run("Error", func(t *testing.T) {
 		getters := []share.Getter{immediateFailGetter, timeoutGetter, immediateFailGetter}
-		_, err := cascadeGetters(ctx, getters, get)
+		_, err := cascadeGetters(ctx, getters, nil, get)
 		assert.Error(t, err)
 		assert.Equal(t, strings.Count(err.Error(), "\n"), 2)
 	})
@@ -105,14 +107,212 @@ func TestCascade(t *testing.T) {
 		ctx, cancel := context.WithCancel(ctx)
 		cancel()
 		getters := []share.Getter{ctxGetter, ctxGetter, ctxGetter}
-		_, err := cascadeGetters(ctx, getters, get)
+		_, err := cascadeGetters(ctx, getters, nil, get)
 		assert.Error(t, err)
 		assert.Equal(t, strings.Count(err.Error(), "\n"), 0)
 	})
 
 	t.Run("Single", func(t *testing.T) {
 		getters := []share.Getter{successGetter}
-		_, err := cascadeGetters(ctx, getters, get)
+		_, err := cascadeGetters(ctx, getters, nil, get)
 		assert.NoError(t, err)
 	})
 }
+
+func TestCascadeFallbackOn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	notFoundGetter := mocks.NewMockGetter(ctrl)
+	rateLimitedGetter := mocks.NewMockGetter(ctrl)
+	successGetter := mocks.NewMockGetter(ctrl)
+	notFoundGetter.EXPECT().GetEDS(gomock.Any(), gomock.Any()).
+		Return(nil, share.ErrNotFound).AnyTimes()
+	rateLimitedGetter.EXPECT().GetEDS(gomock.Any(), gomock.Any()).
+		Return(nil, fmt.Errorf("getter: %w", share.ErrRateLimited)).AnyTimes()
+	// the success getter is reached only after the errors of the classes to fall back on
+	successGetter.EXPECT().GetEDS(gomock.Any(), gomock.Any()).
+		Return(nil, nil).Times(2)
+
+	for _, hedgeDelay := range []time.Duration{0, time.Hour} {
+		getter := NewCascadeGetter(
+			[]share.Getter{notFoundGetter, successGetter},
+			WithFallbackOn(ErrorClassNotFound),
+			WithHedgeDelay(hedgeDelay),
+		)
+		_, err := getter.GetEDS(ctx, nil)
+		assert.NoError(t, err)
+
+		getter = NewCascadeGetter(
+			[]share.Getter{rateLimitedGetter, successGetter},
+			WithFallbackOn(ErrorClassNotFound),
+			WithHedgeDelay(hedgeDelay),
+		)
+		_, err = getter.GetEDS(ctx, nil)
+		assert.ErrorIs(t, err, share.ErrRateLimited)
+	}
+}
+
+func TestErrorClassesOf(t *testing.T) {
+	tests := []struct {
+		err     error
+		classes []ErrorClass
+	}{
+		{share.ErrNotFound, []ErrorClass{ErrorClassNotFound}},
+		{fmt.Errorf("%w: %w", share.ErrTimeout, context.DeadlineExceeded), []ErrorClass{ErrorClassTimeout}},
+		{context.DeadlineExceeded, []ErrorClass{ErrorClassTimeout}},
+		{share.ErrIntegrityMismatch, []ErrorClass{ErrorClassInvalidResponse}},
+		{
+			errors.Join(share.ErrNotFound, share.ErrRateLimited),
+			[]ErrorClass{ErrorClassNotFound, ErrorClassRateLimited},
+		},
+		{errors.New("unknown"), []ErrorClass{ErrorClassOther}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.classes, ErrorClassesOf(tt.err), tt.err.Error())
+	}
+}
+
+func TestHedgedCascade(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	// slowGetter hangs until its request is canceled
+	slowGetter := mocks.NewMockGetter(ctrl)
+	immediateFailGetter := mocks.NewMockGetter(ctrl)
+	successGetter := mocks.NewMockGetter(ctrl)
+	slowGetter.EXPECT().GetEDS(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ *share.Root) (*rsmt2d.ExtendedDataSquare, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}).AnyTimes()
+	immediateFailGetter.EXPECT().GetEDS(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("getter fails immediately")).AnyTimes()
+	successGetter.EXPECT().GetEDS(gomock.Any(), gomock.Any()).
+		Return(nil, nil).AnyTimes()
+
+	get := func(ctx context.Context, get share.Getter) (*rsmt2d.ExtendedDataSquare, error) {
+		return get.GetEDS(ctx, nil)
+	}
+
+	t.Run("SuccessWhileFirstInFlight", func(t *testing.T) {
+		getters := []share.Getter{slowGetter, successGetter}
+		_, err := hedgedCascadeGetters(ctx, getters, nil, time.Millisecond*10, get)
+		assert.NoError(t, err)
+	})
+
+	t.Run("NextLaunchedOnFailure", func(t *testing.T) {
+		// the delay is longer than the test timeout, so the success getter has to be launched right
+		// after the failure
+		getters := []share.Getter{immediateFailGetter, successGetter}
+		_, err := hedgedCascadeGetters(ctx, getters, nil, time.Hour, get)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		getters := []share.Getter{immediateFailGetter, immediateFailGetter, immediateFailGetter}
+		_, err := hedgedCascadeGetters(ctx, getters, nil, time.Millisecond*10, get)
+		assert.Error(t, err)
+		assert.Equal(t, strings.Count(err.Error(), "\n"), 2)
+	})
+
+	t.Run("Context Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(ctx, time.Millisecond*50)
+		defer cancel()
+		getters := []share.Getter{slowGetter, slowGetter}
+		_, err := hedgedCascadeGetters(ctx, getters, nil, time.Millisecond*10, get)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("CascadeGetter", func(t *testing.T) {
+		getter := NewCascadeGetter([]share.Getter{slowGetter, successGetter}, WithHedgeDelay(time.Millisecond*10))
+		_, err := getter.GetEDS(ctx, &share.Root{})
+		assert.NoError(t, err)
+	})
+}
+
+func TestCascade_AdaptiveOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	var calls []string
+	slowGetter := mocks.NewMockGetter(ctrl)
+	slowGetter.EXPECT().GetEDS(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, *share.Root) (*rsmt2d.ExtendedDataSquare, error) {
+			calls = append(calls, "slow")
+			time.Sleep(time.Millisecond * 5)
+			return nil, errors.New("fail")
+		}).AnyTimes()
+	fastGetter := mocks.NewMockGetter(ctrl)
+	fastGetter.EXPECT().GetEDS(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, *share.Root) (*rsmt2d.ExtendedDataSquare, error) {
+			calls = append(calls, "fast")
+			return nil, nil
+		}).AnyTimes()
+
+	pinned := NewCascadeGetter([]share.Getter{slowGetter, fastGetter})
+	adaptive := NewCascadeGetter([]share.Getter{slowGetter, fastGetter}, WithAdaptiveOrder(0))
+
+	// the static order is kept until every getter has enough samples
+	for i := 0; i < minStatsSamples; i++ {
+		calls = calls[:0]
+		_, err := adaptive.GetEDS(ctx, &share.Root{})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"slow", "fast"}, calls)
+	}
+
+	calls = calls[:0]
+	_, err := adaptive.GetEDS(ctx, &share.Root{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"fast"}, calls)
+
+	// the demoted getter is still tried first at an interval, so that it can recover its score
+	var explored int
+	for i := 0; i < exploreEvery*2; i++ {
+		calls = calls[:0]
+		_, err = adaptive.GetEDS(ctx, &share.Root{})
+		assert.NoError(t, err)
+		if calls[0] == "slow" {
+			explored++
+		}
+	}
+	assert.Equal(t, 1, explored)
+
+	// pinned getters keep their place
+	pinnedFirst := NewCascadeGetter([]share.Getter{slowGetter, fastGetter}, WithAdaptiveOrder(1))
+	for i := 0; i < minStatsSamples*2; i++ {
+		calls = calls[:0]
+		_, err = pinnedFirst.GetEDS(ctx, &share.Root{})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"slow", "fast"}, calls)
+	}
+
+	calls = calls[:0]
+	_, err = pinned.GetEDS(ctx, &share.Root{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"slow", "fast"}, calls)
+}
+
+func Test_getterStats(t *testing.T) {
+	stats := newGetterStats()
+	_, ok := stats.score()
+	assert.False(t, ok)
+
+	for i := 1; i <= statsWindow*2; i++ {
+		var err error
+		if i%2 == 0 {
+			err = errors.New("fail")
+		}
+		stats.record(time.Duration(i%statsWindow)*time.Millisecond, err)
+	}
+	assert.Len(t, stats.samples, statsWindow)
+	assert.Equal(t, 0.5, stats.successRate)
+	assert.Equal(t, 94*time.Millisecond, stats.p95)
+
+	score, ok := stats.score()
+	assert.True(t, ok)
+	assert.Equal(t, float64(188*time.Millisecond), score)
+}
@@ -0,0 +1,72 @@
+package getters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// ErrorClass is a class of the errors the getters fail with, by which CascadeGetter decides
+// whether to fall back to the next getter. See WithFallbackOn.
+type ErrorClass string
+
+const (
+	// ErrorClassNotFound is the class of the errors of getters not having the data.
+	ErrorClassNotFound ErrorClass = "not_found"
+	// ErrorClassRateLimited is the class of the errors of getters rejected by overloaded peers.
+	ErrorClassRateLimited ErrorClass = "rate_limited"
+	// ErrorClassInvalidResponse is the class of the errors of getters served invalid data, or failed
+	// internally by the peers.
+	ErrorClassInvalidResponse ErrorClass = "invalid_response"
+	// ErrorClassTimeout is the class of the errors of getters not getting the data in time.
+	ErrorClassTimeout ErrorClass = "timeout"
+	// ErrorClassOther is the class of the errors of none of the other classes.
+	ErrorClassOther ErrorClass = "other"
+)
+
+// ErrorClasses are all the classes of errors.
+var ErrorClasses = []ErrorClass{
+	ErrorClassNotFound,
+	ErrorClassRateLimited,
+	ErrorClassInvalidResponse,
+	ErrorClassTimeout,
+	ErrorClassOther,
+}
+
+// classErrors are the errors the errors of each class wrap.
+var classErrors = map[ErrorClass][]error{
+	ErrorClassNotFound:        {share.ErrNotFound},
+	ErrorClassRateLimited:     {share.ErrRateLimited},
+	ErrorClassInvalidResponse: {share.ErrInvalidResponse, share.ErrIntegrityMismatch},
+	ErrorClassTimeout:         {share.ErrTimeout, context.DeadlineExceeded},
+}
+
+// Validate checks that the class is known.
+func (c ErrorClass) Validate() error {
+	for _, class := range ErrorClasses {
+		if c == class {
+			return nil
+		}
+	}
+	return fmt.Errorf("getters: unknown error class %q, must be one of %v", c, ErrorClasses)
+}
+
+// ErrorClassesOf returns the classes of the given error. An error joined from several failures, e.g.
+// of several requests to different peers, can be of several classes.
+func ErrorClassesOf(err error) []ErrorClass {
+	var classes []ErrorClass
+	for _, class := range ErrorClasses {
+		for _, target := range classErrors[class] {
+			if errors.Is(err, target) {
+				classes = append(classes, class)
+				break
+			}
+		}
+	}
+	if len(classes) == 0 {
+		return []ErrorClass{ErrorClassOther}
+	}
+	return classes
+}
@@ -0,0 +1,158 @@
+package getters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+const (
+	methodLabel  = "method"
+	getterLabel  = "getter"
+	successLabel = "success"
+)
+
+var cascadeMeter = otel.Meter("share/getters/cascade")
+
+// cascadeMetrics records which of the cascaded getters serve requests and how they perform.
+type cascadeMetrics struct {
+	requestTime metric.Float64Histogram
+	failures    metric.Int64Counter
+	served      metric.Int64Counter
+}
+
+// WithMetrics turns on metric collection for every getter the CascadeGetter tries.
+func (cg *CascadeGetter) WithMetrics() error {
+	requestTime, err := cascadeMeter.Float64Histogram("getters_cascade_request_time_hist",
+		metric.WithDescription("duration of requests to a getter in seconds"))
+	if err != nil {
+		return err
+	}
+
+	failures, err := cascadeMeter.Int64Counter("getters_cascade_failures_counter",
+		metric.WithDescription("amount of requests a getter failed to serve"))
+	if err != nil {
+		return err
+	}
+
+	served, err := cascadeMeter.Int64Counter("getters_cascade_served_counter",
+		metric.WithDescription("amount of requests served by a getter"))
+	if err != nil {
+		return err
+	}
+
+	cg.metrics = &cascadeMetrics{
+		requestTime: requestTime,
+		failures:    failures,
+		served:      served,
+	}
+	return nil
+}
+
+// observe records the result of a request to the getter.
+func (m *cascadeMetrics) observe(ctx context.Context, method, getter string, took time.Duration, err error) {
+	if m == nil || errors.Is(err, errOperationNotSupported) {
+		return
+	}
+	if ctx.Err() != nil {
+		ctx = context.Background()
+	}
+
+	attrs := metric.WithAttributes(
+		attribute.String(methodLabel, method),
+		attribute.String(getterLabel, getter),
+	)
+	m.requestTime.Record(ctx, took.Seconds(), attrs,
+		metric.WithAttributes(attribute.Bool(successLabel, err == nil)))
+	if err != nil {
+		m.failures.Add(ctx, 1, attrs)
+		return
+	}
+	m.served.Add(ctx, 1, attrs)
+}
+
+// instrument wraps a request to a cascaded getter, recording metrics of the request and marking
+// the cascade span with the getter that served it.
+func instrument[V any](
+	m *cascadeMetrics,
+	method string,
+	get func(context.Context, share.Getter) (V, error),
+) func(context.Context, share.Getter) (V, error) {
+	return func(ctx context.Context, getter share.Getter) (V, error) {
+		name := getterName(getter)
+		start := time.Now()
+		val, err := get(ctx, getter)
+		m.observe(ctx, method, name, time.Since(start), err)
+		if err == nil {
+			trace.SpanFromContext(ctx).SetAttributes(attribute.String("served-by", name))
+		}
+		return val, err
+	}
+}
+
+// getterName returns the name of the type of the given getter.
+func getterName(getter share.Getter) string {
+	switch g := getter.(type) {
+	case *ShrexGetter:
+		return "shrex"
+	case *IPLDGetter:
+		return "ipld"
+	case *StoreGetter:
+		return "store"
+	case *CascadeGetter:
+		return "cascade"
+	case *TeeGetter:
+		// the tee getter only stores what the wrapped getter retrieves
+		return getterName(g.getter)
+	case *CacheGetter:
+		return "cache"
+	default:
+		return fmt.Sprintf("%T", getter)
+	}
+}
+
+const hitLabel = "hit"
+
+var cacheMeter = otel.Meter("share/getters/cache")
+
+// cacheMetrics records how many requests the CacheGetter serves from memory.
+type cacheMetrics struct {
+	requests metric.Int64Counter
+}
+
+// WithMetrics turns on metric collection of cache hits and misses.
+func (cg *CacheGetter) WithMetrics() error {
+	requests, err := cacheMeter.Int64Counter("getters_cache_requests_counter",
+		metric.WithDescription("amount of requests to the getter cache, labeled by whether they hit it"))
+	if err != nil {
+		return err
+	}
+
+	cg.metrics = &cacheMetrics{
+		requests: requests,
+	}
+	return nil
+}
+
+// observe records whether the request was served from the cache.
+func (m *cacheMetrics) observe(ctx context.Context, method string, hit bool) {
+	if m == nil {
+		return
+	}
+	if ctx.Err() != nil {
+		ctx = context.Background()
+	}
+
+	m.requests.Add(ctx, 1, metric.WithAttributes(
+		attribute.String(methodLabel, method),
+		attribute.Bool(hitLabel, hit),
+	))
+}
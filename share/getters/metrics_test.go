@@ -0,0 +1,65 @@
+package getters
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/mocks"
+)
+
+func TestCascadeGetter_Metrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	prev := otel.GetMeterProvider()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	t.Cleanup(func() {
+		otel.SetMeterProvider(prev)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	ctrl := gomock.NewController(t)
+	failGetter := mocks.NewMockGetter(ctrl)
+	failGetter.EXPECT().GetEDS(gomock.Any(), gomock.Any()).Return(nil, errors.New("fail")).AnyTimes()
+	successGetter := mocks.NewMockGetter(ctrl)
+	successGetter.EXPECT().GetEDS(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	getter := NewCascadeGetter([]share.Getter{failGetter, successGetter})
+	require.NoError(t, getter.WithMetrics())
+	_, err := getter.GetEDS(ctx, &share.Root{})
+	require.NoError(t, err)
+
+	var data metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &data))
+
+	counters := make(map[string]int64)
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if !ok {
+				continue
+			}
+			for _, point := range sum.DataPoints {
+				method, _ := point.Attributes.Value(methodLabel)
+				require.Equal(t, attribute.StringValue("GetEDS"), method)
+				counters[m.Name] += point.Value
+			}
+		}
+	}
+	require.EqualValues(t, 1, counters["getters_cascade_failures_counter"])
+	require.EqualValues(t, 1, counters["getters_cascade_served_counter"])
+}
+
+func Test_getterName(t *testing.T) {
+	require.Equal(t, "ipld", getterName(&IPLDGetter{}))
+	require.Equal(t, "shrex", getterName(NewTeeGetter(&ShrexGetter{}, nil)))
+}
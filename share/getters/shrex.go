@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/libp2p/go-libp2p/core/peer"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
@@ -159,16 +160,29 @@ func (sg *ShrexGetter) GetEDS(ctx context.Context, root *share.Root) (*rsmt2d.Ex
 		switch {
 		case getErr == nil:
 			setStatus(peers.ResultSynced)
+			// only the original quadrant of the square is transferred over the wire
+			odsWidth := int(eds.Width()) / 2
+			sg.peerManager.ObserveLatency(peer, time.Since(reqStart), odsWidth*odsWidth*share.Size)
 			sg.metrics.recordEDSAttempt(ctx, attempt, true)
 			return eds, nil
-		case errors.Is(getErr, context.DeadlineExceeded),
-			errors.Is(getErr, context.Canceled):
+		case errors.Is(getErr, p2p.ErrTimeout),
+			errors.Is(getErr, context.DeadlineExceeded):
+			getErr = fmt.Errorf("%w: %w", share.ErrTimeout, getErr)
+			setStatus(peers.ResultCooldownPeer)
+		case errors.Is(getErr, context.Canceled):
 			setStatus(peers.ResultCooldownPeer)
 		case errors.Is(getErr, p2p.ErrNotFound):
 			getErr = share.ErrNotFound
 			setStatus(peers.ResultCooldownPeer)
+		case errors.Is(getErr, p2p.ErrRateLimited):
+			getErr = share.ErrRateLimited
+			setStatus(peers.ResultCooldownPeer)
 		case errors.Is(getErr, p2p.ErrInvalidResponse):
+			getErr = share.ErrInvalidResponse
 			setStatus(peers.ResultBlacklistPeer)
+		case errors.Is(getErr, share.ErrIntegrityMismatch):
+			reportInvalidData(ctx, "eds", peer, root, getErr)
+			setStatus(peers.ResultQuarantinePeer)
 		default:
 			setStatus(peers.ResultCooldownPeer)
 		}
@@ -237,20 +251,29 @@ func (sg *ShrexGetter) GetSharesByNamespace(
 		case getErr == nil:
 			// both inclusion and non-inclusion cases needs verification
 			if verErr := nd.Verify(root, namespace); verErr != nil {
-				getErr = verErr
-				setStatus(peers.ResultBlacklistPeer)
+				getErr = fmt.Errorf("%w: %w", share.ErrIntegrityMismatch, verErr)
+				reportInvalidData(ctx, "nd", peer, root, getErr, attribute.String("namespace", namespace.String()))
+				setStatus(peers.ResultQuarantinePeer)
 				break
 			}
 			setStatus(peers.ResultNoop)
+			sg.peerManager.ObserveLatency(peer, time.Since(reqStart), len(nd.Flatten())*share.Size)
 			sg.metrics.recordNDAttempt(ctx, attempt, true)
 			return nd, nil
-		case errors.Is(getErr, context.DeadlineExceeded),
-			errors.Is(getErr, context.Canceled):
+		case errors.Is(getErr, p2p.ErrTimeout),
+			errors.Is(getErr, context.DeadlineExceeded):
+			getErr = fmt.Errorf("%w: %w", share.ErrTimeout, getErr)
+			setStatus(peers.ResultCooldownPeer)
+		case errors.Is(getErr, context.Canceled):
 			setStatus(peers.ResultCooldownPeer)
 		case errors.Is(getErr, p2p.ErrNotFound):
 			getErr = share.ErrNotFound
 			setStatus(peers.ResultCooldownPeer)
+		case errors.Is(getErr, p2p.ErrRateLimited):
+			getErr = share.ErrRateLimited
+			setStatus(peers.ResultCooldownPeer)
 		case errors.Is(getErr, p2p.ErrInvalidResponse):
+			getErr = share.ErrInvalidResponse
 			setStatus(peers.ResultBlacklistPeer)
 		default:
 			setStatus(peers.ResultCooldownPeer)
@@ -268,3 +291,28 @@ func (sg *ShrexGetter) GetSharesByNamespace(
 			"finished (s)", time.Since(reqStart))
 	}
 }
+
+// reportInvalidData records the evidence of the peer serving data that does not match the root,
+// before the peer is quarantined.
+func reportInvalidData(
+	ctx context.Context,
+	protocol string,
+	peerID peer.ID,
+	root *share.Root,
+	err error,
+	attrs ...attribute.KeyValue,
+) {
+	attrs = append(attrs,
+		attribute.String("protocol", protocol),
+		attribute.String("peer", peerID.String()),
+		attribute.String("root", root.String()),
+		attribute.String("err", err.Error()),
+	)
+	trace.SpanFromContext(ctx).AddEvent("invalid data", trace.WithAttributes(attrs...))
+
+	kvs := make([]interface{}, 0, len(attrs)*2)
+	for _, attr := range attrs {
+		kvs = append(kvs, string(attr.Key), attr.Value.Emit())
+	}
+	log.Warnw("peer served data not matching the root", kvs...)
+}
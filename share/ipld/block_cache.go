@@ -0,0 +1,153 @@
+package ipld
+
+import (
+	"context"
+	"fmt"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/ipfs/go-cid"
+	bstore "github.com/ipfs/go-ipfs-blockstore"
+	"github.com/ipfs/go-libipfs/blocks"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var meter = otel.Meter("share/ipld")
+
+var _ bstore.Blockstore = (*BlockCache)(nil)
+
+// CachePolicy is the eviction policy of the BlockCache.
+type CachePolicy string
+
+const (
+	// CachePolicyARC is the Adaptive Replacement Cache policy, balancing between recently and
+	// frequently used blocks.
+	CachePolicyARC CachePolicy = "arc"
+	// CachePolicy2Q is the 2Q policy, keeping blocks used more than once apart from the ones used
+	// only recently.
+	CachePolicy2Q CachePolicy = "2q"
+)
+
+// Validate checks the policy is known.
+func (p CachePolicy) Validate() error {
+	switch p {
+	case CachePolicyARC, CachePolicy2Q:
+		return nil
+	default:
+		return fmt.Errorf("ipld: unknown cache policy %q, must be %q or %q", p, CachePolicyARC, CachePolicy2Q)
+	}
+}
+
+// cache is the subset of methods shared by the ARC and 2Q caches.
+type cache interface {
+	Get(key interface{}) (interface{}, bool)
+	Add(key, value interface{})
+	Contains(key interface{}) bool
+	Remove(key interface{})
+}
+
+// BlockCache is a Blockstore keeping the blocks read from the wrapped Blockstore in memory, so
+// repeated reads of the same blocks, e.g. of the same rows while sampling and reconstructing, do
+// not hit the disk.
+type BlockCache struct {
+	bstore.Blockstore
+
+	cache   cache
+	metrics *blockCacheMetrics
+}
+
+// NewBlockCache wraps the given Blockstore with a cache of up to 'size' blocks evicted according
+// to the given policy.
+func NewBlockCache(bs bstore.Blockstore, policy CachePolicy, size int) (*BlockCache, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("ipld: cache size must be positive, got %d", size)
+	}
+
+	var (
+		c   cache
+		err error
+	)
+	switch policy {
+	case CachePolicyARC:
+		c, err = lru.NewARC(size)
+	case CachePolicy2Q:
+		c, err = lru.New2Q(size)
+	default:
+		err = policy.Validate()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &BlockCache{Blockstore: bs, cache: c}, nil
+}
+
+func (bc *BlockCache) Get(ctx context.Context, id cid.Cid) (blocks.Block, error) {
+	if val, ok := bc.cache.Get(key(id)); ok {
+		bc.metrics.observe(ctx, true)
+		return val.(blocks.Block), nil
+	}
+	bc.metrics.observe(ctx, false)
+
+	blk, err := bc.Blockstore.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	bc.cache.Add(key(id), blk)
+	return blk, nil
+}
+
+func (bc *BlockCache) Has(ctx context.Context, id cid.Cid) (bool, error) {
+	if bc.cache.Contains(key(id)) {
+		return true, nil
+	}
+	return bc.Blockstore.Has(ctx, id)
+}
+
+func (bc *BlockCache) GetSize(ctx context.Context, id cid.Cid) (int, error) {
+	if val, ok := bc.cache.Get(key(id)); ok {
+		return len(val.(blocks.Block).RawData()), nil
+	}
+	return bc.Blockstore.GetSize(ctx, id)
+}
+
+func (bc *BlockCache) DeleteBlock(ctx context.Context, id cid.Cid) error {
+	bc.cache.Remove(key(id))
+	return bc.Blockstore.DeleteBlock(ctx, id)
+}
+
+// key keys blocks by their multihash, as the Blockstore does.
+func key(id cid.Cid) string {
+	return string(id.Hash())
+}
+
+// blockCacheMetrics records how many reads the BlockCache serves from memory.
+type blockCacheMetrics struct {
+	requests metric.Int64Counter
+}
+
+// WithMetrics turns on metric collection of cache hits and misses.
+func (bc *BlockCache) WithMetrics() error {
+	requests, err := meter.Int64Counter("ipld_block_cache_requests_counter",
+		metric.WithDescription("amount of block reads from the block cache, labeled by whether they hit it"))
+	if err != nil {
+		return err
+	}
+
+	bc.metrics = &blockCacheMetrics{
+		requests: requests,
+	}
+	return nil
+}
+
+// observe records whether the read was served from the cache.
+func (m *blockCacheMetrics) observe(ctx context.Context, hit bool) {
+	if m == nil {
+		return
+	}
+	if ctx.Err() != nil {
+		ctx = context.Background()
+	}
+
+	m.requests.Add(ctx, 1, metric.WithAttributes(attribute.Bool("hit", hit)))
+}
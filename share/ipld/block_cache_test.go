@@ -0,0 +1,69 @@
+package ipld
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	ds_sync "github.com/ipfs/go-datastore/sync"
+	bstore "github.com/ipfs/go-ipfs-blockstore"
+	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/ipfs/go-libipfs/blocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockCache(t *testing.T) {
+	for _, policy := range []CachePolicy{CachePolicyARC, CachePolicy2Q} {
+		t.Run(string(policy), func(t *testing.T) {
+			ctx := context.Background()
+			bs := &countingBlockstore{Blockstore: bstore.NewBlockstore(ds_sync.MutexWrap(datastore.NewMapDatastore()))}
+			cache, err := NewBlockCache(bs, policy, 2)
+			require.NoError(t, err)
+
+			blks := []blocks.Block{
+				blocks.NewBlock([]byte("a")),
+				blocks.NewBlock([]byte("b")),
+				blocks.NewBlock([]byte("c")),
+			}
+			require.NoError(t, cache.PutMany(ctx, blks))
+
+			// repeated reads are served from memory
+			for i := 0; i < 3; i++ {
+				blk, err := cache.Get(ctx, blks[0].Cid())
+				require.NoError(t, err)
+				assert.Equal(t, blks[0].RawData(), blk.RawData())
+			}
+			assert.Equal(t, 1, bs.gets)
+
+			size, err := cache.GetSize(ctx, blks[0].Cid())
+			require.NoError(t, err)
+			assert.Equal(t, 1, size)
+
+			// deleted blocks are evicted
+			require.NoError(t, cache.DeleteBlock(ctx, blks[0].Cid()))
+			has, err := cache.Has(ctx, blks[0].Cid())
+			require.NoError(t, err)
+			assert.False(t, has)
+			_, err = cache.Get(ctx, blks[0].Cid())
+			assert.True(t, ipld.IsNotFound(err))
+		})
+	}
+
+	_, err := NewBlockCache(bstore.NewBlockstore(datastore.NewMapDatastore()), "lru", 1)
+	assert.Error(t, err)
+	_, err = NewBlockCache(bstore.NewBlockstore(datastore.NewMapDatastore()), CachePolicyARC, 0)
+	assert.Error(t, err)
+}
+
+// countingBlockstore counts reads of blocks.
+type countingBlockstore struct {
+	bstore.Blockstore
+	gets int
+}
+
+func (c *countingBlockstore) Get(ctx context.Context, id cid.Cid) (blocks.Block, error) {
+	c.gets++
+	return c.Blockstore.Get(ctx, id)
+}
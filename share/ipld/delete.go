@@ -0,0 +1,49 @@
+package ipld
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ipfs/go-cid"
+	bstore "github.com/ipfs/go-ipfs-blockstore"
+	ipld "github.com/ipfs/go-ipld-format"
+)
+
+// DeleteNodes removes the NMT node of the given CID along with its descendants from the
+// Blockstore, as far as they are stored locally, and returns the amount of removed nodes. Nodes for
+// which keep returns true are left in place with their descendants. As identical subtrees are
+// stored once, keep must cover the nodes shared with the trees that are not removed, e.g. the
+// subtrees over padding.
+func DeleteNodes(ctx context.Context, bs bstore.Blockstore, root cid.Cid, keep func(cid.Cid) bool) (int, error) {
+	var removed int
+	stack := []cid.Cid{root}
+	for len(stack) > 0 {
+		if ctx.Err() != nil {
+			return removed, ctx.Err()
+		}
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if keep != nil && keep(id) {
+			continue
+		}
+
+		blk, err := bs.Get(ctx, id)
+		if err != nil {
+			var errNotFound ipld.ErrNotFound
+			if errors.As(err, &errNotFound) {
+				continue
+			}
+			return removed, err
+		}
+		if len(blk.RawData()) == innerNodeSize {
+			for _, link := range (nmtNode{Block: blk}).Links() {
+				stack = append(stack, link.Cid)
+			}
+		}
+		if err = bs.DeleteBlock(ctx, id); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
@@ -0,0 +1,248 @@
+package p2p
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"golang.org/x/time/rate"
+)
+
+// bandwidthBurst is the amount of bytes written to a stream at once when its bandwidth is capped.
+const bandwidthBurst = 32 << 10
+
+// BandwidthParameters caps the outbound bandwidth of serving data over shrex and bitswap, so that
+// serving the network does not starve other workloads on the same machine.
+type BandwidthParameters struct {
+	// PeerOutboundRate is the maximum amount of bytes per second sent to a single peer.
+	// Zero disables the cap.
+	PeerOutboundRate uint64
+	// OutboundRate is the maximum amount of bytes per second sent to all the peers together.
+	// Zero disables the cap.
+	OutboundRate uint64
+}
+
+// BandwidthLimiter shapes the writes of streams according to the BandwidthParameters. The limits are
+// shared by all the streams shaped by the same BandwidthLimiter.
+type BandwidthLimiter struct {
+	total    *rate.Limiter
+	peerRate rate.Limit
+
+	lk    sync.Mutex
+	peers map[peer.ID]*peerLimiter
+}
+
+// peerLimiter is the limiter of a peer, kept as long as any of the streams with the peer is open.
+type peerLimiter struct {
+	*rate.Limiter
+	streams int
+}
+
+// NewBandwidthLimiter creates a new BandwidthLimiter with the given caps.
+func NewBandwidthLimiter(params BandwidthParameters) *BandwidthLimiter {
+	l := &BandwidthLimiter{
+		peers: make(map[peer.ID]*peerLimiter),
+	}
+	if params.OutboundRate > 0 {
+		l.total = rate.NewLimiter(rate.Limit(params.OutboundRate), bandwidthBurst)
+	}
+	if params.PeerOutboundRate > 0 {
+		l.peerRate = rate.Limit(params.PeerOutboundRate)
+	}
+	return l
+}
+
+// Enabled reports whether any of the caps is set.
+func (l *BandwidthLimiter) Enabled() bool {
+	return l != nil && (l.total != nil || l.peerRate > 0)
+}
+
+// Host wraps the host, so that the writes of the inbound streams it handles are shaped. The streams
+// the host opens itself are not shaped, as only serving the peers is capped.
+// The host is returned as is if no cap is set.
+func (l *BandwidthLimiter) Host(h host.Host) host.Host {
+	if !l.Enabled() {
+		return h
+	}
+	return &shapedHost{Host: h, limiter: l}
+}
+
+// Handler wraps the handler, so that the writes of the streams it handles are shaped.
+func (l *BandwidthLimiter) Handler(handler network.StreamHandler) network.StreamHandler {
+	if !l.Enabled() {
+		return handler
+	}
+	return func(stream network.Stream) {
+		handler(l.Stream(stream))
+	}
+}
+
+// Stream wraps the stream, so that its writes are shaped.
+func (l *BandwidthLimiter) Stream(stream network.Stream) network.Stream {
+	if !l.Enabled() {
+		return stream
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &shapedStream{
+		Stream:  stream,
+		limiter: l,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	if l.peerRate > 0 {
+		s.peerID = stream.Conn().RemotePeer()
+		s.peer = l.acquire(s.peerID)
+	}
+	return s
+}
+
+func (l *BandwidthLimiter) acquire(id peer.ID) *rate.Limiter {
+	l.lk.Lock()
+	defer l.lk.Unlock()
+	pl, ok := l.peers[id]
+	if !ok {
+		pl = &peerLimiter{Limiter: rate.NewLimiter(l.peerRate, bandwidthBurst)}
+		l.peers[id] = pl
+	}
+	pl.streams++
+	return pl.Limiter
+}
+
+func (l *BandwidthLimiter) release(id peer.ID) {
+	l.lk.Lock()
+	defer l.lk.Unlock()
+	pl, ok := l.peers[id]
+	if !ok {
+		return
+	}
+	pl.streams--
+	if pl.streams <= 0 {
+		delete(l.peers, id)
+	}
+}
+
+type shapedHost struct {
+	host.Host
+	limiter *BandwidthLimiter
+}
+
+func (h *shapedHost) SetStreamHandler(pid protocol.ID, handler network.StreamHandler) {
+	h.Host.SetStreamHandler(pid, h.limiter.Handler(handler))
+}
+
+func (h *shapedHost) SetStreamHandlerMatch(
+	pid protocol.ID,
+	match func(protocol.ID) bool,
+	handler network.StreamHandler,
+) {
+	h.Host.SetStreamHandlerMatch(pid, match, h.limiter.Handler(handler))
+}
+
+type shapedStream struct {
+	network.Stream
+	limiter *BandwidthLimiter
+
+	peerID peer.ID
+	peer   *rate.Limiter
+
+	// ctx is canceled once the stream is closed, aborting the writes waiting for bandwidth.
+	ctx         context.Context
+	cancel      context.CancelFunc
+	releaseOnce sync.Once
+
+	deadlineLk    sync.Mutex
+	writeDeadline time.Time
+}
+
+func (s *shapedStream) Write(p []byte) (int, error) {
+	var written int
+	for len(p) > 0 {
+		n := len(p)
+		if n > bandwidthBurst {
+			n = bandwidthBurst
+		}
+		if err := s.wait(n); err != nil {
+			return written, err
+		}
+		w, err := s.Stream.Write(p[:n])
+		written += w
+		if err != nil {
+			return written, err
+		}
+		p = p[n:]
+	}
+	return written, nil
+}
+
+// wait blocks until n bytes may be written within the caps, the write deadline or the stream is
+// closed.
+func (s *shapedStream) wait(n int) error {
+	ctx := s.ctx
+	s.deadlineLk.Lock()
+	deadline := s.writeDeadline
+	s.deadlineLk.Unlock()
+	if !deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+		defer cancel()
+	}
+
+	for _, limiter := range []*rate.Limiter{s.peer, s.limiter.total} {
+		if limiter == nil {
+			continue
+		}
+		if err := limiter.WaitN(ctx, n); err != nil {
+			if s.ctx.Err() != nil {
+				return io.ErrClosedPipe
+			}
+			if !deadline.IsZero() {
+				// the wait either timed out or would exceed the deadline
+				return os.ErrDeadlineExceeded
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *shapedStream) SetDeadline(t time.Time) error {
+	s.setWriteDeadline(t)
+	return s.Stream.SetDeadline(t)
+}
+
+func (s *shapedStream) SetWriteDeadline(t time.Time) error {
+	s.setWriteDeadline(t)
+	return s.Stream.SetWriteDeadline(t)
+}
+
+func (s *shapedStream) setWriteDeadline(t time.Time) {
+	s.deadlineLk.Lock()
+	defer s.deadlineLk.Unlock()
+	s.writeDeadline = t
+}
+
+func (s *shapedStream) Close() error {
+	s.release()
+	return s.Stream.Close()
+}
+
+func (s *shapedStream) Reset() error {
+	s.release()
+	return s.Stream.Reset()
+}
+
+// release aborts the pending writes and releases the limiter of the peer once the stream is done.
+func (s *shapedStream) release() {
+	s.releaseOnce.Do(func() {
+		s.cancel()
+		if s.peer != nil {
+			s.limiter.release(s.peerID)
+		}
+	})
+}
@@ -0,0 +1,112 @@
+package p2p
+
+import (
+	"context"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testProtocol = protocol.ID("/test/bandwidth")
+
+func TestBandwidthLimiter(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	client, server := newBandwidthHosts(t)
+	limiter := NewBandwidthLimiter(BandwidthParameters{PeerOutboundRate: 10 * bandwidthBurst})
+	require.True(t, limiter.Enabled())
+
+	// the server sends 4 bursts of data with the rate of 10 bursts per second, so the first burst
+	// is sent at once and the rest takes 300ms
+	data := make([]byte, 4*bandwidthBurst)
+	limiter.Host(server).SetStreamHandler(testProtocol, func(stream network.Stream) {
+		defer stream.Close()
+		_, err := stream.Write(data)
+		assert.NoError(t, err)
+	})
+
+	start := time.Now()
+	stream, err := client.NewStream(ctx, server.ID(), testProtocol)
+	require.NoError(t, err)
+	received, err := io.ReadAll(stream)
+	require.NoError(t, err)
+	require.Len(t, received, len(data))
+	require.GreaterOrEqual(t, time.Since(start), 250*time.Millisecond)
+
+	// the limiter of the peer is released once its streams are closed
+	require.Eventually(t, func() bool {
+		limiter.lk.Lock()
+		defer limiter.lk.Unlock()
+		return len(limiter.peers) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestBandwidthLimiter_Deadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	client, server := newBandwidthHosts(t)
+	server.SetStreamHandler(testProtocol, func(stream network.Stream) {
+		_, _ = io.Copy(io.Discard, stream)
+	})
+	limiter := NewBandwidthLimiter(BandwidthParameters{OutboundRate: bandwidthBurst})
+
+	stream, err := client.NewStream(ctx, server.ID(), testProtocol)
+	require.NoError(t, err)
+	stream = limiter.Stream(stream)
+	t.Cleanup(func() { stream.Reset() }) //nolint:errcheck
+
+	// the second burst could only be sent in a second, after the deadline; mocknet streams do not
+	// support deadlines themselves, but the deadline is still applied to waiting for bandwidth
+	_ = stream.SetWriteDeadline(time.Now().Add(100 * time.Millisecond))
+	n, err := stream.Write(make([]byte, 2*bandwidthBurst))
+	require.ErrorIs(t, err, os.ErrDeadlineExceeded)
+	require.Equal(t, bandwidthBurst, n)
+}
+
+func TestBandwidthLimiter_Outbound(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	client, server := newBandwidthHosts(t)
+	server.SetStreamHandler(testProtocol, func(stream network.Stream) {
+		_, _ = io.Copy(io.Discard, stream)
+	})
+	limiter := NewBandwidthLimiter(BandwidthParameters{OutboundRate: bandwidthBurst})
+
+	// the streams opened by the host are not shaped, so the write does not wait for bandwidth
+	stream, err := limiter.Host(client).NewStream(ctx, server.ID(), testProtocol)
+	require.NoError(t, err)
+	t.Cleanup(func() { stream.Reset() }) //nolint:errcheck
+
+	start := time.Now()
+	_, err = stream.Write(make([]byte, 4*bandwidthBurst))
+	require.NoError(t, err)
+	require.Less(t, time.Since(start), time.Second)
+	limiter.lk.Lock()
+	defer limiter.lk.Unlock()
+	require.Empty(t, limiter.peers)
+}
+
+func TestBandwidthLimiter_Disabled(t *testing.T) {
+	client, _ := newBandwidthHosts(t)
+	limiter := NewBandwidthLimiter(BandwidthParameters{})
+	require.False(t, limiter.Enabled())
+	require.Equal(t, client, limiter.Host(client))
+}
+
+func newBandwidthHosts(t *testing.T) (host.Host, host.Host) {
+	net, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+	t.Cleanup(func() { net.Close() }) //nolint:errcheck
+	return net.Hosts()[0], net.Hosts()[1]
+}
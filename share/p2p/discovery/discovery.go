@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	logging "github.com/ipfs/go-log/v2"
@@ -14,6 +15,8 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/p2p/host/eventbus"
 	"golang.org/x/sync/errgroup"
+
+	"github.com/celestiaorg/celestia-node/libs/supervisor"
 )
 
 var log = logging.Logger("share/discovery")
@@ -21,6 +24,13 @@ var log = logging.Logger("share/discovery")
 const (
 	// rendezvousPoint is the namespace where peers advertise and discover each other.
 	rendezvousPoint = "full"
+	// ArchivalRendezvousPoint is the namespace where nodes keeping the whole history advertise
+	// themselves, so that peers needing pruned block data can find them.
+	ArchivalRendezvousPoint = "archival"
+	// PrunedRendezvousPoint is the namespace where nodes pruning block data advertise themselves
+	// instead of the default one, so that peers expecting the whole history under the default one
+	// do not request the pruned block data from them.
+	PrunedRendezvousPoint = "pruned"
 
 	// eventbusBufSize is the size of the buffered channel to handle
 	// events in libp2p. We specify a larger buffer size for the channel
@@ -96,9 +106,15 @@ func (d *Discovery) Start(context.Context) error {
 		return fmt.Errorf("subscribing for connection events: %w", err)
 	}
 
-	go d.discoveryLoop(ctx)
-	go d.disconnectsLoop(ctx, sub)
-	go d.connector.GC(ctx)
+	// the routines are restarted on panics
+	supervisor.Go(ctx, "discovery/discovery", d.discoveryLoop)
+	go func() {
+		defer sub.Close()
+		supervisor.Run(ctx, "discovery/disconnects", func(ctx context.Context) {
+			d.disconnectsLoop(ctx, sub)
+		})
+	}()
+	supervisor.Go(ctx, "discovery/gc", d.connector.GC)
 	return nil
 }
 
@@ -116,7 +132,7 @@ func (d *Discovery) WithOnPeersUpdate(f OnUpdatedPeers) {
 	}
 }
 
-// Peers provides a list of discovered peers in the "full" topic.
+// Peers provides a list of discovered peers under the rendezvous points.
 // If Discovery hasn't found any peers, it blocks until at least one peer is found.
 func (d *Discovery) Peers(ctx context.Context) ([]peer.ID, error) {
 	return d.set.Peers(ctx)
@@ -157,13 +173,12 @@ func (d *Discovery) Advertise(ctx context.Context) {
 	timer := time.NewTimer(d.params.AdvertiseInterval)
 	defer timer.Stop()
 	for {
-		_, err := d.disc.Advertise(ctx, rendezvousPoint)
-		d.metrics.observeAdvertise(ctx, err)
+		err := d.advertise(ctx)
 		if err != nil {
 			if ctx.Err() != nil {
 				return
 			}
-			log.Warnw("error advertising", "rendezvous", rendezvousPoint, "err", err)
+			log.Warnw("error advertising", "err", err)
 
 			// we don't want retry indefinitely in busy loop
 			// internal discovery mechanism may need some time before attempts
@@ -194,6 +209,19 @@ func (d *Discovery) Advertise(ctx context.Context) {
 	}
 }
 
+// advertise advertises the node under all of its rendezvous points.
+func (d *Discovery) advertise(ctx context.Context) error {
+	var errs []error
+	for _, point := range d.params.advertisedPoints() {
+		_, err := d.disc.Advertise(ctx, point)
+		d.metrics.observeAdvertise(ctx, err)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("rendezvous %s: %w", point, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // discoveryLoop ensures we always have '~peerLimit' connected peers.
 // It starts peer discovery per request and restarts the process until the soft limit reached.
 func (d *Discovery) discoveryLoop(ctx context.Context) {
@@ -224,8 +252,6 @@ func (d *Discovery) discoveryLoop(ctx context.Context) {
 // disconnectsLoop listen for disconnect events and ensures Discovery state
 // is updated.
 func (d *Discovery) disconnectsLoop(ctx context.Context, sub event.Subscription) {
-	defer sub.Close()
-
 	for {
 		select {
 		case <-ctx.Done():
@@ -268,7 +294,7 @@ func (d *Discovery) discover(ctx context.Context) bool {
 		findCancel()
 	}()
 
-	peers, err := d.disc.FindPeers(findCtx, rendezvousPoint)
+	peers, err := d.findPeers(findCtx)
 	if err != nil {
 		log.Error("unable to start discovery", "err", err)
 		return false
@@ -316,6 +342,49 @@ func (d *Discovery) discover(ctx context.Context) bool {
 	}
 }
 
+// findPeers finds peers under all the rendezvous points, merging the results into one channel.
+func (d *Discovery) findPeers(ctx context.Context) (<-chan peer.AddrInfo, error) {
+	points := d.params.rendezvousPoints()
+	if len(points) == 1 {
+		return d.disc.FindPeers(ctx, points[0])
+	}
+
+	out := make(chan peer.AddrInfo)
+	var wg sync.WaitGroup
+	var errs []error
+	for _, point := range points {
+		peers, err := d.disc.FindPeers(ctx, point)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("rendezvous %s: %w", point, err))
+			continue
+		}
+
+		wg.Add(1)
+		go func(peers <-chan peer.AddrInfo) {
+			defer wg.Done()
+			for p := range peers {
+				select {
+				case out <- p:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(peers)
+	}
+	if len(errs) == len(points) {
+		return nil, errors.Join(errs...)
+	}
+	if len(errs) > 0 {
+		log.Warnw("unable to discover under some rendezvous points", "err", errors.Join(errs...))
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out, nil
+}
+
 // handleDiscoveredPeer adds peer to the internal if can connect or is connected.
 // Report whether it succeeded.
 func (d *Discovery) handleDiscoveredPeer(ctx context.Context, peer peer.AddrInfo) bool {
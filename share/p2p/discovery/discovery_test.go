@@ -5,13 +5,16 @@ import (
 	"testing"
 	"time"
 
+	"github.com/benbjohnson/clock"
 	dht "github.com/libp2p/go-libp2p-kad-dht"
 	"github.com/libp2p/go-libp2p/core/discovery"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/discovery/mocks"
 	"github.com/libp2p/go-libp2p/p2p/discovery/routing"
 	basic "github.com/libp2p/go-libp2p/p2p/host/basic"
 	"github.com/libp2p/go-libp2p/p2p/host/eventbus"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
 	swarmt "github.com/libp2p/go-libp2p/p2p/net/swarm/testing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -73,6 +76,46 @@ func TestDiscovery(t *testing.T) {
 	assert.EqualValues(t, 0, peerA.set.Size())
 }
 
+func TestDiscovery_RendezvousPoints(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshLinked(4)
+	require.NoError(t, err)
+	server := mocks.NewDiscoveryServer(clock.NewMock()) // frozen, as advertisements have no TTL
+	newDiscovery := func(h host.Host, opts ...Option) *Discovery {
+		return NewDiscovery(h, mocks.NewDiscoveryClient(h, server), opts...)
+	}
+
+	hosts := net.Hosts()
+	full := newDiscovery(hosts[0])
+	archival := newDiscovery(hosts[1], WithAdvertisedPoints("archival"))
+	// nodes pruning block data are neither advertised as full nor as archival
+	pruned := newDiscovery(hosts[3], WithAdvertisedPoints("archival"), WithPruning(true))
+	getter := newDiscovery(hosts[2], WithPeersLimit(3), WithRendezvousPoints("archival"))
+
+	require.NoError(t, full.advertise(ctx))
+	require.NoError(t, archival.advertise(ctx))
+	require.NoError(t, pruned.advertise(ctx))
+
+	// only the peer advertising the archival point is discovered
+	getter.discover(ctx)
+	assert.True(t, getter.set.Contains(archival.host.ID()))
+	assert.False(t, getter.set.Contains(full.host.ID()))
+	assert.EqualValues(t, 1, getter.set.Size())
+
+	getter = newDiscovery(hosts[2], WithPeersLimit(3), WithRendezvousPoints("full"))
+	getter.discover(ctx)
+	assert.False(t, getter.set.Contains(pruned.host.ID()))
+	assert.EqualValues(t, 2, getter.set.Size())
+
+	// peers are discovered under all the points, by default including the pruning ones
+	getter = newDiscovery(hosts[2], WithPeersLimit(3))
+	getter.discover(ctx)
+	assert.True(t, getter.set.Contains(pruned.host.ID()))
+	assert.EqualValues(t, 3, getter.set.Size())
+}
+
 type testnet struct {
 	ctx context.Context
 	T   *testing.T
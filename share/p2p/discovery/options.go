@@ -14,6 +14,18 @@ type Parameters struct {
 	// Set -1 to disable.
 	// NOTE: only full and bridge can advertise themselves.
 	AdvertiseInterval time.Duration
+	// RendezvousPoints are the rendezvous points peers are discovered under. Peers found under any
+	// of them fill the same peer set, e.g. set to "archival" to only discover peers retaining data
+	// beyond the sampling window. The default "full" one is used if empty.
+	RendezvousPoints []string
+	// AdvertisedPoints are the rendezvous points the node advertises itself under in addition to
+	// the default "full" one, e.g. "archival" for nodes retaining data beyond the sampling window.
+	// NOTE: only full and bridge can advertise themselves.
+	AdvertisedPoints []string
+
+	// pruning makes the node advertise itself under the "pruned" rendezvous point instead of the
+	// "full" and "archival" ones, as it does not keep the whole history.
+	pruning bool
 }
 
 // Option is a function that configures Discovery Parameters
@@ -26,6 +38,8 @@ func DefaultParameters() Parameters {
 		PeersLimit: 5,
 		// based on https://github.com/libp2p/go-libp2p-kad-dht/pull/793
 		AdvertiseInterval: time.Hour * 22,
+		RendezvousPoints:  []string{rendezvousPoint, PrunedRendezvousPoint},
+		AdvertisedPoints:  []string{},
 	}
 }
 
@@ -38,7 +52,23 @@ func (p *Parameters) Validate() error {
 			"value must be positive",
 		)
 	}
+	if err := validatePoints("RendezvousPoints", p.RendezvousPoints); err != nil {
+		return err
+	}
+	return validatePoints("AdvertisedPoints", p.AdvertisedPoints)
+}
 
+func validatePoints(name string, points []string) error {
+	seen := make(map[string]bool, len(points))
+	for _, point := range points {
+		if point == "" {
+			return fmt.Errorf("discovery: invalid option: %s must not contain empty values", name)
+		}
+		if seen[point] {
+			return fmt.Errorf("discovery: invalid option: %s contains %q more than once", name, point)
+		}
+		seen[point] = true
+	}
 	return nil
 }
 
@@ -57,3 +87,55 @@ func WithAdvertiseInterval(advInterval time.Duration) Option {
 		p.AdvertiseInterval = advInterval
 	}
 }
+
+// WithRendezvousPoints is a functional option that Discovery
+// uses to set the RendezvousPoints configuration param
+func WithRendezvousPoints(points ...string) Option {
+	return func(p *Parameters) {
+		p.RendezvousPoints = points
+	}
+}
+
+// WithAdvertisedPoints is a functional option that Discovery
+// uses to set the AdvertisedPoints configuration param
+func WithAdvertisedPoints(points ...string) Option {
+	return func(p *Parameters) {
+		p.AdvertisedPoints = points
+	}
+}
+
+// WithPruning is a functional option that makes Discovery advertise the node under the
+// PrunedRendezvousPoint only, as the node prunes block data.
+func WithPruning(pruning bool) Option {
+	return func(p *Parameters) {
+		p.pruning = pruning
+	}
+}
+
+// rendezvousPoints returns the rendezvous points to discover peers under.
+func (p *Parameters) rendezvousPoints() []string {
+	if len(p.RendezvousPoints) == 0 {
+		return []string{rendezvousPoint}
+	}
+	return p.RendezvousPoints
+}
+
+// advertisedPoints returns the rendezvous points to advertise the node under.
+func (p *Parameters) advertisedPoints() []string {
+	if p.pruning {
+		for _, point := range p.AdvertisedPoints {
+			if point != PrunedRendezvousPoint {
+				log.Warnw("not advertising under rendezvous point, as block data is pruned", "point", point)
+			}
+		}
+		return []string{PrunedRendezvousPoint}
+	}
+
+	points := []string{rendezvousPoint}
+	for _, point := range p.AdvertisedPoints {
+		if point != rendezvousPoint {
+			points = append(points, point)
+		}
+	}
+	return points
+}
@@ -13,3 +13,11 @@ var ErrNotFound = errors.New("the requested data or resource could not be found"
 // error. It is used to signal that the peer couldn't serve the data successfully, and should not be
 // retried.
 var ErrInvalidResponse = errors.New("server returned an invalid response or caused an internal error")
+
+// ErrRateLimited is returned when a peer closes the stream without a response, as it serves too many
+// requests at the moment. The request may be retried with another peer, or with the same one later.
+var ErrRateLimited = errors.New("the peer is rate limiting requests")
+
+// ErrTimeout is returned when a peer does not respond within the time given to the request. The
+// request may be retried with another peer.
+var ErrTimeout = errors.New("the request to the peer timed out")
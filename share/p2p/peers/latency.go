@@ -0,0 +1,166 @@
+package peers
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+)
+
+// latencyEWMASmoothing is the weight given to the newest sample in the moving averages.
+const latencyEWMASmoothing = 0.2
+
+// PeerLatency summarizes the observed performance of a single peer.
+type PeerLatency struct {
+	ID peer.ID `json:"id"`
+	// RTT is the moving average of the time taken by the peer to serve a request.
+	RTT time.Duration `json:"rtt"`
+	// Throughput is the moving average of bytes per second received from the peer.
+	Throughput float64 `json:"throughput"`
+	// Samples is the amount of requests observed for the peer.
+	Samples uint64 `json:"samples"`
+}
+
+// LatencyTracker keeps exponentially weighted moving averages of request RTT and throughput per
+// peer. It is shared by the Manager, which observes shrex requests, and the hosts wrapped with
+// Host, e.g. the one used to exchange headers.
+type LatencyTracker struct {
+	lock  sync.RWMutex
+	stats map[peer.ID]*PeerLatency
+}
+
+// NewLatencyTracker creates an empty LatencyTracker.
+func NewLatencyTracker() *LatencyTracker {
+	return &LatencyTracker{
+		stats: make(map[peer.ID]*PeerLatency),
+	}
+}
+
+// Host wraps the host, so that the requests sent over the streams it opens are observed by the
+// LatencyTracker. A request takes from the first write to the stream until the last read before it
+// is closed, and its size is the amount of bytes read.
+func (lt *LatencyTracker) Host(h host.Host) host.Host {
+	return &latencyHost{Host: h, tracker: lt}
+}
+
+// observe records the time taken by the peer to serve the given amount of bytes.
+func (lt *LatencyTracker) observe(peerID peer.ID, rtt time.Duration, size int) {
+	if rtt <= 0 {
+		return
+	}
+	throughput := float64(size) / rtt.Seconds()
+
+	lt.lock.Lock()
+	defer lt.lock.Unlock()
+
+	stat, ok := lt.stats[peerID]
+	if !ok {
+		lt.stats[peerID] = &PeerLatency{
+			ID:         peerID,
+			RTT:        rtt,
+			Throughput: throughput,
+			Samples:    1,
+		}
+		return
+	}
+
+	stat.RTT = time.Duration(latencyEWMASmoothing*float64(rtt) + (1-latencyEWMASmoothing)*float64(stat.RTT))
+	stat.Throughput = latencyEWMASmoothing*throughput + (1-latencyEWMASmoothing)*stat.Throughput
+	stat.Samples++
+}
+
+// less reports whether peer a is preferred over peer b. Peers without samples are preferred, so
+// that every peer gets measured at least once.
+func (lt *LatencyTracker) less(a, b peer.ID) bool {
+	lt.lock.RLock()
+	defer lt.lock.RUnlock()
+
+	statA, okA := lt.stats[a]
+	statB, okB := lt.stats[b]
+	switch {
+	case !okA:
+		return okB
+	case !okB:
+		return false
+	default:
+		return statA.RTT < statB.RTT
+	}
+}
+
+func (lt *LatencyTracker) remove(peerID peer.ID) {
+	lt.lock.Lock()
+	defer lt.lock.Unlock()
+	delete(lt.stats, peerID)
+}
+
+// snapshot returns copies of all tracked stats sorted by RTT, fastest first.
+func (lt *LatencyTracker) snapshot() []PeerLatency {
+	lt.lock.RLock()
+	defer lt.lock.RUnlock()
+
+	out := make([]PeerLatency, 0, len(lt.stats))
+	for _, stat := range lt.stats {
+		out = append(out, *stat)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].RTT < out[j].RTT
+	})
+	return out
+}
+
+type latencyHost struct {
+	host.Host
+	tracker *LatencyTracker
+}
+
+func (h *latencyHost) NewStream(ctx context.Context, p peer.ID, pids ...protocol.ID) (network.Stream, error) {
+	stream, err := h.Host.NewStream(ctx, p, pids...)
+	if err != nil {
+		return nil, err
+	}
+	return &latencyStream{Stream: stream, tracker: h.tracker}, nil
+}
+
+type latencyStream struct {
+	network.Stream
+	tracker *LatencyTracker
+
+	lock     sync.Mutex
+	sent     time.Time
+	received time.Time
+	size     int
+}
+
+func (s *latencyStream) Write(p []byte) (int, error) {
+	s.lock.Lock()
+	if s.sent.IsZero() {
+		s.sent = time.Now()
+	}
+	s.lock.Unlock()
+	return s.Stream.Write(p)
+}
+
+func (s *latencyStream) Read(p []byte) (int, error) {
+	n, err := s.Stream.Read(p)
+	if n > 0 {
+		s.lock.Lock()
+		s.received = time.Now()
+		s.size += n
+		s.lock.Unlock()
+	}
+	return n, err
+}
+
+func (s *latencyStream) Close() error {
+	s.lock.Lock()
+	if !s.sent.IsZero() && s.size > 0 {
+		s.tracker.observe(s.Conn().RemotePeer(), s.received.Sub(s.sent), s.size)
+	}
+	s.lock.Unlock()
+	return s.Stream.Close()
+}
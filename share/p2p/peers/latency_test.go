@@ -0,0 +1,141 @@
+package peers
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatencyTracker(t *testing.T) {
+	t.Run("observe", func(t *testing.T) {
+		lt := NewLatencyTracker()
+		peerID := peer.ID("peer1")
+
+		lt.observe(peerID, time.Second, 1000)
+		stats := lt.snapshot()
+		require.Len(t, stats, 1)
+		require.Equal(t, time.Second, stats[0].RTT)
+		require.Equal(t, float64(1000), stats[0].Throughput)
+
+		// moving average should move towards the new sample without reaching it
+		lt.observe(peerID, 2*time.Second, 1000)
+		stats = lt.snapshot()
+		require.Len(t, stats, 1)
+		require.Greater(t, stats[0].RTT, time.Second)
+		require.Less(t, stats[0].RTT, 2*time.Second)
+		require.EqualValues(t, 2, stats[0].Samples)
+	})
+
+	t.Run("less", func(t *testing.T) {
+		lt := NewLatencyTracker()
+		fast, slow, unknown := peer.ID("fast"), peer.ID("slow"), peer.ID("unknown")
+		lt.observe(fast, time.Millisecond, 1)
+		lt.observe(slow, time.Second, 1)
+
+		require.True(t, lt.less(fast, slow))
+		require.False(t, lt.less(slow, fast))
+		// unmeasured peers are preferred
+		require.True(t, lt.less(unknown, fast))
+		require.False(t, lt.less(fast, unknown))
+
+		stats := lt.snapshot()
+		require.Equal(t, fast, stats[0].ID)
+		require.Equal(t, slow, stats[1].ID)
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		lt := NewLatencyTracker()
+		peerID := peer.ID("peer1")
+		lt.observe(peerID, time.Second, 1)
+		lt.remove(peerID)
+		require.Empty(t, lt.snapshot())
+	})
+}
+
+func TestLatencyTracker_Host(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+	client, server := net.Hosts()[0], net.Hosts()[1]
+	const protocolID = "/latency-test"
+	server.SetStreamHandler(protocolID, func(stream network.Stream) {
+		defer stream.Close()
+		req, err := io.ReadAll(stream)
+		if err != nil {
+			stream.Reset() //nolint:errcheck
+			return
+		}
+		stream.Write(req) //nolint:errcheck
+	})
+
+	lt := NewLatencyTracker()
+	stream, err := lt.Host(client).NewStream(ctx, server.ID(), protocolID)
+	require.NoError(t, err)
+	_, err = stream.Write([]byte("ping"))
+	require.NoError(t, err)
+	require.NoError(t, stream.CloseWrite())
+	resp, err := io.ReadAll(stream)
+	require.NoError(t, err)
+	require.Equal(t, "ping", string(resp))
+	require.NoError(t, stream.Close())
+
+	// the request is observed once the stream is closed
+	stats := lt.snapshot()
+	require.Len(t, stats, 1)
+	require.Equal(t, server.ID(), stats[0].ID)
+	require.Positive(t, stats[0].RTT)
+	require.EqualValues(t, 1, stats[0].Samples)
+}
+
+func TestManager_LatencyRouting(t *testing.T) {
+	m := &Manager{
+		params:  DefaultParameters(),
+		latency: NewLatencyTracker(),
+	}
+	m.params.EnableLatencyRouting = true
+	fast, slow := peer.ID("fast"), peer.ID("slow")
+	m.ObserveLatency(fast, time.Millisecond, 1)
+	m.ObserveLatency(slow, time.Second, 1)
+
+	p := newPool(time.Second)
+	p.add(slow, fast)
+	for i := 0; i < 3; i++ {
+		peerID, ok := m.tryGet(p)
+		require.True(t, ok)
+		require.Equal(t, fast, peerID)
+	}
+
+	// the peers are compared to random ones rather than to fixed pairs, so the second slowest peer
+	// is taken when compared to the slowest one
+	slower, slowest := peer.ID("slower"), peer.ID("slowest")
+	m.ObserveLatency(slower, time.Second*2, 1)
+	m.ObserveLatency(slowest, time.Second*3, 1)
+	p = newPool(time.Second)
+	p.add(fast, slow, slower, slowest)
+	taken := make(map[peer.ID]int)
+	for i := 0; i < 400; i++ {
+		peerID, ok := m.tryGet(p)
+		require.True(t, ok)
+		taken[peerID]++
+	}
+	require.Positive(t, taken[slower])
+	require.Zero(t, taken[slowest])
+	require.Greater(t, taken[fast], taken[slow])
+
+	// latency routing is opt-in
+	require.False(t, DefaultParameters().EnableLatencyRouting)
+	m.params.EnableLatencyRouting = false
+	p = newPool(time.Second)
+	p.add(slow, fast)
+	peerID, ok := m.tryGet(p)
+	require.True(t, ok)
+	require.Equal(t, slow, peerID)
+}
@@ -37,6 +37,10 @@ const (
 	// ResultBlacklistPeer will blacklist peer. Blacklisted peers will be disconnected and blocked from
 	// any p2p communication in future by libp2p Gater
 	ResultBlacklistPeer = "result_blacklist_peer"
+	// ResultQuarantinePeer will put returned peer on quarantine for the PeerQuarantine, meaning it
+	// won't be available by Peer method for a long time, e.g. for serving data that does not match
+	// the requested root. The peer is blacklisted instead if blacklisting is enabled.
+	ResultQuarantinePeer = "result_quarantine_peer"
 
 	// eventbusBufSize is the size of the buffered channel to handle
 	// events in libp2p
@@ -70,6 +74,11 @@ type Manager struct {
 
 	// hashes that are not in the chain
 	blacklistedHashes map[string]bool
+	// quarantined are the peers that are not requested until the given time
+	quarantined map[peer.ID]time.Time
+
+	// latency tracks RTT and throughput of peers serving requests
+	latency *LatencyTracker
 
 	metrics *metrics
 
@@ -118,6 +127,8 @@ func NewManager(
 		host:                  host,
 		pools:                 make(map[string]*syncPool),
 		blacklistedHashes:     make(map[string]bool),
+		quarantined:           make(map[peer.ID]time.Time),
+		latency:               NewLatencyTracker(),
 		headerSubDone:         make(chan struct{}),
 		disconnectedPeersDone: make(chan struct{}),
 	}
@@ -204,7 +215,7 @@ func (m *Manager) Peer(
 	p := m.validatedPool(datahash.String())
 
 	// first, check if a peer is available for the given datahash
-	peerID, ok := p.tryGet()
+	peerID, ok := m.tryGet(p.pool)
 	if ok {
 		if m.removeIfUnreachable(p, peerID) {
 			return m.Peer(ctx, datahash)
@@ -214,7 +225,7 @@ func (m *Manager) Peer(
 
 	// if no peer for datahash is currently available, try to use full node
 	// obtained from discovery
-	peerID, ok = m.fullNodes.tryGet()
+	peerID, ok = m.tryGet(m.fullNodes)
 	if ok {
 		return m.newPeer(ctx, datahash, peerID, sourceFullNodes, m.fullNodes.len(), 0)
 	}
@@ -223,17 +234,73 @@ func (m *Manager) Peer(
 	start := time.Now()
 	select {
 	case peerID = <-p.next(ctx):
-		if m.removeIfUnreachable(p, peerID) {
+		if m.removeIfUnreachable(p, peerID) || m.skipIfQuarantined(p.pool, peerID) {
 			return m.Peer(ctx, datahash)
 		}
 		return m.newPeer(ctx, datahash, peerID, sourceShrexSub, p.len(), time.Since(start))
 	case peerID = <-m.fullNodes.next(ctx):
+		if m.skipIfQuarantined(m.fullNodes, peerID) {
+			return m.Peer(ctx, datahash)
+		}
 		return m.newPeer(ctx, datahash, peerID, sourceFullNodes, m.fullNodes.len(), time.Since(start))
 	case <-ctx.Done():
 		return "", nil, ctx.Err()
 	}
 }
 
+// WithLatencyTracker makes the Manager share the LatencyTracker, so that the latencies observed
+// outside of the Manager are taken into account as well.
+func (m *Manager) WithLatencyTracker(lt *LatencyTracker) {
+	m.latency = lt
+}
+
+// ObserveLatency records the time taken by the peer to serve the request of the given size in
+// bytes. Observed values are used to prefer faster peers when EnableLatencyRouting is set.
+func (m *Manager) ObserveLatency(peerID peer.ID, rtt time.Duration, size int) {
+	m.latency.observe(peerID, rtt, size)
+}
+
+// Latencies returns the latency stats of all peers that served requests, fastest first.
+func (m *Manager) Latencies() []PeerLatency {
+	return m.latency.snapshot()
+}
+
+// tryGet takes a peer from the pool. With latency routing enabled, it compares the peer next in
+// round-robin order with another one picked at random and returns the faster one, which favors low
+// latency peers without piling all the requests onto a single one.
+func (m *Manager) tryGet(p *pool) (peer.ID, bool) {
+	peerID, ok := m.tryGetUnquarantined(p)
+	if !ok || !m.params.EnableLatencyRouting {
+		return peerID, ok
+	}
+
+	other, ok := p.tryGetRandom(peerID)
+	if ok && !m.skipIfQuarantined(p, other) && m.latency.less(other, peerID) {
+		return other, true
+	}
+	return peerID, true
+}
+
+// tryGetUnquarantined takes a peer that is not quarantined from the pool.
+func (m *Manager) tryGetUnquarantined(p *pool) (peer.ID, bool) {
+	for {
+		peerID, ok := p.tryGet()
+		if !ok || !m.skipIfQuarantined(p, peerID) {
+			return peerID, ok
+		}
+	}
+}
+
+// skipIfQuarantined puts the peer on cooldown in the pool if it is quarantined, so that it stays
+// in the pool to be requested once the quarantine ends.
+func (m *Manager) skipIfQuarantined(p *pool, peerID peer.ID) bool {
+	if !m.isQuarantinedPeer(peerID) {
+		return false
+	}
+	p.putOnCooldown(peerID)
+	return true
+}
+
 func (m *Manager) newPeer(
 	ctx context.Context,
 	datahash share.DataHash,
@@ -272,6 +339,12 @@ func (m *Manager) doneFunc(datahash share.DataHash, peerID peer.ID, source peerS
 			m.getOrCreatePool(datahash.String()).putOnCooldown(peerID)
 		case ResultBlacklistPeer:
 			m.blacklistPeers(reasonMisbehave, peerID)
+		case ResultQuarantinePeer:
+			if m.params.EnableBlackListing {
+				m.blacklistPeers(reasonInvalidData, peerID)
+				return
+			}
+			m.quarantinePeer(peerID)
 		}
 	}
 }
@@ -321,6 +394,7 @@ func (m *Manager) subscribeDisconnectedPeers(ctx context.Context, sub event.Subs
 					log.Debugw("peer disconnected, removing from full nodes", "peer", peer.String())
 					m.fullNodes.remove(peer)
 				}
+				m.latency.remove(peer)
 			}
 		}
 	}
@@ -401,6 +475,7 @@ func (m *Manager) blacklistPeers(reason blacklistPeerReason, peerIDs ...peer.ID)
 		}
 
 		m.fullNodes.remove(peerID)
+		m.latency.remove(peerID)
 		// add peer to the blacklist, so we can't connect to it in the future.
 		err := m.connGater.BlockPeer(peerID)
 		if err != nil {
@@ -414,6 +489,32 @@ func (m *Manager) blacklistPeers(reason blacklistPeerReason, peerIDs ...peer.ID)
 	}
 }
 
+// quarantinePeer stops returning the peer by the Peer method for the PeerQuarantine.
+func (m *Manager) quarantinePeer(peerID peer.ID) {
+	if m.params.PeerQuarantine == 0 {
+		return
+	}
+	log.Warnw("quarantining peer", "peer", peerID.String(), "duration", m.params.PeerQuarantine)
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.quarantined[peerID] = time.Now().Add(m.params.PeerQuarantine)
+}
+
+func (m *Manager) isQuarantinedPeer(peerID peer.ID) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	until, ok := m.quarantined[peerID]
+	if !ok {
+		return false
+	}
+	if time.Now().After(until) {
+		delete(m.quarantined, peerID)
+		return false
+	}
+	return true
+}
+
 func (m *Manager) isBlacklistedPeer(peerID peer.ID) bool {
 	return !m.connGater.InterceptPeerDial(peerID)
 }
@@ -254,6 +254,44 @@ func TestManager(t *testing.T) {
 		require.Len(t, pool.peersList, 0)
 	})
 
+	t.Run("quarantine peer", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		t.Cleanup(cancel)
+
+		h := testHeader()
+		headerSub := newSubLock(h, nil)
+
+		// start test manager
+		manager, err := testManager(ctx, headerSub)
+		require.NoError(t, err)
+		manager.params.PeerQuarantine = time.Hour
+
+		invalid, honest := peer.ID("peer1"), peer.ID("peer2")
+		manager.fullNodes.add(invalid, honest)
+
+		pID, done, err := manager.Peer(ctx, h.DataHash.Bytes())
+		require.NoError(t, err)
+		require.Equal(t, invalid, pID)
+		done(ResultQuarantinePeer)
+
+		// the quarantined peer is skipped even once its cooldown is over
+		for i := 0; i < 3; i++ {
+			pID, done, err = manager.Peer(ctx, h.DataHash.Bytes())
+			require.NoError(t, err)
+			require.Equal(t, honest, pID)
+			done(ResultNoop)
+		}
+		require.True(t, manager.fullNodes.has(invalid))
+
+		// the peer is requested again after the quarantine
+		manager.lock.Lock()
+		manager.quarantined[invalid] = time.Now()
+		manager.lock.Unlock()
+		require.False(t, manager.isQuarantinedPeer(invalid))
+
+		stopManager(t, manager)
+	})
+
 	t.Run("shrexSub sends a message lower than first headerSub header height, msg first", func(t *testing.T) {
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 		t.Cleanup(cancel)
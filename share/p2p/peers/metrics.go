@@ -26,6 +26,7 @@ const (
 	blacklistPeerReasonKey                     = "blacklist_reason"
 	reasonInvalidHash      blacklistPeerReason = "invalid_hash"
 	reasonMisbehave        blacklistPeerReason = "misbehave"
+	reasonInvalidData      blacklistPeerReason = "invalid_data"
 
 	validationResultKey = "validation_result"
 	validationAccept    = "accept"
@@ -14,11 +14,19 @@ type Parameters struct {
 	// PeerCooldown is the time a peer is put on cooldown after a ResultCooldownPeer.
 	PeerCooldown time.Duration
 
+	// PeerQuarantine is the time a peer is not requested after a ResultQuarantinePeer, e.g. for
+	// serving data that does not match the requested root. Zero disables quarantining.
+	PeerQuarantine time.Duration
+
 	// GcInterval is the interval at which the manager will garbage collect unvalidated pools.
 	GcInterval time.Duration
 
 	// EnableBlackListing turns on blacklisting for misbehaved peers
 	EnableBlackListing bool
+
+	// EnableLatencyRouting makes the manager prefer peers with lower observed request RTT. It is off
+	// by default, as the peers are then no longer taken evenly.
+	EnableLatencyRouting bool
 }
 
 // Validate validates the values in Parameters
@@ -31,6 +39,10 @@ func (p *Parameters) Validate() error {
 		return fmt.Errorf("peer-manager: peer cooldown must be positive")
 	}
 
+	if p.PeerQuarantine < 0 {
+		return fmt.Errorf("peer-manager: peer quarantine must not be negative")
+	}
+
 	if p.GcInterval <= 0 {
 		return fmt.Errorf("peer-manager: garbage collection interval must be positive")
 	}
@@ -49,10 +61,14 @@ func DefaultParameters() Parameters {
 		// sync time for large blocks. This value gives our (discovery) peers enough time to sync
 		// the new block before we ask them again.
 		PeerCooldown: 3 * time.Second,
-		GcInterval:   time.Second * 30,
+		// PeerQuarantine keeps peers serving invalid data away for long enough to not waste the
+		// requests of the following blocks on them, while blacklisting is off.
+		PeerQuarantine: 10 * time.Minute,
+		GcInterval:     time.Second * 30,
 		// blacklisting is off by default //TODO(@walldiss): enable blacklisting once all related issues
 		// are resolved
-		EnableBlackListing: false,
+		EnableBlackListing:   false,
+		EnableLatencyRouting: false,
 	}
 }
 
@@ -2,6 +2,7 @@ package peers
 
 import (
 	"context"
+	"math/rand"
 	"sync"
 	"time"
 
@@ -79,6 +80,34 @@ func (p *pool) tryGet() (peer.ID, bool) {
 	}
 }
 
+// tryGetRandom returns an active peer picked at random, other than the given one, along with bool
+// flag indicating success of operation. It does not advance the round-robin order.
+func (p *pool) tryGetRandom(except peer.ID) (peer.ID, bool) {
+	p.m.RLock()
+	defer p.m.RUnlock()
+
+	candidates := p.activeCount
+	if status, ok := p.statuses[except]; ok && status == active {
+		candidates--
+	}
+	if candidates <= 0 {
+		return "", false
+	}
+
+	// pick the n-th of the candidates
+	n := rand.Intn(candidates) //nolint:gosec
+	for _, peerID := range p.peersList {
+		if peerID == except || p.statuses[peerID] != active {
+			continue
+		}
+		if n == 0 {
+			return peerID, true
+		}
+		n--
+	}
+	return "", false
+}
+
 // next sends a peer to the returned channel when it becomes available.
 func (p *pool) next(ctx context.Context) <-chan peer.ID {
 	peerCh := make(chan peer.ID, 1)
@@ -32,6 +32,28 @@ func TestPool(t *testing.T) {
 		require.False(t, ok)
 	})
 
+	t.Run("random", func(t *testing.T) {
+		p := newPool(time.Second)
+		p.add("peer1", "peer2", "peer3")
+		p.remove("peer3")
+
+		// the given peer and the inactive ones are never taken
+		taken := make(map[peer.ID]bool)
+		for i := 0; i < 100; i++ {
+			peerID, ok := p.tryGetRandom("peer1")
+			require.True(t, ok)
+			taken[peerID] = true
+		}
+		require.Equal(t, map[peer.ID]bool{"peer2": true}, taken)
+
+		p.remove("peer2")
+		_, ok := p.tryGetRandom("peer1")
+		require.False(t, ok)
+		peerID, ok := p.tryGetRandom("unknown")
+		require.True(t, ok)
+		require.Equal(t, peer.ID("peer1"), peerID)
+	})
+
 	t.Run("round robin", func(t *testing.T) {
 		p := newPool(time.Second)
 
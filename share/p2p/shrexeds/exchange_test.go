@@ -124,7 +124,7 @@ func TestExchange_RequestEDS(t *testing.T) {
 		// wait until all server slots are taken
 		wg.Wait()
 		_, err = client.RequestEDS(ctx, nil, server.host.ID())
-		require.ErrorIs(t, err, p2p.ErrNotFound)
+		require.ErrorIs(t, err, p2p.ErrRateLimited)
 	})
 }
 
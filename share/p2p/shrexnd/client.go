@@ -61,20 +61,25 @@ func (c *Client) RequestND(
 	if err == nil {
 		return shares, nil
 	}
-	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+	if errors.Is(err, context.Canceled) {
 		c.metrics.ObserveRequests(ctx, 1, p2p.StatusTimeout)
 		return nil, err
 	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		c.metrics.ObserveRequests(ctx, 1, p2p.StatusTimeout)
+		return nil, fmt.Errorf("%w: %w", p2p.ErrTimeout, err)
+	}
 	// some net.Errors also mean the context deadline was exceeded, but yamux/mocknet do not
 	// unwrap to a ctx err
 	var ne net.Error
 	if errors.As(err, &ne) && ne.Timeout() {
+		c.metrics.ObserveRequests(ctx, 1, p2p.StatusTimeout)
 		if deadline, _ := ctx.Deadline(); deadline.Before(time.Now()) {
-			c.metrics.ObserveRequests(ctx, 1, p2p.StatusTimeout)
-			return nil, context.DeadlineExceeded
+			return nil, fmt.Errorf("%w: %w", p2p.ErrTimeout, context.DeadlineExceeded)
 		}
+		return nil, fmt.Errorf("%w: %w", p2p.ErrTimeout, err)
 	}
-	if err != p2p.ErrNotFound {
+	if !errors.Is(err, p2p.ErrNotFound) && !errors.Is(err, p2p.ErrRateLimited) {
 		log.Warnw("client-nd: peer returned err", "err", err)
 	}
 	return nil, err
@@ -116,7 +121,7 @@ func (c *Client) doRequest(
 		// server is overloaded and closed the stream
 		if errors.Is(err, io.EOF) {
 			c.metrics.ObserveRequests(ctx, 1, p2p.StatusRateLimited)
-			return nil, p2p.ErrNotFound
+			return nil, p2p.ErrRateLimited
 		}
 		stream.Reset() //nolint:errcheck
 		return nil, fmt.Errorf("client-nd: reading response: %w", err)
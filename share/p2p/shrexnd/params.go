@@ -1,16 +1,24 @@
 package shrexnd
 
 import (
+	"context"
 	"fmt"
 
 	logging "github.com/ipfs/go-log/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 
+	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/p2p"
 )
 
 const protocolString = "/shrex/nd/v0.0.2"
 
-var log = logging.Logger("shrex/nd")
+var (
+	log   = logging.Logger("shrex/nd")
+	meter = otel.Meter("shrex/nd")
+)
 
 // Parameters is the set of parameters that must be configured for the shrex/eds protocol.
 type Parameters = p2p.Parameters
@@ -36,3 +44,44 @@ func (srv *Server) WithMetrics() error {
 	srv.metrics = metrics
 	return nil
 }
+
+// WithNamespaceMetrics turns on counting of served requests for each of the given namespaces.
+// Requests for other namespaces are not counted to keep metrics cardinality bounded.
+func (srv *Server) WithNamespaceMetrics(namespaces []share.Namespace) error {
+	requests, err := meter.Int64Counter("shrex_nd_server_namespace_requests",
+		metric.WithDescription("Total count of served shrex/nd requests per watched namespace"))
+	if err != nil {
+		return fmt.Errorf("shrex/nd: init namespace metrics: %w", err)
+	}
+
+	watched := make(map[string]struct{}, len(namespaces))
+	for _, ns := range namespaces {
+		watched[string(ns)] = struct{}{}
+	}
+	srv.namespaceMetrics = &namespaceMetrics{
+		requests:   requests,
+		namespaces: watched,
+	}
+	return nil
+}
+
+type namespaceMetrics struct {
+	requests   metric.Int64Counter
+	namespaces map[string]struct{}
+}
+
+func (m *namespaceMetrics) observeRequest(ctx context.Context, namespace share.Namespace) {
+	if m == nil {
+		return
+	}
+	if _, ok := m.namespaces[string(namespace)]; !ok {
+		return
+	}
+	if ctx.Err() != nil {
+		ctx = context.Background()
+	}
+	m.requests.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("namespace", namespace.String()),
+		))
+}
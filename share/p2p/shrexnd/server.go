@@ -35,6 +35,8 @@ type Server struct {
 	params     *Parameters
 	middleware *p2p.Middleware
 	metrics    *p2p.Metrics
+
+	namespaceMetrics *namespaceMetrics
 }
 
 // NewServer creates new Server
@@ -113,6 +115,7 @@ func (srv *Server) handleNamespacedData(ctx context.Context, stream network.Stre
 		stream.Reset() //nolint:errcheck
 		return
 	}
+	srv.namespaceMetrics.observeRequest(ctx, req.Namespace)
 
 	ctx, cancel := context.WithTimeout(ctx, srv.params.HandleRequestTimeout)
 	defer cancel()
@@ -0,0 +1,234 @@
+package share
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/celestiaorg/nmt"
+)
+
+// This file defines the canonical encodings of NMT proofs and DAHs served over RPC and the
+// gateway. They are pinned here, independently of the types' own marshaling, so that verifiers in
+// other languages keep decoding them after dependency upgrades. Any change to them is breaking.
+//
+// The canonical JSON encodings are:
+//
+//	NMT proof: {"start":<int>,"end":<int>,"nodes":[<base64>...]|null,"leaf_hash":<base64>|null,
+//	            "is_max_namespace_id_ignored":<bool>}
+//	DAH:       {"row_roots":[<base64>...],"column_roots":[<base64>...]}
+//
+// The canonical protobuf encodings follow the schema below. Fields are written in the order of
+// their numbers, fields with default values are omitted and unknown fields are skipped on decoding.
+// The DAH message is wire-compatible with celestia.da.DataAvailabilityHeader.
+//
+//	message NMTProof {
+//	  int64 start = 1;
+//	  int64 end = 2;
+//	  repeated bytes nodes = 3;
+//	  bytes leaf_hash = 4;
+//	  bool is_max_namespace_id_ignored = 5;
+//	}
+//
+//	message DataAvailabilityHeader {
+//	  repeated bytes row_roots = 1;
+//	  repeated bytes column_roots = 2;
+//	}
+
+// ErrInvalidEncoding is returned when decoding malformed canonical encodings.
+var ErrInvalidEncoding = errors.New("share: invalid encoding")
+
+type jsonNMTProof struct {
+	Start                   int      `json:"start"`
+	End                     int      `json:"end"`
+	Nodes                   [][]byte `json:"nodes"`
+	LeafHash                []byte   `json:"leaf_hash"`
+	IsMaxNamespaceIDIgnored bool     `json:"is_max_namespace_id_ignored"`
+}
+
+type jsonRoot struct {
+	RowRoots    [][]byte `json:"row_roots"`
+	ColumnRoots [][]byte `json:"column_roots"`
+}
+
+// MarshalNMTProofJSON encodes the NMT proof to its canonical JSON.
+func MarshalNMTProofJSON(proof *nmt.Proof) ([]byte, error) {
+	return json.Marshal(&jsonNMTProof{
+		Start:                   proof.Start(),
+		End:                     proof.End(),
+		Nodes:                   proof.Nodes(),
+		LeafHash:                proof.LeafHash(),
+		IsMaxNamespaceIDIgnored: proof.IsMaxNamespaceIDIgnored(),
+	})
+}
+
+// UnmarshalNMTProofJSON decodes the NMT proof from its canonical JSON.
+func UnmarshalNMTProofJSON(data []byte) (*nmt.Proof, error) {
+	var p jsonNMTProof
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, err
+	}
+	return newNMTProof(p.Start, p.End, p.Nodes, p.LeafHash, p.IsMaxNamespaceIDIgnored), nil
+}
+
+// MarshalNMTProof encodes the NMT proof to its canonical protobuf.
+func MarshalNMTProof(proof *nmt.Proof) []byte {
+	var b []byte
+	if proof.Start() != 0 {
+		b = protowire.AppendTag(b, 1, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(proof.Start()))
+	}
+	if proof.End() != 0 {
+		b = protowire.AppendTag(b, 2, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(proof.End()))
+	}
+	for _, node := range proof.Nodes() {
+		b = protowire.AppendTag(b, 3, protowire.BytesType)
+		b = protowire.AppendBytes(b, node)
+	}
+	if len(proof.LeafHash()) != 0 {
+		b = protowire.AppendTag(b, 4, protowire.BytesType)
+		b = protowire.AppendBytes(b, proof.LeafHash())
+	}
+	if proof.IsMaxNamespaceIDIgnored() {
+		b = protowire.AppendTag(b, 5, protowire.VarintType)
+		b = protowire.AppendVarint(b, protowire.EncodeBool(true))
+	}
+	return b
+}
+
+// UnmarshalNMTProof decodes the NMT proof from its canonical protobuf.
+func UnmarshalNMTProof(data []byte) (*nmt.Proof, error) {
+	var (
+		start, end int
+		nodes      [][]byte
+		leafHash   []byte
+		ignored    bool
+	)
+	err := decodeFields(data, func(num protowire.Number, typ protowire.Type, data []byte) (int, error) {
+		switch {
+		case num == 1 && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(data)
+			start = int(v)
+			return n, nil
+		case num == 2 && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(data)
+			end = int(v)
+			return n, nil
+		case num == 3 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(data)
+			nodes = append(nodes, append([]byte{}, v...))
+			return n, nil
+		case num == 4 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(data)
+			leafHash = append([]byte{}, v...)
+			return n, nil
+		case num == 5 && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(data)
+			ignored = protowire.DecodeBool(v)
+			return n, nil
+		case num >= 1 && num <= 5:
+			return 0, fmt.Errorf("%w: unexpected wire type %d of NMTProof field %d", ErrInvalidEncoding, typ, num)
+		default:
+			return protowire.ConsumeFieldValue(num, typ, data), nil
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newNMTProof(start, end, nodes, leafHash, ignored), nil
+}
+
+// MarshalRootJSON encodes the DAH to its canonical JSON.
+func MarshalRootJSON(root *Root) ([]byte, error) {
+	return json.Marshal(&jsonRoot{
+		RowRoots:    root.RowRoots,
+		ColumnRoots: root.ColumnRoots,
+	})
+}
+
+// UnmarshalRootJSON decodes the DAH from its canonical JSON.
+func UnmarshalRootJSON(data []byte) (*Root, error) {
+	var r jsonRoot
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, err
+	}
+	return &Root{RowRoots: r.RowRoots, ColumnRoots: r.ColumnRoots}, nil
+}
+
+// MarshalRoot encodes the DAH to its canonical protobuf.
+func MarshalRoot(root *Root) []byte {
+	var b []byte
+	for _, row := range root.RowRoots {
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendBytes(b, row)
+	}
+	for _, col := range root.ColumnRoots {
+		b = protowire.AppendTag(b, 2, protowire.BytesType)
+		b = protowire.AppendBytes(b, col)
+	}
+	return b
+}
+
+// UnmarshalRoot decodes the DAH from its canonical protobuf.
+func UnmarshalRoot(data []byte) (*Root, error) {
+	root := &Root{}
+	err := decodeFields(data, func(num protowire.Number, typ protowire.Type, data []byte) (int, error) {
+		switch {
+		case num == 1 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(data)
+			root.RowRoots = append(root.RowRoots, append([]byte{}, v...))
+			return n, nil
+		case num == 2 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(data)
+			root.ColumnRoots = append(root.ColumnRoots, append([]byte{}, v...))
+			return n, nil
+		case num == 1 || num == 2:
+			return 0, fmt.Errorf("%w: unexpected wire type %d of DataAvailabilityHeader field %d",
+				ErrInvalidEncoding, typ, num)
+		default:
+			return protowire.ConsumeFieldValue(num, typ, data), nil
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// decodeFields calls decode for every field of the protobuf message with the data following the
+// field's tag. decode reports the length of the field's value it consumed.
+func decodeFields(
+	data []byte,
+	decode func(protowire.Number, protowire.Type, []byte) (int, error),
+) error {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return fmt.Errorf("%w: %s", ErrInvalidEncoding, protowire.ParseError(n))
+		}
+		data = data[n:]
+
+		n, err := decode(num, typ, data)
+		if err != nil {
+			return err
+		}
+		if n < 0 {
+			return fmt.Errorf("%w: %s", ErrInvalidEncoding, protowire.ParseError(n))
+		}
+		data = data[n:]
+	}
+	return nil
+}
+
+func newNMTProof(start, end int, nodes [][]byte, leafHash []byte, ignoreMaxNamespace bool) *nmt.Proof {
+	var proof nmt.Proof
+	if len(leafHash) != 0 {
+		proof = nmt.NewAbsenceProof(start, end, nodes, leafHash, ignoreMaxNamespace)
+	} else {
+		proof = nmt.NewInclusionProof(start, end, nodes, ignoreMaxNamespace)
+	}
+	return &proof
+}
@@ -0,0 +1,116 @@
+package share
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/nmt"
+)
+
+// The golden encodings below are relied upon by verifiers outside of this repository and must
+// never change.
+func TestNMTProofEncoding(t *testing.T) {
+	inclusion := nmt.NewInclusionProof(1, 3, [][]byte{{1, 2}, {3}}, true)
+	absence := nmt.NewAbsenceProof(0, 1, [][]byte{{9}}, []byte{7, 7}, false)
+
+	tests := []struct {
+		name   string
+		proof  *nmt.Proof
+		json   string
+		protob string
+	}{
+		{
+			name:   "inclusion",
+			proof:  &inclusion,
+			json:   `{"start":1,"end":3,"nodes":["AQI=","Aw=="],"leaf_hash":null,"is_max_namespace_id_ignored":true}`,
+			protob: "080110031a0201021a01032801",
+		},
+		{
+			name:   "absence",
+			proof:  &absence,
+			json:   `{"start":0,"end":1,"nodes":["CQ=="],"leaf_hash":"Bwc=","is_max_namespace_id_ignored":false}`,
+			protob: "10011a010922020707",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := MarshalNMTProofJSON(tt.proof)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.json, string(data))
+			proof, err := UnmarshalNMTProofJSON([]byte(tt.json))
+			require.NoError(t, err)
+			assert.Equal(t, tt.proof, proof)
+
+			assert.Equal(t, tt.protob, hex.EncodeToString(MarshalNMTProof(tt.proof)))
+			raw, err := hex.DecodeString(tt.protob)
+			require.NoError(t, err)
+			proof, err = UnmarshalNMTProof(raw)
+			require.NoError(t, err)
+			assert.Equal(t, tt.proof, proof)
+		})
+	}
+
+	// unknown fields are skipped
+	raw, err := hex.DecodeString("080110031a0201021a01032801" + "3005")
+	require.NoError(t, err)
+	proof, err := UnmarshalNMTProof(raw)
+	require.NoError(t, err)
+	assert.Equal(t, &inclusion, proof)
+
+	_, err = UnmarshalNMTProof([]byte{0x0a, 0x01, 0x01})
+	require.ErrorIs(t, err, ErrInvalidEncoding)
+	_, err = UnmarshalNMTProof([]byte{0x1a, 0x05})
+	require.ErrorIs(t, err, ErrInvalidEncoding)
+}
+
+func TestRootEncoding(t *testing.T) {
+	root := &Root{
+		RowRoots:    [][]byte{{1}, {2}},
+		ColumnRoots: [][]byte{{3}},
+	}
+	const (
+		goldenJSON  = `{"row_roots":["AQ==","Ag=="],"column_roots":["Aw=="]}`
+		goldenProto = "0a01010a0102120103"
+	)
+
+	data, err := MarshalRootJSON(root)
+	require.NoError(t, err)
+	assert.JSONEq(t, goldenJSON, string(data))
+	decoded, err := UnmarshalRootJSON([]byte(goldenJSON))
+	require.NoError(t, err)
+	assert.Equal(t, root, decoded)
+
+	assert.Equal(t, goldenProto, hex.EncodeToString(MarshalRoot(root)))
+	raw, err := hex.DecodeString(goldenProto)
+	require.NoError(t, err)
+	decoded, err = UnmarshalRoot(raw)
+	require.NoError(t, err)
+	assert.Equal(t, root, decoded)
+
+	// the protobuf encoding is compatible with the one of celestia-app
+	appProto, err := root.ToProto()
+	require.NoError(t, err)
+	appRaw, err := appProto.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, goldenProto, hex.EncodeToString(appRaw))
+}
+
+func TestNamespacedRowJSON(t *testing.T) {
+	proof := nmt.NewInclusionProof(1, 3, [][]byte{{1, 2}, {3}}, true)
+	row := NamespacedRow{Shares: []Share{{4}}, Proof: &proof}
+	const golden = `{"Shares":["BA=="],"Proof":{"start":1,"end":3,"nodes":["AQI=","Aw=="],"leaf_hash":null,` +
+		`"is_max_namespace_id_ignored":true}}`
+
+	data, err := json.Marshal(row)
+	require.NoError(t, err)
+	assert.JSONEq(t, golden, string(data))
+
+	var decoded NamespacedRow
+	require.NoError(t, json.Unmarshal([]byte(golden), &decoded))
+	assert.Equal(t, row, decoded)
+}
@@ -0,0 +1,129 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrCoreUnavailable is returned by the submission methods without contacting core, while core
+// is considered unreachable after failing too many submissions in a row.
+var ErrCoreUnavailable = errors.New("state: core is unreachable")
+
+// submitBreaker fails the submissions fast once core is persistently unreachable, instead of
+// letting each of them hang until it times out. After the failure threshold is reached, the
+// submissions are rejected for the cooldown, after which a single one is let through to probe
+// core again.
+type submitBreaker struct {
+	// threshold is the amount of consecutive failures opening the breaker. Zero disables it.
+	threshold int
+	cooldown  time.Duration
+
+	lk       sync.Mutex
+	failures int
+	openedAt time.Time
+	probing  bool
+
+	// rejected is the total count of the rejected submissions.
+	rejected atomic.Int64
+}
+
+// allow returns ErrCoreUnavailable if the submission must not reach core. Every allowed submission
+// must be followed by record.
+func (b *submitBreaker) allow() error {
+	if b.threshold == 0 {
+		return nil
+	}
+
+	b.lk.Lock()
+	defer b.lk.Unlock()
+	if b.failures < b.threshold {
+		return nil
+	}
+	if retryIn := b.cooldown - time.Since(b.openedAt); retryIn > 0 {
+		b.rejected.Add(1)
+		return fmt.Errorf("%w: %d submissions failed in a row, retrying in %s",
+			ErrCoreUnavailable, b.failures, retryIn.Round(time.Second))
+	}
+	if b.probing {
+		b.rejected.Add(1)
+		return fmt.Errorf("%w: probing core with another submission", ErrCoreUnavailable)
+	}
+	b.probing = true
+	return nil
+}
+
+// record accounts the outcome of an allowed submission. Any response from core closes the breaker,
+// while the failures to reach it open it once the threshold is reached. The failures of submissions
+// whose context is done are not accounted, as they tell nothing about core.
+func (b *submitBreaker) record(ctx context.Context, err error) {
+	if b.threshold == 0 {
+		return
+	}
+
+	b.lk.Lock()
+	defer b.lk.Unlock()
+	b.probing = false
+	switch {
+	case err != nil && ctx.Err() != nil:
+		// the caller gave up or ran out of time, which tells nothing about core
+	case isCoreUnreachable(err):
+		b.failures++
+		if b.failures >= b.threshold {
+			if b.failures == b.threshold {
+				log.Warnw("core is unreachable, failing submissions fast",
+					"failures", b.failures, "cooldown", b.cooldown)
+			}
+			b.openedAt = time.Now()
+		}
+	default:
+		if b.failures >= b.threshold {
+			log.Info("core is reachable again, resuming submissions")
+		}
+		b.failures = 0
+	}
+}
+
+// isOpen reports whether the submissions are currently rejected.
+func (b *submitBreaker) isOpen() bool {
+	if b.threshold == 0 {
+		return false
+	}
+	b.lk.Lock()
+	defer b.lk.Unlock()
+	return b.failures >= b.threshold
+}
+
+// isCoreUnreachable tells apart the failures to reach core from the errors returned by it, e.g.
+// for transactions it rejected. Only the gRPC statuses of failed calls to core are accounted.
+func isCoreUnreachable(err error) bool {
+	var st interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &st) {
+		return false
+	}
+	switch st.GRPCStatus().Code() {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// guardSubmit runs the submission made with the given context through the breaker.
+func (ca *CoreAccessor) guardSubmit(ctx context.Context, submit func() (*TxResponse, error)) (*TxResponse, error) {
+	if err := ca.injectedFault(); err != nil {
+		return nil, err
+	}
+	if err := ca.breaker.allow(); err != nil {
+		return nil, err
+	}
+	resp, err := submit()
+	ca.breaker.record(ctx, err)
+	return resp, err
+}
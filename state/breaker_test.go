@@ -0,0 +1,95 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSubmitBreaker(t *testing.T) {
+	ctx := context.Background()
+	b := &submitBreaker{threshold: 2, cooldown: time.Millisecond * 50}
+	unreachable := fmt.Errorf("estimating gas: %w", status.Error(codes.Unavailable, "connection refused"))
+
+	require.NoError(t, b.allow())
+	b.record(ctx, unreachable)
+	// errors returned by core prove it reachable
+	require.NoError(t, b.allow())
+	b.record(ctx, errors.New("insufficient fees"))
+	require.NoError(t, b.allow())
+	b.record(ctx, unreachable)
+	// failures of the callers that gave up or ran out of time are not accounted
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	require.NoError(t, b.allow())
+	b.record(canceled, status.Error(codes.Unavailable, "connection refused"))
+	expired, cancel := context.WithTimeout(ctx, 0)
+	defer cancel()
+	require.NoError(t, b.allow())
+	b.record(expired, status.Error(codes.DeadlineExceeded, "context deadline exceeded"))
+	assert.False(t, b.isOpen())
+	// nor are the errors that are not gRPC statuses of failed calls to core
+	require.NoError(t, b.allow())
+	b.record(ctx, context.DeadlineExceeded)
+	assert.False(t, b.isOpen())
+
+	require.NoError(t, b.allow())
+	b.record(ctx, unreachable)
+	require.NoError(t, b.allow())
+	b.record(ctx, status.Error(codes.DeadlineExceeded, "deadline exceeded"))
+	assert.True(t, b.isOpen())
+
+	require.ErrorIs(t, b.allow(), ErrCoreUnavailable)
+	assert.EqualValues(t, 1, b.rejected.Load())
+
+	// a single submission probes core after the cooldown
+	time.Sleep(b.cooldown)
+	require.NoError(t, b.allow())
+	require.ErrorIs(t, b.allow(), ErrCoreUnavailable)
+	b.record(ctx, unreachable)
+	require.ErrorIs(t, b.allow(), ErrCoreUnavailable)
+
+	time.Sleep(b.cooldown)
+	require.NoError(t, b.allow())
+	b.record(ctx, nil)
+	assert.False(t, b.isOpen())
+	require.NoError(t, b.allow())
+	assert.EqualValues(t, 3, b.rejected.Load())
+}
+
+func TestSubmitBreaker_Disabled(t *testing.T) {
+	b := &submitBreaker{}
+	for i := 0; i < 10; i++ {
+		require.NoError(t, b.allow())
+		b.record(context.Background(), status.Error(codes.Unavailable, "connection refused"))
+	}
+	assert.False(t, b.isOpen())
+}
+
+func TestFailNextSubmissions(t *testing.T) {
+	ctx := context.Background()
+	ca := &CoreAccessor{}
+	submit := func() (*TxResponse, error) {
+		return &TxResponse{}, nil
+	}
+
+	ca.FailNextSubmissions(2)
+	for i := 0; i < 2; i++ {
+		_, err := ca.guardSubmit(ctx, submit)
+		require.ErrorIs(t, err, ErrInjectedFault)
+	}
+	_, err := ca.guardSubmit(ctx, submit)
+	require.NoError(t, err)
+
+	ca.FailNextSubmissions(5)
+	ca.FailNextSubmissions(0)
+	_, err = ca.guardSubmit(ctx, submit)
+	require.NoError(t, err)
+}
@@ -2,8 +2,11 @@ package state
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"math"
+	"sync/atomic"
 	"time"
 
 	sdkErrors "cosmossdk.io/errors"
@@ -18,10 +21,9 @@ import (
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	"github.com/tendermint/tendermint/rpc/client/http"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/celestiaorg/celestia-app/app"
-	appblob "github.com/celestiaorg/celestia-app/x/blob"
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
 	apptypes "github.com/celestiaorg/celestia-app/x/blob/types"
 	libhead "github.com/celestiaorg/go-header"
 
@@ -53,6 +55,20 @@ type CoreAccessor struct {
 	coreIP   string
 	rpcPort  string
 	grpcPort string
+	// tls secures the gRPC connection to core, if set.
+	tls *tls.Config
+	// authToken authenticates the node to core with every gRPC request, if set.
+	authToken string
+	// forwardedMetadata are the keys of caller metadata forwarded to core.
+	forwardedMetadata []string
+	// txs tracks the status of the transactions broadcasted through the node.
+	txs *txTracker
+	// gasPrice is the price per unit of gas paid by transactions submitted without a fee.
+	gasPrice float64
+	// breaker fails the submissions fast while core is unreachable.
+	breaker submitBreaker
+	// failNext is the amount of the next submissions failed on purpose.
+	failNext atomic.Uint64
 
 	lastPayForBlob  int64
 	payForBlobCount int64
@@ -67,19 +83,25 @@ func NewCoreAccessor(
 	coreIP,
 	rpcPort string,
 	grpcPort string,
+	opts ...Option,
 ) *CoreAccessor {
 	// create verifier
 	prt := merkle.DefaultProofRuntime()
 	prt.RegisterOpDecoder(storetypes.ProofOpIAVLCommitment, storetypes.CommitmentOpDecoder)
 	prt.RegisterOpDecoder(storetypes.ProofOpSimpleMerkleCommitment, storetypes.CommitmentOpDecoder)
-	return &CoreAccessor{
+	ca := &CoreAccessor{
 		signer:   signer,
 		getter:   getter,
 		coreIP:   coreIP,
 		rpcPort:  rpcPort,
 		grpcPort: grpcPort,
 		prt:      prt,
+		gasPrice: appconsts.DefaultMinGasPrice,
 	}
+	for _, opt := range opts {
+		opt(ca)
+	}
+	return ca
 }
 
 func (ca *CoreAccessor) Start(ctx context.Context) error {
@@ -88,9 +110,17 @@ func (ca *CoreAccessor) Start(ctx context.Context) error {
 	}
 	ca.ctx, ca.cancel = context.WithCancel(context.Background())
 
+	txs, err := newTxTracker()
+	if err != nil {
+		return err
+	}
+	ca.txs = txs
+
 	// dial given celestia-core endpoint
 	endpoint := fmt.Sprintf("%s:%s", ca.coreIP, ca.grpcPort)
-	client, err := grpc.DialContext(ctx, endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	client, err := grpc.DialContext(ctx, endpoint,
+		append(ca.dialOptions(), grpc.WithUnaryInterceptor(ca.forwardMetadata))...,
+	)
 	if err != nil {
 		return err
 	}
@@ -108,6 +138,7 @@ func (ca *CoreAccessor) Start(ctx context.Context) error {
 	}
 	ca.rpcCli = cli
 
+	go ca.pollTxs(ca.ctx)
 	return nil
 }
 
@@ -149,13 +180,27 @@ func (ca *CoreAccessor) constructSignedTx(
 		return nil, err
 	}
 
-	tx, err := ca.signer.BuildSignedTx(ca.signer.NewTxBuilder(opts...), msg)
+	tx, err := buildSignedTx(ca.signer, ca.signer.NewTxBuilder(opts...), msg)
 	if err != nil {
 		return nil, err
 	}
 	return ca.signer.EncodeTx(tx)
 }
 
+// submitMsg signs a transaction with the given message and submits it.
+func (ca *CoreAccessor) submitMsg(ctx context.Context, msg sdktypes.Msg, gasLim uint64, fee Int) (*TxResponse, error) {
+	return ca.guardSubmit(ctx, func() (*TxResponse, error) {
+		signedTx, err := ca.constructSignedTx(ctx, msg, apptypes.SetGasLimit(gasLim), withFee(fee))
+		if err != nil {
+			return nil, err
+		}
+		return ca.broadcastTx(ctx, signedTx, sdktx.BroadcastMode_BROADCAST_MODE_BLOCK)
+	})
+}
+
+// SubmitPayForBlob builds, signs and submits a PayForBlob transaction. A zero gas limit is
+// replaced by the one estimated by simulating the transaction, and a nil or zero fee by the fee
+// of the gas limit at the default gas price.
 func (ca *CoreAccessor) SubmitPayForBlob(
 	ctx context.Context,
 	fee Int,
@@ -174,14 +219,25 @@ func (ca *CoreAccessor) SubmitPayForBlob(
 		appblobs[i] = &b.Blob
 	}
 
-	response, err := appblob.SubmitPayForBlob(
-		ctx,
-		ca.signer,
-		ca.coreConn,
-		appblobs,
-		apptypes.SetGasLimit(gasLim),
-		withFee(fee),
-	)
+	response, err := ca.guardSubmit(ctx, func() (*TxResponse, error) {
+		if gasLim == 0 {
+			estimate, err := ca.estimatePayForBlob(ctx, appblobs)
+			if err != nil {
+				return nil, fmt.Errorf("estimating gas: %w", err)
+			}
+			gasLim = estimate.GasLimit
+		}
+		if fee.IsNil() || fee.IsZero() {
+			fee = ca.defaultFee(gasLim)
+		}
+
+		return ca.submitPayForBlob(
+			ctx,
+			appblobs,
+			apptypes.SetGasLimit(gasLim),
+			withFee(fee),
+		)
+	})
 	// metrics should only be counted on a successful PFD tx
 	if err == nil && response.Code == 0 {
 		ca.lastPayForBlob = time.Now().UnixMilli()
@@ -271,11 +327,7 @@ func (ca *CoreAccessor) BalanceForAddress(ctx context.Context, addr Address) (*B
 }
 
 func (ca *CoreAccessor) SubmitTx(ctx context.Context, tx Tx) (*TxResponse, error) {
-	txResp, err := apptypes.BroadcastTx(ctx, ca.coreConn, sdktx.BroadcastMode_BROADCAST_MODE_BLOCK, tx)
-	if err != nil {
-		return nil, err
-	}
-	return txResp.TxResponse, nil
+	return ca.SubmitTxWithBroadcastMode(ctx, tx, sdktx.BroadcastMode_BROADCAST_MODE_BLOCK)
 }
 
 func (ca *CoreAccessor) SubmitTxWithBroadcastMode(
@@ -283,11 +335,9 @@ func (ca *CoreAccessor) SubmitTxWithBroadcastMode(
 	tx Tx,
 	mode sdktx.BroadcastMode,
 ) (*TxResponse, error) {
-	txResp, err := apptypes.BroadcastTx(ctx, ca.coreConn, mode, tx)
-	if err != nil {
-		return nil, err
-	}
-	return txResp.TxResponse, nil
+	return ca.guardSubmit(ctx, func() (*TxResponse, error) {
+		return ca.broadcastTx(ctx, tx, mode)
+	})
 }
 
 func (ca *CoreAccessor) Transfer(
@@ -307,11 +357,7 @@ func (ca *CoreAccessor) Transfer(
 	}
 	coins := sdktypes.NewCoins(sdktypes.NewCoin(app.BondDenom, amount))
 	msg := banktypes.NewMsgSend(from, addr, coins)
-	signedTx, err := ca.constructSignedTx(ctx, msg, apptypes.SetGasLimit(gasLim), withFee(fee))
-	if err != nil {
-		return nil, err
-	}
-	return ca.SubmitTx(ctx, signedTx)
+	return ca.submitMsg(ctx, msg, gasLim, fee)
 }
 
 func (ca *CoreAccessor) CancelUnbondingDelegation(
@@ -332,11 +378,7 @@ func (ca *CoreAccessor) CancelUnbondingDelegation(
 	}
 	coins := sdktypes.NewCoin(app.BondDenom, amount)
 	msg := stakingtypes.NewMsgCancelUnbondingDelegation(from, valAddr, height.Int64(), coins)
-	signedTx, err := ca.constructSignedTx(ctx, msg, apptypes.SetGasLimit(gasLim), withFee(fee))
-	if err != nil {
-		return nil, err
-	}
-	return ca.SubmitTx(ctx, signedTx)
+	return ca.submitMsg(ctx, msg, gasLim, fee)
 }
 
 func (ca *CoreAccessor) BeginRedelegate(
@@ -357,11 +399,7 @@ func (ca *CoreAccessor) BeginRedelegate(
 	}
 	coins := sdktypes.NewCoin(app.BondDenom, amount)
 	msg := stakingtypes.NewMsgBeginRedelegate(from, srcValAddr, dstValAddr, coins)
-	signedTx, err := ca.constructSignedTx(ctx, msg, apptypes.SetGasLimit(gasLim), withFee(fee))
-	if err != nil {
-		return nil, err
-	}
-	return ca.SubmitTx(ctx, signedTx)
+	return ca.submitMsg(ctx, msg, gasLim, fee)
 }
 
 func (ca *CoreAccessor) Undelegate(
@@ -381,11 +419,7 @@ func (ca *CoreAccessor) Undelegate(
 	}
 	coins := sdktypes.NewCoin(app.BondDenom, amount)
 	msg := stakingtypes.NewMsgUndelegate(from, delAddr, coins)
-	signedTx, err := ca.constructSignedTx(ctx, msg, apptypes.SetGasLimit(gasLim), withFee(fee))
-	if err != nil {
-		return nil, err
-	}
-	return ca.SubmitTx(ctx, signedTx)
+	return ca.submitMsg(ctx, msg, gasLim, fee)
 }
 
 func (ca *CoreAccessor) Delegate(
@@ -405,11 +439,7 @@ func (ca *CoreAccessor) Delegate(
 	}
 	coins := sdktypes.NewCoin(app.BondDenom, amount)
 	msg := stakingtypes.NewMsgDelegate(from, delAddr, coins)
-	signedTx, err := ca.constructSignedTx(ctx, msg, apptypes.SetGasLimit(gasLim), withFee(fee))
-	if err != nil {
-		return nil, err
-	}
-	return ca.SubmitTx(ctx, signedTx)
+	return ca.submitMsg(ctx, msg, gasLim, fee)
 }
 
 func (ca *CoreAccessor) QueryDelegation(
@@ -459,6 +489,11 @@ func (ca *CoreAccessor) IsStopped(context.Context) bool {
 	return ca.ctx.Err() != nil
 }
 
+// defaultFee returns the fee of the given gas limit at the default gas price.
+func (ca *CoreAccessor) defaultFee(gasLim uint64) Int {
+	return sdktypes.NewInt(int64(math.Ceil(ca.gasPrice * float64(gasLim))))
+}
+
 func withFee(fee Int) apptypes.TxBuilderOption {
 	gasFee := sdktypes.NewCoins(sdktypes.NewCoin(app.BondDenom, fee))
 	return apptypes.SetFeeAmount(gasFee)
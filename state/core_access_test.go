@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 func TestLifecycle(t *testing.T) {
@@ -31,3 +33,55 @@ func TestLifecycle(t *testing.T) {
 	err = ca.Stop(stopCtx)
 	require.NoError(t, err)
 }
+
+func TestForwardMetadata(t *testing.T) {
+	ca := NewCoreAccessor(nil, nil, "", "", "", WithForwardedMetadata("Authorization"))
+
+	var forwarded metadata.MD
+	invoker := func(ctx context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		forwarded, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+
+	ctx := WithCallerMetadata(context.Background(), metadata.Pairs(
+		"authorization", "Bearer token",
+		"x-tenant", "tenant",
+	))
+	require.NoError(t, ca.forwardMetadata(ctx, "method", nil, nil, nil, invoker))
+	// only the allowed keys are forwarded
+	require.Equal(t, metadata.Pairs("authorization", "Bearer token"), forwarded)
+
+	require.NoError(t, ca.forwardMetadata(context.Background(), "method", nil, nil, nil, invoker))
+	require.Empty(t, forwarded)
+}
+
+func TestValidateForwardedMetadata(t *testing.T) {
+	require.NoError(t, ValidateForwardedMetadata([]string{"Authorization", "x-api-key"}))
+	for _, key := range []string{"", ":authority", "grpc-timeout", "token-bin", "x api key"} {
+		require.Error(t, ValidateForwardedMetadata([]string{key}), key)
+	}
+}
+
+func TestSubmitRawTx_NotBlobTx(t *testing.T) {
+	ca := NewCoreAccessor(nil, nil, "", "", "")
+	_, err := ca.SubmitRawTx(context.Background(), []byte("not a blob tx"))
+	require.ErrorIs(t, err, ErrNotBlobTx)
+}
+
+func TestDefaultFee(t *testing.T) {
+	ca := NewCoreAccessor(nil, nil, "", "", "")
+	require.EqualValues(t, 10, ca.defaultFee(100).Int64())
+
+	ca = NewCoreAccessor(nil, nil, "", "", "", WithDefaultGasPrice(0.25))
+	require.EqualValues(t, 25, ca.defaultFee(100).Int64())
+	// fractions of utia are rounded up to not fall below the gas price
+	require.EqualValues(t, 1, ca.defaultFee(1).Int64())
+}
+
+func TestFeeEstimate(t *testing.T) {
+	ca := NewCoreAccessor(nil, nil, "", "", "", WithDefaultGasPrice(0.2))
+	estimate := ca.feeEstimate(100_000)
+	require.EqualValues(t, 110_000, estimate.GasLimit)
+	require.Equal(t, 0.2, estimate.GasPrice)
+	require.EqualValues(t, 22_000, estimate.Fee.Int64())
+}
@@ -0,0 +1,53 @@
+package state
+
+import (
+	"context"
+	"crypto/tls"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// WithGRPCTLS connects to the gRPC endpoint of core over TLS with the given config, e.g. to reach
+// a remote core across an untrusted network. Nil config keeps the connection unencrypted. The
+// connection to the RPC endpoint of core, used for ABCI queries, is left unencrypted.
+func WithGRPCTLS(cfg *tls.Config) Option {
+	return func(ca *CoreAccessor) {
+		ca.tls = cfg
+	}
+}
+
+// WithGRPCAuthToken authenticates the node to core, or to a proxy in front of it, with the given
+// bearer token attached to every gRPC request. The token is only sent over TLS, see WithGRPCTLS.
+// The requests to the RPC endpoint of core are not authenticated.
+func WithGRPCAuthToken(token string) Option {
+	return func(ca *CoreAccessor) {
+		ca.authToken = token
+	}
+}
+
+// dialOptions gives the credentials of the gRPC connection to core.
+func (ca *CoreAccessor) dialOptions() []grpc.DialOption {
+	creds := insecure.NewCredentials()
+	if ca.tls != nil {
+		creds = credentials.NewTLS(ca.tls)
+	}
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	if ca.authToken != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(bearerToken(ca.authToken)))
+	}
+	return opts
+}
+
+// bearerToken attaches the token as the authorization metadata of gRPC requests.
+type bearerToken string
+
+func (t bearerToken) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+// RequireTransportSecurity keeps the token from being sent in plaintext.
+func (t bearerToken) RequireTransportSecurity() bool {
+	return true
+}
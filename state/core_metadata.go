@@ -0,0 +1,99 @@
+package state
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type callerMetadataKey struct{}
+
+// WithCallerMetadata attaches the gRPC metadata supplied by the caller of the node's API, e.g. its
+// credentials, to the context. CoreAccessor forwards only the keys allowed with
+// WithForwardedMetadata to core along with the requests made in the context.
+func WithCallerMetadata(ctx context.Context, md metadata.MD) context.Context {
+	return context.WithValue(ctx, callerMetadataKey{}, md)
+}
+
+func callerMetadata(ctx context.Context) metadata.MD {
+	md, _ := ctx.Value(callerMetadataKey{}).(metadata.MD)
+	return md
+}
+
+// Option configures CoreAccessor.
+type Option func(*CoreAccessor)
+
+// WithForwardedMetadata allows the given metadata keys supplied by the callers of the node's API to
+// be forwarded to core with gRPC requests, so that each caller is identified by its own credentials.
+func WithForwardedMetadata(keys ...string) Option {
+	return func(ca *CoreAccessor) {
+		for _, key := range keys {
+			ca.forwardedMetadata = append(ca.forwardedMetadata, strings.ToLower(key))
+		}
+	}
+}
+
+// WithDefaultGasPrice sets the price per unit of gas paid by transactions submitted without a fee.
+// Zero keeps the default minimum gas price of the app.
+func WithDefaultGasPrice(price float64) Option {
+	return func(ca *CoreAccessor) {
+		if price > 0 {
+			ca.gasPrice = price
+		}
+	}
+}
+
+// WithSubmitBreaker fails the submissions fast with ErrCoreUnavailable for the cooldown, once the
+// given amount of submissions in a row failed to reach core. Zero threshold disables it.
+func WithSubmitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(ca *CoreAccessor) {
+		ca.breaker.threshold = threshold
+		ca.breaker.cooldown = cooldown
+	}
+}
+
+// ValidateForwardedMetadata checks the keys can be forwarded as gRPC metadata.
+func ValidateForwardedMetadata(keys []string) error {
+	for _, key := range keys {
+		key = strings.ToLower(key)
+		switch {
+		case key == "":
+			return fmt.Errorf("state: empty forwarded metadata key")
+		// reserved by gRPC and HTTP/2 themselves
+		case strings.HasPrefix(key, ":"), strings.HasPrefix(key, "grpc-"):
+			return fmt.Errorf("state: reserved metadata key %s can not be forwarded", key)
+		case strings.HasSuffix(key, "-bin"):
+			return fmt.Errorf("state: binary metadata key %s can not be forwarded", key)
+		}
+		for _, r := range key {
+			if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.') {
+				return fmt.Errorf("state: invalid metadata key %s", key)
+			}
+		}
+	}
+	return nil
+}
+
+// forwardMetadata is a gRPC interceptor attaching the allowed metadata of the caller to requests
+// to core.
+func (ca *CoreAccessor) forwardMetadata(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	if md := callerMetadata(ctx); md != nil {
+		for _, key := range ca.forwardedMetadata {
+			for _, val := range md.Get(key) {
+				ctx = metadata.AppendToOutgoingContext(ctx, key, val)
+			}
+		}
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
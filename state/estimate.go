@@ -0,0 +1,105 @@
+package state
+
+import (
+	"context"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	sdktx "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	coretypes "github.com/tendermint/tendermint/types"
+
+	apptypes "github.com/celestiaorg/celestia-app/x/blob/types"
+)
+
+// gasPadding is the percentage the simulated gas usage of a transaction is padded with, as it may
+// differ slightly once the transaction is executed in a block.
+const gasPadding = 10
+
+// FeeEstimate is the recommended gas limit and gas price to submit a transaction with.
+type FeeEstimate struct {
+	GasLimit uint64 `json:"gas_limit"`
+	// GasPrice is the price per unit of gas in utia.
+	GasPrice float64 `json:"gas_price"`
+	// Fee is the fee of the GasLimit at the GasPrice in utia.
+	Fee Int `json:"fee"`
+}
+
+// EstimateGas simulates the given signed transaction against core and returns the amount of gas it
+// uses. PayForBlob transactions can be passed together with their blobs.
+func (ca *CoreAccessor) EstimateGas(ctx context.Context, tx Tx) (uint64, error) {
+	if blobTx, ok := coretypes.UnmarshalBlobTx(tx); ok {
+		tx = blobTx.Tx
+	}
+	resp, err := sdktx.NewServiceClient(ca.coreConn).Simulate(ctx, &sdktx.SimulateRequest{TxBytes: tx})
+	if err != nil {
+		return 0, err
+	}
+	return resp.GasInfo.GasUsed, nil
+}
+
+// EstimateFee simulates the given signed transaction against core and recommends the gas limit
+// and the gas price to submit it with.
+func (ca *CoreAccessor) EstimateFee(ctx context.Context, tx Tx) (*FeeEstimate, error) {
+	gasUsed, err := ca.EstimateGas(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	return ca.feeEstimate(gasUsed), nil
+}
+
+// estimateMsg simulates a transaction with the given message from the node's account. The
+// transaction is not signed, as signatures are not verified in simulations, so that accounts on
+// remote signers or Ledger devices are not asked to sign. It pays a nominal fee, since deducting
+// the fee consumes gas as well.
+func (ca *CoreAccessor) estimateMsg(ctx context.Context, msg sdktypes.Msg) (*FeeEstimate, error) {
+	err := ca.signer.QueryAccountNumber(ctx, ca.coreConn)
+	if err != nil {
+		return nil, err
+	}
+	signerData, err := ca.signer.GetSignerData()
+	if err != nil {
+		return nil, err
+	}
+
+	builder := ca.signer.NewTxBuilder(withFee(sdktypes.OneInt()))
+	if err = builder.SetMsgs(msg); err != nil {
+		return nil, err
+	}
+	err = builder.SetSignatures(signing.SignatureV2{
+		PubKey: signerData.PubKey,
+		Data: &signing.SingleSignatureData{
+			SignMode: signing.SignMode_SIGN_MODE_DIRECT,
+		},
+		Sequence: signerData.Sequence,
+	})
+	if err != nil {
+		return nil, err
+	}
+	tx, err := ca.signer.EncodeTx(builder.GetTx())
+	if err != nil {
+		return nil, err
+	}
+	return ca.EstimateFee(ctx, tx)
+}
+
+// estimatePayForBlob simulates a PayForBlob of the given blobs from the node's account.
+func (ca *CoreAccessor) estimatePayForBlob(ctx context.Context, blobs []*apptypes.Blob) (*FeeEstimate, error) {
+	addr, err := ca.signer.GetSignerInfo().GetAddress()
+	if err != nil {
+		return nil, err
+	}
+	msg, err := apptypes.NewMsgPayForBlobs(addr.String(), blobs...)
+	if err != nil {
+		return nil, err
+	}
+	return ca.estimateMsg(ctx, msg)
+}
+
+func (ca *CoreAccessor) feeEstimate(gasUsed uint64) *FeeEstimate {
+	gasLimit := gasUsed + (gasUsed*gasPadding+99)/100
+	return &FeeEstimate{
+		GasLimit: gasLimit,
+		GasPrice: ca.gasPrice,
+		Fee:      ca.defaultFee(gasLimit),
+	}
+}
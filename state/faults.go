@@ -0,0 +1,28 @@
+package state
+
+import (
+	"errors"
+)
+
+// ErrInjectedFault is returned by the submission methods failed on purpose with
+// FailNextSubmissions.
+var ErrInjectedFault = errors.New("state: injected fault")
+
+// FailNextSubmissions makes the next n submissions fail with ErrInjectedFault without contacting
+// core, for tests to exercise the handling of failed submissions. Zero stops failing them.
+func (ca *CoreAccessor) FailNextSubmissions(n uint64) {
+	ca.failNext.Store(n)
+}
+
+// injectedFault returns ErrInjectedFault if the submission is to be failed.
+func (ca *CoreAccessor) injectedFault() error {
+	for {
+		n := ca.failNext.Load()
+		if n == 0 {
+			return nil
+		}
+		if ca.failNext.CompareAndSwap(n, n-1) {
+			return ErrInjectedFault
+		}
+	}
+}
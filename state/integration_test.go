@@ -47,15 +47,19 @@ func (s *IntegrationTestSuite) SetupSuite() {
 
 	cfg := core.DefaultTestConfig()
 	s.cctx = core.StartTestNodeWithConfig(s.T(), cfg)
+	var err error
 	s.accounts = cfg.Accounts
 
 	signer := blobtypes.NewKeyringSigner(s.cctx.Keyring, s.accounts[0], s.cctx.ChainID)
 	accessor := NewCoreAccessor(signer, localHeader{s.cctx.Client}, "", "", "")
 	setClients(accessor, s.cctx.GRPCClient, s.cctx.Client)
+	accessor.ctx = context.Background()
+	accessor.txs, err = newTxTracker()
+	require.NoError(s.T(), err)
 	s.accessor = accessor
 
 	// required to ensure the Head request is non-nil
-	_, err := s.cctx.WaitForHeight(3)
+	_, err = s.cctx.WaitForHeight(3)
 	require.NoError(s.T(), err)
 }
 
@@ -116,6 +120,33 @@ func (s *IntegrationTestSuite) TestGetBalance() {
 	}
 }
 
+func (s *IntegrationTestSuite) TestSubscribeTxStatus() {
+	require := s.Require()
+	ctx := context.Background()
+
+	resp, err := s.accessor.Transfer(ctx, s.getAddress(s.accounts[1]).(sdk.AccAddress),
+		sdk.NewInt(1000), sdk.NewInt(10000), 100000)
+	require.NoError(err)
+	require.EqualValues(0, resp.Code)
+
+	sub, err := s.accessor.SubscribeTxStatus(ctx, resp.TxHash)
+	require.NoError(err)
+	st := <-sub
+	require.Equal(TxCommitted, st.Status)
+	require.Equal(resp.Height, st.Response.Height)
+	_, ok := <-sub
+	require.False(ok, "subscription must end once the transaction is committed")
+
+	subCtx, cancel := context.WithCancel(ctx)
+	sub, err = s.accessor.SubscribeTxStatus(subCtx, "0A0B0C")
+	require.NoError(err)
+	st = <-sub
+	require.Equal(TxUnknown, st.Status)
+	cancel()
+	_, ok = <-sub
+	require.False(ok)
+}
+
 // This test can be used to generate a json encoded block for other test data,
 // such as that in share/availability/light/testdata
 func (s *IntegrationTestSuite) TestGenerateJSONBlock() {
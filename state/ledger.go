@@ -0,0 +1,109 @@
+package state
+
+import (
+	"context"
+
+	sdkclient "github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	sdktx "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	coretypes "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/celestia-app/app"
+	"github.com/celestiaorg/celestia-app/app/encoding"
+	apptypes "github.com/celestiaorg/celestia-app/x/blob/types"
+)
+
+// aminoSignModeHandler produces the sign bytes of transactions signed on Ledger devices.
+var aminoSignModeHandler = encoding.MakeConfig(app.ModuleEncodingRegisters...).TxConfig.SignModeHandler()
+
+// buildSignedTx builds and signs a transaction with the given messages. Accounts kept on a Ledger
+// device are signed in SIGN_MODE_LEGACY_AMINO_JSON, the only mode the Cosmos Ledger app can
+// display to the user for confirmation, while others are signed by the KeyringSigner in
+// SIGN_MODE_DIRECT.
+func buildSignedTx(
+	signer *apptypes.KeyringSigner,
+	builder sdkclient.TxBuilder,
+	msgs ...sdktypes.Msg,
+) (authsigning.Tx, error) {
+	record := signer.GetSignerInfo()
+	if record.GetType() != keyring.TypeLedger {
+		return signer.BuildSignedTx(builder, msgs...)
+	}
+
+	err := builder.SetMsgs(msgs...)
+	if err != nil {
+		return nil, err
+	}
+	signerData, err := signer.GetSignerData()
+	if err != nil {
+		return nil, err
+	}
+
+	// an empty signature has to be set first to generate the correct sign bytes
+	sig := signing.SignatureV2{
+		PubKey: signerData.PubKey,
+		Data: &signing.SingleSignatureData{
+			SignMode: signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON,
+		},
+		Sequence: signerData.Sequence,
+	}
+	if err = builder.SetSignatures(sig); err != nil {
+		return nil, err
+	}
+	signBytes, err := aminoSignModeHandler.GetSignBytes(
+		signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON,
+		signerData,
+		builder.GetTx(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infow("confirm the transaction on the Ledger device", "account", record.Name)
+	sigBytes, _, err := signer.Sign(record.Name, signBytes)
+	if err != nil {
+		return nil, err
+	}
+	sig.Data.(*signing.SingleSignatureData).Signature = sigBytes
+	if err = builder.SetSignatures(sig); err != nil {
+		return nil, err
+	}
+	return builder.GetTx(), nil
+}
+
+// submitPayForBlob signs a PayForBlobs for the given blobs and submits it together with them.
+func (ca *CoreAccessor) submitPayForBlob(
+	ctx context.Context,
+	blobs []*apptypes.Blob,
+	opts ...apptypes.TxBuilderOption,
+) (*TxResponse, error) {
+	addr, err := ca.signer.GetSignerInfo().GetAddress()
+	if err != nil {
+		return nil, err
+	}
+	msg, err := apptypes.NewMsgPayForBlobs(addr.String(), blobs...)
+	if err != nil {
+		return nil, err
+	}
+	err = ca.signer.QueryAccountNumber(ctx, ca.coreConn)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := buildSignedTx(ca.signer, ca.signer.NewTxBuilder(opts...), msg)
+	if err != nil {
+		return nil, err
+	}
+	rawTx, err := ca.signer.EncodeTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	blobTx, err := coretypes.MarshalBlobTx(rawTx, blobs...)
+	if err != nil {
+		return nil, err
+	}
+	return ca.broadcastTx(ctx, blobTx, sdktx.BroadcastMode_BROADCAST_MODE_BLOCK)
+}
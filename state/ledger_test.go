@@ -0,0 +1,120 @@
+package state
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-app/app"
+	"github.com/celestiaorg/celestia-app/app/encoding"
+	appns "github.com/celestiaorg/celestia-app/pkg/namespace"
+	apptypes "github.com/celestiaorg/celestia-app/x/blob/types"
+)
+
+func TestBuildSignedTx_Keyring(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	ring := keyring.NewInMemory(encCfg.Codec)
+	record, _, err := ring.NewMnemonic("local", keyring.English, "", "", hd.Secp256k1)
+	require.NoError(t, err)
+
+	signer := newTestSigner(ring, record.Name)
+	tx, err := buildSignedTx(signer, signer.NewTxBuilder(), newTestPayForBlob(t, record))
+	require.NoError(t, err)
+
+	requireSignature(t, signer, tx, signing.SignMode_SIGN_MODE_DIRECT)
+}
+
+func TestBuildSignedTx_Ledger(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	ring := &ledgerKeyring{
+		Keyring: keyring.NewInMemory(encCfg.Codec),
+		priv:    secp256k1.GenPrivKey(),
+	}
+	record, err := keyring.NewLedgerRecord("ledger", ring.priv.PubKey(), hd.NewFundraiserParams(0, 118, 0))
+	require.NoError(t, err)
+	ring.record = record
+
+	signer := newTestSigner(ring, record.Name)
+	tx, err := buildSignedTx(signer, signer.NewTxBuilder(), newTestPayForBlob(t, record))
+	require.NoError(t, err)
+
+	requireSignature(t, signer, tx, signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON)
+	// the device is asked once to sign the JSON it displays to the user
+	require.Len(t, ring.signed, 1)
+	assert.True(t, bytes.HasPrefix(ring.signed[0], []byte(`{"account_number":"7"`)), string(ring.signed[0]))
+}
+
+func newTestSigner(ring keyring.Keyring, name string) *apptypes.KeyringSigner {
+	signer := apptypes.NewKeyringSigner(ring, name, "private")
+	signer.SetAccountNumber(7)
+	signer.SetSequence(3)
+	return signer
+}
+
+func newTestPayForBlob(t *testing.T, record *keyring.Record) sdktypes.Msg {
+	addr, err := record.GetAddress()
+	require.NoError(t, err)
+	ns := appns.MustNewV0(bytes.Repeat([]byte{1}, appns.NamespaceVersionZeroIDSize))
+	blob, err := apptypes.NewBlob(ns, []byte("data"), 0)
+	require.NoError(t, err)
+	msg, err := apptypes.NewMsgPayForBlobs(addr.String(), blob)
+	require.NoError(t, err)
+	return msg
+}
+
+// requireSignature requires the transaction to carry a single valid signature of the signer made
+// in the given mode.
+func requireSignature(t *testing.T, signer *apptypes.KeyringSigner, tx authsigning.Tx, mode signing.SignMode) {
+	sigs, err := tx.GetSignaturesV2()
+	require.NoError(t, err)
+	require.Len(t, sigs, 1)
+	data, ok := sigs[0].Data.(*signing.SingleSignatureData)
+	require.True(t, ok)
+	require.Equal(t, mode, data.SignMode)
+	require.EqualValues(t, 3, sigs[0].Sequence)
+
+	signerData, err := signer.GetSignerData()
+	require.NoError(t, err)
+	err = authsigning.VerifySignature(sigs[0].PubKey, signerData, data, aminoSignModeHandler, tx)
+	require.NoError(t, err)
+}
+
+// ledgerKeyring presents its only key as kept on a Ledger device, while signing with a local key
+// and keeping the bytes it was asked to sign.
+type ledgerKeyring struct {
+	keyring.Keyring
+
+	record *keyring.Record
+	priv   cryptotypes.PrivKey
+	signed [][]byte
+}
+
+func (k *ledgerKeyring) Key(uid string) (*keyring.Record, error) {
+	if uid != k.record.Name {
+		return nil, errors.New("no such key")
+	}
+	return k.record, nil
+}
+
+func (k *ledgerKeyring) Sign(uid string, msg []byte) ([]byte, cryptotypes.PubKey, error) {
+	if uid != k.record.Name {
+		return nil, nil, errors.New("no such key")
+	}
+	k.signed = append(k.signed, msg)
+	sig, err := k.priv.Sign(msg)
+	return sig, k.priv.PubKey(), err
+}
+
+func (k *ledgerKeyring) SignByAddress(sdktypes.Address, []byte) ([]byte, cryptotypes.PubKey, error) {
+	return nil, nil, errors.New("ledger keys are signed by name in amino JSON mode")
+}
@@ -18,13 +18,27 @@ func WithMetrics(ca *CoreAccessor) {
 		"last_pfb_timestamp",
 		metric.WithDescription("Timestamp of the last submitted PayForBlob transaction"),
 	)
+	breakerOpen, _ := meter.Int64ObservableGauge(
+		"core_breaker_open",
+		metric.WithDescription("Whether submissions fail fast as core is unreachable (1) or not (0)"),
+	)
+	breakerRejected, _ := meter.Int64ObservableCounter(
+		"core_breaker_rejected_count",
+		metric.WithDescription("Total count of submissions failed fast as core is unreachable"),
+	)
 
 	callback := func(ctx context.Context, observer metric.Observer) error {
 		observer.ObserveInt64(pfbCounter, ca.payForBlobCount)
 		observer.ObserveInt64(lastPfbTimestamp, ca.lastPayForBlob)
+		var open int64
+		if ca.breaker.isOpen() {
+			open = 1
+		}
+		observer.ObserveInt64(breakerOpen, open)
+		observer.ObserveInt64(breakerRejected, ca.breaker.rejected.Load())
 		return nil
 	}
-	_, err := meter.RegisterCallback(callback, pfbCounter, lastPfbTimestamp)
+	_, err := meter.RegisterCallback(callback, pfbCounter, lastPfbTimestamp, breakerOpen, breakerRejected)
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,71 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sdkErrors "cosmossdk.io/errors"
+	sdktx "github.com/cosmos/cosmos-sdk/types/tx"
+	coretypes "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/celestia-app/app"
+	"github.com/celestiaorg/celestia-app/app/encoding"
+	apptypes "github.com/celestiaorg/celestia-app/x/blob/types"
+)
+
+var (
+	encCfg = encoding.MakeConfig(app.ModuleEncodingRegisters...)
+
+	ErrNotBlobTx = errors.New("state: transaction is not a PayForBlob transaction")
+)
+
+// TxStatusCode describes the progress of a transaction.
+type TxStatusCode string
+
+const (
+	// TxPending is the status of a transaction accepted into the mempool and not yet included in
+	// a block.
+	TxPending TxStatusCode = "PENDING"
+	// TxCommitted is the status of a transaction included in a block.
+	TxCommitted TxStatusCode = "COMMITTED"
+	// TxFailed is the status of a transaction that failed to broadcast, was rejected by the
+	// mempool or was included in a block but failed to execute.
+	TxFailed TxStatusCode = "FAILED"
+	// TxUnknown is the status of a transaction that is neither included in a block nor was
+	// broadcasted through this node recently.
+	TxUnknown TxStatusCode = "UNKNOWN"
+)
+
+// TxStatus is the status of a transaction.
+type TxStatus struct {
+	Status TxStatusCode `json:"status"`
+	// Response is the result of the transaction, once included in a block or rejected.
+	Response *TxResponse `json:"response,omitempty"`
+	// Error is the error the broadcast of the transaction failed with, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// SubmitRawTx validates and broadcasts an externally constructed and signed PayForBlob
+// transaction. It returns once the transaction is accepted into the mempool, and its status
+// can be tracked by the returned hash with TxStatus or SubscribeTxStatus.
+func (ca *CoreAccessor) SubmitRawTx(ctx context.Context, tx Tx) (*TxResponse, error) {
+	blobTx, ok := coretypes.UnmarshalBlobTx(tx)
+	if !ok {
+		return nil, ErrNotBlobTx
+	}
+	if err := apptypes.ValidateBlobTx(encCfg.TxConfig, blobTx); err != nil {
+		return nil, fmt.Errorf("state: invalid PayForBlob transaction: %w", err)
+	}
+
+	response, err := ca.guardSubmit(ctx, func() (*TxResponse, error) {
+		return ca.broadcastTx(ctx, tx, sdktx.BroadcastMode_BROADCAST_MODE_SYNC)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if response.Code != 0 {
+		return response, sdkErrors.ABCIError(response.Codespace, response.Code, response.RawLog)
+	}
+	return response, nil
+}
@@ -0,0 +1,165 @@
+package state
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/celestiaorg/celestia-app/app"
+	"github.com/celestiaorg/celestia-app/app/encoding"
+)
+
+// ErrInvalidSignature is returned when a Signer produces a signature not verifying against its
+// public key.
+var ErrInvalidSignature = errors.New("state: signer returned invalid signature")
+
+// Signer signs transactions on behalf of the node's account with a key kept outside of the node's
+// keyring. Keys in the keyring are used through the keyring directly.
+type Signer interface {
+	// PubKey returns the public key of the account.
+	PubKey(ctx context.Context) (cryptotypes.PubKey, error)
+	// Sign signs the given SIGN_MODE_DIRECT sign bytes of a transaction.
+	Sign(ctx context.Context, signBytes []byte) ([]byte, error)
+}
+
+// RemoteSigner is a Signer delegating signing to an external service over HTTP(S), e.g. a KMS
+// keeping the key of the account. Other transports, e.g. gRPC, are not supported. The service has
+// to serve two endpoints:
+//   - GET /pubkey, responding with {"pub_key": "<base64 compressed secp256k1 public key>"}
+//   - POST /sign with {"sign_bytes": "<base64>"}, responding with {"signature": "<base64>"}
+//
+// Signatures are verified against the public key before they are used.
+type RemoteSigner struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// NewRemoteSigner creates a new RemoteSigner calling the service under the given URL. A non-empty
+// token is sent as a bearer token with every request.
+func NewRemoteSigner(url, token string, timeout time.Duration) *RemoteSigner {
+	return &RemoteSigner{
+		url:    strings.TrimSuffix(url, "/"),
+		token:  token,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+type remotePubKeyResponse struct {
+	PubKey []byte `json:"pub_key"`
+}
+
+type remoteSignRequest struct {
+	SignBytes []byte `json:"sign_bytes"`
+}
+
+type remoteSignResponse struct {
+	Signature []byte `json:"signature"`
+}
+
+func (s *RemoteSigner) PubKey(ctx context.Context) (cryptotypes.PubKey, error) {
+	resp := &remotePubKeyResponse{}
+	if err := s.call(ctx, http.MethodGet, "/pubkey", nil, resp); err != nil {
+		return nil, err
+	}
+	if len(resp.PubKey) != secp256k1.PubKeySize {
+		return nil, fmt.Errorf("state: remote signer returned public key of %d bytes", len(resp.PubKey))
+	}
+	return &secp256k1.PubKey{Key: resp.PubKey}, nil
+}
+
+func (s *RemoteSigner) Sign(ctx context.Context, signBytes []byte) ([]byte, error) {
+	resp := &remoteSignResponse{}
+	err := s.call(ctx, http.MethodPost, "/sign", &remoteSignRequest{SignBytes: signBytes}, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Signature, nil
+}
+
+func (s *RemoteSigner) call(ctx context.Context, method, path string, body, out any) error {
+	var reqBody io.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(raw)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, s.url+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("state: calling remote signer: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("state: remote signer responded with %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// NewSignerKeyring returns an in-memory keyring holding only the public key of the Signer under
+// the given name and delegating signing with it to the Signer, so it can back a KeyringSigner.
+func NewSignerKeyring(ctx context.Context, name string, signer Signer) (keyring.Keyring, error) {
+	pub, err := signer.PubKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	ring := keyring.NewInMemory(encCfg.Codec)
+	if _, err = ring.SaveOfflineKey(name, pub); err != nil {
+		return nil, err
+	}
+	return &signerKeyring{Keyring: ring, name: name, pub: pub, signer: signer}, nil
+}
+
+// signerKeyring is a keyring signing with the key of its only record through a Signer.
+type signerKeyring struct {
+	keyring.Keyring
+
+	name   string
+	pub    cryptotypes.PubKey
+	signer Signer
+}
+
+func (k *signerKeyring) Sign(uid string, msg []byte) ([]byte, cryptotypes.PubKey, error) {
+	if uid != k.name {
+		return nil, nil, fmt.Errorf("state: no key %s in signer keyring", uid)
+	}
+	// keyring does not pass a context through, so the Signer is relied on to time out
+	sig, err := k.signer.Sign(context.Background(), msg)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !k.pub.VerifySignature(msg, sig) {
+		return nil, nil, ErrInvalidSignature
+	}
+	return sig, k.pub, nil
+}
+
+func (k *signerKeyring) SignByAddress(address sdktypes.Address, msg []byte) ([]byte, cryptotypes.PubKey, error) {
+	if !bytes.Equal(address.Bytes(), k.pub.Address()) {
+		return nil, nil, fmt.Errorf("state: no key with address %s in signer keyring", address)
+	}
+	return k.Sign(k.name, msg)
+}
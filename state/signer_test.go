@@ -0,0 +1,72 @@
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteSigner(t *testing.T) {
+	ctx := context.Background()
+	priv := secp256k1.GenPrivKey()
+	var tamper atomic.Bool
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/pubkey":
+			_ = json.NewEncoder(w).Encode(&remotePubKeyResponse{PubKey: priv.PubKey().Bytes()})
+		case "/sign":
+			// failures are reported to the signer under test, as require must not be called from
+			// the handler's goroutine
+			req := &remoteSignRequest{}
+			if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			sig, err := priv.Sign(req.SignBytes)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if tamper.Load() {
+				sig[0] ^= 0xff
+			}
+			_ = json.NewEncoder(w).Encode(&remoteSignResponse{Signature: sig})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	_, err := NewSignerKeyring(ctx, "remote", NewRemoteSigner(srv.URL, "wrong", time.Second))
+	assert.Error(t, err)
+
+	ring, err := NewSignerKeyring(ctx, "remote", NewRemoteSigner(srv.URL, "token", time.Second))
+	require.NoError(t, err)
+	record, err := ring.Key("remote")
+	require.NoError(t, err)
+	addr, err := record.GetAddress()
+	require.NoError(t, err)
+	assert.EqualValues(t, priv.PubKey().Address(), addr)
+
+	msg := []byte("sign bytes")
+	sig, pub, err := ring.SignByAddress(addr, msg)
+	require.NoError(t, err)
+	assert.True(t, pub.VerifySignature(msg, sig))
+
+	tamper.Store(true)
+	_, _, err = ring.Sign("remote", msg)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
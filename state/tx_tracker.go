@@ -0,0 +1,246 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	sdktx "github.com/cosmos/cosmos-sdk/types/tx"
+	lru "github.com/hashicorp/golang-lru"
+	coretypes "github.com/tendermint/tendermint/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	apptypes "github.com/celestiaorg/celestia-app/x/blob/types"
+)
+
+const (
+	// maxTrackedTxs is the amount of the most recent transactions whose status is tracked.
+	maxTrackedTxs = 1000
+	// maxTxSubscriptions bounds the amount of concurrent subscriptions to transaction statuses, as
+	// each subscribed transaction is polled from core.
+	maxTxSubscriptions = 100
+	// txSubscriptionTimeout bounds the time a subscription to a transaction status lasts.
+	txSubscriptionTimeout = time.Minute * 10
+)
+
+// txPollInterval is how often core is polled for the status of the transactions subscribed to.
+var txPollInterval = time.Second * 2
+
+// ErrTooManyTxSubscriptions is returned when subscribing to a transaction status while
+// maxTxSubscriptions are active.
+var ErrTooManyTxSubscriptions = errors.New("state: too many transaction status subscriptions")
+
+// txTracker tracks the status of the transactions broadcasted through the node, from the
+// broadcast through the mempool to their inclusion in a block, and notifies the subscribers to
+// their status.
+type txTracker struct {
+	// statuses are the last known statuses of the tracked transactions by hash.
+	statuses *lru.Cache
+
+	lk     sync.Mutex
+	subs   map[string][]chan *TxStatus
+	amount int
+}
+
+func newTxTracker() (*txTracker, error) {
+	statuses, err := lru.New(maxTrackedTxs)
+	if err != nil {
+		return nil, err
+	}
+	return &txTracker{
+		statuses: statuses,
+		subs:     make(map[string][]chan *TxStatus),
+	}, nil
+}
+
+// status returns the last known status of the transaction, if it is tracked.
+func (t *txTracker) status(hash string) (*TxStatus, bool) {
+	st, ok := t.statuses.Get(hash)
+	if !ok {
+		return nil, false
+	}
+	return st.(*TxStatus), true
+}
+
+// update records the status of the transaction and notifies the subscribers to it, if the status
+// changed.
+func (t *txTracker) update(hash string, st *TxStatus) {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+
+	prev, ok := t.status(hash)
+	t.statuses.Add(hash, st)
+	if ok && prev.Status == st.Status {
+		return
+	}
+	for _, sub := range t.subs[hash] {
+		// the status changes at most twice after subscribing, see subscribe
+		select {
+		case sub <- st:
+		default:
+		}
+	}
+}
+
+// subscribe returns a channel receiving the changes of the transaction's status.
+func (t *txTracker) subscribe(hash string) (chan *TxStatus, error) {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+
+	if t.amount >= maxTxSubscriptions {
+		return nil, ErrTooManyTxSubscriptions
+	}
+	// a transaction goes at most from unknown to pending and then to committed or failed
+	sub := make(chan *TxStatus, 2)
+	t.subs[hash] = append(t.subs[hash], sub)
+	t.amount++
+	return sub, nil
+}
+
+func (t *txTracker) unsubscribe(hash string, sub chan *TxStatus) {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+
+	subs := t.subs[hash]
+	for i := range subs {
+		if subs[i] == sub {
+			subs = append(subs[:i], subs[i+1:]...)
+			t.amount--
+			break
+		}
+	}
+	if len(subs) == 0 {
+		delete(t.subs, hash)
+		return
+	}
+	t.subs[hash] = subs
+}
+
+// subscribed returns the hashes of the transactions with subscribers.
+func (t *txTracker) subscribed() []string {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+
+	hashes := make([]string, 0, len(t.subs))
+	for hash := range t.subs {
+		hashes = append(hashes, hash)
+	}
+	return hashes
+}
+
+// broadcastTx broadcasts the transaction in the given mode and tracks its status. A transaction
+// whose broadcast errors is recorded as failed. If it reached the mempool regardless, e.g. when
+// waiting for its inclusion timed out, it is reported as committed once found in a block.
+func (ca *CoreAccessor) broadcastTx(ctx context.Context, tx Tx, mode sdktx.BroadcastMode) (*TxResponse, error) {
+	hash := fmt.Sprintf("%X", coretypes.Tx(tx).Hash())
+	ca.txs.update(hash, &TxStatus{Status: TxPending})
+
+	txResp, err := apptypes.BroadcastTx(ctx, ca.coreConn, mode, tx)
+	if err != nil {
+		ca.txs.update(hash, &TxStatus{Status: TxFailed, Error: err.Error()})
+		return nil, err
+	}
+	response := txResp.TxResponse
+	switch {
+	case response.Code != 0:
+		ca.txs.update(hash, &TxStatus{Status: TxFailed, Response: response})
+	case mode == sdktx.BroadcastMode_BROADCAST_MODE_BLOCK:
+		ca.txs.update(hash, &TxStatus{Status: TxCommitted, Response: response})
+	}
+	return response, nil
+}
+
+// TxStatus returns the status of the transaction with the given hash. Transactions broadcasted
+// through the node are reported as pending until included in a block.
+func (ca *CoreAccessor) TxStatus(ctx context.Context, hash string) (*TxStatus, error) {
+	hash = strings.ToUpper(hash)
+	txResp, err := sdktx.NewServiceClient(ca.coreConn).GetTx(ctx, &sdktx.GetTxRequest{Hash: hash})
+	if status.Code(err) == codes.NotFound {
+		if st, ok := ca.txs.status(hash); ok {
+			return st, nil
+		}
+		return &TxStatus{Status: TxUnknown}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	st := &TxStatus{Status: TxCommitted, Response: txResp.TxResponse}
+	if txResp.TxResponse.Code != 0 {
+		st.Status = TxFailed
+	}
+	ca.txs.update(hash, st)
+	return st, nil
+}
+
+// SubscribeTxStatus streams the status of the transaction with the given hash, starting with its
+// current status and followed by every change of it. The stream ends once the transaction is
+// committed or failed, the context is done or txSubscriptionTimeout passes. At most
+// maxTxSubscriptions can be active at once.
+func (ca *CoreAccessor) SubscribeTxStatus(ctx context.Context, hash string) (<-chan *TxStatus, error) {
+	hash = strings.ToUpper(hash)
+	updates, err := ca.txs.subscribe(hash)
+	if err != nil {
+		return nil, err
+	}
+	st, err := ca.TxStatus(ctx, hash)
+	if err != nil {
+		ca.txs.unsubscribe(hash, updates)
+		return nil, err
+	}
+
+	out := make(chan *TxStatus)
+	go func() {
+		ctx, cancel := context.WithTimeout(ctx, txSubscriptionTimeout)
+		defer cancel()
+		defer close(out)
+		defer ca.txs.unsubscribe(hash, updates)
+		for {
+			select {
+			case out <- st:
+			case <-ctx.Done():
+				return
+			case <-ca.ctx.Done():
+				return
+			}
+			if st.Status == TxCommitted || st.Status == TxFailed {
+				return
+			}
+
+			sent := st.Status
+			for st.Status == sent {
+				select {
+				case st = <-updates:
+				case <-ctx.Done():
+					return
+				case <-ca.ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+// pollTxs polls core for the status of the transactions subscribed to, until the context is done.
+func (ca *CoreAccessor) pollTxs(ctx context.Context) {
+	ticker := time.NewTicker(txPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		for _, hash := range ca.txs.subscribed() {
+			if _, err := ca.TxStatus(ctx, hash); err != nil && ctx.Err() == nil {
+				log.Debugw("polling transaction status", "hash", hash, "err", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxTracker(t *testing.T) {
+	tracker, err := newTxTracker()
+	require.NoError(t, err)
+
+	_, ok := tracker.status("HASH")
+	assert.False(t, ok)
+
+	sub, err := tracker.subscribe("HASH")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"HASH"}, tracker.subscribed())
+
+	tracker.update("HASH", &TxStatus{Status: TxPending})
+	// unchanged statuses are not notified again
+	tracker.update("HASH", &TxStatus{Status: TxPending})
+	tracker.update("OTHER", &TxStatus{Status: TxPending})
+	tracker.update("HASH", &TxStatus{Status: TxCommitted, Response: &TxResponse{Height: 10}})
+
+	st := <-sub
+	assert.Equal(t, TxPending, st.Status)
+	st = <-sub
+	assert.Equal(t, TxCommitted, st.Status)
+	assert.EqualValues(t, 10, st.Response.Height)
+	assert.Empty(t, sub)
+
+	st, ok = tracker.status("HASH")
+	require.True(t, ok)
+	assert.Equal(t, TxCommitted, st.Status)
+
+	tracker.unsubscribe("HASH", sub)
+	assert.Empty(t, tracker.subscribed())
+
+	// the amount of subscriptions is bounded
+	subs := make([]chan *TxStatus, maxTxSubscriptions)
+	for i := range subs {
+		subs[i], err = tracker.subscribe("HASH")
+		require.NoError(t, err)
+	}
+	_, err = tracker.subscribe("OTHER")
+	assert.ErrorIs(t, err, ErrTooManyTxSubscriptions)
+	tracker.unsubscribe("HASH", subs[0])
+	_, err = tracker.subscribe("OTHER")
+	assert.NoError(t, err)
+}